@@ -0,0 +1,54 @@
+// sink.go
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// HTTPSink forwards analytics events to an external collector instead of the database.
+// It implements repository.AnalyticsRepository.
+//
+// Each batch is sent as `POST <url>` with a `{"events": [...]}` JSON body; any 2xx status is a success.
+type HTTPSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewHTTPSink creates an HTTPSink posting to the given URL.
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{url: url, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// InsertMany sends a batch of events to the collector.
+func (s *HTTPSink) InsertMany(ctx context.Context, events []models.AnalyticsEvent) error {
+	body, err := json.Marshal(models.AnalyticsBatch{Events: events})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("analytics collector responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
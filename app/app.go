@@ -0,0 +1,654 @@
+// app.go
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"los-complejos-backend/analytics"
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/config"
+	"los-complejos-backend/database"
+	"los-complejos-backend/federation"
+	"los-complejos-backend/imaging"
+	"los-complejos-backend/jobs"
+	"los-complejos-backend/mailer"
+	"los-complejos-backend/middleware"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/outbox"
+	"los-complejos-backend/profilecache"
+	"los-complejos-backend/push"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/repository/mongodb"
+	"los-complejos-backend/repository/postgres"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/scheduler"
+	"los-complejos-backend/seed"
+	"los-complejos-backend/services"
+	"los-complejos-backend/sharecard"
+	"los-complejos-backend/utils"
+	"los-complejos-backend/validation"
+	"los-complejos-backend/weather"
+	"los-complejos-backend/webhook"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// App holds every long-lived component of the backend, wired together.
+type App struct {
+	Config *config.Config
+	Logger *slog.Logger
+	Clock  clock.Clock
+
+	Mongo    *mongo.Client
+	DB       *mongo.Database
+	Postgres *sql.DB // nil unless the postgres storage backend is selected
+
+	Moderation    *services.ModerationService
+	Complejos     *services.ComplejoService
+	Events        *services.EventService
+	Settings      *services.SettingsService
+	Federation    *services.FederationService
+	Journal       *services.JournalService
+	Analytics     *services.AnalyticsService
+	Reports       *services.ReportService
+	Finance       *services.FinanceService
+	Inventory     *services.InventoryService
+	LostFound     *services.LostFoundService
+	Volunteers    *services.VolunteerService
+	Weather       *services.WeatherService
+	Photos        *services.PhotoService
+	Media         *services.MediaService
+	Share         *services.ShareService
+	Push          *services.PushService
+	Announcements *services.AnnouncementService
+	Terms         *services.TermsService
+	Offboarding   *services.OffboardingService
+	Import        *services.ImportService
+	Sandbox       *services.SandboxService
+	Webhooks      *services.WebhookService
+	DataExports   *services.DataExportService
+	Erasure       *services.ErasureService
+	Audit         *services.AuditService
+	Leaderboard   *services.LeaderboardService
+	Lifts         *services.LiftService
+	Workouts      *services.WorkoutService
+	Exercises     *services.ExerciseService
+	Nutrition     *services.NutritionService
+	Challenges    *services.ChallengeService
+	Groups        *services.GroupService
+	Follows       *services.FollowService
+	Feed          *services.FeedService
+	Privacy       *services.PrivacyService
+	Blocks        *services.BlockService
+
+	ServiceAccounts *services.ServiceAccountService // Accounts of the integrations, authenticated by rotating tokens
+
+	Notifications *services.NotificationService // nil unless an SMTP server is configured
+
+	Bus        *bus.Bus // Domain events, published by the outbox dispatcher after their change is committed
+	Outbox     *outbox.Dispatcher
+	Jobs       *jobs.Queue          // Work done in the background instead of during the requests
+	Scheduler  *scheduler.Scheduler // Recurring tasks (reminders, purges, scorings)
+	Purger     *services.Purger
+	Churn      *services.ChurnScorer
+	Images     *imaging.Pool
+	Thumbnails *imaging.Pool // Scales stored photos down for listings
+	Objects    objectstore.Store
+	Profiles   *profilecache.Cache // Caches the profiles of the Complejos read by the requests
+	// Profile photos: the S3 bucket, or else a GridFS bucket of their own with MongoDB and the directory with PostgreSQL
+	ProfilePhotos objectstore.Store
+
+	Router *gin.Engine
+
+	shutdown chan struct{} // Closed when the server shuts down, to end the long-lived streams
+}
+
+// Option customizes how an App is built (e.g. to inject a fake clock in integration tests).
+type Option func(*App)
+
+// WithClock replaces the system clock used by the App.
+func WithClock(clk clock.Clock) Option {
+	return func(a *App) {
+		a.Clock = clk
+	}
+}
+
+// WithLogger replaces the default logger used by the App.
+func WithLogger(logger *slog.Logger) Option {
+	return func(a *App) {
+		a.Logger = logger
+	}
+}
+
+// New builds the App: it connects to the configured storage backend, creates the repositories and services
+// and registers every route on the router.
+func New(ctx context.Context, cfg *config.Config, opts ...Option) (*App, error) {
+	a := &App{
+		Config:   cfg,
+		Logger:   slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		Clock:    clock.New(),
+		shutdown: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	utils.JWTSecret = []byte(cfg.JWTSecret)
+	utils.TokenLifetime = cfg.JWTLifetime
+	utils.IMCCategories = cfg.IMCCategories
+	validation.SetClock(a.Clock)
+	validation.SetStrict(cfg.JSONStrict)
+	responses.SetJSONOptions(responses.JSONOptions{Naming: cfg.JSONNaming, OmitNull: cfg.JSONOmitNull})
+
+	repos, err := a.openStorage(ctx)
+	if err != nil {
+		a.Close(ctx)
+		return nil, err
+	}
+
+	a.Profiles = profilecache.New(cfg.ProfileCacheTTL, cfg.ProfileCacheSize, a.Clock)
+	repos.complejos = profilecache.NewRepository(repos.complejos, a.Profiles)
+	repos.outbox = outbox.NewShadowRepository(repos.outbox)
+
+	a.Images = imaging.NewPool(cfg.ImageWorkers, cfg.ImageQueue, imaging.DefaultOptions)
+	a.Thumbnails = imaging.NewPool(cfg.ImageWorkers, cfg.ImageQueue, imaging.ThumbnailOptions)
+	switch cfg.ObjectStore {
+	case config.ObjectStoreS3:
+		a.Objects = objectstore.NewS3(cfg.S3)
+		a.ProfilePhotos = a.Objects
+	case config.ObjectStoreGridFS:
+		a.Objects = objectstore.NewGridFS(a.DB, "objects")
+		a.ProfilePhotos = objectstore.NewGridFS(a.DB, "profile_photos")
+	default:
+		a.Objects = objectstore.NewDir(cfg.ObjectStoreDir)
+		a.ProfilePhotos = a.Objects
+		if a.DB != nil {
+			a.ProfilePhotos = objectstore.NewGridFS(a.DB, "profile_photos")
+		}
+	}
+	a.Jobs = jobs.NewQueue(repos.jobs, a.Clock, a.Logger)
+	a.Jobs.Workers = cfg.JobWorkers
+
+	// Services
+	a.Moderation = services.NewModerationService(repos.moderation, repos.reported, repos.complejos, repos.lostFound, repos.tx, repos.outbox, a.Clock)
+	a.Moderation.Filter = cfg.ContentFilter
+	a.Complejos = services.NewComplejoService(repos.complejos, repos.events, repos.subscriptions, repos.invitations, repos.tx, repos.outbox, a.Moderation, a.Jobs, a.Images, a.Thumbnails, a.ProfilePhotos, a.Clock)
+	a.Settings = services.NewSettingsService(repos.settings, a.Clock)
+	a.Events = services.NewEventService(repos.events, repos.complejos, repos.subscriptions, repos.tx, repos.outbox, a.Settings, a.Thumbnails, a.Objects, a.ProfilePhotos, a.Clock)
+	a.Events.GuestPasses = cfg.GuestPasses
+	a.Events.Location = cfg.Location
+	a.Events.Markdown = cfg.Markdown
+	a.Events.PinDuration = cfg.EventPinDuration
+	a.Events.Watcher = repos.watcher
+	a.Moderation.Events = a.Events
+	a.Moderation.Accounts = a.Complejos
+
+	var federationClient *federation.Client
+	if cfg.FederationURL != "" {
+		federationClient = federation.NewClient(cfg.FederationURL, cfg.FederationClub, cfg.FederationSecret)
+	}
+	a.Federation = services.NewFederationService(federationClient, cfg.FederationClub, repos.events, repos.nearby, repos.tx, a.Clock)
+	a.Journal = services.NewJournalService(repos.journal, a.Clock)
+	a.Reports = services.NewReportService(repos.reports, repos.records, a.Clock)
+	a.Reports.Location = cfg.Location
+	a.Finance = services.NewFinanceService(repos.payments, repos.expenses, repos.events, a.Clock)
+	a.Finance.WebhookSecret = cfg.StripeWebhookSecret
+	a.Finance.Location = cfg.Location
+	a.Inventory = services.NewInventoryService(repos.inventory, repos.complejos, repos.events, repos.tx, repos.outbox, a.Clock)
+	a.LostFound = services.NewLostFoundService(repos.lostFound, repos.complejos, repos.tx, repos.outbox, a.Moderation, a.Images, a.Clock)
+	a.Volunteers = services.NewVolunteerService(repos.volunteers, repos.events, repos.complejos, repos.tx, repos.outbox, a.Clock)
+	a.Share = services.NewShareService(repos.events, a.Images, a.Objects, sharecard.NewFetcher(5*time.Second, int64(imaging.DefaultOptions.MaxBytes)), a.Clock, a.Logger)
+	a.Share.Location = cfg.Location
+	a.Share.SiteURL = cfg.PublicSiteURL
+	a.Share.APIURL = cfg.PublicAPIURL
+	a.Photos = services.NewPhotoService(repos.photos, repos.events, repos.complejos, repos.tx, repos.outbox, a.Jobs, a.Images, a.Thumbnails, a.Objects, a.Clock)
+	a.Media = services.NewMediaService(repos.photos, repos.events, a.Objects, a.ProfilePhotos)
+
+	var forecasts weather.Provider
+	if cfg.WeatherProviderURL != "" {
+		forecasts = weather.NewHTTPProvider(cfg.WeatherProviderURL, cfg.WeatherAPIKey)
+	}
+	a.Weather = services.NewWeatherService(repos.events, forecasts, repos.tx, repos.outbox, a.Clock, a.Logger)
+	a.Weather.TTL = cfg.WeatherCacheTTL
+
+	if cfg.SMTPHost != "" {
+		smtpMailer, err := mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+		if err != nil {
+			a.Close(ctx)
+			return nil, err
+		}
+		a.Notifications = services.NewNotificationService(repos.events, repos.complejos, smtpMailer, cfg.MailTemplates, a.Clock, a.Logger)
+		a.Notifications.Location = cfg.Location
+	}
+
+	senders := map[string]push.Sender{}
+	if cfg.FCMProjectID != "" {
+		fcm, err := push.NewFCMSender(cfg.FCMProjectID, cfg.FCMCredentials)
+		if err != nil {
+			a.Close(ctx)
+			return nil, err
+		}
+		senders[models.PlatformFCM] = fcm
+	}
+	if cfg.VAPIDPrivateKey != "" {
+		webPush, err := push.NewWebPushSender(cfg.VAPIDPrivateKey, cfg.VAPIDSubject)
+		if err != nil {
+			a.Close(ctx)
+			return nil, err
+		}
+		senders[models.PlatformWebPush] = webPush
+	}
+	a.Push = services.NewPushService(repos.devices, repos.events, repos.tx, repos.outbox, senders, a.Clock, a.Logger)
+	a.Push.Location = cfg.Location
+	a.Push.RemindBefore = cfg.EventReminderBefore
+	a.Announcements = services.NewAnnouncementService(repos.announcements, repos.versions, repos.tx, repos.outbox, a.Clock)
+	a.Terms = services.NewTermsService(repos.versions, a.Clock)
+	a.Offboarding = services.NewOffboardingService(repos.complejos, repos.events, repos.offboarding, a.Objects, a.Clock, a.Logger)
+	a.Offboarding.Club = cfg.FederationClub
+	a.Offboarding.Delay = cfg.OffboardingDelay
+	a.Offboarding.OnPurge = a.Profiles.Clear
+	a.Import = services.NewImportService(a.Complejos, a.Events)
+	a.Sandbox = services.NewSandboxService(repos.offboarding, a.Objects, func(ctx context.Context) (models.SeedSummary, error) {
+		return seed.Run(ctx, a.Complejos, a.Events, a.Clock)
+	}, a.Clock, a.Logger)
+	a.Sandbox.Enabled = cfg.SandboxEnabled
+	a.Sandbox.OnPurge = a.Profiles.Clear
+	if cfg.SandboxEnabled {
+		a.Logger.Warn("sandbox mode is enabled: admins may reset the data to the seed data")
+	}
+	a.Webhooks = services.NewWebhookService(repos.webhooks, webhook.NewClient(cfg.WebhookTimeout), a.Clock, a.Logger)
+	a.Webhooks.MaxAttempts = cfg.WebhookMaxAttempts
+	a.Webhooks.Lease = cfg.WebhookTimeout + time.Minute
+	a.ServiceAccounts = services.NewServiceAccountService(repos.accounts, repos.tx, a.Clock)
+	a.DataExports = services.NewDataExportService(repos.exports, repos.complejos, repos.events, repos.subscriptions, repos.devices, repos.inventory, repos.lifts, repos.workouts, repos.nutrition, repos.tx, a.Jobs, a.Objects, a.ProfilePhotos, a.Clock)
+	a.Erasure = services.NewErasureService(repos.complejos, repos.events, repos.subscriptions, repos.erasure, repos.audit, repos.exports, repos.tx, repos.outbox, a.Objects, a.ProfilePhotos, a.Clock)
+	a.Audit = services.NewAuditService(repos.audit)
+	a.Leaderboard = services.NewLeaderboardService(repos.leaderboard)
+	a.Lifts = services.NewLiftService(repos.complejos, repos.lifts, repos.tx, repos.outbox, a.Clock)
+	a.Lifts.Location = cfg.Location
+	a.Exercises = services.NewExerciseService(repos.exercises, a.Clock)
+	a.Workouts = services.NewWorkoutService(repos.workouts, repos.exercises, repos.complejos, a.Clock)
+	a.Workouts.Location = cfg.Location
+	a.Nutrition = services.NewNutritionService(repos.nutrition, repos.complejos, a.Clock)
+	a.Nutrition.Location = cfg.Location
+	a.Challenges = services.NewChallengeService(repos.challenges, repos.complejos, repos.events, repos.workouts, repos.nutrition, repos.tx, repos.outbox, a.Clock)
+	a.Challenges.Location = cfg.Location
+	a.Groups = services.NewGroupService(repos.groups, repos.complejos, repos.tx, a.Events, a.Leaderboard, a.Clock)
+	a.Events.Groups = repos.groups
+	a.Follows = services.NewFollowService(repos.follows, repos.blocks, repos.complejos, a.Clock)
+	a.Blocks = services.NewBlockService(repos.blocks, repos.follows, repos.complejos, repos.tx, a.Clock)
+	a.Feed = services.NewFeedService(repos.feed, repos.follows, repos.groups, repos.events, a.Clock)
+	a.Privacy = services.NewPrivacyService(repos.privacy, repos.follows, repos.blocks, repos.complejos, a.Clock)
+	a.Events.Privacy = a.Privacy
+
+	var analyticsSink repository.AnalyticsRepository = repos.analytics
+	if cfg.AnalyticsSinkURL != "" {
+		analyticsSink = analytics.NewHTTPSink(cfg.AnalyticsSinkURL)
+	}
+	a.Analytics = services.NewAnalyticsService(analyticsSink, a.Clock, a.Logger)
+	a.Analytics.SampleRate = cfg.AnalyticsSampleRate
+	a.Analytics.FlushInterval = cfg.AnalyticsFlushInterval
+	for _, tenant := range cfg.AnalyticsDisabledTenants {
+		a.Analytics.DisabledTenants[tenant] = true
+	}
+
+	// Background workers
+	a.Bus = bus.New(a.Logger)
+	a.registerSubscribers()
+	a.registerJobs()
+	a.Outbox = outbox.NewDispatcher(repos.outbox, a.Clock, a.Logger)
+	a.registerOutboxHandlers()
+	a.Purger = services.NewPurger(repos.complejos, repos.events, a.Clock)
+	a.Purger.Retention = cfg.SoftDeleteRetention
+	a.Churn = services.NewChurnScorer(repos.complejos, repos.reports, a.Clock)
+	a.Scheduler = scheduler.New(a.Clock, a.Logger)
+	a.registerTasks(cfg)
+	if err := a.Scheduler.Disable(cfg.SchedulerDisabledTasks...); err != nil {
+		a.Close(ctx)
+		return nil, fmt.Errorf("invalid SCHEDULER_DISABLED_TASKS: %w", err)
+	}
+
+	a.Router = gin.Default()
+	// Handlers pass their gin.Context to the services: with the fallback, it carries the deadline and the
+	// cancellation of the request down to the database calls
+	a.Router.ContextWithFallback = true
+	a.Router.Use(middleware.RequestIDMiddleware(), middleware.JournalMiddleware(a.Journal, a.Logger), middleware.ErrorMiddleware(a.Logger))
+	a.registerRoutes()
+
+	return a, nil
+}
+
+// repositories groups the storage implementations selected by the configuration.
+type repositories struct {
+	complejos     repository.ComplejoRepository
+	events        repository.EventRepository
+	subscriptions repository.SubscriptionEventRepository
+	outbox        repository.OutboxRepository
+	nearby        repository.FederatedEventRepository
+	journal       repository.JournalRepository
+	analytics     repository.AnalyticsRepository
+	reports       repository.ReportRepository
+	payments      repository.PaymentRepository
+	expenses      repository.ExpenseRepository
+	inventory     repository.InventoryRepository
+	lostFound     repository.LostFoundRepository
+	volunteers    repository.VolunteerRepository
+	photos        repository.PhotoRepository
+	invitations   repository.InvitationRepository
+	moderation    repository.ModerationRepository
+	reported      repository.ContentReportRepository
+	devices       repository.DeviceRepository
+	announcements repository.AnnouncementRepository
+	versions      repository.ContentVersionRepository
+	offboarding   repository.OffboardingRepository
+	settings      repository.SettingsRepository
+	webhooks      repository.WebhookRepository
+	accounts      repository.ServiceAccountRepository
+	exports       repository.DataExportRepository
+	erasure       repository.ErasureRepository
+	audit         repository.AuditRepository
+	leaderboard   repository.LeaderboardRepository
+	lifts         repository.LiftRepository
+	workouts      repository.WorkoutRepository
+	exercises     repository.ExerciseRepository
+	nutrition     repository.NutritionRepository
+	challenges    repository.ChallengeRepository
+	groups        repository.GroupRepository
+	follows       repository.FollowRepository
+	feed          repository.FeedRepository
+	privacy       repository.PrivacyRepository
+	blocks        repository.BlockRepository
+	jobs          repository.JobRepository
+	records       repository.PersonalRecordRepository
+	watcher       repository.EventWatcher // nil when the deployment cannot stream changes
+	tx            repository.Transactor
+}
+
+// openStorage connects to the configured storage backend and returns its repositories.
+func (a *App) openStorage(ctx context.Context) (*repositories, error) {
+	switch a.Config.StorageBackend {
+	case config.BackendPostgres:
+		db, err := postgres.Open(ctx, a.Config.PostgresDSN)
+		if err != nil {
+			return nil, err
+		}
+		a.Postgres = db
+		a.Logger.Info("connected to PostgreSQL")
+
+		applied, err := postgres.Migrate(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range applied {
+			a.Logger.Info("applied PostgreSQL migration", "version", version)
+		}
+
+		return &repositories{
+			complejos:     postgres.NewComplejoRepository(db),
+			events:        postgres.NewEventRepository(db),
+			subscriptions: postgres.NewSubscriptionEventRepository(db),
+			outbox:        postgres.NewOutboxRepository(db),
+			nearby:        postgres.NewFederatedEventRepository(db),
+			journal:       postgres.NewJournalRepository(db),
+			analytics:     postgres.NewAnalyticsRepository(db),
+			reports:       postgres.NewReportRepository(db),
+			payments:      postgres.NewPaymentRepository(db),
+			expenses:      postgres.NewExpenseRepository(db),
+			inventory:     postgres.NewInventoryRepository(db),
+			lostFound:     postgres.NewLostFoundRepository(db),
+			volunteers:    postgres.NewVolunteerRepository(db),
+			photos:        postgres.NewPhotoRepository(db),
+			invitations:   postgres.NewInvitationRepository(db),
+			moderation:    postgres.NewModerationRepository(db),
+			reported:      postgres.NewContentReportRepository(db),
+			devices:       postgres.NewDeviceRepository(db),
+			announcements: postgres.NewAnnouncementRepository(db),
+			versions:      postgres.NewContentVersionRepository(db),
+			offboarding:   postgres.NewOffboardingRepository(db),
+			settings:      postgres.NewSettingsRepository(db),
+			webhooks:      postgres.NewWebhookRepository(db),
+			accounts:      postgres.NewServiceAccountRepository(db),
+			exports:       postgres.NewDataExportRepository(db),
+			erasure:       postgres.NewErasureRepository(db),
+			audit:         postgres.NewAuditRepository(db),
+			leaderboard:   postgres.NewLeaderboardRepository(db),
+			lifts:         postgres.NewLiftRepository(db),
+			workouts:      postgres.NewWorkoutRepository(db),
+			exercises:     postgres.NewExerciseRepository(db),
+			nutrition:     postgres.NewNutritionRepository(db),
+			challenges:    postgres.NewChallengeRepository(db),
+			groups:        postgres.NewGroupRepository(db),
+			follows:       postgres.NewFollowRepository(db),
+			feed:          postgres.NewFeedRepository(db),
+			privacy:       postgres.NewPrivacyRepository(db),
+			blocks:        postgres.NewBlockRepository(db),
+			jobs:          postgres.NewJobRepository(db),
+			records:       postgres.NewPersonalRecordRepository(db),
+			tx:            postgres.NewTransactor(db),
+		}, nil
+
+	default:
+		client, err := database.Connect(ctx, a.Config.MongoURI)
+		if err != nil {
+			return nil, err
+		}
+		a.Mongo = client
+		a.DB = client.Database(a.Config.DatabaseName)
+		a.Logger.Info("connected to MongoDB", "database", a.Config.DatabaseName)
+
+		tx, err := mongodb.NewTransactor(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		if !tx.Supported() {
+			a.Logger.Warn("MongoDB deployment does not support transactions; compound writes are not atomic")
+		}
+
+		var watcher repository.EventWatcher
+		if tx.Supported() {
+			// Change streams need the same replica set or sharded cluster as transactions
+			watcher = mongodb.NewEventWatcher(a.DB.Collection("event"))
+		}
+
+		return &repositories{
+			complejos:     mongodb.NewComplejoRepository(a.DB.Collection("complejo")),
+			events:        mongodb.NewEventRepository(a.DB.Collection("event"), a.DB.Collection("complejo")),
+			subscriptions: mongodb.NewSubscriptionEventRepository(a.DB.Collection("subscription_events")),
+			outbox:        mongodb.NewOutboxRepository(a.DB.Collection("outbox")),
+			nearby:        mongodb.NewFederatedEventRepository(a.DB.Collection("nearby_events")),
+			journal:       mongodb.NewJournalRepository(a.DB.Collection("request_journal")),
+			analytics:     mongodb.NewAnalyticsRepository(a.DB.Collection("analytics_events")),
+			reports:       mongodb.NewReportRepository(a.DB.Collection("complejo"), a.DB.Collection("event"), a.DB.Collection("subscription_events")),
+			payments:      mongodb.NewPaymentRepository(a.DB.Collection("payments")),
+			expenses:      mongodb.NewExpenseRepository(a.DB.Collection("expenses")),
+			inventory:     mongodb.NewInventoryRepository(a.DB.Collection("inventory"), a.DB.Collection("loans")),
+			lostFound:     mongodb.NewLostFoundRepository(a.DB.Collection("lost_found"), a.DB.Collection("lost_found_claims")),
+			volunteers:    mongodb.NewVolunteerRepository(a.DB.Collection("volunteer_shifts"), a.DB.Collection("event"), a.DB.Collection("complejo")),
+			photos:        mongodb.NewPhotoRepository(a.DB.Collection("event_photos")),
+			invitations:   mongodb.NewInvitationRepository(a.DB.Collection("guest_invitations")),
+			moderation:    mongodb.NewModerationRepository(a.DB.Collection("content_holds")),
+			reported:      mongodb.NewContentReportRepository(a.DB.Collection("content_reports")),
+			devices:       mongodb.NewDeviceRepository(a.DB.Collection("devices")),
+			announcements: mongodb.NewAnnouncementRepository(a.DB.Collection("announcements")),
+			versions:      mongodb.NewContentVersionRepository(a.DB.Collection("content_versions")),
+			offboarding:   mongodb.NewOffboardingRepository(a.DB),
+			settings:      mongodb.NewSettingsRepository(a.DB.Collection("settings")),
+			webhooks:      mongodb.NewWebhookRepository(a.DB.Collection("webhooks"), a.DB.Collection("webhook_deliveries")),
+			accounts:      mongodb.NewServiceAccountRepository(a.DB.Collection("service_accounts"), a.DB.Collection("service_tokens")),
+			exports:       mongodb.NewDataExportRepository(a.DB.Collection("data_exports")),
+			erasure:       mongodb.NewErasureRepository(a.DB),
+			audit:         mongodb.NewAuditRepository(a.DB.Collection("audit_log")),
+			leaderboard:   mongodb.NewLeaderboardRepository(a.DB.Collection("complejo")),
+			lifts:         mongodb.NewLiftRepository(a.DB.Collection("lift_entries")),
+			workouts:      mongodb.NewWorkoutRepository(a.DB.Collection("workouts")),
+			exercises:     mongodb.NewExerciseRepository(a.DB.Collection("exercises")),
+			nutrition:     mongodb.NewNutritionRepository(a.DB.Collection("nutrition_logs")),
+			challenges:    mongodb.NewChallengeRepository(a.DB.Collection("challenges"), a.DB.Collection("challenge_participants"), a.DB.Collection("complejo")),
+			groups:        mongodb.NewGroupRepository(a.DB.Collection("groups"), a.DB.Collection("group_memberships"), a.DB.Collection("complejo")),
+			follows:       mongodb.NewFollowRepository(a.DB.Collection("follows"), a.DB.Collection("complejo")),
+			feed:          mongodb.NewFeedRepository(a.DB.Collection("feed_items")),
+			privacy:       mongodb.NewPrivacyRepository(a.DB.Collection("privacy_settings")),
+			blocks:        mongodb.NewBlockRepository(a.DB.Collection("blocks"), a.DB.Collection("complejo")),
+			jobs:          mongodb.NewJobRepository(a.DB.Collection("jobs")),
+			records:       mongodb.NewPersonalRecordRepository(a.DB.Collection("personal_records")),
+			watcher:       watcher,
+			tx:            tx,
+		}, nil
+	}
+}
+
+// registerOutboxHandlers publishes every outbox message on the domain event bus once its change is committed.
+// A message is retried when any subscriber fails.
+func (a *App) registerOutboxHandlers() {
+	publish := func(ctx context.Context, message models.OutboxMessage) error {
+		event, err := bus.Decode(message.Topic, message.Payload)
+		if err != nil {
+			return err
+		}
+		return a.Bus.Publish(ctx, event)
+	}
+
+	for _, topic := range bus.Topics() {
+		a.Outbox.Register(topic, publish)
+	}
+}
+
+// registerSubscribers subscribes the consumers of domain events to the bus.
+// Every event is delivered to the log; updated and deleted events are also emailed to their participants
+// when an SMTP server is configured, event reminders and announcements are pushed to the devices, and every
+// event is delivered to the webhooks of its topic. Emails, pushes and webhooks are handled in jobs, each
+// retried on its own. Lift records are logged for the counters of the public homepage, and the activity feed is
+// kept up to date.
+func (a *App) registerSubscribers() {
+	a.Bus.Subscribe("log", func(ctx context.Context, event bus.Event) error {
+		a.Logger.Info("domain event published", "topic", event.Topic(), "event", event)
+		return nil
+	})
+	if a.Notifications != nil {
+		a.Bus.Subscribe("email", a.Jobs.Defer("email", a.Notifications.Handle), outbox.TopicEventUpdated, outbox.TopicEventDeleted)
+	}
+	a.Bus.Subscribe("push", a.Jobs.Defer("push", a.Push.Handle), outbox.TopicEventReminder, outbox.TopicAnnouncement)
+	a.Bus.Subscribe("webhooks", a.Jobs.Defer("webhooks", a.Webhooks.Handle))
+	a.Bus.Subscribe("personal_records", a.Reports.RecordPersonalRecord, outbox.TopicComplejoPRAchieved)
+	a.Bus.Subscribe("feed", a.Feed.Record, outbox.TopicComplejoPRAchieved, outbox.TopicEventSubscribed,
+		outbox.TopicEventUnsubscribed, outbox.TopicChallengeWon, outbox.TopicEventCreated, outbox.TopicEventDeleted,
+		outbox.TopicComplejoDeleted)
+}
+
+// registerJobs registers the handlers of the background jobs enqueued by the services.
+func (a *App) registerJobs() {
+	a.Jobs.Register(services.JobPhotoThumbnail, a.Photos.MakeThumbnail)
+	a.Jobs.Register(services.JobProfilePhotoSizes, a.Complejos.MakePhotoSizes)
+	a.Jobs.Register(services.JobDataExport, a.DataExports.Generate)
+}
+
+// registerTasks schedules the recurring tasks, each every interval of the configuration.
+func (a *App) registerTasks(cfg *config.Config) {
+	a.Scheduler.Add("event_reminders", cfg.EventReminderInterval, counted(a.Push.RemindUpcoming))
+	a.Scheduler.Add("volunteer_reminders", cfg.VolunteerReminderInterval, counted(a.Volunteers.RemindUpcoming))
+	a.Scheduler.Add("loan_reminders", cfg.LoanReminderInterval, counted(a.Inventory.RemindOverdue))
+	a.Scheduler.Add("weather_warnings", cfg.WeatherWarningInterval, counted(a.Weather.WarnSevere))
+	a.Scheduler.Add("churn_scoring", cfg.ChurnScoringInterval, counted(a.Churn.Score))
+	a.Scheduler.Add("challenge_scoring", cfg.ChallengeScoringInterval, counted(a.Challenges.Score))
+	a.Scheduler.Add("federation_sync", cfg.FederationSyncInterval, func(ctx context.Context) (int64, error) {
+		return 0, a.Federation.Sync(ctx)
+	})
+	a.Scheduler.Add("soft_delete_purge", time.Hour, a.Purger.Purge)
+	a.Scheduler.Add("lost_found_cleanup", cfg.LostFoundCleanupInterval, a.LostFound.PurgeExpired)
+	a.Scheduler.Add("invitation_cleanup", time.Hour, a.Complejos.PurgeExpiredInvitations)
+	a.Scheduler.Add("service_token_cleanup", time.Hour, a.ServiceAccounts.PurgeExpiredTokens)
+	a.Scheduler.Add("data_export_cleanup", time.Hour, a.DataExports.PurgeExpired)
+	a.Scheduler.Add("public_stats", cfg.PublicStatsInterval, func(ctx context.Context) (int64, error) {
+		return 0, a.Reports.RefreshPublicStats(ctx)
+	})
+}
+
+// counted adapts a task counting its items with an int to a scheduler.Task.
+func counted(run func(ctx context.Context) (int, error)) scheduler.Task {
+	return func(ctx context.Context) (int64, error) {
+		n, err := run(ctx)
+		return int64(n), err
+	}
+}
+
+// Run serves HTTP requests and runs the background workers until the context is cancelled,
+// then shuts the server down gracefully.
+func (a *App) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go a.Outbox.Run(ctx)
+	go a.Jobs.Run(ctx)
+	go a.Scheduler.Run(ctx)
+	go a.Webhooks.Run(ctx)
+	go a.Analytics.Run(ctx)
+
+	server := &http.Server{
+		Addr:    ":" + a.Config.Port,
+		Handler: a.Router,
+	}
+	server.RegisterOnShutdown(func() { close(a.shutdown) })
+
+	errCh := make(chan error, 1)
+	go func() {
+		a.Logger.Info("server listening", "addr", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	a.Logger.Info("shutting down server")
+	return server.Shutdown(shutdownCtx)
+}
+
+// Close releases the resources held by the App.
+func (a *App) Close(ctx context.Context) error {
+	if a.Images != nil {
+		a.Images.Close()
+	}
+	if a.Thumbnails != nil {
+		a.Thumbnails.Close()
+	}
+
+	var errs []error
+	if a.Analytics != nil {
+		if err := a.Analytics.Flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if a.Mongo != nil {
+		if err := a.Mongo.Disconnect(ctx); err != nil {
+			errs = append(errs, err)
+		} else {
+			a.Logger.Info("MongoDB connection closed")
+		}
+	}
+	if a.Postgres != nil {
+		if err := a.Postgres.Close(); err != nil {
+			errs = append(errs, err)
+		} else {
+			a.Logger.Info("PostgreSQL connection closed")
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,382 @@
+// routes.go
+package app
+
+import (
+	"time"
+
+	"los-complejos-backend/changelog"
+	"los-complejos-backend/handlers"
+	"los-complejos-backend/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Message struct for test endpoint response
+type Message struct {
+	Content string `json:"content"`
+}
+
+// registerRoutes mounts every HTTP route on the App's router.
+func (a *App) registerRoutes() {
+	r := a.Router
+	auth := middleware.AuthMiddleware(a.Clock, a.ServiceAccounts)
+	optionalAuth := middleware.OptionalAuthMiddleware(a.Clock, a.ServiceAccounts)
+
+	// Expensive endpoints (exports, analytics, search) share one concurrency limit
+	heavy := middleware.ConcurrencyLimit(a.Config.HeavyConcurrency, a.Config.HeavyQueue, a.Config.HeavyQueueTimeout)
+
+	// Live streams are long-lived, so they are capped apart from the other requests
+	streams := middleware.ConcurrencyLimit(a.Config.EventStreamLimit, 0, time.Second)
+
+	// Bound every request: reads tightly, changes more loosely and exports and reports the most; live streams
+	// are left unbounded
+	r.Use(middleware.Timeout(middleware.TimeoutOptions{
+		Read:  a.Config.ReadTimeout,
+		Write: a.Config.WriteTimeout,
+		Routes: map[string]time.Duration{
+			"GET /event/stream":               0,
+			"GET /admin/export":               a.Config.ExportTimeout,
+			"GET /admin/export/complejos.csv": a.Config.ExportTimeout,
+			"GET /admin/export/events.csv":    a.Config.ExportTimeout,
+			"POST /admin/import":              a.Config.ExportTimeout,
+			"GET /admin/analytics/retention":  a.Config.ExportTimeout,
+			"GET /admin/analytics/heatmap":    a.Config.ExportTimeout,
+			"GET /admin/analytics/churn-risk": a.Config.ExportTimeout,
+			"GET /admin/finance/summary":      a.Config.ExportTimeout,
+			"POST /ingest/events":             a.Config.ExportTimeout,
+			"POST /admin/sandbox/reset":       a.Config.ExportTimeout,
+		},
+	}))
+
+	// Identical subscription requests of a user within this window are collapsed
+	dedup := middleware.Deduplicate(a.Clock, 2*time.Second)
+
+	// Inject faults in test environments
+	if a.Config.ChaosEnabled {
+		a.Logger.Warn("chaos fault injection is enabled",
+			"latency_rate", a.Config.ChaosLatencyRate, "error_rate", a.Config.ChaosErrorRate, "drop_rate", a.Config.ChaosDropRate)
+		r.Use(middleware.ChaosMiddleware(middleware.ChaosOptions{
+			LatencyRate: a.Config.ChaosLatencyRate,
+			Latency:     a.Config.ChaosLatency,
+			ErrorRate:   a.Config.ChaosErrorRate,
+			DropRate:    a.Config.ChaosDropRate,
+		}))
+	}
+
+	// Mirror or log mutating requests while validating a new deployment
+	r.Use(middleware.ShadowTraffic(a.Config.ShadowMode, a.Config.ShadowTarget, a.Logger))
+	r.Use(middleware.ShadowReceiver(a.Config.ShadowReceiver))
+
+	// Read the profiles of the Complejos at most once per request
+	r.Use(middleware.ProfileCacheMiddleware())
+
+	// Resolve the locale and units of every request once
+	r.Use(middleware.PreferencesMiddleware(a.Clock, a.Complejos))
+
+	// Warn the clients of the deprecated routes, pointing them to the changelog
+	r.Use(middleware.Deprecation(changelog.Entries, "/api/changelog"))
+
+	// Test route
+	r.GET("/test", func(c *gin.Context) {
+		c.JSON(200, Message{Content: "Server is running!"})
+	})
+
+	// Changelog routes
+	// Lists the changes of the API for the client apps
+	r.GET("/api/changelog", handlers.GetChangelog())
+
+	// Complejo routes
+	// Handles user management for "Complejo" resources
+	r.POST("/complejo", handlers.CreateComplejo(a.Complejos))
+	r.GET("/complejo/join/:token", handlers.GetInvitation(a.Complejos))
+	r.POST("/complejo/join/:token", handlers.JoinByInvitation(a.Complejos))
+	r.GET("/complejo", optionalAuth, handlers.GetComplejos(a.Complejos, a.Privacy))
+	r.GET("/complejo/me", auth, handlers.GetOwnComplejo(a.Complejos, a.Volunteers, a.Follows))
+	r.GET("/complejo/me/calendar", auth, handlers.GetCalendarLink(a.Events))
+	r.POST("/complejo/me/calendar/rotate", auth, dedup, handlers.RotateCalendarLink(a.Events))
+	r.GET("/complejo/me/export", auth, handlers.RequestDataExport(a.DataExports))
+	r.POST("/complejo/me/erase", auth, dedup, handlers.EraseOwnComplejo(a.Erasure))
+	r.POST("/complejo/me/lifts", auth, dedup, handlers.RecordLift(a.Lifts))
+	r.GET("/complejo/me/progress", auth, handlers.GetOwnProgress(a.Lifts))
+	r.GET("/complejo/me/privacy", auth, handlers.GetOwnPrivacy(a.Privacy))
+	r.PUT("/complejo/me/privacy", auth, handlers.UpdateOwnPrivacy(a.Privacy))
+	r.GET("/complejo/me/blocks", auth, handlers.GetOwnBlocks(a.Blocks))
+	r.GET("/complejo/:id", optionalAuth, handlers.GetComplejo(a.Complejos, a.Volunteers, a.Follows, a.Privacy))
+	r.GET("/complejo/:id/calendar.ics", handlers.GetPersonalCalendar(a.Events))
+	r.GET("/complejo/:id/export.zip", handlers.DownloadDataExport(a.DataExports))
+	r.GET("/complejo/:id/lifts", optionalAuth, handlers.GetLiftHistory(a.Lifts, a.Privacy))
+	r.PUT("/complejo/:id/follow", auth, dedup, handlers.FollowComplejo(a.Follows))
+	r.DELETE("/complejo/:id/follow", auth, dedup, handlers.UnfollowComplejo(a.Follows))
+	r.GET("/complejo/:id/followers", auth, handlers.GetFollowers(a.Follows))
+	r.GET("/complejo/:id/following", auth, handlers.GetFollowing(a.Follows))
+	r.PUT("/complejo/:id/block", auth, dedup, handlers.BlockComplejo(a.Blocks))
+	r.DELETE("/complejo/:id/block", auth, dedup, handlers.UnblockComplejo(a.Blocks))
+	r.PUT("/complejo/admin/:id", auth, handlers.UpdateComplejoForAdmin(a.Complejos))
+	r.PUT("/complejo/user", auth, handlers.UpdateComplejoForUser(a.Complejos))
+	r.POST("/complejo/photo", auth, handlers.UploadComplejoPhoto(a.Complejos))
+	r.DELETE("/complejo/me", auth, handlers.DeleteOwnComplejo(a.Complejos))
+	r.DELETE("/complejo/:id", auth, handlers.DeleteComplejo(a.Complejos))
+	r.PUT("/complejo/:id/restore", auth, handlers.RestoreComplejo(a.Complejos))
+	r.POST("/complejo/:id/erase", auth, dedup, handlers.EraseComplejo(a.Erasure))
+
+	// Leaderboard routes
+	// Ranks the Complejos by their lifts, with the place of the caller when authenticated
+	r.GET("/leaderboard", optionalAuth, heavy, handlers.GetLeaderboard(a.Leaderboard))
+
+	// Workout routes
+	// Each user keeps a private training log, summarized by week and muscle group
+	r.GET("/workouts", auth, handlers.GetWorkouts(a.Workouts))
+	r.POST("/workouts", auth, dedup, handlers.LogWorkout(a.Workouts))
+	r.GET("/workouts/volume", auth, handlers.GetWorkoutVolume(a.Workouts))
+	r.GET("/workouts/:id", auth, handlers.GetWorkout(a.Workouts))
+	r.PUT("/workouts/:id", auth, handlers.UpdateWorkout(a.Workouts))
+	r.DELETE("/workouts/:id", auth, handlers.DeleteWorkout(a.Workouts))
+
+	// Nutrition routes
+	// Each user keeps private daily nutrition logs, summarized by week or month against its targets
+	r.GET("/nutrition", auth, handlers.GetNutritionLogs(a.Nutrition))
+	r.POST("/nutrition", auth, dedup, handlers.LogNutrition(a.Nutrition))
+	r.GET("/nutrition/summary", auth, handlers.GetNutritionSummary(a.Nutrition))
+	r.GET("/nutrition/targets", auth, handlers.GetNutritionTargets(a.Nutrition))
+	r.GET("/nutrition/:id", auth, handlers.GetNutritionLog(a.Nutrition))
+	r.PUT("/nutrition/:id", auth, handlers.UpdateNutritionLog(a.Nutrition))
+	r.DELETE("/nutrition/:id", auth, handlers.DeleteNutritionLog(a.Nutrition))
+
+	// Challenge routes
+	// Members compete in challenges ranked by a metric, scored every day by default
+	r.GET("/challenges", handlers.GetChallenges(a.Challenges))
+	r.POST("/challenges", auth, dedup, handlers.CreateChallenge(a.Challenges))
+	r.GET("/challenges/:id", handlers.GetChallenge(a.Challenges))
+	r.DELETE("/challenges/:id", auth, handlers.DeleteChallenge(a.Challenges))
+	r.PUT("/challenges/:id/join", auth, dedup, handlers.JoinChallenge(a.Challenges))
+	r.DELETE("/challenges/:id/join", auth, dedup, handlers.LeaveChallenge(a.Challenges))
+	r.GET("/challenges/:id/standings", optionalAuth, handlers.GetChallengeStandings(a.Challenges))
+
+	// Group routes
+	// Crews of members with their own events and leaderboard, managed by their owners
+	r.GET("/groups", auth, handlers.GetGroups(a.Groups))
+	r.POST("/groups", auth, dedup, handlers.CreateGroup(a.Groups))
+	r.GET("/groups/:id", auth, handlers.GetGroup(a.Groups))
+	r.PUT("/groups/:id", auth, handlers.UpdateGroup(a.Groups))
+	r.DELETE("/groups/:id", auth, handlers.DeleteGroup(a.Groups))
+	r.GET("/groups/:id/members", auth, handlers.GetGroupMembers(a.Groups))
+	r.PUT("/groups/:id/members/:complejo_id", auth, dedup, handlers.SetGroupMember(a.Groups))
+	r.DELETE("/groups/:id/members/:complejo_id", auth, handlers.RemoveGroupMember(a.Groups))
+	r.GET("/groups/:id/events", auth, handlers.GetGroupEvents(a.Groups))
+	r.POST("/groups/:id/events", auth, dedup, handlers.CreateGroupEvent(a.Groups))
+	r.GET("/groups/:id/leaderboard", auth, heavy, handlers.GetGroupLeaderboard(a.Groups))
+
+	// Feed routes
+	// Activity of the followed users and of the joined groups, written by the feed subscriber of the domain events
+	r.GET("/feed", auth, handlers.GetFeed(a.Feed))
+
+	// Exercise routes
+	// The exercise library the workouts are logged with: public to browse, managed by the admins
+	r.GET("/exercises", handlers.GetExercises(a.Exercises))
+	r.GET("/exercises/:id", handlers.GetExercise(a.Exercises))
+	r.POST("/exercises", auth, handlers.CreateExercise(a.Exercises))
+	r.PUT("/exercises/:id", auth, handlers.UpdateExercise(a.Exercises))
+	r.DELETE("/exercises/:id", auth, handlers.DeleteExercise(a.Exercises))
+
+	// Tool routes
+	// Calculators for the lifters, no token needed
+	r.GET("/tools/1rm", handlers.GetOneRepMax())
+
+	// Photo routes
+	// Serves the profile photos linked by the profiles
+	r.GET("/photos/:id", handlers.GetProfilePhoto(a.Complejos))
+
+	// Media routes
+	// Serves the stored images by their ID, for browsers and CDNs to cache
+	r.GET("/media/:id", optionalAuth, handlers.GetMedia(a.Media))
+	r.HEAD("/media/:id", optionalAuth, handlers.GetMedia(a.Media))
+
+	// Event routes
+	// Handles event management and user subscription/unsubscription
+	r.POST("/event", auth, handlers.CreateEvent(a.Events))
+	r.GET("/event", optionalAuth, handlers.GetEvents(a.Events))
+	r.GET("/event/search", optionalAuth, heavy, handlers.SearchEvents(a.Events))
+	r.GET("/event/nearby", handlers.GetNearbyEvents(a.Federation))
+	r.GET("/event/featured", handlers.GetFeaturedEvents(a.Events))
+	r.GET("/event/ical", handlers.GetEventsCalendar(a.Events))
+	r.GET("/event/stream", streams, handlers.StreamEvents(a.Events, a.shutdown))
+	r.GET("/event/:id", optionalAuth, handlers.GetEvent(a.Events, a.Weather, a.Photos))
+	r.GET("/event/:id/ical", handlers.GetEventCalendar(a.Events))
+	r.GET("/event/:id/og.png", handlers.GetEventShareImage(a.Share))
+	r.PUT("/event/admin/:id", auth, handlers.UpdateEventForAdmin(a.Events))
+	r.PUT("/event/:id", auth, handlers.UpdateEvent(a.Events))
+	r.DELETE("/event/:id", auth, handlers.DeleteEvent(a.Events))
+	r.PUT("/event/:id/restore", auth, handlers.RestoreEvent(a.Events))
+	r.PUT("/event/:id/pin", auth, handlers.PinEvent(a.Events))
+	r.DELETE("/event/:id/pin", auth, handlers.UnpinEvent(a.Events))
+	r.PUT("/event/:id/featured", auth, handlers.FeatureEvent(a.Events))
+	r.DELETE("/event/:id/featured", auth, handlers.UnfeatureEvent(a.Events))
+	r.PUT("/event/:id/subscribe", auth, dedup, handlers.SubscribeEvent(a.Events))
+	r.PUT("/event/:id/unsubscribe", auth, dedup, handlers.UnsuscribeEvent(a.Events))
+	r.PUT("/event/:id/rsvp", auth, dedup, handlers.RSVPEvent(a.Events))
+	r.PUT("/event/:id/level-overrides/:complejo_id", auth, handlers.AllowLevelOverride(a.Events))
+	r.DELETE("/event/:id/level-overrides/:complejo_id", auth, handlers.RevokeLevelOverride(a.Events))
+	r.PUT("/event/:id/route", auth, handlers.UploadEventRoute(a.Events))
+	r.DELETE("/event/:id/route", auth, handlers.DeleteEventRoute(a.Events))
+	r.GET("/event/:id/route.gpx", auth, handlers.DownloadEventRoute(a.Events))
+	r.GET("/event/:id/photos", optionalAuth, handlers.GetEventPhotos(a.Photos))
+	r.POST("/event/:id/photos", auth, handlers.UploadEventPhoto(a.Photos))
+	r.GET("/event/:id/photos/:photo_id", optionalAuth, handlers.DownloadEventPhoto(a.Photos))
+	r.DELETE("/event/:id/photos/:photo_id", auth, handlers.DeleteEventPhoto(a.Photos))
+	r.PUT("/event/:id/photos/:photo_id/approve", auth, handlers.ApproveEventPhoto(a.Photos))
+	r.PUT("/event/:id/photos/:photo_id/consent", auth, handlers.ConsentEventPhoto(a.Photos))
+	r.PUT("/event/:id/like", auth, dedup, handlers.LikeEvent(a.Events))
+	r.DELETE("/event/:id/like", auth, dedup, handlers.UnlikeEvent(a.Events))
+	r.POST("/event/:id/guest", auth, dedup, handlers.RegisterGuest(a.Events))
+	r.DELETE("/event/:id/guest/:guest_id", auth, handlers.RemoveGuest(a.Events))
+	r.POST("/event/:id/guest/:guest_id/invitation", auth, handlers.InviteGuest(a.Complejos))
+	r.GET("/event/:id/participants", auth, handlers.GetEventParticipants(a.Events))
+	r.GET("/event/:id/subscription-history", auth, handlers.GetSubscriptionHistory(a.Events))
+
+	// Public site routes
+	// Describes the event pages of the public site to search engines and its server-side rendering
+	r.GET("/public/sitemap.xml", handlers.GetSitemap(a.Share))
+	r.GET("/public/event/:id/meta", handlers.GetEventMeta(a.Share))
+	r.GET("/public/stats", handlers.GetPublicStats(a.Reports))
+
+	// Ingestion routes
+	// Lets trusted external producers push event definitions
+	r.POST("/ingest/events", middleware.APIKeyMiddleware(a.Config.IngestAPIKey), handlers.IngestEvents(a.Events))
+
+	// Analytics routes
+	// Collects lightweight client events, from anonymous or authenticated callers
+	r.POST("/analytics/track", optionalAuth, handlers.TrackAnalytics(a.Analytics))
+	r.GET("/admin/analytics/retention", auth, heavy, handlers.GetRetentionReport(a.Reports))
+	r.GET("/admin/analytics/heatmap", auth, heavy, handlers.GetAttendanceHeatmap(a.Reports))
+	r.GET("/admin/analytics/churn-risk", auth, heavy, handlers.GetChurnRisk(a.Churn))
+	r.GET("/busy-times", handlers.GetBusyTimes(a.Reports))
+
+	// Finance routes
+	// Records Stripe payments and summarizes the revenue of paid events
+	r.POST("/webhooks/stripe", handlers.StripeWebhook(a.Finance))
+	r.GET("/admin/finance/summary", auth, heavy, handlers.GetFinanceSummary(a.Finance))
+	r.GET("/event/:id/expenses", auth, handlers.GetExpenses(a.Finance))
+	r.POST("/event/:id/expenses", auth, handlers.CreateExpense(a.Finance))
+	r.PUT("/event/:id/expenses/:expense_id", auth, handlers.UpdateExpense(a.Finance))
+	r.DELETE("/event/:id/expenses/:expense_id", auth, handlers.DeleteExpense(a.Finance))
+
+	// Inventory routes
+	// Manages the club equipment and its loans to Complejos
+	r.GET("/inventory", auth, handlers.GetInventory(a.Inventory))
+	r.POST("/inventory", auth, handlers.CreateInventoryItem(a.Inventory))
+	r.PUT("/inventory/:id", auth, handlers.UpdateInventoryItem(a.Inventory))
+	r.DELETE("/inventory/:id", auth, handlers.DeleteInventoryItem(a.Inventory))
+	r.GET("/inventory/loans", auth, handlers.GetLoans(a.Inventory))
+	r.POST("/inventory/loans", auth, handlers.LendInventoryItem(a.Inventory))
+	r.PUT("/inventory/loans/:id/return", auth, handlers.ReturnInventoryItem(a.Inventory))
+	r.GET("/event/:id/equipment", auth, handlers.GetEventEquipment(a.Inventory))
+
+	// Lost-and-found routes
+	// Members post lost and found items and claim them; admins decide the claims
+	r.GET("/lost-found", auth, handlers.GetLostItems(a.LostFound))
+	r.POST("/lost-found", auth, handlers.PostLostItem(a.LostFound))
+	r.DELETE("/lost-found/:id", auth, handlers.DeleteLostItem(a.LostFound))
+	r.GET("/lost-found/:id/claims", auth, handlers.GetClaims(a.LostFound))
+	r.POST("/lost-found/:id/claims", auth, handlers.ClaimLostItem(a.LostFound))
+	r.PUT("/lost-found/:id/claims/:claim_id", auth, handlers.DecideClaim(a.LostFound))
+
+	// Moderation routes
+	// Admins review the content held by the content filter
+	r.GET("/moderation/holds", auth, handlers.GetHolds(a.Moderation))
+	r.PUT("/moderation/holds/:id", auth, handlers.DecideHold(a.Moderation))
+	// Users report abusive events and profiles; admins dismiss the reports or remove the content
+	r.POST("/report", auth, dedup, handlers.ReportContent(a.Moderation))
+	r.GET("/moderation/reports", auth, handlers.GetReports(a.Moderation))
+	r.PUT("/moderation/reports/:id", auth, handlers.DecideReport(a.Moderation))
+
+	// Volunteer routes
+	// Organizers open volunteer shifts for their events; members sign up and earn volunteer hours
+	r.GET("/event/:id/volunteers", auth, handlers.GetVolunteerShifts(a.Volunteers))
+	r.POST("/event/:id/volunteers", auth, handlers.CreateVolunteerShift(a.Volunteers))
+	r.DELETE("/event/:id/volunteers/:shift_id", auth, handlers.DeleteVolunteerShift(a.Volunteers))
+	r.POST("/event/:id/volunteers/:shift_id/signup", auth, dedup, handlers.SignUpVolunteer(a.Volunteers))
+	r.DELETE("/event/:id/volunteers/:shift_id/signup", auth, dedup, handlers.WithdrawVolunteer(a.Volunteers))
+	r.GET("/volunteers/leaderboard", auth, handlers.GetVolunteerLeaderboard(a.Volunteers))
+
+	// Push notification routes
+	// Members register their apps and browsers; admins publish announcements pushed to every device
+	r.GET("/devices/webpush-key", handlers.GetWebPushKey(a.Push))
+	r.GET("/devices", auth, handlers.GetDevices(a.Push))
+	r.POST("/devices", auth, dedup, handlers.RegisterDevice(a.Push))
+	r.DELETE("/devices/:id", auth, handlers.UnregisterDevice(a.Push))
+	r.GET("/announcements", auth, handlers.GetAnnouncements(a.Announcements))
+	r.GET("/announcements/:id", auth, handlers.GetAnnouncement(a.Announcements))
+	r.POST("/announcements", auth, dedup, handlers.PublishAnnouncement(a.Announcements))
+	r.PUT("/announcements/:id", auth, handlers.EditAnnouncement(a.Announcements))
+	r.GET("/announcements/:id/versions", auth, handlers.GetAnnouncementVersions(a.Announcements))
+	r.GET("/announcements/:id/versions/:version", auth, handlers.GetAnnouncementVersion(a.Announcements))
+	r.POST("/announcements/:id/versions/:version/rollback", auth, dedup, handlers.RollbackAnnouncement(a.Announcements))
+
+	// Terms routes
+	// The terms of service and privacy policy are versioned; admins publish new versions and roll back
+	r.GET("/terms/:kind", handlers.GetTerms(a.Terms))
+	r.PUT("/terms/:kind", auth, handlers.PublishTerms(a.Terms))
+	r.GET("/terms/:kind/versions", handlers.GetTermsVersions(a.Terms))
+	r.GET("/terms/:kind/versions/:version", handlers.GetTermsVersion(a.Terms))
+	r.POST("/terms/:kind/versions/:version/rollback", auth, dedup, handlers.RollbackTerms(a.Terms))
+
+	// Export and offboarding routes
+	// Lets admins import members and events in bulk, and a club leaving the platform export its data, then purge it
+	// after a confirmed cooling-off delay
+	r.GET("/export/schema", handlers.GetExportSchema())
+	r.GET("/admin/export", auth, heavy, handlers.ExportData(a.Offboarding))
+	r.GET("/admin/export/complejos.csv", auth, heavy, handlers.ExportComplejosCSV(a.Complejos))
+	r.GET("/admin/export/events.csv", auth, heavy, handlers.ExportEventsCSV(a.Events))
+	r.POST("/admin/import", auth, heavy, dedup, handlers.ImportData(a.Import))
+	r.GET("/admin/offboarding", auth, handlers.GetOffboarding(a.Offboarding))
+	r.POST("/admin/offboarding", auth, dedup, handlers.RequestOffboarding(a.Offboarding))
+	r.DELETE("/admin/offboarding", auth, handlers.CancelOffboarding(a.Offboarding))
+	r.POST("/admin/offboarding/confirm", auth, dedup, handlers.ConfirmOffboarding(a.Offboarding))
+
+	// Settings routes
+	// Lets admins choose the defaults of the event listing
+	r.GET("/admin/settings/listing", auth, handlers.GetListingSettings(a.Settings))
+	r.PUT("/admin/settings/listing", auth, handlers.UpdateListingSettings(a.Settings))
+
+	// Sandbox routes
+	// Lets admins of the sandbox deployment reset its data to the seed data
+	r.POST("/admin/sandbox/reset", auth, dedup, handlers.ResetSandbox(a.Sandbox))
+
+	// Webhook routes
+	// Lets admins register the URLs the domain events are delivered to, and follow their deliveries
+	r.GET("/admin/webhooks/topics", auth, handlers.GetWebhookTopics(a.Webhooks))
+	r.GET("/admin/webhooks", auth, handlers.GetWebhooks(a.Webhooks))
+	r.POST("/admin/webhooks", auth, dedup, handlers.CreateWebhook(a.Webhooks))
+	r.GET("/admin/webhooks/:id", auth, handlers.GetWebhook(a.Webhooks))
+	r.PUT("/admin/webhooks/:id", auth, handlers.UpdateWebhook(a.Webhooks))
+	r.DELETE("/admin/webhooks/:id", auth, handlers.DeleteWebhook(a.Webhooks))
+	r.GET("/admin/webhooks/:id/deliveries", auth, handlers.GetWebhookDeliveries(a.Webhooks))
+
+	// Service account routes
+	// Lets admins manage the accounts of the integrations (kiosk, Discord bot, scheduler), and the integrations
+	// rotate their tokens
+	r.GET("/admin/service-accounts", auth, handlers.GetServiceAccounts(a.ServiceAccounts))
+	r.POST("/admin/service-accounts", auth, dedup, handlers.CreateServiceAccount(a.ServiceAccounts))
+	r.GET("/admin/service-accounts/:id", auth, handlers.GetServiceAccount(a.ServiceAccounts))
+	r.PUT("/admin/service-accounts/:id", auth, handlers.UpdateServiceAccount(a.ServiceAccounts))
+	r.DELETE("/admin/service-accounts/:id", auth, handlers.DeleteServiceAccount(a.ServiceAccounts))
+	r.POST("/admin/service-accounts/:id/rotate", auth, dedup, handlers.RotateServiceAccount(a.ServiceAccounts))
+	r.POST("/service/token", auth, dedup, handlers.RotateServiceToken(a.ServiceAccounts))
+
+	// Cache routes
+	// Lets admins check how often the profiles of the Complejos are served from the cache
+	r.GET("/admin/cache/profiles", auth, handlers.GetProfileCacheStats(a.Profiles))
+
+	// Scheduler routes
+	// Lets admins check the recurring tasks of the instance and how their runs went
+	r.GET("/admin/scheduler", auth, handlers.GetScheduledTasks(a.Scheduler))
+
+	// Audit log routes
+	// Lets admins review the sensitive actions, such as the erasures of Complejos
+	r.GET("/admin/audit", auth, handlers.GetAuditLog(a.Audit))
+
+	// Request journal routes
+	// Lets admins inspect failed requests to replay them
+	r.GET("/journal", auth, handlers.GetJournal(a.Journal))
+	r.GET("/journal/:id", auth, handlers.GetJournalEntry(a.Journal))
+}
@@ -0,0 +1,141 @@
+// apperrors.go
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Machine-readable error codes returned in the `error` field of error responses.
+const (
+	CodeBadRequest   = "bad_request"
+	CodeUnauthorized = "unauthorized"
+	CodeForbidden    = "forbidden"
+	CodeNotFound     = "not_found"
+	CodeConflict     = "conflict"
+	CodeValidation   = "validation_failed"
+	CodeInternal     = "internal_error"
+	CodeUnavailable  = "service_unavailable"
+	CodeTooMany      = "too_many_requests"
+	CodeTimeout      = "timeout"
+	CodeDuplicate    = "duplicate_value"
+)
+
+// Error is a typed API error: it carries the HTTP status, a machine-readable code
+// and a message that is safe to show to clients. The wrapped cause is never exposed.
+type Error struct {
+	Status  int         // HTTP status code
+	Code    string      // Machine-readable error code
+	Message string      // Human-readable message for clients
+	Details interface{} // Optional structured details (e.g. per-field validation errors)
+	Err     error       // Underlying cause, logged but not returned to clients
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithDetails returns a copy of the error carrying the given details.
+func (e *Error) WithDetails(details interface{}) *Error {
+	copied := *e
+	copied.Details = details
+	return &copied
+}
+
+// Wrap returns a copy of the error with the given underlying cause.
+func (e *Error) Wrap(err error) *Error {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+// Is reports whether target is an *Error with the same status and code,
+// so copies made by WithDetails or Wrap still match their sentinel.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Status == e.Status && t.Code == e.Code
+}
+
+// New creates an Error with the given status, code and message.
+func New(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// BadRequest creates a 400 error.
+func BadRequest(message string) *Error {
+	return New(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+// Unauthorized creates a 401 error.
+func Unauthorized(message string) *Error {
+	return New(http.StatusUnauthorized, CodeUnauthorized, message)
+}
+
+// Forbidden creates a 403 error.
+func Forbidden(message string) *Error {
+	return New(http.StatusForbidden, CodeForbidden, message)
+}
+
+// NotFound creates a 404 error.
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+// Conflict creates a 409 error.
+func Conflict(message string) *Error {
+	return New(http.StatusConflict, CodeConflict, message)
+}
+
+// Validation creates a 422 error with per-field details.
+func Validation(message string, details interface{}) *Error {
+	return New(http.StatusUnprocessableEntity, CodeValidation, message).WithDetails(details)
+}
+
+// Internal creates a 500 error wrapping the underlying cause.
+func Internal(message string, err error) *Error {
+	return New(http.StatusInternalServerError, CodeInternal, message).Wrap(err)
+}
+
+// TooManyRequests creates a 429 error for requests rejected to protect a saturated resource.
+func TooManyRequests(message string) *Error {
+	return New(http.StatusTooManyRequests, CodeTooMany, message)
+}
+
+// Unavailable creates a 503 error for requests the server cannot take right now.
+func Unavailable(message string) *Error {
+	return New(http.StatusServiceUnavailable, CodeUnavailable, message)
+}
+
+// GatewayTimeout creates a 504 error for requests that ran out of time.
+func GatewayTimeout(message string) *Error {
+	return New(http.StatusGatewayTimeout, CodeTimeout, message)
+}
+
+// Typed is implemented by the errors of lower layers that know the API error they stand for, like the
+// repository errors naming the field of a broken constraint.
+type Typed interface {
+	APIError() *Error
+}
+
+// From converts any error into an *Error. Errors implementing Typed become their API error, wrapping them;
+// other untyped errors become a generic 500.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	var typed Typed
+	if errors.As(err, &typed) {
+		return typed.APIError().Wrap(err)
+	}
+	return Internal("Internal server error", err)
+}
@@ -0,0 +1,82 @@
+// bus.go
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Event is a domain event: something that happened in the system, identified by its topic.
+type Event interface {
+	Topic() string
+}
+
+// Handler reacts to a published event. A failed event is published again later,
+// so handlers must tolerate seeing the same event more than once.
+type Handler func(ctx context.Context, event Event) error
+
+// subscriber is a named handler, named so failures can be traced back to it.
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+// Bus delivers published domain events to every subscriber of their topic.
+// Subscribers are independent: a failing or panicking one does not prevent the others from running.
+type Bus struct {
+	mu     sync.RWMutex
+	topics map[string][]subscriber
+	all    []subscriber
+	logger *slog.Logger
+}
+
+// New creates a Bus without subscribers.
+func New(logger *slog.Logger) *Bus {
+	return &Bus{topics: map[string][]subscriber{}, logger: logger}
+}
+
+// Subscribe registers the handler under the given name for the given topics,
+// or for every topic when none is given.
+func (b *Bus) Subscribe(name string, handler Handler, topics ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := subscriber{name: name, handler: handler}
+	if len(topics) == 0 {
+		b.all = append(b.all, s)
+		return
+	}
+	for _, topic := range topics {
+		b.topics[topic] = append(b.topics[topic], s)
+	}
+}
+
+// Publish runs every subscriber of the event's topic, in subscription order.
+// It returns the failures of all subscribers joined together, or nil when every one succeeded.
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	subscribers := append(append([]subscriber{}, b.all...), b.topics[event.Topic()]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subscribers {
+		if err := b.deliver(ctx, s, event); err != nil {
+			b.logger.Warn("domain event subscriber failed", "subscriber", s.name, "topic", event.Topic(), "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver runs a single subscriber, turning a panic into an error.
+func (b *Bus) deliver(ctx context.Context, s subscriber, event Event) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return s.handler(ctx, event)
+}
@@ -0,0 +1,81 @@
+// bus_test.go
+package bus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"los-complejos-backend/outbox"
+)
+
+func TestPublishIsolatesSubscribers(t *testing.T) {
+	b := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var ran []string
+	record := func(name string, err error) Handler {
+		return func(ctx context.Context, event Event) error {
+			ran = append(ran, name)
+			return err
+		}
+	}
+	b.Subscribe("audit", record("audit", nil))
+	b.Subscribe("mailer", func(ctx context.Context, event Event) error {
+		ran = append(ran, "mailer")
+		panic("template missing")
+	}, outbox.TopicComplejoDeleted)
+	b.Subscribe("webhooks", record("webhooks", errors.New("down")), outbox.TopicComplejoDeleted, outbox.TopicEventCreated)
+	b.Subscribe("search", record("search", nil), outbox.TopicComplejoDeleted)
+	b.Subscribe("feed", record("feed", nil), outbox.TopicEventCreated)
+
+	err := b.Publish(context.Background(), ComplejoDeleted{ID: "c1"})
+	if got, want := strings.Join(ran, ","), "audit,mailer,webhooks,search"; got != want {
+		t.Errorf("ran %s, want %s", got, want)
+	}
+	if err == nil || !strings.Contains(err.Error(), "mailer: panic: template missing") || !strings.Contains(err.Error(), "webhooks: down") {
+		t.Errorf("Publish error = %v, want the failures of mailer and webhooks", err)
+	}
+
+	ran = nil
+	if err := b.Publish(context.Background(), EventReminder{}); err != nil || strings.Join(ran, ",") != "audit" {
+		t.Errorf("Publish of a topic without subscribers ran %v, %v, want only the subscriber of every topic", ran, err)
+	}
+}
+
+func TestMessageDecodesBack(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	message, err := Message(ComplejoDeleted{ID: "c1", Username: "maria", Events: []string{"e1"}}, now)
+	if err != nil {
+		t.Fatalf("Message: %v", err)
+	}
+	if message.Topic != outbox.TopicComplejoDeleted {
+		t.Errorf("topic = %s, want %s", message.Topic, outbox.TopicComplejoDeleted)
+	}
+	event, err := Decode(message.Topic, message.Payload)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	deleted, ok := event.(*ComplejoDeleted)
+	if !ok || deleted.ID != "c1" || deleted.Username != "maria" || len(deleted.Events) != 1 {
+		t.Errorf("decoded %#v, want the ComplejoDeleted", event)
+	}
+}
+
+func TestDecodeEveryTopic(t *testing.T) {
+	for _, topic := range Topics() {
+		event, err := Decode(topic, []byte(`{}`))
+		if err != nil {
+			t.Errorf("Decode(%s): %v", topic, err)
+			continue
+		}
+		if event.Topic() != topic {
+			t.Errorf("Decode(%s) built a %T of topic %s", topic, event, event.Topic())
+		}
+	}
+	if _, err := Decode("complejo.unknown", []byte(`{}`)); err == nil {
+		t.Error("Decode of an unknown topic succeeded")
+	}
+}
@@ -0,0 +1,257 @@
+// events.go
+package bus
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/outbox"
+)
+
+// ComplejoRegistered is published when a Complejo signs up (never includes the password).
+type ComplejoRegistered struct {
+	ID       string `json:"_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// GuestConverted is published when an invited guest joins as a Complejo, with the Events it attended as a guest
+// (now attended as the Complejo).
+type GuestConverted struct {
+	ComplejoID string   `json:"complejo_id"`
+	Username   string   `json:"username"`
+	HostID     string   `json:"host_id"`
+	Events     []string `json:"events"`
+}
+
+// ComplejoDeleted is published when a Complejo is deleted.
+type ComplejoDeleted struct {
+	ID       string   `json:"_id"`
+	Username string   `json:"username"`
+	Events   []string `json:"events"` // Events the Complejo was withdrawn from
+}
+
+// PRAchieved is published when a Complejo improves one of its lifts.
+type PRAchieved struct {
+	ComplejoID string  `json:"complejo_id"`
+	Username   string  `json:"username"`
+	Lift       string  `json:"lift"`     // "bench", "squad" or "dl"
+	Previous   float64 `json:"previous"` // Previous record in kilograms (0 for the first one)
+	Value      float64 `json:"value"`    // New record in kilograms
+}
+
+// EventCreated is published when an Event is created; it carries the whole Event.
+type EventCreated struct {
+	models.Event
+}
+
+// EventUpdated is published when an admin updates an Event.
+type EventUpdated struct {
+	ID      string                 `json:"_id"`
+	Changes map[string]interface{} `json:"changes"`
+}
+
+// EventDeleted is published when an Event is deleted, with the usernames and IDs of the Complejos going
+// or maybe going to notify.
+type EventDeleted struct {
+	ID             string    `json:"_id"`
+	Title          string    `json:"title"`
+	Date           time.Time `json:"date"`
+	Participants   []string  `json:"participants"`
+	ParticipantIDs []string  `json:"participant_ids"`
+}
+
+// UserSubscribed is published when a Complejo starts going to an Event.
+type UserSubscribed struct {
+	EventID    string `json:"event_id"`
+	ComplejoID string `json:"complejo_id"`
+	Username   string `json:"username"`
+}
+
+// UserUnsubscribed is published when a Complejo stops going to an Event.
+type UserUnsubscribed struct {
+	EventID    string `json:"event_id"`
+	ComplejoID string `json:"complejo_id"`
+	Username   string `json:"username"`
+}
+
+// RSVPChanged is published when a Complejo answers an Event or changes its answer.
+type RSVPChanged struct {
+	EventID    string `json:"event_id"`
+	ComplejoID string `json:"complejo_id"`
+	Username   string `json:"username"`
+	Status     string `json:"status"`             // "going", "maybe" or "declined"
+	Previous   string `json:"previous,omitempty"` // Previous answer (empty for the first one)
+}
+
+// LoanOverdue is published when a lent InventoryItem has not been returned by its due date,
+// so the Complejo can be reminded. It is published again every day until the item is returned.
+type LoanOverdue struct {
+	LoanID     string    `json:"loan_id"`
+	ItemID     string    `json:"item_id"`
+	ItemName   string    `json:"item_name"`
+	ComplejoID string    `json:"complejo_id"`
+	Username   string    `json:"username"`
+	Quantity   int       `json:"quantity"`
+	DueAt      time.Time `json:"due_at"`
+}
+
+// ClaimDecided is published when an admin approves or rejects a claim on a lost-and-found post,
+// so the claimant can be notified.
+type ClaimDecided struct {
+	ClaimID    string `json:"claim_id"`
+	ItemID     string `json:"item_id"`
+	ItemTitle  string `json:"item_title"`
+	ClaimantID string `json:"claimant_id"`
+	Username   string `json:"username"`
+	Status     string `json:"status"` // "approved" or "rejected"
+	Note       string `json:"note,omitempty"`
+}
+
+// HoldDecided is published when an admin approves or rejects content held by the content filter,
+// so its author can be notified.
+type HoldDecided struct {
+	HoldID   string `json:"hold_id"`
+	Kind     string `json:"kind"` // "username" or "lost_item"
+	TargetID string `json:"target_id"`
+	AuthorID string `json:"author_id"`
+	Status   string `json:"status"` // "approved" or "rejected"
+	Note     string `json:"note,omitempty"`
+}
+
+// ShiftReminder is published once to each volunteer of a shift starting within a day, so they can be reminded.
+type ShiftReminder struct {
+	ShiftID    string    `json:"shift_id"`
+	EventID    string    `json:"event_id"`
+	EventTitle string    `json:"event_title"`
+	Role       string    `json:"role"`
+	ComplejoID string    `json:"complejo_id"`
+	Username   string    `json:"username"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+}
+
+// SevereWeather is published once when severe weather is forecast for an outdoor Event starting within
+// 12 hours, with the usernames of the Complejos going or maybe going to notify.
+type SevereWeather struct {
+	EventID      string          `json:"event_id"`
+	Title        string          `json:"title"`
+	Date         time.Time       `json:"date"`
+	Location     string          `json:"location"`
+	Forecast     models.Forecast `json:"forecast"`
+	Participants []string        `json:"participants"`
+}
+
+// EventReminder is published once when an Event is about to start, with the IDs of the Complejos going to it
+// to remind.
+type EventReminder struct {
+	EventID        string    `json:"event_id"`
+	Title          string    `json:"title"`
+	Date           time.Time `json:"date"`
+	Location       string    `json:"location"`
+	ParticipantIDs []string  `json:"participant_ids"`
+}
+
+// PhotoTagged is published when a Complejo that is asked for its consent is tagged in an album photo,
+// so it can grant or refuse it.
+type PhotoTagged struct {
+	PhotoID    string `json:"photo_id"`
+	EventID    string `json:"event_id"`
+	EventTitle string `json:"event_title"`
+	ComplejoID string `json:"complejo_id"`
+	Username   string `json:"username"`
+	TaggedBy   string `json:"tagged_by"` // Username of the Complejo that uploaded the photo
+}
+
+// AnnouncementPublished is published when an admin publishes an Announcement to every member.
+type AnnouncementPublished struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	CreatedBy string `json:"created_by"`
+}
+
+// ChallengeWon is published once to each winner of a Challenge, when its standings become final: the
+// participants with the highest score, when it is not 0.
+type ChallengeWon struct {
+	ChallengeID string  `json:"challenge_id"`
+	Title       string  `json:"title"`
+	Metric      string  `json:"metric"`
+	ComplejoID  string  `json:"complejo_id"`
+	Username    string  `json:"username"`
+	Score       float64 `json:"score"`
+}
+
+func (ComplejoRegistered) Topic() string    { return outbox.TopicComplejoRegistered }
+func (ComplejoDeleted) Topic() string       { return outbox.TopicComplejoDeleted }
+func (PRAchieved) Topic() string            { return outbox.TopicComplejoPRAchieved }
+func (GuestConverted) Topic() string        { return outbox.TopicGuestConverted }
+func (EventCreated) Topic() string          { return outbox.TopicEventCreated }
+func (EventUpdated) Topic() string          { return outbox.TopicEventUpdated }
+func (EventDeleted) Topic() string          { return outbox.TopicEventDeleted }
+func (UserSubscribed) Topic() string        { return outbox.TopicEventSubscribed }
+func (UserUnsubscribed) Topic() string      { return outbox.TopicEventUnsubscribed }
+func (RSVPChanged) Topic() string           { return outbox.TopicEventRSVPChanged }
+func (SevereWeather) Topic() string         { return outbox.TopicSevereWeather }
+func (EventReminder) Topic() string         { return outbox.TopicEventReminder }
+func (PhotoTagged) Topic() string           { return outbox.TopicPhotoTagged }
+func (LoanOverdue) Topic() string           { return outbox.TopicLoanOverdue }
+func (ClaimDecided) Topic() string          { return outbox.TopicClaimDecided }
+func (ShiftReminder) Topic() string         { return outbox.TopicShiftReminder }
+func (HoldDecided) Topic() string           { return outbox.TopicHoldDecided }
+func (AnnouncementPublished) Topic() string { return outbox.TopicAnnouncement }
+func (ChallengeWon) Topic() string          { return outbox.TopicChallengeWon }
+
+// decoders builds an empty event of each topic, ready to be decoded.
+var decoders = map[string]func() Event{
+	outbox.TopicComplejoRegistered: func() Event { return &ComplejoRegistered{} },
+	outbox.TopicComplejoDeleted:    func() Event { return &ComplejoDeleted{} },
+	outbox.TopicComplejoPRAchieved: func() Event { return &PRAchieved{} },
+	outbox.TopicGuestConverted:     func() Event { return &GuestConverted{} },
+	outbox.TopicEventCreated:       func() Event { return &EventCreated{} },
+	outbox.TopicEventUpdated:       func() Event { return &EventUpdated{} },
+	outbox.TopicEventDeleted:       func() Event { return &EventDeleted{} },
+	outbox.TopicEventSubscribed:    func() Event { return &UserSubscribed{} },
+	outbox.TopicEventUnsubscribed:  func() Event { return &UserUnsubscribed{} },
+	outbox.TopicEventRSVPChanged:   func() Event { return &RSVPChanged{} },
+	outbox.TopicSevereWeather:      func() Event { return &SevereWeather{} },
+	outbox.TopicEventReminder:      func() Event { return &EventReminder{} },
+	outbox.TopicPhotoTagged:        func() Event { return &PhotoTagged{} },
+	outbox.TopicLoanOverdue:        func() Event { return &LoanOverdue{} },
+	outbox.TopicClaimDecided:       func() Event { return &ClaimDecided{} },
+	outbox.TopicShiftReminder:      func() Event { return &ShiftReminder{} },
+	outbox.TopicHoldDecided:        func() Event { return &HoldDecided{} },
+	outbox.TopicAnnouncement:       func() Event { return &AnnouncementPublished{} },
+	outbox.TopicChallengeWon:       func() Event { return &ChallengeWon{} },
+}
+
+// Topics returns the topic of every domain event.
+func Topics() []string {
+	topics := make([]string, 0, len(decoders))
+	for topic := range decoders {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// Decode rebuilds the domain event of the given topic from its JSON payload.
+// The returned event is a pointer to one of the event types of this package.
+func Decode(topic string, payload []byte) (Event, error) {
+	build, ok := decoders[topic]
+	if !ok {
+		return nil, fmt.Errorf("unknown domain event topic %q", topic)
+	}
+
+	event := build()
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("error decoding %s event: %w", topic, err)
+	}
+	return event, nil
+}
+
+// Message records the event in a pending outbox message, so that it is published once its change is committed.
+func Message(event Event, now time.Time) (*models.OutboxMessage, error) {
+	return outbox.NewMessage(event.Topic(), event, now)
+}
@@ -0,0 +1,870 @@
+// changelog.go
+package changelog
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of the changes of the API.
+const (
+	Added      = "added"      // A new route or field
+	Changed    = "changed"    // A route or field whose behavior changed
+	Deprecated = "deprecated" // A route or field still served until its sunset, with a replacement
+	Removed    = "removed"    // A route or field no longer served
+)
+
+// DateLayout is the layout of the dates of the entries.
+const DateLayout = "2006-01-02"
+
+// Entry is a change of the API, for client apps to warn before they break.
+type Entry struct {
+	Date        string `json:"date"`                  // Day of the change ("2006-01-02")
+	Kind        string `json:"kind"`                  // "added", "changed", "deprecated" or "removed"
+	Method      string `json:"method"`                // Method of the route concerned
+	Path        string `json:"path"`                  // Path of the route concerned, as registered (e.g. "/event/:id")
+	Field       string `json:"field,omitempty"`       // Field concerned, when the change is limited to one
+	Description string `json:"description"`           // What changed
+	Replacement string `json:"replacement,omitempty"` // What to use instead of a deprecated or removed route or field
+	Sunset      string `json:"sunset,omitempty"`      // Day from which a deprecated route or field may be removed ("2006-01-02")
+}
+
+// Route reports whether the entry concerns a whole route rather than one of its fields.
+func (e *Entry) Route() bool {
+	return e.Field == ""
+}
+
+// Entries are the changes of the API, newest first. Add an entry with every new route or field and every
+// deprecation; the deprecated routes answer with the Deprecation and Sunset headers of their entry.
+var Entries = []Entry{
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/complejo/me/calendar/rotate",
+		Description: "Revokes the link of the own personal calendar feed and returns a new one.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/event/:id/participants",
+		Field:       "thumbnail",
+		Description: "Left out when the privacy settings of the participant hide its photo from the caller; participants blocking the caller are left out.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Removed,
+		Method:      "PUT",
+		Path:        "/complejo/admin",
+		Description: "Replaced by a route naming the Complejo updated.",
+		Replacement: "PUT /complejo/admin/:id",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/complejo/admin/:id",
+		Description: "Updates any fields of the Complejo with the given ID (admins only).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Removed,
+		Method:      "PUT",
+		Path:        "/event/admin",
+		Description: "Replaced by a route naming the Event updated.",
+		Replacement: "PUT /event/admin/:id",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/event/admin/:id",
+		Description: "Updates any fields of the Event with the given ID (admins only).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/complejo/:id/block",
+		Description: "Blocks a user: it no longer sees the caller's profile, and their follows of each other are removed.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/complejo/:id/block",
+		Description: "Stops blocking a user.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/me/blocks",
+		Description: "Users the caller blocks, latest first, paginated.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/complejo",
+		Description: "Users blocking the caller are left out.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/complejo/:id",
+		Description: "Returns 404 when the user blocks the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/complejo/:id/lifts",
+		Description: "Returns 404 when the user blocks the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "PUT",
+		Path:        "/complejo/:id/follow",
+		Description: "Returns 403 (blocked) when either user blocks the other.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/report",
+		Description: "Reports an event or a user to the admins.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/moderation/reports",
+		Description: "Moderation queue of the reported content, oldest first, paginated (admins only).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/moderation/reports/:id",
+		Description: "Dismisses a report, or acts on it by deleting the reported content (admins only).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/me/privacy",
+		Description: "Privacy settings of the caller: who sees its weight, height, IMC and photo.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/complejo/me/privacy",
+		Description: "Changes who sees the weight, height, IMC and photo of the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/complejo",
+		Description: "Weight, height, IMC and photo are only shown to the audience the user chose in its privacy settings.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/complejo/:id",
+		Description: "Weight, height, IMC and photo are only shown to the audience the user chose in its privacy settings.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/complejo/:id/lifts",
+		Field:       "bodyweight",
+		Description: "Left out when the privacy settings of the user hide its weight from the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/feed",
+		Description: "Activity feed of the followed users and of the joined groups, latest first, paginated.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/complejo/:id/follow",
+		Description: "Follow a user.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/complejo/:id/follow",
+		Description: "Stop following a user.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/:id/followers",
+		Description: "Followers of a user, latest first, paginated.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/:id/following",
+		Description: "Users a user follows, latest first, paginated.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/complejo",
+		Description: "Weight, height and IMC are only shown to the user itself, its followers and admins.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/complejo/:id",
+		Description: "Weight, height and IMC are only shown to the user itself, its followers and admins.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo",
+		Field:       "hidden",
+		Description: "Names of the profile fields left out because the caller does not follow the user.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/:id",
+		Field:       "hidden",
+		Description: "Names of the profile fields left out because the caller does not follow the user.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/:id",
+		Field:       "follows",
+		Description: "Numbers of followers and followed users, and whether the caller follows the user.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/me",
+		Field:       "follows",
+		Description: "Numbers of followers and followed users.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "PUT",
+		Path:        "/event/:id/rsvp",
+		Description: "Answering going to the event of a group is reserved to its members.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "PUT",
+		Path:        "/event/:id/subscribe",
+		Description: "Subscribing to the event of a group is reserved to its members.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/event/:id",
+		Field:       "group_id",
+		Description: "Group the event belongs to, whose members alone may go.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/event",
+		Field:       "group",
+		Description: "Keeps the events of a group.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/groups/:id/leaderboard",
+		Description: "Ranks the members of a group by their lifts.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/groups/:id/events",
+		Description: "Creates an event of a group, for admins and its owners.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/groups/:id/events",
+		Description: "Lists the events of a group.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/groups/:id/members/:complejo_id",
+		Description: "Removes a member from a group, or leaves it.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/groups/:id/members/:complejo_id",
+		Description: "Adds a member to a group or changes its role, for admins and its owners.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/groups/:id/members",
+		Description: "Lists the members of a group with their role.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/groups/:id",
+		Description: "Removes a group, for admins and its owners.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/groups/:id",
+		Description: "Renames or describes a group, for admins and its owners.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/groups/:id",
+		Description: "Retrieves a group.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/groups",
+		Description: "Creates a group owned by the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/groups",
+		Description: "Lists the groups by name, with the role of the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/challenges/:id/standings",
+		Description: "Ranks the participants of a challenge by their last score.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/challenges/:id/join",
+		Description: "Leaves a challenge that has not ended.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/challenges/:id/join",
+		Description: "Joins a challenge that has not ended.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/challenges/:id",
+		Description: "Removes a challenge, for admins and its creator.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/challenges/:id",
+		Description: "Retrieves a challenge.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/challenges",
+		Description: "Creates a challenge ranked by events attended, workouts, volume or nutrition days.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/challenges",
+		Description: "Lists the challenges, by status.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/:id",
+		Field:       "goal",
+		Description: "Goal of the nutrition targets of the Complejo.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/complejo/user",
+		Field:       "goal",
+		Description: "Sets the goal of the nutrition targets (lose, maintain or gain).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/nutrition/:id",
+		Description: "Removes a nutrition log of the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/nutrition/:id",
+		Description: "Replaces a nutrition log of the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/nutrition/:id",
+		Description: "Returns a nutrition log of the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/nutrition/targets",
+		Description: "Calculates the daily nutrition targets of the caller from its weight and goal.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/nutrition/summary",
+		Description: "Sums the nutrition logs of the caller by week or month, with its targets.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/nutrition",
+		Description: "Logs what the caller ate over a day.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/nutrition",
+		Description: "Lists the nutrition logs of the caller over a range of days.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "PUT",
+		Path:        "/workouts/:id",
+		Field:       "exercises",
+		Description: "Exercises are referenced by their exercise_id in the library instead of a free name and muscle group.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "POST",
+		Path:        "/workouts",
+		Field:       "exercises",
+		Description: "Exercises are referenced by their exercise_id in the library instead of a free name and muscle group.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/exercises/:id",
+		Description: "Removes an exercise from the library (admin only).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/exercises/:id",
+		Description: "Replaces an exercise of the library (admin only).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/exercises",
+		Description: "Adds an exercise to the library (admin only).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/exercises/:id",
+		Description: "Returns an exercise of the library.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/exercises",
+		Description: "Searches the exercise library by name, muscle group and equipment.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/workouts/volume",
+		Description: "Summarizes the weekly training volume of the caller by muscle group.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/workouts/:id",
+		Description: "Removes a workout of the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/workouts/:id",
+		Description: "Replaces a workout of the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/workouts/:id",
+		Description: "Returns a workout of the caller.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/workouts",
+		Description: "Logs a workout of the caller, with its exercises, sets and notes.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/workouts",
+		Description: "Lists the workouts of the caller, newest first.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/tools/1rm",
+		Description: "Estimates the one-rep max of a set with the Epley and Brzycki formulas.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/complejo/me/lifts",
+		Field:       "reps",
+		Description: "Records the repetitions of the lift, 1 by default, and its estimated one-rep max in e1rm.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "GET",
+		Path:        "/complejo/:id",
+		Field:       "imc",
+		Description: "The IMC is an object with its numeric value and its category, or null when the weight or height is unknown, instead of the category alone.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/me/progress",
+		Description: "Returns the monthly trends of the bodyweight, lifts and estimated total of the caller, for charts.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/complejo/me/lifts",
+		Field:       "bodyweight",
+		Description: "Records the bodyweight with the lift, the weight on the profile by default.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/:id/lifts",
+		Description: "Lists the lift history of a user, oldest first, optionally of one lift.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/complejo/me/lifts",
+		Description: "Records a lift in the history of the caller, raising the record on the profile when it beats it.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/leaderboard",
+		Description: "Ranks the users by a lift or by its Wilks or DOTS score, with the place of the caller when authenticated.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/admin/audit",
+		Description: "Lists the most recent entries of the audit log, such as the erasures of users (admin only).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/complejo/:id/erase",
+		Description: "Erases the personal data of a user, confirmed by its current username (admin only).",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/complejo/me/erase",
+		Description: "Erases the personal data of the caller, confirmed by its current username, and records it in the audit log.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Changed,
+		Method:      "PUT",
+		Path:        "/complejo/:id/restore",
+		Description: "Erased users cannot be restored.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/:id/export.zip",
+		Description: "Downloads the archive of the latest data export of a user, authenticated by the token of its link.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/me/export",
+		Description: "Requests an export of everything stored about the caller, generated in the background, and returns its download link once ready.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/service/token",
+		Description: "Issues the next token of the service account authenticating the request.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/admin/service-accounts/:id/rotate",
+		Description: "Issues a new token of a service account, keeping the previous ones for its overlap.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "DELETE",
+		Path:        "/admin/service-accounts/:id",
+		Description: "Removes a service account and its tokens.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/admin/service-accounts/:id",
+		Description: "Replaces the name, role, rotation and state of a service account.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/admin/service-accounts/:id",
+		Description: "Returns a service account with its working tokens.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/admin/service-accounts",
+		Description: "Creates the service account of an integration with its first rotating token.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/admin/service-accounts",
+		Description: "Lists the service accounts of the integrations with their working tokens.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/admin/import",
+		Description: "Imports members or events in bulk from CSV or a JSON array, with a dry run and a per-row report.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/admin/export/events.csv",
+		Description: "Streams the events as CSV, with the columns selected by ?fields=.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/admin/export/complejos.csv",
+		Description: "Streams the members as CSV, with the columns selected by ?fields=.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "PUT",
+		Path:        "/admin/settings/listing",
+		Description: "Sets the default order, page size and hiding of past events of GET /event.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/event",
+		Field:       "sort",
+		Description: "Orders the events by date, asc or desc; the defaults of the listing now come from the club settings.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/media/:id",
+		Description: "Serves a profile photo or an album photo by its ID with an ETag and cache headers, or a ?size= of it.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/api/changelog",
+		Description: "Lists the changes of the API, newest first.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Deprecated,
+		Method:      "PUT",
+		Path:        "/event/:id/subscribe",
+		Description: "Subscribing is answering going to the event.",
+		Replacement: `PUT /event/:id/rsvp with "status": "going"`,
+		Sunset:      "2027-04-16",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Deprecated,
+		Method:      "PUT",
+		Path:        "/event/:id/unsubscribe",
+		Description: "Unsubscribing is withdrawing a going answer to the event.",
+		Replacement: `PUT /event/:id/rsvp with "status": "declined"`,
+		Sunset:      "2027-04-16",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/event/:id/photos",
+		Field:       "sizes",
+		Description: "Paths downloading the thumbnail and medium sizes of each photo, with ?size=thumb and ?size=medium.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/complejo/:id",
+		Field:       "photo_sizes",
+		Description: "Paths downloading the thumbnail and medium sizes of the profile photo.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/admin/sandbox/reset",
+		Description: "Resets the data of the sandbox deployment to the seed data.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Deprecated,
+		Method:      "POST",
+		Path:        "/complejo",
+		Field:       "photo",
+		Description: "Profile photos are uploaded as multipart files.",
+		Replacement: "POST /complejo/photo",
+		Sunset:      "2027-04-16",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Deprecated,
+		Method:      "PUT",
+		Path:        "/complejo/user",
+		Field:       "photo",
+		Description: "Profile photos are uploaded as multipart files.",
+		Replacement: "POST /complejo/photo",
+		Sunset:      "2027-04-16",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "POST",
+		Path:        "/complejo/photo",
+		Description: "Uploads the profile photo of the caller as a multipart file, served at the photo_url of the profile.",
+	},
+	{
+		Date:        "2026-10-16",
+		Kind:        Added,
+		Method:      "GET",
+		Path:        "/public/stats",
+		Description: "Club counters for the public homepage.",
+	},
+}
+
+// Day parses a date of an entry.
+func Day(date string) (time.Time, error) {
+	day, err := time.Parse(DateLayout, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid changelog date %q", date)
+	}
+	return day, nil
+}
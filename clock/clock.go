@@ -0,0 +1,59 @@
+// clock.go
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts the current time so that time-dependent logic (token expiry,
+// upcoming/past events, scheduled jobs) can be driven deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock backed by the real wall clock.
+type systemClock struct{}
+
+// New returns a Clock that reports the real current time.
+func New() Clock {
+	return systemClock{}
+}
+
+// Now returns the current wall-clock time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Fake is a Clock whose time only changes when it is explicitly set or advanced.
+// It is intended for tests of reminder, streak and expiry logic.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake returns a Fake clock frozen at the given time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake clock's current time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set moves the fake clock to the given time.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+// Advance moves the fake clock forward by the given duration.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
@@ -0,0 +1,66 @@
+// main.go
+//
+// Command migrate applies the pending MongoDB data migrations (package migrations) to the database
+// configured in the environment:
+//
+//	go run ./cmd/migrate           # apply the pending migrations
+//	go run ./cmd/migrate -status   # list every migration and whether it is applied
+//
+// The PostgreSQL backend does not need it: its schema migrations are applied on startup.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	"los-complejos-backend/config"
+	"los-complejos-backend/database"
+	"los-complejos-backend/migrations"
+)
+
+func main() {
+	status := flag.Bool("status", false, "List the migrations and whether they are applied, without applying any")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.StorageBackend != config.BackendMongo {
+		log.Fatalf("migrate only applies to the %s backend; PostgreSQL migrations run on startup", config.BackendMongo)
+	}
+
+	ctx := context.Background()
+	client, err := database.Connect(ctx, cfg.MongoURI)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Disconnect(ctx)
+	db := client.Database(cfg.DatabaseName)
+
+	if *status {
+		applied, err := migrations.Applied(ctx, db)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, migration := range migrations.All {
+			state := "pending"
+			if at, ok := applied[migration.Version]; ok {
+				state = "applied " + at.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%s %-28s %s\n", migration.Version, state, migration.Description)
+		}
+		return
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	applied, err := migrations.Run(ctx, db, logger)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%d migration(s) applied\n", len(applied))
+}
@@ -0,0 +1,129 @@
+// main.go
+//
+// Command replay re-executes journaled failed requests against the database configured in the environment
+// (point MONGO_URI / POSTGRES_DSN at a staging database), to reproduce bugs users report.
+//
+// Export the entries with `GET /journal` (or `GET /journal/:id`) and run:
+//
+//	go run ./cmd/replay -entries journal.json -confirm
+//
+// Each request is served in-process by the same router as the server, with a body synthesized from the
+// recorded schema and a token carrying the recorded role.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"los-complejos-backend/app"
+	"los-complejos-backend/config"
+	"los-complejos-backend/database"
+	"los-complejos-backend/journal"
+	"los-complejos-backend/models"
+	"los-complejos-backend/utils"
+)
+
+func main() {
+	entriesPath := flag.String("entries", "", "JSON file with the journal entries (a list, a single entry or a GET /journal response)")
+	only := flag.String("id", "", "Replay only the entry with this ID")
+	confirm := flag.Bool("confirm", false, "Confirm that the requests may write to the configured database")
+	flag.Parse()
+
+	if *entriesPath == "" {
+		log.Fatal("-entries is required")
+	}
+	if !*confirm {
+		log.Fatal("replaying executes the requests against the configured database; pass -confirm to proceed")
+	}
+
+	entries, err := readEntries(*entriesPath)
+	if err != nil {
+		log.Fatal("Error reading entries: ", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	application, err := app.New(ctx, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer application.Close(ctx)
+
+	if application.DB != nil {
+		if err := database.EnsureIndexes(ctx, application.DB, application.Logger); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	for _, entry := range entries {
+		if *only != "" && entry.ID != *only {
+			continue
+		}
+
+		status, body, err := replay(application, entry)
+		if err != nil {
+			fmt.Printf("%s %s %s: %v\n", entry.ID, entry.Method, entry.Path, err)
+			continue
+		}
+		fmt.Printf("%s %s %s: recorded %d, replayed %d %s\n", entry.ID, entry.Method, entry.Path, entry.Status, status, strings.TrimSpace(body))
+	}
+}
+
+// replay serves the journaled request through the App's router and returns the response.
+func replay(application *app.App, entry models.JournalEntry) (int, string, error) {
+	req, err := http.NewRequest(entry.Method, entry.Path, bytes.NewReader(journal.Sample(entry.BodySchema)))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if entry.Role != "" {
+		token, err := utils.GenerateToken("replay", entry.Role, "replay", application.Clock.Now())
+		if err != nil {
+			return 0, "", err
+		}
+		req.Header.Set("Authorization", token)
+	}
+
+	recorder := httptest.NewRecorder()
+	application.Router.ServeHTTP(recorder, req)
+	return recorder.Code, recorder.Body.String(), nil
+}
+
+// readEntries decodes a list of entries, a single entry or a response envelope wrapping either.
+func readEntries(path string) ([]models.JournalEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &envelope); err == nil && len(envelope.Data) > 0 {
+		data = envelope.Data
+	}
+
+	var entries []models.JournalEntry
+	if err := json.Unmarshal(data, &entries); err == nil {
+		return entries, nil
+	}
+
+	var entry models.JournalEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return []models.JournalEntry{entry}, nil
+}
@@ -0,0 +1,51 @@
+// main.go
+//
+// Command seed populates the database configured in the environment with realistic Complejos and Events
+// (an admin account included) so the backend can be run locally with data:
+//
+//	APP_ENV=development go run ./cmd/seed
+//
+// It refuses to run when APP_ENV is "production" (the default). Seeding is idempotent: accounts and events
+// that already exist (by username and title) are left untouched, so it can be run again safely.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"los-complejos-backend/app"
+	"los-complejos-backend/config"
+	"los-complejos-backend/database"
+	"los-complejos-backend/seed"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.IsProduction() {
+		log.Fatalf("refusing to seed a production database; set APP_ENV=%s or %s", config.EnvDevelopment, config.EnvTest)
+	}
+
+	ctx := context.Background()
+	application, err := app.New(ctx, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer application.Close(ctx)
+
+	if application.DB != nil {
+		if err := database.EnsureIndexes(ctx, application.DB, application.Logger); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	summary, err := seed.Run(ctx, application.Complejos, application.Events, application.Clock)
+	if err != nil {
+		log.Fatal("Error ", err)
+	}
+	fmt.Printf("%d Complejo(s) created (password %q, admin account %q)\n", summary.Complejos, summary.Password, summary.Admin)
+	fmt.Printf("%d Event(s) created\n", summary.Events)
+}
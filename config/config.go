@@ -0,0 +1,682 @@
+// config.go
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/mail"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"los-complejos-backend/locale"
+	"los-complejos-backend/mailer"
+	"los-complejos-backend/markdown"
+	"los-complejos-backend/moderation"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/utils"
+
+	"github.com/joho/godotenv"
+)
+
+// Config holds the runtime configuration of the application.
+type Config struct {
+	Port         string // HTTP port the server listens on (PORT, default "8080")
+	MongoURI     string // MongoDB connection string (MONGO_URI, default "mongodb://localhost:27017")
+	DatabaseName string // MongoDB database name (MONGO_DB, default "COMPLEJOS")
+	JWTSecret    string // Secret used to sign and verify JWTs (JWT_SECRET, required)
+	Environment  string // Deployment environment (APP_ENV, "development", "test" or "production", default "production")
+
+	// Location is the gym's time zone, used to report hours of the day (TIMEZONE, IANA name, default "UTC")
+	Location *time.Location
+
+	// JWTLifetime is how long the tokens issued on sign-up are valid (JWT_LIFETIME, unset for tokens that never
+	// expire, as there is no login route to issue new ones)
+	JWTLifetime time.Duration
+
+	// StorageBackend selects where Complejos and Events are stored (STORAGE_BACKEND, "mongo" or "postgres", default "mongo")
+	StorageBackend string
+	// PostgresDSN is the PostgreSQL connection string, required when StorageBackend is "postgres" (POSTGRES_DSN)
+	PostgresDSN string
+
+	// IngestAPIKey authenticates external producers on POST /ingest/events (INGEST_API_KEY, ingestion disabled when empty)
+	IngestAPIKey string
+
+	// StripeWebhookSecret verifies the Stripe webhooks on POST /webhooks/stripe
+	// (STRIPE_WEBHOOK_SECRET, payment recording disabled when empty)
+	StripeWebhookSecret string
+
+	// FederationURL is the base URL of the shared federation endpoint (FEDERATION_URL, federation disabled when empty)
+	FederationURL string
+	// FederationClub identifies this club in the federation feeds (FEDERATION_CLUB, required with FEDERATION_URL)
+	FederationClub string
+	// FederationSecret signs and verifies the federation feeds (FEDERATION_SECRET, required with FEDERATION_URL)
+	FederationSecret string
+	// FederationSyncInterval is the time between two federation synchronizations (FEDERATION_SYNC_INTERVAL, default "15m")
+	FederationSyncInterval time.Duration
+
+	// HeavyConcurrency caps the concurrent executions of expensive endpoints such as search (HEAVY_CONCURRENCY, default 4)
+	HeavyConcurrency int
+	// HeavyQueue is how many more requests to expensive endpoints may wait for a slot (HEAVY_QUEUE, default 16)
+	HeavyQueue int
+	// HeavyQueueTimeout is how long a queued request waits before getting a 503 (HEAVY_QUEUE_TIMEOUT, default "5s")
+	HeavyQueueTimeout time.Duration
+
+	// ReadTimeout bounds the GET requests (REQUEST_TIMEOUT_READ, default "10s", "0" for no bound)
+	ReadTimeout time.Duration
+	// WriteTimeout bounds the requests changing data (REQUEST_TIMEOUT_WRITE, default "30s", "0" for no bound)
+	WriteTimeout time.Duration
+	// ExportTimeout bounds the exports and reports (REQUEST_TIMEOUT_EXPORT, default "5m", "0" for no bound)
+	ExportTimeout time.Duration
+
+	// EventStreamLimit is how many live streams of the event changes may be open at the same time
+	// (EVENT_STREAM_LIMIT, default 100)
+	EventStreamLimit int
+
+	// ImageWorkers is the number of workers processing uploaded images (IMAGE_WORKERS, default 2)
+	ImageWorkers int
+	// ImageQueue is how many images may wait for a worker before uploads get a 429 (IMAGE_QUEUE, default 16)
+	ImageQueue int
+
+	// ProfileCacheTTL is how long a profile read by a request stays cached for the next ones (PROFILE_CACHE_TTL,
+	// default "5s", "0" to only cache within a request)
+	ProfileCacheTTL time.Duration
+	// ProfileCacheSize is how many profiles may be cached across requests (PROFILE_CACHE_SIZE, default 1000)
+	ProfileCacheSize int
+
+	// JobWorkers is how many background jobs each instance runs at the same time (JOB_WORKERS, default 4)
+	JobWorkers int
+
+	// WebhookTimeout is how long a webhook may take to answer a delivery (WEBHOOK_TIMEOUT, default "10s")
+	WebhookTimeout time.Duration
+	// WebhookMaxAttempts is how many times a delivery is attempted before it is given up
+	// (WEBHOOK_MAX_ATTEMPTS, default 8)
+	WebhookMaxAttempts int
+
+	// JSONNaming is the naming of the fields of the responses (JSON_FIELD_NAMING, "snake_case" or "declared",
+	// default "snake_case")
+	JSONNaming string
+	// JSONOmitNull leaves the null fields out of the responses instead of sending null (JSON_NULLS, "keep" or
+	// "omit", default "keep")
+	JSONOmitNull bool
+	// JSONStrict rejects the request payloads with fields the endpoint does not know instead of ignoring them
+	// (JSON_UNKNOWN_FIELDS, "reject" or "ignore", default "reject")
+	JSONStrict bool
+
+	// PublicSiteURL is the base URL of the public site (frontend), whose Event pages are at /event/<id>
+	// (PUBLIC_SITE_URL, default "http://localhost:3000")
+	PublicSiteURL string
+	// PublicAPIURL is the base URL of this API as reached from outside, for the links it hands out such as the
+	// share images of the Events (PUBLIC_API_URL, default "http://localhost:<PORT>")
+	PublicAPIURL string
+
+	// ObjectStore selects where the uploaded files (profile photos, album photos, GPX routes, share images) are kept
+	// (OBJECT_STORE, "dir", "gridfs" or "s3", default "dir")
+	ObjectStore string
+	// ObjectStoreDir is the directory keeping the uploaded files with the "dir" store
+	// (OBJECT_STORE_DIR, default "data/objects")
+	ObjectStoreDir string
+	// S3 locates and authenticates the bucket of the "s3" store (S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY_ID and
+	// S3_SECRET_ACCESS_KEY, required; S3_REGION, default "us-east-1")
+	S3 objectstore.S3Options
+
+	// SoftDeleteRetention is how long deleted Complejos and Events stay restorable before being purged
+	// (SOFT_DELETE_RETENTION, default "720h")
+	SoftDeleteRetention time.Duration
+
+	// OffboardingDelay is the cooling-off delay between the request of the purge of the club data and its
+	// earliest confirmation (OFFBOARDING_DELAY, default "72h")
+	OffboardingDelay time.Duration
+
+	// SchedulerDisabledTasks lists the scheduled tasks that do not run on this instance
+	// (SCHEDULER_DISABLED_TASKS, comma-separated names such as "churn_scoring")
+	SchedulerDisabledTasks []string
+
+	// PublicStatsInterval is the time between two computations of the counters of the public homepage
+	// (PUBLIC_STATS_INTERVAL, default "10m")
+	PublicStatsInterval time.Duration
+
+	// ChurnScoringInterval is the time between two churn-risk scorings of the members (CHURN_SCORING_INTERVAL, default "24h")
+	ChurnScoringInterval time.Duration
+
+	// ChallengeScoringInterval is the time between two scorings of the participants of the challenges
+	// (CHALLENGE_SCORING_INTERVAL, default "24h")
+	ChallengeScoringInterval time.Duration
+
+	// LoanReminderInterval is the time between two checks for overdue equipment loans (LOAN_REMINDER_INTERVAL, default "1h")
+	LoanReminderInterval time.Duration
+
+	// LostFoundCleanupInterval is the time between two removals of the expired lost-and-found posts
+	// (LOST_FOUND_CLEANUP_INTERVAL, default "1h")
+	LostFoundCleanupInterval time.Duration
+
+	// VolunteerReminderInterval is the time between two checks for volunteer shifts starting within a day
+	// (VOLUNTEER_REMINDER_INTERVAL, default "1h")
+	VolunteerReminderInterval time.Duration
+
+	// WeatherProviderURL is the weather service forecasting the outdoor events (WEATHER_PROVIDER_URL, forecasts disabled when empty)
+	WeatherProviderURL string
+	// WeatherAPIKey authenticates the requests to the weather service (WEATHER_API_KEY, optional)
+	WeatherAPIKey string
+	// WeatherCacheTTL is how long a forecast is cached on its event (WEATHER_CACHE_TTL, default "3h")
+	WeatherCacheTTL time.Duration
+	// WeatherWarningInterval is the time between two checks for severe weather forecast for outdoor events starting
+	// within 12 hours (WEATHER_WARNING_INTERVAL, default "1h")
+	WeatherWarningInterval time.Duration
+
+	// SMTPHost is the SMTP server sending the notification emails (SMTP_HOST, emails disabled when empty)
+	SMTPHost string
+	// SMTPPort is the port of the SMTP server (SMTP_PORT, default 587)
+	SMTPPort int
+	// SMTPUsername and SMTPPassword authenticate to the SMTP server (SMTP_USERNAME, SMTP_PASSWORD, optional)
+	SMTPUsername string
+	SMTPPassword string
+	// MailFrom is the sender of the notification emails, e.g. "Los Complejos <no-reply@example.com>"
+	// (MAIL_FROM, required with SMTP_HOST)
+	MailFrom string
+	// MailTemplates renders the notification emails: the default templates, replaced by the `<name>.<locale>.tmpl`
+	// files of MAIL_TEMPLATES_DIR when set
+	MailTemplates *mailer.Templates
+
+	// FCMProjectID is the Firebase project delivering the push notifications to the apps (FCM_PROJECT_ID, app
+	// notifications disabled when empty)
+	FCMProjectID string
+	// FCMCredentials is the JSON key of a service account of the Firebase project, read from FCM_CREDENTIALS_FILE
+	// (required with FCM_PROJECT_ID)
+	FCMCredentials []byte
+	// VAPIDPrivateKey is the P-256 private key identifying the server to the Web Push services, base64url-encoded
+	// (VAPID_PRIVATE_KEY, browser notifications disabled when empty)
+	VAPIDPrivateKey string
+	// VAPIDSubject is the contact of the server for the Web Push services, a mailto: or https: URL
+	// (VAPID_SUBJECT, required with VAPID_PRIVATE_KEY)
+	VAPIDSubject string
+	// EventReminderInterval is the time between two checks for events starting soon (EVENT_REMINDER_INTERVAL,
+	// default "15m")
+	EventReminderInterval time.Duration
+	// EventReminderBefore is how long before an event starts its participants are reminded of it
+	// (EVENT_REMINDER_BEFORE, default "2h")
+	EventReminderBefore time.Duration
+
+	// Markdown renders the Markdown of event descriptions into HTML keeping only the allowed elements
+	// (MARKDOWN_ALLOWED_TAGS, comma-separated, default every element the renderer produces)
+	Markdown markdown.Policy
+
+	// ContentFilter holds usernames and lost-and-found posts for review when they contain a blocked word of the
+	// author's locale (CONTENT_FILTER_WORDS_EN, CONTENT_FILTER_WORDS_ES, comma-separated) or more links than allowed
+	// (CONTENT_FILTER_MAX_LINKS, default 2, -1 to disable)
+	ContentFilter moderation.Filter
+
+	// IMCCategories names the ranges of the IMC of the Complejos, from the lowest: under 18.5, from 18.5 to 25,
+	// from 25 to 30 and from 30 (IMC_CATEGORIES, four comma-separated names, default the names of utils.IMCCategories)
+	IMCCategories []string
+
+	// EventPinDuration is how long an event stays pinned when the admin sets no expiry (EVENT_PIN_DURATION, default "168h")
+	EventPinDuration time.Duration
+
+	// GuestPasses is how many guests each member may bring to events per calendar month (GUEST_PASSES_PER_MONTH, default 2)
+	GuestPasses int
+
+	// SandboxEnabled designates the deployment as the sandbox, whose data admins may reset to the seed data
+	// (SANDBOX_ENABLED, "true" to enable; never in production, and only when the database and the object store are
+	// the sandbox's own, their names containing "sandbox")
+	SandboxEnabled bool
+
+	// ChaosEnabled turns on fault injection in test environments (CHAOS_ENABLED, "true" to enable; never in production)
+	ChaosEnabled bool
+	// ChaosLatencyRate, ChaosErrorRate and ChaosDropRate are the shares (0 to 1) of requests that are delayed,
+	// fail with a simulated database error or are dropped (CHAOS_LATENCY_RATE, CHAOS_ERROR_RATE, CHAOS_DROP_RATE, default 0)
+	ChaosLatencyRate float64
+	ChaosErrorRate   float64
+	ChaosDropRate    float64
+	// ChaosLatency is the delay added to slowed-down requests (CHAOS_LATENCY, default "2s")
+	ChaosLatency time.Duration
+
+	// AnalyticsSampleRate is the share (0 to 1) of tracked analytics events kept (ANALYTICS_SAMPLE_RATE, default 1)
+	AnalyticsSampleRate float64
+	// AnalyticsDisabledTenants lists the tenants whose analytics events are discarded
+	// (ANALYTICS_DISABLED_TENANTS, comma-separated)
+	AnalyticsDisabledTenants []string
+	// AnalyticsSinkURL sends analytics events to an external collector instead of the database (ANALYTICS_SINK_URL)
+	AnalyticsSinkURL string
+	// AnalyticsFlushInterval is the time between two writes of buffered analytics events (ANALYTICS_FLUSH_INTERVAL, default "10s")
+	AnalyticsFlushInterval time.Duration
+
+	// ShadowMode mirrors or logs mutating requests before a cutover (SHADOW_MODE, "mirror" or "log", disabled when empty)
+	ShadowMode string
+	// ShadowTarget is the base URL of the deployment receiving mirrored requests (SHADOW_TARGET, required in "mirror" mode)
+	ShadowTarget string
+	// ShadowReceiver designates the deployment as the target of mirrored requests, whose changes are then stored
+	// without notifications, pushes or webhooks (SHADOW_RECEIVER, "true" to enable)
+	ShadowReceiver bool
+}
+
+// Supported storage backends.
+const (
+	BackendMongo    = "mongo"
+	BackendPostgres = "postgres"
+)
+
+// Supported object stores.
+const (
+	ObjectStoreDir    = "dir"
+	ObjectStoreGridFS = "gridfs"
+	ObjectStoreS3     = "s3"
+)
+
+// Deployment environments.
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvProduction  = "production"
+)
+
+// Load reads the configuration from the environment.
+// Values from a `.env` file in the working directory are loaded first when the file exists.
+func Load() (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("error loading .env file: %w", err)
+	}
+
+	cfg := &Config{
+		Port:         getEnv("PORT", "8080"),
+		MongoURI:     getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		DatabaseName: getEnv("MONGO_DB", "COMPLEJOS"),
+		JWTSecret:    os.Getenv("JWT_SECRET"),
+		Environment:  getEnv("APP_ENV", EnvProduction),
+
+		StorageBackend: getEnv("STORAGE_BACKEND", BackendMongo),
+		PostgresDSN:    os.Getenv("POSTGRES_DSN"),
+
+		IngestAPIKey: os.Getenv("INGEST_API_KEY"),
+
+		StripeWebhookSecret: os.Getenv("STRIPE_WEBHOOK_SECRET"),
+
+		FederationURL:    os.Getenv("FEDERATION_URL"),
+		FederationClub:   os.Getenv("FEDERATION_CLUB"),
+		FederationSecret: os.Getenv("FEDERATION_SECRET"),
+
+		AnalyticsSinkURL: os.Getenv("ANALYTICS_SINK_URL"),
+
+		ObjectStore:    getEnv("OBJECT_STORE", ObjectStoreDir),
+		ObjectStoreDir: getEnv("OBJECT_STORE_DIR", "data/objects"),
+		S3: objectstore.S3Options{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			Region:          getEnv("S3_REGION", "us-east-1"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		},
+
+		WeatherProviderURL: os.Getenv("WEATHER_PROVIDER_URL"),
+		WeatherAPIKey:      os.Getenv("WEATHER_API_KEY"),
+
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		MailFrom:     os.Getenv("MAIL_FROM"),
+
+		FCMProjectID:    os.Getenv("FCM_PROJECT_ID"),
+		VAPIDPrivateKey: os.Getenv("VAPID_PRIVATE_KEY"),
+		VAPIDSubject:    os.Getenv("VAPID_SUBJECT"),
+
+		ShadowMode:   os.Getenv("SHADOW_MODE"),
+		ShadowTarget: os.Getenv("SHADOW_TARGET"),
+	}
+
+	if cfg.JWTSecret == "" {
+		return nil, errors.New("JWT_SECRET is not set in the environment")
+	}
+
+	cfg.PublicAPIURL = getEnv("PUBLIC_API_URL", "http://localhost:"+cfg.Port)
+	cfg.PublicSiteURL = getEnv("PUBLIC_SITE_URL", "http://localhost:3000")
+	for key, value := range map[string]string{"PUBLIC_SITE_URL": cfg.PublicSiteURL, "PUBLIC_API_URL": cfg.PublicAPIURL} {
+		if parsed, err := url.Parse(value); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid %s %q", key, value)
+		}
+	}
+
+	location, err := time.LoadLocation(getEnv("TIMEZONE", "UTC"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TIMEZONE %q", os.Getenv("TIMEZONE"))
+	}
+	cfg.Location = location
+
+	if lifetime := os.Getenv("JWT_LIFETIME"); lifetime != "" {
+		if cfg.JWTLifetime, err = time.ParseDuration(lifetime); err != nil || cfg.JWTLifetime <= 0 {
+			return nil, fmt.Errorf("invalid JWT_LIFETIME %q", lifetime)
+		}
+	}
+
+	switch cfg.Environment {
+	case EnvDevelopment, EnvTest, EnvProduction:
+	default:
+		return nil, fmt.Errorf("unsupported APP_ENV %q", cfg.Environment)
+	}
+
+	switch cfg.StorageBackend {
+	case BackendMongo:
+	case BackendPostgres:
+		if cfg.PostgresDSN == "" {
+			return nil, errors.New("POSTGRES_DSN is required when STORAGE_BACKEND is postgres")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported STORAGE_BACKEND %q", cfg.StorageBackend)
+	}
+
+	switch cfg.ObjectStore {
+	case ObjectStoreDir:
+	case ObjectStoreGridFS:
+		if cfg.StorageBackend != BackendMongo {
+			return nil, errors.New("OBJECT_STORE gridfs requires STORAGE_BACKEND mongo")
+		}
+	case ObjectStoreS3:
+		if parsed, err := url.Parse(cfg.S3.Endpoint); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid S3_ENDPOINT %q", cfg.S3.Endpoint)
+		}
+		if cfg.S3.Bucket == "" || cfg.S3.AccessKeyID == "" || cfg.S3.SecretAccessKey == "" {
+			return nil, errors.New("S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required when OBJECT_STORE is s3")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported OBJECT_STORE %q", cfg.ObjectStore)
+	}
+
+	interval, err := time.ParseDuration(getEnv("FEDERATION_SYNC_INTERVAL", "15m"))
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid FEDERATION_SYNC_INTERVAL %q", os.Getenv("FEDERATION_SYNC_INTERVAL"))
+	}
+	cfg.FederationSyncInterval = interval
+
+	if cfg.HeavyConcurrency, err = getEnvInt("HEAVY_CONCURRENCY", 4); err != nil || cfg.HeavyConcurrency < 1 {
+		return nil, fmt.Errorf("invalid HEAVY_CONCURRENCY %q", os.Getenv("HEAVY_CONCURRENCY"))
+	}
+	if cfg.HeavyQueue, err = getEnvInt("HEAVY_QUEUE", 16); err != nil || cfg.HeavyQueue < 0 {
+		return nil, fmt.Errorf("invalid HEAVY_QUEUE %q", os.Getenv("HEAVY_QUEUE"))
+	}
+	if cfg.HeavyQueueTimeout, err = time.ParseDuration(getEnv("HEAVY_QUEUE_TIMEOUT", "5s")); err != nil || cfg.HeavyQueueTimeout <= 0 {
+		return nil, fmt.Errorf("invalid HEAVY_QUEUE_TIMEOUT %q", os.Getenv("HEAVY_QUEUE_TIMEOUT"))
+	}
+	if cfg.ReadTimeout, err = time.ParseDuration(getEnv("REQUEST_TIMEOUT_READ", "10s")); err != nil || cfg.ReadTimeout < 0 {
+		return nil, fmt.Errorf("invalid REQUEST_TIMEOUT_READ %q", os.Getenv("REQUEST_TIMEOUT_READ"))
+	}
+	if cfg.WriteTimeout, err = time.ParseDuration(getEnv("REQUEST_TIMEOUT_WRITE", "30s")); err != nil || cfg.WriteTimeout < 0 {
+		return nil, fmt.Errorf("invalid REQUEST_TIMEOUT_WRITE %q", os.Getenv("REQUEST_TIMEOUT_WRITE"))
+	}
+	if cfg.ExportTimeout, err = time.ParseDuration(getEnv("REQUEST_TIMEOUT_EXPORT", "5m")); err != nil || cfg.ExportTimeout < 0 {
+		return nil, fmt.Errorf("invalid REQUEST_TIMEOUT_EXPORT %q", os.Getenv("REQUEST_TIMEOUT_EXPORT"))
+	}
+
+	if cfg.SoftDeleteRetention, err = time.ParseDuration(getEnv("SOFT_DELETE_RETENTION", "720h")); err != nil || cfg.SoftDeleteRetention <= 0 {
+		return nil, fmt.Errorf("invalid SOFT_DELETE_RETENTION %q", os.Getenv("SOFT_DELETE_RETENTION"))
+	}
+	if cfg.OffboardingDelay, err = time.ParseDuration(getEnv("OFFBOARDING_DELAY", "72h")); err != nil || cfg.OffboardingDelay < 0 {
+		return nil, fmt.Errorf("invalid OFFBOARDING_DELAY %q", os.Getenv("OFFBOARDING_DELAY"))
+	}
+	for _, task := range strings.Split(os.Getenv("SCHEDULER_DISABLED_TASKS"), ",") {
+		if task = strings.TrimSpace(task); task != "" {
+			cfg.SchedulerDisabledTasks = append(cfg.SchedulerDisabledTasks, task)
+		}
+	}
+	if cfg.PublicStatsInterval, err = time.ParseDuration(getEnv("PUBLIC_STATS_INTERVAL", "10m")); err != nil || cfg.PublicStatsInterval <= 0 {
+		return nil, fmt.Errorf("invalid PUBLIC_STATS_INTERVAL %q", os.Getenv("PUBLIC_STATS_INTERVAL"))
+	}
+	if cfg.ChurnScoringInterval, err = time.ParseDuration(getEnv("CHURN_SCORING_INTERVAL", "24h")); err != nil || cfg.ChurnScoringInterval <= 0 {
+		return nil, fmt.Errorf("invalid CHURN_SCORING_INTERVAL %q", os.Getenv("CHURN_SCORING_INTERVAL"))
+	}
+	if cfg.ChallengeScoringInterval, err = time.ParseDuration(getEnv("CHALLENGE_SCORING_INTERVAL", "24h")); err != nil || cfg.ChallengeScoringInterval <= 0 {
+		return nil, fmt.Errorf("invalid CHALLENGE_SCORING_INTERVAL %q", os.Getenv("CHALLENGE_SCORING_INTERVAL"))
+	}
+	if cfg.LoanReminderInterval, err = time.ParseDuration(getEnv("LOAN_REMINDER_INTERVAL", "1h")); err != nil || cfg.LoanReminderInterval <= 0 {
+		return nil, fmt.Errorf("invalid LOAN_REMINDER_INTERVAL %q", os.Getenv("LOAN_REMINDER_INTERVAL"))
+	}
+	if cfg.LostFoundCleanupInterval, err = time.ParseDuration(getEnv("LOST_FOUND_CLEANUP_INTERVAL", "1h")); err != nil || cfg.LostFoundCleanupInterval <= 0 {
+		return nil, fmt.Errorf("invalid LOST_FOUND_CLEANUP_INTERVAL %q", os.Getenv("LOST_FOUND_CLEANUP_INTERVAL"))
+	}
+	if cfg.VolunteerReminderInterval, err = time.ParseDuration(getEnv("VOLUNTEER_REMINDER_INTERVAL", "1h")); err != nil || cfg.VolunteerReminderInterval <= 0 {
+		return nil, fmt.Errorf("invalid VOLUNTEER_REMINDER_INTERVAL %q", os.Getenv("VOLUNTEER_REMINDER_INTERVAL"))
+	}
+
+	if cfg.WeatherCacheTTL, err = time.ParseDuration(getEnv("WEATHER_CACHE_TTL", "3h")); err != nil || cfg.WeatherCacheTTL <= 0 {
+		return nil, fmt.Errorf("invalid WEATHER_CACHE_TTL %q", os.Getenv("WEATHER_CACHE_TTL"))
+	}
+	if cfg.WeatherWarningInterval, err = time.ParseDuration(getEnv("WEATHER_WARNING_INTERVAL", "1h")); err != nil || cfg.WeatherWarningInterval <= 0 {
+		return nil, fmt.Errorf("invalid WEATHER_WARNING_INTERVAL %q", os.Getenv("WEATHER_WARNING_INTERVAL"))
+	}
+
+	if cfg.SMTPPort, err = getEnvInt("SMTP_PORT", 587); err != nil || cfg.SMTPPort < 1 || cfg.SMTPPort > 65535 {
+		return nil, fmt.Errorf("invalid SMTP_PORT %q", os.Getenv("SMTP_PORT"))
+	}
+	if cfg.SMTPHost != "" {
+		if _, err := mail.ParseAddress(cfg.MailFrom); err != nil {
+			return nil, fmt.Errorf("invalid MAIL_FROM %q, required when SMTP_HOST is set", cfg.MailFrom)
+		}
+	}
+	if cfg.MailTemplates, err = mailer.LoadTemplates(os.Getenv("MAIL_TEMPLATES_DIR")); err != nil {
+		return nil, fmt.Errorf("invalid MAIL_TEMPLATES_DIR: %w", err)
+	}
+
+	if cfg.FCMProjectID != "" {
+		if cfg.FCMCredentials, err = os.ReadFile(os.Getenv("FCM_CREDENTIALS_FILE")); err != nil {
+			return nil, fmt.Errorf("invalid FCM_CREDENTIALS_FILE, required when FCM_PROJECT_ID is set: %w", err)
+		}
+	}
+	if cfg.VAPIDPrivateKey != "" && !strings.HasPrefix(cfg.VAPIDSubject, "mailto:") && !strings.HasPrefix(cfg.VAPIDSubject, "https://") {
+		return nil, fmt.Errorf("invalid VAPID_SUBJECT %q, a mailto: or https: URL required when VAPID_PRIVATE_KEY is set", cfg.VAPIDSubject)
+	}
+	if cfg.EventReminderInterval, err = time.ParseDuration(getEnv("EVENT_REMINDER_INTERVAL", "15m")); err != nil || cfg.EventReminderInterval <= 0 {
+		return nil, fmt.Errorf("invalid EVENT_REMINDER_INTERVAL %q", os.Getenv("EVENT_REMINDER_INTERVAL"))
+	}
+	if cfg.EventReminderBefore, err = time.ParseDuration(getEnv("EVENT_REMINDER_BEFORE", "2h")); err != nil || cfg.EventReminderBefore <= 0 {
+		return nil, fmt.Errorf("invalid EVENT_REMINDER_BEFORE %q", os.Getenv("EVENT_REMINDER_BEFORE"))
+	}
+
+	tags := markdown.Tags
+	if value := os.Getenv("MARKDOWN_ALLOWED_TAGS"); value != "" {
+		tags = strings.Split(value, ",")
+	}
+	if cfg.Markdown, err = markdown.NewPolicy(tags); err != nil {
+		return nil, fmt.Errorf("invalid MARKDOWN_ALLOWED_TAGS: %w", err)
+	}
+
+	words := map[string][]string{}
+	for _, loc := range locale.Locales {
+		if value := os.Getenv("CONTENT_FILTER_WORDS_" + strings.ToUpper(loc)); value != "" {
+			words[loc] = strings.Split(value, ",")
+		}
+	}
+	maxLinks, err := getEnvInt("CONTENT_FILTER_MAX_LINKS", moderation.DefaultMaxLinks)
+	if err != nil || maxLinks < -1 {
+		return nil, fmt.Errorf("invalid CONTENT_FILTER_MAX_LINKS %q", os.Getenv("CONTENT_FILTER_MAX_LINKS"))
+	}
+	cfg.ContentFilter = moderation.NewFilter(words, maxLinks)
+
+	cfg.IMCCategories = utils.IMCCategories
+	if value := os.Getenv("IMC_CATEGORIES"); value != "" {
+		cfg.IMCCategories = strings.Split(value, ",")
+		for i := range cfg.IMCCategories {
+			cfg.IMCCategories[i] = strings.TrimSpace(cfg.IMCCategories[i])
+		}
+	}
+	if len(cfg.IMCCategories) != len(utils.IMCCategories) || slices.Contains(cfg.IMCCategories, "") {
+		return nil, fmt.Errorf("invalid IMC_CATEGORIES %q, four non-empty comma-separated names required", os.Getenv("IMC_CATEGORIES"))
+	}
+
+	if cfg.EventPinDuration, err = time.ParseDuration(getEnv("EVENT_PIN_DURATION", "168h")); err != nil || cfg.EventPinDuration <= 0 {
+		return nil, fmt.Errorf("invalid EVENT_PIN_DURATION %q", os.Getenv("EVENT_PIN_DURATION"))
+	}
+
+	if cfg.GuestPasses, err = getEnvInt("GUEST_PASSES_PER_MONTH", 2); err != nil || cfg.GuestPasses < 0 {
+		return nil, fmt.Errorf("invalid GUEST_PASSES_PER_MONTH %q", os.Getenv("GUEST_PASSES_PER_MONTH"))
+	}
+
+	if cfg.EventStreamLimit, err = getEnvInt("EVENT_STREAM_LIMIT", 100); err != nil || cfg.EventStreamLimit < 1 {
+		return nil, fmt.Errorf("invalid EVENT_STREAM_LIMIT %q", os.Getenv("EVENT_STREAM_LIMIT"))
+	}
+
+	if cfg.ImageWorkers, err = getEnvInt("IMAGE_WORKERS", 2); err != nil || cfg.ImageWorkers < 1 {
+		return nil, fmt.Errorf("invalid IMAGE_WORKERS %q", os.Getenv("IMAGE_WORKERS"))
+	}
+	if cfg.ImageQueue, err = getEnvInt("IMAGE_QUEUE", 16); err != nil || cfg.ImageQueue < 0 {
+		return nil, fmt.Errorf("invalid IMAGE_QUEUE %q", os.Getenv("IMAGE_QUEUE"))
+	}
+
+	if cfg.ProfileCacheTTL, err = time.ParseDuration(getEnv("PROFILE_CACHE_TTL", "5s")); err != nil || cfg.ProfileCacheTTL < 0 {
+		return nil, fmt.Errorf("invalid PROFILE_CACHE_TTL %q", os.Getenv("PROFILE_CACHE_TTL"))
+	}
+	if cfg.ProfileCacheSize, err = getEnvInt("PROFILE_CACHE_SIZE", 1000); err != nil || cfg.ProfileCacheSize < 1 {
+		return nil, fmt.Errorf("invalid PROFILE_CACHE_SIZE %q", os.Getenv("PROFILE_CACHE_SIZE"))
+	}
+
+	if cfg.JobWorkers, err = getEnvInt("JOB_WORKERS", 4); err != nil || cfg.JobWorkers < 1 {
+		return nil, fmt.Errorf("invalid JOB_WORKERS %q", os.Getenv("JOB_WORKERS"))
+	}
+	if cfg.WebhookTimeout, err = time.ParseDuration(getEnv("WEBHOOK_TIMEOUT", "10s")); err != nil || cfg.WebhookTimeout <= 0 {
+		return nil, fmt.Errorf("invalid WEBHOOK_TIMEOUT %q", os.Getenv("WEBHOOK_TIMEOUT"))
+	}
+	if cfg.WebhookMaxAttempts, err = getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8); err != nil || cfg.WebhookMaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid WEBHOOK_MAX_ATTEMPTS %q", os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	}
+
+	cfg.JSONNaming = getEnv("JSON_FIELD_NAMING", "snake_case")
+	if cfg.JSONNaming != "snake_case" && cfg.JSONNaming != "declared" {
+		return nil, fmt.Errorf("invalid JSON_FIELD_NAMING %q", cfg.JSONNaming)
+	}
+	switch getEnv("JSON_NULLS", "keep") {
+	case "keep":
+	case "omit":
+		cfg.JSONOmitNull = true
+	default:
+		return nil, fmt.Errorf("invalid JSON_NULLS %q", os.Getenv("JSON_NULLS"))
+	}
+	switch getEnv("JSON_UNKNOWN_FIELDS", "reject") {
+	case "reject":
+		cfg.JSONStrict = true
+	case "ignore":
+	default:
+		return nil, fmt.Errorf("invalid JSON_UNKNOWN_FIELDS %q", os.Getenv("JSON_UNKNOWN_FIELDS"))
+	}
+
+	if cfg.AnalyticsSampleRate, err = strconv.ParseFloat(getEnv("ANALYTICS_SAMPLE_RATE", "1"), 64); err != nil || cfg.AnalyticsSampleRate < 0 || cfg.AnalyticsSampleRate > 1 {
+		return nil, fmt.Errorf("invalid ANALYTICS_SAMPLE_RATE %q", os.Getenv("ANALYTICS_SAMPLE_RATE"))
+	}
+	if cfg.AnalyticsFlushInterval, err = time.ParseDuration(getEnv("ANALYTICS_FLUSH_INTERVAL", "10s")); err != nil || cfg.AnalyticsFlushInterval <= 0 {
+		return nil, fmt.Errorf("invalid ANALYTICS_FLUSH_INTERVAL %q", os.Getenv("ANALYTICS_FLUSH_INTERVAL"))
+	}
+	for _, tenant := range strings.Split(os.Getenv("ANALYTICS_DISABLED_TENANTS"), ",") {
+		if tenant = strings.TrimSpace(tenant); tenant != "" {
+			cfg.AnalyticsDisabledTenants = append(cfg.AnalyticsDisabledTenants, tenant)
+		}
+	}
+
+	cfg.SandboxEnabled = os.Getenv("SANDBOX_ENABLED") == "true"
+	if cfg.SandboxEnabled {
+		if cfg.IsProduction() {
+			return nil, errors.New("SANDBOX_ENABLED cannot be set when APP_ENV is production")
+		}
+		if err := sandboxTenant(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.ChaosEnabled = os.Getenv("CHAOS_ENABLED") == "true"
+	if cfg.ChaosEnabled {
+		if cfg.IsProduction() {
+			return nil, errors.New("CHAOS_ENABLED cannot be set when APP_ENV is production")
+		}
+		rates := map[string]*float64{
+			"CHAOS_LATENCY_RATE": &cfg.ChaosLatencyRate,
+			"CHAOS_ERROR_RATE":   &cfg.ChaosErrorRate,
+			"CHAOS_DROP_RATE":    &cfg.ChaosDropRate,
+		}
+		for key, rate := range rates {
+			if *rate, err = strconv.ParseFloat(getEnv(key, "0"), 64); err != nil || *rate < 0 || *rate > 1 {
+				return nil, fmt.Errorf("invalid %s %q", key, os.Getenv(key))
+			}
+		}
+		if cfg.ChaosLatency, err = time.ParseDuration(getEnv("CHAOS_LATENCY", "2s")); err != nil || cfg.ChaosLatency < 0 {
+			return nil, fmt.Errorf("invalid CHAOS_LATENCY %q", os.Getenv("CHAOS_LATENCY"))
+		}
+	}
+
+	cfg.ShadowReceiver = os.Getenv("SHADOW_RECEIVER") == "true"
+	switch cfg.ShadowMode {
+	case "", "log":
+	case "mirror":
+		if cfg.ShadowTarget == "" {
+			return nil, errors.New("SHADOW_TARGET is required when SHADOW_MODE is mirror")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported SHADOW_MODE %q", cfg.ShadowMode)
+	}
+
+	if cfg.FederationURL != "" && (cfg.FederationClub == "" || cfg.FederationSecret == "") {
+		return nil, errors.New("FEDERATION_CLUB and FEDERATION_SECRET are required when FEDERATION_URL is set")
+	}
+
+	return cfg, nil
+}
+
+// sandboxTenant checks that the data a sandbox reset removes belongs to the sandbox: the database and the object
+// store (the directory or the bucket; GridFS lives in the database) must be named after it, so a sandbox
+// configuration pointed at the data of another deployment is refused before anything is removed.
+func sandboxTenant(cfg *Config) error {
+	database := cfg.DatabaseName
+	if cfg.StorageBackend == BackendPostgres {
+		database = postgresDatabase(cfg.PostgresDSN)
+	}
+	if !strings.Contains(strings.ToLower(database), "sandbox") {
+		return fmt.Errorf("SANDBOX_ENABLED requires a sandbox database, whose name contains \"sandbox\" (got %q)", database)
+	}
+	store := ""
+	switch cfg.ObjectStore {
+	case ObjectStoreDir:
+		store = cfg.ObjectStoreDir
+	case ObjectStoreS3:
+		store = cfg.S3.Bucket
+	case ObjectStoreGridFS:
+		return nil
+	}
+	if !strings.Contains(strings.ToLower(store), "sandbox") {
+		return fmt.Errorf("SANDBOX_ENABLED requires a sandbox object store, whose directory or bucket contains \"sandbox\" (got %q)", store)
+	}
+	return nil
+}
+
+// postgresDatabase returns the database name of a PostgreSQL connection string, in the URL form
+// ("postgres://user@host/name") or the keyword form ("host=... dbname=name").
+func postgresDatabase(dsn string) string {
+	if parsed, err := url.Parse(dsn); err == nil && (parsed.Scheme == "postgres" || parsed.Scheme == "postgresql") {
+		return strings.TrimPrefix(parsed.Path, "/")
+	}
+	for _, field := range strings.Fields(dsn) {
+		if name, ok := strings.CutPrefix(field, "dbname="); ok {
+			return strings.Trim(name, "'")
+		}
+	}
+	return ""
+}
+
+// IsProduction reports whether the application runs in production.
+func (c *Config) IsProduction() bool {
+	return c.Environment == EnvProduction
+}
+
+// getEnv returns the value of the environment variable or the fallback when it is unset or empty.
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvInt returns the integer value of the environment variable or the fallback when it is unset or empty.
+func getEnvInt(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,103 @@
+// config_test.go
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadSandbox(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "production",
+			env:     map[string]string{"APP_ENV": EnvProduction, "MONGO_DB": "COMPLEJOS_SANDBOX", "OBJECT_STORE_DIR": "data/sandbox"},
+			wantErr: "production",
+		},
+		{
+			name:    "shared database",
+			env:     map[string]string{"APP_ENV": EnvDevelopment, "MONGO_DB": "COMPLEJOS", "OBJECT_STORE_DIR": "data/sandbox"},
+			wantErr: "sandbox database",
+		},
+		{
+			name:    "shared object store",
+			env:     map[string]string{"APP_ENV": EnvDevelopment, "MONGO_DB": "COMPLEJOS_SANDBOX", "OBJECT_STORE_DIR": "data/objects"},
+			wantErr: "sandbox object store",
+		},
+		{
+			name: "postgres keyword form",
+			env: map[string]string{"APP_ENV": EnvDevelopment, "STORAGE_BACKEND": BackendPostgres,
+				"POSTGRES_DSN": "host=db dbname=complejos sslmode=disable", "OBJECT_STORE_DIR": "data/sandbox"},
+			wantErr: "sandbox database",
+		},
+		{
+			name: "postgres url form",
+			env: map[string]string{"APP_ENV": EnvDevelopment, "STORAGE_BACKEND": BackendPostgres,
+				"POSTGRES_DSN": "postgres://app@db/complejos_sandbox?sslmode=disable", "OBJECT_STORE_DIR": "data/sandbox"},
+		},
+		{
+			name: "gridfs in the sandbox database",
+			env:  map[string]string{"APP_ENV": EnvTest, "MONGO_DB": "COMPLEJOS_SANDBOX", "OBJECT_STORE": ObjectStoreGridFS},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", "test-secret")
+			t.Setenv("SANDBOX_ENABLED", "true")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Load: %v", err)
+				}
+				if !cfg.SandboxEnabled {
+					t.Error("SandboxEnabled = false, want true")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadChaos(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		wantErr bool
+	}{
+		{"production", EnvProduction, true},
+		{"development", EnvDevelopment, false},
+		{"test", EnvTest, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", "test-secret")
+			t.Setenv("APP_ENV", tt.env)
+			t.Setenv("CHAOS_ENABLED", "true")
+			t.Setenv("CHAOS_ERROR_RATE", "0.5")
+
+			cfg, err := Load()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "CHAOS_ENABLED") {
+					t.Fatalf("Load error = %v, want one about CHAOS_ENABLED", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if !cfg.ChaosEnabled || cfg.ChaosErrorRate != 0.5 {
+				t.Errorf("ChaosEnabled = %v, ChaosErrorRate = %v, want true and 0.5", cfg.ChaosEnabled, cfg.ChaosErrorRate)
+			}
+		})
+	}
+}
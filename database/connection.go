@@ -13,21 +13,31 @@ import (
 
 var Client *mongo.Client
 
-// ConnectDB establishes a connection to the MongoDB server
-func ConnectDB(uri string) *mongo.Client {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// Connect establishes and verifies a connection to the MongoDB server
+func Connect(ctx context.Context, uri string) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	clientOptions := options.Client().ApplyURI(uri)
 
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
-		log.Fatalf("Error connecting to MongoDB: %v", err)
+		return nil, fmt.Errorf("error connecting to MongoDB: %w", err)
 	}
 
 	err = client.Ping(ctx, nil)
 	if err != nil {
-		log.Fatalf("Error verifying the MongoDB connection: %v", err)
+		return nil, fmt.Errorf("error verifying the MongoDB connection: %w", err)
+	}
+
+	return client, nil
+}
+
+// ConnectDB establishes a connection to the MongoDB server
+func ConnectDB(uri string) *mongo.Client {
+	client, err := Connect(context.Background(), uri)
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	fmt.Println("Successfully connected to MongoDB")
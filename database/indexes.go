@@ -0,0 +1,395 @@
+// indexes.go
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index declares an index of a MongoDB collection. Its name identifies it, so it must be set in Model.Options.
+type Index struct {
+	Collection string
+	Model      mongo.IndexModel
+}
+
+// Indexes lists every index the MongoDB repositories rely on.
+var Indexes = []Index{
+	// Usernames are unique, including deleted Complejos so that a restore never clashes.
+	{Collection: "complejo", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "username", Value: 1}},
+		Options: options.Index().SetName("complejo_username_unique").SetUnique(true),
+	}},
+	// Retention reports group Complejos by sign-up week.
+	{Collection: "complejo", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetName("complejo_created_at"),
+	}},
+	// Listings and search results are sorted by date.
+	{Collection: "event", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("event_date"),
+	}},
+	// Withdrawing a deleted Complejo looks up the events it answered.
+	{Collection: "event", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "rsvps.complejo_id", Value: 1}},
+		Options: options.Index().SetName("event_rsvps_complejo"),
+	}},
+	// Counting the guest passes of a member looks up the events it brought guests to.
+	{Collection: "event", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "guests.host_id", Value: 1}},
+		Options: options.Index().SetName("event_guests_host"),
+	}},
+	// Weighted full-text index used by TextSearch.
+	{Collection: "event", Model: mongo.IndexModel{
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}, {Key: "location", Value: "text"}},
+		Options: options.Index().
+			SetName("event_text").
+			SetWeights(bson.D{{Key: "title", Value: 10}, {Key: "location", Value: 3}, {Key: "description", Value: 1}}).
+			SetDefaultLanguage("none"),
+	}},
+	// The feed of the public site lists the featured events by date.
+	{Collection: "event", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "featured", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetName("event_featured").SetSparse(true),
+	}},
+	// The severe-weather check looks for the outdoor events starting soon.
+	{Collection: "event", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "outdoor", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetName("event_outdoor").SetSparse(true),
+	}},
+	// Ingestion matches events by the producer's ID.
+	{Collection: "event", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "external_id", Value: 1}},
+		Options: options.Index().SetName("event_external_id").SetSparse(true),
+	}},
+	// The events of a group are listed by date.
+	{Collection: "event", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "group_id", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetName("event_group").SetSparse(true),
+	}},
+	{Collection: "subscription_events", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "occurred_at", Value: 1}},
+		Options: options.Index().SetName("subscription_events_event"),
+	}},
+	// Churn scoring counts the recent subscription changes of each member.
+	{Collection: "subscription_events", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "username", Value: 1}, {Key: "occurred_at", Value: 1}},
+		Options: options.Index().SetName("subscription_events_username"),
+	}},
+	{Collection: "outbox", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "dispatched_at", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		Options: options.Index().SetName("outbox_pending"),
+	}},
+	{Collection: "nearby_events", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "date", Value: 1}},
+		Options: options.Index().SetName("nearby_events_date"),
+	}},
+	{Collection: "analytics_events", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant", Value: 1}, {Key: "occurred_at", Value: 1}},
+		Options: options.Index().SetName("analytics_events_tenant_occurred_at"),
+	}},
+	// Finance summaries select payments by date.
+	{Collection: "payments", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "paid_at", Value: 1}},
+		Options: options.Index().SetName("payments_paid_at"),
+	}},
+	{Collection: "expenses", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "incurred_at", Value: 1}},
+		Options: options.Index().SetName("expenses_event"),
+	}},
+	{Collection: "expenses", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "incurred_at", Value: 1}},
+		Options: options.Index().SetName("expenses_incurred_at"),
+	}},
+	{Collection: "inventory", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetName("inventory_name"),
+	}},
+	// Availability counts the open loans of each item, and the reminder job looks for overdue ones.
+	{Collection: "loans", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "returned_at", Value: 1}, {Key: "due_at", Value: 1}},
+		Options: options.Index().SetName("loans_open"),
+	}},
+	{Collection: "loans", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "item_id", Value: 1}, {Key: "lent_at", Value: -1}},
+		Options: options.Index().SetName("loans_item"),
+	}},
+	{Collection: "loans", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "complejo_id", Value: 1}, {Key: "lent_at", Value: -1}},
+		Options: options.Index().SetName("loans_complejo"),
+	}},
+	// The lost-and-found board lists the open posts, newest first, and the cleanup job removes the expired ones.
+	{Collection: "lost_found", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("lost_found_open"),
+	}},
+	{Collection: "lost_found", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("lost_found_expires_at"),
+	}},
+	{Collection: "lost_found_claims", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "item_id", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetName("lost_found_claims_item"),
+	}},
+	// Volunteer shifts are listed by event, the reminder job looks for the ones starting soon,
+	// and volunteer hours are tallied by Complejo.
+	{Collection: "volunteer_shifts", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "starts_at", Value: 1}},
+		Options: options.Index().SetName("volunteer_shifts_event"),
+	}},
+	{Collection: "volunteer_shifts", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "starts_at", Value: 1}},
+		Options: options.Index().SetName("volunteer_shifts_starts_at"),
+	}},
+	{Collection: "volunteer_shifts", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "volunteers.complejo_id", Value: 1}},
+		Options: options.Index().SetName("volunteer_shifts_complejo"),
+	}},
+	// Albums are listed by event, oldest photo first.
+	{Collection: "event_photos", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetName("event_photos_event"),
+	}},
+	// Invitation links are looked up by the hash of their token.
+	{Collection: "guest_invitations", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "token_hash", Value: 1}},
+		Options: options.Index().SetName("guest_invitations_token_hash").SetUnique(true),
+	}},
+	// Admins review the held content oldest first.
+	{Collection: "content_holds", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetName("content_holds_status"),
+	}},
+	// Admins review the reported content oldest first; a reporter has one pending report of the same content, and
+	// erasures remove the reports of a Complejo.
+	{Collection: "content_reports", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetName("content_reports_status"),
+	}},
+	{Collection: "content_reports", Model: mongo.IndexModel{
+		Keys: bson.D{{Key: "reporter_id", Value: 1}, {Key: "kind", Value: 1}, {Key: "target_id", Value: 1}},
+		Options: options.Index().SetName("content_reports_pending_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": "pending"}),
+	}},
+	// Push notifications look devices up by token when registering them or pruning invalid ones, and by Complejo
+	// when delivering.
+	{Collection: "devices", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "token", Value: 1}},
+		Options: options.Index().SetName("devices_token_unique").SetUnique(true),
+	}},
+	{Collection: "devices", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "complejo_id", Value: 1}},
+		Options: options.Index().SetName("devices_complejo"),
+	}},
+	{Collection: "announcements", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("announcements_created_at"),
+	}},
+	// Each version of a document has its own number, and versions are listed latest first.
+	{Collection: "content_versions", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "document_type", Value: 1}, {Key: "document_id", Value: 1}, {Key: "version", Value: -1}},
+		Options: options.Index().SetName("content_versions_document").SetUnique(true),
+	}},
+	// The reminder job looks for the events starting soon whose participants were not reminded yet.
+	{Collection: "event", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "reminded_at", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetName("event_reminded_at"),
+	}},
+	{Collection: "request_journal", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "occurred_at", Value: -1}},
+		Options: options.Index().SetName("request_journal_occurred_at"),
+	}},
+	// The audit log is listed most recent first, of every action or of one.
+	{Collection: "audit_log", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "occurred_at", Value: -1}},
+		Options: options.Index().SetName("audit_log_occurred_at"),
+	}},
+	{Collection: "audit_log", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "action", Value: 1}, {Key: "occurred_at", Value: -1}},
+		Options: options.Index().SetName("audit_log_action"),
+	}},
+	// The lift history of a Complejo is listed by date, of every lift or of one.
+	{Collection: "lift_entries", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "complejo_id", Value: 1}, {Key: "lift", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetName("lift_entries_complejo"),
+	}},
+	// The workouts of a Complejo are listed newest first, and summarized by week.
+	{Collection: "workouts", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "complejo_id", Value: 1}, {Key: "date", Value: -1}},
+		Options: options.Index().SetName("workouts_complejo"),
+	}},
+	// Exercise names are unique, and the library is listed by name.
+	{Collection: "exercises", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetName("exercises_name_unique").SetUnique(true),
+	}},
+	// A Complejo logs its nutrition once a day, and its logs are listed by day.
+	{Collection: "nutrition_logs", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "complejo_id", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetName("nutrition_logs_day_unique").SetUnique(true),
+	}},
+	// Challenges are listed by start, and scored while they are not finally scored.
+	{Collection: "challenges", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "starts_at", Value: -1}},
+		Options: options.Index().SetName("challenges_starts_at"),
+	}},
+	// A Complejo joins a challenge once, and its participants are ranked by score.
+	{Collection: "challenge_participants", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "challenge_id", Value: 1}, {Key: "complejo_id", Value: 1}},
+		Options: options.Index().SetName("challenge_participants_unique").SetUnique(true),
+	}},
+	{Collection: "challenge_participants", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "challenge_id", Value: 1}, {Key: "score", Value: -1}},
+		Options: options.Index().SetName("challenge_participants_score"),
+	}},
+	// Erasures remove the participations of a Complejo.
+	{Collection: "challenge_participants", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "complejo_id", Value: 1}},
+		Options: options.Index().SetName("challenge_participants_complejo"),
+	}},
+	// A Complejo follows another once, and the Complejos it follows are listed, latest first.
+	{Collection: "follows", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "follower_id", Value: 1}, {Key: "followee_id", Value: 1}},
+		Options: options.Index().SetName("follows_unique").SetUnique(true),
+	}},
+	// The followers of a Complejo are listed, latest first.
+	{Collection: "follows", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "followee_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("follows_followee"),
+	}},
+	// A Complejo blocks another once, and the Complejos it blocks are listed, latest first.
+	{Collection: "blocks", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "blocker_id", Value: 1}, {Key: "blocked_id", Value: 1}},
+		Options: options.Index().SetName("blocks_unique").SetUnique(true),
+	}},
+	// Profiles are hidden from the Complejos their owners block.
+	{Collection: "blocks", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "blocked_id", Value: 1}},
+		Options: options.Index().SetName("blocks_blocked"),
+	}},
+	// Groups are listed by name.
+	{Collection: "groups", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetName("groups_name"),
+	}},
+	// A Complejo is a member of a group once, with a single role.
+	{Collection: "group_memberships", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "group_id", Value: 1}, {Key: "complejo_id", Value: 1}},
+		Options: options.Index().SetName("group_memberships_unique").SetUnique(true),
+	}},
+	// The groups of a Complejo are listed, and erasures remove its memberships.
+	{Collection: "group_memberships", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "complejo_id", Value: 1}},
+		Options: options.Index().SetName("group_memberships_complejo"),
+	}},
+	// The feed lists the items of the followed Complejos and of the joined groups, latest first.
+	{Collection: "feed_items", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "complejo_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("feed_items_complejo"),
+	}},
+	{Collection: "feed_items", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "group_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("feed_items_group").SetSparse(true),
+	}},
+	// Unsubscriptions and deleted events remove their items.
+	{Collection: "feed_items", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}},
+		Options: options.Index().SetName("feed_items_event").SetSparse(true),
+	}},
+	// The public homepage totals the lift records of the month.
+	{Collection: "personal_records", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "achieved_at", Value: 1}},
+		Options: options.Index().SetName("personal_records_achieved_at"),
+	}},
+	// Job workers poll for the due pending jobs.
+	{Collection: "jobs", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "run_at", Value: 1}},
+		Options: options.Index().SetName("jobs_pending"),
+	}},
+	// Webhooks are looked up by topic for each domain event; their delivery worker polls for the due pending
+	// deliveries, and the log lists the deliveries of a webhook newest first.
+	{Collection: "webhooks", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "topics", Value: 1}},
+		Options: options.Index().SetName("webhooks_topics"),
+	}},
+	{Collection: "webhook_deliveries", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		Options: options.Index().SetName("webhook_deliveries_pending"),
+	}},
+	{Collection: "webhook_deliveries", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("webhook_deliveries_webhook"),
+	}},
+	// Service accounts have unique names; their tokens are looked up by hash on every request, and listed and
+	// expired by account.
+	{Collection: "service_accounts", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetName("service_accounts_name").SetUnique(true),
+	}},
+	{Collection: "service_tokens", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "hash", Value: 1}},
+		Options: options.Index().SetName("service_tokens_hash").SetUnique(true),
+	}},
+	{Collection: "service_tokens", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "account_id", Value: 1}, {Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("service_tokens_account"),
+	}},
+	{Collection: "data_exports", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "complejo_id", Value: 1}, {Key: "requested_at", Value: -1}},
+		Options: options.Index().SetName("data_exports_complejo"),
+	}},
+	{Collection: "data_exports", Model: mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("data_exports_expires"),
+	}},
+}
+
+// EnsureIndexes creates the declared Indexes missing from db and logs each one it builds.
+// Existing indexes are left untouched, so it is safe to call on every startup.
+func EnsureIndexes(ctx context.Context, db *mongo.Database, logger *slog.Logger) error {
+	existing := map[string]map[string]bool{}
+
+	for _, index := range Indexes {
+		name := *index.Model.Options.Name
+
+		names, ok := existing[index.Collection]
+		if !ok {
+			var err error
+			names, err = indexNames(ctx, db.Collection(index.Collection))
+			if err != nil {
+				return fmt.Errorf("error listing the indexes of %s: %w", index.Collection, err)
+			}
+			existing[index.Collection] = names
+		}
+		if names[name] {
+			continue
+		}
+
+		if _, err := db.Collection(index.Collection).Indexes().CreateOne(ctx, index.Model); err != nil {
+			return fmt.Errorf("error creating index %s on %s: %w", name, index.Collection, err)
+		}
+		names[name] = true
+		logger.Info("index created", "collection", index.Collection, "index", name)
+	}
+
+	return nil
+}
+
+// indexNames returns the names of the indexes of the collection.
+func indexNames(ctx context.Context, collection *mongo.Collection) (map[string]bool, error) {
+	specs, err := collection.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		names[spec.Name] = true
+	}
+	return names, nil
+}
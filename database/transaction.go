@@ -0,0 +1,31 @@
+// transaction.go
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WithTransaction runs fn inside a multi-document transaction of the client, committing when fn returns nil
+// and aborting otherwise. Operations in fn must use the context it receives to take part in the transaction.
+// Calls nested in an existing transaction reuse it, and transient errors are retried by the driver.
+//
+// Transactions require a replica set or a sharded cluster; use repository.Transactor (mongodb.NewTransactor)
+// in the services, which falls back to running fn directly on a standalone server.
+func WithTransaction(ctx context.Context, client *mongo.Client, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
@@ -0,0 +1,171 @@
+// csv.go
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// csvFlushRows is the number of rows written between two flushes of a CSV export, so the download streams.
+const csvFlushRows = 100
+
+// Column is a column of a CSV export of T: its name, in the header and the field selection, and its value in a row.
+type Column[T any] struct {
+	Name  string
+	Value func(T) string
+}
+
+// MemberColumns are the columns of the CSV export of the members, in their default order. Passwords and photos
+// are never exported.
+var MemberColumns = []Column[models.Complejo]{
+	{"id", func(c models.Complejo) string { return c.ID }},
+	{"username", func(c models.Complejo) string { return c.Username }},
+	{"email", func(c models.Complejo) string { return c.Email }},
+	{"role", func(c models.Complejo) string { return c.Role }},
+	{"gender", func(c models.Complejo) string { return c.Gender }},
+	{"weight_kg", func(c models.Complejo) string { return csvFloat(c.Weight) }},
+	{"height_m", func(c models.Complejo) string { return csvFloat(c.Height) }},
+	{"imc", func(c models.Complejo) string { return csvIMC(c.IMC, false) }},
+	{"imc_category", func(c models.Complejo) string { return csvIMC(c.IMC, true) }},
+	{"bench_kg", func(c models.Complejo) string { return csvFloat(c.Bench) }},
+	{"squat_kg", func(c models.Complejo) string { return csvFloat(c.Squad) }},
+	{"deadlift_kg", func(c models.Complejo) string { return csvFloat(c.DL) }},
+	{"locale", func(c models.Complejo) string { return c.Locale }},
+	{"units", func(c models.Complejo) string { return c.Units }},
+	{"photo_consent", func(c models.Complejo) string { return c.PhotoConsentSetting() }},
+	{"created_at", func(c models.Complejo) string { return csvTime(c.CreatedAt) }},
+}
+
+// EventColumns are the columns of the CSV export of the events, in their default order.
+var EventColumns = []Column[models.Event]{
+	{"id", func(e models.Event) string { return e.ID }},
+	{"title", func(e models.Event) string { return e.Title }},
+	{"date", func(e models.Event) string { return csvTime(&e.Date) }},
+	{"location", func(e models.Event) string { return e.Location }},
+	{"capacity", func(e models.Event) string { return strconv.Itoa(e.Capacity) }},
+	{"level", func(e models.Event) string { return e.Level }},
+	{"intensity", func(e models.Event) string { return e.Intensity }},
+	{"outdoor", func(e models.Event) string { return strconv.FormatBool(e.Outdoor) }},
+	{"featured", func(e models.Event) string { return strconv.FormatBool(e.Featured) }},
+	{"created_by", func(e models.Event) string { return e.CreatedBy }},
+	{"going", func(e models.Event) string { return strconv.Itoa(e.RSVPCounts.Going) }},
+	{"maybe", func(e models.Event) string { return strconv.Itoa(e.RSVPCounts.Maybe) }},
+	{"declined", func(e models.Event) string { return strconv.Itoa(e.RSVPCounts.Declined) }},
+	{"guests", func(e models.Event) string { return strconv.Itoa(e.RSVPCounts.Guests) }},
+	{"likes", func(e models.Event) string { return strconv.Itoa(e.LikeCount) }},
+}
+
+// SelectColumns returns the columns named in the comma-separated list, in its order, or every column when it is
+// empty. The names matching no column are returned instead, with no columns.
+func SelectColumns[T any](columns []Column[T], fields string) ([]Column[T], []string) {
+	if strings.TrimSpace(fields) == "" {
+		return columns, nil
+	}
+	byName := make(map[string]Column[T], len(columns))
+	for _, column := range columns {
+		byName[column.Name] = column
+	}
+
+	var selected []Column[T]
+	var unknown []string
+	for _, name := range strings.Split(fields, ",") {
+		name = strings.TrimSpace(name)
+		column, ok := byName[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		selected = append(selected, column)
+	}
+	if unknown != nil {
+		return nil, unknown
+	}
+	return selected, nil
+}
+
+// ColumnNames returns the names of the columns, in their order.
+func ColumnNames[T any](columns []Column[T]) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// WriteMembersCSV writes the CSV export of the Complejos with the columns, in sign-up order like the Document
+// (unknown sign-up times first).
+func WriteMembersCSV(w io.Writer, complejos []models.Complejo, columns []Column[models.Complejo]) error {
+	sort.SliceStable(complejos, func(i, j int) bool {
+		a, b := complejos[i].CreatedAt, complejos[j].CreatedAt
+		return a == nil && b != nil || a != nil && b != nil && a.Before(*b)
+	})
+	return writeCSV(w, complejos, columns)
+}
+
+// WriteEventsCSV writes the CSV export of the Events with the columns, oldest first like the Document.
+func WriteEventsCSV(w io.Writer, events []models.Event, columns []Column[models.Event]) error {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+	return writeCSV(w, events, columns)
+}
+
+// writeCSV writes the header and a row per value, flushing the rows written every csvFlushRows rows when w is
+// an http.Flusher.
+func writeCSV[T any](w io.Writer, rows []T, columns []Column[T]) error {
+	flusher, _ := w.(http.Flusher)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ColumnNames(columns)); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for i, row := range rows {
+		for j, column := range columns {
+			record[j] = column.Value(row)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+		if (i+1)%csvFlushRows == 0 {
+			cw.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvFloat formats a number of a CSV export, 0 for unknown values.
+func csvFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// csvIMC formats the value or the category of an IMC of a CSV export, empty when unknown.
+func csvIMC(imc *models.IMC, category bool) string {
+	switch {
+	case imc == nil:
+		return ""
+	case category:
+		return imc.Category
+	default:
+		return csvFloat(imc.Value)
+	}
+}
+
+// csvTime formats a time of a CSV export in RFC 3339 (UTC), empty when unknown.
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
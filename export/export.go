@@ -0,0 +1,158 @@
+// export.go
+package export
+
+import (
+	_ "embed"
+	"sort"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// SchemaVersion is the version of the export format described by Schema. It changes only when a field is
+// removed or changes meaning; new optional fields keep the version.
+const SchemaVersion = 1
+
+// Schema is the JSON Schema (draft 2020-12) of the Document.
+//
+//go:embed schema.json
+var Schema []byte
+
+// Document is the full export of the data of the club: its members, events and lift results.
+type Document struct {
+	SchemaVersion int       `json:"schema_version"` // Version of the format (SchemaVersion)
+	Club          string    `json:"club"`           // Name of the club (empty when not configured)
+	ExportedAt    time.Time `json:"exported_at"`    // When the export was taken
+	Members       []Member  `json:"members"`        // Members, in sign-up order (unknown sign-up times first)
+	Events        []Event   `json:"events"`         // Events, oldest first
+	Results       []Result  `json:"results"`        // Lift records of the members
+}
+
+// Member is a member of the club. Passwords are never exported.
+type Member struct {
+	ID        string     `json:"id"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email,omitempty"`
+	Role      string     `json:"role"`             // "user" or "admin"
+	Gender    string     `json:"gender"`           // "male", "female" or "other"
+	Weight    float64    `json:"weight_kg"`        // 0 when unknown
+	Height    float64    `json:"height_m"`         // 0 when unknown
+	Locale    string     `json:"locale,omitempty"` // "en" or "es"
+	Units     string     `json:"units,omitempty"`  // "metric" or "imperial"
+	Photo     string     `json:"photo,omitempty"`  // Base64-encoded profile photo
+	CreatedAt *time.Time `json:"created_at"`       // Null for members who signed up before sign-up times were recorded
+}
+
+// Event is an event of the club with the answers of the members and their guests.
+type Event struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"` // Markdown
+	Date        time.Time `json:"date"`
+	Location    string    `json:"location"`
+	Image       string    `json:"image,omitempty"` // URL
+	Capacity    int       `json:"capacity"`        // 0 for no limit
+	Level       string    `json:"level,omitempty"`
+	Intensity   string    `json:"intensity,omitempty"`
+	Outdoor     bool      `json:"outdoor"`
+	CreatedBy   string    `json:"created_by,omitempty"` // Member ID
+	RSVPs       []RSVP    `json:"rsvps"`
+	Guests      []Guest   `json:"guests"`
+}
+
+// RSVP is the answer of a member to an event.
+type RSVP struct {
+	MemberID    string    `json:"member_id"`
+	Username    string    `json:"username"`
+	Status      string    `json:"status"` // "going", "maybe" or "declined"
+	RespondedAt time.Time `json:"responded_at"`
+}
+
+// Guest is a guest brought to an event by a member.
+type Guest struct {
+	Name         string    `json:"name"`
+	HostID       string    `json:"host_id"` // Member ID
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+// Result is the record of a member on a lift.
+type Result struct {
+	MemberID string  `json:"member_id"`
+	Username string  `json:"username"`
+	Lift     string  `json:"lift"` // "bench", "squat" or "deadlift"
+	Kg       float64 `json:"kg"`
+}
+
+// Build assembles the Document of the club from its Complejos and Events. Lifts without a record are left out
+// of the results.
+func Build(club string, at time.Time, complejos []models.Complejo, events []models.Event) *Document {
+	doc := &Document{
+		SchemaVersion: SchemaVersion,
+		Club:          club,
+		ExportedAt:    at,
+		Members:       make([]Member, 0, len(complejos)),
+		Events:        make([]Event, 0, len(events)),
+		Results:       []Result{},
+	}
+
+	for _, c := range complejos {
+		doc.Members = append(doc.Members, Member{
+			ID:        c.ID,
+			Username:  c.Username,
+			Email:     c.Email,
+			Role:      c.Role,
+			Gender:    c.Gender,
+			Weight:    c.Weight,
+			Height:    c.Height,
+			Locale:    c.Locale,
+			Units:     c.Units,
+			Photo:     c.Photo,
+			CreatedAt: c.CreatedAt,
+		})
+
+		for _, lift := range []struct {
+			name string
+			kg   float64
+		}{{"bench", c.Bench}, {"squat", c.Squad}, {"deadlift", c.DL}} {
+			if lift.kg > 0 {
+				doc.Results = append(doc.Results, Result{MemberID: c.ID, Username: c.Username, Lift: lift.name, Kg: lift.kg})
+			}
+		}
+	}
+
+	for _, e := range events {
+		event := Event{
+			ID:          e.ID,
+			Title:       e.Title,
+			Description: e.Description,
+			Date:        e.Date,
+			Location:    e.Location,
+			Capacity:    e.Capacity,
+			Level:       e.Level,
+			Intensity:   e.Intensity,
+			Outdoor:     e.Outdoor,
+			CreatedBy:   e.CreatedBy,
+			RSVPs:       make([]RSVP, 0, len(e.RSVPs)),
+			Guests:      make([]Guest, 0, len(e.Guests)),
+		}
+		if e.Image != nil {
+			event.Image = *e.Image
+		}
+		for _, r := range e.RSVPs {
+			event.RSVPs = append(event.RSVPs, RSVP{MemberID: r.ComplejoID, Username: r.Username, Status: r.Status, RespondedAt: r.RespondedAt})
+		}
+		for _, g := range e.Guests {
+			event.Guests = append(event.Guests, Guest{Name: g.Name, HostID: g.HostID, RegisteredAt: g.CreatedAt})
+		}
+		doc.Events = append(doc.Events, event)
+	}
+
+	sort.SliceStable(doc.Members, func(i, j int) bool {
+		a, b := doc.Members[i].CreatedAt, doc.Members[j].CreatedAt
+		return a == nil && b != nil || a != nil && b != nil && a.Before(*b)
+	})
+	sort.SliceStable(doc.Events, func(i, j int) bool {
+		return doc.Events[i].Date.Before(doc.Events[j].Date)
+	})
+	return doc
+}
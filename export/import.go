@@ -0,0 +1,207 @@
+// import.go
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// Field is a column of a CSV import of T: the JSON name of the field it sets, under which the payloads of the
+// API validate it, and how a cell sets it. Empty cells leave the field unset.
+type Field[T any] struct {
+	JSON  string
+	Parse func(*T, string) error
+}
+
+// MemberFields are the columns of a CSV import of members, named like the columns of their export, plus the
+// password. Photos are never imported.
+var MemberFields = map[string]Field[models.Complejo]{
+	"username":      {"username", text(func(c *models.Complejo) *string { return &c.Username })},
+	"password":      {"password", text(func(c *models.Complejo) *string { return &c.Password })},
+	"email":         {"email", text(func(c *models.Complejo) *string { return &c.Email })},
+	"role":          {"role", text(func(c *models.Complejo) *string { return &c.Role })},
+	"gender":        {"gender", text(func(c *models.Complejo) *string { return &c.Gender })},
+	"weight_kg":     {"weight", number(func(c *models.Complejo) *float64 { return &c.Weight })},
+	"height_m":      {"height", number(func(c *models.Complejo) *float64 { return &c.Height })},
+	"bench_kg":      {"bench", number(func(c *models.Complejo) *float64 { return &c.Bench })},
+	"squat_kg":      {"squad", number(func(c *models.Complejo) *float64 { return &c.Squad })},
+	"deadlift_kg":   {"dl", number(func(c *models.Complejo) *float64 { return &c.DL })},
+	"locale":        {"locale", text(func(c *models.Complejo) *string { return &c.Locale })},
+	"units":         {"units", text(func(c *models.Complejo) *string { return &c.Units })},
+	"photo_consent": {"photo_consent", text(func(c *models.Complejo) *string { return &c.PhotoConsent })},
+}
+
+// EventFields are the columns of a CSV import of events, named like the columns of their export, plus the
+// description and the level gate.
+var EventFields = map[string]Field[models.Event]{
+	"title":       {"title", text(func(e *models.Event) *string { return &e.Title })},
+	"description": {"description", text(func(e *models.Event) *string { return &e.Description })},
+	"date":        {"date", instant(func(e *models.Event) *time.Time { return &e.Date })},
+	"location":    {"location", text(func(e *models.Event) *string { return &e.Location })},
+	"capacity":    {"capacity", integer(func(e *models.Event) *int { return &e.Capacity })},
+	"level":       {"level", text(func(e *models.Event) *string { return &e.Level })},
+	"intensity":   {"intensity", text(func(e *models.Event) *string { return &e.Intensity })},
+	"level_gate":  {"level_gate", text(func(e *models.Event) *string { return &e.LevelGate })},
+	"outdoor":     {"outdoor", boolean(func(e *models.Event) *bool { return &e.Outdoor })},
+}
+
+// Record is a row read from a CSV import: its line, the value its cells set, and the message of each cell that
+// could not be read, by column.
+type Record[T any] struct {
+	Line   int
+	Value  T
+	Errors map[string]string
+}
+
+// ErrCSVHeader is returned when a CSV import has no header row.
+var ErrCSVHeader = errors.New("the file has no header row")
+
+// ReadMembersCSV reads a CSV import of members. The columns of their export that cannot be imported (like id
+// and created_at) are ignored, so an export can be imported again.
+func ReadMembersCSV(r io.Reader) ([]Record[models.Complejo], []string, error) {
+	return readCSV(r, MemberFields, ColumnNames(MemberColumns))
+}
+
+// ReadEventsCSV reads a CSV import of events. The columns of their export that cannot be imported (like id and
+// the RSVP counts) are ignored, so an export can be imported again.
+func ReadEventsCSV(r io.Reader) ([]Record[models.Event], []string, error) {
+	return readCSV(r, EventFields, ColumnNames(EventColumns))
+}
+
+// readCSV reads the rows of a CSV import with the fields named by its header row, skipping the ignored columns.
+// The other columns naming no field are returned instead, with no records. A row with more cells than the
+// header is recorded as an error of the row; missing cells are empty.
+func readCSV[T any](r io.Reader, fields map[string]Field[T], ignored []string) ([]Record[T], []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, ErrCSVHeader
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	// Spreadsheets often start their UTF-8 files with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	var unknown []string
+	for _, name := range header {
+		if _, ok := fields[name]; !ok && !contains(ignored, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if unknown != nil {
+		return nil, unknown, nil
+	}
+
+	var records []Record[T]
+	for {
+		cells, err := reader.Read()
+		if err == io.EOF {
+			return records, nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		record := Record[T]{Line: line, Errors: map[string]string{}}
+		if len(cells) > len(header) {
+			record.Errors["row"] = "has " + strconv.Itoa(len(cells)) + " cells for " + strconv.Itoa(len(header)) + " columns"
+		}
+		for i, name := range header {
+			field, ok := fields[name]
+			if !ok || i >= len(cells) || strings.TrimSpace(cells[i]) == "" {
+				continue
+			}
+			if err := field.Parse(&record.Value, strings.TrimSpace(cells[i])); err != nil {
+				record.Errors[name] = err.Error()
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+// JSONNames returns the column of each JSON field set by the fields, to report the errors of a row under its
+// column.
+func JSONNames[T any](fields map[string]Field[T]) map[string]string {
+	columns := make(map[string]string, len(fields))
+	for column, field := range fields {
+		columns[field.JSON] = column
+	}
+	return columns
+}
+
+// text sets a string field to the cell.
+func text[T any](field func(*T) *string) func(*T, string) error {
+	return func(value *T, cell string) error {
+		*field(value) = cell
+		return nil
+	}
+}
+
+// number sets a float field to the number of the cell.
+func number[T any](field func(*T) *float64) func(*T, string) error {
+	return func(value *T, cell string) error {
+		parsed, err := strconv.ParseFloat(cell, 64)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		*field(value) = parsed
+		return nil
+	}
+}
+
+// integer sets an int field to the whole number of the cell.
+func integer[T any](field func(*T) *int) func(*T, string) error {
+	return func(value *T, cell string) error {
+		parsed, err := strconv.Atoi(cell)
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		*field(value) = parsed
+		return nil
+	}
+}
+
+// boolean sets a bool field to the cell, true or false.
+func boolean[T any](field func(*T) *bool) func(*T, string) error {
+	return func(value *T, cell string) error {
+		parsed, err := strconv.ParseBool(cell)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		*field(value) = parsed
+		return nil
+	}
+}
+
+// instant sets a time field to the RFC 3339 time of the cell.
+func instant[T any](field func(*T) *time.Time) func(*T, string) error {
+	return func(value *T, cell string) error {
+		parsed, err := time.Parse(time.RFC3339, cell)
+		if err != nil {
+			return errors.New("must be an RFC 3339 time (e.g. 2026-11-01T10:00:00Z)")
+		}
+		*field(value) = parsed
+		return nil
+	}
+}
+
+// contains reports whether names contains name.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
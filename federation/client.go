@@ -0,0 +1,97 @@
+// client.go
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrInvalidSignature is returned when a payload received from the federation is not correctly signed.
+var ErrInvalidSignature = errors.New("invalid federation signature")
+
+// Client talks to the shared federation endpoint.
+//
+// Feeds are published with `POST <base>/feeds/<club>` and every club's feed is read with
+// `GET <base>/feeds`; both directions carry an HMAC-SHA256 signature of the body.
+type Client struct {
+	baseURL    string
+	club       string
+	secret     string
+	httpClient *http.Client
+}
+
+// NewClient creates a Client for the federation endpoint at baseURL, acting as the given club.
+func NewClient(baseURL, club, secret string) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		club:       club,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Publish sends the club's feed to the federation.
+func (c *Client) Publish(ctx context.Context, feed Feed) error {
+	body, err := json.Marshal(feed)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/feeds/"+c.club, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ClubHeader, c.club)
+	req.Header.Set(SignatureHeader, Sign(body, c.secret))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("federation rejected the feed: %s", resp.Status)
+	}
+	return nil
+}
+
+// FetchFeeds downloads the feeds of every federated club and verifies their signature.
+func (c *Client) FetchFeeds(ctx context.Context) ([]Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/feeds", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(ClubHeader, c.club)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("federation returned %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, err
+	}
+	if !Verify(body, resp.Header.Get(SignatureHeader), c.secret) {
+		return nil, ErrInvalidSignature
+	}
+
+	var feeds []Feed
+	if err := json.Unmarshal(body, &feeds); err != nil {
+		return nil, err
+	}
+	return feeds, nil
+}
@@ -0,0 +1,47 @@
+// federation.go
+package federation
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of a federation payload ("sha256=<hex>").
+const SignatureHeader = "X-Federation-Signature"
+
+// ClubHeader identifies the club that sent a federation request.
+const ClubHeader = "X-Federation-Club"
+
+// Event is a public event in the shared inter-club format.
+type Event struct {
+	ID          string    `json:"id"`    // Event ID at the publishing club
+	Title       string    `json:"title"` // Event title
+	Description string    `json:"description"`
+	Date        time.Time `json:"date"` // Start of the event (RFC 3339)
+	Location    string    `json:"location"`
+}
+
+// Feed is the list of public events published by one club.
+type Feed struct {
+	Club        string    `json:"club"`         // Publishing club identifier
+	GeneratedAt time.Time `json:"generated_at"` // When the feed was built
+	Events      []Event   `json:"events"`
+}
+
+// Sign returns the signature header value of the payload for the shared secret.
+func Sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature header value matches the payload for the shared secret.
+func Verify(payload []byte, signature, secret string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(payload, secret)))
+}
@@ -0,0 +1,167 @@
+// gpx.go
+package gpx
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalid is returned when the file is not a GPX document with at least two points.
+var ErrInvalid = errors.New("not a GPX route")
+
+// Summary describes a GPX route or track.
+type Summary struct {
+	Name          string   // Name of the route, track or file (empty when it has none)
+	Distance      float64  // Length in metres
+	ElevationGain float64  // Total climb in metres
+	ElevationLoss float64  // Total descent in metres
+	MinElevation  *float64 // Lowest elevation in metres (nil without elevation data)
+	MaxElevation  *float64 // Highest elevation in metres (nil without elevation data)
+	Points        int      // Number of points
+}
+
+// Threshold is the elevation change, in metres, below which GPS noise is not counted as climb or descent.
+const Threshold = 3
+
+// earthRadius is the mean radius of the Earth in metres.
+const earthRadius = 6371008.8
+
+// point is a track or route point.
+type point struct {
+	lat, lon float64
+	ele      *float64
+}
+
+// Parse reads a GPX 1.0 or 1.1 document and summarizes its tracks (<trkseg>) and routes (<rte>). The distance
+// and elevation are summed over every segment; the gap between two segments is not counted.
+func Parse(r io.Reader) (*Summary, error) {
+	decoder := xml.NewDecoder(r)
+	summary := &Summary{}
+	var segment []point
+	var current *point
+	var text strings.Builder
+	root := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalid
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			text.Reset()
+			switch t.Name.Local {
+			case "gpx":
+				root = true
+			case "trkseg", "rte":
+				segment = segment[:0]
+			case "trkpt", "rtept":
+				p, err := parsePoint(t)
+				if err != nil {
+					return nil, ErrInvalid
+				}
+				current = &p
+			}
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "ele":
+				if current != nil {
+					if ele, err := strconv.ParseFloat(strings.TrimSpace(text.String()), 64); err == nil {
+						current.ele = &ele
+					}
+				}
+			case "name":
+				if summary.Name == "" && current == nil {
+					summary.Name = strings.TrimSpace(text.String())
+				}
+			case "trkpt", "rtept":
+				if current != nil {
+					segment = append(segment, *current)
+					current = nil
+				}
+			case "trkseg", "rte":
+				summary.add(segment)
+			}
+		}
+	}
+
+	if !root || summary.Points < 2 {
+		return nil, ErrInvalid
+	}
+	return summary, nil
+}
+
+// parsePoint reads the coordinates of a <trkpt> or <rtept>.
+func parsePoint(element xml.StartElement) (point, error) {
+	var p point
+	var hasLat, hasLon bool
+	for _, attr := range element.Attr {
+		var err error
+		switch attr.Name.Local {
+		case "lat":
+			p.lat, err = strconv.ParseFloat(attr.Value, 64)
+			hasLat = err == nil && p.lat >= -90 && p.lat <= 90
+		case "lon":
+			p.lon, err = strconv.ParseFloat(attr.Value, 64)
+			hasLon = err == nil && p.lon >= -180 && p.lon <= 180
+		}
+	}
+	if !hasLat || !hasLon {
+		return p, ErrInvalid
+	}
+	return p, nil
+}
+
+// add adds the distance and elevation of a segment to the summary.
+func (s *Summary) add(segment []point) {
+	var reference *float64
+	for i, p := range segment {
+		s.Points++
+		if i > 0 {
+			s.Distance += haversine(segment[i-1], p)
+		}
+		if p.ele == nil {
+			continue
+		}
+
+		ele := *p.ele
+		if s.MinElevation == nil || ele < *s.MinElevation {
+			s.MinElevation = &ele
+		}
+		if s.MaxElevation == nil || ele > *s.MaxElevation {
+			s.MaxElevation = &ele
+		}
+		// Climb and descent are only counted once they exceed the threshold from the last counted elevation
+		switch {
+		case reference == nil:
+			reference = &ele
+		case ele-*reference >= Threshold:
+			s.ElevationGain += ele - *reference
+			reference = &ele
+		case *reference-ele >= Threshold:
+			s.ElevationLoss += *reference - ele
+			reference = &ele
+		}
+	}
+}
+
+// haversine returns the great-circle distance between two points in metres.
+func haversine(a, b point) float64 {
+	lat1, lat2 := a.lat*math.Pi/180, b.lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.lon - a.lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
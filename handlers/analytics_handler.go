@@ -0,0 +1,56 @@
+// analytics_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TenantHeader is the request header naming the tenant of an analytics client.
+const TenantHeader = "X-Tenant"
+
+// TrackAnalytics accepts a batch of lightweight client events (screen views, feature usage).
+//
+// Events are sampled, buffered and written to the database or the external collector in the background,
+// so the response only reports how many were accepted, discarded by sampling, or dropped (disabled tenant
+// or full buffer). The tenant comes from the X-Tenant header ("default" when missing); when the request
+// carries a valid token, the events are attributed to the caller.
+//
+// HTTP Status Codes:
+// - 202 Accepted: The batch was processed (see the receipt for the fate of each event).
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 422 Unprocessable Entity: The batch is empty, too large, or an event has invalid values.
+//
+// Parameters:
+// - svc (*services.AnalyticsService): The service that buffers analytics events.
+//
+// Example JSON payload:
+//
+//	{
+//	    "events": [
+//	        {"type": "screen_view", "name": "event_list", "session_id": "a1b2"},
+//	        {"type": "feature_usage", "name": "subscribe", "properties": {"source": "push"}}
+//	    ]
+//	}
+//
+// Example usage:
+// r.POST("/analytics/track", OptionalAuthMiddleware(clk, accounts), TrackAnalytics(svc))
+func TrackAnalytics(svc *services.AnalyticsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var batch models.AnalyticsBatch
+		if err := validation.BindJSON(c, &batch); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		receipt := svc.Track(c.GetHeader(TenantHeader), c.GetString("_id"), batch.Events)
+
+		// 202 Accepted: Events buffered for storage
+		responses.Accepted(c, receipt)
+	}
+}
@@ -0,0 +1,299 @@
+// announcement_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAnnouncements lists the most recent announcements of the admins, newest first. The `?limit=` query
+// parameter sets how many are listed (default 20, at most 100).
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the announcements.
+// - 400 Bad Request: The query parameters could not be parsed.
+// - 422 Unprocessable Entity: The limit is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the announcements.
+//
+// Parameters:
+// - svc (*services.AnnouncementService): The service that manages the announcements.
+//
+// Example usage:
+// r.GET("/announcements", GetAnnouncements(svc))
+func GetAnnouncements(svc *services.AnnouncementService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.AnnouncementQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		announcements, err := svc.List(c, query)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the announcements
+		responses.OK(c, announcements)
+	}
+}
+
+// GetAnnouncement retrieves an announcement by its ID.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the announcement.
+// - 404 Not Found: The announcement with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the announcement.
+//
+// Parameters:
+// - svc (*services.AnnouncementService): The service that manages the announcements.
+//
+// Example usage:
+// r.GET("/announcements/:id", GetAnnouncement(svc))
+func GetAnnouncement(svc *services.AnnouncementService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		announcement, err := svc.Get(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the announcement
+		responses.OK(c, announcement)
+	}
+}
+
+// PublishAnnouncement publishes an announcement to every member, restricted to admin role. It is pushed to
+// the registered devices.
+//
+// HTTP Status Codes:
+// - 201 Created: The announcement was successfully published.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The title (at most 100 characters) or the body (at most 2000) is missing or too long.
+// - 500 Internal Server Error: An issue occurred while publishing the announcement.
+//
+// Parameters:
+// - svc (*services.AnnouncementService): The service that manages the announcements.
+//
+// Example JSON payload:
+//
+//	{
+//	    "title": "Closed on Monday",
+//	    "body": "The gym is closed on Monday 2 November for maintenance."
+//	}
+//
+// Example usage:
+// r.POST("/announcements", PublishAnnouncement(svc))
+func PublishAnnouncement(svc *services.AnnouncementService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to publish announcements."))
+			return
+		}
+
+		var input models.AnnouncementInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		announcement, err := svc.Publish(c, input, id.(string))
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The announcement was successfully published
+		responses.Created(c, announcement)
+	}
+}
+
+// EditAnnouncement replaces the title and body of an announcement with a new version, restricted to admin role.
+// The previous versions are kept; the edit is not pushed to the devices again.
+//
+// HTTP Status Codes:
+// - 200 OK: The announcement was successfully edited.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The announcement with the specified ID was not found.
+// - 409 Conflict: The new version is identical to the current one, or another edit was saved at the same time.
+// - 422 Unprocessable Entity: The title (at most 100 characters) or the body (at most 2000) is missing or too long.
+// - 500 Internal Server Error: An issue occurred while editing the announcement.
+//
+// Parameters:
+// - svc (*services.AnnouncementService): The service that manages the announcements.
+//
+// Example JSON payload:
+//
+//	{
+//	    "title": "Closed on Monday morning",
+//	    "body": "The gym is closed on Monday 2 November until 14:00 for maintenance."
+//	}
+//
+// Example usage:
+// r.PUT("/announcements/:id", EditAnnouncement(svc))
+func EditAnnouncement(svc *services.AnnouncementService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to edit announcements."))
+			return
+		}
+
+		var input models.AnnouncementInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		announcement, err := svc.Edit(c, c.Param("id"), input, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The announcement was successfully edited
+		responses.OK(c, announcement)
+	}
+}
+
+// GetAnnouncementVersions lists the versions of an announcement, the latest first, without their content.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the versions.
+// - 404 Not Found: The announcement with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the versions.
+//
+// Parameters:
+// - svc (*services.AnnouncementService): The service that manages the announcements.
+//
+// Example usage:
+// r.GET("/announcements/:id/versions", GetAnnouncementVersions(svc))
+func GetAnnouncementVersions(svc *services.AnnouncementService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		versions, err := svc.Versions(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the versions
+		responses.OK(c, versions)
+	}
+}
+
+// GetAnnouncementVersion retrieves a version of an announcement with the unified diff of its body from the
+// previous version.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the version.
+// - 404 Not Found: The announcement or the version was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the version.
+//
+// Parameters:
+// - svc (*services.AnnouncementService): The service that manages the announcements.
+//
+// Example usage:
+// r.GET("/announcements/:id/versions/:version", GetAnnouncementVersion(svc))
+func GetAnnouncementVersion(svc *services.AnnouncementService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		number, err := versionParam(c)
+		if err != nil {
+			// 404 Not Found: The version is not a number
+			c.Error(err)
+			return
+		}
+
+		version, err := svc.Version(c, c.Param("id"), number)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the version
+		responses.OK(c, version)
+	}
+}
+
+// RollbackAnnouncement restores the content of an earlier version of an announcement as a new version,
+// restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: The announcement was successfully rolled back.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The announcement or the version was not found.
+// - 409 Conflict: The version has the same content as the current one, or another edit was saved at the same time.
+// - 500 Internal Server Error: An issue occurred while rolling the announcement back.
+//
+// Parameters:
+// - svc (*services.AnnouncementService): The service that manages the announcements.
+//
+// Example usage:
+// r.POST("/announcements/:id/versions/:version/rollback", RollbackAnnouncement(svc))
+func RollbackAnnouncement(svc *services.AnnouncementService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to edit announcements."))
+			return
+		}
+
+		number, err := versionParam(c)
+		if err != nil {
+			// 404 Not Found: The version is not a number
+			c.Error(err)
+			return
+		}
+
+		announcement, err := svc.Rollback(c, c.Param("id"), number, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The announcement was successfully rolled back
+		responses.OK(c, announcement)
+	}
+}
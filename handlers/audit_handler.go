@@ -0,0 +1,56 @@
+// audit_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAuditLog lists the most recent entries of the audit log, restricted to admin role. The entries record
+// sensitive actions such as the erasures of Complejos (action "complejo.erased"); they name the Complejos by
+// their ID only and are kept after the data they concern is gone.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the entries (possibly an empty list).
+// - 400 Bad Request: The limit is not a number.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The limit is out of range (1 to 500).
+// - 500 Internal Server Error: An issue occurred while reading the audit log.
+//
+// Parameters:
+// - svc (*services.AuditService): The service that lists the audit log.
+//
+// Example usage:
+// r.GET("/admin/audit?action=complejo.erased&limit=50", GetAuditLog(svc))
+func GetAuditLog(svc *services.AuditService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the audit log."))
+			return
+		}
+
+		var query models.AuditQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		entries, err := svc.Recent(c, query)
+		if err != nil {
+			// 500 Internal Server Error: Query error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the entries
+		responses.OK(c, entries)
+	}
+}
@@ -0,0 +1,131 @@
+// block_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BlockComplejo makes the authenticated user block a Complejo: the Complejo no longer sees the user's profile,
+// and their follows of each other are removed. Blocking a Complejo again changes nothing, and a Complejo cannot
+// block itself.
+//
+// HTTP Status Codes:
+// - 204 No Content: The user blocks the Complejo.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 422 Unprocessable Entity: The Complejo is the user itself (error code "block_self").
+// - 500 Internal Server Error: An issue occurred while storing the block.
+//
+// Parameters:
+// - svc (*services.BlockService): The service that tells who blocks whom.
+//
+// Example usage:
+// r.PUT("/complejo/:id/block", BlockComplejo(svc))
+func BlockComplejo(svc *services.BlockService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Block(c, id.(string), c.Param("id")); err != nil {
+			// 404 Not Found, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The user blocks the Complejo
+		responses.NoContent(c)
+	}
+}
+
+// UnblockComplejo stops the authenticated user from blocking a Complejo; the follows removed by the block are not
+// restored. Unblocking a Complejo that is not blocked changes nothing.
+//
+// HTTP Status Codes:
+// - 204 No Content: The user does not block the Complejo.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the block.
+//
+// Parameters:
+// - svc (*services.BlockService): The service that tells who blocks whom.
+//
+// Example usage:
+// r.DELETE("/complejo/:id/block", UnblockComplejo(svc))
+func UnblockComplejo(svc *services.BlockService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Unblock(c, id.(string), c.Param("id")); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The user does not block the Complejo
+		responses.NoContent(c)
+	}
+}
+
+// GetOwnBlocks lists the Complejos the authenticated user blocks with their username, latest first, with
+// `page`/`limit` pagination. Deleted Complejos are left out.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of blocked Complejos (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 422 Unprocessable Entity: A query parameter is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the blocked Complejos.
+//
+// Parameters:
+// - svc (*services.BlockService): The service that tells who blocks whom.
+//
+// Example response data:
+//
+//	[
+//	    {"complejo_id": "...", "username": "pepe", "blocked_at": "2026-10-12T09:40:00Z"}
+//	]
+//
+// Example usage:
+// r.GET("/complejo/me/blocks", GetOwnBlocks(svc))
+func GetOwnBlocks(svc *services.BlockService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var query models.BlockQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+
+		blocked, total, err := svc.Blocked(c, id.(string), &query)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of blocked Complejos
+		responses.OKWithMeta(c, blocked, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
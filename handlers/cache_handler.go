@@ -0,0 +1,50 @@
+// cache_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/profilecache"
+	"los-complejos-backend/responses"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetProfileCacheStats returns the metrics of the profile cache since the server started, restricted to admin
+// role: the reads served within their request, across requests and from the database, and the resulting hit rate.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the metrics.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+//
+// Parameters:
+// - cache (*profilecache.Cache): The cache of the profiles of the Complejos.
+//
+// Example response data:
+//
+//	{
+//	    "entries": 42,
+//	    "capacity": 1000,
+//	    "ttl": "5s",
+//	    "request_hits": 1830,
+//	    "hits": 950,
+//	    "misses": 310,
+//	    "hit_rate": 0.8997,
+//	    "invalidations": 12,
+//	    "evictions": 0
+//	}
+//
+// Example usage:
+// r.GET("/admin/cache/profiles", GetProfileCacheStats(cache))
+func GetProfileCacheStats(cache *profilecache.Cache) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the cache metrics."))
+			return
+		}
+
+		// 200 OK: Successfully retrieved the metrics
+		responses.OK(c, cache.Stats())
+	}
+}
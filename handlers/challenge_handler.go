@@ -0,0 +1,284 @@
+// challenge_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetChallenges lists the challenges, latest start first, with `page`/`limit` pagination. The `?status=` query
+// parameter keeps the "upcoming", "active" or "ended" ones. No authentication is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of challenges (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 422 Unprocessable Entity: A query parameter is not one of the allowed values or out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the challenges.
+//
+// Parameters:
+// - svc (*services.ChallengeService): The service that runs the challenges.
+//
+// Example usage:
+// r.GET("/challenges?status=active", GetChallenges(svc))
+func GetChallenges(svc *services.ChallengeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var filter models.ChallengeFilter
+		if err := validation.BindQuery(c, &filter); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		challenges, total, err := svc.List(c, &filter)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of challenges
+		responses.OKWithMeta(c, challenges, responses.NewPagination(filter.Page, filter.Limit, total))
+	}
+}
+
+// GetChallenge retrieves a challenge. No authentication is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the challenge.
+// - 404 Not Found: The challenge with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the challenge.
+//
+// Parameters:
+// - svc (*services.ChallengeService): The service that runs the challenges.
+//
+// Example usage:
+// r.GET("/challenges/:id", GetChallenge(svc))
+func GetChallenge(svc *services.ChallengeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		challenge, err := svc.Get(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the challenge
+		responses.OK(c, challenge)
+	}
+}
+
+// CreateChallenge creates a challenge on behalf of the authenticated user, who may then join it like any other
+// Complejo. The participants are ranked by the metric counted between the start and the end: "events"
+// (events attended), "workouts" (workouts logged), "volume" (kilos lifted in the workouts, repetitions times
+// weight) or "nutrition_days" (days with a nutrition log). The start may be in the past; the end is in the
+// future, at most 366 days after the start.
+//
+// HTTP Status Codes:
+// - 201 Created: The challenge was successfully created.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 422 Unprocessable Entity: Required fields are missing or have invalid values, or the end is not after the
+// start.
+// - 500 Internal Server Error: An issue occurred while storing the challenge.
+//
+// Parameters:
+// - svc (*services.ChallengeService): The service that runs the challenges.
+//
+// Example JSON payload:
+//
+//	{
+//	    "title": "Most workouts in March",
+//	    "description": "The winner picks the music for a month",
+//	    "metric": "workouts",
+//	    "starts_at": "2027-03-01T00:00:00+01:00",
+//	    "ends_at": "2027-04-01T00:00:00+02:00"
+//	}
+//
+// Example usage:
+// r.POST("/challenges", CreateChallenge(svc))
+func CreateChallenge(svc *services.ChallengeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.ChallengeInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		challenge, err := svc.Create(c, id.(string), input)
+		if err != nil {
+			// 404 Not Found, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The challenge was successfully created
+		responses.Created(c, challenge)
+	}
+}
+
+// DeleteChallenge removes a challenge and its standings. Only admins and the creator of the challenge may
+// remove it.
+//
+// HTTP Status Codes:
+// - 204 No Content: The challenge was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the challenge.
+// - 404 Not Found: The challenge with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the challenge.
+//
+// Parameters:
+// - svc (*services.ChallengeService): The service that runs the challenges.
+//
+// Example usage:
+// r.DELETE("/challenges/:id", DeleteChallenge(svc))
+func DeleteChallenge(svc *services.ChallengeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The challenge was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// JoinChallenge adds the authenticated user to the participants of a challenge that has not ended; joining it
+// again changes nothing. The participant scores 0 until the next scoring, which counts what it did since the
+// start of the challenge, even before it joined.
+//
+// HTTP Status Codes:
+// - 200 OK: The user participates; the response carries the standings, with its place in `me`.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The challenge with the specified ID was not found, or the Complejo no longer exists.
+// - 409 Conflict: The challenge has ended.
+// - 500 Internal Server Error: An issue occurred while joining the challenge.
+//
+// Parameters:
+// - svc (*services.ChallengeService): The service that runs the challenges.
+//
+// Example usage:
+// r.PUT("/challenges/:id/join", JoinChallenge(svc))
+func JoinChallenge(svc *services.ChallengeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		standings, err := svc.Join(c, c.Param("id"), id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The user participates
+		responses.OK(c, standings)
+	}
+}
+
+// LeaveChallenge removes the authenticated user from the participants of a challenge that has not ended, with
+// its score; leaving it again changes nothing.
+//
+// HTTP Status Codes:
+// - 200 OK: The user does not participate; the response carries the standings.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The challenge with the specified ID was not found.
+// - 409 Conflict: The challenge has ended.
+// - 500 Internal Server Error: An issue occurred while leaving the challenge.
+//
+// Parameters:
+// - svc (*services.ChallengeService): The service that runs the challenges.
+//
+// Example usage:
+// r.DELETE("/challenges/:id/join", LeaveChallenge(svc))
+func LeaveChallenge(svc *services.ChallengeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		standings, err := svc.Leave(c, c.Param("id"), id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The user does not participate
+		responses.OK(c, standings)
+	}
+}
+
+// GetChallengeStandings ranks the participants of a challenge, highest score first, with the place of the caller
+// when authenticated. The scores are those of the last scoring (`scored_at`), run every day by default while the
+// challenge is active and a last time after its end, which makes the standings `final`. Participants with the
+// same score share their rank.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the standings (possibly empty).
+// - 404 Not Found: The challenge with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while ranking the participants.
+//
+// Parameters:
+// - svc (*services.ChallengeService): The service that runs the challenges.
+//
+// Example response data:
+//
+//	{
+//	    "challenge_id": "...",
+//	    "metric": "workouts",
+//	    "status": "active",
+//	    "scored_at": "2027-03-16T03:00:00Z",
+//	    "final": false,
+//	    "entries": [
+//	        {"rank": 1, "complejo_id": "...", "username": "maria", "score": 12, "joined_at": "2027-02-27T18:02:11Z"},
+//	        {"rank": 2, "complejo_id": "...", "username": "juan", "score": 9, "joined_at": "2027-03-02T07:45:00Z"}
+//	    ],
+//	    "me": {"rank": 2, "complejo_id": "...", "username": "juan", "score": 9, "joined_at": "2027-03-02T07:45:00Z"}
+//	}
+//
+// Example usage:
+// r.GET("/challenges/:id/standings", GetChallengeStandings(svc))
+func GetChallengeStandings(svc *services.ChallengeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		standings, err := svc.Standings(c, c.Param("id"), c.GetString("_id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the standings
+		responses.OK(c, standings)
+	}
+}
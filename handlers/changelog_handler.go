@@ -0,0 +1,39 @@
+// changelog_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/changelog"
+	"los-complejos-backend/responses"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetChangelog lists the changes of the API, newest first: the routes and fields added, changed, deprecated or
+// removed, with the replacement and sunset day of the deprecated ones, so client apps can warn before they break.
+// No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the changelog.
+//
+// Example response data:
+//
+//	[
+//	    {
+//	        "date": "2026-10-16",
+//	        "kind": "deprecated",
+//	        "method": "PUT",
+//	        "path": "/event/:id/subscribe",
+//	        "description": "Subscribing is answering going to the event.",
+//	        "replacement": "PUT /event/:id/rsvp with \"status\": \"going\"",
+//	        "sunset": "2027-04-16"
+//	    }
+//	]
+//
+// Example usage:
+// r.GET("/api/changelog", GetChangelog())
+func GetChangelog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 200 OK: Successfully retrieved the changelog
+		responses.OK(c, changelog.Entries)
+	}
+}
@@ -2,28 +2,54 @@
 package handlers
 
 import (
+	"los-complejos-backend/apperrors"
 	"los-complejos-backend/models"
-	"los-complejos-backend/utils"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// registrationResponse is returned when a Complejo is created: the new profile and its token.
+type registrationResponse struct {
+	Complejo *models.ComplejoResponse `json:"complejo"`
+	Token    string                   `json:"token"`
+}
+
+// complejoView binds the `?include=` query string and returns the optional fields the caller asked for and may see:
+// the photo when `include=photo` is given, and the email address and churn-risk score for admins, who also see
+// every field whatever the privacy settings of the Complejo.
+func complejoView(c *gin.Context) (models.ComplejoView, error) {
+	var query models.ComplejoQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		return models.ComplejoView{}, err
+	}
+
+	role, _ := c.Get("role")
+	view := models.ComplejoView{Photo: query.Include == "photo", Email: role == "admin", ChurnRisk: role == "admin"}
+	if role == "admin" {
+		view.Relation = models.RelationSelf
+	}
+	return view, nil
+}
+
 // CreateComplejo creates a new Complejo and inserts it into the MongoDB collection.
 //
 // This function accepts a JSON payload to create a new Complejo document. It generates a unique ID for the Complejo,
 // calculates its IMC (Body Mass Index) based on the weight and height provided, and generates a JWT token for authentication.
 //
 // HTTP Status Codes:
-// - 201 Created: The Complejo was successfully created.
+// - 201 Created: The Complejo was successfully created (returned without its password and photo).
 // - 400 Bad Request: Invalid JSON data was provided.
+// - 409 Conflict: The username is already taken (error code "username_taken").
+// - 422 Unprocessable Entity: Required fields are missing or have invalid values (role, gender, photo, out-of-range numbers).
+// - 429 Too Many Requests: The photo could not be queued for processing.
 // - 500 Internal Server Error: There was an issue inserting the Complejo into the database or generating the token.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Complejo documents are stored.
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
 //
 // Example JSON payload for creating a Complejo:
 //
@@ -31,155 +57,107 @@ import (
 //	    "username": "test_user",
 //	    "password": "securepassword",
 //	    "role": "user",
-//	    "weight": "75.5",
-//	    "height": "1.78",
+//	    "weight": 75.5,
+//	    "height": 1.78,
 //	    "gender": "male",
-//	    "bench": "100",
-//	    "squad": "140",
-//	    "dl": "180",
+//	    "bench": 100,
+//	    "squad": 140,
+//	    "dl": 180,
 //	    "photo": "base64_encoded_photo"
 //	}
 //
 // Example usage:
-// r.POST("/complejo", CreateComplejo(collection))
-func CreateComplejo(collection *mongo.Collection) gin.HandlerFunc {
+// r.POST("/complejo", CreateComplejo(svc))
+func CreateComplejo(svc *services.ComplejoService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var complejo models.Complejo
 
-		// Parse the incoming JSON request into the Complejo model
-		if err := c.ShouldBindJSON(&complejo); err != nil {
+		// Parse and validate the incoming JSON request into the Complejo model
+		if err := validation.BindJSON(c, &complejo); err != nil {
 			// 400 Bad Request: The JSON is invalid
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"code":    http.StatusBadRequest,
-				"message": "Invalid JSON format: " + err.Error(),
-			})
-			return
-		}
-
-		// Generate a unique ID and calculate the IMC
-		complejo.ID = uuid.NewString()
-		complejo.IMC = utils.CalcIMC(complejo.Weight, complejo.Height)
-
-		// Prepare the document for MongoDB insertion
-		document := bson.M{
-			"_id":      complejo.ID,
-			"username": complejo.Username,
-			"password": complejo.Password,
-			"role":     complejo.Role,
-			"weight":   complejo.Weight,
-			"height":   complejo.Height,
-			"imc":      complejo.IMC,
-			"gender":   complejo.Gender,
-			"bench":    complejo.Bench,
-			"squad":    complejo.Squad,
-			"dl":       complejo.DL,
-			"photo":    complejo.Photo,
-		}
-
-		// Insert the document into the MongoDB collection
-		_, err := collection.InsertOne(c, document)
-		if err != nil {
-			// 500 Internal Server Error: Failed to insert the document
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to create Complejo: " + err.Error(),
-			})
+			// 422 Unprocessable Entity: Required fields are missing or invalid
+			c.Error(err)
 			return
 		}
 
-		// Generate a token for the user (infinite or long-lived)
-		token, err := utils.GenerateToken(complejo.ID, complejo.Role, complejo.Username)
+		// Store the Complejo and generate a token for the user (valid for JWT_LIFETIME, or forever when unset)
+		token, err := svc.Create(c, &complejo)
 		if err != nil {
-			// 500 Internal Server Error: Failed to generate the token
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to generate token: " + err.Error(),
-			})
+			// 500 Internal Server Error: Failed to insert the document or generate the token
+			c.Error(err)
 			return
 		}
 
 		// 201 Created: The Complejo was successfully created
-		c.JSON(http.StatusCreated, gin.H{
-			"status":  "success",
-			"code":    http.StatusCreated,
-			"message": "Complejo created successfully",
-			"data":    complejo,
-			"token":   token,
-		})
+		responses.Created(c, registrationResponse{Complejo: complejo.Response(models.ComplejoView{Relation: models.RelationSelf}), Token: token})
 	}
 }
 
 // GetComplejos retrieves all Complejos from the MongoDB collection.
 //
 // This function fetches all Complejo documents from the MongoDB collection. If no Complejos are found, it responds with a 404 status.
+// Passwords are never returned; base64 photos only with `?include=photo`, and churn-risk scores only to admins.
+// The weight, height, IMC and photo of a Complejo are only returned to the callers its privacy settings let see
+// them (by default, the weight, height and IMC to itself, its followers and admins); the fields left out are
+// listed in `hidden`. The Complejos blocking the caller are left out.
 //
 // HTTP Status Codes:
 // - 200 OK: Successfully retrieved all Complejos.
 // - 404 Not Found: No Complejos were found in the database.
+// - 422 Unprocessable Entity: The include parameter is not "photo".
 // - 500 Internal Server Error: An issue occurred while fetching or processing the data.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Complejo documents are stored.
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+// - privacy (*services.PrivacyService): The service that tells which fields the caller sees.
 //
 // Example usage:
-// r.GET("/complejo", GetComplejos(collection))
-func GetComplejos(collection *mongo.Collection) gin.HandlerFunc {
+// r.GET("/complejo?include=photo", GetComplejos(svc, privacy))
+func GetComplejos(svc *services.ComplejoService, privacy *services.PrivacyService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Find all documents in the collection
-		cursor, err := collection.Find(c, bson.M{})
+		view, err := complejoView(c)
 		if err != nil {
-			// 500 Internal Server Error: Database query failed
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to fetch Complejos from the database: " + err.Error(),
-			})
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
 			return
 		}
-		defer func() {
-			if err := cursor.Close(c); err != nil {
-				// 500 Internal Server Error: Failed to close the cursor
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"status":  "error",
-					"code":    http.StatusInternalServerError,
-					"message": "Failed to close the database cursor: " + err.Error(),
-				})
-			}
-		}()
-
-		// Parse the cursor results into a slice of Complejos
-		var complejos []models.Complejo
-		if err := cursor.All(c, &complejos); err != nil {
-			// 500 Internal Server Error: Failed to parse data
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to parse Complejos data: " + err.Error(),
-			})
+
+		// Retrieve all Complejos
+		complejos, err := svc.List(c)
+		if err != nil {
+			// 500 Internal Server Error: Database query failed
+			c.Error(err)
 			return
 		}
 
 		// Handle the case where no Complejos are found
 		if len(complejos) == 0 {
 			// 404 Not Found: No Complejos exist
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"code":    http.StatusNotFound,
-				"message": "No Complejos found in the database",
-			})
+			c.Error(apperrors.NotFound("No Complejos found in the database"))
+			return
+		}
+
+		ids := make([]string, 0, len(complejos))
+		for i := range complejos {
+			ids = append(ids, complejos[i].ID)
+		}
+		views, err := privacy.Views(c, view, c.GetString("_id"), ids)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
 			return
 		}
 
 		// 200 OK: Successfully retrieved all Complejos
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "success",
-			"code":    http.StatusOK,
-			"message": "Complejos retrieved successfully",
-			"data":    complejos,
-		})
+		response := make([]*models.ComplejoResponse, 0, len(complejos))
+		for i := range complejos {
+			view, visible := views[complejos[i].ID]
+			if !visible {
+				continue
+			}
+			response = append(response, complejos[i].Response(view))
+		}
+		responses.OK(c, response)
 	}
 }
 
@@ -187,66 +165,146 @@ func GetComplejos(collection *mongo.Collection) gin.HandlerFunc {
 //
 // This function fetches a single Complejo document using its unique `_id`.
 // If the document is not found, it responds with a 404 status.
+// The password is never returned; a base64 photo only with `?include=photo`, and the churn-risk score only to admins.
+// The weight, height, IMC and photo are only returned to the callers the privacy settings of the Complejo let see
+// them; the fields left out are listed in `hidden`. The profile includes the hours the Complejo volunteered in the shifts of events that
+// have ended, and its `follows`: the numbers of its followers and of the Complejos it follows, and whether the
+// caller follows it. A Complejo blocking the caller is not found.
 //
 // HTTP Status Codes:
 // - 200 OK: Successfully retrieved the Complejo.
-// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 404 Not Found: The Complejo with the specified ID was not found, or it blocks the caller.
+// - 422 Unprocessable Entity: The include parameter is not "photo".
 // - 500 Internal Server Error: Failed to fetch or process the Complejo.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Complejo documents are stored.
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+// - volunteers (*services.VolunteerService): The service that tallies the volunteer hours.
+// - follows (*services.FollowService): The service that counts the followers.
+// - privacy (*services.PrivacyService): The service that tells which fields the caller sees.
 //
 // Example usage:
-// r.GET("/complejo/:id", GetComplejo(collection))
-func GetComplejo(collection *mongo.Collection) gin.HandlerFunc {
+// r.GET("/complejo/:id?include=photo", GetComplejo(svc, volunteers, follows, privacy))
+func GetComplejo(svc *services.ComplejoService, volunteers *services.VolunteerService, follows *services.FollowService, privacy *services.PrivacyService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id := c.Param("id")
+		view, err := complejoView(c)
+		if err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
 
-		// Find the document in the collection by "_id"
-		var complejo models.Complejo
-		err := collection.FindOne(c, bson.M{"_id": id}).Decode(&complejo)
+		// Find the Complejo by "_id"
+		complejo, err := svc.Get(c, c.Param("id"))
 		if err != nil {
-			// 404 Not Found: Document not found
-			if err == mongo.ErrNoDocuments {
-				c.JSON(http.StatusNotFound, gin.H{
-					"status":  "error",
-					"code":    http.StatusNotFound,
-					"message": "Complejo not found",
-				})
-				return
-			}
-			// 500 Internal Server Error: Query error
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to retrieve Complejo: " + err.Error(),
-			})
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		view, err = privacy.View(c, view, c.GetString("_id"), complejo.ID)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+		hours, err := volunteers.Hours(c, complejo.ID)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+		status, err := follows.Status(c, complejo.ID, c.GetString("_id"))
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+		response := complejo.Response(view)
+		response.VolunteerHours = &hours
+		response.Follows = status
+
+		// 200 OK: Successfully retrieved the Complejo
+		responses.OK(c, response)
+	}
+}
+
+// GetOwnComplejo retrieves the authenticated user's own Complejo, identified by the JWT token,
+// so clients do not need to decode the token to know their ID.
+//
+// The full profile is returned, including the photo, the volunteer hours and the follow counts; the password is
+// never returned, and the churn-risk score only to admins.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the Complejo.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 500 Internal Server Error: Failed to fetch or process the Complejo.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+// - volunteers (*services.VolunteerService): The service that tallies the volunteer hours.
+// - follows (*services.FollowService): The service that counts the followers.
+//
+// Example usage:
+// r.GET("/complejo/me", GetOwnComplejo(svc, volunteers, follows))
+func GetOwnComplejo(svc *services.ComplejoService, volunteers *services.VolunteerService, follows *services.FollowService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		complejo, err := svc.Get(c, id.(string))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		hours, err := volunteers.Hours(c, complejo.ID)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+		status, err := follows.Status(c, complejo.ID, "")
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
 			return
 		}
+		response := complejo.Response(models.ComplejoView{Photo: true, Email: true, ChurnRisk: role == "admin", Relation: models.RelationSelf})
+		response.VolunteerHours = &hours
+		response.Follows = status
 
 		// 200 OK: Successfully retrieved the Complejo
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "success",
-			"code":    http.StatusOK,
-			"message": "Complejo retrieved successfully",
-			"data":    complejo,
-		})
+		responses.OK(c, response)
 	}
 }
 
 // UpdateComplejoForUser updates specific fields of a Complejo, restricted to user role.
 //
 // This function allows users with the "user" role to update specific personal fields in their Complejo document.
-// Only the fields listed as "allowed" are updated, and any invalid or unauthorized fields are ignored.
+// Only the profile fields (username, weight, height, bench, squad, dl, photo, locale, units, goal) present in the
+// payload are updated; any other field is rejected.
 //
 // HTTP Status Codes:
 // - 200 OK: Successfully updated the Complejo.
-// - 400 Bad Request: Invalid JSON data was provided or no valid fields were included in the payload.
-// - 404 Not Found: The Complejo with the specified ID was not found or the role is not "user".
+// - 400 Bad Request: Invalid JSON data, a field of the wrong type or no profile fields were included in the payload.
+// - 403 Forbidden: The token is missing or invalid, or the role is not "user".
+// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 409 Conflict: The new username is already taken.
+// - 422 Unprocessable Entity: The locale, units, goal, photo or numeric fields (weight, height, lifts) have invalid values,
+// or a field is unknown (e.g. "dead_lift").
+// - 429 Too Many Requests: The photo could not be queued for processing.
 // - 500 Internal Server Error: An issue occurred while updating the Complejo in the database.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Complejo documents are stored.
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
 //
 // Example JSON payload for updating a Complejo:
 //
@@ -257,101 +315,55 @@ func GetComplejo(collection *mongo.Collection) gin.HandlerFunc {
 //	}
 //
 // Example usage:
-// r.PUT("/complejo/user", UpdateComplejoForUser(collection))
-func UpdateComplejoForUser(collection *mongo.Collection) gin.HandlerFunc {
+// r.PUT("/complejo/user", UpdateComplejoForUser(svc))
+func UpdateComplejoForUser(svc *services.ComplejoService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, idExist := c.Get("_id")
 		role, roleExist := c.Get("role")
 
-		if !idExist || !roleExist || role == "user" {
+		if !idExist || !roleExist || role != "user" {
 			// 403 Forbidden: Insufficient permissions
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"code":    http.StatusForbidden,
-				"message": "You do not have permission to update this Complejo.",
-			})
-			return
-		}
-
-		var updateData map[string]interface{}
-		if err := c.ShouldBindJSON(&updateData); err != nil {
-			// 400 Bad Request: Invalid JSON format
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"code":    http.StatusBadRequest,
-				"message": "Invalid JSON format: " + err.Error(),
-			})
-			return
-		}
-
-		allowedFields := []string{"username", "weight", "height", "bench", "squad", "deadlift", "photo"}
-		filteredUpdate := bson.M{}
-		for _, field := range allowedFields {
-			if value, exists := updateData[field]; exists {
-				filteredUpdate[field] = value
-			}
-		}
-
-		// Ensure no invalid fields were sent
-		if len(filteredUpdate) == 0 {
-			// 400 Bad Request: No valid fields provided
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"code":    http.StatusBadRequest,
-				"message": "No valid fields to update",
-			})
+			c.Error(apperrors.Forbidden("You do not have permission to update this Complejo."))
 			return
 		}
 
-		// Prepare the update payload
-		update := bson.M{"$set": filteredUpdate}
-
-		// Perform the update operation
-		result, err := collection.UpdateOne(c, bson.M{"_id": id, "role": "user"}, update)
-		if err != nil {
-			// 500 Internal Server Error: Database update failed
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to update Complejo: " + err.Error(),
-			})
+		// Only the profile fields are bound; anything else in the payload is rejected
+		var update models.ProfileUpdate
+		if err := validation.BindJSON(c, &update); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
 			return
 		}
 
-		// Handle the case where no document was updated
-		if result.MatchedCount == 0 {
-			// 404 Not Found: Document with the given ID does not exist or is not a user
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"code":    http.StatusNotFound,
-				"message": "Complejo not found or insufficient permissions",
-			})
+		// Perform the update operation on the fields present in the payload
+		if err := svc.UpdateForUser(c, id.(string), update); err != nil {
+			// 400 Bad Request, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
 			return
 		}
 
 		// 200 OK: Successfully updated the Complejo
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "success",
-			"code":    http.StatusOK,
-			"message": "Complejo updated successfully",
-		})
+		responses.Message(c, http.StatusOK, "Complejo updated successfully")
 	}
 }
 
 // UpdateComplejoForAdmin updates specific fields of a Complejo by ID, restricted to admin role.
 //
-// This function allows administrators with the "admin" role to update any field of a Complejo document.
-// Unlike user updates, admin updates have no restrictions on the fields that can be modified.
+// This function allows administrators with the "admin" role to update the Complejo document named by the route.
+// Besides the profile fields, admins may change the password, role and gender; any other field is rejected.
 //
 // HTTP Status Codes:
 // - 200 OK: Successfully updated the Complejo.
-// - 400 Bad Request: Invalid JSON data was provided.
+// - 400 Bad Request: Invalid JSON data, a field of the wrong type or no updatable fields were included in the payload.
 // - 403 Forbidden: The user does not have sufficient permissions to perform this action.
 // - 404 Not Found: The Complejo with the specified ID was not found.
+// - 409 Conflict: The new username is already taken.
+// - 422 Unprocessable Entity: The role, gender, locale, units, goal, photo or numeric fields have invalid values,
+// or a field is unknown.
 // - 500 Internal Server Error: An issue occurred while updating the Complejo in the database.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Complejo documents are stored.
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
 //
 // Example JSON payload for updating a Complejo:
 //
@@ -362,69 +374,143 @@ func UpdateComplejoForUser(collection *mongo.Collection) gin.HandlerFunc {
 //	}
 //
 // Example usage:
-// r.PUT("/complejos/admin", UpdateComplejoForAdmin(collection))
-func UpdateComplejoForAdmin(collection *mongo.Collection) gin.HandlerFunc {
+// r.PUT("/complejo/admin/:id", UpdateComplejoForAdmin(svc))
+func UpdateComplejoForAdmin(svc *services.ComplejoService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		// Retrieve the id and role from the context (set by the JWT middleware)
+		// Retrieve the role from the context (set by the JWT middleware)
 		role, roleExists := c.Get("role")
-		id, idExist := c.Get("_id")
-		if !roleExists || role != "admin" && !idExist || id != "_id" {
+		if !roleExists || role != "admin" {
 			// 403 Forbidden: Insufficient permissions
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"code":    http.StatusForbidden,
-				"message": "You do not have permission to update this Complejo.",
-			})
+			c.Error(apperrors.Forbidden("You do not have permission to update this Complejo."))
 			return
 		}
 
-		// Parse the incoming JSON to a map for flexible updates
-		var updateData map[string]interface{}
-		if err := c.ShouldBindJSON(&updateData); err != nil {
-			// 400 Bad Request: Invalid JSON format
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"code":    http.StatusBadRequest,
-				"message": "Invalid JSON format: " + err.Error(),
-			})
+		// Parse the incoming JSON into a typed update (unknown fields are rejected)
+		var update models.ComplejoUpdate
+		if err := validation.BindJSON(c, &update); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
 			return
 		}
 
-		// Remove `_id` to avoid overwriting the document ID
-		delete(updateData, "_id")
+		// Perform the update operation on the fields present in the payload
+		if err := svc.UpdateForAdmin(c, c.Param("id"), update); err != nil {
+			// 400 Bad Request, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
 
-		// Prepare the update payload
-		update := bson.M{"$set": updateData}
+		// 200 OK: Successfully updated the Complejo
+		responses.Message(c, http.StatusOK, "Complejo updated successfully")
+	}
+}
 
-		// Perform the update operation
-		result, err := collection.UpdateOne(c, bson.M{"_id": id}, update)
-		if err != nil {
-			// 500 Internal Server Error: Database update failed
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to update Complejo: " + err.Error(),
-			})
+// DeleteOwnComplejo deletes the authenticated user's own Complejo.
+//
+// This function:
+// 1. Extracts the user's ID from the JWT token.
+// 2. Marks the Complejo as deleted and withdraws its username from every Event it joined, in one operation.
+//
+// HTTP Status Codes:
+// - 204 No Content: The Complejo was successfully deleted.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 500 Internal Server Error: An issue occurred while deleting the Complejo.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+//
+// Example usage:
+// r.DELETE("/complejo/me", DeleteOwnComplejo(svc))
+func DeleteOwnComplejo(svc *services.ComplejoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
 			return
 		}
 
-		// Handle the case where no document was updated
-		if result.MatchedCount == 0 {
-			// 404 Not Found: Document with the given ID does not exist
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"code":    http.StatusNotFound,
-				"message": "Complejo not found",
-			})
+		if err := svc.Delete(c, id.(string)); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
 			return
 		}
 
-		// 200 OK: Successfully updated the Complejo
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "success",
-			"code":    http.StatusOK,
-			"message": "Complejo updated successfully",
-		})
+		// 204 No Content: The Complejo was successfully deleted
+		responses.NoContent(c)
+	}
+}
+
+// DeleteComplejo deletes any Complejo by ID, restricted to admin role.
+//
+// This function marks the Complejo as deleted and withdraws its username from every Event it joined, in one operation.
+// The Complejo can be restored until it is purged.
+//
+// HTTP Status Codes:
+// - 204 No Content: The Complejo was successfully deleted.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while deleting the Complejo.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+//
+// Example usage:
+// r.DELETE("/complejo/:id", DeleteComplejo(svc))
+func DeleteComplejo(svc *services.ComplejoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to delete this Complejo."))
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id")); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The Complejo was successfully deleted
+		responses.NoContent(c)
+	}
+}
+
+// RestoreComplejo restores a deleted Complejo by ID, restricted to admin role.
+//
+// Deleted Complejos stay restorable until the purge job removes them after the retention window.
+// Their event subscriptions are not restored, and erased Complejos cannot be restored.
+//
+// HTTP Status Codes:
+// - 200 OK: The Complejo was successfully restored.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: No deleted Complejo with the specified ID exists.
+// - 500 Internal Server Error: An issue occurred while restoring the Complejo.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+//
+// Example usage:
+// r.PUT("/complejo/:id/restore", RestoreComplejo(svc))
+func RestoreComplejo(svc *services.ComplejoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to restore this Complejo."))
+			return
+		}
+
+		if err := svc.Restore(c, c.Param("id")); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The Complejo was successfully restored
+		responses.Message(c, http.StatusOK, "Complejo restored successfully")
 	}
 }
@@ -0,0 +1,85 @@
+// complejo_handler_test.go
+package handlers
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"los-complejos-backend/middleware"
+	"los-complejos-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newUpdateRouter serves the update routes as a caller with the given role. The services are never reached: the
+// requests are refused by the role checks or the binding.
+func newUpdateRouter(role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ErrorMiddleware(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	r.Use(func(c *gin.Context) {
+		c.Set("_id", "c1")
+		if role != "" {
+			c.Set("role", role)
+		}
+		c.Next()
+	})
+	complejos, events := &services.ComplejoService{}, &services.EventService{}
+	r.PUT("/complejo/user", UpdateComplejoForUser(complejos))
+	r.PUT("/complejo/admin/:id", UpdateComplejoForAdmin(complejos))
+	r.PUT("/event/admin/:id", UpdateEventForAdmin(events))
+	return r
+}
+
+func TestUpdateRoutesCheckTheRole(t *testing.T) {
+	tests := []struct {
+		name string
+		role string
+		path string
+	}{
+		{"admin on the user route", "admin", "/complejo/user"},
+		{"no role on the user route", "", "/complejo/user"},
+		{"user on the admin route", "user", "/complejo/admin/c2"},
+		{"no role on the admin route", "", "/complejo/admin/c2"},
+		{"user on the event admin route", "user", "/event/admin/e1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(`{"weight":80}`))
+			newUpdateRouter(tt.role).ServeHTTP(w, req)
+			if w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d: %s", w.Code, http.StatusForbidden, w.Body)
+			}
+		})
+	}
+}
+
+func TestUpdateRoutesRejectUnknownFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		role  string
+		path  string
+		body  string
+		field string
+	}{
+		{"user", "user", "/complejo/user", `{"dead_lift":200}`, "dead_lift"},
+		{"user changing its role", "user", "/complejo/user", `{"role":"admin"}`, "role"},
+		{"admin", "admin", "/complejo/admin/c2", `{"weight":80,"is_admin":true}`, "is_admin"},
+		{"admin on an event", "admin", "/event/admin/e1", `{"titel":"Ride"}`, "titel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			newUpdateRouter(tt.role).ServeHTTP(w, req)
+			if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), tt.field) {
+				t.Errorf("status = %d, body = %s, want %d naming %q", w.Code, w.Body, http.StatusUnprocessableEntity, tt.field)
+			}
+		})
+	}
+}
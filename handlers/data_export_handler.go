@@ -0,0 +1,101 @@
+// data_export_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestDataExport exports everything stored about the authenticated user (GDPR right of access): the
+// archive is generated in the background, so the first call requests it and answers 202 Accepted, and the
+// next calls answer 202 until it is ready, then 200 OK with its download link. The link carries a signed
+// token, so keep it private; it works until the archive expires, after which a call requests a new one.
+//
+// HTTP Status Codes:
+// - 200 OK: The archive is ready; the response has its link.
+// - 202 Accepted: The archive was requested, or is still being generated.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 500 Internal Server Error: An issue occurred while requesting the export.
+//
+// Parameters:
+// - svc (*services.DataExportService): The service that exports the data of the Complejos.
+//
+// Example response data (once ready):
+//
+//	{
+//	    "_id": "...",
+//	    "complejo_id": "...",
+//	    "status": "ready",
+//	    "requested_at": "2026-10-16T09:00:00Z",
+//	    "completed_at": "2026-10-16T09:00:04Z",
+//	    "expires_at": "2026-10-23T09:00:04Z",
+//	    "size": 48213,
+//	    "link": "/complejo/.../export.zip?token=3f9a...c41e"
+//	}
+//
+// Example usage:
+// r.GET("/complejo/me/export", RequestDataExport(svc))
+func RequestDataExport(svc *services.DataExportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		export, err := svc.Request(c, id.(string))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		if export.Status == models.DataExportPending {
+			// 202 Accepted: The archive is being generated
+			responses.Accepted(c, export)
+			return
+		}
+		// 200 OK: The archive is ready
+		responses.OK(c, export)
+	}
+}
+
+// DownloadDataExport downloads the archive of the latest export of a Complejo: a zip of JSON files
+// (profile.json, events.json, subscriptions.json, devices.json, loans.json, lifts.json, workouts.json,
+// nutrition.json) and its profile photo. No JWT is needed: the download is authenticated by the signed `?token=`
+// query parameter of the link returned by GET /complejo/me/export.
+//
+// HTTP Status Codes:
+// - 200 OK: The archive was successfully downloaded.
+// - 401 Unauthorized: The token is missing or does not match the latest export.
+// - 404 Not Found: No export was requested, or it expired.
+// - 409 Conflict: The archive is still being generated.
+// - 500 Internal Server Error: An issue occurred while reading the archive.
+//
+// Parameters:
+// - svc (*services.DataExportService): The service that exports the data of the Complejos.
+//
+// Example usage:
+// r.GET("/complejo/:id/export.zip", DownloadDataExport(svc))
+func DownloadDataExport(svc *services.DataExportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		archive, err := svc.Download(c, c.Param("id"), c.Query("token"))
+		if err != nil {
+			// 401 Unauthorized, 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Archive downloaded
+		c.Header("Content-Disposition", `attachment; filename="los-complejos-data.zip"`)
+		c.Data(http.StatusOK, "application/zip", archive)
+	}
+}
@@ -0,0 +1,166 @@
+// device_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWebPushKey returns the key browsers subscribe to push notifications with (the `applicationServerKey`
+// of `pushManager.subscribe`). No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the key.
+// - 403 Forbidden: Web Push is disabled on this server.
+//
+// Parameters:
+// - svc (*services.PushService): The service that delivers the push notifications.
+//
+// Example usage:
+// r.GET("/devices/webpush-key", GetWebPushKey(svc))
+func GetWebPushKey(svc *services.PushService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, err := svc.WebPushKey()
+		if err != nil {
+			// 403 Forbidden: Web Push is disabled
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the key
+		responses.OK(c, key)
+	}
+}
+
+// RegisterDevice registers an app or browser of the authenticated Complejo for push notifications: the
+// reminders of the events it is going to and the announcements of the admins. Apps send their FCM registration
+// token and browsers their push subscription. Registering a token again updates it, moving it to the Complejo.
+//
+// HTTP Status Codes:
+// - 201 Created: The device was successfully registered.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: Push notifications are disabled for the platform.
+// - 422 Unprocessable Entity: The platform is not "fcm" or "webpush", or its token or subscription is
+// missing or invalid.
+// - 500 Internal Server Error: An issue occurred while registering the device.
+//
+// Parameters:
+// - svc (*services.PushService): The service that delivers the push notifications.
+//
+// Example JSON payloads:
+//
+//	{
+//	    "platform": "fcm",
+//	    "token": "dQw4w9WgXcQ:APA91bH...",
+//	    "name": "Pixel 8"
+//	}
+//
+//	{
+//	    "platform": "webpush",
+//	    "subscription": {
+//	        "endpoint": "https://fcm.googleapis.com/fcm/send/c1KrmpTuRm...",
+//	        "keys": {"p256dh": "BNcRdreALRFXTkOOUHK1EtK2wtaz5Ry4YfYCA_0QTpQtUbVlUls0VJXg7A8u-Ts1XbjhazAkj7I99e8QcYP7DkM", "auth": "tBHItJI5svbpez7KI4CCXg"}
+//	    }
+//	}
+//
+// Example usage:
+// r.POST("/devices", RegisterDevice(svc))
+func RegisterDevice(svc *services.PushService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.DeviceInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		device, err := svc.Register(c, id.(string), input)
+		if err != nil {
+			// 403 Forbidden, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The device was successfully registered
+		responses.Created(c, device)
+	}
+}
+
+// GetDevices lists the devices registered by the authenticated Complejo, most recently registered first.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the devices.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 500 Internal Server Error: An issue occurred while fetching the devices.
+//
+// Parameters:
+// - svc (*services.PushService): The service that delivers the push notifications.
+//
+// Example usage:
+// r.GET("/devices", GetDevices(svc))
+func GetDevices(svc *services.PushService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		devices, err := svc.Devices(c, id.(string))
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the devices
+		responses.OK(c, devices)
+	}
+}
+
+// UnregisterDevice stops the push notifications to a device of the authenticated Complejo.
+//
+// HTTP Status Codes:
+// - 204 No Content: The device was successfully unregistered.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo has no device with the specified ID.
+// - 500 Internal Server Error: An issue occurred while unregistering the device.
+//
+// Parameters:
+// - svc (*services.PushService): The service that delivers the push notifications.
+//
+// Example usage:
+// r.DELETE("/devices/:id", UnregisterDevice(svc))
+func UnregisterDevice(svc *services.PushService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Unregister(c, c.Param("id"), id.(string)); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The device was successfully unregistered
+		responses.NoContent(c)
+	}
+}
@@ -0,0 +1,117 @@
+// erasure_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EraseOwnComplejo erases the personal data of the authenticated user (GDPR right to be forgotten). The current
+// username must be typed again in the body to confirm it, since the erasure cannot be undone.
+//
+// The profile keeps only its ID: the username becomes "erased-<id>", which also replaces it in the guests it
+// brought, its subscription history, volunteer sign-ups, loans and claims, and the other personal fields are
+// cleared. The user leaves the participants of every Event and its photo tags are removed; the photos it uploaded,
+// its lost-and-found posts, its content held for review, its devices, its lift history, its workouts, its
+// nutrition logs, its challenge participations, its group memberships, its follows and blocks (both ways), its activity in the feed, the reports it filed and its latest data export are deleted. The profile is then deleted like with DELETE
+// /complejo/:id, but can no longer be restored. The erasure is recorded in the audit log, which names the user by
+// its ID only; the response is that entry.
+//
+// HTTP Status Codes:
+// - 200 OK: The personal data was erased.
+// - 400 Bad Request: The body is not valid JSON.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists, or is deleted.
+// - 422 Unprocessable Entity: The username is missing or is not the current one.
+// - 500 Internal Server Error: An issue occurred while erasing the data.
+//
+// Parameters:
+// - svc (*services.ErasureService): The service that erases the personal data of the Complejos.
+//
+// Example request body:
+//
+//	{
+//	    "username": "maria"
+//	}
+//
+// Example response data:
+//
+//	{
+//	    "_id": "...",
+//	    "action": "complejo.erased",
+//	    "actor_id": "...",
+//	    "target_id": "...",
+//	    "details": {"events_left": 2, "anonymized_loans": 1, "removed_event_photos": 3, "removed_devices": 1},
+//	    "occurred_at": "2026-10-16T09:00:00Z"
+//	}
+//
+// Example usage:
+// r.POST("/complejo/me/erase", EraseOwnComplejo(svc))
+func EraseOwnComplejo(svc *services.ErasureService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		erase(c, svc, id.(string), id.(string))
+	}
+}
+
+// EraseComplejo erases the personal data of a Complejo by ID, restricted to admin role, like
+// POST /complejo/me/erase does for the authenticated user. The current username of the Complejo must be typed
+// again in the body to confirm it. A deleted Complejo must be restored (PUT /complejo/:id/restore) before it can
+// be erased.
+//
+// HTTP Status Codes:
+// - 200 OK: The personal data was erased.
+// - 400 Bad Request: The body is not valid JSON.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: No Complejo with the specified ID exists, or it is deleted.
+// - 422 Unprocessable Entity: The username is missing or is not the current one.
+// - 500 Internal Server Error: An issue occurred while erasing the data.
+//
+// Parameters:
+// - svc (*services.ErasureService): The service that erases the personal data of the Complejos.
+//
+// Example usage:
+// r.POST("/complejo/:id/erase", EraseComplejo(svc))
+func EraseComplejo(svc *services.ErasureService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to erase this Complejo."))
+			return
+		}
+
+		erase(c, svc, c.Param("id"), c.GetString("_id"))
+	}
+}
+
+// erase binds the confirmation and erases the Complejo with the given ID on behalf of the actor.
+func erase(c *gin.Context, svc *services.ErasureService, complejoID, actorID string) {
+	var confirmation models.ErasureConfirmation
+	if err := validation.BindJSON(c, &confirmation); err != nil {
+		// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+		c.Error(err)
+		return
+	}
+
+	entry, err := svc.Erase(c, complejoID, actorID, confirmation)
+	if err != nil {
+		// 404 Not Found, 422 Unprocessable Entity or 500 Internal Server Error
+		c.Error(err)
+		return
+	}
+
+	// 200 OK: The personal data was erased
+	responses.OK(c, entry)
+}
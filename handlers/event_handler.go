@@ -2,205 +2,217 @@
 package handlers
 
 import (
-	"fmt"
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/ical"
 	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // CreateEvent allows only admin users to create a new event and insert it into the MongoDB collection.
 //
 // This function:
 // 1. Validates the user's role to ensure they are an admin.
-// 2. Parses the incoming JSON payload to create a new Event document.
+// 2. Parses the incoming JSON payload to create a new Event document (the description is Markdown).
 // 3. Inserts the Event into the MongoDB collection.
 //
 // HTTP Status Codes:
 // - 201 Created: The Event was successfully created.
 // - 400 Bad Request: Invalid JSON data was provided.
 // - 403 Forbidden: The user does not have sufficient permissions to create an event.
+// - 422 Unprocessable Entity: Required fields are missing, the date is not in the future, the capacity is out of range,
+// or the level, intensity or level gate is not one of the allowed values.
 // - 500 Internal Server Error: An issue occurred while inserting the Event into the database.
 //
-// Example JSON payload:
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example JSON payload (capacity, level, intensity and level_gate are optional; a capacity of 0 means no limit):
 //
 //	{
 //	    "title": "Gym Meetup",
 //	    "description": "A gathering of fitness enthusiasts.",
 //	    "date": "2025-02-01T10:00:00Z",
-//	    "location": "Local Gym, Main Street"
+//	    "location": "Local Gym, Main Street",
+//	    "capacity": 30,
+//	    "level": "advanced",
+//	    "intensity": "high",
+//	    "level_gate": "warn"
 //	}
 //
 // Example usage:
-// r.POST("/event", CreateEvent(collection))
-func CreateEvent(collection *mongo.Collection) gin.HandlerFunc {
+// r.POST("/event", CreateEvent(svc))
+func CreateEvent(svc *services.EventService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Retrieve the role from the context (set by the JWT middleware)
 		role, exists := c.Get("role")
 		if !exists {
-			// Log a message if the token is missing or invalid
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  "error",
-				"code":    http.StatusUnauthorized,
-				"message": "Authorization token is missing or invalid",
-			})
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
 			return
 		}
 
-		// Debug: Log the role extracted from the token
-		fmt.Println("Token validated successfully. Role:", role)
-
 		if role != "admin" {
 			// 403 Forbidden: Insufficient permissions
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"code":    http.StatusForbidden,
-				"message": "You do not have permission to create events.",
-			})
+			c.Error(apperrors.Forbidden("You do not have permission to create events."))
 			return
 		}
 
-		// Parse the incoming JSON request into the Event model
+		// Parse and validate the incoming JSON request into the Event model
 		var event models.Event
-		if err := c.ShouldBindJSON(&event); err != nil {
+		if err := validation.BindJSON(c, &event); err != nil {
 			// 400 Bad Request: Invalid JSON format
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"code":    http.StatusBadRequest,
-				"message": "Invalid JSON format: " + err.Error(),
-			})
+			// 422 Unprocessable Entity: Required fields are missing or the date is not in the future
+			c.Error(err)
 			return
 		}
 
-		// Generate a unique ID for the event
-		event.ID = uuid.NewString()
-		document := bson.M{
-			"_id":          event.ID,
-			"title":        event.Title,
-			"description":  event.Description,
-			"participants": event.Participants,
-			"date":         event.Date,
-			"image":        event.Image,
-			"location":     event.Location,
-		}
+		// RSVPs, guests, pins, features, forecasts, routes and photos are managed through their own endpoints
+		event.ClearManaged()
 
-		// Insert the event into the MongoDB collection
-		_, err := collection.InsertOne(c, document)
-		if err != nil {
+		// Generate a unique ID for the event and store it with its creator
+		if err := svc.Create(c, &event, c.GetString("_id")); err != nil {
 			// 500 Internal Server Error: Database insertion failed
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to create event: " + err.Error(),
-			})
+			c.Error(err)
 			return
 		}
 
 		// 201 Created: The Event was successfully created
-		c.JSON(http.StatusCreated, gin.H{
-			"status":  "success",
-			"code":    http.StatusCreated,
-			"message": "Event created successfully",
-			"data":    event,
-		})
+		responses.Created(c, event)
 	}
 }
 
-// GetEvents retrieves all Event documents from the MongoDB collection.
+// GetEvents retrieves a page of Event documents, optionally filtered by date range and location.
 //
-// This function fetches all Event documents from the MongoDB collection.
-// If no Events are found, it responds with a 404 status.
+// This function reads the filters and pagination from the query string and returns the matching
+// Events sorted by date, together with the pagination metadata (page, limit, total, pages).
+// Each Event carries its RSVPs and their counts by status (`rsvp_counts`), and its `like_count`
+// (with `liked_by_me` when a token is sent).
+// If no Events match, it responds with a 404 status.
+//
+// Query parameters (all optional, defaulted by the listing settings of the club):
+// - from, to: RFC 3339 timestamps bounding the event date (inclusive). Without them, the events dated more than
+// `hide_past_after_days` days ago are hidden when the settings say so.
+// - location: Case-insensitive substring of the event location.
+// - group: ID of a group, to list only its events.
+// - sort: Order by date, "asc" or "desc" (default: "asc").
+// - page: 1-based page number (default: 1).
+// - limit: Page size (default: 20, at most 100).
 //
 // HTTP Status Codes:
-// - 200 OK: Successfully retrieved all Events.
-// - 404 Not Found: No Events were found in the database.
+// - 200 OK: Successfully retrieved the page of Events.
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 404 Not Found: No Events match the filters.
+// - 422 Unprocessable Entity: The page or limit is out of range, or `to` is before `from`.
 // - 500 Internal Server Error: An issue occurred while fetching or processing the data.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Event documents are stored.
+// - svc (*services.EventService): The service that manages Event resources.
 //
 // Example usage:
-// r.GET("/events", GetEvents(collection))
+// r.GET("/event", GetEvents(svc))
+//
+// Example request:
+// GET /event?from=2025-02-01T00:00:00Z&location=gym&page=2&limit=10
+func GetEvents(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Parse and validate the filters
+		var filter models.EventFilter
+		if err := validation.BindQuery(c, &filter); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+		if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+			// 422 Unprocessable Entity: Empty date range
+			c.Error(apperrors.Validation("Validation failed", []validation.FieldError{
+				{Field: "to", Rule: "gtefield", Message: "must not be before from"},
+			}))
+			return
+		}
 
-// GetEvent retrieves a single Event by ID from the MongoDB collection.
+		// Retrieve the requested page of Events
+		events, total, err := svc.Search(c, &filter)
+		if err != nil {
+			// 500 Internal Server Error: Database query failed
+			c.Error(err)
+			return
+		}
+
+		for i := range events {
+			events[i].MarkLiked(c.GetString("_id"))
+		}
+
+		// Handle the case where no Event are found
+		if total == 0 {
+			// 404 Not Found: No Event exist
+			c.Error(apperrors.NotFound("No Event found in the database"))
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of Events
+		responses.OKWithMeta(c, events, responses.NewPagination(filter.Page, filter.Limit, total))
+	}
+}
+
+// SearchEvents performs a full-text search over the title, description and location of the Events.
+//
+// Results are ranked by relevance (title matches weigh the most) and each one carries its `score`
+// and its RSVP counts by status (`rsvp_counts`).
 //
-// This function fetches a single Event document using its unique `_id`.
-// If the document is not found, it responds with a 404 status.
+// Query parameters:
+// - q: Words to look for (required).
+// - limit: Maximum number of results (default: 20, at most 100).
 //
 // HTTP Status Codes:
-// - 200 OK: Successfully retrieved the Event.
-// - 404 Not Found: The Event with the specified ID was not found.
-// - 500 Internal Server Error: Failed to fetch or process the Event.
+// - 200 OK: Successfully searched the Events (possibly no results).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 422 Unprocessable Entity: The query is missing or the limit is out of range.
+// - 500 Internal Server Error: An issue occurred while searching the Events.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Event documents are stored.
+// - svc (*services.EventService): The service that manages Event resources.
 //
 // Example usage:
-// r.GET("/event/:id", GetEvent(collection))
-func GetEvents(collection *mongo.Collection) gin.HandlerFunc {
+// r.GET("/event/search", SearchEvents(svc))
+//
+// Example request:
+// GET /event/search?q=powerlifting+meet&limit=5
+func SearchEvents(svc *services.EventService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Find all documents in the collection
-		cursor, err := collection.Find(c, bson.M{})
-		if err != nil {
-			// 500 Internal Server Error: Database query failed
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to fetch Event from the database: " + err.Error(),
-			})
-			return
-		}
-		defer func() {
-			if err := cursor.Close(c); err != nil {
-				// 500 Internal Server Error: Failed to close the cursor
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"status":  "error",
-					"code":    http.StatusInternalServerError,
-					"message": "Failed to close the database cursor: " + err.Error(),
-				})
-			}
-		}()
-
-		// Parse the cursor results into a slice of Event
-		var events []models.Event
-		if err := cursor.All(c, &events); err != nil {
-			// 500 Internal Server Error: Failed to parse data
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to parse Events data: " + err.Error(),
-			})
+		var search models.EventSearch
+		if err := validation.BindQuery(c, &search); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
 			return
 		}
 
-		// Handle the case where no Event are found
-		if len(events) == 0 {
-			// 404 Not Found: No Event exist
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"code":    http.StatusNotFound,
-				"message": "No Event found in the database",
-			})
+		events, err := svc.TextSearch(c, search)
+		if err != nil {
+			// 500 Internal Server Error: Search failed
+			c.Error(err)
 			return
 		}
+		for i := range events {
+			events[i].MarkLiked(c.GetString("_id"))
+		}
 
-		// 200 OK: Successfully retrieved all Event
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "success",
-			"code":    http.StatusOK,
-			"message": "Event retrieved successfully",
-			"data":    events,
-		})
+		// 200 OK: Successfully searched the Events
+		responses.OK(c, events)
 	}
 }
 
 // GetEvent retrieves a single Event by ID from the MongoDB collection.
 //
-// This function fetches a single Event document using its unique `_id`.
-// If the document is not found, it responds with a 404 status.
+// This function fetches a single Event document using its unique `_id`, with its RSVPs
+// and their counts by status (`rsvp_counts`), its `like_count` (with `liked_by_me` when a token is sent),
+// its Markdown description rendered as sanitized HTML (`description_html`) and, for an upcoming outdoor Event,
+// its weather forecast (`weather`), cached for a few hours, and its photo `album` as the requester may see it
+// (see GetEventPhotos). If the document is not found, it responds with a 404 status.
 //
 // HTTP Status Codes:
 // - 200 OK: Successfully retrieved the Event.
@@ -208,60 +220,54 @@ func GetEvents(collection *mongo.Collection) gin.HandlerFunc {
 // - 500 Internal Server Error: Failed to fetch or process the Event.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Event documents are stored.
+// - svc (*services.EventService): The service that manages Event resources.
+// - forecasts (*services.WeatherService): The service that forecasts the weather of outdoor Events.
+// - photos (*services.PhotoService): The service that manages the event albums.
 //
 // Example usage:
-// r.GET("/event/:id", GetEvent(collection))
-func GetEvent(collection *mongo.Collection) gin.HandlerFunc {
+// r.GET("/event/:id", GetEvent(svc, forecasts, photos))
+func GetEvent(svc *services.EventService, forecasts *services.WeatherService, photos *services.PhotoService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id := c.Param("id")
+		// Find the Event by "_id"
+		event, err := svc.Get(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+		event.MarkLiked(c.GetString("_id"))
 
-		// Find the document in the collection by "_id"
-		var event models.Event
-		err := collection.FindOne(c, bson.M{"_id": id}).Decode(&event)
+		// An unavailable forecast never fails the request
+		forecasts.Forecast(c, event)
+
+		event.Album, err = photos.Album(c, event, c.GetString("_id"), c.GetString("role") == "admin")
 		if err != nil {
-			// 404 Not Found: Document not found
-			if err == mongo.ErrNoDocuments {
-				c.JSON(http.StatusNotFound, gin.H{
-					"status":  "error",
-					"code":    http.StatusNotFound,
-					"message": "Event not found",
-				})
-				return
-			}
-			// 500 Internal Server Error: Query error
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to retrieve Event: " + err.Error(),
-			})
+			// 500 Internal Server Error: Failed to fetch the album
+			c.Error(err)
 			return
 		}
 
 		// 200 OK: Successfully retrieved the Event
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "success",
-			"code":    http.StatusOK,
-			"message": "Event retrieved successfully",
-			"data":    event,
-		})
+		responses.OK(c, event)
 	}
 }
 
 // UpdateEventForAdmin updates specific fields of an Event by ID, restricted to admin role.
 //
-// This function allows administrators with the "admin" role to update any field of an Event document.
-// Unlike user updates, admin updates have no restrictions on the fields that can be modified.
+// This function allows administrators with the "admin" role to update the title, description, date, image,
+// location, capacity, level, intensity, level gate and outdoor flag of an Event document. Only the fields present in the payload are updated; any other field is rejected.
 //
 // HTTP Status Codes:
 // - 200 OK: Successfully updated the Event.
-// - 400 Bad Request: Invalid JSON data was provided.
+// - 400 Bad Request: Invalid JSON data, a field of the wrong type or no updatable fields were included in the payload.
 // - 403 Forbidden: The user does not have sufficient permissions to perform this action.
 // - 404 Not Found: The Event with the specified ID was not found.
+// - 422 Unprocessable Entity: A field is empty, the date is not in the future, the capacity is out of range,
+// the level, intensity or level gate is not one of the allowed values, or a field is unknown.
 // - 500 Internal Server Error: An issue occurred while updating the Event in the database.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Event documents are stored.
+// - svc (*services.EventService): The service that manages Event resources.
 //
 // Example JSON payload for updating an Event:
 //
@@ -271,206 +277,977 @@ func GetEvent(collection *mongo.Collection) gin.HandlerFunc {
 //	}
 //
 // Example usage:
-// r.PUT("/event/admin", UpdateEventForAdmin(collection))
-func UpdateEventForAdmin(collection *mongo.Collection) gin.HandlerFunc {
+// r.PUT("/event/admin/:id", UpdateEventForAdmin(svc))
+func UpdateEventForAdmin(svc *services.EventService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		// Retrieve the id and role from the context (set by the JWT middleware)
+		// Retrieve the role from the context (set by the JWT middleware)
 		role, roleExists := c.Get("role")
-		id, idExist := c.Get("_id")
-		if !roleExists || role != "admin" && !idExist || id != "_id" {
+		if !roleExists || role != "admin" {
 			// 403 Forbidden: Insufficient permissions
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"code":    http.StatusForbidden,
-				"message": "You do not have permission to update this Complejo.",
-			})
+			c.Error(apperrors.Forbidden("You do not have permission to update this Event."))
 			return
 		}
 
-		// Parse the incoming JSON to a map for flexible updates
-		var updateData map[string]interface{}
-		if err := c.ShouldBindJSON(&updateData); err != nil {
-			// 400 Bad Request: Invalid JSON format
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"code":    http.StatusBadRequest,
-				"message": "Invalid JSON format: " + err.Error(),
-			})
+		// Parse the incoming JSON into a typed update (unknown fields are rejected)
+		var update models.EventUpdate
+		if err := validation.BindJSON(c, &update); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
 			return
 		}
 
-		// Remove `_id` to avoid overwriting the document ID
-		delete(updateData, "_id")
+		// Perform the update operation on the fields present in the payload
+		if err := svc.UpdateForAdmin(c, c.Param("id"), update); err != nil {
+			// 400 Bad Request, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
 
-		// Prepare the update payload
-		update := bson.M{"$set": updateData}
+		// 200 OK: Successfully updated the Event
+		responses.Message(c, http.StatusOK, "Event updated successfully")
+	}
+}
 
-		// Perform the update operation
-		result, err := collection.UpdateOne(c, bson.M{"_id": id}, update)
-		if err != nil {
-			// 500 Internal Server Error: Database update failed
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"code":    http.StatusInternalServerError,
-				"message": "Failed to update Complejo: " + err.Error(),
-			})
+// UpdateEvent updates specific fields of an Event by ID, restricted to admins and the Complejo that created the Event.
+//
+// This function:
+// 1. Parses the fields present in the payload; any other field is rejected.
+// 2. Checks that the caller is an admin or the creator of the Event.
+// 3. Updates the fields and announces the changes.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully updated the Event.
+// - 400 Bad Request: Invalid JSON data, a field of the wrong type or no updatable fields were included in the payload.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 422 Unprocessable Entity: A field is empty, the date is not in the future, the capacity is out of range,
+// the level, intensity or level gate is not one of the allowed values, or a field is unknown.
+// - 500 Internal Server Error: An issue occurred while updating the Event in the database.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example JSON payload for updating an Event:
+//
+//	{
+//	    "date": "2025-02-08T10:00:00Z",
+//	    "capacity": 40
+//	}
+//
+// Example usage:
+// r.PUT("/event/:id", UpdateEvent(svc))
+func UpdateEvent(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var update models.EventUpdate
+		if err := validation.BindJSON(c, &update); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
 			return
 		}
 
-		// Handle the case where no document was updated
-		if result.MatchedCount == 0 {
-			// 404 Not Found: Document with the given ID does not exist
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"code":    http.StatusNotFound,
-				"message": "Complejo not found",
-			})
+		if err := svc.Update(c, c.Param("id"), update, id.(string), role == "admin"); err != nil {
+			// 400 Bad Request, 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
 			return
 		}
 
-		// 200 OK: Successfully updated the Complejo
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "success",
-			"code":    http.StatusOK,
-			"message": "Complejo updated successfully",
-		})
+		// 200 OK: Successfully updated the Event
+		responses.Message(c, http.StatusOK, "Event updated successfully")
 	}
 }
 
-// SubscribeEvent allows a user to subscribe to an Event by adding their username to the Event's participants.
+// DeleteEvent removes an Event by ID, restricted to admins and the Complejo that created the Event.
 //
 // This function:
-// 1. Extracts the username from the JWT token.
-// 2. Adds the username to the Event's participants list using MongoDB's `$addToSet` operator.
+// 1. Checks that the caller is an admin or the creator of the Event.
+// 2. Marks the Event as deleted (it can be restored until it is purged).
+// 3. Announces the deletion so the Complejos going or maybe going can be notified.
 //
 // HTTP Status Codes:
-// - 200 OK: Successfully subscribed to the Event.
-// - 403 Forbidden: The user does not have a valid username.
+// - 204 No Content: The Event was successfully deleted.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
 // - 404 Not Found: The Event with the specified ID was not found.
-// - 409 Conflict: The user is already subscribed to the Event.
-// - 500 Internal Server Error: An issue occurred while subscribing to the Event.
+// - 500 Internal Server Error: An issue occurred while deleting the Event.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Event documents are stored.
+// - svc (*services.EventService): The service that manages Event resources.
 //
 // Example usage:
-// r.PUT("/event/:id/subscribe", SubscribeEvent(collection))
-func SubscribeEvent(collection *mongo.Collection) gin.HandlerFunc {
+// r.DELETE("/event/:id", DeleteEvent(svc))
+func DeleteEvent(svc *services.EventService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		eventID := c.Param("_id")
-		username, exist := c.Get("username")
-		if !exist || username == "username" {
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"code":    http.StatusForbidden,
-				"message": "You do not have a valid username.",
-			})
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
 			return
 		}
 
-		update := bson.M{
-			"$addToSet": bson.M{"participants": username},
+		if err := svc.Delete(c, c.Param("id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
 		}
 
-		result, err := collection.UpdateOne(c, bson.M{"_id": eventID}, update)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"message": "Failed to subscribe to the event: " + err.Error(),
-			})
+		// 204 No Content: The Event was successfully deleted
+		responses.NoContent(c)
+	}
+}
+
+// RestoreEvent restores a deleted Event by ID, restricted to admin role.
+//
+// Deleted Events stay restorable until the purge job removes them after the retention window.
+//
+// HTTP Status Codes:
+// - 200 OK: The Event was successfully restored.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: No deleted Event with the specified ID exists.
+// - 500 Internal Server Error: An issue occurred while restoring the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.PUT("/event/:id/restore", RestoreEvent(svc))
+func RestoreEvent(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to restore this Event."))
 			return
 		}
 
-		if result.MatchedCount == 0 {
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": "Event not found",
-			})
+		if err := svc.Restore(c, c.Param("id")); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The Event was successfully restored
+		responses.Message(c, http.StatusOK, "Event restored successfully")
+	}
+}
+
+// SubscribeEvent allows a user to subscribe to an Event by answering "going" to it.
+// The route is deprecated in favor of RSVPEvent (see changelog.Entries).
+//
+// This function:
+// 1. Extracts the ID and username of the Complejo from the JWT token.
+// 2. Rejects the subscription if the Event already took place.
+// 3. Applies the level gate of an advanced session: beginners (by their recorded lifts) are warned in the message
+// or rejected, unless the organizer let them through.
+// 4. Rejects the subscription to the Event of a group the Complejo is not a member of.
+// 5. Sets the Complejo's RSVP on the Event to "going".
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully subscribed to the Event.
+// - 403 Forbidden: The user does not have a valid username, the level gate blocks beginners from the Event, or
+// the Event belongs to a group the user is not a member of.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 409 Conflict: The user is already subscribed to the Event, or the Event is in the past or full.
+// - 500 Internal Server Error: An issue occurred while subscribing to the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.PUT("/event/:id/subscribe", SubscribeEvent(svc))
+func SubscribeEvent(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID := c.Param("id")
+		id, idExist := c.Get("_id")
+		username, exist := c.Get("username")
+		if !idExist || !exist || username == "username" {
+			c.Error(apperrors.Forbidden("You do not have a valid username."))
 			return
 		}
 
-		if result.ModifiedCount == 0 {
-			c.JSON(http.StatusConflict, gin.H{
-				"status":  "error",
-				"message": "Complejo is already subscribed to the event.",
-			})
+		warning, err := svc.Subscribe(c, eventID, id.(string), username.(string))
+		if err != nil {
+			// 403 Forbidden, 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "success",
-			"message": "Successfully subscribed to the event",
-		})
+		if warning != "" {
+			responses.Message(c, http.StatusOK, "Successfully subscribed to the event. "+warning)
+			return
+		}
+		responses.Message(c, http.StatusOK, "Successfully subscribed to the event")
 	}
 }
 
-// UnsuscribeEvent allows a user to unsubscribe from an Event by removing their username from the Event's participants.
+// UnsuscribeEvent allows a user to unsubscribe from an Event by withdrawing their "going" RSVP.
+// The route is deprecated in favor of RSVPEvent (see changelog.Entries).
 //
 // This function:
-// 1. Extracts the username from the JWT token.
-// 2. Removes the username from the Event's participants list using MongoDB's `$pull` operator.
+// 1. Extracts the ID and username of the Complejo from the JWT token.
+// 2. Removes the Complejo's "going" RSVP from the Event.
 //
 // HTTP Status Codes:
 // - 200 OK: Successfully unsubscribed from the Event.
 // - 403 Forbidden: The user does not have a valid username.
-// - 404 Not Found: The Event with the specified ID was not found or the user is not subscribed.
-// - 409 Conflict: The user is not subscribed to the Event.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 409 Conflict: The user is not going to the Event.
 // - 500 Internal Server Error: An issue occurred while unsubscribing from the Event.
 //
 // Parameters:
-// - collection (*mongo.Collection): The MongoDB collection where the Event documents are stored.
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.PUT("/event/:id/unsubscribe", UnsuscribeEvent(svc))
+func UnsuscribeEvent(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID := c.Param("id")
+		id, idExist := c.Get("_id")
+		username, exist := c.Get("username")
+		if !idExist || !exist || username == "username" {
+			c.Error(apperrors.Forbidden("You do not have a valid username."))
+			return
+		}
+
+		if err := svc.Unsubscribe(c, eventID, id.(string), username.(string)); err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		responses.Message(c, http.StatusOK, "Successfully unsubscribed from event")
+	}
+}
+
+// RSVPEvent records the user's answer to an upcoming Event: "going", "maybe" or "declined".
+//
+// This function:
+// 1. Extracts the ID and username of the Complejo from the JWT token.
+// 2. Rejects the answer if the Event already took place.
+// 3. Replaces the Complejo's RSVP on the Event; giving the same answer again changes nothing.
+//
+// Only the Complejos going count as participants: answering "going" subscribes the user, and changing
+// the answer from "going" unsubscribes them. Beginners going to a gated advanced session get a `warning`
+// in the RSVP or are rejected, depending on the level gate of the Event. Nobody can start going to an Event
+// whose capacity is taken by the Complejos going and their guests, and only the members of its group can go to
+// the Event of a group.
+//
+// HTTP Status Codes:
+// - 200 OK: The answer was successfully recorded; the response carries the RSVP.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have a valid username, the level gate blocks beginners from the Event, or
+// the answer is "going" to the Event of a group the user is not a member of.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 409 Conflict: The Event is in the past, or it is full and the answer is "going".
+// - 422 Unprocessable Entity: The status is not "going", "maybe" or "declined".
+// - 500 Internal Server Error: An issue occurred while recording the answer.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example JSON payload:
+//
+//	{
+//	    "status": "maybe"
+//	}
 //
 // Example usage:
-// r.PUT("/event/:id/unsubscribe", UnsuscribeEvent(collection))
-func UnsuscribeEvent(collection *mongo.Collection) gin.HandlerFunc {
+// r.PUT("/event/:id/rsvp", RSVPEvent(svc))
+func RSVPEvent(svc *services.EventService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		eventID := c.Param("_id")
+		id, idExist := c.Get("_id")
 		username, exist := c.Get("username")
-		if !exist || username == "username" {
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"code":    http.StatusForbidden,
-				"message": "You do not have a valid username.",
-			})
+		if !idExist || !exist || username == "username" {
+			c.Error(apperrors.Forbidden("You do not have a valid username."))
 			return
 		}
 
-		update := bson.M{
-			"$pull": bson.M{
-				"participants": username,
-			},
+		var input models.RSVPInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
 		}
 
-		result, err := collection.UpdateOne(c, bson.M{"_id": eventID}, update)
+		rsvp, err := svc.RSVP(c, c.Param("id"), id.(string), username.(string), input.Status)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"message": "Failed to unsubscribe from event: " + err.Error(),
-			})
+			// 403 Forbidden, 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The answer was successfully recorded
+		responses.OK(c, rsvp)
+	}
+}
+
+// AllowLevelOverride lets a Complejo through the level gate of an Event regardless of its recorded lifts,
+// restricted to admins and the creator of the Event.
+//
+// HTTP Status Codes:
+// - 204 No Content: The Complejo was successfully let through.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event or the Complejo was not found.
+// - 500 Internal Server Error: An issue occurred while updating the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.PUT("/event/:id/level-overrides/:complejo_id", AllowLevelOverride(svc))
+func AllowLevelOverride(svc *services.EventService) gin.HandlerFunc {
+	return setLevelOverride(svc, true)
+}
+
+// RevokeLevelOverride withdraws the level-gate override of a Complejo on an Event,
+// restricted to admins and the creator of the Event.
+//
+// HTTP Status Codes:
+// - 204 No Content: The override was successfully withdrawn (or there was none).
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event was not found.
+// - 500 Internal Server Error: An issue occurred while updating the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.DELETE("/event/:id/level-overrides/:complejo_id", RevokeLevelOverride(svc))
+func RevokeLevelOverride(svc *services.EventService) gin.HandlerFunc {
+	return setLevelOverride(svc, false)
+}
+
+// setLevelOverride returns the handler granting (allowed) or withdrawing a level-gate override.
+func setLevelOverride(svc *services.EventService, allowed bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
 			return
 		}
 
-		// Comprobar si se encontró y actualizó el documento
-		if result.MatchedCount == 0 {
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": "Event not found or user not subscribed",
-			})
+		err := svc.SetLevelOverride(c, c.Param("id"), c.Param("complejo_id"), id.(string), role == "admin", allowed)
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
 			return
 		}
 
-		if result.ModifiedCount == 0 {
-			c.JSON(http.StatusConflict, gin.H{
-				"status":  "error",
-				"message": "Complejo is not already subscribed to the event.",
-			})
+		// 204 No Content: The override was successfully updated
+		responses.NoContent(c)
+	}
+}
+
+// RegisterGuest registers a guest of the authenticated Complejo (bring-a-friend) for an upcoming Event.
+//
+// The guest has no account: it is listed in the `guests` of the Event under its host and takes one of
+// its places. Each member has a limited number of guest passes per calendar month (GUEST_PASSES_PER_MONTH).
+//
+// HTTP Status Codes:
+// - 201 Created: The guest was successfully registered; the response carries the guest.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Event or the Complejo was not found.
+// - 409 Conflict: The Event is in the past or full, or the guest passes of the month are used up.
+// - 422 Unprocessable Entity: The name is missing or too long.
+// - 500 Internal Server Error: An issue occurred while registering the guest.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example JSON payload:
+//
+//	{
+//	    "name": "Laura Gómez"
+//	}
+//
+// Example usage:
+// r.POST("/event/:id/guest", RegisterGuest(svc))
+func RegisterGuest(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.GuestInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		guest, err := svc.RegisterGuest(c, c.Param("id"), id.(string), input)
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The guest was successfully registered
+		responses.Created(c, guest)
+	}
+}
+
+// RemoveGuest removes a guest from an upcoming Event, handing the pass back to its host,
+// restricted to admins and the Complejo that registered the guest.
+//
+// HTTP Status Codes:
+// - 204 No Content: The guest was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the host of the guest.
+// - 404 Not Found: The Event or the guest was not found.
+// - 409 Conflict: The Event is in the past.
+// - 500 Internal Server Error: An issue occurred while removing the guest.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.DELETE("/event/:id/guest/:guest_id", RemoveGuest(svc))
+func RemoveGuest(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.RemoveGuest(c, c.Param("id"), c.Param("guest_id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The guest was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// LikeEvent adds the authenticated Complejo to the likes of an Event; liking it again changes nothing.
+//
+// HTTP Status Codes:
+// - 200 OK: The Event is liked; the response carries its `like_count` and `liked_by_me`.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while updating the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.PUT("/event/:id/like", LikeEvent(svc))
+func LikeEvent(svc *services.EventService) gin.HandlerFunc {
+	return setLike(svc, true)
+}
+
+// UnlikeEvent removes the authenticated Complejo from the likes of an Event; unliking it again changes nothing.
+//
+// HTTP Status Codes:
+// - 200 OK: The Event is not liked; the response carries its `like_count` and `liked_by_me`.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while updating the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.DELETE("/event/:id/like", UnlikeEvent(svc))
+func UnlikeEvent(svc *services.EventService) gin.HandlerFunc {
+	return setLike(svc, false)
+}
+
+// setLike returns the handler liking (liked) or unliking an Event.
+func setLike(svc *services.EventService, liked bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		likes, err := svc.Like(c, c.Param("id"), id.(string), liked)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The like was successfully updated
+		responses.OK(c, likes)
+	}
+}
+
+// GetEventsCalendar downloads the upcoming Events as an iCalendar (.ics) feed, to subscribe to from Apple,
+// Google or Outlook calendars. No token is needed. Each event is a VEVENT whose UID stays the same across
+// downloads, lasting two hours from its date.
+//
+// HTTP Status Codes:
+// - 200 OK: The calendar was successfully generated (possibly without events).
+// - 500 Internal Server Error: An issue occurred while fetching the Events.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.GET("/event/ical", GetEventsCalendar(svc))
+func GetEventsCalendar(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		calendar, err := svc.Calendar(c)
+		if err != nil {
+			// 500 Internal Server Error: Database query failed
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Calendar downloaded as iCalendar
+		writeICal(c, "events.ics", calendar)
+	}
+}
+
+// GetEventCalendar downloads an Event as an iCalendar (.ics) file, to add it to Apple, Google or Outlook calendars.
+// No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: The calendar was successfully generated.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.GET("/event/:id/ical", GetEventCalendar(svc))
+func GetEventCalendar(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		calendar, err := svc.EventCalendar(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Calendar downloaded as iCalendar
+		writeICal(c, "event-"+c.Param("id")+".ics", calendar)
+	}
+}
+
+// GetCalendarLink returns the link of the authenticated user's personal calendar feed, to subscribe to in Apple,
+// Google or Outlook calendars (e.g. as a `webcal://` URL). The link carries a signed token, so keep it private;
+// POST /complejo/me/calendar/rotate revokes it.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the link.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The authenticated Complejo was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the Complejo.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.GET("/complejo/me/calendar", GetCalendarLink(svc))
+func GetCalendarLink(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		link, err := svc.CalendarLink(c, id.(string))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the link
+		responses.OK(c, link)
+	}
+}
+
+// RotateCalendarLink revokes the link of the authenticated user's personal calendar feed, e.g. after it was
+// shared by mistake, and returns the new one. Calendar apps subscribed to the previous link get 401 from then on.
+//
+// HTTP Status Codes:
+// - 201 Created: The new link was successfully issued.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The authenticated Complejo was not found.
+// - 500 Internal Server Error: An issue occurred while storing the new token.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.POST("/complejo/me/calendar/rotate", RotateCalendarLink(svc))
+func RotateCalendarLink(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		link, err := svc.RotateCalendarLink(c, id.(string))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The new link was successfully issued
+		responses.Created(c, link)
+	}
+}
+
+// GetPersonalCalendar serves the personal calendar feed of a Complejo as iCalendar (.ics): the Events it is
+// going to, from a month ago on. Calendar apps cannot send a JWT, so the feed is authenticated by the signed
+// `?token=` query parameter of the link returned by GET /complejo/me/calendar, and fetched again every hour.
+//
+// HTTP Status Codes:
+// - 200 OK: The calendar was successfully generated.
+// - 401 Unauthorized: The token is missing, does not match the Complejo or was revoked.
+// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the Events.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.GET("/complejo/:id/calendar.ics", GetPersonalCalendar(svc))
+func GetPersonalCalendar(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		calendar, err := svc.PersonalCalendar(c, c.Param("id"), c.Query("token"))
+		if err != nil {
+			// 401 Unauthorized, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Calendar served as iCalendar
+		writeICal(c, "calendar.ics", calendar)
+	}
+}
+
+// writeICal writes the calendar as an iCalendar attachment with the given file name.
+func writeICal(c *gin.Context, filename string, calendar *ical.Calendar) {
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+	calendar.WriteTo(c.Writer)
+}
+
+// GetFeaturedEvents lists the upcoming featured Events for the feed of the public site, the pinned ones first,
+// then by date. No token is needed. The `?limit=` query parameter sets how many are listed (default 10, at most 50).
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the featured Events (possibly none).
+// - 400 Bad Request: The query parameters could not be parsed.
+// - 422 Unprocessable Entity: The limit is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the Events.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.GET("/event/featured", GetFeaturedEvents(svc))
+func GetFeaturedEvents(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.FeaturedQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		events, err := svc.Featured(c, query)
+		if err != nil {
+			// 500 Internal Server Error: Database query failed
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the featured Events
+		responses.OK(c, events)
+	}
+}
+
+// PinEvent pins an Event to the top of the listings until its pin expires, restricted to admin role.
+// Pinning it again replaces the expiry.
+//
+// HTTP Status Codes:
+// - 200 OK: The Event was successfully pinned; it is returned.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 422 Unprocessable Entity: The expiry is not in the future.
+// - 500 Internal Server Error: An issue occurred while updating the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example JSON payload (until is optional, a week from now by default; send {} to use it):
+//
+//	{
+//	    "until": "2026-11-01T00:00:00Z"
+//	}
+//
+// Example usage:
+// r.PUT("/event/:id/pin", PinEvent(svc))
+func PinEvent(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to pin this Event."))
+			return
+		}
+
+		var pin models.EventPin
+		if err := validation.BindJSON(c, &pin); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		event, err := svc.Pin(c, c.Param("id"), pin)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The Event was successfully pinned
+		responses.OK(c, event)
+	}
+}
+
+// UnpinEvent removes the pin of an Event, restricted to admin role; unpinning it again changes nothing.
+//
+// HTTP Status Codes:
+// - 200 OK: The Event is not pinned; it is returned.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while updating the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.DELETE("/event/:id/pin", UnpinEvent(svc))
+func UnpinEvent(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to unpin this Event."))
+			return
+		}
+
+		event, err := svc.Unpin(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The pin was successfully removed
+		responses.OK(c, event)
+	}
+}
+
+// FeatureEvent shows an Event in the feed of the public site (GET /event/featured), restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: The Event is featured; it is returned.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while updating the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.PUT("/event/:id/featured", FeatureEvent(svc))
+func FeatureEvent(svc *services.EventService) gin.HandlerFunc {
+	return setFeatured(svc, true)
+}
+
+// UnfeatureEvent removes an Event from the feed of the public site, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: The Event is not featured; it is returned.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while updating the Event.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.DELETE("/event/:id/featured", UnfeatureEvent(svc))
+func UnfeatureEvent(svc *services.EventService) gin.HandlerFunc {
+	return setFeatured(svc, false)
+}
+
+// setFeatured returns the handler featuring (featured) or unfeaturing an Event.
+func setFeatured(svc *services.EventService, featured bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to feature this Event."))
+			return
+		}
+
+		event, err := svc.Feature(c, c.Param("id"), featured)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The feature flag was successfully updated
+		responses.OK(c, event)
+	}
+}
+
+// GetEventParticipants retrieves a page of the profiles of the Complejos going to an Event.
+//
+// Participants are listed in the order they answered "going", each with their current username, a thumbnail
+// of their photo (left out when they have none, or when their privacy settings hide it from the caller) and their
+// lifts, together with the pagination metadata. Participants blocking the caller are left out; admins see every
+// photo.
+//
+// Query parameters (all optional):
+// - page: 1-based page number (default: 1).
+// - limit: Page size (default: 20, at most 100).
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of participants (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 422 Unprocessable Entity: The page or limit is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the participants.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.GET("/event/:id/participants", GetEventParticipants(svc))
+//
+// Example request:
+// GET /event/2f6c1c0e-8d4b-4a4e-9a51-0c1f3b7d9e21/participants?page=1&limit=20
+func GetEventParticipants(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.ParticipantQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+
+		var view models.ComplejoView
+		if role, _ := c.Get("role"); role == "admin" {
+			view.Relation = models.RelationSelf
+		}
+		participants, total, err := svc.Participants(c, c.Param("id"), view, c.GetString("_id"), &query)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of participants
+		responses.OKWithMeta(c, participants, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
+
+// GetSubscriptionHistory returns the full subscription history of an Event, restricted to admin role.
+//
+// This function lists every subscribe/unsubscribe/waitlist transition recorded for the Event in
+// chronological order, together with the participants and waitlist derived from them, so
+// disputes about who was subscribed and when can be resolved.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the subscription history.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while reading the history.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.GET("/event/:id/subscription-history", GetSubscriptionHistory(svc))
+func GetSubscriptionHistory(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the subscription history."))
+			return
+		}
+
+		history, err := svc.SubscriptionHistory(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the history
+		responses.OK(c, history)
+	}
+}
+
+// GetNearbyEvents lists the upcoming public events of the other clubs in the federation.
+//
+// The events are imported periodically from the shared federation feed and ordered by date.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the events (possibly an empty list).
+// - 500 Internal Server Error: An issue occurred while reading the events.
+//
+// Parameters:
+// - svc (*services.FederationService): The service that exchanges events with the federation.
+//
+// Example usage:
+// r.GET("/event/nearby", GetNearbyEvents(svc))
+func GetNearbyEvents(svc *services.FederationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		events, err := svc.Nearby(c)
+		if err != nil {
+			// 500 Internal Server Error: Query error
+			c.Error(err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "success",
-			"message": "Successfully unsubscribed from event",
-		})
+		// 200 OK: Successfully retrieved the events
+		responses.OK(c, events)
 	}
 }
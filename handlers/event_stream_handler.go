@@ -0,0 +1,101 @@
+// event_stream_handler.go
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StreamEvents streams the creations, updates and deletions of the Events as server-sent events
+// (text/event-stream), as they happen. No token is needed. Each server-sent event is named after the operation
+// ("insert", "update" or "delete"), carries the change as JSON (with the Event after the change, except for
+// deletions) and has a resume token as ID. Restored Events are sent as updates.
+//
+// A client reconnecting with the ID of the last change it received, in the Last-Event-ID header (sent by
+// EventSource on its own) or the last_event_id query parameter, receives every change made since. Comments are
+// sent when no change happens, carrying a fresh ID so a quiet stream can still be resumed. When the stream cannot
+// be resumed, the client gets 410 and should reload the Events and reconnect without an ID. The stream ends
+// with an "error" event when it fails, and is closed when the server shuts down.
+//
+// HTTP Status Codes:
+// - 200 OK: The stream is open.
+// - 410 Gone: The stream cannot resume after the given ID (malformed, or too old).
+// - 503 Service Unavailable: The storage backend cannot stream changes, or too many streams are open.
+// - 500 Internal Server Error: An issue occurred while opening the stream.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages the Events.
+// - shutdown (<-chan struct{}): Closed when the server shuts down, ending the stream.
+//
+// Example stream:
+//
+//	id: 8263F1A2B4000000012B022C0100296E5A1004...
+//	event: update
+//	data: {"operation":"update","event_id":"c27f...","event":{"_id":"c27f...","title":"Deadlift day",...},"at":"2026-10-16T12:00:00Z"}
+//
+// Example usage:
+// r.GET("/event/stream", StreamEvents(svc, shutdown))
+func StreamEvents(svc *services.EventService, shutdown <-chan struct{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lastID := c.GetHeader("Last-Event-ID")
+		if lastID == "" {
+			lastID = c.Query("last_event_id")
+		}
+
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+		go func() {
+			select {
+			case <-shutdown:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		stream, err := svc.Stream(ctx, lastID)
+		if err != nil {
+			// 410 Gone, 503 Service Unavailable or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+		defer stream.Close(context.Background())
+
+		// 200 OK: The stream is open
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no") // Disables the buffering of nginx
+		c.Status(http.StatusOK)
+		c.Writer.Flush()
+
+		for {
+			change, err := stream.Next(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					fmt.Fprintf(c.Writer, "event: error\ndata: {\"error\":%q}\n\n", apperrors.From(err).Code)
+					c.Writer.Flush()
+					c.Error(err)
+				}
+				return
+			}
+
+			if change == nil {
+				fmt.Fprintf(c.Writer, "id: %s\n: keepalive\n\n", stream.ResumeToken())
+			} else {
+				data, err := responses.Marshal(change)
+				if err != nil {
+					c.Error(err)
+					return
+				}
+				fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", change.Token, change.Operation, data)
+			}
+			c.Writer.Flush()
+		}
+	}
+}
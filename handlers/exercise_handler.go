@@ -0,0 +1,207 @@
+// exercise_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetExercises lists the exercise library, ordered by name, with `page`/`limit` pagination. The `?q=` query
+// parameter searches the names (case-insensitive), and `?muscle_group=` and `?equipment=` keep the exercises
+// training a muscle group or performed with some equipment. No authentication is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of exercises (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 422 Unprocessable Entity: A query parameter is not one of the allowed values or out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the exercises.
+//
+// Parameters:
+// - svc (*services.ExerciseService): The service that manages the exercise library.
+//
+// Example usage:
+// r.GET("/exercises?q=squat&muscle_group=legs", GetExercises(svc))
+func GetExercises(svc *services.ExerciseService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var filter models.ExerciseFilter
+		if err := validation.BindQuery(c, &filter); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		exercises, total, err := svc.Search(c, &filter)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of exercises
+		responses.OKWithMeta(c, exercises, responses.NewPagination(filter.Page, filter.Limit, total))
+	}
+}
+
+// GetExercise retrieves an exercise of the library. No authentication is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the exercise.
+// - 404 Not Found: The exercise with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the exercise.
+//
+// Parameters:
+// - svc (*services.ExerciseService): The service that manages the exercise library.
+//
+// Example usage:
+// r.GET("/exercises/:id", GetExercise(svc))
+func GetExercise(svc *services.ExerciseService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		exercise, err := svc.Get(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the exercise
+		responses.OK(c, exercise)
+	}
+}
+
+// CreateExercise adds an exercise to the library, restricted to admin role. Its first muscle group is the one it
+// trains the most, which the volume of the workouts is counted in.
+//
+// HTTP Status Codes:
+// - 201 Created: The exercise was successfully added.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 409 Conflict: Another exercise has this name.
+// - 422 Unprocessable Entity: Required fields are missing or have invalid values.
+// - 500 Internal Server Error: An issue occurred while storing the exercise.
+//
+// Parameters:
+// - svc (*services.ExerciseService): The service that manages the exercise library.
+//
+// Example JSON payload (equipment is "barbell", "dumbbell", "kettlebell", "machine", "cable", "band",
+// "bodyweight" or "other"):
+//
+//	{
+//	    "name": "Romanian deadlift",
+//	    "muscle_groups": ["legs", "back"],
+//	    "equipment": "barbell",
+//	    "video_url": "https://videos.example.com/romanian-deadlift"
+//	}
+//
+// Example usage:
+// r.POST("/exercises", CreateExercise(svc))
+func CreateExercise(svc *services.ExerciseService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the exercise library."))
+			return
+		}
+
+		var input models.ExerciseInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		exercise, err := svc.Create(c, input)
+		if err != nil {
+			// 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The exercise was successfully added
+		responses.Created(c, exercise)
+	}
+}
+
+// UpdateExercise replaces the details of an exercise of the library, restricted to admin role. The workouts
+// already logged keep the name and muscle group it had.
+//
+// HTTP Status Codes:
+// - 200 OK: The exercise was successfully updated.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The exercise with the specified ID was not found.
+// - 409 Conflict: Another exercise has this name.
+// - 422 Unprocessable Entity: Required fields are missing or have invalid values.
+// - 500 Internal Server Error: An issue occurred while updating the exercise.
+//
+// Parameters:
+// - svc (*services.ExerciseService): The service that manages the exercise library.
+//
+// Example usage:
+// r.PUT("/exercises/:id", UpdateExercise(svc))
+func UpdateExercise(svc *services.ExerciseService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the exercise library."))
+			return
+		}
+
+		var input models.ExerciseInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		exercise, err := svc.Update(c, c.Param("id"), input)
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The exercise was successfully updated
+		responses.OK(c, exercise)
+	}
+}
+
+// DeleteExercise removes an exercise from the library, restricted to admin role. The workouts already logged
+// keep their copy of it.
+//
+// HTTP Status Codes:
+// - 204 No Content: The exercise was successfully removed.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The exercise with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the exercise.
+//
+// Parameters:
+// - svc (*services.ExerciseService): The service that manages the exercise library.
+//
+// Example usage:
+// r.DELETE("/exercises/:id", DeleteExercise(svc))
+func DeleteExercise(svc *services.ExerciseService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the exercise library."))
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id")); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The exercise was successfully removed
+		responses.NoContent(c)
+	}
+}
@@ -0,0 +1,65 @@
+// feed_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFeed returns the activity feed of the authenticated user, latest first, with `page`/`limit` pagination:
+// the personal records, event subscriptions and challenges won of the users it follows, and the events created
+// in the groups it belongs to. The feed is written as the activity is announced, so an item shows the username
+// and the title it had then; unsubscribing from an event, deleting an event or deleting a user removes its items.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of the feed (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 422 Unprocessable Entity: A query parameter is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the feed.
+//
+// Parameters:
+// - svc (*services.FeedService): The service that keeps the activity feed.
+//
+// Example response data:
+//
+//	[
+//	    {"_id": "...", "kind": "personal_record", "complejo_id": "...", "username": "maria", "lift": "dl", "value": 140, "created_at": "2026-10-16T18:02:11Z"},
+//	    {"_id": "...", "kind": "event_subscribed", "complejo_id": "...", "username": "juan", "event_id": "...", "title": "Sunday run", "created_at": "2026-10-16T09:30:00Z"},
+//	    {"_id": "...", "kind": "group_event", "group_id": "...", "event_id": "...", "title": "Crew deadlift night", "created_at": "2026-10-15T20:00:00Z"}
+//	]
+//
+// Example usage:
+// r.GET("/feed", GetFeed(svc))
+func GetFeed(svc *services.FeedService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var query models.FeedQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+
+		items, total, err := svc.Feed(c, id.(string), &query)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of the feed
+		responses.OKWithMeta(c, items, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
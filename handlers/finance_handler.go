@@ -0,0 +1,319 @@
+// finance_handler.go
+package handlers
+
+import (
+	"encoding/csv"
+	"io"
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/stripe"
+	"los-complejos-backend/validation"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookSize is the largest Stripe webhook payload accepted.
+const maxWebhookSize = 1 << 20
+
+// StripeWebhook records the card payments notified by Stripe.
+//
+// The payload is authenticated with its `Stripe-Signature` header and the endpoint's signing secret
+// (STRIPE_WEBHOOK_SECRET). Paid `charge.succeeded` and `charge.refunded` events are stored, keyed by charge ID,
+// with the event paid for taken from the charge's `event_id` metadata; other events are acknowledged and ignored.
+//
+// HTTP Status Codes:
+// - 200 OK: The webhook was recorded or ignored.
+// - 400 Bad Request: The signature is invalid or expired, or the payload is not a Stripe event.
+// - 403 Forbidden: Payment webhooks are disabled (no signing secret is configured).
+// - 500 Internal Server Error: An issue occurred while storing the payment (Stripe retries the webhook).
+//
+// Parameters:
+// - svc (*services.FinanceService): The service that records payments.
+//
+// Example usage:
+// r.POST("/webhooks/stripe", StripeWebhook(svc))
+func StripeWebhook(svc *services.FinanceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payload, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookSize))
+		if err != nil {
+			// 400 Bad Request: Unreadable or oversized payload
+			c.Error(apperrors.BadRequest("Invalid webhook payload: " + err.Error()))
+			return
+		}
+
+		if err := svc.HandleWebhook(c, payload, c.GetHeader(stripe.SignatureHeader)); err != nil {
+			// 400 Bad Request, 403 Forbidden or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Webhook handled
+		responses.Message(c, http.StatusOK, "Webhook received")
+	}
+}
+
+// GetFinanceSummary returns the revenue, expenses and profit of the events per event and per month, restricted to admin role.
+//
+// Refunds are netted out of the month and event of the original payment, and expenses out of the month they were
+// incurred in. Amounts are in the smallest unit of their currency (e.g. cents) and each currency is totalled
+// separately. With `?format=csv` the summary is downloaded as a CSV file with one row per event, month and
+// currency total.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully computed the summary.
+// - 400 Bad Request: The from or to parameter is not an RFC 3339 time.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The format parameter is not "json" or "csv".
+// - 500 Internal Server Error: An issue occurred while aggregating the payments or expenses.
+//
+// Parameters:
+// - svc (*services.FinanceService): The service that summarizes the payments.
+//
+// Example response data:
+//
+//	{
+//	    "from": "2025-10-16T12:00:00Z",
+//	    "to": "2026-10-16T12:00:00Z",
+//	    "timezone": "Europe/Madrid",
+//	    "events": [{"event_id": "...", "title": "Powerlifting Meet", "currency": "eur", "payments": 12, "gross": 24000, "refunded": 2000, "net": 22000, "expenses": 15000, "profit": 7000}],
+//	    "months": [{"month": "2026-09", "currency": "eur", "payments": 12, "gross": 24000, "refunded": 2000, "net": 22000, "expenses": 15000, "profit": 7000}],
+//	    "totals": [{"currency": "eur", "payments": 12, "gross": 24000, "refunded": 2000, "net": 22000, "expenses": 15000, "profit": 7000}]
+//	}
+//
+// Example usage:
+// r.GET("/admin/finance/summary?from=2026-01-01T00:00:00Z&format=csv", GetFinanceSummary(svc))
+func GetFinanceSummary(svc *services.FinanceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the finance summary."))
+			return
+		}
+
+		var query models.FinanceQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		summary, err := svc.Summary(c, query.From, query.To)
+		if err != nil {
+			// 500 Internal Server Error: Aggregation error
+			c.Error(err)
+			return
+		}
+
+		if query.Format == "csv" {
+			// 200 OK: Summary downloaded as CSV
+			writeFinanceCSV(c, summary)
+			return
+		}
+
+		// 200 OK: Successfully computed the summary
+		responses.OK(c, summary)
+	}
+}
+
+// writeFinanceCSV writes the summary as a CSV attachment: one row per event, then per month, then per currency total.
+func writeFinanceCSV(c *gin.Context, summary *models.FinanceSummary) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="finance-summary.csv"`)
+	c.Status(http.StatusOK)
+
+	row := func(group, key, title string, revenue models.Revenue) []string {
+		return []string{group, key, title, revenue.Currency, strconv.Itoa(revenue.Payments),
+			strconv.FormatInt(revenue.Gross, 10), strconv.FormatInt(revenue.Refunded, 10), strconv.FormatInt(revenue.Net, 10),
+			strconv.FormatInt(revenue.Expenses, 10), strconv.FormatInt(revenue.Profit, 10)}
+	}
+
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"group", "key", "title", "currency", "payments", "gross", "refunded", "net", "expenses", "profit"})
+	for _, event := range summary.Events {
+		w.Write(row("event", event.EventID, event.Title, event.Revenue))
+	}
+	for _, month := range summary.Months {
+		w.Write(row("month", month.Month, "", month.Revenue))
+	}
+	for _, total := range summary.Totals {
+		w.Write(row("total", "", "", total))
+	}
+	w.Flush()
+}
+
+// GetExpenses lists the expenses of an Event, oldest first, restricted to admins and the creator of the Event.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the expenses.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the expenses.
+//
+// Parameters:
+// - svc (*services.FinanceService): The service that manages expenses.
+//
+// Example usage:
+// r.GET("/event/:id/expenses", GetExpenses(svc))
+func GetExpenses(svc *services.FinanceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		expenses, err := svc.Expenses(c, c.Param("id"), id.(string), role == "admin")
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the expenses
+		responses.OK(c, expenses)
+	}
+}
+
+// CreateExpense records an expense of an Event (e.g. venue rental or prizes), restricted to admins and the creator
+// of the Event. The expense is netted against the Event's payments in the finance summary.
+//
+// HTTP Status Codes:
+// - 201 Created: The expense was successfully recorded.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 422 Unprocessable Entity: Required fields are missing or have invalid values.
+// - 500 Internal Server Error: An issue occurred while storing the expense.
+//
+// Parameters:
+// - svc (*services.FinanceService): The service that manages expenses.
+//
+// Example JSON payload (amount in the smallest unit of the currency, incurred_at defaults to now):
+//
+//	{
+//	    "category": "venue",
+//	    "description": "Sports hall rental",
+//	    "amount": 15000,
+//	    "currency": "eur",
+//	    "incurred_at": "2026-09-20T09:00:00Z"
+//	}
+//
+// Example usage:
+// r.POST("/event/:id/expenses", CreateExpense(svc))
+func CreateExpense(svc *services.FinanceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.ExpenseInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		expense, err := svc.CreateExpense(c, c.Param("id"), input, id.(string), role == "admin")
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The expense was successfully recorded
+		responses.Created(c, expense)
+	}
+}
+
+// UpdateExpense replaces the details of an expense of an Event, restricted to admins and the creator of the Event.
+//
+// HTTP Status Codes:
+// - 200 OK: The expense was successfully updated.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event or the expense was not found.
+// - 422 Unprocessable Entity: Required fields are missing or have invalid values.
+// - 500 Internal Server Error: An issue occurred while updating the expense.
+//
+// Parameters:
+// - svc (*services.FinanceService): The service that manages expenses.
+//
+// Example usage:
+// r.PUT("/event/:id/expenses/:expense_id", UpdateExpense(svc))
+func UpdateExpense(svc *services.FinanceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.ExpenseInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		expense, err := svc.UpdateExpense(c, c.Param("id"), c.Param("expense_id"), input, id.(string), role == "admin")
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The expense was successfully updated
+		responses.OK(c, expense)
+	}
+}
+
+// DeleteExpense removes an expense of an Event, restricted to admins and the creator of the Event.
+//
+// HTTP Status Codes:
+// - 204 No Content: The expense was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event or the expense was not found.
+// - 500 Internal Server Error: An issue occurred while removing the expense.
+//
+// Parameters:
+// - svc (*services.FinanceService): The service that manages expenses.
+//
+// Example usage:
+// r.DELETE("/event/:id/expenses/:expense_id", DeleteExpense(svc))
+func DeleteExpense(svc *services.FinanceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.DeleteExpense(c, c.Param("id"), c.Param("expense_id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The expense was successfully removed
+		responses.NoContent(c)
+	}
+}
@@ -0,0 +1,172 @@
+// follow_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FollowComplejo makes the authenticated user follow a Complejo, so it sees the weight, height and IMC of its
+// profile. Following a Complejo again changes nothing; a Complejo cannot follow itself, nor a Complejo it blocks
+// or that blocks it.
+//
+// HTTP Status Codes:
+// - 200 OK: The user follows the Complejo; the follow status of the Complejo is returned.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user blocks the Complejo, or the Complejo blocks the user (error code "blocked").
+// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 422 Unprocessable Entity: The Complejo is the user itself (error code "follow_self").
+// - 500 Internal Server Error: An issue occurred while storing the follow.
+//
+// Parameters:
+// - svc (*services.FollowService): The service that tells who follows whom.
+//
+// Example response data:
+//
+//	{
+//	    "complejo_id": "...",
+//	    "followers": 12,
+//	    "following": 4,
+//	    "followed_by_me": true
+//	}
+//
+// Example usage:
+// r.PUT("/complejo/:id/follow", FollowComplejo(svc))
+func FollowComplejo(svc *services.FollowService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		status, err := svc.Follow(c, id.(string), c.Param("id"))
+		if err != nil {
+			// 403 Forbidden, 404 Not Found, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The user follows the Complejo
+		responses.OK(c, status)
+	}
+}
+
+// UnfollowComplejo stops the authenticated user from following a Complejo. Unfollowing a Complejo it does not
+// follow changes nothing.
+//
+// HTTP Status Codes:
+// - 200 OK: The user does not follow the Complejo; the follow status of the Complejo is returned.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the follow.
+//
+// Parameters:
+// - svc (*services.FollowService): The service that tells who follows whom.
+//
+// Example usage:
+// r.DELETE("/complejo/:id/follow", UnfollowComplejo(svc))
+func UnfollowComplejo(svc *services.FollowService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		status, err := svc.Unfollow(c, id.(string), c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The user does not follow the Complejo
+		responses.OK(c, status)
+	}
+}
+
+// GetFollowers lists the followers of a Complejo with their username, latest first, with `page`/`limit`
+// pagination. Deleted Complejos are left out.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of followers (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 422 Unprocessable Entity: A query parameter is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the followers.
+//
+// Parameters:
+// - svc (*services.FollowService): The service that tells who follows whom.
+//
+// Example response data:
+//
+//	[
+//	    {"complejo_id": "...", "username": "maria", "followed_at": "2026-10-02T18:02:11Z"}
+//	]
+//
+// Example usage:
+// r.GET("/complejo/:id/followers", GetFollowers(svc))
+func GetFollowers(svc *services.FollowService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.FollowQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+
+		followers, total, err := svc.Followers(c, c.Param("id"), &query)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of followers
+		responses.OKWithMeta(c, followers, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
+
+// GetFollowing lists the Complejos a Complejo follows with their username, latest first, with `page`/`limit`
+// pagination. Deleted Complejos are left out.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of followed Complejos (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 404 Not Found: The Complejo with the specified ID was not found.
+// - 422 Unprocessable Entity: A query parameter is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the followed Complejos.
+//
+// Parameters:
+// - svc (*services.FollowService): The service that tells who follows whom.
+//
+// Example usage:
+// r.GET("/complejo/:id/following", GetFollowing(svc))
+func GetFollowing(svc *services.FollowService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.FollowQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+
+		following, total, err := svc.Following(c, c.Param("id"), &query)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of followed Complejos
+		responses.OKWithMeta(c, following, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
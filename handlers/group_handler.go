@@ -0,0 +1,489 @@
+// group_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetGroups lists the groups by name, with `page`/`limit` pagination and the `role` of the authenticated user in
+// each group it belongs to. The `?q=` query parameter keeps the groups whose name contains it (case-insensitive),
+// and `?mine=true` the groups of the user.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of groups (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 422 Unprocessable Entity: A query parameter is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the groups.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example usage:
+// r.GET("/groups?mine=true", GetGroups(svc))
+func GetGroups(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var filter models.GroupFilter
+		if err := validation.BindQuery(c, &filter); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		groups, total, err := svc.List(c, &filter, id.(string))
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of groups
+		responses.OKWithMeta(c, groups, responses.NewPagination(filter.Page, filter.Limit, total))
+	}
+}
+
+// GetGroup retrieves a group, with the `role` of the authenticated user when it belongs to it.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the group.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The group with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the group.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example usage:
+// r.GET("/groups/:id", GetGroup(svc))
+func GetGroup(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		group, err := svc.Get(c, c.Param("id"), id.(string))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the group
+		responses.OK(c, group)
+	}
+}
+
+// CreateGroup creates a group on behalf of the authenticated user, who becomes its first owner.
+//
+// HTTP Status Codes:
+// - 201 Created: The group was successfully created.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 422 Unprocessable Entity: The name is missing or a field is too long.
+// - 500 Internal Server Error: An issue occurred while storing the group.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example JSON payload:
+//
+//	{
+//	    "name": "Early birds",
+//	    "description": "Squats at 6:30 before work"
+//	}
+//
+// Example usage:
+// r.POST("/groups", CreateGroup(svc))
+func CreateGroup(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.GroupInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		group, err := svc.Create(c, id.(string), input)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The group was successfully created
+		responses.Created(c, group)
+	}
+}
+
+// UpdateGroup replaces the name and description of a group. Only admins and the owners of the group may change
+// it.
+//
+// HTTP Status Codes:
+// - 200 OK: The group was successfully changed.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor an owner of the group.
+// - 404 Not Found: The group with the specified ID was not found.
+// - 422 Unprocessable Entity: The name is missing or a field is too long.
+// - 500 Internal Server Error: An issue occurred while storing the group.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example usage:
+// r.PUT("/groups/:id", UpdateGroup(svc))
+func UpdateGroup(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.GroupInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		group, err := svc.Update(c, c.Param("id"), id.(string), role == "admin", input)
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The group was successfully changed
+		responses.OK(c, group)
+	}
+}
+
+// DeleteGroup removes a group and its memberships. Only admins and the owners of the group may remove it. Its
+// events are kept, and every Complejo may then go to them.
+//
+// HTTP Status Codes:
+// - 204 No Content: The group was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor an owner of the group.
+// - 404 Not Found: The group with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the group.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example usage:
+// r.DELETE("/groups/:id", DeleteGroup(svc))
+func DeleteGroup(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The group was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// GetGroupMembers lists the members of a group with their username and role, owners first then by join time,
+// with `page`/`limit` pagination. Deleted Complejos are left out.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of members.
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 404 Not Found: The group with the specified ID was not found.
+// - 422 Unprocessable Entity: A query parameter is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the members.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example response data:
+//
+//	[
+//	    {"complejo_id": "...", "username": "maria", "role": "owner", "joined_at": "2026-09-01T18:02:11Z"},
+//	    {"complejo_id": "...", "username": "juan", "role": "member", "joined_at": "2026-09-03T07:45:00Z"}
+//	]
+//
+// Example usage:
+// r.GET("/groups/:id/members", GetGroupMembers(svc))
+func GetGroupMembers(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.GroupMemberQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+
+		members, total, err := svc.Members(c, c.Param("id"), &query)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of members
+		responses.OKWithMeta(c, members, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
+
+// SetGroupMember adds a Complejo to the members of a group, or changes its role: "owner" (manages the group, its
+// members and its events) or "member" (the default). Only admins and the owners of the group may manage its
+// members. A group keeps at least one owner, so its last owner cannot be made a plain member.
+//
+// HTTP Status Codes:
+// - 200 OK: The Complejo is a member with the given role.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor an owner of the group.
+// - 404 Not Found: The group or the Complejo with the specified ID was not found.
+// - 409 Conflict: The Complejo is the last owner of the group.
+// - 422 Unprocessable Entity: The role is neither "owner" nor "member".
+// - 500 Internal Server Error: An issue occurred while storing the membership.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example JSON payload:
+//
+//	{
+//	    "role": "owner"
+//	}
+//
+// Example usage:
+// r.PUT("/groups/:id/members/:complejo_id", SetGroupMember(svc))
+func SetGroupMember(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.GroupMembershipInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		membership, err := svc.SetMember(c, c.Param("id"), c.Param("complejo_id"), id.(string), role == "admin", input)
+		if err != nil {
+			// 403 Forbidden, 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The Complejo is a member with the given role
+		responses.OK(c, membership)
+	}
+}
+
+// RemoveGroupMember removes a Complejo from the members of a group. Members may leave a group by removing
+// themselves; only admins and the owners of the group may remove the others. A group keeps at least one owner,
+// so its last owner cannot leave: it may delete the group instead.
+//
+// HTTP Status Codes:
+// - 204 No Content: The Complejo was successfully removed from the group.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user removes another member and is neither an admin nor an owner of the group.
+// - 404 Not Found: The group was not found, or the Complejo is not one of its members.
+// - 409 Conflict: The Complejo is the last owner of the group.
+// - 500 Internal Server Error: An issue occurred while removing the membership.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example usage:
+// r.DELETE("/groups/:id/members/:complejo_id", RemoveGroupMember(svc))
+func RemoveGroupMember(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.RemoveMember(c, c.Param("id"), c.Param("complejo_id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The Complejo was successfully removed from the group
+		responses.NoContent(c)
+	}
+}
+
+// CreateGroupEvent creates an event of a group on behalf of the authenticated user. The payload is that of
+// POST /event; only the members of the group may answer "going" to the event. Only admins and the owners of the
+// group may create its events.
+//
+// HTTP Status Codes:
+// - 201 Created: The event was successfully created.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor an owner of the group.
+// - 404 Not Found: The group with the specified ID was not found.
+// - 422 Unprocessable Entity: Required fields are missing or the date is not in the future.
+// - 500 Internal Server Error: An issue occurred while storing the event.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example usage:
+// r.POST("/groups/:id/events", CreateGroupEvent(svc))
+func CreateGroupEvent(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var event models.Event
+		if err := validation.BindJSON(c, &event); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		// RSVPs, guests, pins, features, forecasts, routes and photos are managed through their own endpoints
+		event.ClearManaged()
+
+		if err := svc.CreateEvent(c, c.Param("id"), id.(string), role == "admin", &event); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The event was successfully created
+		responses.Created(c, event)
+	}
+}
+
+// GetGroupEvents lists the events of a group like GET /event, with its `from`, `to`, `location`, `sort`, `page`
+// and `limit` query parameters.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of events (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 404 Not Found: The group with the specified ID was not found.
+// - 422 Unprocessable Entity: A query parameter is not one of its allowed values, or out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the events.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example usage:
+// r.GET("/groups/:id/events", GetGroupEvents(svc))
+func GetGroupEvents(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var filter models.EventFilter
+		if err := validation.BindQuery(c, &filter); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+		if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+			// 422 Unprocessable Entity: Empty date range
+			c.Error(apperrors.Validation("Validation failed", []validation.FieldError{
+				{Field: "to", Rule: "gtefield", Message: "must not be before from"},
+			}))
+			return
+		}
+
+		events, total, err := svc.Events(c, c.Param("id"), &filter)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		for i := range events {
+			events[i].MarkLiked(c.GetString("_id"))
+		}
+
+		// 200 OK: Successfully retrieved the page of events
+		responses.OKWithMeta(c, events, responses.NewPagination(filter.Page, filter.Limit, total))
+	}
+}
+
+// GetGroupLeaderboard ranks the members of a group by their lifts like GET /leaderboard, with its `lift`,
+// `gender`, `scoring`, `page` and `limit` query parameters and the place of the authenticated user in `me`.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of the leaderboard (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 404 Not Found: The group with the specified ID was not found.
+// - 422 Unprocessable Entity: A query parameter is not one of its allowed values, or out of range.
+// - 500 Internal Server Error: An issue occurred while ranking the members.
+//
+// Parameters:
+// - svc (*services.GroupService): The service that runs the groups.
+//
+// Example usage:
+// r.GET("/groups/:id/leaderboard?lift=squad", GetGroupLeaderboard(svc))
+func GetGroupLeaderboard(svc *services.GroupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.LeaderboardQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+
+		leaderboard, total, err := svc.Leaderboard(c, c.Param("id"), &query, c.GetString("_id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of the leaderboard
+		responses.OKWithMeta(c, leaderboard, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
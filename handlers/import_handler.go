@@ -0,0 +1,114 @@
+// import_handler.go
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportData creates members or events in bulk from a CSV file or a JSON array, restricted to admin role.
+//
+// This function:
+// 1. Reads the file as CSV (text/csv, named columns like the CSV exports) or JSON (application/json, an array).
+// 2. Validates each row like the create endpoint, and skips those duplicating a stored record or an earlier row.
+// 3. Creates the other rows unless `dry_run` is true, and reports the outcome of every row with a summary.
+//
+// Members are duplicates by username and events by title and date; JSON rows are the payloads of POST /complejo
+// or POST /event.
+//
+// HTTP Status Codes:
+// - 200 OK: The file was imported (or checked, in a dry run); see the per-row results.
+// - 400 Bad Request: The file is malformed, too large, or not a JSON array.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 415 Unsupported Media Type: The file is neither CSV nor JSON.
+// - 422 Unprocessable Entity: The kind is missing or unknown, or the CSV file names unknown columns.
+// - 500 Internal Server Error: An issue occurred while storing the records.
+// - 503 Service Unavailable: Too many expensive requests are running.
+//
+// Parameters:
+// - svc (*services.ImportService): The service that imports the records.
+//
+// Example request (POST /admin/import?kind=complejos&dry_run=true, Content-Type: text/csv):
+//
+//	username,password,role,gender,weight_kg,height_m
+//	ana,s3cret,user,female,61.5,1.68
+//	ana,s3cret,user,female,61.5,1.68
+//	luis,s3cret,user,male,abc,1.80
+//
+// Example response data:
+//
+//	{
+//	    "kind": "complejos",
+//	    "dry_run": true,
+//	    "summary": {"created": 1, "skipped": 1, "failed": 1},
+//	    "results": [
+//	        {"row": 2, "key": "ana", "status": "created"},
+//	        {"row": 3, "key": "ana", "status": "skipped", "reason": "duplicates row 2"},
+//	        {"row": 4, "key": "luis", "status": "failed", "reason": "Validation failed",
+//	         "details": [{"field": "weight_kg", "rule": "format", "message": "must be a number"}]}
+//	    ]
+//	}
+//
+// Example usage:
+// r.POST("/admin/import", ImportData(svc))
+func ImportData(svc *services.ImportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExists := c.Get("role")
+		if !idExist || !roleExists {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		if role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to import data into the club."))
+			return
+		}
+
+		var query models.ImportQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query
+			c.Error(err)
+			return
+		}
+
+		var format string
+		switch c.ContentType() {
+		case "text/csv", "application/csv":
+			format = models.ImportCSV
+		case "application/json":
+			format = models.ImportJSON
+		default:
+			// 415 Unsupported Media Type: Neither CSV nor JSON
+			c.Error(services.ErrImportFormat)
+			return
+		}
+
+		data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, models.MaxImportSize))
+		if err != nil {
+			// 400 Bad Request: Unreadable or oversized body
+			c.Error(apperrors.BadRequest("Invalid import file: " + err.Error()))
+			return
+		}
+
+		report, err := svc.Import(c, query.Kind, format, data, query.DryRun, id.(string))
+		if err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity / 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: File imported
+		responses.OK(c, report)
+	}
+}
@@ -0,0 +1,70 @@
+// ingest_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ingestRequest is the payload accepted by IngestEvents.
+type ingestRequest struct {
+	Events []models.IngestEvent `json:"events"`
+}
+
+// IngestEvents upserts event definitions pushed by a trusted external producer (e.g. the federation's central calendar).
+//
+// This function:
+// 1. Parses a batch of event definitions, each identified by its `external_id`.
+// 2. Creates, updates or leaves untouched the matching Events, so the same batch can be re-sent safely.
+// 3. Reports the outcome of every definition (created, updated, unchanged, conflict or invalid).
+//
+// HTTP Status Codes:
+// - 200 OK: The batch was processed; see the per-definition results.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized / 403 Forbidden: The API key is missing, wrong, or ingestion is disabled.
+// - 500 Internal Server Error: An issue occurred while storing the Events.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example JSON payload:
+//
+//	{
+//	    "events": [
+//	        {
+//	            "external_id": "fed-2025-0042",
+//	            "title": "Regional Powerlifting Open",
+//	            "description": "Federation-sanctioned meet.",
+//	            "date": "2025-03-15T09:00:00Z",
+//	            "location": "Municipal Sports Hall",
+//	            "updated_at": "2025-02-01T12:00:00Z"
+//	        }
+//	    ]
+//	}
+//
+// Example usage:
+// r.POST("/ingest/events", middleware.APIKeyMiddleware(key), IngestEvents(svc))
+func IngestEvents(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request ingestRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			// 400 Bad Request: Invalid JSON format
+			c.Error(apperrors.BadRequest("Invalid JSON format: " + err.Error()))
+			return
+		}
+
+		report, err := svc.Ingest(c, request.Events)
+		if err != nil {
+			// 500 Internal Server Error: Storage failure
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Batch processed
+		responses.OK(c, report)
+	}
+}
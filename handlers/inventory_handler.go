@@ -0,0 +1,329 @@
+// inventory_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetInventory lists the club equipment, ordered by name, with the units on loan and available.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the inventory.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 500 Internal Server Error: An issue occurred while fetching the inventory.
+//
+// Parameters:
+// - svc (*services.InventoryService): The service that manages the inventory.
+//
+// Example usage:
+// r.GET("/inventory", GetInventory(svc))
+func GetInventory(svc *services.InventoryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := svc.Items(c)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the inventory
+		responses.OK(c, items)
+	}
+}
+
+// CreateInventoryItem adds a piece of club equipment, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 201 Created: The item was successfully added.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: Required fields are missing or have invalid values.
+// - 500 Internal Server Error: An issue occurred while storing the item.
+//
+// Parameters:
+// - svc (*services.InventoryService): The service that manages the inventory.
+//
+// Example JSON payload (category is "bar", "plates", "belt" or "other"):
+//
+//	{
+//	    "name": "Lifting belt (M)",
+//	    "category": "belt",
+//	    "description": "10 mm leather belt",
+//	    "quantity": 4
+//	}
+//
+// Example usage:
+// r.POST("/inventory", CreateInventoryItem(svc))
+func CreateInventoryItem(svc *services.InventoryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the inventory."))
+			return
+		}
+
+		var input models.InventoryItemInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		item, err := svc.CreateItem(c, input)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The item was successfully added
+		responses.Created(c, item)
+	}
+}
+
+// UpdateInventoryItem replaces the details of a piece of club equipment, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: The item was successfully updated.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The item with the specified ID was not found.
+// - 409 Conflict: The new quantity is lower than the units on loan.
+// - 422 Unprocessable Entity: Required fields are missing or have invalid values.
+// - 500 Internal Server Error: An issue occurred while updating the item.
+//
+// Parameters:
+// - svc (*services.InventoryService): The service that manages the inventory.
+//
+// Example usage:
+// r.PUT("/inventory/:id", UpdateInventoryItem(svc))
+func UpdateInventoryItem(svc *services.InventoryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the inventory."))
+			return
+		}
+
+		var input models.InventoryItemInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		item, err := svc.UpdateItem(c, c.Param("id"), input)
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The item was successfully updated
+		responses.OK(c, item)
+	}
+}
+
+// DeleteInventoryItem removes a piece of club equipment, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 204 No Content: The item was successfully removed.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The item with the specified ID was not found.
+// - 409 Conflict: Units of the item are on loan.
+// - 500 Internal Server Error: An issue occurred while removing the item.
+//
+// Parameters:
+// - svc (*services.InventoryService): The service that manages the inventory.
+//
+// Example usage:
+// r.DELETE("/inventory/:id", DeleteInventoryItem(svc))
+func DeleteInventoryItem(svc *services.InventoryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the inventory."))
+			return
+		}
+
+		if err := svc.DeleteItem(c, c.Param("id")); err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The item was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// GetLoans lists the loans of club equipment, most recently lent first, restricted to admin role.
+// The `?status=` query parameter selects the "open", "overdue" or "returned" loans, and `?item_id=`
+// and `?complejo_id=` the loans of an item or a Complejo.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the loans.
+// - 400 Bad Request: The query parameters could not be parsed.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The status is not one of the allowed values.
+// - 500 Internal Server Error: An issue occurred while fetching the loans.
+//
+// Parameters:
+// - svc (*services.InventoryService): The service that manages the inventory.
+//
+// Example usage:
+// r.GET("/inventory/loans", GetLoans(svc))
+func GetLoans(svc *services.InventoryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the loans."))
+			return
+		}
+
+		var filter models.LoanFilter
+		if err := validation.BindQuery(c, &filter); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		loans, err := svc.Loans(c, filter)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the loans
+		responses.OK(c, loans)
+	}
+}
+
+// LendInventoryItem lends units of a piece of club equipment to a Complejo until a due date, restricted to admin role.
+// The Complejo is reminded every day once the loan is overdue.
+//
+// HTTP Status Codes:
+// - 201 Created: The loan was successfully recorded.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The item or the Complejo was not found.
+// - 409 Conflict: Not enough units of the item are available.
+// - 422 Unprocessable Entity: Required fields are missing or the due date is not in the future.
+// - 500 Internal Server Error: An issue occurred while storing the loan.
+//
+// Parameters:
+// - svc (*services.InventoryService): The service that manages the inventory.
+//
+// Example JSON payload (quantity defaults to 1):
+//
+//	{
+//	    "item_id": "2f6c1c0e-8d4b-4a4e-9a51-0c1f3b7d9e21",
+//	    "complejo_id": "8a1d2e3f-4b5c-6d7e-8f90-a1b2c3d4e5f6",
+//	    "quantity": 1,
+//	    "due_at": "2026-10-30T20:00:00Z"
+//	}
+//
+// Example usage:
+// r.POST("/inventory/loans", LendInventoryItem(svc))
+func LendInventoryItem(svc *services.InventoryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		id, idExist := c.Get("_id")
+		if !roleExists || role != "admin" || !idExist {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to lend equipment."))
+			return
+		}
+
+		var input models.LoanInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		loan, err := svc.Lend(c, input, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The loan was successfully recorded
+		responses.Created(c, loan)
+	}
+}
+
+// ReturnInventoryItem records the return of lent equipment, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: The return was successfully recorded.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The loan with the specified ID was not found.
+// - 409 Conflict: The item was already returned.
+// - 500 Internal Server Error: An issue occurred while updating the loan.
+//
+// Parameters:
+// - svc (*services.InventoryService): The service that manages the inventory.
+//
+// Example usage:
+// r.PUT("/inventory/loans/:id/return", ReturnInventoryItem(svc))
+func ReturnInventoryItem(svc *services.InventoryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to record returns."))
+			return
+		}
+
+		loan, err := svc.Return(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The return was successfully recorded
+		responses.OK(c, loan)
+	}
+}
+
+// GetEventEquipment lists the club equipment with the units available on the date of an Event,
+// so organizers can plan what to reserve. Loans due on or after the Event, and overdue ones, count as on loan.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the equipment availability.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the inventory.
+//
+// Parameters:
+// - svc (*services.InventoryService): The service that manages the inventory.
+//
+// Example usage:
+// r.GET("/event/:id/equipment", GetEventEquipment(svc))
+func GetEventEquipment(svc *services.InventoryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := svc.EventEquipment(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the equipment availability
+		responses.OK(c, items)
+	}
+}
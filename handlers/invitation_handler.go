@@ -0,0 +1,129 @@
+// invitation_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// joinResponse is returned when an invited guest joins: its registration and the Events carried over to it.
+type joinResponse struct {
+	registrationResponse
+	Events []string `json:"events"`
+}
+
+// InviteGuest makes an invitation link for a guest of an Event to join as a Complejo,
+// restricted to admins and the Complejo that brought the guest.
+//
+// The token of the link is only returned once; the link works for a week and can be used once.
+//
+// HTTP Status Codes:
+// - 201 Created: The invitation was successfully made; the response carries the token and the link.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the host of the guest.
+// - 404 Not Found: The Event or the guest was not found.
+// - 500 Internal Server Error: An issue occurred while storing the invitation.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+//
+// Example usage:
+// r.POST("/event/:id/guest/:guest_id/invitation", InviteGuest(svc))
+func InviteGuest(svc *services.ComplejoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		link, err := svc.InviteGuest(c, c.Param("id"), c.Param("guest_id"), id.(string), role == "admin")
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The invitation was successfully made
+		responses.Created(c, link)
+	}
+}
+
+// GetInvitation returns the name of an invited guest and the Events it was brought to, to pre-fill its sign-up.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the invitation.
+// - 404 Not Found: No invitation matches the token.
+// - 409 Conflict: The invitation has already been used.
+// - 410 Gone: The invitation has expired.
+// - 500 Internal Server Error: An issue occurred while fetching the invitation.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+//
+// Example usage:
+// r.GET("/complejo/join/:token", GetInvitation(svc))
+func GetInvitation(svc *services.ComplejoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		preview, err := svc.Invitation(c, c.Param("token"))
+		if err != nil {
+			// 404 Not Found, 409 Conflict, 410 Gone or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the invitation
+		responses.OK(c, preview)
+	}
+}
+
+// JoinByInvitation creates a Complejo for an invited guest, like POST /complejo, and carries over the Events
+// the guest was brought to: they become "going" RSVPs of the new Complejo, so its attendance history is kept.
+//
+// HTTP Status Codes:
+// - 201 Created: The Complejo was successfully created; the response carries it, its token and the Events carried over.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 404 Not Found: No invitation matches the token.
+// - 409 Conflict: The invitation has already been used or the username is already taken.
+// - 410 Gone: The invitation has expired.
+// - 422 Unprocessable Entity: Required fields are missing or have invalid values.
+// - 429 Too Many Requests: The photo could not be queued for processing.
+// - 500 Internal Server Error: An issue occurred while creating the Complejo or generating the token.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+//
+// Example JSON payload: the same as for POST /complejo.
+//
+// Example usage:
+// r.POST("/complejo/join/:token", JoinByInvitation(svc))
+func JoinByInvitation(svc *services.ComplejoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var complejo models.Complejo
+		if err := validation.BindJSON(c, &complejo); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		token, events, err := svc.Join(c, c.Param("token"), &complejo)
+		if err != nil {
+			// 404 Not Found, 409 Conflict, 410 Gone, 429 Too Many Requests or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The Complejo was successfully created
+		responses.Created(c, joinResponse{
+			registrationResponse: registrationResponse{Complejo: complejo.Response(models.ComplejoView{Relation: models.RelationSelf}), Token: token},
+			Events:               events,
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// journal_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJournal lists the most recent failed requests recorded in the request journal, restricted to admin role.
+//
+// Each entry is anonymized: it carries the method, route, path, body schema and its hash, the caller's role
+// and the outcome, but never the body values or the caller's identity. The list can be fed to the replay
+// tool (`go run ./cmd/replay`) to reproduce the failures against a staging database.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the entries (possibly an empty list).
+// - 400 Bad Request: The limit is not a number.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 500 Internal Server Error: An issue occurred while reading the journal.
+//
+// Parameters:
+// - svc (*services.JournalService): The service that manages the request journal.
+//
+// Example usage:
+// r.GET("/journal?limit=100", GetJournal(svc))
+func GetJournal(svc *services.JournalService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the request journal."))
+			return
+		}
+
+		limit := 0
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				// 400 Bad Request: Invalid limit
+				c.Error(apperrors.BadRequest("Invalid limit: " + value))
+				return
+			}
+			limit = parsed
+		}
+
+		entries, err := svc.Recent(c, limit)
+		if err != nil {
+			// 500 Internal Server Error: Query error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the entries
+		responses.OK(c, entries)
+	}
+}
+
+// GetJournalEntry retrieves a single entry of the request journal by ID, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the entry.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The entry with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while reading the journal.
+//
+// Parameters:
+// - svc (*services.JournalService): The service that manages the request journal.
+//
+// Example usage:
+// r.GET("/journal/:id", GetJournalEntry(svc))
+func GetJournalEntry(svc *services.JournalService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the request journal."))
+			return
+		}
+
+		entry, err := svc.Get(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the entry
+		responses.OK(c, entry)
+	}
+}
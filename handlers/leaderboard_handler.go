@@ -0,0 +1,75 @@
+// leaderboard_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetLeaderboard retrieves a page of the ranking of the Complejos by a lift, best first, together with the
+// pagination metadata. No token is needed; with one, the response includes the place of the caller (`me`), on
+// whichever page it is.
+//
+// Complejos are ranked by the kilos of the lift, or by their Wilks or DOTS score relative to bodyweight when a
+// scoring is given; Complejos with the same kilos (or score) share their rank. Complejos that did not record the
+// lift are left out, the total only ranks the Complejos that recorded all three lifts, and the scored rankings
+// only the "male" and "female" Complejos that recorded their bodyweight, the formulas being defined for those
+// only.
+//
+// Query parameters (all optional):
+// - lift: "bench", "squad", "dl" or "total" (default: "total").
+// - gender: "male", "female" or "other" (default: every gender).
+// - scoring: "wilks" or "dots" (default: none, ranked by kilos).
+// - page: 1-based page number (default: 1).
+// - limit: Page size (default: 20, at most 100).
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of the leaderboard (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 422 Unprocessable Entity: A query parameter is not one of its allowed values, or out of range.
+// - 500 Internal Server Error: An issue occurred while ranking the Complejos.
+//
+// Parameters:
+// - svc (*services.LeaderboardService): The service that ranks the Complejos.
+//
+// Example response data:
+//
+//	{
+//	    "lift": "total",
+//	    "scoring": "dots",
+//	    "entries": [
+//	        {"rank": 1, "complejo_id": "...", "username": "maria", "gender": "female", "kilos": 420, "score": 465.59},
+//	        {"rank": 2, "complejo_id": "...", "username": "juan", "gender": "male", "kilos": 610, "score": 375.46}
+//	    ],
+//	    "me": {"rank": 2, "complejo_id": "...", "username": "juan", "gender": "male", "kilos": 610, "score": 375.46}
+//	}
+//
+// Example usage:
+// r.GET("/leaderboard", GetLeaderboard(svc))
+//
+// Example request:
+// GET /leaderboard?lift=total&gender=female&scoring=dots&page=1&limit=20
+func GetLeaderboard(svc *services.LeaderboardService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.LeaderboardQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request or 422 Unprocessable Entity
+			c.Error(err)
+			return
+		}
+
+		leaderboard, total, err := svc.Leaderboard(c, &query, c.GetString("_id"))
+		if err != nil {
+			// 500 Internal Server Error: Query error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of the leaderboard
+		responses.OKWithMeta(c, leaderboard, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
@@ -0,0 +1,179 @@
+// lift_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecordLift adds a lift to the history of the authenticated user. A lift beating the best weight of the lift on
+// the profile (bench, squad or dl) raises it, and is marked as a `record`; older or lighter lifts are kept in the
+// history only, so logging past sessions never lowers the profile. The bodyweight defaults to the weight on the
+// profile. A lift of several repetitions (`reps`, 1 by default) also gets its estimated one-rep max (`e1rm`, see
+// GET /tools/1rm), the weight itself for a single; the records compare the weight lifted, not the estimates.
+//
+// HTTP Status Codes:
+// - 201 Created: The lift was recorded.
+// - 400 Bad Request: The body is not valid JSON.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 422 Unprocessable Entity: The lift is unknown, the weight (above 0, at most 1000), repetitions (1 to 20) or
+// bodyweight (above 0, at most 500) out of range, or the date in the future.
+// - 500 Internal Server Error: An issue occurred while recording the lift.
+//
+// Parameters:
+// - svc (*services.LiftService): The service that keeps the lift history.
+//
+// Example request body:
+//
+//	{
+//	    "lift": "bench",
+//	    "weight": 102.5,
+//	    "reps": 3,
+//	    "bodyweight": 82.4,
+//	    "date": "2026-10-14T19:30:00Z"
+//	}
+//
+// Example usage:
+// r.POST("/complejo/me/lifts", RecordLift(svc))
+func RecordLift(svc *services.LiftService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.LiftEntryInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		entry, err := svc.Record(c, id.(string), input)
+		if err != nil {
+			// 404 Not Found, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The lift was recorded
+		responses.Created(c, entry)
+	}
+}
+
+// GetLiftHistory retrieves the lift history of a Complejo by ID, oldest first, for progress charts. The best
+// weight of each lift is on the profile itself (GET /complejo/:id). The bodyweight recorded with the lifts is
+// left out for the callers the privacy settings of the Complejo do not let see its weight. A Complejo blocking
+// the caller is not found.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the history (possibly an empty list).
+// - 404 Not Found: The Complejo with the specified ID was not found, or it blocks the caller.
+// - 422 Unprocessable Entity: The lift is not "bench", "squad" or "dl".
+// - 500 Internal Server Error: An issue occurred while reading the history.
+//
+// Parameters:
+// - svc (*services.LiftService): The service that keeps the lift history.
+// - privacy (*services.PrivacyService): The service that tells which fields the caller sees.
+//
+// Example usage:
+// r.GET("/complejo/:id/lifts?lift=bench", GetLiftHistory(svc, privacy))
+func GetLiftHistory(svc *services.LiftService, privacy *services.PrivacyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.LiftHistoryQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		entries, err := svc.History(c, c.Param("id"), query)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		var view models.ComplejoView
+		if role, _ := c.Get("role"); role == "admin" {
+			view.Relation = models.RelationSelf
+		}
+		view, err = privacy.View(c, view, c.GetString("_id"), c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+		if !view.Sees("weight") {
+			for i := range entries {
+				entries[i].Bodyweight = 0
+			}
+		}
+
+		// 200 OK: Successfully retrieved the history
+		responses.OK(c, entries)
+	}
+}
+
+// GetOwnProgress retrieves the monthly trends of the lift history of the authenticated user, oldest month first,
+// for charts: the average bodyweight recorded with the lifts and the heaviest of each lift by month (null in the
+// months without one), and the estimated total, the sum of the best bench, squat and deadlift up to the end of
+// the month, once all three are recorded. Months are calendar months in the time zone of the server.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the trends.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 422 Unprocessable Entity: The number of months is out of range (1 to 60).
+// - 500 Internal Server Error: An issue occurred while aggregating the history.
+//
+// Parameters:
+// - svc (*services.LiftService): The service that keeps the lift history.
+//
+// Example response data:
+//
+//	{
+//	    "timezone": "Europe/Madrid",
+//	    "months": [
+//	        {"month": "2026-09", "bodyweight": 82.4, "bench": 100, "squad": null, "dl": 180, "estimated_total": 420},
+//	        {"month": "2026-10", "bodyweight": 81.9, "bench": 102.5, "squad": 145, "dl": null, "estimated_total": 427.5}
+//	    ]
+//	}
+//
+// Example usage:
+// r.GET("/complejo/me/progress?months=12", GetOwnProgress(svc))
+func GetOwnProgress(svc *services.LiftService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var query models.ProgressQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		progress, err := svc.Progress(c, id.(string), query)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the trends
+		responses.OK(c, progress)
+	}
+}
@@ -0,0 +1,276 @@
+// lost_found_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetLostItems lists the open posts of the lost-and-found board, newest first.
+// The `?kind=` query parameter selects the "lost" or "found" posts; photos are left out unless `?include=photo`.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the posts.
+// - 400 Bad Request: The query parameters could not be parsed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 422 Unprocessable Entity: The kind or include parameter is not one of the allowed values.
+// - 500 Internal Server Error: An issue occurred while fetching the posts.
+//
+// Parameters:
+// - svc (*services.LostFoundService): The service that manages the lost-and-found board.
+//
+// Example usage:
+// r.GET("/lost-found", GetLostItems(svc))
+func GetLostItems(svc *services.LostFoundService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.LostItemQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		items, err := svc.List(c, query)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the posts
+		responses.OK(c, items)
+	}
+}
+
+// PostLostItem publishes a lost or found item on the lost-and-found board. The post expires after 60 days.
+// A post flagged by the content filter is returned with the "held" status and published once an admin approves it.
+//
+// HTTP Status Codes:
+// - 201 Created: The post was successfully published (or held for review).
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo of the token no longer exists.
+// - 422 Unprocessable Entity: Required fields are missing or the photo is not a valid image.
+// - 429 Too Many Requests: The photo could not be queued for processing.
+// - 500 Internal Server Error: An issue occurred while storing the post.
+//
+// Parameters:
+// - svc (*services.LostFoundService): The service that manages the lost-and-found board.
+//
+// Example JSON payload (kind is "lost" or "found", the photo is optional):
+//
+//	{
+//	    "kind": "found",
+//	    "title": "Black water bottle",
+//	    "description": "Left next to the squat rack on Monday evening",
+//	    "photo": "data:image/jpeg;base64,/9j/4AAQSkZJRg..."
+//	}
+//
+// Example usage:
+// r.POST("/lost-found", PostLostItem(svc))
+func PostLostItem(svc *services.LostFoundService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.LostItemInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		item, err := svc.Post(c, input, id.(string))
+		if err != nil {
+			// 404 Not Found, 422 Unprocessable Entity, 429 Too Many Requests or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The post was successfully published
+		responses.Created(c, item)
+	}
+}
+
+// DeleteLostItem removes a post of the lost-and-found board and its claims,
+// restricted to admins and the author of the post.
+//
+// HTTP Status Codes:
+// - 204 No Content: The post was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the author of the post.
+// - 404 Not Found: The post with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the post.
+//
+// Parameters:
+// - svc (*services.LostFoundService): The service that manages the lost-and-found board.
+//
+// Example usage:
+// r.DELETE("/lost-found/:id", DeleteLostItem(svc))
+func DeleteLostItem(svc *services.LostFoundService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The post was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// ClaimLostItem claims an open post of the lost-and-found board: the owner of a found item asks to collect it,
+// or the finder of a lost item reports where it is. An admin approves or rejects the claim.
+//
+// HTTP Status Codes:
+// - 201 Created: The claim was successfully recorded.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The post was not found or has expired.
+// - 409 Conflict: The post is resolved or the user's own, or the user already has a pending claim on it.
+// - 422 Unprocessable Entity: The message is missing or too long.
+// - 500 Internal Server Error: An issue occurred while storing the claim.
+//
+// Parameters:
+// - svc (*services.LostFoundService): The service that manages the lost-and-found board.
+//
+// Example JSON payload:
+//
+//	{
+//	    "message": "It is mine, it has a sticker of the club on the bottom"
+//	}
+//
+// Example usage:
+// r.POST("/lost-found/:id/claims", ClaimLostItem(svc))
+func ClaimLostItem(svc *services.LostFoundService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.ClaimInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		claim, err := svc.Claim(c, c.Param("id"), input, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The claim was successfully recorded
+		responses.Created(c, claim)
+	}
+}
+
+// GetClaims lists the claims of a post of the lost-and-found board, oldest first, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the claims.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The post with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the claims.
+//
+// Parameters:
+// - svc (*services.LostFoundService): The service that manages the lost-and-found board.
+//
+// Example usage:
+// r.GET("/lost-found/:id/claims", GetClaims(svc))
+func GetClaims(svc *services.LostFoundService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the claims."))
+			return
+		}
+
+		claims, err := svc.Claims(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the claims
+		responses.OK(c, claims)
+	}
+}
+
+// DecideClaim approves or rejects a pending claim on a post of the lost-and-found board, restricted to admin role.
+// Approving a claim resolves the post and rejects its other pending claims; claimants are notified of the decision.
+//
+// HTTP Status Codes:
+// - 200 OK: The claim was successfully decided.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The post (or an unexpired one) or the claim was not found.
+// - 409 Conflict: The post is already resolved or the claim already decided.
+// - 422 Unprocessable Entity: The status is not "approved" or "rejected", or the note is too long.
+// - 500 Internal Server Error: An issue occurred while storing the decision.
+//
+// Parameters:
+// - svc (*services.LostFoundService): The service that manages the lost-and-found board.
+//
+// Example JSON payload:
+//
+//	{
+//	    "status": "approved",
+//	    "note": "Pick it up at the front desk"
+//	}
+//
+// Example usage:
+// r.PUT("/lost-found/:id/claims/:claim_id", DecideClaim(svc))
+func DecideClaim(svc *services.LostFoundService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		id, idExist := c.Get("_id")
+		if !roleExists || role != "admin" || !idExist {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to decide claims."))
+			return
+		}
+
+		var decision models.ClaimDecision
+		if err := validation.BindJSON(c, &decision); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		claim, err := svc.Decide(c, c.Param("id"), c.Param("claim_id"), decision, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The claim was successfully decided
+		responses.OK(c, claim)
+	}
+}
@@ -0,0 +1,74 @@
+// media_handler.go
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMedia serves a stored image by its ID, a profile photo or an album photo, for browsers and CDNs to cache.
+// The `size` query parameter selects a size variant: "thumb" (96 pixels) or "medium" (480 pixels), served as the
+// image itself until it is made. No token is needed for the profile photos and the published album photos; the
+// other album photos are served to the users that may see them (see GetEventPhotos).
+//
+// Every image carries an `ETag`, and a request whose `If-None-Match` matches it gets a 304 Not Modified without
+// the image. Profile photos are cached for good (a new upload gets a new ID), published album photos may be kept
+// by shared caches but must be revalidated (they may be removed), and the others only by the browser of the user.
+// Range requests are honored.
+//
+// HTTP Status Codes:
+// - 200 OK: The image was successfully downloaded.
+// - 206 Partial Content: The requested range of the image was downloaded.
+// - 304 Not Modified: The cached image is still current.
+// - 404 Not Found: No image has the given ID, or the user may not see it.
+// - 422 Unprocessable Entity: The size is neither "thumb" nor "medium".
+// - 500 Internal Server Error: An issue occurred while reading the image.
+//
+// Parameters:
+// - svc (*services.MediaService): The service that serves the stored images.
+//
+// Example request:
+//
+//	curl -i -H 'If-None-Match: "9b2f0c6d1e8a4f3b7c5d2e1f0a9b8c7d"' http://localhost:8080/media/3f6c2a9e-...?size=thumb
+//
+// Example usage:
+// r.GET("/media/:id", GetMedia(svc))
+func GetMedia(svc *services.MediaService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.PhotoSizeQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 422 Unprocessable Entity: Unknown size
+			c.Error(err)
+			return
+		}
+
+		media, err := svc.Get(c, c.Param("id"), query.Size, c.GetString("_id"), c.GetString("role") == "admin")
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK, 206 Partial Content or 304 Not Modified: The conditional and range headers are handled by
+		// http.ServeContent against the ETag
+		switch {
+		case media.Immutable:
+			c.Header("Cache-Control", "public, max-age=31536000, immutable")
+		case media.Private:
+			c.Header("Cache-Control", "private, no-cache")
+		default:
+			c.Header("Cache-Control", "public, no-cache")
+		}
+		c.Header("ETag", media.ETag)
+		c.Header("Content-Type", media.ContentType)
+		c.Header("X-Content-Type-Options", "nosniff")
+		http.ServeContent(c.Writer, c.Request, "", time.Time{}, bytes.NewReader(media.Data))
+	}
+}
@@ -0,0 +1,262 @@
+// moderation_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetHolds lists the user content held by the content filter, oldest first, restricted to admin role.
+// The `?status=` query parameter selects the pending (default), approved or rejected holds.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the held content.
+// - 400 Bad Request: The query parameters could not be parsed.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The status is not "pending", "approved" or "rejected".
+// - 500 Internal Server Error: An issue occurred while fetching the held content.
+//
+// Parameters:
+// - svc (*services.ModerationService): The service that reviews the held content.
+//
+// Example usage:
+// r.GET("/moderation/holds", GetHolds(svc))
+func GetHolds(svc *services.ModerationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to review held content."))
+			return
+		}
+
+		var query models.HoldQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		holds, err := svc.Holds(c, query)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the held content
+		responses.OK(c, holds)
+	}
+}
+
+// DecideHold approves or rejects user content held by the content filter, restricted to admin role.
+// Approving a lost-and-found post publishes it and rejecting it removes it; rejecting a username restores the
+// previous one (or a neutral one). The author is notified of the decision.
+//
+// HTTP Status Codes:
+// - 200 OK: The held content was successfully reviewed.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The held content with the specified ID was not found.
+// - 409 Conflict: The held content was already reviewed, or the previous username has been taken since.
+// - 422 Unprocessable Entity: The status is not "approved" or "rejected", or the note is too long.
+// - 500 Internal Server Error: An issue occurred while storing the decision.
+//
+// Parameters:
+// - svc (*services.ModerationService): The service that reviews the held content.
+//
+// Example JSON payload:
+//
+//	{
+//	    "status": "rejected",
+//	    "note": "Links to other shops are not allowed"
+//	}
+//
+// Example usage:
+// r.PUT("/moderation/holds/:id", DecideHold(svc))
+func DecideHold(svc *services.ModerationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		id, idExist := c.Get("_id")
+		if !roleExists || role != "admin" || !idExist {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to review held content."))
+			return
+		}
+
+		var decision models.HoldDecision
+		if err := validation.BindJSON(c, &decision); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		hold, err := svc.Decide(c, c.Param("id"), decision, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The held content was successfully reviewed
+		responses.OK(c, hold)
+	}
+}
+
+// ReportContent reports an event or the profile of a Complejo to the admins, who review it in the moderation
+// queue. A user cannot report itself, nor report the same content again while its report is pending.
+//
+// HTTP Status Codes:
+// - 201 Created: The report was stored, pending review.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The reported event or Complejo was not found.
+// - 409 Conflict: The user already reported this content (error code "already_reported").
+// - 422 Unprocessable Entity: The kind or reason is invalid, the details are too long, or the user reports
+// itself.
+// - 500 Internal Server Error: An issue occurred while storing the report.
+//
+// Parameters:
+// - svc (*services.ModerationService): The service that reviews the reported content.
+//
+// Example JSON payload:
+//
+//	{
+//	    "kind": "event",
+//	    "target_id": "...",
+//	    "reason": "spam",
+//	    "details": "Advertises a supplements shop"
+//	}
+//
+// Example usage:
+// r.POST("/report", ReportContent(svc))
+func ReportContent(svc *services.ModerationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.ContentReportInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		report, err := svc.Report(c, input, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The report is pending review
+		responses.Created(c, report)
+	}
+}
+
+// GetReports lists the reported content, oldest first, with `page`/`limit` pagination, restricted to admin role.
+// The `?status=` query parameter selects the pending (default), dismissed or actioned reports, and `?kind=` the
+// reports of events or of Complejos.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of reports (possibly empty).
+// - 400 Bad Request: The query parameters could not be parsed.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The status, kind, page or limit is invalid.
+// - 500 Internal Server Error: An issue occurred while fetching the reports.
+//
+// Parameters:
+// - svc (*services.ModerationService): The service that reviews the reported content.
+//
+// Example usage:
+// r.GET("/moderation/reports?kind=event", GetReports(svc))
+func GetReports(svc *services.ModerationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to review reported content."))
+			return
+		}
+
+		var query models.ContentReportQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		reports, total, err := svc.Reports(c, &query)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of reports
+		responses.OKWithMeta(c, reports, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
+
+// DecideReport dismisses a report or acts on it, restricted to admin role. Dismissing it leaves the content
+// alone; acting on it deletes the reported event or Complejo, and closes every pending report of that content
+// with the same decision.
+//
+// HTTP Status Codes:
+// - 200 OK: The report was successfully reviewed.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The report with the specified ID was not found.
+// - 409 Conflict: The report was already reviewed.
+// - 422 Unprocessable Entity: The status is not "dismissed" or "actioned", or the note is too long.
+// - 500 Internal Server Error: An issue occurred while storing the decision or deleting the content.
+//
+// Parameters:
+// - svc (*services.ModerationService): The service that reviews the reported content.
+//
+// Example JSON payload:
+//
+//	{
+//	    "status": "actioned",
+//	    "note": "Advertising is not allowed"
+//	}
+//
+// Example usage:
+// r.PUT("/moderation/reports/:id", DecideReport(svc))
+func DecideReport(svc *services.ModerationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		id, idExist := c.Get("_id")
+		if !roleExists || role != "admin" || !idExist {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to review reported content."))
+			return
+		}
+
+		var decision models.ContentReportDecision
+		if err := validation.BindJSON(c, &decision); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		report, err := svc.DecideReport(c, c.Param("id"), decision, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The report was successfully reviewed
+		responses.OK(c, report)
+	}
+}
@@ -0,0 +1,328 @@
+// nutrition_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetNutritionLogs lists the nutrition logs of the authenticated user from the day `from` to the day `to`
+// ("2006-01-02", by default the 30 days up to today in the time zone of the server), oldest first.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the logs (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 422 Unprocessable Entity: A day is not formatted as "2006-01-02", or from is after to.
+// - 500 Internal Server Error: An issue occurred while fetching the logs.
+//
+// Parameters:
+// - svc (*services.NutritionService): The service that keeps the nutrition logs.
+//
+// Example usage:
+// r.GET("/nutrition?from=2026-10-01&to=2026-10-16", GetNutritionLogs(svc))
+func GetNutritionLogs(svc *services.NutritionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var query models.NutritionQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		logs, err := svc.List(c, id.(string), query)
+		if err != nil {
+			// 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the logs
+		responses.OK(c, logs)
+	}
+}
+
+// LogNutrition records what the authenticated user ate over a day: the calories (kcal), the protein, carbs and
+// fat (grams) and free notes. The day defaults to today in the time zone of the server; a day has at most one
+// log, changed with PUT /nutrition/:id.
+//
+// HTTP Status Codes:
+// - 201 Created: The log was recorded.
+// - 400 Bad Request: The body is not valid JSON.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 409 Conflict: The day already has a log.
+// - 422 Unprocessable Entity: A field is out of range, or the day is in the future.
+// - 500 Internal Server Error: An issue occurred while recording the log.
+//
+// Parameters:
+// - svc (*services.NutritionService): The service that keeps the nutrition logs.
+//
+// Example request body:
+//
+//	{
+//	    "date": "2026-10-15",
+//	    "calories": 2650,
+//	    "protein": 170,
+//	    "carbs": 310,
+//	    "fat": 75,
+//	    "notes": "Cheat meal at dinner"
+//	}
+//
+// Example usage:
+// r.POST("/nutrition", LogNutrition(svc))
+func LogNutrition(svc *services.NutritionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.NutritionInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		log, err := svc.Log(c, id.(string), input)
+		if err != nil {
+			// 404 Not Found, 409 Conflict, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The log was recorded
+		responses.Created(c, log)
+	}
+}
+
+// GetNutritionLog retrieves a nutrition log of the authenticated user by ID.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the log.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The log belongs to another user.
+// - 404 Not Found: The log with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the log.
+//
+// Parameters:
+// - svc (*services.NutritionService): The service that keeps the nutrition logs.
+//
+// Example usage:
+// r.GET("/nutrition/:id", GetNutritionLog(svc))
+func GetNutritionLog(svc *services.NutritionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		log, err := svc.Get(c, c.Param("id"), id.(string))
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the log
+		responses.OK(c, log)
+	}
+}
+
+// UpdateNutritionLog replaces a nutrition log of the authenticated user, and its day when the body has one. The
+// body is the one of POST /nutrition.
+//
+// HTTP Status Codes:
+// - 200 OK: The log was successfully updated.
+// - 400 Bad Request: The body is not valid JSON.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The log belongs to another user.
+// - 404 Not Found: The log with the specified ID was not found.
+// - 409 Conflict: The new day already has another log.
+// - 422 Unprocessable Entity: A field is out of range, or the day is in the future.
+// - 500 Internal Server Error: An issue occurred while updating the log.
+//
+// Parameters:
+// - svc (*services.NutritionService): The service that keeps the nutrition logs.
+//
+// Example usage:
+// r.PUT("/nutrition/:id", UpdateNutritionLog(svc))
+func UpdateNutritionLog(svc *services.NutritionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.NutritionInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		log, err := svc.Update(c, c.Param("id"), id.(string), input)
+		if err != nil {
+			// 403 Forbidden, 404 Not Found, 409 Conflict, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The log was successfully updated
+		responses.OK(c, log)
+	}
+}
+
+// DeleteNutritionLog removes a nutrition log of the authenticated user.
+//
+// HTTP Status Codes:
+// - 204 No Content: The log was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The log belongs to another user.
+// - 404 Not Found: The log with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the log.
+//
+// Parameters:
+// - svc (*services.NutritionService): The service that keeps the nutrition logs.
+//
+// Example usage:
+// r.DELETE("/nutrition/:id", DeleteNutritionLog(svc))
+func DeleteNutritionLog(svc *services.NutritionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id"), id.(string)); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The log was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// GetNutritionTargets calculates the daily targets of the authenticated user from the weight and goal ("lose",
+// "maintain" or "gain", set with PUT /complejo/user) of its profile: the calories scale with the bodyweight by
+// goal (26, 31 or 35 kcal/kg), the protein too (2.2, 1.8 or 2 g/kg), the fat is 0.9 g/kg and the carbs fill the
+// remaining calories.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully calculated the targets.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 409 Conflict: The weight of the profile is not set.
+// - 500 Internal Server Error: An issue occurred while reading the profile.
+//
+// Parameters:
+// - svc (*services.NutritionService): The service that keeps the nutrition logs.
+//
+// Example response data:
+//
+//	{"goal": "maintain", "bodyweight": 80, "calories": 2480, "protein": 144, "carbs": 314, "fat": 72}
+//
+// Example usage:
+// r.GET("/nutrition/targets", GetNutritionTargets(svc))
+func GetNutritionTargets(svc *services.NutritionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		targets, err := svc.Targets(c, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully calculated the targets
+		responses.OK(c, targets)
+	}
+}
+
+// GetNutritionSummary sums the nutrition logs of the authenticated user by week (starting on Monday) or by month,
+// in the time zone of the server, oldest first: the days logged, their totals and the daily average of the days
+// logged, with the daily targets when the weight of the profile is set. Every period of the range is listed, for
+// charts.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the summary.
+// - 400 Bad Request: The number of periods is not a number.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 422 Unprocessable Entity: The period is not "week" or "month", or the number of periods is out of range.
+// - 500 Internal Server Error: An issue occurred while summarizing the logs.
+//
+// Parameters:
+// - svc (*services.NutritionService): The service that keeps the nutrition logs.
+//
+// Example response data:
+//
+//	{
+//	    "period": "week",
+//	    "timezone": "Europe/Madrid",
+//	    "targets": {"goal": "maintain", "bodyweight": 80, "calories": 2480, "protein": 144, "carbs": 314, "fat": 72},
+//	    "periods": [
+//	        {"start": "2026-10-12", "end": "2026-10-18", "days_logged": 2,
+//	         "total": {"calories": 5100, "protein": 330, "carbs": 600, "fat": 150},
+//	         "daily_average": {"calories": 2550, "protein": 165, "carbs": 300, "fat": 75}}
+//	    ]
+//	}
+//
+// Example usage:
+// r.GET("/nutrition/summary?period=month&periods=6", GetNutritionSummary(svc))
+func GetNutritionSummary(svc *services.NutritionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var query models.NutritionSummaryQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		summary, err := svc.Summary(c, id.(string), query)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the summary
+		responses.OK(c, summary)
+	}
+}
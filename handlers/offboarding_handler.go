@@ -0,0 +1,374 @@
+// offboarding_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/export"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportData downloads the full export of the data of the club (members, events and lift results) as a JSON
+// attachment following the schema served by GetExportSchema, restricted to admin role. Passwords are never
+// exported. During an offboarding, downloading the export allows confirming the purge.
+//
+// HTTP Status Codes:
+// - 200 OK: The export was successfully downloaded.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 500 Internal Server Error: An issue occurred while reading the data.
+// - 503 Service Unavailable: Too many expensive requests are running.
+//
+// Parameters:
+// - svc (*services.OffboardingService): The service that exports and purges the data of the club.
+//
+// Example usage:
+// r.GET("/admin/export", ExportData(svc))
+func ExportData(svc *services.OffboardingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to export the data of the club."))
+			return
+		}
+
+		doc, err := svc.Export(c)
+		if err != nil {
+			// 500 Internal Server Error: Read error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Export downloaded
+		c.Header("Content-Disposition", `attachment; filename="export-`+doc.ExportedAt.UTC().Format("2006-01-02")+`.json"`)
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// ExportComplejosCSV streams the members of the club as a CSV attachment for spreadsheets, in sign-up order,
+// restricted to admin role. The `fields` query parameter selects the columns and their order among id, username,
+// email, role, gender, weight_kg, height_m, imc, imc_category, bench_kg, squat_kg, deadlift_kg, locale, units,
+// photo_consent and created_at (default: all of them). Passwords and photos are never exported.
+//
+// HTTP Status Codes:
+// - 200 OK: The CSV export was successfully downloaded.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: A selected field is not a column of the export.
+// - 500 Internal Server Error: An issue occurred while reading the members.
+// - 503 Service Unavailable: Too many expensive requests are running.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages the Complejos.
+//
+// Example usage:
+// r.GET("/admin/export/complejos.csv", ExportComplejosCSV(svc))
+func ExportComplejosCSV(svc *services.ComplejoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to export the data of the club."))
+			return
+		}
+
+		var query models.CSVExportQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query
+			c.Error(err)
+			return
+		}
+		columns, unknown := export.SelectColumns(export.MemberColumns, query.Fields)
+		if unknown != nil {
+			// 422 Unprocessable Entity: Unknown field
+			c.Error(unknownColumns(unknown, export.ColumnNames(export.MemberColumns)))
+			return
+		}
+
+		complejos, err := svc.List(c)
+		if err != nil {
+			// 500 Internal Server Error: Read error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: CSV export downloaded
+		startCSV(c, "complejos.csv")
+		export.WriteMembersCSV(c.Writer, complejos, columns)
+	}
+}
+
+// ExportEventsCSV streams the events of the club as a CSV attachment for spreadsheets, oldest first, restricted
+// to admin role. The `fields` query parameter selects the columns and their order among id, title, date,
+// location, capacity, level, intensity, outdoor, featured, created_by, going, maybe, declined, guests and likes
+// (default: all of them).
+//
+// HTTP Status Codes:
+// - 200 OK: The CSV export was successfully downloaded.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: A selected field is not a column of the export.
+// - 500 Internal Server Error: An issue occurred while reading the events.
+// - 503 Service Unavailable: Too many expensive requests are running.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages the Events.
+//
+// Example usage:
+// r.GET("/admin/export/events.csv", ExportEventsCSV(svc))
+func ExportEventsCSV(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to export the data of the club."))
+			return
+		}
+
+		var query models.CSVExportQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query
+			c.Error(err)
+			return
+		}
+		columns, unknown := export.SelectColumns(export.EventColumns, query.Fields)
+		if unknown != nil {
+			// 422 Unprocessable Entity: Unknown field
+			c.Error(unknownColumns(unknown, export.ColumnNames(export.EventColumns)))
+			return
+		}
+
+		events, err := svc.List(c)
+		if err != nil {
+			// 500 Internal Server Error: Read error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: CSV export downloaded
+		startCSV(c, "events.csv")
+		export.WriteEventsCSV(c.Writer, events, columns)
+	}
+}
+
+// startCSV answers the headers of a CSV attachment with the given file name.
+func startCSV(c *gin.Context, filename string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+}
+
+// unknownColumns is the validation error of a field selection naming fields that are not columns of the export.
+func unknownColumns(unknown, columns []string) error {
+	return apperrors.Validation("Validation failed", []validation.FieldError{{
+		Field:   "fields",
+		Rule:    "oneof",
+		Message: "Unknown fields " + strings.Join(unknown, ", ") + "; the columns are " + strings.Join(columns, ", "),
+	}})
+}
+
+// GetExportSchema returns the JSON Schema of the exports. No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the schema.
+//
+// Example usage:
+// r.GET("/export/schema", GetExportSchema())
+func GetExportSchema() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 200 OK: Successfully retrieved the schema
+		c.Data(http.StatusOK, "application/schema+json", export.Schema)
+	}
+}
+
+// GetOffboarding returns the offboarding of the club, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the offboarding.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: No offboarding was requested.
+// - 500 Internal Server Error: An issue occurred while reading the offboarding.
+//
+// Parameters:
+// - svc (*services.OffboardingService): The service that exports and purges the data of the club.
+//
+// Example usage:
+// r.GET("/admin/offboarding", GetOffboarding(svc))
+func GetOffboarding(svc *services.OffboardingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the offboarding of the club."))
+			return
+		}
+
+		offboarding, err := svc.Status(c)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the offboarding
+		responses.OK(c, offboarding)
+	}
+}
+
+// RequestOffboarding starts the offboarding of the club, restricted to admin role: every data of the club will
+// be purged once confirmed with ConfirmOffboarding. The response holds the confirmation code, which is shown
+// only once, and the earliest time the purge can be confirmed (after OFFBOARDING_DELAY).
+//
+// HTTP Status Codes:
+// - 201 Created: The offboarding was successfully requested.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 409 Conflict: An offboarding is already pending.
+// - 500 Internal Server Error: An issue occurred while storing the offboarding.
+//
+// Parameters:
+// - svc (*services.OffboardingService): The service that exports and purges the data of the club.
+//
+// Example response data:
+//
+//	{
+//	    "status": "pending",
+//	    "requested_by": "8a1d...",
+//	    "requested_at": "2026-10-16T12:00:00Z",
+//	    "purge_after": "2026-10-19T12:00:00Z",
+//	    "confirmation_code": "K7QM-2XDA-9RTF-W4HN"
+//	}
+//
+// Example usage:
+// r.POST("/admin/offboarding", RequestOffboarding(svc))
+func RequestOffboarding(svc *services.OffboardingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExists := c.Get("role")
+		if !idExist || !roleExists {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		if role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the offboarding of the club."))
+			return
+		}
+
+		offboarding, err := svc.Request(c, id.(string))
+		if err != nil {
+			// 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The offboarding was successfully requested
+		responses.Created(c, offboarding)
+	}
+}
+
+// CancelOffboarding cancels the pending offboarding of the club, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 204 No Content: The offboarding was successfully cancelled.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: No offboarding is pending.
+// - 500 Internal Server Error: An issue occurred while cancelling the offboarding.
+//
+// Parameters:
+// - svc (*services.OffboardingService): The service that exports and purges the data of the club.
+//
+// Example usage:
+// r.DELETE("/admin/offboarding", CancelOffboarding(svc))
+func CancelOffboarding(svc *services.OffboardingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExists := c.Get("role")
+		if !idExist || !roleExists {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		if role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the offboarding of the club."))
+			return
+		}
+
+		if err := svc.Cancel(c, id.(string)); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The offboarding was successfully cancelled
+		responses.NoContent(c)
+	}
+}
+
+// ConfirmOffboarding purges every data of the club (Complejos, events and every other record, and the stored
+// files), restricted to admin role. It requires the confirmation code of the pending offboarding, an export
+// downloaded after the offboarding was requested, and the cooling-off delay to have elapsed. This cannot be
+// undone: the admins themselves are removed.
+//
+// HTTP Status Codes:
+// - 200 OK: The data was successfully purged; the response counts the records removed.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is not an admin, or the confirmation code does not match.
+// - 404 Not Found: No offboarding is pending.
+// - 409 Conflict: The data was not exported since the request, or the cooling-off delay has not elapsed.
+// - 422 Unprocessable Entity: The code is missing.
+// - 500 Internal Server Error: An issue occurred while purging the data.
+//
+// Parameters:
+// - svc (*services.OffboardingService): The service that exports and purges the data of the club.
+//
+// Example JSON payload:
+//
+//	{
+//	    "code": "K7QM-2XDA-9RTF-W4HN"
+//	}
+//
+// Example usage:
+// r.POST("/admin/offboarding/confirm", ConfirmOffboarding(svc))
+func ConfirmOffboarding(svc *services.OffboardingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExists := c.Get("role")
+		if !idExist || !roleExists {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		if role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the offboarding of the club."))
+			return
+		}
+
+		var input models.OffboardingConfirmation
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		offboarding, err := svc.Confirm(c, id.(string), input)
+		if err != nil {
+			// 403 Forbidden, 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The data was successfully purged
+		responses.OK(c, offboarding)
+	}
+}
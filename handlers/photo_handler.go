@@ -0,0 +1,274 @@
+// photo_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEventPhotos lists the album of an Event, oldest photo first. No token is needed: anonymous users see the
+// published photos (approved by an organizer and consented to by everyone tagged in them); admins and the creator
+// of the Event see every photo, and the other Complejos also the photos they uploaded or are tagged in.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the album.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the album.
+//
+// Parameters:
+// - svc (*services.PhotoService): The service that manages the event albums.
+//
+// Example usage:
+// r.GET("/event/:id/photos", GetEventPhotos(svc))
+func GetEventPhotos(svc *services.PhotoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		photos, err := svc.Photos(c, c.Param("id"), c.GetString("_id"), c.GetString("role") == "admin")
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the album
+		responses.OK(c, photos)
+	}
+}
+
+// UploadEventPhoto adds a photo to the album of an Event once it has started, restricted to admins, the creator
+// of the Event and the Complejos going. The photo is normalized like profile photos (which drops its location
+// metadata). The photos of the organizers are published at once; the others wait for an organizer to approve them.
+//
+// The Complejos appearing in the photo are listed in `tagged`. Depending on their `photo_consent` privacy
+// setting a tag is granted at once ("allow"), waits for the Complejo to grant it ("ask", the default), or is
+// rejected ("deny"). The photo is only published once every tag is granted.
+//
+// HTTP Status Codes:
+// - 201 Created: The photo was successfully added.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin, the creator of the Event nor going to it.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 409 Conflict: The Event has not started yet.
+// - 422 Unprocessable Entity: The photo is missing or not a JPEG, PNG or GIF image of at most 5 MB, the caption
+// is longer than 200 characters, or a tagged Complejo is unknown or does not allow being tagged.
+// - 429 Too Many Requests: Too many images are being processed.
+// - 500 Internal Server Error: An issue occurred while storing the photo.
+//
+// Parameters:
+// - svc (*services.PhotoService): The service that manages the event albums.
+//
+// Example JSON payload:
+//
+//	{
+//	    "photo": "data:image/jpeg;base64,/9j/4AAQSkZJRg...",
+//	    "caption": "Last set of the meet",
+//	    "tagged": ["8a1d...", "c27f..."]
+//	}
+//
+// Example usage:
+// r.POST("/event/:id/photos", UploadEventPhoto(svc))
+func UploadEventPhoto(svc *services.PhotoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.EventPhotoInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		photo, err := svc.Upload(c, c.Param("id"), input, id.(string), role == "admin")
+		if err != nil {
+			// 403 Forbidden, 404 Not Found, 409 Conflict, 422 Unprocessable Entity, 429 Too Many Requests
+			// or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The photo was successfully added
+		responses.Created(c, photo)
+	}
+}
+
+// ApproveEventPhoto approves a photo of the album of an Event, restricted to admins and the creator of the Event.
+//
+// HTTP Status Codes:
+// - 200 OK: The photo was successfully approved.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event or the photo was not found.
+// - 409 Conflict: The photo has already been approved.
+// - 500 Internal Server Error: An issue occurred while approving the photo.
+//
+// Parameters:
+// - svc (*services.PhotoService): The service that manages the event albums.
+//
+// Example usage:
+// r.PUT("/event/:id/photos/:photo_id/approve", ApproveEventPhoto(svc))
+func ApproveEventPhoto(svc *services.PhotoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		photo, err := svc.Approve(c, c.Param("id"), c.Param("photo_id"), id.(string), role == "admin")
+		if err != nil {
+			// 403 Forbidden, 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The photo was successfully approved
+		responses.OK(c, photo)
+	}
+}
+
+// DeleteEventPhoto removes a photo from the album of an Event. Admins and the creator of the Event may remove
+// any photo, and the other Complejos the photos they uploaded.
+//
+// HTTP Status Codes:
+// - 204 No Content: The photo was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin, the creator of the Event nor the author of the photo.
+// - 404 Not Found: The Event or the photo was not found.
+// - 500 Internal Server Error: An issue occurred while removing the photo.
+//
+// Parameters:
+// - svc (*services.PhotoService): The service that manages the event albums.
+//
+// Example usage:
+// r.DELETE("/event/:id/photos/:photo_id", DeleteEventPhoto(svc))
+func DeleteEventPhoto(svc *services.PhotoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id"), c.Param("photo_id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The photo was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// ConsentEventPhoto records the answer of the authenticated Complejo to a photo of the album of an Event it is
+// tagged in. Granting returns the photo; refusing, even after granting, removes the photo from the album.
+//
+// HTTP Status Codes:
+// - 200 OK: The consent was successfully granted.
+// - 204 No Content: The consent was refused and the photo removed.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Event or the photo was not found.
+// - 409 Conflict: The user is not tagged in the photo, or already granted its consent.
+// - 422 Unprocessable Entity: The consent is not "granted" or "refused".
+// - 500 Internal Server Error: An issue occurred while recording the consent.
+//
+// Parameters:
+// - svc (*services.PhotoService): The service that manages the event albums.
+//
+// Example JSON payload:
+//
+//	{
+//	    "consent": "granted"
+//	}
+//
+// Example usage:
+// r.PUT("/event/:id/photos/:photo_id/consent", ConsentEventPhoto(svc))
+func ConsentEventPhoto(svc *services.PhotoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.PhotoConsentInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		photo, err := svc.Consent(c, c.Param("id"), c.Param("photo_id"), id.(string), input)
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+		if photo == nil {
+			// 204 No Content: The consent was refused and the photo removed
+			responses.NoContent(c)
+			return
+		}
+
+		// 200 OK: The consent was successfully granted
+		responses.OK(c, photo)
+	}
+}
+
+// DownloadEventPhoto downloads a photo of the album of an Event, to the users that may see it
+// (see GetEventPhotos). No token is needed for the published photos. The `size` query parameter selects a size
+// variant, as linked by the `sizes` of the photo: "thumb" (96 pixels) or "medium" (480 pixels). They are made in
+// the background shortly after the upload; until then the photo itself is served.
+//
+// HTTP Status Codes:
+// - 200 OK: The photo was successfully downloaded.
+// - 404 Not Found: The Event or the photo was not found, or the user may not see the photo.
+// - 422 Unprocessable Entity: The size is neither "thumb" nor "medium".
+// - 500 Internal Server Error: An issue occurred while reading the photo.
+//
+// Parameters:
+// - svc (*services.PhotoService): The service that manages the event albums.
+//
+// Example usage:
+// r.GET("/event/:id/photos/:photo_id", DownloadEventPhoto(svc))
+func DownloadEventPhoto(svc *services.PhotoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.PhotoSizeQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 422 Unprocessable Entity: Unknown size
+			c.Error(err)
+			return
+		}
+
+		photo, data, err := svc.File(c, c.Param("id"), c.Param("photo_id"), query.Size, c.GetString("_id"), c.GetString("role") == "admin")
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Photo downloaded
+		c.Header("Content-Length", strconv.Itoa(len(data)))
+		c.Data(http.StatusOK, photo.ContentType, data)
+	}
+}
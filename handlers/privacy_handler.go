@@ -0,0 +1,108 @@
+// privacy_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetOwnPrivacy retrieves the privacy settings of the authenticated user: the audience of each field of its
+// profile that it chooses, "everyone", "followers" (itself and its followers) or "me" (itself). Admins see every
+// field. Users that never changed them get the default ones.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the privacy settings.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 500 Internal Server Error: An issue occurred while reading the settings.
+//
+// Parameters:
+// - svc (*services.PrivacyService): The service that keeps the privacy settings.
+//
+// Example response data:
+//
+//	{
+//	    "weight": "followers",
+//	    "height": "followers",
+//	    "imc": "followers",
+//	    "photo": "everyone"
+//	}
+//
+// Example usage:
+// r.GET("/complejo/me/privacy", GetOwnPrivacy(svc))
+func GetOwnPrivacy(svc *services.PrivacyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		settings, err := svc.Settings(c, id.(string))
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the privacy settings
+		responses.OK(c, settings)
+	}
+}
+
+// UpdateOwnPrivacy changes the audiences of the fields of the profile of the authenticated user present in the
+// payload; the others are kept. The profile reads (GET /complejo, GET /complejo/:id and the bodyweight of GET
+// /complejo/:id/lifts) leave out the fields the caller is not in the audience of.
+//
+// HTTP Status Codes:
+// - 200 OK: The privacy settings were successfully updated.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 422 Unprocessable Entity: An audience is not "everyone", "followers" or "me".
+// - 500 Internal Server Error: An issue occurred while storing the settings.
+//
+// Parameters:
+// - svc (*services.PrivacyService): The service that keeps the privacy settings.
+//
+// Example request body:
+//
+//	{
+//	    "weight": "me",
+//	    "photo": "followers"
+//	}
+//
+// Example usage:
+// r.PUT("/complejo/me/privacy", UpdateOwnPrivacy(svc))
+func UpdateOwnPrivacy(svc *services.PrivacyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.PrivacySettingsInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		settings, err := svc.Update(c, id.(string), input)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The privacy settings were successfully updated
+		responses.OK(c, settings)
+	}
+}
@@ -0,0 +1,144 @@
+// profile_photo_handler.go
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// profilePhotoResponse is returned when a profile photo is uploaded: its ID and the paths serving it and its sizes.
+type profilePhotoResponse struct {
+	PhotoID    string            `json:"photo_id"`
+	PhotoURL   string            `json:"photo_url"`
+	PhotoSizes map[string]string `json:"photo_sizes"`
+}
+
+// UploadComplejoPhoto sets the profile photo of the authenticated Complejo from a multipart upload, replacing its
+// previous photo. The image is sent in the "photo" part (a JPEG, PNG or GIF of at most 5 MB) and normalized before
+// being stored; the Complejo then only keeps the ID of the photo, returned with its URL as the `photo_url` of the
+// profile. Its thumbnail and medium sizes are made in the background, and linked by the `photo_sizes` of the profile.
+//
+// HTTP Status Codes:
+// - 200 OK: The photo was successfully uploaded.
+// - 400 Bad Request: The body is not a multipart form with a "photo" part, or is larger than 5 MB.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo was not found.
+// - 422 Unprocessable Entity: The photo is not a JPEG, PNG or GIF image of at most 5 MB.
+// - 429 Too Many Requests: Too many images are being processed.
+// - 500 Internal Server Error: An issue occurred while storing the photo.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+//
+// Example request:
+//
+//	curl -X POST -H "Authorization: Bearer <token>" -F "photo=@me.jpg" http://localhost:8080/complejo/photo
+//
+// Example response data:
+//
+//	{
+//	    "photo_id": "3f6c2a9e-...",
+//	    "photo_url": "/photos/3f6c2a9e-...",
+//	    "photo_sizes": {
+//	        "thumb": "/photos/3f6c2a9e-...?size=thumb",
+//	        "medium": "/photos/3f6c2a9e-...?size=medium"
+//	    }
+//	}
+//
+// Example usage:
+// r.POST("/complejo/photo", UploadComplejoPhoto(svc))
+func UploadComplejoPhoto(svc *services.ComplejoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.MaxPhotoUploadSize)
+		header, err := c.FormFile("photo")
+		if err != nil {
+			// 400 Bad Request: Not a multipart form with a photo, or oversized body
+			c.Error(apperrors.BadRequest("Invalid photo upload: " + err.Error()))
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			// 400 Bad Request: Unreadable photo
+			c.Error(apperrors.BadRequest("Invalid photo upload: " + err.Error()))
+			return
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		if err != nil {
+			// 400 Bad Request: Unreadable photo
+			c.Error(apperrors.BadRequest("Invalid photo upload: " + err.Error()))
+			return
+		}
+
+		photoID, err := svc.SetPhoto(c, id.(string), data)
+		if err != nil {
+			// 404 Not Found, 422 Unprocessable Entity, 429 Too Many Requests or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The photo was successfully uploaded
+		complejo := models.Complejo{PhotoID: photoID}
+		responses.OK(c, profilePhotoResponse{PhotoID: photoID, PhotoURL: complejo.PhotoURL(), PhotoSizes: complejo.PhotoSizeURLs()})
+	}
+}
+
+// GetProfilePhoto serves a profile photo by its ID, as linked by the `photo_url` of the profiles. It needs no
+// token: the IDs cannot be guessed and a new upload gets a new ID, so the photo may be cached for good.
+// The `size` query parameter selects a size variant, as linked by the `photo_sizes` of the profiles: "thumb"
+// (96 pixels) or "medium" (480 pixels). Until the variant is made in the background the photo itself is served,
+// and must not be cached as the variant.
+//
+// HTTP Status Codes:
+// - 200 OK: The photo was successfully downloaded.
+// - 404 Not Found: No photo has the given ID.
+// - 422 Unprocessable Entity: The size is neither "thumb" nor "medium".
+// - 500 Internal Server Error: An issue occurred while reading the photo.
+//
+// Parameters:
+// - svc (*services.ComplejoService): The service that manages Complejo resources.
+//
+// Example usage:
+// r.GET("/photos/:id", GetProfilePhoto(svc))
+func GetProfilePhoto(svc *services.ComplejoService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.PhotoSizeQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 422 Unprocessable Entity: Unknown size
+			c.Error(err)
+			return
+		}
+
+		data, exact, err := svc.Photo(c, c.Param("id"), query.Size)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Photo downloaded
+		if exact {
+			c.Header("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			c.Header("Cache-Control", "no-cache")
+		}
+		c.Header("Content-Length", strconv.Itoa(len(data)))
+		c.Data(http.StatusOK, http.DetectContentType(data), data)
+	}
+}
@@ -0,0 +1,248 @@
+// report_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRetentionReport returns the weekly cohort retention matrix, restricted to admin role.
+//
+// Members are grouped by the week (starting on Monday, UTC) they signed up in; for each cohort the report
+// counts how many members attended an event in each following week, and the matching rate. Members who
+// signed up before sign-up times were recorded are not counted.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully computed the report.
+// - 400 Bad Request: The weeks parameter is not a number.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The weeks parameter is out of range (1 to 52).
+// - 500 Internal Server Error: An issue occurred while aggregating the data.
+//
+// Parameters:
+// - svc (*services.ReportService): The service that builds the dashboard reports.
+//
+// Example response data:
+//
+//	{
+//	    "from": "2026-07-27T00:00:00Z",
+//	    "to": "2026-10-16T12:00:00Z",
+//	    "cohorts": [
+//	        {"week": "2026-07-27T00:00:00Z", "members": 8, "retained": [5, 4, ...], "rates": [0.625, 0.5, ...]},
+//	        ...
+//	    ]
+//	}
+//
+// Example usage:
+// r.GET("/admin/analytics/retention?weeks=12", GetRetentionReport(svc))
+func GetRetentionReport(svc *services.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the reports."))
+			return
+		}
+
+		var query models.ReportQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		report, err := svc.Retention(c, query.Weeks)
+		if err != nil {
+			// 500 Internal Server Error: Aggregation error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully computed the report
+		responses.OK(c, report)
+	}
+}
+
+// GetAttendanceHeatmap returns the event attendance of the past weeks by hour and weekday, restricted to admin role.
+//
+// Each cell counts the participants of the events starting at that hour (in the gym's time zone, TIMEZONE)
+// of that weekday; rows are weekdays, Monday first, and columns the 24 hours.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully computed the heatmap.
+// - 400 Bad Request: The weeks parameter is not a number.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The weeks parameter is out of range (1 to 52).
+// - 500 Internal Server Error: An issue occurred while aggregating the data.
+//
+// Parameters:
+// - svc (*services.ReportService): The service that builds the dashboard reports.
+//
+// Example usage:
+// r.GET("/admin/analytics/heatmap?weeks=12", GetAttendanceHeatmap(svc))
+func GetAttendanceHeatmap(svc *services.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the reports."))
+			return
+		}
+
+		var query models.ReportQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		heatmap, err := svc.Heatmap(c, query.Weeks)
+		if err != nil {
+			// 500 Internal Server Error: Aggregation error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully computed the heatmap
+		responses.OK(c, heatmap)
+	}
+}
+
+// GetChurnRisk lists the members at risk of leaving, riskiest first, with suggested outreach actions,
+// restricted to admin role.
+//
+// Scores are computed daily by comparing each member's event attendance and subscription activity
+// over the last 4 weeks with the 4 weeks before; members who signed up in the last 4 weeks are not scored.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the at-risk members.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The level parameter is not "low", "medium" or "high".
+// - 500 Internal Server Error: An issue occurred while retrieving the scores.
+//
+// Parameters:
+// - svc (*services.ChurnScorer): The job that scores the churn risk of the members.
+//
+// Example response data:
+//
+//	[
+//	    {
+//	        "complejo_id": "e3b0c442-...",
+//	        "username": "john_doe",
+//	        "score": 100,
+//	        "level": "high",
+//	        "reasons": ["attendance dropped from 6 to 0 events", "no activity in the recent window"],
+//	        "actions": ["Send a personal message from a coach", "Invite them to events similar to the ones they attended"],
+//	        "scored_at": "2026-10-16T03:00:00Z"
+//	    }
+//	]
+//
+// Example usage:
+// r.GET("/admin/analytics/churn-risk?level=high", GetChurnRisk(svc))
+func GetChurnRisk(svc *services.ChurnScorer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the reports."))
+			return
+		}
+
+		var query models.ChurnQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		candidates, err := svc.AtRisk(c, query.Level)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the at-risk members
+		responses.OK(c, candidates)
+	}
+}
+
+// GetBusyTimes returns how busy the gym usually is at each hour of each weekday, so members can pick quieter slots.
+//
+// The levels ("quiet", "moderate" or "busy") are relative to the busiest slot of the last 12 weeks of event
+// attendance; no attendance figures are exposed. The summary is refreshed every 15 minutes.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the busy times.
+// - 500 Internal Server Error: An issue occurred while aggregating the data.
+//
+// Parameters:
+// - svc (*services.ReportService): The service that builds the reports.
+//
+// Example response data:
+//
+//	{
+//	    "timezone": "Europe/Madrid",
+//	    "days": [{"weekday": "monday", "levels": ["quiet", ..., "busy", "moderate", ...]}, ...]
+//	}
+//
+// Example usage:
+// r.GET("/busy-times", GetBusyTimes(svc))
+func GetBusyTimes(svc *services.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		busy, err := svc.BusyTimes(c)
+		if err != nil {
+			// 500 Internal Server Error: Aggregation error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the busy times
+		responses.OK(c, busy)
+	}
+}
+
+// GetPublicStats returns the counters of the club for the public homepage: its members, the events it hosted
+// and the kilograms of the lift records set this month. No token is needed.
+//
+// The counters are computed by a scheduled task (every 10 minutes by default) and served from its cache, so
+// the homepage never queries the database; browsers and proxies may cache them for a minute.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the counters.
+// - 500 Internal Server Error: The counters were not computed yet and computing them failed.
+//
+// Parameters:
+// - svc (*services.ReportService): The service that builds the reports.
+//
+// Example response data:
+//
+//	{
+//	    "members": 128,
+//	    "events_hosted": 342,
+//	    "kilos_lifted_this_month": 4870.5,
+//	    "month": "2026-10",
+//	    "updated_at": "2026-10-16T12:00:00.000Z"
+//	}
+//
+// Example usage:
+// r.GET("/public/stats", GetPublicStats(svc))
+func GetPublicStats(svc *services.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stats, err := svc.PublicStats(c)
+		if err != nil {
+			// 500 Internal Server Error: Aggregation error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the counters
+		c.Header("Cache-Control", "public, max-age=60")
+		responses.OK(c, stats)
+	}
+}
@@ -0,0 +1,152 @@
+// route_handler.go
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UploadEventRoute attaches a GPX route to an outdoor Event (a run or a hike), replacing its previous route,
+// restricted to admins and the creator of the Event. The request body is the GPX file itself (at most 5 MB);
+// the summary of the route is returned and listed as the `route` of the Event.
+//
+// HTTP Status Codes:
+// - 200 OK: The route was successfully attached.
+// - 400 Bad Request: The body could not be read or is larger than 5 MB.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 409 Conflict: The Event is not an outdoor Event.
+// - 422 Unprocessable Entity: The body is not a GPX file with a track or route of at least two points.
+// - 500 Internal Server Error: An issue occurred while storing the route.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example response data:
+//
+//	{
+//	    "name": "Casa de Campo loop",
+//	    "distance_m": 10342.7,
+//	    "elevation_gain_m": 126,
+//	    "elevation_loss_m": 126,
+//	    "min_elevation_m": 598,
+//	    "max_elevation_m": 684,
+//	    "points": 1532,
+//	    "size": 184211,
+//	    "uploaded_by": "8a1d...",
+//	    "uploaded_at": "2026-10-16T18:00:00Z"
+//	}
+//
+// Example usage:
+// r.PUT("/event/:id/route", UploadEventRoute(svc))
+func UploadEventRoute(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, models.MaxRouteSize))
+		if err != nil {
+			// 400 Bad Request: Unreadable or oversized body
+			c.Error(apperrors.BadRequest("Invalid GPX file: " + err.Error()))
+			return
+		}
+
+		route, err := svc.SetRoute(c, c.Param("id"), data, id.(string), role == "admin")
+		if err != nil {
+			// 403 Forbidden, 404 Not Found, 409 Conflict, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The route was successfully attached
+		responses.OK(c, route)
+	}
+}
+
+// DeleteEventRoute removes the GPX route of an Event, restricted to admins and the creator of the Event.
+//
+// HTTP Status Codes:
+// - 204 No Content: The route was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event with the specified ID was not found, or it has no route.
+// - 500 Internal Server Error: An issue occurred while removing the route.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.DELETE("/event/:id/route", DeleteEventRoute(svc))
+func DeleteEventRoute(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.DeleteRoute(c, c.Param("id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The route was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// DownloadEventRoute downloads the GPX file of the route of an Event, to load it in a watch or a map app.
+// Only admins, the creator of the Event and the users going or maybe going may download it.
+//
+// HTTP Status Codes:
+// - 200 OK: The GPX file was successfully downloaded.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not take part in the Event.
+// - 404 Not Found: The Event with the specified ID was not found, or it has no route.
+// - 500 Internal Server Error: An issue occurred while reading the route.
+//
+// Parameters:
+// - svc (*services.EventService): The service that manages Event resources.
+//
+// Example usage:
+// r.GET("/event/:id/route.gpx", DownloadEventRoute(svc))
+func DownloadEventRoute(svc *services.EventService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		data, err := svc.RouteFile(c, c.Param("id"), id.(string), role == "admin")
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: GPX file downloaded
+		c.Header("Content-Disposition", `attachment; filename="route-`+c.Param("id")+`.gpx"`)
+		c.Header("Content-Length", strconv.Itoa(len(data)))
+		c.Data(http.StatusOK, "application/gpx+xml", data)
+	}
+}
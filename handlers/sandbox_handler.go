@@ -0,0 +1,62 @@
+// sandbox_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResetSandbox removes every data of the sandbox (Complejos, events, every other record and the stored files)
+// and creates the seed data again, restricted to admin role. It is only available on the deployment designated
+// as the sandbox (SANDBOX_ENABLED). The admin calling it is removed too: the response names the seeded admin
+// account and its password, to sign in again.
+//
+// HTTP Status Codes:
+// - 200 OK: The sandbox was successfully reset; the response counts the records removed and created.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is not an admin, or this deployment is not the sandbox (error code "sandbox_disabled").
+// - 500 Internal Server Error: An issue occurred while removing or seeding the data.
+//
+// Parameters:
+// - svc (*services.SandboxService): The service that resets the sandbox.
+//
+// Example response data:
+//
+//	{
+//	    "reset_by": "8a1d...",
+//	    "reset_at": "2026-10-16T18:00:00Z",
+//	    "removed": {"complejo": 12, "event": 9, "outbox": 31},
+//	    "seeded": {"complejos": 8, "events": 7, "admin_username": "admin", "password": "complejos"}
+//	}
+//
+// Example usage:
+// r.POST("/admin/sandbox/reset", ResetSandbox(svc))
+func ResetSandbox(svc *services.SandboxService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExists := c.Get("role")
+		if !idExist || !roleExists {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		if role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to reset the sandbox."))
+			return
+		}
+
+		reset, err := svc.Reset(c, id.(string))
+		if err != nil {
+			// 403 Forbidden or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The sandbox was successfully reset
+		responses.OK(c, reset)
+	}
+}
@@ -0,0 +1,56 @@
+// scheduler_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/scheduler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetScheduledTasks returns the recurring tasks of this instance and their metrics since the server started,
+// restricted to admin role: whether each task is enabled, its runs, failures and items handled, and its last
+// and next runs.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the tasks.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+//
+// Parameters:
+// - sched (*scheduler.Scheduler): The scheduler running the recurring tasks.
+//
+// Example response data:
+//
+//	[
+//	    {
+//	        "name": "event_reminders",
+//	        "interval": "15m0s",
+//	        "enabled": true,
+//	        "running": false,
+//	        "runs": 96,
+//	        "failures": 1,
+//	        "items": 212,
+//	        "last_run_at": "2025-02-08T10:00:00.000Z",
+//	        "last_duration": "84ms",
+//	        "last_items": 3,
+//	        "last_error": "",
+//	        "next_run_at": "2025-02-08T10:15:00.000Z"
+//	    }
+//	]
+//
+// Example usage:
+// r.GET("/admin/scheduler", GetScheduledTasks(sched))
+func GetScheduledTasks(sched *scheduler.Scheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to view the scheduled tasks."))
+			return
+		}
+
+		// 200 OK: Successfully retrieved the tasks
+		responses.OK(c, sched.Stats())
+	}
+}
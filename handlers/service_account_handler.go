@@ -0,0 +1,312 @@
+// service_account_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetServiceAccounts lists the service accounts of the integrations, oldest first, with their working tokens,
+// restricted to admin role. The tokens themselves are never returned, only their hint.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the service accounts.
+// - 403 Forbidden: The user does not have sufficient permissions, or is a service account.
+// - 500 Internal Server Error: An issue occurred while fetching the service accounts.
+//
+// Parameters:
+// - svc (*services.ServiceAccountService): The service that manages the service accounts.
+//
+// Example usage:
+// r.GET("/admin/service-accounts", GetServiceAccounts(svc))
+func GetServiceAccounts(svc *services.ServiceAccountService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := manageServiceAccounts(c); err != nil {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(err)
+			return
+		}
+
+		accounts, err := svc.Accounts(c)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the service accounts
+		responses.OK(c, accounts)
+	}
+}
+
+// GetServiceAccount retrieves a service account by its ID with its working tokens, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the service account.
+// - 403 Forbidden: The user does not have sufficient permissions, or is a service account.
+// - 404 Not Found: The service account with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the service account.
+//
+// Parameters:
+// - svc (*services.ServiceAccountService): The service that manages the service accounts.
+//
+// Example usage:
+// r.GET("/admin/service-accounts/:id", GetServiceAccount(svc))
+func GetServiceAccount(svc *services.ServiceAccountService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := manageServiceAccounts(c); err != nil {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(err)
+			return
+		}
+
+		account, err := svc.Account(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the service account
+		responses.OK(c, account)
+	}
+}
+
+// CreateServiceAccount creates the service account of an integration (the kiosk, the Discord bot, the
+// scheduler) with its first token, restricted to admin role. The token is only returned here: the integration
+// sends it in the Authorization header like a JWT, and its requests act with the role of the account.
+//
+// HTTP Status Codes:
+// - 201 Created: The service account was successfully created.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions, or is a service account.
+// - 409 Conflict: Another service account has this name.
+// - 422 Unprocessable Entity: The name, role or rotation is invalid.
+// - 500 Internal Server Error: An issue occurred while creating the service account.
+//
+// Parameters:
+// - svc (*services.ServiceAccountService): The service that manages the service accounts.
+//
+// Example JSON payload (rotation_days defaults to 30, overlap_hours to 24 and active to true):
+//
+//	{
+//	    "name": "discord-bot",
+//	    "role": "user",
+//	    "rotation_days": 7,
+//	    "overlap_hours": 12
+//	}
+//
+// Example response data:
+//
+//	{
+//	    "account": {"_id": "...", "name": "discord-bot", "role": "user", "rotation_days": 7, "overlap_hours": 12, ...},
+//	    "token": {"token": "svc_3f9a...c41e", "_id": "...", "rotate_at": "2026-10-23T09:00:00Z", "expires_at": "2026-10-23T21:00:00Z", ...}
+//	}
+//
+// Example usage:
+// r.POST("/admin/service-accounts", CreateServiceAccount(svc))
+func CreateServiceAccount(svc *services.ServiceAccountService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		if err := manageServiceAccounts(c); err != nil {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(err)
+			return
+		}
+
+		var input models.ServiceAccountInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		created, err := svc.Create(c, input, id.(string))
+		if err != nil {
+			// 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The service account was successfully created
+		responses.Created(c, created)
+	}
+}
+
+// UpdateServiceAccount replaces the name, role, rotation and state of a service account, restricted to admin
+// role. The new rotation applies to the next tokens; setting "active" to false refuses every token at once.
+//
+// HTTP Status Codes:
+// - 200 OK: The service account was successfully updated.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions, or is a service account.
+// - 404 Not Found: The service account with the specified ID was not found.
+// - 409 Conflict: Another service account has this name.
+// - 422 Unprocessable Entity: The name, role or rotation is invalid.
+// - 500 Internal Server Error: An issue occurred while updating the service account.
+//
+// Parameters:
+// - svc (*services.ServiceAccountService): The service that manages the service accounts.
+//
+// Example usage:
+// r.PUT("/admin/service-accounts/:id", UpdateServiceAccount(svc))
+func UpdateServiceAccount(svc *services.ServiceAccountService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := manageServiceAccounts(c); err != nil {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(err)
+			return
+		}
+
+		var input models.ServiceAccountInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		account, err := svc.Update(c, c.Param("id"), input)
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The service account was successfully updated
+		responses.OK(c, account)
+	}
+}
+
+// DeleteServiceAccount removes a service account with its tokens, which stop working at once, restricted to
+// admin role.
+//
+// HTTP Status Codes:
+// - 204 No Content: The service account was successfully removed.
+// - 403 Forbidden: The user does not have sufficient permissions, or is a service account.
+// - 404 Not Found: The service account with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the service account.
+//
+// Parameters:
+// - svc (*services.ServiceAccountService): The service that manages the service accounts.
+//
+// Example usage:
+// r.DELETE("/admin/service-accounts/:id", DeleteServiceAccount(svc))
+func DeleteServiceAccount(svc *services.ServiceAccountService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := manageServiceAccounts(c); err != nil {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(err)
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id")); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The service account was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// RotateServiceAccount issues a new token of a service account, restricted to admin role, e.g. when a token
+// leaked. The new token is only returned here; the previous ones keep working for the overlap of the account
+// at most.
+//
+// HTTP Status Codes:
+// - 201 Created: The token was successfully issued.
+// - 403 Forbidden: The user does not have sufficient permissions, or is a service account.
+// - 404 Not Found: The service account with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while issuing the token.
+//
+// Parameters:
+// - svc (*services.ServiceAccountService): The service that manages the service accounts.
+//
+// Example usage:
+// r.POST("/admin/service-accounts/:id/rotate", RotateServiceAccount(svc))
+func RotateServiceAccount(svc *services.ServiceAccountService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := manageServiceAccounts(c); err != nil {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(err)
+			return
+		}
+
+		token, err := svc.Rotate(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The token was successfully issued
+		responses.Created(c, token)
+	}
+}
+
+// RotateServiceToken issues the next token of the service account authenticating the request, for the
+// integrations to rotate their token on schedule: once it is due, the responses to its requests carry the
+// X-Service-Token-Rotate header with the time it stops working. The new token is only returned here; the
+// previous ones keep working for the overlap of the account at most.
+//
+// HTTP Status Codes:
+// - 201 Created: The token was successfully issued.
+// - 401 Unauthorized: The token is missing, invalid or expired.
+// - 403 Forbidden: The request is not authenticated with a service token.
+// - 500 Internal Server Error: An issue occurred while issuing the token.
+//
+// Parameters:
+// - svc (*services.ServiceAccountService): The service that manages the service accounts.
+//
+// Example usage:
+// r.POST("/service/token", RotateServiceToken(svc))
+func RotateServiceToken(svc *services.ServiceAccountService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		if _, service := c.Get("service_token"); !service {
+			// 403 Forbidden: Not a service account
+			c.Error(services.ErrNotServiceToken)
+			return
+		}
+
+		token, err := svc.Rotate(c, id.(string))
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The token was successfully issued
+		responses.Created(c, token)
+	}
+}
+
+// manageServiceAccounts returns the error refusing the caller the management of the service accounts: only
+// admins may manage them, and not with a service token.
+func manageServiceAccounts(c *gin.Context) error {
+	role, roleExists := c.Get("role")
+	if !roleExists || role != "admin" {
+		return apperrors.Forbidden("You do not have permission to manage the service accounts.")
+	}
+	if _, service := c.Get("service_token"); service {
+		return services.ErrServiceAccountForbidden
+	}
+	return nil
+}
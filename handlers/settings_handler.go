@@ -0,0 +1,106 @@
+// settings_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetListingSettings returns the defaults of the event listing (GET /event) chosen by the admins of the club,
+// restricted to admin role. The default settings are returned while the admins never changed them.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the listing settings.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 500 Internal Server Error: An issue occurred while reading the settings.
+//
+// Parameters:
+// - svc (*services.SettingsService): The service that manages the settings of the club.
+//
+// Example usage:
+// r.GET("/admin/settings/listing", GetListingSettings(svc))
+func GetListingSettings(svc *services.SettingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the settings of the club."))
+			return
+		}
+
+		settings, err := svc.Listing(c)
+		if err != nil {
+			// 500 Internal Server Error: Read error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the listing settings
+		responses.OK(c, settings)
+	}
+}
+
+// UpdateListingSettings replaces the defaults of the event listing (GET /event), restricted to admin role. They
+// apply to the requests leaving them out: the order of the events by date (`sort`), the page size (`limit`) and,
+// unless a date range (`from` or `to`) is given, the hiding of the events dated more than `hide_past_after_days`
+// days ago (0 never hides them).
+//
+// HTTP Status Codes:
+// - 200 OK: The listing settings were successfully updated.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: A setting is missing or out of range.
+// - 500 Internal Server Error: An issue occurred while storing the settings.
+//
+// Parameters:
+// - svc (*services.SettingsService): The service that manages the settings of the club.
+//
+// Example request body:
+//
+//	{
+//	    "hide_past_after_days": 7,
+//	    "sort": "asc",
+//	    "page_size": 20
+//	}
+//
+// Example usage:
+// r.PUT("/admin/settings/listing", UpdateListingSettings(svc))
+func UpdateListingSettings(svc *services.SettingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExists := c.Get("role")
+		if !idExist || !roleExists {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		if role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the settings of the club."))
+			return
+		}
+
+		var input models.ListingSettingsInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		settings, err := svc.SetListing(c, id.(string), input)
+		if err != nil {
+			// 500 Internal Server Error: Write error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The listing settings were successfully updated
+		responses.OK(c, settings)
+	}
+}
@@ -0,0 +1,112 @@
+// share_handler.go
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEventShareImage serves the Open Graph share image of an Event as a 1200 × 630 PNG: its title, date and
+// location over its image (or a plain background), so links to the Event look good when shared on social
+// networks. No token is needed. The image is cached until the Event is updated, and clients may revalidate
+// it with its ETag.
+//
+// HTTP Status Codes:
+// - 200 OK: The image was successfully served.
+// - 304 Not Modified: The image matches the ETag sent in If-None-Match.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while rendering the image.
+//
+// Parameters:
+// - svc (*services.ShareService): The service that presents the Events to search engines and social networks.
+//
+// Example usage:
+// r.GET("/event/:id/og.png", GetEventShareImage(svc))
+func GetEventShareImage(svc *services.ShareService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		data, err := svc.Image(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		sum := sha256.Sum256(data)
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		c.Header("ETag", etag)
+		c.Header("Cache-Control", "public, max-age=3600")
+		if c.GetHeader("If-None-Match") == etag {
+			// 304 Not Modified: The client has the image already
+			c.Status(http.StatusNotModified)
+			return
+		}
+
+		// 200 OK: Image served
+		c.Header("Content-Length", strconv.Itoa(len(data)))
+		c.Data(http.StatusOK, "image/png", data)
+	}
+}
+
+// GetSitemap serves the sitemap (sitemaps.org XML) of the public pages of the Events, the most recent first,
+// for search engines. No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: The sitemap was successfully generated (possibly without pages).
+// - 500 Internal Server Error: An issue occurred while fetching the Events.
+//
+// Parameters:
+// - svc (*services.ShareService): The service that presents the Events to search engines and social networks.
+//
+// Example usage:
+// r.GET("/public/sitemap.xml", GetSitemap(svc))
+func GetSitemap(svc *services.ShareService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sitemap, err := svc.Sitemap(c)
+		if err != nil {
+			// 500 Internal Server Error: Database query failed
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Sitemap served
+		c.Header("Content-Type", "application/xml; charset=utf-8")
+		c.Header("Cache-Control", "public, max-age=3600")
+		c.Status(http.StatusOK)
+		sitemap.WriteTo(c.Writer)
+	}
+}
+
+// GetEventMeta returns the SEO metadata of the public page of an Event, for the server-side rendering of the
+// frontend: its title, the plain text of its description shortened for search results, the canonical URL of
+// the page and the URL and size of its share image, for the Open Graph tags. No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the metadata.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the Event.
+//
+// Parameters:
+// - svc (*services.ShareService): The service that presents the Events to search engines and social networks.
+//
+// Example usage:
+// r.GET("/public/event/:id/meta", GetEventMeta(svc))
+func GetEventMeta(svc *services.ShareService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		meta, err := svc.Meta(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the metadata
+		responses.OK(c, meta)
+	}
+}
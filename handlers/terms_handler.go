@@ -0,0 +1,209 @@
+// terms_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTerms retrieves the current version of a terms document: `tos` (terms of service) or `privacy` (privacy
+// policy). No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the document.
+// - 404 Not Found: The kind is unknown or no version of the document has been published.
+// - 500 Internal Server Error: An issue occurred while fetching the document.
+//
+// Parameters:
+// - svc (*services.TermsService): The service that manages the terms documents.
+//
+// Example usage:
+// r.GET("/terms/:kind", GetTerms(svc))
+func GetTerms(svc *services.TermsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, err := svc.Current(c, c.Param("kind"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the document
+		responses.OK(c, version)
+	}
+}
+
+// PublishTerms publishes a new version of a terms document, restricted to admin role. The previous versions
+// are kept.
+//
+// HTTP Status Codes:
+// - 201 Created: The version was successfully published.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The kind is unknown.
+// - 409 Conflict: The new version is identical to the current one, or another version was published at the same time.
+// - 422 Unprocessable Entity: The title (at most 200 characters) or the body (at most 100000) is missing or too long.
+// - 500 Internal Server Error: An issue occurred while publishing the version.
+//
+// Parameters:
+// - svc (*services.TermsService): The service that manages the terms documents.
+//
+// Example JSON payload (the body is Markdown):
+//
+//	{
+//	    "title": "Terms of Service",
+//	    "body": "## Membership\n\nMembers must be at least 16 years old..."
+//	}
+//
+// Example usage:
+// r.PUT("/terms/:kind", PublishTerms(svc))
+func PublishTerms(svc *services.TermsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to publish terms."))
+			return
+		}
+
+		var input models.TermsInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		version, err := svc.Publish(c, c.Param("kind"), input, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The version was successfully published
+		responses.Created(c, version)
+	}
+}
+
+// GetTermsVersions lists the versions of a terms document, the latest first, without their content.
+// No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the versions.
+// - 404 Not Found: The kind is unknown.
+// - 500 Internal Server Error: An issue occurred while fetching the versions.
+//
+// Parameters:
+// - svc (*services.TermsService): The service that manages the terms documents.
+//
+// Example usage:
+// r.GET("/terms/:kind/versions", GetTermsVersions(svc))
+func GetTermsVersions(svc *services.TermsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		versions, err := svc.Versions(c, c.Param("kind"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the versions
+		responses.OK(c, versions)
+	}
+}
+
+// GetTermsVersion retrieves a version of a terms document with the unified diff of its body from the previous
+// version. No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the version.
+// - 404 Not Found: The kind is unknown or the version was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the version.
+//
+// Parameters:
+// - svc (*services.TermsService): The service that manages the terms documents.
+//
+// Example usage:
+// r.GET("/terms/:kind/versions/:version", GetTermsVersion(svc))
+func GetTermsVersion(svc *services.TermsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		number, err := versionParam(c)
+		if err != nil {
+			// 404 Not Found: The version is not a number
+			c.Error(err)
+			return
+		}
+
+		version, err := svc.Version(c, c.Param("kind"), number)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the version
+		responses.OK(c, version)
+	}
+}
+
+// RollbackTerms publishes the content of an earlier version of a terms document as a new version, restricted
+// to admin role.
+//
+// HTTP Status Codes:
+// - 201 Created: The version was successfully published.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The kind is unknown or the version was not found.
+// - 409 Conflict: The version has the same content as the current one, or another version was published at the same time.
+// - 500 Internal Server Error: An issue occurred while publishing the version.
+//
+// Parameters:
+// - svc (*services.TermsService): The service that manages the terms documents.
+//
+// Example usage:
+// r.POST("/terms/:kind/versions/:version/rollback", RollbackTerms(svc))
+func RollbackTerms(svc *services.TermsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to publish terms."))
+			return
+		}
+
+		number, err := versionParam(c)
+		if err != nil {
+			// 404 Not Found: The version is not a number
+			c.Error(err)
+			return
+		}
+
+		version, err := svc.Rollback(c, c.Param("kind"), number, id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The version was successfully published
+		responses.Created(c, version)
+	}
+}
@@ -0,0 +1,46 @@
+// tools_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/utils"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetOneRepMax estimates the one-rep max of a set from its weight and repetitions with the Epley and Brzycki
+// formulas, and their average. The estimates get less accurate with the repetitions, so at most 20 are accepted.
+// No token is needed.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully estimated the one-rep max.
+// - 400 Bad Request: The query parameters are not numbers.
+// - 422 Unprocessable Entity: The weight (above 0, at most 1000) or the repetitions (1 to 20) are out of range.
+//
+// Example response data:
+//
+//	{
+//	    "weight": 100,
+//	    "reps": 5,
+//	    "epley": 116.7,
+//	    "brzycki": 112.5,
+//	    "estimate": 114.6
+//	}
+//
+// Example usage:
+// r.GET("/tools/1rm?weight=100&reps=5", GetOneRepMax())
+func GetOneRepMax() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.OneRepMaxQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully estimated the one-rep max
+		responses.OK(c, utils.EstimateOneRepMax(query.Weight, query.Reps))
+	}
+}
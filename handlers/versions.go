@@ -0,0 +1,20 @@
+// versions.go
+package handlers
+
+import (
+	"strconv"
+
+	"los-complejos-backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// versionParam returns the version number of the `:version` path parameter, or services.ErrVersionNotFound
+// when it is not a positive number.
+func versionParam(c *gin.Context) (int, error) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		return 0, services.ErrVersionNotFound
+	}
+	return version, nil
+}
@@ -0,0 +1,244 @@
+// volunteer_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetVolunteerShifts lists the volunteer shifts of an Event, ordered by start and role,
+// with their volunteers and how many can still sign up.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the shifts.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the shifts.
+//
+// Parameters:
+// - svc (*services.VolunteerService): The service that manages the volunteer shifts.
+//
+// Example usage:
+// r.GET("/event/:id/volunteers", GetVolunteerShifts(svc))
+func GetVolunteerShifts(svc *services.VolunteerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		shifts, err := svc.Shifts(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the shifts
+		responses.OK(c, shifts)
+	}
+}
+
+// CreateVolunteerShift opens a volunteer shift for an Event, restricted to admins and the creator of the Event.
+//
+// HTTP Status Codes:
+// - 201 Created: The shift was successfully opened.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event with the specified ID was not found.
+// - 422 Unprocessable Entity: Required fields are missing, the capacity is out of range,
+// the shift does not start in the future or does not end after it starts.
+// - 500 Internal Server Error: An issue occurred while storing the shift.
+//
+// Parameters:
+// - svc (*services.VolunteerService): The service that manages the volunteer shifts.
+//
+// Example JSON payload (role is "spotter", "loader" or "scorer", capacity between 1 and 50):
+//
+//	{
+//	    "role": "spotter",
+//	    "capacity": 3,
+//	    "starts_at": "2026-11-07T09:00:00Z",
+//	    "ends_at": "2026-11-07T13:00:00Z"
+//	}
+//
+// Example usage:
+// r.POST("/event/:id/volunteers", CreateVolunteerShift(svc))
+func CreateVolunteerShift(svc *services.VolunteerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.VolunteerShiftInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+		if !input.EndsAt.After(input.StartsAt) {
+			// 422 Unprocessable Entity: Empty shift
+			c.Error(apperrors.Validation("Validation failed", []validation.FieldError{
+				{Field: "ends_at", Rule: "gtfield", Message: "must be after starts_at"},
+			}))
+			return
+		}
+
+		shift, err := svc.CreateShift(c, c.Param("id"), input, id.(string), role == "admin")
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The shift was successfully opened
+		responses.Created(c, shift)
+	}
+}
+
+// DeleteVolunteerShift removes a volunteer shift of an Event and its sign-ups,
+// restricted to admins and the creator of the Event.
+//
+// HTTP Status Codes:
+// - 204 No Content: The shift was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user is neither an admin nor the creator of the Event.
+// - 404 Not Found: The Event or the shift was not found.
+// - 500 Internal Server Error: An issue occurred while removing the shift.
+//
+// Parameters:
+// - svc (*services.VolunteerService): The service that manages the volunteer shifts.
+//
+// Example usage:
+// r.DELETE("/event/:id/volunteers/:shift_id", DeleteVolunteerShift(svc))
+func DeleteVolunteerShift(svc *services.VolunteerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		role, roleExist := c.Get("role")
+		if !idExist || !roleExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.DeleteShift(c, c.Param("id"), c.Param("shift_id"), id.(string), role == "admin"); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The shift was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// SignUpVolunteer signs the authenticated Complejo up for a volunteer shift of an Event.
+// Volunteers are reminded the day before the shift starts.
+//
+// HTTP Status Codes:
+// - 200 OK: The Complejo was successfully signed up; the shift is returned.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Event, the shift or the Complejo was not found.
+// - 409 Conflict: The shift has started or is full, or the Complejo already signed up.
+// - 500 Internal Server Error: An issue occurred while storing the sign-up.
+//
+// Parameters:
+// - svc (*services.VolunteerService): The service that manages the volunteer shifts.
+//
+// Example usage:
+// r.POST("/event/:id/volunteers/:shift_id/signup", SignUpVolunteer(svc))
+func SignUpVolunteer(svc *services.VolunteerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		shift, err := svc.SignUp(c, c.Param("id"), c.Param("shift_id"), id.(string))
+		if err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The Complejo was successfully signed up
+		responses.OK(c, shift)
+	}
+}
+
+// WithdrawVolunteer removes the authenticated Complejo from a volunteer shift of an Event that has not started.
+//
+// HTTP Status Codes:
+// - 204 No Content: The Complejo was successfully withdrawn.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Event or the shift was not found.
+// - 409 Conflict: The shift has started or the Complejo has not signed up.
+// - 500 Internal Server Error: An issue occurred while removing the sign-up.
+//
+// Parameters:
+// - svc (*services.VolunteerService): The service that manages the volunteer shifts.
+//
+// Example usage:
+// r.DELETE("/event/:id/volunteers/:shift_id/signup", WithdrawVolunteer(svc))
+func WithdrawVolunteer(svc *services.VolunteerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Withdraw(c, c.Param("id"), c.Param("shift_id"), id.(string)); err != nil {
+			// 404 Not Found, 409 Conflict or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The Complejo was successfully withdrawn
+		responses.NoContent(c)
+	}
+}
+
+// GetVolunteerLeaderboard lists the members with the most hours volunteered in the shifts that have ended,
+// most hours first. The `?limit=` query parameter sets how many are listed (default 10, at most 100).
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the leaderboard.
+// - 400 Bad Request: The query parameters could not be parsed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 422 Unprocessable Entity: The limit is out of range.
+// - 500 Internal Server Error: An issue occurred while tallying the hours.
+//
+// Parameters:
+// - svc (*services.VolunteerService): The service that manages the volunteer shifts.
+//
+// Example usage:
+// r.GET("/volunteers/leaderboard", GetVolunteerLeaderboard(svc))
+func GetVolunteerLeaderboard(svc *services.VolunteerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var query models.VolunteerLeaderboardQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		leaderboard, err := svc.Leaderboard(c, query)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the leaderboard
+		responses.OK(c, leaderboard)
+	}
+}
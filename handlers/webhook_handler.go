@@ -0,0 +1,287 @@
+// webhook_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWebhookTopics lists the topics of the domain events webhooks can receive, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the topics.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+//
+// Parameters:
+// - svc (*services.WebhookService): The service that delivers the domain events to the webhooks.
+//
+// Example usage:
+// r.GET("/admin/webhooks/topics", GetWebhookTopics(svc))
+func GetWebhookTopics(svc *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the webhooks."))
+			return
+		}
+
+		// 200 OK: Successfully retrieved the topics
+		responses.OK(c, svc.Topics())
+	}
+}
+
+// GetWebhooks lists the registered webhooks, oldest first, restricted to admin role. Their secrets are never
+// returned.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the webhooks.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 500 Internal Server Error: An issue occurred while fetching the webhooks.
+//
+// Parameters:
+// - svc (*services.WebhookService): The service that delivers the domain events to the webhooks.
+//
+// Example usage:
+// r.GET("/admin/webhooks", GetWebhooks(svc))
+func GetWebhooks(svc *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the webhooks."))
+			return
+		}
+
+		webhooks, err := svc.Webhooks(c)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the webhooks
+		responses.OK(c, webhooks)
+	}
+}
+
+// GetWebhook retrieves a webhook by its ID, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the webhook.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The webhook with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the webhook.
+//
+// Parameters:
+// - svc (*services.WebhookService): The service that delivers the domain events to the webhooks.
+//
+// Example usage:
+// r.GET("/admin/webhooks/:id", GetWebhook(svc))
+func GetWebhook(svc *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the webhooks."))
+			return
+		}
+
+		webhook, err := svc.Webhook(c, c.Param("id"))
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the webhook
+		responses.OK(c, webhook)
+	}
+}
+
+// CreateWebhook registers a URL receiving the domain events of the given topics, restricted to admin role.
+// Each delivery is POSTed as JSON and signed with the secret in the X-Complejos-Signature header.
+//
+// HTTP Status Codes:
+// - 201 Created: The webhook was successfully registered.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 422 Unprocessable Entity: The URL is not an http or https URL, the secret is shorter than 16 characters,
+// or a topic is unknown.
+// - 500 Internal Server Error: An issue occurred while registering the webhook.
+//
+// Parameters:
+// - svc (*services.WebhookService): The service that delivers the domain events to the webhooks.
+//
+// Example JSON payload (active defaults to true):
+//
+//	{
+//	    "url": "https://crm.example.org/hooks/complejos",
+//	    "secret": "a1f4c9e07b2d4e6f8a3c5b7d9e1f2a4c",
+//	    "topics": ["event.created", "complejo.registered"]
+//	}
+//
+// Example usage:
+// r.POST("/admin/webhooks", CreateWebhook(svc))
+func CreateWebhook(svc *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the webhooks."))
+			return
+		}
+
+		var input models.WebhookInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		webhook, err := svc.Create(c, input, id.(string))
+		if err != nil {
+			// 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The webhook was successfully registered
+		responses.Created(c, webhook)
+	}
+}
+
+// UpdateWebhook replaces the URL, secret, topics and state of a webhook, restricted to admin role. Setting
+// "active" to false stops its deliveries; those still pending are given up.
+//
+// HTTP Status Codes:
+// - 200 OK: The webhook was successfully updated.
+// - 400 Bad Request: Invalid JSON data was provided.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The webhook with the specified ID was not found.
+// - 422 Unprocessable Entity: The URL is not an http or https URL, the secret is shorter than 16 characters,
+// or a topic is unknown.
+// - 500 Internal Server Error: An issue occurred while updating the webhook.
+//
+// Parameters:
+// - svc (*services.WebhookService): The service that delivers the domain events to the webhooks.
+//
+// Example usage:
+// r.PUT("/admin/webhooks/:id", UpdateWebhook(svc))
+func UpdateWebhook(svc *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the webhooks."))
+			return
+		}
+
+		var input models.WebhookInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		webhook, err := svc.Update(c, c.Param("id"), input)
+		if err != nil {
+			// 404 Not Found, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The webhook was successfully updated
+		responses.OK(c, webhook)
+	}
+}
+
+// DeleteWebhook removes a webhook with its deliveries, restricted to admin role.
+//
+// HTTP Status Codes:
+// - 204 No Content: The webhook was successfully removed.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The webhook with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the webhook.
+//
+// Parameters:
+// - svc (*services.WebhookService): The service that delivers the domain events to the webhooks.
+//
+// Example usage:
+// r.DELETE("/admin/webhooks/:id", DeleteWebhook(svc))
+func DeleteWebhook(svc *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the webhooks."))
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id")); err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The webhook was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// GetWebhookDeliveries returns the log of the deliveries of a webhook, newest first, with every attempt
+// (time, HTTP status, error and duration), restricted to admin role. The `?status=` query parameter selects
+// the "pending", "delivered" or "failed" deliveries, and `?limit=` how many are listed (default 50, at most 200).
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the deliveries.
+// - 400 Bad Request: The query parameters could not be parsed.
+// - 403 Forbidden: The user does not have sufficient permissions to perform this action.
+// - 404 Not Found: The webhook with the specified ID was not found.
+// - 422 Unprocessable Entity: The status is not one of the allowed values or the limit is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the deliveries.
+//
+// Parameters:
+// - svc (*services.WebhookService): The service that delivers the domain events to the webhooks.
+//
+// Example usage:
+// r.GET("/admin/webhooks/:id/deliveries", GetWebhookDeliveries(svc))
+func GetWebhookDeliveries(svc *services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, roleExists := c.Get("role")
+		if !roleExists || role != "admin" {
+			// 403 Forbidden: Insufficient permissions
+			c.Error(apperrors.Forbidden("You do not have permission to manage the webhooks."))
+			return
+		}
+
+		var filter models.WebhookDeliveryFilter
+		if err := validation.BindQuery(c, &filter); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		deliveries, err := svc.Deliveries(c, c.Param("id"), filter)
+		if err != nil {
+			// 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the deliveries
+		responses.OK(c, deliveries)
+	}
+}
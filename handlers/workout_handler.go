@@ -0,0 +1,284 @@
+// workout_handler.go
+package handlers
+
+import (
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/models"
+	"los-complejos-backend/responses"
+	"los-complejos-backend/services"
+	"los-complejos-backend/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWorkouts lists the workouts of the authenticated user, newest first, with `page`/`limit` pagination.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the page of workouts (possibly empty).
+// - 400 Bad Request: A query parameter could not be parsed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 422 Unprocessable Entity: The page or limit is out of range.
+// - 500 Internal Server Error: An issue occurred while fetching the workouts.
+//
+// Parameters:
+// - svc (*services.WorkoutService): The service that keeps the training log.
+//
+// Example usage:
+// r.GET("/workouts?page=1&limit=20", GetWorkouts(svc))
+func GetWorkouts(svc *services.WorkoutService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var query models.WorkoutQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		workouts, total, err := svc.List(c, id.(string), &query)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the page of workouts
+		responses.OKWithMeta(c, workouts, responses.NewPagination(query.Page, query.Limit, total))
+	}
+}
+
+// LogWorkout records a workout of the authenticated user: its exercises, each referenced by its ID in the
+// exercise library (GET /exercises) with its sets of repetitions, weight (0 for bodyweight exercises) and optional
+// RPE, and free notes. The date defaults to now. The name and main muscle group of each exercise are copied from
+// the library.
+//
+// HTTP Status Codes:
+// - 201 Created: The workout was recorded.
+// - 400 Bad Request: The body is not valid JSON.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 404 Not Found: The Complejo no longer exists.
+// - 422 Unprocessable Entity: A field is missing or out of range, the date is in the future, or an exercise is
+// not in the library.
+// - 500 Internal Server Error: An issue occurred while recording the workout.
+//
+// Parameters:
+// - svc (*services.WorkoutService): The service that keeps the training log.
+//
+// Example request body:
+//
+//	{
+//	    "date": "2026-10-14T19:30:00Z",
+//	    "exercises": [
+//	        {"exercise_id": "5b1e...", "sets": [{"reps": 5, "weight": 120, "rpe": 8}, {"reps": 5, "weight": 120, "rpe": 8.5}]},
+//	        {"exercise_id": "c07d...", "sets": [{"reps": 10, "weight": 0}]}
+//	    ],
+//	    "notes": "Felt strong"
+//	}
+//
+// Example usage:
+// r.POST("/workouts", LogWorkout(svc))
+func LogWorkout(svc *services.WorkoutService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.WorkoutInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		workout, err := svc.Log(c, id.(string), input)
+		if err != nil {
+			// 404 Not Found, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 201 Created: The workout was recorded
+		responses.Created(c, workout)
+	}
+}
+
+// GetWorkout retrieves a workout of the authenticated user by ID.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the workout.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The workout was logged by another user.
+// - 404 Not Found: The workout with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while fetching the workout.
+//
+// Parameters:
+// - svc (*services.WorkoutService): The service that keeps the training log.
+//
+// Example usage:
+// r.GET("/workouts/:id", GetWorkout(svc))
+func GetWorkout(svc *services.WorkoutService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		workout, err := svc.Get(c, c.Param("id"), id.(string))
+		if err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the workout
+		responses.OK(c, workout)
+	}
+}
+
+// UpdateWorkout replaces the exercises and notes of a workout of the authenticated user, and its date when the
+// body has one. The body is the one of POST /workouts.
+//
+// HTTP Status Codes:
+// - 200 OK: The workout was successfully updated.
+// - 400 Bad Request: The body is not valid JSON.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The workout was logged by another user.
+// - 404 Not Found: The workout with the specified ID was not found.
+// - 422 Unprocessable Entity: A field is missing or out of range, the date is in the future, or an exercise is
+// not in the library.
+// - 500 Internal Server Error: An issue occurred while updating the workout.
+//
+// Parameters:
+// - svc (*services.WorkoutService): The service that keeps the training log.
+//
+// Example usage:
+// r.PUT("/workouts/:id", UpdateWorkout(svc))
+func UpdateWorkout(svc *services.WorkoutService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var input models.WorkoutInput
+		if err := validation.BindJSON(c, &input); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid payload
+			c.Error(err)
+			return
+		}
+
+		workout, err := svc.Update(c, c.Param("id"), id.(string), input)
+		if err != nil {
+			// 403 Forbidden, 404 Not Found, 422 Unprocessable Entity or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: The workout was successfully updated
+		responses.OK(c, workout)
+	}
+}
+
+// DeleteWorkout removes a workout of the authenticated user.
+//
+// HTTP Status Codes:
+// - 204 No Content: The workout was successfully removed.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 403 Forbidden: The workout was logged by another user.
+// - 404 Not Found: The workout with the specified ID was not found.
+// - 500 Internal Server Error: An issue occurred while removing the workout.
+//
+// Parameters:
+// - svc (*services.WorkoutService): The service that keeps the training log.
+//
+// Example usage:
+// r.DELETE("/workouts/:id", DeleteWorkout(svc))
+func DeleteWorkout(svc *services.WorkoutService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		if err := svc.Delete(c, c.Param("id"), id.(string)); err != nil {
+			// 403 Forbidden, 404 Not Found or 500 Internal Server Error
+			c.Error(err)
+			return
+		}
+
+		// 204 No Content: The workout was successfully removed
+		responses.NoContent(c)
+	}
+}
+
+// GetWorkoutVolume summarizes the training volume of the authenticated user by week (starting on Monday, in the
+// time zone of the server), oldest first, and muscle group: the sets, the repetitions and the volume (the
+// repetitions times the weight, in kilograms). Every week of the range and every muscle group is listed, with
+// zeros when untrained, for charts.
+//
+// HTTP Status Codes:
+// - 200 OK: Successfully retrieved the summary.
+// - 400 Bad Request: The number of weeks is not a number.
+// - 401 Unauthorized: The token is missing or invalid.
+// - 422 Unprocessable Entity: The number of weeks is out of range (1 to 52).
+// - 500 Internal Server Error: An issue occurred while summarizing the workouts.
+//
+// Parameters:
+// - svc (*services.WorkoutService): The service that keeps the training log.
+//
+// Example response data:
+//
+//	{
+//	    "timezone": "Europe/Madrid",
+//	    "weeks": [
+//	        {"week": "2026-10-12", "muscle_groups": [
+//	            {"muscle_group": "chest", "sets": 0, "reps": 0, "volume": 0},
+//	            {"muscle_group": "legs", "sets": 2, "reps": 10, "volume": 1200}
+//	        ]}
+//	    ]
+//	}
+//
+// Example usage:
+// r.GET("/workouts/volume?weeks=8", GetWorkoutVolume(svc))
+func GetWorkoutVolume(svc *services.WorkoutService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, idExist := c.Get("_id")
+		if !idExist {
+			// 401 Unauthorized: The token is missing or invalid
+			c.Error(apperrors.Unauthorized("Authorization token is missing or invalid"))
+			return
+		}
+
+		var query models.VolumeQuery
+		if err := validation.BindQuery(c, &query); err != nil {
+			// 400 Bad Request / 422 Unprocessable Entity: Invalid query parameters
+			c.Error(err)
+			return
+		}
+
+		volume, err := svc.Volume(c, id.(string), query)
+		if err != nil {
+			// 500 Internal Server Error: Database error
+			c.Error(err)
+			return
+		}
+
+		// 200 OK: Successfully retrieved the summary
+		responses.OK(c, volume)
+	}
+}
@@ -0,0 +1,140 @@
+// ical.go
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProdID identifies the product that produced the calendars.
+const ProdID = "-//Los Complejos//Events//EN"
+
+// Calendar is an iCalendar (RFC 5545) object holding events, ready to be added to Apple, Google or Outlook calendars.
+type Calendar struct {
+	Name    string        // Shown by the calendar apps (X-WR-CALNAME), optional
+	Refresh time.Duration // How often subscribed calendar apps fetch the calendar again, optional
+	Events  []Event       // One VEVENT each
+}
+
+// Event is a VEVENT of a Calendar.
+type Event struct {
+	UID         string    // Globally unique and stable across exports, so re-imports update the event
+	Summary     string    // Title
+	Description string    // Plain text, optional
+	Location    string    // Optional
+	Start       time.Time // DTSTART
+	End         time.Time // DTEND, omitted when zero
+	Stamp       time.Time // DTSTAMP: when the calendar was generated
+}
+
+// timeFormat is the UTC date-time format of iCalendar.
+const timeFormat = "20060102T150405Z"
+
+// maxLine is the maximum length, in octets, of a content line before it is folded.
+const maxLine = 75
+
+// WriteTo writes the calendar to w with CRLF line endings and long lines folded.
+func (c Calendar) WriteTo(w io.Writer) (int64, error) {
+	out := &writer{w: bufio.NewWriter(w)}
+	out.line("BEGIN", "VCALENDAR")
+	out.line("VERSION", "2.0")
+	out.line("PRODID", ProdID)
+	out.line("CALSCALE", "GREGORIAN")
+	out.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		out.line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.Refresh > 0 {
+		// RFC 7986 property, and its older equivalent still read by Outlook
+		out.line("REFRESH-INTERVAL;VALUE=DURATION", duration(c.Refresh))
+		out.line("X-PUBLISHED-TTL", duration(c.Refresh))
+	}
+	for _, event := range c.Events {
+		out.line("BEGIN", "VEVENT")
+		out.line("UID", escape(event.UID))
+		out.line("DTSTAMP", event.Stamp.UTC().Format(timeFormat))
+		out.line("DTSTART", event.Start.UTC().Format(timeFormat))
+		if !event.End.IsZero() {
+			out.line("DTEND", event.End.UTC().Format(timeFormat))
+		}
+		out.line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			out.line("DESCRIPTION", escape(event.Description))
+		}
+		if event.Location != "" {
+			out.line("LOCATION", escape(event.Location))
+		}
+		out.line("END", "VEVENT")
+	}
+	out.line("END", "VCALENDAR")
+
+	if out.err == nil {
+		out.err = out.w.Flush()
+	}
+	return out.n, out.err
+}
+
+// writer writes content lines, keeping the first error and the number of bytes written.
+type writer struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+// line writes the property with its (already escaped) value, folding it into lines of at most maxLine octets.
+// Continuation lines start with a space, and multi-byte characters are never split.
+func (w *writer) line(name, value string) {
+	if w.err != nil {
+		return
+	}
+	content := name + ":" + value
+	limit := maxLine
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.write(content[:cut] + "\r\n ")
+		content = content[cut:]
+		limit = maxLine - 1 // The leading space counts
+	}
+	w.write(content + "\r\n")
+}
+
+// write writes s, unless an error occurred before.
+func (w *writer) write(s string) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.WriteString(s)
+	w.n += int64(n)
+	w.err = err
+}
+
+// duration returns d, rounded down to the second, as an iCalendar DURATION value (e.g. "PT1H30M").
+func duration(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	value := "PT"
+	if hours := seconds / 3600; hours > 0 {
+		value += strconv.FormatInt(hours, 10) + "H"
+	}
+	if minutes := seconds % 3600 / 60; minutes > 0 {
+		value += strconv.FormatInt(minutes, 10) + "M"
+	}
+	if seconds%60 > 0 || value == "PT" {
+		value += strconv.FormatInt(seconds%60, 10) + "S"
+	}
+	return value
+}
+
+// escaper escapes the characters of TEXT values.
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escape returns the text as an iCalendar TEXT value.
+func escape(text string) string {
+	return escaper.Replace(text)
+}
@@ -0,0 +1,162 @@
+// imaging.go
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	_ "image/gif" // Register the GIF decoder
+)
+
+// ErrUnsupportedImage is returned when the data is not a decodable image within the configured limits.
+var ErrUnsupportedImage = errors.New("unsupported image")
+
+// Options bounds and shapes the processed images.
+type Options struct {
+	MaxBytes     int // Largest accepted input, in bytes
+	MaxPixels    int // Largest accepted input, in pixels (width × height), checked before decoding
+	MaxDimension int // Longest side of the output; larger images are scaled down
+	JPEGQuality  int // Quality of re-encoded JPEG images
+}
+
+// DefaultOptions are suitable for profile photos.
+var DefaultOptions = Options{
+	MaxBytes:     5 << 20,
+	MaxPixels:    40_000_000,
+	MaxDimension: 1024,
+	JPEGQuality:  85,
+}
+
+// ThumbnailOptions are suitable for the thumbnails of listings, made from already normalized photos.
+var ThumbnailOptions = Options{
+	MaxBytes:     5 << 20,
+	MaxPixels:    40_000_000,
+	MaxDimension: 96,
+	JPEGQuality:  75,
+}
+
+// MediumOptions are suitable for the medium size of photos shown in galleries, made from already normalized photos.
+var MediumOptions = Options{
+	MaxBytes:     5 << 20,
+	MaxPixels:    40_000_000,
+	MaxDimension: 480,
+	JPEGQuality:  80,
+}
+
+// Image is a processed image.
+type Image struct {
+	Data        []byte // Encoded image
+	ContentType string // MIME type of Data ("image/jpeg" or "image/png")
+	Width       int
+	Height      int
+}
+
+// Normalize decodes the image, scales it down to fit opts.MaxDimension and re-encodes it.
+// Re-encoding drops any embedded metadata (such as EXIF location). JPEG input stays JPEG;
+// every other format becomes PNG.
+func Normalize(data []byte, opts Options) (*Image, error) {
+	if len(data) > opts.MaxBytes {
+		return nil, fmt.Errorf("%w: larger than %d bytes", ErrUnsupportedImage, opts.MaxBytes)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if config.Width*config.Height > opts.MaxPixels {
+		return nil, fmt.Errorf("%w: larger than %d pixels", ErrUnsupportedImage, opts.MaxPixels)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	dst := fit(src, opts.MaxDimension)
+
+	var out bytes.Buffer
+	contentType := "image/png"
+	if format == "jpeg" {
+		contentType = "image/jpeg"
+		err = jpeg.Encode(&out, dst, &jpeg.Options{Quality: opts.JPEGQuality})
+	} else {
+		err = png.Encode(&out, dst)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := dst.Bounds()
+	return &Image{Data: out.Bytes(), ContentType: contentType, Width: bounds.Dx(), Height: bounds.Dy()}, nil
+}
+
+// fit scales src down (keeping its aspect ratio) so that its longest side is at most maxDimension.
+func fit(src image.Image, maxDimension int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxDimension && h <= maxDimension {
+		// Copy anyway so the output never carries decoder-specific state
+		dst := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+		return dst
+	}
+
+	dw, dh := maxDimension, h*maxDimension/w
+	if h > w {
+		dw, dh = w*maxDimension/h, maxDimension
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	return resample(src, bounds, dw, dh)
+}
+
+// Cover scales src to fill width × height without distorting it: the centered part of src with the aspect
+// ratio of the output is kept and the rest is cropped.
+func Cover(src image.Image, width, height int) *image.RGBA {
+	crop := src.Bounds()
+	w, h := crop.Dx(), crop.Dy()
+	if w*height > h*width {
+		// Wider than the output: crop the sides
+		cw := max(h*width/height, 1)
+		crop.Min.X += (w - cw) / 2
+		crop.Max.X = crop.Min.X + cw
+	} else {
+		// Taller than the output: crop the top and bottom
+		ch := max(w*height/width, 1)
+		crop.Min.Y += (h - ch) / 2
+		crop.Max.Y = crop.Min.Y + ch
+	}
+	return resample(src, crop, width, height)
+}
+
+// resample scales the area of src within bounds to dw × dh. Each output pixel is the average of the source
+// pixels it covers (or the nearest one when scaling up).
+func resample(src image.Image, bounds image.Rectangle, dw, dh int) *image.RGBA {
+	w, h := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		sy0, sy1 := bounds.Min.Y+y*h/dh, bounds.Min.Y+(y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			sx0, sx1 := bounds.Min.X+x*w/dw, bounds.Min.X+(x+1)*w/dw
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < max(sy1, sy0+1); sy++ {
+				for sx := sx0; sx < max(sx1, sx0+1); sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset+0] = uint8(r / n >> 8)
+			dst.Pix[offset+1] = uint8(g / n >> 8)
+			dst.Pix[offset+2] = uint8(b / n >> 8)
+			dst.Pix[offset+3] = uint8(a / n >> 8)
+		}
+	}
+	return dst
+}
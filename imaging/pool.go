@@ -0,0 +1,153 @@
+// pool.go
+package imaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrQueueFull is returned when an image cannot be queued because the pool is saturated.
+var ErrQueueFull = errors.New("image processing queue is full")
+
+// ErrPoolClosed is returned when an image is submitted to a closed pool.
+var ErrPoolClosed = errors.New("image processing pool is closed")
+
+// Stats is a snapshot of the pool's queue metrics.
+type Stats struct {
+	Workers       int    `json:"workers"`        // Number of workers
+	Busy          int64  `json:"busy"`           // Workers currently processing an image
+	QueueDepth    int    `json:"queue_depth"`    // Images waiting for a worker
+	QueueCapacity int    `json:"queue_capacity"` // Images that may wait before new ones are rejected
+	Processed     uint64 `json:"processed"`      // Images processed successfully
+	Failed        uint64 `json:"failed"`         // Images that could not be processed
+	Rejected      uint64 `json:"rejected"`       // Images rejected because the queue was full
+}
+
+// job is an image waiting for a worker.
+type job struct {
+	ctx     context.Context
+	data    []byte
+	options Options
+	result  chan<- result
+}
+
+// result is the outcome of a job.
+type result struct {
+	image *Image
+	err   error
+}
+
+// Pool processes images on a fixed number of workers fed by a bounded queue.
+// When the queue is full, new images are rejected instead of piling up.
+type Pool struct {
+	options Options
+	workers int
+	jobs    chan job
+	wg      sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+
+	busy      atomic.Int64
+	processed atomic.Uint64
+	failed    atomic.Uint64
+	rejected  atomic.Uint64
+}
+
+// NewPool starts a Pool with the given number of workers and queue capacity.
+func NewPool(workers, queue int, options Options) *Pool {
+	p := &Pool{
+		options: options,
+		workers: workers,
+		jobs:    make(chan job, queue),
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Process queues the image and waits for it to be processed with the options of the pool.
+// It returns ErrQueueFull right away when the queue is full.
+func (p *Pool) Process(ctx context.Context, data []byte) (*Image, error) {
+	return p.ProcessWith(ctx, data, p.options)
+}
+
+// ProcessWith is Process with other options, e.g. to make several sizes of an image on the same pool.
+func (p *Pool) ProcessWith(ctx context.Context, data []byte, options Options) (*Image, error) {
+	done := make(chan result, 1)
+
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return nil, ErrPoolClosed
+	}
+	select {
+	case p.jobs <- job{ctx: ctx, data: data, options: options, result: done}:
+		p.mu.RUnlock()
+	default:
+		p.mu.RUnlock()
+		p.rejected.Add(1)
+		return nil, ErrQueueFull
+	}
+
+	select {
+	case r := <-done:
+		return r.image, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the queue metrics.
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Workers:       p.workers,
+		Busy:          p.busy.Load(),
+		QueueDepth:    len(p.jobs),
+		QueueCapacity: cap(p.jobs),
+		Processed:     p.processed.Load(),
+		Failed:        p.failed.Load(),
+		Rejected:      p.rejected.Load(),
+	}
+}
+
+// Close stops accepting images and waits for the queued ones to be processed.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+// work processes jobs until the queue is closed.
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for j := range p.jobs {
+		if j.ctx.Err() != nil {
+			// The caller gave up while the image was queued
+			j.result <- result{err: j.ctx.Err()}
+			continue
+		}
+
+		p.busy.Add(1)
+		image, err := Normalize(j.data, j.options)
+		p.busy.Add(-1)
+
+		if err != nil {
+			p.failed.Add(1)
+		} else {
+			p.processed.Add(1)
+		}
+		j.result <- result{image: image, err: err}
+	}
+}
@@ -0,0 +1,257 @@
+// queue.go
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/outbox"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// Handler runs a job of its kind with the JSON payload it was enqueued with. Returning an error schedules a
+// retry, so handlers must tolerate running more than once for the same job.
+type Handler func(ctx context.Context, payload []byte) error
+
+// jobNamespace derives the IDs of the jobs enqueued once from their kind and key.
+var jobNamespace = uuid.MustParse("0c6f3a52-93d4-4b8e-a1f7-2e5d8c4b9a61")
+
+// Queue runs work in the background instead of during the requests: jobs are stored, so they survive restarts
+// and are shared by every instance of the API, and picked up by a pool of workers that retries the failed ones
+// with exponential backoff.
+type Queue struct {
+	repo   repository.JobRepository
+	clock  clock.Clock
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+
+	Workers      int           // Jobs run at the same time by this instance
+	PollInterval time.Duration // Wait of an idle worker between two polls
+	Lease        time.Duration // How long a running job is hidden from the other workers
+	MaxAttempts  int           // Attempts before a job is given up
+	MaxBackoff   time.Duration // Upper bound of the retry delay
+	Retention    time.Duration // How long finished jobs are kept
+}
+
+// NewQueue creates a Queue with 4 workers, giving a job up after 10 attempts and keeping finished jobs for
+// 7 days.
+func NewQueue(repo repository.JobRepository, clk clock.Clock, logger *slog.Logger) *Queue {
+	return &Queue{
+		repo:         repo,
+		clock:        clk,
+		logger:       logger,
+		handlers:     map[string]Handler{},
+		Workers:      4,
+		PollInterval: time.Second,
+		Lease:        5 * time.Minute,
+		MaxAttempts:  10,
+		MaxBackoff:   30 * time.Minute,
+		Retention:    7 * 24 * time.Hour,
+	}
+}
+
+// Register sets the handler running the jobs of the kind. Jobs of kinds without a handler stay pending until
+// one is registered.
+func (q *Queue) Register(kind string, handler Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[kind] = handler
+}
+
+// Enqueue records a job of the kind with the JSON-encoded payload, run as soon as a worker is free. Within a
+// transaction, the job is only enqueued if the transaction commits.
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload interface{}) error {
+	return q.enqueue(ctx, uuid.NewString(), kind, payload)
+}
+
+// EnqueueOnce is like Enqueue, but enqueues a single job of the kind for the key: enqueuing it again, e.g.
+// when the change that needs it is retried, does nothing.
+func (q *Queue) EnqueueOnce(ctx context.Context, kind, key string, payload interface{}) error {
+	return q.enqueue(ctx, uuid.NewSHA1(jobNamespace, []byte(kind+"/"+key)).String(), kind, payload)
+}
+
+// enqueue records the job with the given ID.
+func (q *Queue) enqueue(ctx context.Context, id, kind string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	now := q.clock.Now()
+	return q.repo.Enqueue(ctx, &models.Job{
+		ID:        id,
+		Kind:      kind,
+		Payload:   body,
+		Status:    models.JobPending,
+		CreatedAt: now,
+		RunAt:     now,
+	})
+}
+
+// deferredEvent is the payload of the jobs of Defer.
+type deferredEvent struct {
+	Topic     string          `json:"topic"`
+	MessageID string          `json:"message_id,omitempty"` // ID of the outbox message of the event
+	Event     json.RawMessage `json:"event"`
+}
+
+// Defer returns a bus handler that enqueues a job of the kind for each domain event, so the handler runs in
+// a worker instead of during the publication and is retried on its own: its failures no longer deliver the
+// event again to the other subscribers, and theirs no longer run it again. The handler sees the ID of the
+// outbox message of the event (outbox.MessageID), as if it ran during the publication.
+func (q *Queue) Defer(kind string, handler bus.Handler) bus.Handler {
+	q.Register(kind, func(ctx context.Context, payload []byte) error {
+		var deferred deferredEvent
+		if err := json.Unmarshal(payload, &deferred); err != nil {
+			return err
+		}
+		event, err := bus.Decode(deferred.Topic, deferred.Event)
+		if err != nil {
+			return err
+		}
+		if deferred.MessageID != "" {
+			ctx = outbox.WithMessageID(ctx, deferred.MessageID)
+		}
+		return handler(ctx, event)
+	})
+
+	return func(ctx context.Context, event bus.Event) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		deferred := deferredEvent{Topic: event.Topic(), MessageID: outbox.MessageID(ctx), Event: data}
+		if deferred.MessageID == "" {
+			return q.Enqueue(ctx, kind, deferred)
+		}
+		return q.EnqueueOnce(ctx, kind, deferred.MessageID, deferred)
+	}
+}
+
+// Run runs the jobs with Workers workers, and removes the finished jobs older than Retention every hour,
+// until the context is cancelled.
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < q.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		purged, err := q.repo.Purge(ctx, q.clock.Now().Add(-q.Retention))
+		if err != nil && ctx.Err() == nil {
+			q.logger.Error("purge of finished jobs failed", "error", err)
+		} else if purged > 0 {
+			q.logger.Info("purged finished jobs", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// work runs the due jobs, polling every PollInterval while there is none, until the context is cancelled.
+func (q *Queue) work(ctx context.Context) {
+	ticker := time.NewTicker(q.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := q.RunPending(ctx); err != nil && ctx.Err() == nil {
+			q.logger.Error("jobs failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunPending runs every job that is currently due and returns how many succeeded.
+func (q *Queue) RunPending(ctx context.Context) (int, error) {
+	q.mu.RLock()
+	kinds := make([]string, 0, len(q.handlers))
+	for kind := range q.handlers {
+		kinds = append(kinds, kind)
+	}
+	q.mu.RUnlock()
+	if len(kinds) == 0 {
+		return 0, nil
+	}
+
+	done := 0
+	for ctx.Err() == nil {
+		job, err := q.repo.Claim(ctx, kinds, q.clock.Now(), q.Lease)
+		if errors.Is(err, repository.ErrNotFound) {
+			return done, nil
+		}
+		if err != nil {
+			return done, err
+		}
+
+		err = q.run(ctx, job)
+		switch {
+		case err == nil:
+			err = q.repo.Complete(ctx, job.ID, q.clock.Now())
+			done++
+		case job.Attempts >= q.MaxAttempts:
+			q.logger.Error("job given up", "id", job.ID, "kind", job.Kind, "attempt", job.Attempts, "error", err)
+			err = q.repo.Fail(ctx, job.ID, err.Error(), q.clock.Now())
+		default:
+			retryAt := q.clock.Now().Add(q.backoff(job.Attempts))
+			q.logger.Warn("job failed", "id", job.ID, "kind", job.Kind, "attempt", job.Attempts, "retry_at", retryAt, "error", err)
+			err = q.repo.Retry(ctx, job.ID, err.Error(), retryAt)
+		}
+		if err != nil {
+			return done, err
+		}
+	}
+	return done, ctx.Err()
+}
+
+// run runs the handler of the job, turning a panic into an error.
+func (q *Queue) run(ctx context.Context, job *models.Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+
+	q.mu.RLock()
+	handler := q.handlers[job.Kind]
+	q.mu.RUnlock()
+	return handler(ctx, job.Payload)
+}
+
+// backoff returns the delay before the next attempt after the given number of attempts.
+func (q *Queue) backoff(attempts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempts && delay < q.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > q.MaxBackoff {
+		delay = q.MaxBackoff
+	}
+	return delay
+}
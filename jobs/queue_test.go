@@ -0,0 +1,221 @@
+// queue_test.go
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/outbox"
+	"los-complejos-backend/repository"
+)
+
+// memoryJobs is an in-memory repository.JobRepository claiming the jobs like the database ones.
+type memoryJobs struct {
+	mu   sync.Mutex
+	jobs []*models.Job
+}
+
+func (r *memoryJobs) Enqueue(ctx context.Context, job *models.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.find(job.ID) == nil {
+		r.jobs = append(r.jobs, job)
+	}
+	return nil
+}
+
+func (r *memoryJobs) Claim(ctx context.Context, kinds []string, now time.Time, lease time.Duration) (*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if job.Status == models.JobPending && slices.Contains(kinds, job.Kind) && !job.RunAt.After(now) {
+			job.Attempts++
+			job.RunAt = now.Add(lease)
+			claimed := *job
+			return &claimed, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *memoryJobs) Complete(ctx context.Context, id string, at time.Time) error {
+	return r.finish(id, models.JobDone, "", at)
+}
+
+func (r *memoryJobs) Retry(ctx context.Context, id, reason string, runAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	job.LastError, job.RunAt = reason, runAt
+	return nil
+}
+
+func (r *memoryJobs) Fail(ctx context.Context, id, reason string, at time.Time) error {
+	return r.finish(id, models.JobFailed, reason, at)
+}
+
+func (r *memoryJobs) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *memoryJobs) finish(id, status, reason string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	job.Status, job.FinishedAt = status, &at
+	if reason != "" {
+		job.LastError = reason
+	}
+	return nil
+}
+
+func (r *memoryJobs) find(id string) *models.Job {
+	for _, job := range r.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+func newTestQueue(repo repository.JobRepository, clk clock.Clock) *Queue {
+	return NewQueue(repo, clk, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestRunPendingRunsByKind(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryJobs{}
+	q := newTestQueue(repo, clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)))
+	var payloads []string
+	q.Register("email", func(ctx context.Context, payload []byte) error {
+		payloads = append(payloads, string(payload))
+		return nil
+	})
+	q.Enqueue(ctx, "email", map[string]string{"to": "maria"})
+	q.Enqueue(ctx, "push", map[string]string{"to": "maria"})
+
+	n, err := q.RunPending(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("RunPending = %d, %v, want 1 job run", n, err)
+	}
+	if len(payloads) != 1 || payloads[0] != `{"to":"maria"}` {
+		t.Errorf("payloads = %v, want the JSON payload of the email job", payloads)
+	}
+	if repo.jobs[0].Status != models.JobDone || repo.jobs[0].FinishedAt == nil {
+		t.Errorf("email job = %+v, want done", repo.jobs[0])
+	}
+	if repo.jobs[1].Status != models.JobPending || repo.jobs[1].Attempts != 0 {
+		t.Errorf("job without handler = %+v, want it left pending and unclaimed", repo.jobs[1])
+	}
+}
+
+func TestEnqueueOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryJobs{}
+	q := newTestQueue(repo, clock.NewFake(time.Now()))
+	q.EnqueueOnce(ctx, "thumbnail", "p1", "p1")
+	q.EnqueueOnce(ctx, "thumbnail", "p1", "p1")
+	q.EnqueueOnce(ctx, "thumbnail", "p2", "p2")
+	q.EnqueueOnce(ctx, "medium", "p1", "p1")
+	if len(repo.jobs) != 3 {
+		t.Errorf("enqueued %d jobs, want one per kind and key (3)", len(repo.jobs))
+	}
+}
+
+func TestRunPendingRetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	repo := &memoryJobs{}
+	q := newTestQueue(repo, clk)
+	q.MaxAttempts = 3
+	attempts := 0
+	q.Register("webhook", func(ctx context.Context, payload []byte) error {
+		attempts++
+		if attempts == 1 {
+			panic("nil map")
+		}
+		return errors.New("receiver down")
+	})
+	q.Enqueue(ctx, "webhook", nil)
+
+	for i, want := range []struct {
+		wait  time.Duration
+		error string
+	}{{time.Second, "panic: nil map"}, {2 * time.Second, "receiver down"}} {
+		if n, err := q.RunPending(ctx); n != 0 || err != nil {
+			t.Fatalf("attempt %d: RunPending = %d, %v, want a failed job", i+1, n, err)
+		}
+		job := repo.jobs[0]
+		if job.Status != models.JobPending || job.RunAt.Sub(clk.Now()) != want.wait || job.LastError != want.error {
+			t.Fatalf("attempt %d: job = %+v, want retried in %v after %q", i+1, job, want.wait, want.error)
+		}
+		clk.Advance(want.wait)
+	}
+
+	q.RunPending(ctx)
+	if job := repo.jobs[0]; job.Status != models.JobFailed || job.Attempts != 3 || job.FinishedAt == nil {
+		t.Errorf("job = %+v, want given up after MaxAttempts (3)", job)
+	}
+	clk.Advance(time.Hour)
+	q.RunPending(ctx)
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want no attempt after the job was given up", attempts)
+	}
+}
+
+func TestDeferRunsTheEventInAJob(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryJobs{}
+	q := newTestQueue(repo, clock.NewFake(time.Now()))
+	var received []bus.ComplejoDeleted
+	var messageIDs []string
+	publish := q.Defer("notify", func(ctx context.Context, event bus.Event) error {
+		received = append(received, *event.(*bus.ComplejoDeleted))
+		messageIDs = append(messageIDs, outbox.MessageID(ctx))
+		return nil
+	})
+
+	event := bus.ComplejoDeleted{ID: "c1", Username: "maria", Events: []string{"e1"}}
+	delivery := outbox.WithMessageID(ctx, "m1")
+	for i := 0; i < 2; i++ {
+		// The outbox delivers a message again when a subscriber fails
+		if err := publish(delivery, event); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+	if len(received) != 0 {
+		t.Fatal("the handler ran during the publication")
+	}
+	if len(repo.jobs) != 1 {
+		t.Fatalf("enqueued %d jobs, want one per outbox message", len(repo.jobs))
+	}
+
+	if n, err := q.RunPending(ctx); n != 1 || err != nil {
+		t.Fatalf("RunPending = %d, %v, want 1 job run", n, err)
+	}
+	if len(received) != 1 || received[0].ID != "c1" || received[0].Username != "maria" || len(received[0].Events) != 1 {
+		t.Errorf("received %+v, want the published event", received)
+	}
+	if messageIDs[0] != "m1" {
+		t.Errorf("MessageID = %q, want the ID of the outbox message (m1)", messageIDs[0])
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	q := newTestQueue(&memoryJobs{}, clock.NewFake(time.Now()))
+	q.MaxBackoff = 10 * time.Second
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 30: 10 * time.Second} {
+		if got := q.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// schema.go
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Schema returns the JSON structure of body with every value replaced by the name of its type
+// ("string", "number", "boolean" or "null"), and the SHA-256 of that structure.
+// Arrays keep the structure of their first element only. A body that is not JSON has the schema "opaque"
+// and an empty body the schema "empty".
+func Schema(body []byte) (string, string) {
+	schema := `"empty"`
+	if len(body) > 0 {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			schema = `"opaque"`
+		} else {
+			encoded, _ := json.Marshal(shape(value)) // Map keys are sorted, so the schema is canonical
+			schema = string(encoded)
+		}
+	}
+	return schema, digest(schema)
+}
+
+// Opaque returns the schema "opaque" and its SHA-256, for the bodies that are not read, such as uploads.
+func Opaque() (string, string) {
+	return `"opaque"`, digest(`"opaque"`)
+}
+
+// digest returns the hex-encoded SHA-256 of the schema.
+func digest(schema string) string {
+	sum := sha256.Sum256([]byte(schema))
+	return hex.EncodeToString(sum[:])
+}
+
+// shape replaces every value of a decoded JSON document by its type name.
+func shape(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		shaped := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			shaped[key] = shape(item)
+		}
+		return shaped
+	case []interface{}:
+		if len(v) == 0 {
+			return []interface{}{}
+		}
+		return []interface{}{shape(v[0])}
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+// Sample builds a request body matching schema, with placeholder values of the recorded types.
+// It returns nil for the "empty" schema and for schemas that are not JSON structures.
+func Sample(schema string) []byte {
+	var value interface{}
+	if err := json.Unmarshal([]byte(schema), &value); err != nil {
+		return nil
+	}
+	if name, ok := value.(string); ok && (name == "empty" || name == "opaque") {
+		return nil
+	}
+
+	body, _ := json.Marshal(sample(value))
+	return body
+}
+
+// sample replaces every type name of a shaped document by a placeholder value.
+func sample(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		sampled := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			sampled[key] = sample(item)
+		}
+		return sampled
+	case []interface{}:
+		sampled := make([]interface{}, len(v))
+		for i, item := range v {
+			sampled[i] = sample(item)
+		}
+		return sampled
+	case string:
+		switch v {
+		case "string":
+			return "replay"
+		case "number":
+			return 1
+		case "boolean":
+			return true
+		}
+	}
+	return nil
+}
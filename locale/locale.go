@@ -0,0 +1,119 @@
+// locale.go
+package locale
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported locales.
+const (
+	English = "en"
+	Spanish = "es"
+
+	DefaultLocale = English
+)
+
+// Supported unit systems.
+const (
+	Metric   = "metric"   // Kilograms and meters
+	Imperial = "imperial" // Pounds and feet
+
+	DefaultUnits = Metric
+)
+
+// Locales and UnitSystems list the accepted values, in order of preference.
+var (
+	Locales     = []string{English, Spanish}
+	UnitSystems = []string{Metric, Imperial}
+)
+
+// ContextKey is the key under which the request's Preferences are stored in the Gin context.
+const ContextKey = "preferences"
+
+// Preferences are the locale and unit system resolved for a request.
+type Preferences struct {
+	Locale string `json:"locale"`
+	Units  string `json:"units"`
+}
+
+// Default returns the Preferences used when nothing else is known.
+func Default() Preferences {
+	return Preferences{Locale: DefaultLocale, Units: DefaultUnits}
+}
+
+// FromContext returns the Preferences resolved for the request, or the defaults when none were resolved.
+func FromContext(ctx context.Context) Preferences {
+	if prefs, ok := ctx.Value(ContextKey).(Preferences); ok {
+		return prefs
+	}
+	return Default()
+}
+
+// IsLocale reports whether value is a supported locale.
+func IsLocale(value string) bool {
+	return contains(Locales, value)
+}
+
+// IsUnitSystem reports whether value is a supported unit system.
+func IsUnitSystem(value string) bool {
+	return contains(UnitSystems, value)
+}
+
+// Negotiate picks the supported locale that best matches an Accept-Language header
+// (e.g. "es-ES,es;q=0.9,en;q=0.8"). It reports false when no supported locale is acceptable.
+func Negotiate(acceptLanguage string) (string, bool) {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{tag: tag, quality: quality})
+		}
+	}
+
+	// Highest quality first; the header order breaks ties
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if c.tag == "*" {
+			return DefaultLocale, true
+		}
+		base, _, _ := strings.Cut(c.tag, "-")
+		if IsLocale(base) {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// contains reports whether value is one of values.
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
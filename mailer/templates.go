@@ -0,0 +1,88 @@
+// templates.go
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+
+	"los-complejos-backend/locale"
+)
+
+// Names of the email templates.
+const (
+	TemplateEventUpdated   = "event_updated"
+	TemplateEventCancelled = "event_cancelled"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// Templates renders the emails from text/template files named `<name>.<locale>.tmpl` (e.g. `event_updated.es.tmpl`).
+// A template starts with a `Subject: ` line and a blank line, followed by the body.
+type Templates struct {
+	templates map[string]*template.Template
+}
+
+// LoadTemplates parses the default templates, then the templates in dir (none when empty), which replace the
+// default ones of the same name.
+func LoadTemplates(dir string) (*Templates, error) {
+	t := &Templates{templates: map[string]*template.Template{}}
+	if err := t.parse(defaultTemplates, "templates"); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := t.parse(os.DirFS(dir), "."); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// parse adds the templates found in the directory of fsys.
+func (t *Templates) parse(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.tmpl"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(path.Base(file), ".tmpl")
+		tmpl, err := template.New(name).Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("invalid email template %s: %w", file, err)
+		}
+		t.templates[name] = tmpl
+	}
+	return nil
+}
+
+// Render renders the template with the given name in the locale (the default locale when there is no
+// template in it) into an email to the recipient.
+func (t *Templates) Render(name, loc, to string, data interface{}) (Message, error) {
+	tmpl, ok := t.templates[name+"."+loc]
+	if !ok {
+		if tmpl, ok = t.templates[name+"."+locale.DefaultLocale]; !ok {
+			return Message{}, fmt.Errorf("unknown email template %q", name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return Message{}, err
+	}
+	header, body, ok := strings.Cut(buf.String(), "\n\n")
+	subject, found := strings.CutPrefix(header, "Subject: ")
+	if !ok || !found || strings.Contains(subject, "\n") {
+		return Message{}, fmt.Errorf("email template %q does not start with a Subject line and a blank line", tmpl.Name())
+	}
+	return Message{To: to, Subject: strings.TrimSpace(subject), Body: strings.TrimSpace(body) + "\n"}, nil
+}
@@ -2,66 +2,54 @@
 package main
 
 import (
+	"context"
 	"log"
+	"los-complejos-backend/app"
+	"los-complejos-backend/config"
 	"los-complejos-backend/database"
-	"los-complejos-backend/handlers"
-	"los-complejos-backend/middleware"
+	"los-complejos-backend/migrations"
 	"os"
-
-	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
-// Message struct for test endpoint response
-type Message struct {
-	Content string `json:"content"`
-}
-
 func main() {
-	err := godotenv.Load()
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Error loading .env file: %v", err)
+		log.Fatalf("Error loading configuration: %v", err)
 	}
 
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		log.Fatal("JWT_SECRET is not set in the environment")
-	}
-
-	uri := "mongodb://localhost:27017"
-
-	// Connect to the database
-	_ = database.ConnectDB(uri)
-	defer database.CloseDB()
-
-	// Collections
-	complejo_collection := database.GetCollection("COMPLEJOS", "complejo")
-	event_collection := database.GetCollection("COMPLEJOS", "event")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	r := gin.Default()
-
-	// Test route
-	r.GET("/test", func(c *gin.Context) {
-		c.JSON(200, Message{Content: "Server is running!"})
-	})
-
-	// Complejo routes
-	// Handles user management for "Complejo" resources
-	r.POST("/complejo", handlers.CreateComplejo(complejo_collection))
-	r.GET("/complejo", handlers.GetComplejos(complejo_collection))
-	r.GET("/complejo/:id", handlers.GetComplejo(complejo_collection))
-	r.PUT("/complejo/admin", middleware.AuthMiddleware(), handlers.UpdateComplejoForAdmin(complejo_collection))
-	r.PUT("/complejo/user", middleware.AuthMiddleware(), handlers.UpdateComplejoForUser(complejo_collection))
-
-	// Event routes
-	// Handles event management and user subscription/unsubscription
-	r.POST("/event", middleware.AuthMiddleware(), handlers.CreateEvent(event_collection))
-	r.GET("/event", handlers.GetEvents(event_collection))
-	r.GET("/event/:id", handlers.GetEvent(event_collection))
-	r.PUT("/event/admin", middleware.AuthMiddleware(), handlers.UpdateEventForAdmin(event_collection))
-	r.PUT("/event/:id/subscribe", middleware.AuthMiddleware(), handlers.SubscribeEvent(event_collection))
-	r.PUT("/event/:id/unsubscribe", middleware.AuthMiddleware(), handlers.UnsuscribeEvent(event_collection))
+	application, err := app.New(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Error building the application: %v", err)
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := application.Close(closeCtx); err != nil {
+			log.Printf("Error closing the application: %v", err)
+		}
+	}()
+
+	// Build the missing MongoDB indexes before serving (PostgreSQL indexes come with the migrations)
+	if application.DB != nil {
+		if err := database.EnsureIndexes(ctx, application.DB, application.Logger); err != nil {
+			log.Printf("Error ensuring the MongoDB indexes: %v", err)
+			return
+		}
+		if pending, err := migrations.Pending(ctx, application.DB); err != nil {
+			log.Printf("Error checking the MongoDB migrations: %v", err)
+		} else if len(pending) > 0 {
+			application.Logger.Warn("MongoDB migrations are pending; apply them with `go run ./cmd/migrate`", "count", len(pending))
+		}
+	}
 
-	// Start the server on port 8080
-	r.Run(":8080")
+	// Start the server on the configured port (8080 by default)
+	if err := application.Run(ctx); err != nil {
+		log.Printf("Server error: %v", err)
+	}
 }
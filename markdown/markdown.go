@@ -0,0 +1,335 @@
+// markdown.go
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Tags lists the elements the renderer produces, in the order of the default allow-list.
+var Tags = []string{"p", "h1", "h2", "h3", "h4", "h5", "h6", "em", "strong", "del", "code", "pre",
+	"blockquote", "ul", "ol", "li", "a", "hr", "br"}
+
+// Schemes lists the URL schemes of the links kept by default; relative links are always kept.
+var Schemes = []string{"http", "https", "mailto"}
+
+// Policy renders Markdown into sanitized HTML. Raw HTML in the source is always escaped, elements outside the
+// allow-list are replaced by their content, and links whose scheme is not allowed are replaced by their text.
+type Policy struct {
+	Tags    map[string]bool // Elements kept
+	Schemes map[string]bool // URL schemes of the links kept
+}
+
+// DefaultPolicy returns the Policy allowing every element in Tags and the links with a scheme in Schemes.
+func DefaultPolicy() Policy {
+	policy, _ := NewPolicy(Tags)
+	return policy
+}
+
+// NewPolicy returns the Policy allowing the given elements and the links with a scheme in Schemes.
+// An error is returned for an element the renderer does not produce.
+func NewPolicy(tags []string) (Policy, error) {
+	policy := Policy{Tags: map[string]bool{}, Schemes: map[string]bool{}}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !supported(tag) {
+			return Policy{}, fmt.Errorf("unsupported markdown element %q", tag)
+		}
+		policy.Tags[tag] = true
+	}
+	for _, scheme := range Schemes {
+		policy.Schemes[scheme] = true
+	}
+	return policy, nil
+}
+
+// supported reports whether the renderer produces the element.
+func supported(tag string) bool {
+	for _, t := range Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	heading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	rule        = regexp.MustCompile(`^\s{0,3}([-*_])(\s*([-*_]))\s*([-*_]\s*)+$`)
+	bullet      = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	numbered    = regexp.MustCompile(`^\s{0,3}\d{1,9}[.)]\s+(.*)$`)
+	quote       = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	fence       = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	scheme      = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*):`)
+	punctuation = "\\`*_{}[]()#+-.!~>|"
+)
+
+// Render converts the Markdown source into HTML allowed by the policy. It supports paragraphs, headings,
+// emphasis (*em*, **strong**, ~~del~~), inline and fenced code, links, block quotes, lists, rules and hard line breaks.
+func (p Policy) Render(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	var out strings.Builder
+	p.blocks(&out, lines)
+	return out.String()
+}
+
+// blocks renders the block-level structure of the lines.
+func (p Policy) blocks(out *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			i++
+			continue
+		}
+		// Blocks are separated by a newline, so their text stays apart when their element is not allowed
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+
+		switch {
+		case fence.MatchString(line):
+			marker := fence.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), marker); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // closing fence
+			body := html.EscapeString(strings.Join(code, "\n"))
+			if len(code) > 0 {
+				body += "\n"
+			}
+			out.WriteString(p.wrap("pre", p.wrap("code", body)))
+
+		case heading.MatchString(line):
+			m := heading.FindStringSubmatch(line)
+			out.WriteString(p.wrap(fmt.Sprintf("h%d", len(m[1])), p.inline(m[2])))
+			i++
+
+		case rule.MatchString(line):
+			if p.Tags["hr"] {
+				out.WriteString("<hr>")
+			}
+			i++
+
+		case quote.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && quote.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quote.FindStringSubmatch(lines[i])[1])
+			}
+			var inner strings.Builder
+			p.blocks(&inner, quoted)
+			out.WriteString(p.wrap("blockquote", inner.String()))
+
+		case bullet.MatchString(line), numbered.MatchString(line):
+			list, item := "ul", bullet
+			if !bullet.MatchString(line) {
+				list, item = "ol", numbered
+			}
+			var items strings.Builder
+			for i < len(lines) && item.MatchString(lines[i]) {
+				text := []string{item.FindStringSubmatch(lines[i])[1]}
+				// Indented lines continue the item
+				for i++; i < len(lines) && strings.HasPrefix(lines[i], "  ") && strings.TrimSpace(lines[i]) != ""; i++ {
+					text = append(text, strings.TrimSpace(lines[i]))
+				}
+				items.WriteString(p.wrap("li", p.paragraph(text)) + "\n")
+			}
+			out.WriteString(p.wrap(list, items.String()))
+
+		default:
+			var text []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !p.interrupts(lines[i]); i++ {
+				text = append(text, lines[i])
+			}
+			out.WriteString(p.wrap("p", p.paragraph(text)))
+		}
+	}
+}
+
+// interrupts reports whether the line starts a block that ends the current paragraph.
+func (p Policy) interrupts(line string) bool {
+	return fence.MatchString(line) || heading.MatchString(line) || rule.MatchString(line) ||
+		quote.MatchString(line) || bullet.MatchString(line) || numbered.MatchString(line)
+}
+
+// paragraph renders the lines of a paragraph; lines ending with two spaces or a backslash break the line.
+func (p Policy) paragraph(lines []string) string {
+	var out strings.Builder
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		hard := strings.HasSuffix(line, "  ") || strings.HasSuffix(trimmed, "\\")
+		trimmed = strings.TrimSuffix(trimmed, "\\")
+		out.WriteString(p.inline(trimmed))
+		if i == len(lines)-1 {
+			break
+		}
+		if hard && p.Tags["br"] {
+			out.WriteString("<br>")
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// inline renders the inline elements of the text, escaping everything else.
+func (p Policy) inline(text string) string {
+	var out strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte(punctuation, text[i+1]) >= 0:
+			out.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			run := 1
+			for i+run < len(text) && text[i+run] == '`' {
+				run++
+			}
+			delimiter := strings.Repeat("`", run)
+			if end := strings.Index(text[i+run:], delimiter); end >= 0 {
+				code := strings.TrimSpace(text[i+run : i+run+end])
+				out.WriteString(p.wrap("code", html.EscapeString(code)))
+				i += run + end + run
+				continue
+			}
+			out.WriteString(delimiter)
+			i += run
+			continue
+
+		case c == '[':
+			if label, target, n, ok := link(text[i:]); ok {
+				inner := p.inline(label)
+				if p.Tags["a"] && p.allowed(target) {
+					out.WriteString(`<a href="` + html.EscapeString(target) + `" rel="nofollow noopener">` + inner + `</a>`)
+				} else {
+					out.WriteString(inner)
+				}
+				i += n
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			for _, emphasis := range []struct{ delimiter, tag string }{
+				{strings.Repeat(string(c), 2), "strong"},
+				{string(c), "em"},
+			} {
+				tag := emphasis.tag
+				if c == '~' {
+					if len(emphasis.delimiter) == 1 {
+						continue
+					}
+					tag = "del"
+				}
+				d := emphasis.delimiter
+				if !strings.HasPrefix(text[i:], d) || i+len(d) >= len(text) || text[i+len(d)] == ' ' {
+					continue
+				}
+				// Underscores inside words (snake_case) are not emphasis
+				if c == '_' && i > 0 && isWordByte(text[i-1]) {
+					continue
+				}
+				end := strings.Index(text[i+len(d):], d)
+				if end <= 0 || text[i+len(d)+end-1] == ' ' {
+					continue
+				}
+				// A longer closing run (***) closes an inner emphasis first: **a *b*** is <strong>a <em>b</em></strong>
+				for len(d) == 2 && c != '~' && i+len(d)+end+len(d) < len(text) && text[i+len(d)+end+len(d)] == c {
+					end++
+				}
+				out.WriteString(p.wrap(tag, p.inline(text[i+len(d):i+len(d)+end])))
+				i += len(d) + end + len(d)
+				goto next
+			}
+		}
+		out.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	next:
+	}
+	return out.String()
+}
+
+// isWordByte reports whether the byte is an ASCII letter or digit.
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// link parses a [label](target) link at the start of the text and returns its parts and length.
+func link(text string) (label, target string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if i+1 >= len(text) || text[i+1] != '(' {
+				return "", "", 0, false
+			}
+			end := closing(text[i+2:])
+			if end < 0 {
+				return "", "", 0, false
+			}
+			target = strings.TrimSpace(text[i+2 : i+2+end])
+			// Drop an optional title: [label](url "title")
+			if space := strings.IndexAny(target, " \t"); space >= 0 {
+				target = target[:space]
+			}
+			return text[1:i], strings.Trim(target, "<>"), i + 3 + end, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// closing returns the index of the parenthesis closing the text, allowing balanced parentheses inside, or -1.
+func closing(text string) int {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// allowed reports whether a link may point to the target: relative links, and absolute links with an allowed scheme.
+// Character references are decoded first, so &#106;avascript: is recognized as javascript:.
+func (p Policy) allowed(target string) bool {
+	target = html.UnescapeString(target)
+	if m := scheme.FindStringSubmatch(target); m != nil {
+		return p.Schemes[strings.ToLower(m[1])]
+	}
+	// A colon before any slash would be read as a scheme by browsers
+	if colon := strings.IndexByte(target, ':'); colon >= 0 && !strings.ContainsAny(target[:colon], "/?#") {
+		return false
+	}
+	return true
+}
+
+// wrap encloses the HTML in the element, or returns it unchanged when the element is not allowed.
+func (p Policy) wrap(tag, inner string) string {
+	if !p.Tags[tag] {
+		return inner
+	}
+	return "<" + tag + ">" + inner + "</" + tag + ">"
+}
+
+// PlainText returns the text of the Markdown source without its markup, on a single line, e.g. for the
+// descriptions shown by search engines and link previews.
+func PlainText(source string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(Policy{}.Render(source))), " ")
+}
@@ -0,0 +1,139 @@
+// markdown_test.go
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"https", `[site](https://example.com/a?b=1&c=2)`,
+			`<p><a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener">site</a></p>`},
+		{"mailto", `[mail](mailto:club@example.com)`, `<p><a href="mailto:club@example.com" rel="nofollow noopener">mail</a></p>`},
+		{"relative", `[event](/event/42)`, `<p><a href="/event/42" rel="nofollow noopener">event</a></p>`},
+		{"title dropped", `[site](https://example.com "Title")`, `<p><a href="https://example.com" rel="nofollow noopener">site</a></p>`},
+		{"javascript", `[x](javascript:alert(1))`, `<p>x</p>`},
+		{"uppercase javascript", `[x](JAVASCRIPT:alert(1))`, `<p>x</p>`},
+		{"mixed case javascript", `[x](JavaScript:alert(1))`, `<p>x</p>`},
+		{"data", `[x](data:text/html;base64,PHNjcmlwdD4=)`, `<p>x</p>`},
+		{"vbscript", `[x](vbscript:msgbox(1))`, `<p>x</p>`},
+		{"leading space", `[x](  javascript:alert(1))`, `<p>x</p>`},
+		{"control character", "[x](\x01javascript:alert(1))", `<p>x</p>`},
+		{"angle brackets", `[x](<javascript:alert(1)>)`, `<p>x</p>`},
+		{"angle brackets allowed", `[x](<https://example.com>)`, `<p><a href="https://example.com" rel="nofollow noopener">x</a></p>`},
+		{"entity encoded scheme", `[x](&#106;avascript:alert(1))`, `<p>x</p>`},
+		{"hex entity encoded colon", `[x](javascript&#x3A;alert(1))`, `<p>x</p>`},
+		{"named entity encoded colon", `[x](javascript&colon;alert(1))`, `<p>x</p>`},
+		{"attribute injection", `[x](https://example.com/"onmouseover="alert(1))`,
+			`<p><a href="https://example.com/&#34;onmouseover=&#34;alert(1)" rel="nofollow noopener">x</a></p>`},
+		{"label markup", `[**bold** <b>](https://example.com)`,
+			`<p><a href="https://example.com" rel="nofollow noopener"><strong>bold</strong> &lt;b&gt;</a></p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultPolicy().Render(tt.source); got != tt.want {
+				t.Errorf("Render(%q) =\n%s\nwant\n%s", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderEscapesRawHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"script", `<script>alert(1)</script>`, `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`},
+		{"event handler", `<img src=x onerror="alert(1)">`, `<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>`},
+		{"in emphasis", `*<script>*`, `<p><em>&lt;script&gt;</em></p>`},
+		{"in heading", `# <b>title</b>`, `<h1>&lt;b&gt;title&lt;/b&gt;</h1>`},
+		{"in code", "`<script>`", `<p><code>&lt;script&gt;</code></p>`},
+		{"in fenced code", "```\n<script>alert(1)</script>\n```", "<pre><code>&lt;script&gt;alert(1)&lt;/script&gt;\n</code></pre>"},
+		{"escaped markup", `\*not em\*`, `<p>*not em*</p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultPolicy().Render(tt.source); got != tt.want {
+				t.Errorf("Render(%q) =\n%s\nwant\n%s", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderBlocksAndEmphasis(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"nested emphasis", `**bold *and em***`, `<p><strong>bold <em>and em</em></strong></p>`},
+		{"strong and em together", `***both***`, `<p><strong><em>both</em></strong></p>`},
+		{"emphasis in strong in del", `~~**a** *b*~~`, `<p><del><strong>a</strong> <em>b</em></del></p>`},
+		{"snake case", `a_b_c`, `<p>a_b_c</p>`},
+		{"unclosed", `**open`, `<p>**open</p>`},
+		{"heading", `## Title ##`, `<h2>Title</h2>`},
+		{"list", "- one\n- two", "<ul><li>one</li>\n<li>two</li>\n</ul>"},
+		{"ordered list", "1. one\n2. two", "<ol><li>one</li>\n<li>two</li>\n</ol>"},
+		{"quote", "> quoted", "<blockquote><p>quoted</p></blockquote>"},
+		{"rule", "---", "<hr>"},
+		{"hard break", "one  \ntwo", "<p>one<br>\ntwo</p>"},
+		{"paragraphs", "one\n\ntwo", "<p>one</p>\n<p>two</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultPolicy().Render(tt.source); got != tt.want {
+				t.Errorf("Render(%q) =\n%s\nwant\n%s", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderRestrictedPolicy(t *testing.T) {
+	policy, err := NewPolicy([]string{"p", "em"})
+	if err != nil {
+		t.Fatalf("NewPolicy: %v", err)
+	}
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"link removed", `[site](https://example.com)`, `<p>site</p>`},
+		{"strong removed", `**bold** *em*`, `<p>bold <em>em</em></p>`},
+		{"heading removed", `# Title`, `Title`},
+		{"list removed", "- one\n- two", "one\ntwo\n"},
+		{"rule removed", "one\n\n---\n\ntwo", "<p>one</p>\n\n<p>two</p>"},
+		{"code removed, still escaped", "`<b>`", `<p>&lt;b&gt;</p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Render(tt.source); got != tt.want {
+				t.Errorf("Render(%q) =\n%s\nwant\n%s", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPolicyRejectsUnsupportedTags(t *testing.T) {
+	for _, tag := range []string{"script", "img", "iframe", "div"} {
+		if _, err := NewPolicy([]string{"p", tag}); err == nil || !strings.Contains(err.Error(), tag) {
+			t.Errorf("NewPolicy(%q) error = %v, want one naming the element", tag, err)
+		}
+	}
+	if _, err := NewPolicy([]string{" EM ", "Strong"}); err != nil {
+		t.Errorf("NewPolicy with spaced and uppercase elements: %v", err)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	source := "# Ride\n\nMeet at **8:00** & bring [lights](https://example.com).\n\n- water"
+	if got, want := PlainText(source), "Ride Meet at 8:00 & bring lights. water"; got != want {
+		t.Errorf("PlainText = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+// api_key_middleware.go
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"los-complejos-backend/apperrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is the header trusted integrations use to present their API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyMiddleware only lets through requests presenting the given API key in the X-API-Key header.
+// When no key is configured the protected routes are disabled.
+func APIKeyMiddleware(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key == "" {
+			abortWithError(c, apperrors.Forbidden("This integration is disabled"))
+			return
+		}
+
+		provided := c.GetHeader(APIKeyHeader)
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(key)) != 1 {
+			abortWithError(c, apperrors.Unauthorized("A valid API key is required"))
+			return
+		}
+
+		c.Next()
+	}
+}
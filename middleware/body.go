@@ -0,0 +1,34 @@
+// body.go
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// Limits of the request bodies read by the middlewares before the handlers, which enforce their own limits.
+const (
+	JournalBodyLimit = 64 << 10 // Bodies whose schema is journaled
+	ShadowBodyLimit  = 1 << 20  // Bodies mirrored or logged by the shadow traffic
+)
+
+// peekBody reads at most limit bytes of the request body and puts them back in front of the rest of the stream,
+// which is left unread for the handler. It returns the bytes read and whether they are the whole body.
+func peekBody(r *http.Request, limit int64) ([]byte, bool, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, true, nil
+	}
+	head, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), r.Body), r.Body}
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(head)) > limit {
+		return head[:limit], false, nil
+	}
+	return head, true, nil
+}
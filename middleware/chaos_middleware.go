@@ -0,0 +1,54 @@
+// chaos_middleware.go
+package middleware
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrChaosStorage is the simulated storage failure injected by ChaosMiddleware.
+var ErrChaosStorage = errors.New("chaos: simulated database failure")
+
+// ChaosOptions sets how often each fault is injected. Rates are probabilities between 0 and 1.
+type ChaosOptions struct {
+	LatencyRate float64       // Share of requests delayed by Latency
+	Latency     time.Duration // Delay added to slowed-down requests
+	ErrorRate   float64       // Share of requests failing with a simulated database error (500)
+	DropRate    float64       // Share of requests whose connection is closed without a response
+}
+
+// ChaosMiddleware injects faults (latency, database errors and dropped connections) at the configured rates,
+// to verify that retries, timeouts and user-facing error messages behave sanely.
+// It is meant for test environments only and must never be enabled in production.
+//
+// Example usage:
+// r.Use(ChaosMiddleware(ChaosOptions{LatencyRate: 0.1, Latency: 2 * time.Second, ErrorRate: 0.05}))
+func ChaosMiddleware(opts ChaosOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rand.Float64() < opts.LatencyRate {
+			select {
+			case <-time.After(opts.Latency):
+			case <-c.Request.Context().Done():
+			}
+		}
+
+		if rand.Float64() < opts.DropRate {
+			if conn, _, err := c.Writer.Hijack(); err == nil {
+				conn.Close()
+				c.Abort()
+				return
+			}
+		}
+
+		if rand.Float64() < opts.ErrorRate {
+			// Rendered as a generic 500, exactly like a real storage failure
+			abortWithError(c, ErrChaosStorage)
+			return
+		}
+
+		c.Next()
+	}
+}
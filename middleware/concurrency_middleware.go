@@ -0,0 +1,59 @@
+// concurrency_middleware.go
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"los-complejos-backend/apperrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConcurrencyLimit caps how many requests run the wrapped handlers at the same time.
+//
+// Up to limit requests run concurrently and up to queue more wait for a free slot for at most
+// wait. Requests beyond the queue, or still waiting after wait, are rejected with 503 Service
+// Unavailable and a Retry-After header, so a burst of expensive requests cannot overload the database.
+// Share one limiter between routes to cap them together.
+//
+// Example usage:
+// heavy := ConcurrencyLimit(4, 16, 5*time.Second)
+// r.GET("/event/search", heavy, SearchEvents(svc))
+func ConcurrencyLimit(limit, queue int, wait time.Duration) gin.HandlerFunc {
+	running := make(chan struct{}, limit)
+	waiting := make(chan struct{}, limit+queue)
+	retryAfter := strconv.Itoa(int((wait + time.Second - 1) / time.Second))
+
+	reject := func(c *gin.Context) {
+		c.Header("Retry-After", retryAfter)
+		abortWithError(c, apperrors.Unavailable("The server is busy, please retry later"))
+	}
+
+	return func(c *gin.Context) {
+		// Take a place in the queue (which includes the running slots)
+		select {
+		case waiting <- struct{}{}:
+		default:
+			reject(c)
+			return
+		}
+		defer func() { <-waiting }()
+
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case running <- struct{}{}:
+		case <-timer.C:
+			reject(c)
+			return
+		case <-c.Request.Context().Done():
+			c.Abort()
+			return
+		}
+		defer func() { <-running }()
+
+		c.Next()
+	}
+}
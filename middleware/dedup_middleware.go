@@ -0,0 +1,142 @@
+// dedup_middleware.go
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"sync"
+	"time"
+
+	"los-complejos-backend/clock"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeduplicatedHeader marks a response replayed from an identical earlier request.
+const DeduplicatedHeader = "X-Deduplicated"
+
+// dedupEntry is the outcome of the first request of a fingerprint.
+type dedupEntry struct {
+	done    chan struct{} // Closed once the outcome is recorded
+	expires time.Time
+
+	status      int
+	contentType string
+	body        []byte
+	err         error // Error left for the error middleware, replayed as is
+}
+
+// capturingWriter copies everything written to the client.
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Deduplicate collapses identical requests from the same user arriving within window.
+//
+// Requests share a fingerprint when they have the same user, method, path and body. The first one is
+// executed; duplicates wait for it and receive the same response (marked with X-Deduplicated) instead of
+// executing again, so a double tap on "subscribe" does not produce a confusing 409. It must run after
+// AuthMiddleware.
+//
+// Example usage:
+// r.PUT("/event/:id/subscribe", AuthMiddleware(clk, accounts), Deduplicate(clk, 2*time.Second), SubscribeEvent(svc))
+func Deduplicate(clk clock.Clock, window time.Duration) gin.HandlerFunc {
+	var (
+		mu        sync.Mutex
+		entries   = map[string]*dedupEntry{}
+		lastSweep time.Time
+	)
+
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Next()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(body)
+		key := c.GetString("username") + " " + c.Request.Method + " " + c.Request.URL.Path + " " + hex.EncodeToString(sum[:])
+
+		now := clk.Now()
+		mu.Lock()
+		if now.Sub(lastSweep) > window {
+			for k, e := range entries {
+				if isClosed(e.done) && now.After(e.expires) {
+					delete(entries, k)
+				}
+			}
+			lastSweep = now
+		}
+		entry, duplicate := entries[key]
+		if duplicate && isClosed(entry.done) && now.After(entry.expires) {
+			duplicate = false
+		}
+		if !duplicate {
+			entry = &dedupEntry{done: make(chan struct{})}
+			entries[key] = entry
+		}
+		mu.Unlock()
+
+		if duplicate {
+			select {
+			case <-entry.done:
+			case <-c.Request.Context().Done():
+				c.Abort()
+				return
+			}
+
+			c.Header(DeduplicatedHeader, "true")
+			if entry.err != nil {
+				abortWithError(c, entry.err)
+				return
+			}
+			c.Data(entry.status, entry.contentType, entry.body)
+			c.Abort()
+			return
+		}
+
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer func() {
+			c.Writer = writer.ResponseWriter
+
+			entry.status = writer.Status()
+			entry.contentType = writer.Header().Get("Content-Type")
+			entry.body = writer.body.Bytes()
+			if !writer.Written() && len(c.Errors) > 0 {
+				entry.err = c.Errors.Last().Err
+			}
+
+			mu.Lock()
+			entry.expires = clk.Now().Add(window)
+			close(entry.done)
+			mu.Unlock()
+		}()
+
+		c.Next()
+	}
+}
+
+// isClosed reports whether the channel is closed.
+func isClosed(done chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,57 @@
+// deprecation_middleware.go
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"los-complejos-backend/changelog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// deprecation holds the headers answered by a deprecated route.
+type deprecation struct {
+	deprecation string // Deprecation header: when the route was deprecated (RFC 9745)
+	sunset      string // Sunset header: when the route may be removed (RFC 8594), empty when not planned
+}
+
+// Deprecation answers the requests of the routes deprecated by the changelog entries with the Deprecation
+// header, the Sunset header when the entry has one, and a Link to the changelog (at link). Entries deprecating
+// a field only are left to the changelog. It panics when a date of the entries is invalid, which is a
+// programming error.
+//
+// Example usage:
+// r.Use(Deprecation(changelog.Entries, "/api/changelog"))
+func Deprecation(entries []changelog.Entry, link string) gin.HandlerFunc {
+	routes := map[string]deprecation{}
+	for _, entry := range entries {
+		if entry.Kind != changelog.Deprecated || !entry.Route() {
+			continue
+		}
+		day, err := changelog.Day(entry.Date)
+		if err != nil {
+			panic(err)
+		}
+		headers := deprecation{deprecation: "@" + strconv.FormatInt(day.Unix(), 10)}
+		if entry.Sunset != "" {
+			sunset, err := changelog.Day(entry.Sunset)
+			if err != nil {
+				panic(err)
+			}
+			headers.sunset = sunset.Format(http.TimeFormat)
+		}
+		routes[entry.Method+" "+entry.Path] = headers
+	}
+
+	return func(c *gin.Context) {
+		if headers, ok := routes[c.Request.Method+" "+c.FullPath()]; ok {
+			c.Header("Deprecation", headers.deprecation)
+			if headers.sunset != "" {
+				c.Header("Sunset", headers.sunset)
+			}
+			c.Header("Link", "<"+link+`>; rel="deprecation"; type="application/json"`)
+		}
+		c.Next()
+	}
+}
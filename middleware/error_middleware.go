@@ -0,0 +1,33 @@
+// error_middleware.go
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/responses"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorMiddleware converts the last error attached with c.Error into a consistent JSON error response.
+// Handlers report failures with c.Error(err) and return; typed errors (apperrors.Error) keep their
+// status and code, every other error becomes a 500 whose cause is logged but not exposed.
+func ErrorMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		appErr := apperrors.From(err)
+		if appErr.Status >= http.StatusInternalServerError {
+			logger.Error("request failed", "method", c.Request.Method, "path", c.FullPath(), "request_id", c.GetString(RequestIDKey), "error", err)
+		}
+
+		responses.Error(c, appErr.Status, appErr)
+	}
+}
@@ -0,0 +1,66 @@
+// journal_middleware.go
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/journal"
+	"los-complejos-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JournalRecorder stores the envelope of a failed request.
+type JournalRecorder interface {
+	Record(ctx context.Context, entry *models.JournalEntry) error
+}
+
+// JournalMiddleware records an anonymized envelope of every request that fails with a 5xx status:
+// method, route, path, the schema of the body (never its values), the caller's role and the outcome.
+// Bodies that are not JSON or are larger than JournalBodyLimit are journaled as "opaque".
+// It must be registered before ErrorMiddleware so it sees the rendered status.
+//
+// Example usage:
+// r.Use(JournalMiddleware(journalService, logger), ErrorMiddleware(logger))
+func JournalMiddleware(recorder JournalRecorder, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Only JSON bodies have a schema; the first JournalBodyLimit bytes are read, the rest is streamed
+		// untouched to the handler, which enforces its own size limit
+		var body []byte
+		whole := c.ContentType() == "application/json"
+		if whole {
+			body, whole, _ = peekBody(c.Request, JournalBodyLimit)
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError {
+			return
+		}
+
+		schema, hash := journal.Opaque()
+		if whole {
+			schema, hash = journal.Schema(body)
+		}
+		entry := &models.JournalEntry{
+			Method:         c.Request.Method,
+			Route:          c.FullPath(),
+			Path:           c.Request.URL.Path,
+			BodySchema:     schema,
+			BodySchemaHash: hash,
+			Role:           c.GetString("role"),
+			Status:         status,
+		}
+		if len(c.Errors) > 0 {
+			entry.Error = apperrors.From(c.Errors.Last().Err).Code
+		}
+
+		if err := recorder.Record(context.WithoutCancel(c.Request.Context()), entry); err != nil {
+			logger.Error("failed to journal request", "method", entry.Method, "path", entry.Path, "error", err)
+		}
+	}
+}
@@ -2,55 +2,99 @@
 package middleware
 
 import (
-	"net/http"
+	"context"
+	"strings"
+	"time"
 
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
 	"los-complejos-backend/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// AuthMiddleware validates the JWT and extracts the user's role, username, and ID
-func AuthMiddleware() gin.HandlerFunc {
+// ServiceTokenRotateHeader is the response header telling an integration that its service token is due for
+// rotation, with the time it stops working (RFC 3339).
+const ServiceTokenRotateHeader = "X-Service-Token-Rotate"
+
+// ServiceAuthenticator authenticates the service tokens of the integrations.
+type ServiceAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*models.ServiceIdentity, error)
+}
+
+// abortWithError stops the handler chain and hands the error to the error middleware.
+func abortWithError(c *gin.Context, err error) {
+	c.Error(err)
+	c.Abort()
+}
+
+// parseToken validates the signed JWT against the injected clock and returns its claims.
+func parseToken(tokenString string, clk clock.Clock) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		// Ensure the token uses the correct signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return utils.JWTSecret, nil
+	}, jwt.WithTimeFunc(clk.Now))
+
+	// Handle parsing or validation errors
+	if err != nil || !token.Valid {
+		return nil, apperrors.Unauthorized("Invalid or expired token")
+	}
+
+	// Extract claims
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, apperrors.Unauthorized("Invalid token claims")
+	}
+	return claims, nil
+}
+
+// setServiceIdentity stores the ID, name and role of the service account like those of a user, with the ID of
+// its token, and asks for a rotation once the token is due.
+func setServiceIdentity(c *gin.Context, identity *models.ServiceIdentity, clk clock.Clock) {
+	utils.SetContextValues(c, map[string]interface{}{
+		"_id":           identity.AccountID,
+		"username":      identity.Name,
+		"role":          identity.Role,
+		"service_token": identity.TokenID,
+	})
+	if !clk.Now().Before(identity.RotateAt) {
+		c.Header(ServiceTokenRotateHeader, identity.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+}
+
+// AuthMiddleware validates the JWT and extracts the user's role, username, and ID.
+// Time-based claims (such as "exp") are checked against the injected clock. Service tokens (starting with
+// models.ServiceTokenPrefix) are checked by the authenticator and identify their service account instead.
+func AuthMiddleware(clk clock.Clock, accounts ServiceAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the token from the Authorization header
 		tokenString := c.GetHeader("Authorization")
 		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  "error",
-				"message": "Authorization token is required",
-			})
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized("Authorization token is required"))
 			return
 		}
 
-		// Parse and validate the token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Ensure the token uses the correct signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
+		// Integrations authenticate with the service token of their account
+		if strings.HasPrefix(tokenString, models.ServiceTokenPrefix) {
+			identity, err := accounts.Authenticate(c, tokenString)
+			if err != nil {
+				abortWithError(c, err)
+				return
 			}
-			return utils.JWTSecret, nil
-		})
-
-		// Handle parsing or validation errors
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  "error",
-				"message": "Invalid or expired token",
-			})
-			c.Abort()
+			setServiceIdentity(c, identity, clk)
+			c.Next()
 			return
 		}
 
-		// Extract claims
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  "error",
-				"message": "Invalid token claims",
-			})
-			c.Abort()
+		// Parse and validate the token
+		claims, err := parseToken(tokenString, clk)
+		if err != nil {
+			abortWithError(c, err)
 			return
 		}
 
@@ -60,29 +104,17 @@ func AuthMiddleware() gin.HandlerFunc {
 		id, idOk := claims["_id"].(string)
 
 		if !roleOk || role == "" {
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"message": "Role is missing or invalid in the token",
-			})
-			c.Abort()
+			abortWithError(c, apperrors.Forbidden("Role is missing or invalid in the token"))
 			return
 		}
 
 		if !usernameOk || username == "" {
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"message": "Username is missing or invalid in the token",
-			})
-			c.Abort()
+			abortWithError(c, apperrors.Forbidden("Username is missing or invalid in the token"))
 			return
 		}
 
 		if !idOk || id == "" {
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"message": "User ID is missing or invalid in the token",
-			})
-			c.Abort()
+			abortWithError(c, apperrors.Forbidden("User ID is missing or invalid in the token"))
 			return
 		}
 
@@ -97,3 +129,28 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware stores the user's role, username, and ID like AuthMiddleware when the request
+// carries a valid token, and lets anonymous requests (or requests with an invalid token) through unchanged.
+func OptionalAuthMiddleware(clk clock.Clock, accounts ServiceAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := c.GetHeader("Authorization")
+		if strings.HasPrefix(tokenString, models.ServiceTokenPrefix) {
+			if identity, err := accounts.Authenticate(c, tokenString); err == nil {
+				setServiceIdentity(c, identity, clk)
+			}
+			c.Next()
+			return
+		}
+		if claims, err := parseToken(tokenString, clk); err == nil {
+			values := map[string]interface{}{}
+			for _, key := range []string{"_id", "username", "role"} {
+				if value, ok := claims[key].(string); ok && value != "" {
+					values[key] = value
+				}
+			}
+			utils.SetContextValues(c, values)
+		}
+		c.Next()
+	}
+}
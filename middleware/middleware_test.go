@@ -0,0 +1,70 @@
+// middleware_test.go
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/utils"
+)
+
+func TestParseTokenExpiresWithTheClock(t *testing.T) {
+	utils.JWTSecret = []byte("test-secret")
+	utils.TokenLifetime = 24 * time.Hour
+	defer func() { utils.TokenLifetime = 0 }()
+	issued := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(issued)
+
+	token, err := utils.GenerateToken("complejo-1", "user", "maria", clk.Now())
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	clk.Advance(utils.TokenLifetime - time.Minute)
+	claims, err := parseToken(token, clk)
+	if err != nil {
+		t.Fatalf("parseToken before expiry: %v", err)
+	}
+	if claims["_id"] != "complejo-1" || claims["role"] != "user" || claims["username"] != "maria" {
+		t.Errorf("claims = %v, want the ID, role and username of the user", claims)
+	}
+
+	clk.Advance(2 * time.Minute)
+	if _, err := parseToken(token, clk); err == nil {
+		t.Fatal("parseToken after expiry: want an error, got none")
+	}
+}
+
+func TestParseTokenWithoutLifetimeNeverExpires(t *testing.T) {
+	utils.JWTSecret = []byte("test-secret")
+	clk := clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+
+	token, err := utils.GenerateToken("complejo-1", "user", "maria", clk.Now())
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	clk.Advance(5 * 365 * 24 * time.Hour)
+	claims, err := parseToken(token, clk)
+	if err != nil {
+		t.Fatalf("parseToken years later: %v", err)
+	}
+	if _, ok := claims["exp"]; ok {
+		t.Errorf("claims = %v, want no exp without a TokenLifetime", claims)
+	}
+}
+
+func TestParseTokenRejectsOtherSecrets(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	utils.JWTSecret = []byte("other-secret")
+	token, err := utils.GenerateToken("complejo-1", "admin", "maria", clk.Now())
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	utils.JWTSecret = []byte("test-secret")
+	if _, err := parseToken(token, clk); err == nil {
+		t.Fatal("parseToken: want an error for a token signed with another secret, got none")
+	}
+}
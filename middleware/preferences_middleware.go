@@ -0,0 +1,66 @@
+// preferences_middleware.go
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/locale"
+	"los-complejos-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnitsHeader is the request header that selects the unit system ("metric" or "imperial").
+const UnitsHeader = "X-Units"
+
+// ProfileFinder returns the profile of an authenticated user.
+type ProfileFinder interface {
+	Get(ctx context.Context, id string) (*models.Complejo, error)
+}
+
+// PreferencesMiddleware resolves the locale and unit system of the request once and stores them
+// in the context (read them with locale.FromContext).
+//
+// The locale comes from the Accept-Language header and the units from the X-Units header.
+// When a header is missing or unsupported and the request carries a valid token, the caller's
+// profile is used; otherwise the defaults apply. The resolved locale is echoed in Content-Language.
+//
+// Example usage:
+// r.Use(middleware.PreferencesMiddleware(clk, complejoService))
+func PreferencesMiddleware(clk clock.Clock, profiles ProfileFinder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		prefs := locale.Default()
+
+		lang, langOk := locale.Negotiate(c.GetHeader("Accept-Language"))
+		if langOk {
+			prefs.Locale = lang
+		}
+		units := strings.ToLower(strings.TrimSpace(c.GetHeader(UnitsHeader)))
+		unitsOk := locale.IsUnitSystem(units)
+		if unitsOk {
+			prefs.Units = units
+		}
+
+		// Fall back to the profile of the authenticated caller, if any
+		if !langOk || !unitsOk {
+			if claims, err := parseToken(c.GetHeader("Authorization"), clk); err == nil {
+				if id, _ := claims["_id"].(string); id != "" {
+					if profile, err := profiles.Get(c, id); err == nil {
+						if !langOk && locale.IsLocale(profile.Locale) {
+							prefs.Locale = profile.Locale
+						}
+						if !unitsOk && locale.IsUnitSystem(profile.Units) {
+							prefs.Units = profile.Units
+						}
+					}
+				}
+			}
+		}
+
+		c.Set(locale.ContextKey, prefs)
+		c.Header("Content-Language", prefs.Locale)
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+// profile_cache_middleware.go
+package middleware
+
+import (
+	"los-complejos-backend/profilecache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProfileCacheMiddleware gives every request its own profilecache.Scope, so the profiles it reads (the caller's
+// preferences, then its privacy settings in the handler) are read from the database at most once.
+//
+// Example usage:
+// r.Use(middleware.ProfileCacheMiddleware())
+func ProfileCacheMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(profilecache.ScopeKey, profilecache.NewScope())
+		c.Next()
+	}
+}
@@ -0,0 +1,36 @@
+// request_id_middleware.go
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the ID of a request, in the request when a proxy already assigned one and in the response.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the key of the ID of the request in the gin.Context.
+const RequestIDKey = "request_id"
+
+// validRequestID matches the request IDs assigned upstream that are kept.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDMiddleware identifies every request, so a client reporting a failure can be matched with the logs.
+// The ID sent by a proxy in the X-Request-ID header is kept when it is a short token, otherwise a new UUID is
+// assigned; it is returned in the X-Request-ID header of the response.
+//
+// Example usage:
+// r.Use(middleware.RequestIDMiddleware())
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+		c.Set(RequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
@@ -0,0 +1,189 @@
+// shadow_middleware.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"los-complejos-backend/outbox"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Shadow traffic modes.
+const (
+	ShadowOff    = ""       // Shadow traffic disabled
+	ShadowMirror = "mirror" // Send a copy of mutating requests to a secondary deployment
+	ShadowLog    = "log"    // Log mutating requests in full so they can be replayed
+)
+
+// ShadowHeader marks requests mirrored to the secondary deployment (see ShadowReceiver).
+const ShadowHeader = "X-Shadow-Request"
+
+// redactedHeaders are never logged in clear (they are still mirrored so the shadow can authenticate).
+var redactedHeaders = map[string]bool{"Authorization": true, APIKeyHeader: true, "Cookie": true}
+
+// redactedFields are the words marking the fields of a JSON body that are never logged in clear: any field whose
+// name contains one of them (e.g. "password", "new_password", "refresh_token", "client_secret", "code").
+var redactedFields = []string{"password", "token", "code", "secret"}
+
+// ShadowTraffic copies mutating requests (POST, PUT, PATCH, DELETE) for soft-launch validation.
+//
+// In "mirror" mode, each request is sent once more, after it has been served, to the deployment at target
+// (e.g. one running the PostgreSQL backend); the mirror's response is discarded and only a status code
+// that differs from the primary one is logged. Mirroring is best effort: when too many copies are in
+// flight, new ones are dropped. In "log" mode, the request (method, path, headers without secrets, body,
+// caller and outcome) is logged in full for later replay, with the values of the secret fields of JSON bodies
+// (see redactedFields) redacted and other bodies not logged at all. Requests whose body is larger than ShadowBodyLimit
+// are served without being copied. Any other mode disables the middleware.
+//
+// Example usage:
+// r.Use(ShadowTraffic(ShadowMirror, "http://shadow:8080", logger))
+func ShadowTraffic(mode, target string, logger *slog.Logger) gin.HandlerFunc {
+	if mode != ShadowMirror && mode != ShadowLog {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	inFlight := make(chan struct{}, 32)
+	target = strings.TrimSuffix(target, "/")
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+
+		// Bodies larger than ShadowBodyLimit, such as uploads, are streamed to the handler and not copied
+		body, whole, err := peekBody(c.Request, ShadowBodyLimit)
+		if err != nil || !whole {
+			c.Next()
+			return
+		}
+
+		method, uri, header := c.Request.Method, c.Request.URL.RequestURI(), c.Request.Header.Clone()
+
+		c.Next()
+		status := c.Writer.Status()
+
+		if mode == ShadowLog {
+			logged := make(map[string]string, len(header))
+			for name := range header {
+				if redactedHeaders[name] {
+					logged[name] = "[redacted]"
+				} else {
+					logged[name] = header.Get(name)
+				}
+			}
+			logger.Info("shadow request",
+				"method", method, "uri", uri, "headers", logged, "body", redactBody(body),
+				"user", c.GetString("_id"), "role", c.GetString("role"), "status", status)
+			return
+		}
+
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			logger.Warn("shadow request dropped", "method", method, "uri", uri)
+			return
+		}
+
+		go func() {
+			defer func() { <-inFlight }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), client.Timeout)
+			defer cancel()
+
+			req, err := http.NewRequestWithContext(ctx, method, target+uri, bytes.NewReader(body))
+			if err != nil {
+				logger.Warn("shadow request failed", "method", method, "uri", uri, "error", err)
+				return
+			}
+			req.Header = header
+			req.Header.Set(ShadowHeader, "true")
+
+			resp, err := client.Do(req)
+			if err != nil {
+				logger.Warn("shadow request failed", "method", method, "uri", uri, "error", err)
+				return
+			}
+			defer resp.Body.Close()
+			io.Copy(io.Discard, resp.Body)
+
+			if resp.StatusCode != status {
+				logger.Warn("shadow response mismatch", "method", method, "uri", uri, "status", status, "shadow_status", resp.StatusCode)
+			}
+		}()
+	}
+}
+
+// ShadowReceiver marks the requests carrying ShadowHeader on the deployment receiving the mirrored traffic, so
+// their changes are stored without enqueuing outbox messages (see outbox.ShadowRepository): the primary deployment
+// already sent the notifications and webhooks. The header is ignored unless enabled, so clients of the primary
+// deployment cannot suppress the deliveries of their own changes.
+//
+// Example usage:
+// r.Use(ShadowReceiver(cfg.ShadowReceiver))
+func ShadowReceiver(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if enabled && c.GetHeader(ShadowHeader) == "true" {
+			c.Set(outbox.ShadowKey, true)
+		}
+		c.Next()
+	}
+}
+
+// redactBody returns the JSON body with the values of its secret fields replaced by "[redacted]", at any depth.
+// A body that is not JSON may hold secrets that cannot be found (e.g. a form), so only its size is returned.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return fmt.Sprintf("[%d bytes, not JSON]", len(body))
+	}
+	encoded, _ := json.Marshal(redactValue(value))
+	return string(encoded)
+}
+
+// redactValue replaces the values of the secret fields of a decoded JSON document.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if secretField(key) {
+				v[key] = "[redacted]"
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+// secretField reports whether the JSON field name designates a secret.
+func secretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range redactedFields {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
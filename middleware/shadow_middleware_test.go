@@ -0,0 +1,121 @@
+// shadow_middleware_test.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/outbox"
+	"los-complejos-backend/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty", ``, ``},
+		{"no secret", `{"username":"maria","age":31}`, `{"age":31,"username":"maria"}`},
+		{"password", `{"username":"maria","password":"hunter2"}`, `{"password":"[redacted]","username":"maria"}`},
+		{"case and compound names", `{"New_Password":"a","refreshToken":"b","client_secret":"c","code":"123456"}`,
+			`{"New_Password":"[redacted]","client_secret":"[redacted]","code":"[redacted]","refreshToken":"[redacted]"}`},
+		{"nested", `{"items":[{"token":"t","id":1}],"account":{"secret":{"key":"k"}}}`,
+			`{"account":{"secret":"[redacted]"},"items":[{"id":1,"token":"[redacted]"}]}`},
+		{"not json", `username=maria&password=hunter2`, `[31 bytes, not JSON]`},
+		{"trailing data", `{"a":1} {"password":"x"}`, `[24 bytes, not JSON]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactBody([]byte(tt.body)); got != tt.want {
+				t.Errorf("redactBody(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShadowLogRedactsSecrets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	r := gin.New()
+	r.Use(ShadowTraffic(ShadowLog, "", slog.New(slog.NewTextHandler(&logs, nil))))
+	r.POST("/complejo", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	req := httptest.NewRequest(http.MethodPost, "/complejo", strings.NewReader(`{"username":"maria","password":"hunter2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret-token")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	logged := logs.String()
+	if !strings.Contains(logged, "shadow request") || !strings.Contains(logged, "maria") {
+		t.Fatalf("log = %q, want the shadow request with its body", logged)
+	}
+	for _, secret := range []string{"hunter2", "secret-token"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log = %q, want %q redacted", logged, secret)
+		}
+	}
+}
+
+// countingOutbox counts the messages enqueued through it.
+type countingOutbox struct {
+	repository.OutboxRepository
+	enqueued int
+}
+
+func (o *countingOutbox) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	o.enqueued++
+	return nil
+}
+
+func TestShadowReceiverSkipsTheOutbox(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		enabled bool
+		header  string
+		want    int
+	}{
+		{"mirrored request", true, "true", 0},
+		{"regular request", true, "", 1},
+		{"header ignored when not receiving", false, "true", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &countingOutbox{}
+			repo := outbox.NewShadowRepository(store)
+			r := gin.New()
+			r.Use(ShadowReceiver(tt.enabled))
+			r.POST("/event", func(c *gin.Context) {
+				message, _ := outbox.NewMessage(outbox.TopicEventCreated, map[string]string{"id": "event-1"}, time.Now())
+				if err := repo.Enqueue(c, message); err != nil {
+					t.Errorf("Enqueue: %v", err)
+				}
+				c.Status(http.StatusCreated)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/event", strings.NewReader(`{}`))
+			if tt.header != "" {
+				req.Header.Set(ShadowHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("status = %d, want %d: the write is served either way", w.Code, http.StatusCreated)
+			}
+			if store.enqueued != tt.want {
+				t.Errorf("enqueued = %d, want %d", store.enqueued, tt.want)
+			}
+		})
+	}
+}
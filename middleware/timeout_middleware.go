@@ -0,0 +1,63 @@
+// timeout_middleware.go
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"los-complejos-backend/apperrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutOptions sets how long the requests of each route may take; a zero duration leaves them unbounded.
+type TimeoutOptions struct {
+	Read   time.Duration            // GET and HEAD requests
+	Write  time.Duration            // Requests of the other methods
+	Routes map[string]time.Duration // Routes with their own timeout, keyed by method and path as registered (e.g. "GET /admin/export")
+}
+
+// timeoutDetails are the details of the 504 responses.
+type timeoutDetails struct {
+	RequestID string `json:"request_id"` // ID of the request, to find it in the logs
+}
+
+// Timeout bounds the time the handlers of every request may take, by route. At the deadline the context of the
+// request is cancelled, so the database calls it is waiting on are aborted; when the handler has not answered
+// yet, the request gets a 504 Gateway Timeout carrying its ID (see RequestIDMiddleware).
+//
+// Handlers must pass the request context (the gin.Context, with gin's ContextWithFallback) to the calls they make.
+//
+// Example usage:
+// r.Use(Timeout(TimeoutOptions{Read: 10 * time.Second, Write: 30 * time.Second}))
+func Timeout(opts TimeoutOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := opts.Routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = opts.Write
+			if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+				timeout = opts.Read
+			}
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) || c.Writer.Written() {
+			return
+		}
+		// The error middleware answers with the last error, whatever the handler reported on its way out
+		c.Error(apperrors.GatewayTimeout("The request took too long and was cancelled").
+			WithDetails(timeoutDetails{RequestID: c.GetString(RequestIDKey)}))
+		c.Abort()
+	}
+}
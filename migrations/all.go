@@ -0,0 +1,315 @@
+// all.go
+package migrations
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/outbox"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// All lists every migration in version order. Append new migrations at the end and never edit applied ones.
+var All = []Migration{
+	{
+		Version:     "0001",
+		Description: "store an empty participants list on events created without one",
+		Up:          eventParticipantsArray,
+	},
+	{
+		Version:     "0002",
+		Description: "backfill the sign-up time of Complejos from their registration in the outbox",
+		Up:          complejoCreatedAt,
+	},
+	{
+		Version:     "0003",
+		Description: "convert the weight, height and lifts of Complejos from strings to numbers",
+		Up:          complejoNumericFields,
+	},
+	{
+		Version:     "0004",
+		Description: "replace the participants of events with \"going\" RSVPs of the matching Complejos",
+		Up:          eventRSVPs,
+	},
+	{
+		Version:     "0005",
+		Description: "record the content of the announcements published before versioning as their first version",
+		Up:          announcementVersions,
+	},
+	{
+		Version:     "0006",
+		Description: "move the base64-encoded profile photos of Complejos to the profile_photos GridFS bucket",
+		Up:          complejoPhotoFiles,
+	},
+	{
+		Version:     "0007",
+		Description: "turn the IMC of Complejos from its category into a subdocument with its value and category",
+		Up:          complejoIMC,
+	},
+	{
+		Version:     "0008",
+		Description: "record the lifts logged before repetitions as singles, their weight being their one-rep max",
+		Up:          liftEntryReps,
+	},
+}
+
+// eventParticipantsArray replaces missing or null participants with an empty list,
+// which $addToSet and $pull require.
+func eventParticipantsArray(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("event").UpdateMany(ctx,
+		bson.M{"participants": nil},
+		bson.M{"$set": bson.M{"participants": bson.A{}}})
+	return err
+}
+
+// complejoCreatedAt sets the created_at of Complejos without one to the time of their
+// complejo.registered outbox message. Complejos registered before the outbox existed keep no sign-up time.
+func complejoCreatedAt(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("outbox").Find(ctx, bson.M{"topic": outbox.TopicComplejoRegistered})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	complejos := db.Collection("complejo")
+	for cursor.Next(ctx) {
+		var message models.OutboxMessage
+		if err := cursor.Decode(&message); err != nil {
+			return err
+		}
+		var payload struct {
+			ID string `json:"_id"`
+		}
+		if err := json.Unmarshal(message.Payload, &payload); err != nil || payload.ID == "" {
+			continue
+		}
+
+		_, err := complejos.UpdateOne(ctx,
+			bson.M{"_id": payload.ID, "created_at": nil},
+			bson.M{"$set": bson.M{"created_at": message.CreatedAt}})
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// complejoNumericFields converts the weight, height and lift fields stored as strings to doubles.
+// Empty or unparsable values (a decimal comma is accepted) become 0, meaning unknown; the IMC is left as is.
+func complejoNumericFields(ctx context.Context, db *mongo.Database) error {
+	complejos := db.Collection("complejo")
+	for _, field := range []string{"weight", "height", "bench", "squad", "dl"} {
+		_, err := complejos.UpdateMany(ctx,
+			bson.M{field: bson.M{"$type": "string"}},
+			mongo.Pipeline{{{Key: "$set", Value: bson.M{field: bson.M{"$convert": bson.M{
+				"input": bson.M{"$replaceAll": bson.M{
+					"input":       bson.M{"$trim": bson.M{"input": "$" + field}},
+					"find":        ",",
+					"replacement": ".",
+				}},
+				"to":      "double",
+				"onError": 0.0,
+				"onNull":  0.0,
+			}}}}}})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventRSVPs turns the participants (usernames) of every event into "going" RSVPs of the Complejos with those
+// usernames, answered at the time of the migration, and drops the participants field and its index.
+// Usernames no Complejo uses anymore are dropped.
+func eventRSVPs(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("complejo").Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"username": 1}))
+	if err != nil {
+		return err
+	}
+	var complejos []struct {
+		ID       string `bson:"_id"`
+		Username string `bson:"username"`
+	}
+	if err := cursor.All(ctx, &complejos); err != nil {
+		return err
+	}
+	ids := make(map[string]string, len(complejos))
+	for _, complejo := range complejos {
+		ids[complejo.Username] = complejo.ID
+	}
+
+	events := db.Collection("event")
+	cursor, err = events.Find(ctx, bson.M{"participants": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"participants": 1, "rsvps": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	now := time.Now().UTC()
+	for cursor.Next(ctx) {
+		var event struct {
+			ID           string        `bson:"_id"`
+			Participants []string      `bson:"participants"`
+			RSVPs        []models.RSVP `bson:"rsvps"`
+		}
+		if err := cursor.Decode(&event); err != nil {
+			return err
+		}
+
+		rsvps := append([]models.RSVP{}, event.RSVPs...)
+		answered := map[string]bool{}
+		for _, rsvp := range rsvps {
+			answered[rsvp.ComplejoID] = true
+		}
+		for _, username := range event.Participants {
+			id, ok := ids[username]
+			if !ok || answered[id] {
+				continue
+			}
+			answered[id] = true
+			rsvps = append(rsvps, models.RSVP{ComplejoID: id, Username: username, Status: models.RSVPGoing, RespondedAt: now})
+		}
+
+		_, err := events.UpdateOne(ctx, bson.M{"_id": event.ID}, bson.M{
+			"$set":   bson.M{"rsvps": rsvps},
+			"$unset": bson.M{"participants": ""},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	_, err = events.Indexes().DropOne(ctx, "event_participants")
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && (commandErr.Name == "IndexNotFound" || commandErr.Name == "NamespaceNotFound") {
+		return nil
+	}
+	return err
+}
+
+// announcementVersions sets the version of the Announcements without one to 1 and records their content as
+// that version. Running it again skips the versions already recorded.
+func announcementVersions(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("announcements").Find(ctx, bson.M{"version": bson.M{"$in": bson.A{nil, 0}}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	announcements := db.Collection("announcements")
+	versions := db.Collection("content_versions")
+	for cursor.Next(ctx) {
+		var announcement models.Announcement
+		if err := cursor.Decode(&announcement); err != nil {
+			return err
+		}
+
+		_, err := versions.InsertOne(ctx, models.ContentVersion{
+			ID:           "announcement-" + announcement.ID,
+			DocumentType: models.ContentAnnouncement,
+			DocumentID:   announcement.ID,
+			Version:      1,
+			Title:        announcement.Title,
+			Body:         announcement.Body,
+			CreatedBy:    announcement.CreatedBy,
+			CreatedAt:    announcement.CreatedAt,
+		})
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		if _, err := announcements.UpdateOne(ctx, bson.M{"_id": announcement.ID}, bson.M{"$set": bson.M{"version": 1}}); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// complejoPhotoFiles stores the base64-encoded photo of each Complejo as a file of the profile_photos GridFS
+// bucket (the photo store of app.New), keeps its ID as photo_id and removes the photo from the document.
+// Photos that are not valid base64 are left in place, still served by `?include=photo`. Running it again skips
+// the Complejos already moved; a run interrupted between the upload and the update leaves at worst an orphan file.
+func complejoPhotoFiles(ctx context.Context, db *mongo.Database) error {
+	complejos := db.Collection("complejo")
+	cursor, err := complejos.Find(ctx, bson.M{"photo": bson.M{"$nin": bson.A{nil, ""}}},
+		options.Find().SetProjection(bson.M{"photo": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	photos := objectstore.NewGridFS(db, "profile_photos")
+	for cursor.Next(ctx) {
+		var complejo struct {
+			ID    string `bson:"_id"`
+			Photo string `bson:"photo"`
+		}
+		if err := cursor.Decode(&complejo); err != nil {
+			return err
+		}
+
+		encoded := complejo.Photo
+		if rest, ok := strings.CutPrefix(encoded, "data:"); ok {
+			_, encoded, _ = strings.Cut(rest, ";base64,")
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(data) == 0 {
+			continue
+		}
+
+		photoID := uuid.NewString()
+		if err := photos.Put(ctx, models.ProfilePhotoKey(photoID), data); err != nil {
+			return err
+		}
+		_, err = complejos.UpdateOne(ctx, bson.M{"_id": complejo.ID}, bson.M{
+			"$set":   bson.M{"photo_id": photoID},
+			"$unset": bson.M{"photo": ""},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// complejoIMC replaces the IMC category stored as a string with the subdocument of its value, calculated from the
+// weight and height, and the category. It is removed when the weight or height is unknown.
+func complejoIMC(ctx context.Context, db *mongo.Database) error {
+	known := bson.M{"$and": bson.A{
+		bson.M{"$gt": bson.A{"$weight", 0}},
+		bson.M{"$gt": bson.A{"$height", 0}},
+		bson.M{"$not": bson.A{bson.M{"$in": bson.A{"$imc", bson.A{"", "N/A"}}}}},
+	}}
+	value := bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$weight", bson.M{"$multiply": bson.A{"$height", "$height"}}}}, 1}}
+
+	_, err := db.Collection("complejo").UpdateMany(ctx,
+		bson.M{"imc": bson.M{"$type": "string"}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"imc": bson.M{"$cond": bson.A{
+			known,
+			bson.M{"value": value, "category": "$imc"},
+			"$$REMOVE",
+		}}}}}})
+	return err
+}
+
+// liftEntryReps sets the repetitions of the lift entries without any to 1 and their estimated one-rep max to their
+// weight.
+func liftEntryReps(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("lift_entries").UpdateMany(ctx,
+		bson.M{"reps": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"reps": 1, "e1rm": "$weight"}}}})
+	return err
+}
@@ -0,0 +1,97 @@
+// migrations.go
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Collection is the MongoDB collection recording the applied migrations.
+const Collection = "schema_migrations"
+
+// Migration is a versioned change of the MongoDB data (e.g. converting a field to another type).
+//
+// Up must be safe to run again on partially migrated data: it is retried from the start when it fails.
+type Migration struct {
+	Version     string // Sortable identifier, e.g. "0001"
+	Description string // What the migration does
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// record is the document stored in Collection for each applied migration.
+type record struct {
+	Version     string    `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Applied returns the versions of the migrations already applied to db.
+func Applied(ctx context.Context, db *mongo.Database) (map[string]time.Time, error) {
+	cursor, err := db.Collection(Collection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]time.Time, len(records))
+	for _, r := range records {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+// Pending returns the migrations of All not applied to db yet, in version order.
+func Pending(ctx context.Context, db *mongo.Database) ([]Migration, error) {
+	applied, err := Applied(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range All {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Run applies the pending migrations in version order, recording each one once it succeeds,
+// and returns the versions applied by this call. It stops at the first failure.
+//
+// Migrations are not locked against concurrent runs: run them from a single process (`go run ./cmd/migrate`).
+func Run(ctx context.Context, db *mongo.Database, logger *slog.Logger) ([]string, error) {
+	pending, err := Pending(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the applied migrations: %w", err)
+	}
+
+	var applied []string
+	for _, migration := range pending {
+		logger.Info("applying migration", "version", migration.Version, "description", migration.Description)
+		if err := migration.Up(ctx, db); err != nil {
+			return applied, fmt.Errorf("error applying migration %s: %w", migration.Version, err)
+		}
+
+		_, err := db.Collection(Collection).InsertOne(ctx, record{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return applied, fmt.Errorf("error recording migration %s: %w", migration.Version, err)
+		}
+		applied = append(applied, migration.Version)
+	}
+	return applied, nil
+}
@@ -0,0 +1,37 @@
+// analytics_event.go
+package models
+
+import "time"
+
+// Types of analytics events sent by the clients.
+const (
+	AnalyticsScreenView   = "screen_view"
+	AnalyticsFeatureUsage = "feature_usage"
+)
+
+// AnalyticsEvent is a lightweight client event (a screen view or the use of a feature).
+type AnalyticsEvent struct {
+	ID         string            `json:"_id" bson:"_id"`                                                       // Unique identifier (assigned by the server)
+	Type       string            `json:"type" bson:"type" validate:"required,oneof=screen_view feature_usage"` // "screen_view" or "feature_usage" (required)
+	Name       string            `json:"name" bson:"name" validate:"required,max=100"`                         // Screen or feature name (required)
+	Properties map[string]string `json:"properties,omitempty" bson:"properties,omitempty" validate:"max=20"`   // Free-form attributes (at most 20)
+	SessionID  string            `json:"session_id,omitempty" bson:"session_id,omitempty"`                     // Client session, to group events (optional)
+	OccurredAt *time.Time        `json:"occurred_at,omitempty" bson:"occurred_at"`                             // Client time of the event (default: received_at)
+
+	Tenant     string    `json:"tenant" bson:"tenant"`                               // Tenant of the client (assigned by the server)
+	ComplejoID string    `json:"complejo_id,omitempty" bson:"complejo_id,omitempty"` // Authenticated caller, if any (assigned by the server)
+	ReceivedAt time.Time `json:"received_at" bson:"received_at"`                     // When the server accepted the event
+	SampleRate float64   `json:"sample_rate" bson:"sample_rate"`                     // Share of events kept when it was accepted, to scale counts
+}
+
+// AnalyticsBatch is the body of POST /analytics/track.
+type AnalyticsBatch struct {
+	Events []AnalyticsEvent `json:"events" validate:"required,min=1,max=50,dive"` // Between 1 and 50 events
+}
+
+// AnalyticsReceipt reports what happened to a tracked batch.
+type AnalyticsReceipt struct {
+	Accepted int `json:"accepted"` // Events buffered for storage
+	Sampled  int `json:"sampled"`  // Events discarded by sampling
+	Dropped  int `json:"dropped"`  // Events discarded because the tenant is disabled or the buffer is full
+}
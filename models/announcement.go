@@ -0,0 +1,34 @@
+// announcement.go
+package models
+
+import "time"
+
+// Announcement is a message of the admins to every member, such as a change of opening hours, also pushed to
+// their devices. It holds its current version; every version is kept as a ContentVersion.
+type Announcement struct {
+	ID        string     `json:"_id" bson:"_id"`                                   // Unique identifier (assigned by the server)
+	Title     string     `json:"title" bson:"title"`                               // Title, also the title of the push notification
+	Body      string     `json:"body" bson:"body"`                                 // Plain-text message
+	CreatedBy string     `json:"created_by" bson:"created_by"`                     // ID of the admin that published it
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`                     // When it was published
+	Version   int        `json:"version" bson:"version"`                           // Current version, 1 until it is edited
+	UpdatedBy string     `json:"updated_by,omitempty" bson:"updated_by,omitempty"` // ID of the admin that wrote the current version
+	UpdatedAt *time.Time `json:"updated_at,omitempty" bson:"updated_at,omitempty"` // When the current version was written
+}
+
+// AnnouncementInput publishes an Announcement, or a new version of it.
+type AnnouncementInput struct {
+	Title string `json:"title" validate:"required,max=100"`
+	Body  string `json:"body" validate:"required,max=2000"`
+}
+
+// Default and maximum number of Announcements listed.
+const (
+	DefaultAnnouncementLimit = 20
+	MaxAnnouncementLimit     = 100
+)
+
+// AnnouncementQuery is bound from the `?limit=` query string of GET /announcements.
+type AnnouncementQuery struct {
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // How many Announcements to list (default 20, at most 100)
+}
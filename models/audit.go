@@ -0,0 +1,26 @@
+// audit.go
+package models
+
+import "time"
+
+// Actions recorded in the audit log.
+const (
+	AuditComplejoErased = "complejo.erased" // The personal data of a Complejo was erased
+)
+
+// AuditEntry records a sensitive action in the audit log, kept after the data it concerns is gone. It names the
+// Complejos involved by their ID only, never by their personal data.
+type AuditEntry struct {
+	ID         string           `json:"_id" bson:"_id"`                             // Unique identifier (assigned by the server)
+	Action     string           `json:"action" bson:"action"`                       // One of the Audit* constants
+	ActorID    string           `json:"actor_id" bson:"actor_id"`                   // ID of the Complejo that performed the action
+	TargetID   string           `json:"target_id" bson:"target_id"`                 // ID of the resource the action applies to
+	Details    map[string]int64 `json:"details,omitempty" bson:"details,omitempty"` // How many records the action changed, by kind
+	OccurredAt time.Time        `json:"occurred_at" bson:"occurred_at"`             // When the action was performed
+}
+
+// AuditQuery is bound from the `?action=&limit=` query string of GET /admin/audit.
+type AuditQuery struct {
+	Action string `json:"action" form:"action"`                                  // Only the entries of this action (default: every action)
+	Limit  int    `json:"limit" form:"limit" validate:"omitempty,min=1,max=500"` // Maximum number of entries (default: 100, at most 500)
+}
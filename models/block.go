@@ -0,0 +1,50 @@
+// block.go
+package models
+
+import "time"
+
+// Default and maximum page sizes of the blocked Complejos.
+const (
+	DefaultBlockLimit = 20
+	MaxBlockLimit     = 100
+)
+
+// Block records that a Complejo blocked another: the blocked Complejo no longer sees its profile, and neither
+// follows the other.
+type Block struct {
+	ID        string    `json:"-" bson:"_id"`                 // Unique identifier (assigned by the server)
+	BlockerID string    `json:"blocker_id" bson:"blocker_id"` // Complejo that blocks
+	BlockedID string    `json:"blocked_id" bson:"blocked_id"` // Complejo blocked
+	CreatedAt time.Time `json:"created_at" bson:"created_at"` // When it was blocked
+}
+
+// BlockQuery paginates the Complejos a Complejo blocked.
+type BlockQuery struct {
+	Page  int `json:"page" form:"page" validate:"omitempty,min=1"`           // 1-based page number (default: 1)
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // Page size (default: 20, at most 100)
+}
+
+// Normalize fills in the default page and page size.
+func (q *BlockQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultBlockLimit
+	}
+	if q.Limit > MaxBlockLimit {
+		q.Limit = MaxBlockLimit
+	}
+}
+
+// Offset returns the number of Complejos skipped before the requested page.
+func (q BlockQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// BlockEntry is a live Complejo in the list of the Complejos a Complejo blocked.
+type BlockEntry struct {
+	ComplejoID string    `json:"complejo_id" bson:"_id"`       // Complejo blocked
+	Username   string    `json:"username" bson:"username"`     // Its current username
+	BlockedAt  time.Time `json:"blocked_at" bson:"blocked_at"` // When it was blocked
+}
@@ -0,0 +1,120 @@
+// challenge.go
+package models
+
+import "time"
+
+// Metrics a challenge ranks its participants by, counted between its start and its end.
+const (
+	ChallengeEvents        = "events"         // Events attended (answered "going", dated before now)
+	ChallengeWorkouts      = "workouts"       // Workouts logged
+	ChallengeVolume        = "volume"         // Kilos lifted in the workouts logged (repetitions times weight)
+	ChallengeNutritionDays = "nutrition_days" // Days with a nutrition log
+)
+
+// Statuses of a challenge, relative to the current time.
+const (
+	ChallengeUpcoming = "upcoming" // Not started yet
+	ChallengeActive   = "active"   // Started and not ended
+	ChallengeEnded    = "ended"    // Ended: its standings are final once scored after its end
+)
+
+// Default and maximum page sizes of the challenges.
+const (
+	DefaultChallengeLimit = 20
+	MaxChallengeLimit     = 100
+)
+
+// MaxChallengeDays is the longest a challenge may last, in days.
+const MaxChallengeDays = 366
+
+// Challenge is a competition between the Complejos that join it, ranked by a metric between its start and its
+// end. Its standings are scored by a recurring task.
+type Challenge struct {
+	ID          string     `json:"_id" bson:"_id"`                                 // Unique identifier (assigned by the server)
+	Title       string     `json:"title" bson:"title"`                             // Title (e.g. "Most workouts in March")
+	Description string     `json:"description" bson:"description"`                 // Rules or prizes, free text
+	Metric      string     `json:"metric" bson:"metric"`                           // "events", "workouts", "volume" or "nutrition_days"
+	StartsAt    time.Time  `json:"starts_at" bson:"starts_at"`                     // When the metric starts being counted
+	EndsAt      time.Time  `json:"ends_at" bson:"ends_at"`                         // When the metric stops being counted
+	Status      string     `json:"status" bson:"-"`                                // "upcoming", "active" or "ended" (computed when the challenge is read)
+	CreatedBy   string     `json:"created_by" bson:"created_by"`                   // ID of the Complejo that created the challenge (assigned by the server)
+	ScoredAt    *time.Time `json:"scored_at,omitempty" bson:"scored_at,omitempty"` // When the standings were last scored
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`                   // When it was created (assigned by the server)
+}
+
+// StatusAt returns the status of the challenge at the given time.
+func (c Challenge) StatusAt(now time.Time) string {
+	switch {
+	case now.Before(c.StartsAt):
+		return ChallengeUpcoming
+	case now.Before(c.EndsAt):
+		return ChallengeActive
+	default:
+		return ChallengeEnded
+	}
+}
+
+// ChallengeInput is the payload creating a Challenge.
+type ChallengeInput struct {
+	Title       string    `json:"title" validate:"required,max=100"`
+	Description string    `json:"description" validate:"max=2000"`
+	Metric      string    `json:"metric" validate:"required,oneof=events workouts volume nutrition_days"`
+	StartsAt    time.Time `json:"starts_at" validate:"required"`      // May be in the past, to count what was done since
+	EndsAt      time.Time `json:"ends_at" validate:"required,future"` // After the start, at most 366 days later
+}
+
+// ChallengeFilter selects and paginates the challenges.
+// It is bound from the `?status=&page=&limit=` query string of GET /challenges.
+type ChallengeFilter struct {
+	Status string `json:"status" form:"status" validate:"omitempty,oneof=upcoming active ended"` // Only the challenges with this status (default: every status)
+	Page   int    `json:"page" form:"page" validate:"omitempty,min=1"`                           // 1-based page number (default: 1)
+	Limit  int    `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"`                 // Page size (default: 20, at most 100)
+}
+
+// Normalize fills in the default page and page size.
+func (f *ChallengeFilter) Normalize() {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit < 1 {
+		f.Limit = DefaultChallengeLimit
+	}
+	if f.Limit > MaxChallengeLimit {
+		f.Limit = MaxChallengeLimit
+	}
+}
+
+// Offset returns the number of challenges skipped before the requested page.
+func (f ChallengeFilter) Offset() int {
+	return (f.Page - 1) * f.Limit
+}
+
+// ChallengeParticipant is a Complejo that joined a Challenge, with its score as of the last scoring.
+type ChallengeParticipant struct {
+	ID          string    `json:"-" bson:"_id"`                     // Unique identifier (assigned by the server)
+	ChallengeID string    `json:"challenge_id" bson:"challenge_id"` // Challenge joined
+	ComplejoID  string    `json:"complejo_id" bson:"complejo_id"`   // Complejo that joined
+	Score       float64   `json:"score" bson:"score"`               // Value of the metric as of the last scoring
+	JoinedAt    time.Time `json:"joined_at" bson:"joined_at"`       // When it joined
+}
+
+// ChallengeStanding is the place of a participant in the standings of a Challenge. Participants with the same
+// score share their rank.
+type ChallengeStanding struct {
+	Rank       int64     `json:"rank" bson:"rank"`           // 1-based rank
+	ComplejoID string    `json:"complejo_id" bson:"_id"`     // Participant ranked
+	Username   string    `json:"username" bson:"username"`   // Current username of the participant
+	Score      float64   `json:"score" bson:"score"`         // Value of the metric as of the last scoring
+	JoinedAt   time.Time `json:"joined_at" bson:"joined_at"` // When it joined
+}
+
+// ChallengeStandings ranks the participants of a Challenge, with the place of the caller.
+type ChallengeStandings struct {
+	ChallengeID string              `json:"challenge_id"`        // Challenge ranked
+	Metric      string              `json:"metric"`              // Metric ranked by
+	Status      string              `json:"status"`              // "upcoming", "active" or "ended"
+	ScoredAt    *time.Time          `json:"scored_at,omitempty"` // When the scores were last computed (absent before the first scoring)
+	Final       bool                `json:"final"`               // Whether the challenge was scored after its end, so the standings no longer change
+	Entries     []ChallengeStanding `json:"entries"`             // Every participant, best first
+	Me          *ChallengeStanding  `json:"me,omitempty"`        // Place of the authenticated caller, when it joined
+}
@@ -0,0 +1,44 @@
+// churn.go
+package models
+
+import "time"
+
+// Churn risk levels.
+const (
+	ChurnLow    = "low"
+	ChurnMedium = "medium"
+	ChurnHigh   = "high"
+)
+
+// ChurnRisk is the latest churn-risk score of a member, stored on the profile by the scoring job.
+type ChurnRisk struct {
+	Score    int       `json:"score" bson:"score"`         // 0 (engaged) to 100 (very likely to leave)
+	Level    string    `json:"level" bson:"level"`         // "low", "medium" or "high"
+	Reasons  []string  `json:"reasons" bson:"reasons"`     // Why the member got this score
+	Actions  []string  `json:"actions" bson:"actions"`     // Suggested outreach actions
+	ScoredAt time.Time `json:"scored_at" bson:"scored_at"` // When the score was computed
+}
+
+// MemberActivity counts the activity of a member in the previous and the recent scoring windows.
+type MemberActivity struct {
+	ID        string
+	Username  string
+	CreatedAt *time.Time
+
+	PreviousAttendance    int // Past events attended in the previous window
+	RecentAttendance      int // Past events attended in the recent window
+	PreviousSubscriptions int // Subscription transitions in the previous window
+	RecentSubscriptions   int // Subscription transitions in the recent window
+}
+
+// ChurnQuery is bound from the `?level=` query string of GET /admin/analytics/churn-risk.
+type ChurnQuery struct {
+	Level string `json:"level" form:"level" validate:"omitempty,oneof=low medium high"` // Lowest level listed (default: medium)
+}
+
+// ChurnCandidate is a member listed in the churn-risk report.
+type ChurnCandidate struct {
+	ComplejoID string `json:"complejo_id"`
+	Username   string `json:"username"`
+	ChurnRisk
+}
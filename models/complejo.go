@@ -1,18 +1,226 @@
 // complejo.go
 package models
 
+import "time"
+
 // Complejo represents a user in the system with optional fitness-related attributes.
 type Complejo struct {
-	ID       string `json:"_id" bson:"_id" validate:"required"`           // Unique identifier
-	Username string `json:"username" bson:"username" validate:"required"` // User's username (required)
-	Password string `json:"password" bson:"password" validate:"required"` // User's password (required)
-	Role     string `json:"role" bson:"role" validate:"required"`         // Role of the user (e.g., "user" or "admin") (required)
-	Weight   string `json:"weight" bson:"weight"`                         // Weight in kilograms (optional)
-	Height   string `json:"height" bson:"height"`                         // Height in meters (optional)
-	IMC      string `json:"imc" bson:"imc"`                               // Calculated IMC based on weight and height
-	Gender   string `json:"gender" bson:"gender" validate:"required"`     // User's gender (required)
-	Bench    string `json:"bench" bson:"bench"`                           // Bench press weight in kilograms (optional)
-	Squad    string `json:"squad" bson:"squad"`                           // Squat weight in kilograms (optional)
-	DL       string `json:"dl" bson:"dl"`                                 // Deadlift weight in kilograms (optional)
-	Photo    string `json:"photo" bson:"photo"`                           // Base64-encoded profile photo (optional)
+	ID       string  `json:"_id" bson:"_id"`                                                       // Unique identifier (assigned by the server)
+	Username string  `json:"username" bson:"username" validate:"required"`                         // User's username (required)
+	Password string  `json:"password" bson:"password" validate:"required"`                         // User's password (required)
+	Email    string  `json:"email,omitempty" bson:"email,omitempty" validate:"omitempty,email"`    // Address of the notification emails (optional)
+	Role     string  `json:"role" bson:"role" validate:"required,role"`                            // Role of the user ("user" or "admin") (required)
+	Weight   float64 `json:"weight" bson:"weight" validate:"gte=0,lte=500"`                        // Weight in kilograms (optional, 0 when unknown)
+	Height   float64 `json:"height" bson:"height" validate:"gte=0,lte=3"`                          // Height in meters (optional, 0 when unknown)
+	IMC      *IMC    `json:"imc" bson:"imc,omitempty"`                                             // Calculated IMC based on weight and height (null when either is unknown)
+	Gender   string  `json:"gender" bson:"gender" validate:"required,gender"`                      // User's gender ("male", "female" or "other") (required)
+	Bench    float64 `json:"bench" bson:"bench" validate:"gte=0,lte=1000"`                         // Bench press weight in kilograms (optional, 0 when unknown)
+	Squad    float64 `json:"squad" bson:"squad" validate:"gte=0,lte=1000"`                         // Squat weight in kilograms (optional, 0 when unknown)
+	DL       float64 `json:"dl" bson:"dl" validate:"gte=0,lte=1000"`                               // Deadlift weight in kilograms (optional, 0 when unknown)
+	Photo    string  `json:"photo" bson:"photo"`                                                   // Base64-encoded profile photo (optional, moved to the object store on sign-up)
+	PhotoID  string  `json:"-" bson:"photo_id,omitempty"`                                          // ID of the profile photo in the object store (assigned by the server)
+	Locale   string  `json:"locale,omitempty" bson:"locale,omitempty" validate:"omitempty,locale"` // Preferred locale ("en" or "es") (optional)
+	Units    string  `json:"units,omitempty" bson:"units,omitempty" validate:"omitempty,units"`    // Preferred unit system ("metric" or "imperial") (optional)
+
+	PhotoConsent string `json:"photo_consent,omitempty" bson:"photo_consent,omitempty" validate:"omitempty,oneof=allow ask deny"` // Whether it may be tagged in album photos ("allow", "ask" or "deny") (optional, "ask" when unset)
+	Goal         string `json:"goal,omitempty" bson:"goal,omitempty" validate:"omitempty,oneof=lose maintain gain"`               // Goal of its nutrition targets ("lose", "maintain" or "gain") (optional, "maintain" when unset)
+
+	CalendarNonce string `json:"-" bson:"calendar_nonce,omitempty"` // Random part of the calendar feed token, replaced to revoke the links (empty until first rotated)
+
+	ChurnRisk *ChurnRisk `json:"churn_risk,omitempty" bson:"churn_risk,omitempty"` // Latest churn-risk score (assigned by the scoring job)
+	CreatedAt *time.Time `json:"created_at,omitempty" bson:"created_at,omitempty"` // When the Complejo signed up (assigned by the server)
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"` // When the Complejo was deleted (restorable until purged)
+}
+
+// MaxPhotoUploadSize is the largest multipart request accepted by the profile photo upload, in bytes:
+// a 5 MB image plus the multipart framing.
+const MaxPhotoUploadSize = 5<<20 + 64<<10
+
+// ProfilePhotoKey returns the object store key of the profile photo with the given ID.
+func ProfilePhotoKey(photoID string) string {
+	return "profiles/" + photoID
+}
+
+// PhotoURL returns the path serving the profile photo of the Complejo, or "" when it has none in the object store.
+func (c *Complejo) PhotoURL() string {
+	if c.PhotoID == "" {
+		return ""
+	}
+	return "/photos/" + c.PhotoID
+}
+
+// PhotoSizeURLs returns the paths serving the size variants of the profile photo of the Complejo, by size,
+// or nil when it has none in the object store.
+func (c *Complejo) PhotoSizeURLs() map[string]string {
+	if c.PhotoID == "" {
+		return nil
+	}
+	return variantURLs(c.PhotoURL())
+}
+
+// PhotoConsentSetting returns whether the Complejo may be tagged in album photos: "allow", "ask" or "deny".
+// Complejos that never chose are asked.
+func (c *Complejo) PhotoConsentSetting() string {
+	if c.PhotoConsent == "" {
+		return PhotoConsentAsk
+	}
+	return c.PhotoConsent
+}
+
+// GoalSetting returns the goal of the nutrition targets of the Complejo: "lose", "maintain" or "gain". Complejos
+// that never chose maintain their bodyweight.
+func (c *Complejo) GoalSetting() string {
+	if c.Goal == "" {
+		return GoalMaintain
+	}
+	return c.Goal
+}
+
+// ProfileUpdate is a partial update of the profile fields a user may change on their own Complejo.
+// Only the fields present in the request (non-nil) are changed.
+type ProfileUpdate struct {
+	Username *string  `json:"username" validate:"omitnil,min=1"`       // New username
+	Email    *string  `json:"email" validate:"omitnil,eq=|email"`      // Address of the notification emails ("" removes it)
+	Weight   *float64 `json:"weight" validate:"omitnil,gte=0,lte=500"` // Weight in kilograms
+	Height   *float64 `json:"height" validate:"omitnil,gte=0,lte=3"`   // Height in meters
+	Bench    *float64 `json:"bench" validate:"omitnil,gte=0,lte=1000"` // Bench press weight in kilograms
+	Squad    *float64 `json:"squad" validate:"omitnil,gte=0,lte=1000"` // Squat weight in kilograms
+	DL       *float64 `json:"dl" validate:"omitnil,gte=0,lte=1000"`    // Deadlift weight in kilograms
+	Photo    *string  `json:"photo"`                                   // Base64-encoded profile photo, moved to the object store ("" removes it)
+	Locale   *string  `json:"locale" validate:"omitnil,locale"`        // Preferred locale ("en" or "es")
+	Units    *string  `json:"units" validate:"omitnil,units"`          // Preferred unit system ("metric" or "imperial")
+
+	PhotoConsent *string `json:"photo_consent" validate:"omitnil,oneof=allow ask deny"` // Whether it may be tagged in album photos
+	Goal         *string `json:"goal" validate:"omitnil,oneof=lose maintain gain"`      // Goal of its nutrition targets
+}
+
+// Fields returns the fields set by the update, keyed by their JSON/BSON name.
+func (u ProfileUpdate) Fields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	setString(fields, "username", u.Username)
+	setString(fields, "email", u.Email)
+	setFloat(fields, "weight", u.Weight)
+	setFloat(fields, "height", u.Height)
+	setFloat(fields, "bench", u.Bench)
+	setFloat(fields, "squad", u.Squad)
+	setFloat(fields, "dl", u.DL)
+	setString(fields, "photo", u.Photo)
+	setString(fields, "locale", u.Locale)
+	setString(fields, "units", u.Units)
+	setString(fields, "photo_consent", u.PhotoConsent)
+	setString(fields, "goal", u.Goal)
+	return fields
+}
+
+// ComplejoUpdate is a partial update of a Complejo by an admin: the profile fields plus the account fields.
+type ComplejoUpdate struct {
+	ProfileUpdate
+	Password *string `json:"password" validate:"omitnil,min=1"`      // New password
+	Role     *string `json:"role" validate:"omitnil,min=1,role"`     // "user" or "admin"
+	Gender   *string `json:"gender" validate:"omitnil,min=1,gender"` // "male", "female" or "other"
+}
+
+// Fields returns the fields set by the update, keyed by their JSON/BSON name.
+func (u ComplejoUpdate) Fields() map[string]interface{} {
+	fields := u.ProfileUpdate.Fields()
+	setString(fields, "password", u.Password)
+	setString(fields, "role", u.Role)
+	setString(fields, "gender", u.Gender)
+	return fields
+}
+
+// ComplejoQuery is bound from the query string of the Complejo read endpoints.
+type ComplejoQuery struct {
+	Include string `json:"include" form:"include" validate:"omitempty,oneof=photo"` // "photo" to include the profile photo
+}
+
+// ComplejoView selects the optional fields of a ComplejoResponse.
+type ComplejoView struct {
+	Photo     bool             // Include the base64-encoded profile photo (Complejos whose photo was not moved to the object store)
+	Email     bool             // Include the email address (its owner and admins only)
+	ChurnRisk bool             // Include the churn-risk score (admins only)
+	Relation  string           // Relation of the caller to the Complejo ("self", "follower" or "other")
+	Privacy   *PrivacySettings // Audiences of the PrivacyFields chosen by the Complejo (the default ones when nil)
+}
+
+// Sees reports whether the view includes the profile field, one of the PrivacyFields.
+func (v ComplejoView) Sees(field string) bool {
+	return v.Privacy.Visible(field, v.Relation)
+}
+
+// ComplejoResponse is how a Complejo is returned by the API: the password is never included, the base64 photo
+// and churn-risk score only when the view asks for them, and the PrivacyFields only when the privacy settings of
+// the Complejo let the caller see them, those fields being listed in Hidden otherwise. The volunteer hours and
+// follow counts are only set on a single profile.
+type ComplejoResponse struct {
+	ID           string            `json:"_id"`
+	Username     string            `json:"username"`
+	Email        string            `json:"email,omitempty"`
+	Role         string            `json:"role"`
+	Weight       *float64          `json:"weight,omitempty"`
+	Height       *float64          `json:"height,omitempty"`
+	IMC          *IMC              `json:"imc,omitempty"`
+	Gender       string            `json:"gender"`
+	Bench        float64           `json:"bench"`
+	Squad        float64           `json:"squad"`
+	DL           float64           `json:"dl"`
+	Photo        string            `json:"photo,omitempty"`
+	PhotoURL     string            `json:"photo_url,omitempty"`
+	PhotoSizes   map[string]string `json:"photo_sizes,omitempty"`
+	Locale       string            `json:"locale,omitempty"`
+	Units        string            `json:"units,omitempty"`
+	PhotoConsent string            `json:"photo_consent"`
+	Goal         string            `json:"goal"`
+	ChurnRisk    *ChurnRisk        `json:"churn_risk,omitempty"`
+	CreatedAt    *time.Time        `json:"created_at,omitempty"`
+	Hidden       []string          `json:"hidden,omitempty"` // PrivacyFields left out by the privacy settings of the Complejo
+
+	VolunteerHours *float64      `json:"volunteer_hours,omitempty"` // Hours volunteered in the shifts that have ended
+	Follows        *FollowStatus `json:"follows,omitempty"`         // Followers and followed Complejos
+}
+
+// Response returns the representation of the Complejo selected by the view.
+func (c *Complejo) Response(view ComplejoView) *ComplejoResponse {
+	response := &ComplejoResponse{
+		ID:           c.ID,
+		Username:     c.Username,
+		Role:         c.Role,
+		Gender:       c.Gender,
+		Bench:        c.Bench,
+		Squad:        c.Squad,
+		DL:           c.DL,
+		Locale:       c.Locale,
+		Units:        c.Units,
+		PhotoConsent: c.PhotoConsentSetting(),
+		Goal:         c.GoalSetting(),
+		CreatedAt:    c.CreatedAt,
+	}
+	if view.Email {
+		response.Email = c.Email
+	}
+	if view.ChurnRisk {
+		response.ChurnRisk = c.ChurnRisk
+	}
+	for _, field := range PrivacyFields {
+		if !view.Sees(field) {
+			response.Hidden = append(response.Hidden, field)
+			continue
+		}
+		switch field {
+		case "weight":
+			weight := c.Weight
+			response.Weight = &weight
+		case "height":
+			height := c.Height
+			response.Height = &height
+		case "imc":
+			response.IMC = c.IMC
+		case "photo":
+			response.PhotoURL, response.PhotoSizes = c.PhotoURL(), c.PhotoSizeURLs()
+			if view.Photo {
+				response.Photo = c.Photo
+			}
+		}
+	}
+	return response
 }
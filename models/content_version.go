@@ -0,0 +1,45 @@
+// content_version.go
+package models
+
+import "time"
+
+// Types of the versioned documents.
+const (
+	ContentAnnouncement = "announcement"
+	ContentTerms        = "terms"
+)
+
+// ContentVersion is a version of a versioned document (an Announcement or a terms document). Versions are never
+// changed: an edit or a rollback adds a version.
+type ContentVersion struct {
+	ID           string    `json:"_id" bson:"_id"`                                         // Unique identifier (assigned by the server)
+	DocumentType string    `json:"document_type" bson:"document_type"`                     // "announcement" or "terms"
+	DocumentID   string    `json:"document_id" bson:"document_id"`                         // ID of the Announcement, or kind of the terms document
+	Version      int       `json:"version" bson:"version"`                                 // 1 for the first version, then consecutive
+	Title        string    `json:"title" bson:"title"`                                     // Title of the document in this version
+	Body         string    `json:"body" bson:"body"`                                       // Body of the document in this version
+	Diff         string    `json:"diff,omitempty" bson:"diff,omitempty"`                   // Unified diff of the body from the previous version
+	CreatedBy    string    `json:"created_by" bson:"created_by"`                           // ID of the admin that wrote the version
+	CreatedAt    time.Time `json:"created_at" bson:"created_at"`                           // When the version was written
+	RestoredFrom int       `json:"restored_from,omitempty" bson:"restored_from,omitempty"` // Version a rollback restored
+}
+
+// ContentVersionSummary describes a ContentVersion in the version listings, without its body and diff.
+type ContentVersionSummary struct {
+	Version      int       `json:"version"`
+	Title        string    `json:"title"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	RestoredFrom int       `json:"restored_from,omitempty"`
+}
+
+// Summary returns the summary of the version.
+func (v ContentVersion) Summary() ContentVersionSummary {
+	return ContentVersionSummary{
+		Version:      v.Version,
+		Title:        v.Title,
+		CreatedBy:    v.CreatedBy,
+		CreatedAt:    v.CreatedAt,
+		RestoredFrom: v.RestoredFrom,
+	}
+}
@@ -0,0 +1,42 @@
+// data_export.go
+package models
+
+import "time"
+
+// Statuses of a DataExport.
+const (
+	DataExportPending = "pending" // Requested, the archive is being generated
+	DataExportReady   = "ready"   // The archive can be downloaded until it expires
+)
+
+// DataExport is the archive of everything stored about a Complejo, requested by the Complejo itself (GDPR
+// right of access). It is generated in a background job and kept in the object store until it expires.
+type DataExport struct {
+	ID          string     `json:"_id" bson:"_id"`                                       // Unique identifier (assigned by the server)
+	ComplejoID  string     `json:"complejo_id" bson:"complejo_id"`                       // Complejo whose data is exported
+	Status      string     `json:"status" bson:"status"`                                 // "pending" or "ready"
+	RequestedAt time.Time  `json:"requested_at" bson:"requested_at"`                     // When the export was requested
+	CompletedAt *time.Time `json:"completed_at,omitempty" bson:"completed_at,omitempty"` // When the archive was generated
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`     // When the archive is removed
+	Size        int64      `json:"size,omitempty" bson:"size,omitempty"`                 // Size of the archive in bytes
+
+	Link string `json:"link,omitempty" bson:"-"` // Path of the archive, with the token authenticating it (once ready)
+}
+
+// DataExportKey returns the object store key of the archive of the DataExport with the given ID.
+func DataExportKey(exportID string) string {
+	return "exports/" + exportID + ".zip"
+}
+
+// PersonalEvent is an Event as it relates to a Complejo in its DataExport: whether it organized it, its answer,
+// whether it likes it and the guests it brought.
+type PersonalEvent struct {
+	ID        string    `json:"_id"`
+	Title     string    `json:"title"`
+	Date      time.Time `json:"date"`
+	Location  string    `json:"location"`
+	Organizer bool      `json:"organizer"`        // The Complejo created the Event
+	RSVP      *RSVP     `json:"rsvp,omitempty"`   // Answer of the Complejo, if any
+	Liked     bool      `json:"liked"`            // The Complejo likes the Event
+	Guests    []Guest   `json:"guests,omitempty"` // Guests the Complejo brought
+}
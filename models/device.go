@@ -0,0 +1,47 @@
+// device.go
+package models
+
+import "time"
+
+// Push platforms of the devices.
+const (
+	PlatformFCM     = "fcm"     // Android and iOS apps, through Firebase Cloud Messaging
+	PlatformWebPush = "webpush" // Browsers, through the Web Push protocol
+)
+
+// Device is an app or browser of a Complejo receiving push notifications. A token belongs to a single
+// Complejo: registering it again moves it to the Complejo registering it.
+type Device struct {
+	ID         string    `json:"_id" bson:"_id"`                 // Unique identifier (assigned by the server)
+	ComplejoID string    `json:"complejo_id" bson:"complejo_id"` // Complejo receiving the notifications
+	Platform   string    `json:"platform" bson:"platform"`       // "fcm" or "webpush"
+	Token      string    `json:"token" bson:"token"`             // FCM registration token, or endpoint of the Web Push subscription
+	P256dh     string    `json:"-" bson:"p256dh,omitempty"`      // Public key of the Web Push subscription (base64url)
+	Auth       string    `json:"-" bson:"auth,omitempty"`        // Authentication secret of the Web Push subscription (base64url)
+	Name       string    `json:"name,omitempty" bson:"name"`     // Name of the device shown to its owner, e.g. "Pixel 8" (optional)
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`   // When the device was first registered
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`   // When the device was last registered
+}
+
+// DeviceInput registers a device: the registration token of an app, or the subscription of a browser
+// (the JSON of its PushSubscription).
+type DeviceInput struct {
+	Platform     string               `json:"platform" validate:"required,oneof=fcm webpush"`
+	Token        string               `json:"token" validate:"required_if=Platform fcm,max=4096"`
+	Subscription *WebPushSubscription `json:"subscription" validate:"required_if=Platform webpush"`
+	Name         string               `json:"name" validate:"max=100"`
+}
+
+// WebPushSubscription is the push subscription of a browser.
+type WebPushSubscription struct {
+	Endpoint string `json:"endpoint" validate:"required,url,startswith=https://,max=2048"`
+	Keys     struct {
+		P256dh string `json:"p256dh" validate:"required,base64rawurl"`
+		Auth   string `json:"auth" validate:"required,base64rawurl"`
+	} `json:"keys"`
+}
+
+// WebPushKey is the application server key browsers subscribe with (applicationServerKey).
+type WebPushKey struct {
+	PublicKey string `json:"public_key"` // Uncompressed P-256 public key, base64url without padding
+}
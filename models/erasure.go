@@ -0,0 +1,17 @@
+// erasure.go
+package models
+
+// ErasedUsernamePrefix starts the placeholder replacing the username of an erased Complejo.
+const ErasedUsernamePrefix = "erased-"
+
+// ErasedUsername returns the placeholder replacing the username of the erased Complejo with the given ID, unique
+// like the usernames.
+func ErasedUsername(complejoID string) string {
+	return ErasedUsernamePrefix + complejoID
+}
+
+// ErasureConfirmation is the payload of POST /complejo/me/erase and POST /complejo/:id/erase: the current
+// username of the Complejo, typed again to confirm the erasure.
+type ErasureConfirmation struct {
+	Username string `json:"username" validate:"required"`
+}
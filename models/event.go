@@ -4,11 +4,288 @@ import "time"
 
 // Event represents the structure of an event in the system
 type Event struct {
-	ID           string    `json:"_id" bson:"_id"`                                     // Unique identifier for the event
-	Title        string    `json:"title" bson:"title" validate:"required"`             // Title of the event (required)
-	Description  string    `json:"description" bson:"description" validate:"required"` // Description of the event (required)
-	Participants []string  `json:"participants" bson:"participants" default:"[]"`      // List of participants (default: empty)
-	Date         time.Time `json:"date" bson:"date" validate:"required"`               // Date of the event (required)
-	Image        *string   `json:"image,omitempty" bson:"image,omitempty"`             // Optional image URL for the event
-	Location     string    `json:"location" bson:"location" validate:"required"`       // Location of the event (required)
+	ID              string      `json:"_id" bson:"_id"`                                                          // Unique identifier for the event
+	Title           string      `json:"title" bson:"title" validate:"required"`                                  // Title of the event (required)
+	Description     string      `json:"description" bson:"description" validate:"required"`                      // Description of the event in Markdown (required)
+	DescriptionHTML string      `json:"description_html" bson:"-"`                                               // Sanitized HTML of the description (computed when the event is read)
+	RSVPs           []RSVP      `json:"rsvps" bson:"rsvps"`                                                      // Answers of the Complejos, in the order they were last changed
+	RSVPCounts      RSVPCounts  `json:"rsvp_counts" bson:"-"`                                                    // RSVPs by status and guests (computed when the event is read)
+	Guests          []Guest     `json:"guests" bson:"guests"`                                                    // Guests brought by members, in the order they were registered
+	Capacity        int         `json:"capacity,omitempty" bson:"capacity,omitempty" validate:"gte=0,lte=10000"` // Places for the Complejos going and their guests (0 for no limit)
+	Likes           []string    `json:"-" bson:"likes,omitempty"`                                                // IDs of the Complejos that like the event
+	LikeCount       int         `json:"like_count" bson:"-"`                                                     // Number of likes (computed when the event is read)
+	LikedByMe       bool        `json:"liked_by_me" bson:"-"`                                                    // Whether the authenticated Complejo likes the event
+	Date            time.Time   `json:"date" bson:"date" validate:"required,future"`                             // Date of the event (required, in the future)
+	Image           *string     `json:"image,omitempty" bson:"image,omitempty"`                                  // Optional image URL for the event
+	Location        string      `json:"location" bson:"location" validate:"required"`                            // Location of the event (required)
+	CreatedBy       string      `json:"created_by,omitempty" bson:"created_by,omitempty"`                        // ID of the Complejo that created the event (assigned by the server)
+	GroupID         string      `json:"group_id,omitempty" bson:"group_id,omitempty"`                            // ID of the group the event belongs to, whose members alone may go (assigned by the server)
+	PinnedUntil     *time.Time  `json:"pinned_until,omitempty" bson:"pinned_until,omitempty"`                    // When the pin of the event expires (set by an admin)
+	Pinned          bool        `json:"pinned" bson:"-"`                                                         // Whether the event is pinned to the top of the listings (computed when the event is read)
+	Featured        bool        `json:"featured" bson:"featured,omitempty"`                                      // Whether the event is shown in the feed of the public site (set by an admin)
+	Outdoor         bool        `json:"outdoor" bson:"outdoor,omitempty"`                                        // Whether the event takes place outdoors, so its weather is forecast
+	Weather         *Forecast   `json:"weather,omitempty" bson:"weather,omitempty"`                              // Cached weather forecast of an outdoor event (assigned by the server)
+	WeatherWarnedAt *time.Time  `json:"-" bson:"weather_warned_at,omitempty"`                                    // When the participants were warned of severe weather
+	RemindedAt      *time.Time  `json:"-" bson:"reminded_at,omitempty"`                                          // When the participants were reminded of the event
+	Route           *EventRoute `json:"route,omitempty" bson:"route,omitempty"`                                  // GPX route of an outdoor event, uploaded by its organizer
+
+	Level          string   `json:"level,omitempty" bson:"level,omitempty" validate:"omitempty,oneof=beginner intermediate advanced"` // Skill level of the session (optional)
+	Intensity      string   `json:"intensity,omitempty" bson:"intensity,omitempty" validate:"omitempty,oneof=low moderate high"`      // Intensity of the session (optional)
+	LevelGate      string   `json:"level_gate,omitempty" bson:"level_gate,omitempty" validate:"omitempty,oneof=off warn block"`       // Whether beginners are warned or blocked from an advanced session (default: off)
+	LevelOverrides []string `json:"level_overrides,omitempty" bson:"level_overrides,omitempty"`                                       // IDs of the Complejos the organizer let through the level gate
+
+	ExternalID        string     `json:"external_id,omitempty" bson:"external_id,omitempty"`                 // ID assigned by the external producer that pushed the event
+	ExternalUpdatedAt *time.Time `json:"external_updated_at,omitempty" bson:"external_updated_at,omitempty"` // Producer-side version of the ingested definition
+
+	Album []EventPhoto `json:"album,omitempty" bson:"-"` // Photos of the album the reader may see (set on the event detail)
+
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"` // When the event was deleted (restorable until purged)
+}
+
+// ClearManaged clears the fields of a new Event that are managed through their own endpoints rather than set
+// on creation.
+func (e *Event) ClearManaged() {
+	// RSVPs are answered by each Complejo through PUT /event/:id/rsvp, overrides of the level gate
+	// are granted through PUT /event/:id/level-overrides/:complejo_id and guests are registered
+	// through POST /event/:id/guest
+	e.RSVPs = nil
+	e.LevelOverrides = nil
+	e.Guests = nil
+	// Pins and features are set through PUT /event/:id/pin and PUT /event/:id/featured
+	e.PinnedUntil = nil
+	e.Featured = false
+	// The forecast of an outdoor event is fetched when it is read
+	e.Weather = nil
+	// Routes are uploaded through PUT /event/:id/route
+	e.Route = nil
+	// Photos are added through POST /event/:id/photos
+	e.Album = nil
+	// Group events are created through POST /groups/:id/events
+	e.GroupID = ""
+}
+
+// EventUpdate is a partial update of an Event by an admin.
+// Only the fields present in the request (non-nil) are changed.
+type EventUpdate struct {
+	Title       *string    `json:"title" validate:"omitnil,min=1"`                                // Title of the event
+	Description *string    `json:"description" validate:"omitnil,min=1"`                          // Description of the event in Markdown
+	Date        *time.Time `json:"date" validate:"omitnil,future"`                                // Date of the event (in the future)
+	Image       *string    `json:"image"`                                                         // Image URL of the event
+	Location    *string    `json:"location" validate:"omitnil,min=1"`                             // Location of the event
+	Capacity    *int       `json:"capacity" validate:"omitnil,gte=0,lte=10000"`                   // Places for the Complejos going and their guests (0 for no limit)
+	Level       *string    `json:"level" validate:"omitnil,oneof=beginner intermediate advanced"` // Skill level of the session
+	Intensity   *string    `json:"intensity" validate:"omitnil,oneof=low moderate high"`          // Intensity of the session
+	LevelGate   *string    `json:"level_gate" validate:"omitnil,oneof=off warn block"`            // Level gate of the session
+	Outdoor     *bool      `json:"outdoor"`                                                       // Whether the event takes place outdoors
+}
+
+// Fields returns the fields set by the update, keyed by their JSON/BSON name.
+func (u EventUpdate) Fields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	setString(fields, "title", u.Title)
+	setString(fields, "description", u.Description)
+	if u.Date != nil {
+		fields["date"] = *u.Date
+	}
+	setString(fields, "image", u.Image)
+	setString(fields, "location", u.Location)
+	if u.Capacity != nil {
+		fields["capacity"] = *u.Capacity
+	}
+	setString(fields, "level", u.Level)
+	setString(fields, "intensity", u.Intensity)
+	setString(fields, "level_gate", u.LevelGate)
+	if u.Outdoor != nil {
+		fields["outdoor"] = *u.Outdoor
+	}
+	return fields
+}
+
+// FindRSVP returns the RSVP of the Complejo, or nil when it has not answered.
+func (e Event) FindRSVP(complejoID string) *RSVP {
+	for i := range e.RSVPs {
+		if e.RSVPs[i].ComplejoID == complejoID {
+			return &e.RSVPs[i]
+		}
+	}
+	return nil
+}
+
+// MarkLiked sets LikedByMe when the Complejo with the given ID (empty when anonymous) likes the event.
+func (e *Event) MarkLiked(complejoID string) {
+	e.LikedByMe = false
+	for _, id := range e.Likes {
+		if complejoID != "" && id == complejoID {
+			e.LikedByMe = true
+		}
+	}
+}
+
+// EventLikes is returned when a Complejo likes or unlikes an Event.
+type EventLikes struct {
+	LikeCount int  `json:"like_count"`  // Number of likes of the Event
+	LikedByMe bool `json:"liked_by_me"` // Whether the Complejo likes the Event
+}
+
+// Usernames returns the usernames of the RSVPs with one of the given statuses, in order.
+func (e Event) Usernames(statuses ...string) []string {
+	usernames := []string{}
+	for _, rsvp := range e.RSVPs {
+		for _, status := range statuses {
+			if rsvp.Status == status {
+				usernames = append(usernames, rsvp.Username)
+				break
+			}
+		}
+	}
+	return usernames
+}
+
+// ComplejoIDs returns the Complejo IDs of the RSVPs with one of the given statuses, in order.
+func (e Event) ComplejoIDs(statuses ...string) []string {
+	ids := []string{}
+	for _, rsvp := range e.RSVPs {
+		for _, status := range statuses {
+			if rsvp.Status == status {
+				ids = append(ids, rsvp.ComplejoID)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// Gated reports whether the level gate of the event applies to the Complejo with the given skill level:
+// the event is an advanced session gating beginners, and the Complejo is neither its creator nor let through.
+func (e Event) Gated(complejoID, skill string) bool {
+	if e.Level != SkillAdvanced || skill != SkillBeginner || e.CreatedBy == complejoID {
+		return false
+	}
+	if e.LevelGate != LevelGateWarn && e.LevelGate != LevelGateBlock {
+		return false
+	}
+	for _, id := range e.LevelOverrides {
+		if id == complejoID {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFull reports whether the event has a capacity and the Complejos going and their guests take every place.
+func (e Event) IsFull() bool {
+	counts := CountRSVPs(e)
+	return e.Capacity > 0 && counts.Going+counts.Guests >= e.Capacity
+}
+
+// IsPast reports whether the event took place before the given time.
+func (e Event) IsPast(now time.Time) bool {
+	return e.Date.Before(now)
+}
+
+// IsUpcoming reports whether the event has not yet taken place at the given time.
+func (e Event) IsUpcoming(now time.Time) bool {
+	return !e.IsPast(now)
+}
+
+// Default and maximum page sizes of an Event listing.
+const (
+	DefaultEventPageLimit = 20
+	MaxEventPageLimit     = 100
+)
+
+// EventFilter narrows down and paginates an Event listing.
+// It is bound from the `?from=&to=&location=&group=&sort=&page=&limit=` query string of GET /event.
+type EventFilter struct {
+	From     *time.Time `json:"from" form:"from" time_format:"2006-01-02T15:04:05Z07:00"` // Only events on or after this time
+	To       *time.Time `json:"to" form:"to" time_format:"2006-01-02T15:04:05Z07:00"`     // Only events on or before this time
+	Location string     `json:"location" form:"location"`                                 // Case-insensitive substring of the location
+	Group    string     `json:"group" form:"group"`                                       // Only the events of the group with this ID
+	Sort     string     `json:"sort" form:"sort" validate:"omitempty,oneof=asc desc"`     // Order by date: "asc" or "desc" (default: the listing settings)
+	Page     int        `json:"page" form:"page" validate:"omitnil,min=1"`                // 1-based page number (default: 1)
+	Limit    int        `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"`    // Page size (default: the listing settings, at most 100)
+}
+
+// Apply fills in what the filter leaves out from the listing settings of the club: the order, the page size
+// and, unless a date range is given, the start hiding the events dated too long before now.
+func (f *EventFilter) Apply(settings *ListingSettings, now time.Time) {
+	if f.Sort == "" {
+		f.Sort = settings.Sort
+	}
+	if f.Limit == 0 {
+		f.Limit = settings.PageSize
+	}
+	if f.From == nil && f.To == nil && settings.HidePastAfterDays > 0 {
+		from := now.AddDate(0, 0, -settings.HidePastAfterDays)
+		f.From = &from
+	}
+}
+
+// Normalize fills in the default order, page and page size.
+func (f *EventFilter) Normalize() {
+	if f.Sort == "" {
+		f.Sort = SortAscending
+	}
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit < 1 {
+		f.Limit = DefaultEventPageLimit
+	}
+	if f.Limit > MaxEventPageLimit {
+		f.Limit = MaxEventPageLimit
+	}
+}
+
+// Offset returns the number of events skipped before the requested page.
+func (f EventFilter) Offset() int {
+	return (f.Page - 1) * f.Limit
+}
+
+// Limits of the featured events feed.
+const (
+	DefaultFeaturedLimit = 10
+	MaxFeaturedLimit     = 50
+)
+
+// FeaturedQuery is bound from the `?limit=` query string of GET /event/featured.
+type FeaturedQuery struct {
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=50"` // Maximum number of events (default: 10, at most 50)
+}
+
+// EventPin is the payload pinning an Event to the top of the listings.
+type EventPin struct {
+	Until *time.Time `json:"until" validate:"omitnil,future"` // When the pin expires (default: a week from now)
+}
+
+// CalendarLink is the personal calendar feed of a Complejo, to subscribe to in a calendar app.
+type CalendarLink struct {
+	Token string `json:"token"` // Token authenticating the feed
+	Link  string `json:"link"`  // Path of the feed, with its token
+}
+
+// EventMeta is the SEO metadata of the public page of an Event, for the server-side rendering of the frontend
+// (title, description and Open Graph tags).
+type EventMeta struct {
+	Title       string    `json:"title"`        // Title of the Event
+	Description string    `json:"description"`  // Plain text of the description, shortened for search results
+	URL         string    `json:"url"`          // Canonical URL of the public page
+	Image       string    `json:"image"`        // URL of the share image (og:image)
+	ImageWidth  int       `json:"image_width"`  // Width of the share image in pixels
+	ImageHeight int       `json:"image_height"` // Height of the share image in pixels
+	Date        time.Time `json:"date"`         // Date of the Event
+	Location    string    `json:"location"`     // Location of the Event
+}
+
+// EventSearch is a full-text search over the title, description and location of Events.
+// It is bound from the `?q=&limit=` query string of GET /event/search.
+type EventSearch struct {
+	Query string `json:"q" form:"q" validate:"required"`                        // Words to look for (required)
+	Limit int    `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // Maximum number of results (default: 20, at most 100)
+}
+
+// ScoredEvent is an Event matched by a full-text search together with its relevance.
+type ScoredEvent struct {
+	Event `bson:",inline"`
+	Score float64 `json:"score" bson:"score"` // Relevance of the match (higher is better)
 }
@@ -0,0 +1,20 @@
+// event_change.go
+package models
+
+import "time"
+
+// Operations of the changes of the Events.
+const (
+	EventInserted = "insert" // The Event was created
+	EventUpdated  = "update" // The Event was changed, or restored after being deleted
+	EventDeleted  = "delete" // The Event was deleted
+)
+
+// EventChange is a change of an Event, streamed by GET /event/stream.
+type EventChange struct {
+	Token     string    `json:"-"`               // Resume token of the change, sent as the ID of the server-sent event
+	Operation string    `json:"operation"`       // "insert", "update" or "delete"
+	EventID   string    `json:"event_id"`        // ID of the changed Event
+	Event     *Event    `json:"event,omitempty"` // The Event after the change (not set on deletions)
+	At        time.Time `json:"at"`              // When the change was made
+}
@@ -0,0 +1,57 @@
+// exercise.go
+package models
+
+import "time"
+
+// Default and maximum page sizes of the exercise library.
+const (
+	DefaultExerciseLimit = 50
+	MaxExerciseLimit     = 100
+)
+
+// Exercise is an exercise of the library the workouts are logged with.
+type Exercise struct {
+	ID           string    `json:"_id" bson:"_id"`                     // Unique identifier (assigned by the server)
+	Name         string    `json:"name" bson:"name"`                   // Unique name of the exercise (e.g. "Romanian deadlift")
+	MuscleGroups []string  `json:"muscle_groups" bson:"muscle_groups"` // Muscle groups it trains, the one it trains the most first
+	Equipment    string    `json:"equipment" bson:"equipment"`         // "barbell", "dumbbell", "kettlebell", "machine", "cable", "band", "bodyweight" or "other"
+	VideoURL     string    `json:"video_url" bson:"video_url"`         // Link to a demonstration of the technique (optional)
+	CreatedAt    time.Time `json:"created_at" bson:"created_at"`       // When it was added (assigned by the server)
+	UpdatedAt    time.Time `json:"updated_at" bson:"updated_at"`       // When it was last changed (assigned by the server)
+}
+
+// ExerciseInput is the payload creating or replacing an Exercise.
+type ExerciseInput struct {
+	Name         string   `json:"name" validate:"required,max=100"`
+	MuscleGroups []string `json:"muscle_groups" validate:"required,min=1,max=6,unique,dive,oneof=chest back shoulders arms legs core"`
+	Equipment    string   `json:"equipment" validate:"required,oneof=barbell dumbbell kettlebell machine cable band bodyweight other"`
+	VideoURL     string   `json:"video_url" validate:"omitempty,url,max=500"`
+}
+
+// ExerciseFilter narrows down and paginates the exercise library.
+// It is bound from the `?q=&muscle_group=&equipment=&page=&limit=` query string of GET /exercises.
+type ExerciseFilter struct {
+	Query       string `json:"q" form:"q" validate:"max=100"`                                                                                         // Part of the name, case-insensitive (optional)
+	MuscleGroup string `json:"muscle_group" form:"muscle_group" validate:"omitempty,oneof=chest back shoulders arms legs core"`                       // Only the exercises training this muscle group (optional)
+	Equipment   string `json:"equipment" form:"equipment" validate:"omitempty,oneof=barbell dumbbell kettlebell machine cable band bodyweight other"` // Only the exercises performed with this equipment (optional)
+	Page        int    `json:"page" form:"page" validate:"omitempty,min=1"`                                                                           // 1-based page number (default: 1)
+	Limit       int    `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"`                                                                 // Page size (default: 50, at most 100)
+}
+
+// Normalize fills in the default page and page size.
+func (f *ExerciseFilter) Normalize() {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit < 1 {
+		f.Limit = DefaultExerciseLimit
+	}
+	if f.Limit > MaxExerciseLimit {
+		f.Limit = MaxExerciseLimit
+	}
+}
+
+// Offset returns the number of exercises skipped before the requested page.
+func (f ExerciseFilter) Offset() int {
+	return (f.Page - 1) * f.Limit
+}
@@ -0,0 +1,36 @@
+// expense.go
+package models
+
+import "time"
+
+// Expense is a cost of an Event (e.g. venue rental or prizes), netted against its payments in the finance summary.
+// Amounts are in the smallest unit of the currency (e.g. cents).
+type Expense struct {
+	ID          string    `json:"_id" bson:"_id"`                 // Unique identifier (assigned by the server)
+	EventID     string    `json:"event_id" bson:"event_id"`       // Event the expense belongs to (assigned by the server)
+	Category    string    `json:"category" bson:"category"`       // "venue", "prizes", "equipment", "staff" or "other"
+	Description string    `json:"description" bson:"description"` // What was paid for
+	Amount      int64     `json:"amount" bson:"amount"`           // Amount spent
+	Currency    string    `json:"currency" bson:"currency"`       // Lowercase ISO currency code
+	IncurredAt  time.Time `json:"incurred_at" bson:"incurred_at"` // When the expense was incurred
+	CreatedBy   string    `json:"created_by" bson:"created_by"`   // ID of the Complejo that recorded it (assigned by the server)
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`   // When it was recorded (assigned by the server)
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`   // When it was last changed (assigned by the server)
+}
+
+// ExpenseInput is the payload creating or replacing an Expense.
+type ExpenseInput struct {
+	Category    string     `json:"category" validate:"required,oneof=venue prizes equipment staff other"`
+	Description string     `json:"description" validate:"max=500"`
+	Amount      int64      `json:"amount" validate:"gt=0"`
+	Currency    string     `json:"currency" validate:"required,len=3,alpha"`
+	IncurredAt  *time.Time `json:"incurred_at"` // Default: when it is recorded
+}
+
+// ExpenseRow aggregates the expenses of an Event in a currency during a month.
+type ExpenseRow struct {
+	EventID  string
+	Month    string // "2006-01", in the gym's time zone
+	Currency string
+	Amount   int64
+}
@@ -0,0 +1,16 @@
+// federated_event.go
+package models
+
+import "time"
+
+// FederatedEvent is a public event of another club, imported from the federation feed.
+type FederatedEvent struct {
+	ID          string    `json:"_id" bson:"_id"`                 // "<club>:<external_id>"
+	Club        string    `json:"club" bson:"club"`               // Club that published the event
+	ExternalID  string    `json:"external_id" bson:"external_id"` // Event ID at the publishing club
+	Title       string    `json:"title" bson:"title"`
+	Description string    `json:"description" bson:"description"`
+	Date        time.Time `json:"date" bson:"date"`
+	Location    string    `json:"location" bson:"location"`
+	ImportedAt  time.Time `json:"imported_at" bson:"imported_at"` // When the event was last refreshed from the feed
+}
@@ -0,0 +1,59 @@
+// feed.go
+package models
+
+import "time"
+
+// Kinds of the items of the activity feed.
+const (
+	FeedPersonalRecord  = "personal_record"  // A Complejo improved one of its lifts
+	FeedEventSubscribed = "event_subscribed" // A Complejo started going to an event
+	FeedChallengeWon    = "challenge_won"    // A Complejo won a challenge
+	FeedGroupEvent      = "group_event"      // An event of a group was created
+)
+
+// Default and maximum page sizes of the activity feed.
+const (
+	DefaultFeedLimit = 20
+	MaxFeedLimit     = 100
+)
+
+// FeedItem is an activity of a Complejo, or of a group, in the activity feed. It is written by the feed
+// subscriber of the domain events and keeps what it shows as it was then, so the feed is read without joins.
+type FeedItem struct {
+	ID          string    `json:"_id" bson:"_id"`                                       // Unique identifier, derived from the activity
+	Kind        string    `json:"kind" bson:"kind"`                                     // "personal_record", "event_subscribed", "challenge_won" or "group_event"
+	ComplejoID  string    `json:"complejo_id,omitempty" bson:"complejo_id,omitempty"`   // Complejo whose activity it is (none for the events of a group)
+	Username    string    `json:"username,omitempty" bson:"username,omitempty"`         // Its username at the time of the activity
+	GroupID     string    `json:"group_id,omitempty" bson:"group_id,omitempty"`         // Group whose event was created
+	EventID     string    `json:"event_id,omitempty" bson:"event_id,omitempty"`         // Event subscribed to or created
+	ChallengeID string    `json:"challenge_id,omitempty" bson:"challenge_id,omitempty"` // Challenge won
+	Title       string    `json:"title,omitempty" bson:"title,omitempty"`               // Title of the event or the challenge
+	Lift        string    `json:"lift,omitempty" bson:"lift,omitempty"`                 // "bench", "squad" or "dl", for a personal record
+	Value       float64   `json:"value,omitempty" bson:"value,omitempty"`               // New record in kilograms, or winning score of a challenge
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`                         // When the activity reached the feed
+}
+
+// FeedQuery paginates the activity feed.
+// It is bound from the `?page=&limit=` query string of GET /feed.
+type FeedQuery struct {
+	Page  int `json:"page" form:"page" validate:"omitempty,min=1"`           // 1-based page number (default: 1)
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // Page size (default: 20, at most 100)
+}
+
+// Normalize fills in the default page and page size.
+func (q *FeedQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultFeedLimit
+	}
+	if q.Limit > MaxFeedLimit {
+		q.Limit = MaxFeedLimit
+	}
+}
+
+// Offset returns the number of items skipped before the requested page.
+func (q FeedQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
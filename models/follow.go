@@ -0,0 +1,58 @@
+// follow.go
+package models
+
+import "time"
+
+// Default and maximum page sizes of the followers and followed Complejos.
+const (
+	DefaultFollowLimit = 20
+	MaxFollowLimit     = 100
+)
+
+// Follow records that a Complejo follows another.
+type Follow struct {
+	ID         string    `json:"-" bson:"_id"`                   // Unique identifier (assigned by the server)
+	FollowerID string    `json:"follower_id" bson:"follower_id"` // Complejo that follows
+	FolloweeID string    `json:"followee_id" bson:"followee_id"` // Complejo followed
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`   // When it started following
+}
+
+// FollowQuery paginates the followers or the followed Complejos of a Complejo.
+type FollowQuery struct {
+	Page  int `json:"page" form:"page" validate:"omitempty,min=1"`           // 1-based page number (default: 1)
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // Page size (default: 20, at most 100)
+}
+
+// Normalize fills in the default page and page size.
+func (q *FollowQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultFollowLimit
+	}
+	if q.Limit > MaxFollowLimit {
+		q.Limit = MaxFollowLimit
+	}
+}
+
+// Offset returns the number of Complejos skipped before the requested page.
+func (q FollowQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// FollowEntry is a live follower or followed Complejo in the lists of a Complejo.
+type FollowEntry struct {
+	ComplejoID string    `json:"complejo_id" bson:"_id"`         // Follower or followed Complejo
+	Username   string    `json:"username" bson:"username"`       // Its current username
+	FollowedAt time.Time `json:"followed_at" bson:"followed_at"` // When the follow started
+}
+
+// FollowStatus counts the live followers and followed Complejos of a Complejo, and tells whether the caller
+// follows it.
+type FollowStatus struct {
+	ComplejoID   string `json:"complejo_id"`    // Complejo counted
+	Followers    int64  `json:"followers"`      // Live Complejos following it
+	Following    int64  `json:"following"`      // Live Complejos it follows
+	FollowedByMe bool   `json:"followed_by_me"` // Whether the authenticated Complejo follows it
+}
@@ -0,0 +1,106 @@
+// group.go
+package models
+
+import "time"
+
+// Roles of the members of a group.
+const (
+	GroupRoleOwner  = "owner"  // Manages the group, its members and its events
+	GroupRoleMember = "member" // Takes part in the group events and leaderboard
+)
+
+// Default and maximum page sizes of the groups and of their members.
+const (
+	DefaultGroupLimit = 20
+	MaxGroupLimit     = 100
+)
+
+// Group is a crew of Complejos within the club, with its own members, events and leaderboard.
+type Group struct {
+	ID          string    `json:"_id" bson:"_id"`                 // Unique identifier (assigned by the server)
+	Name        string    `json:"name" bson:"name"`               // Name of the crew (e.g. "Early birds")
+	Description string    `json:"description" bson:"description"` // What the crew is about, free text
+	CreatedBy   string    `json:"created_by" bson:"created_by"`   // ID of the Complejo that created the group (assigned by the server)
+	Role        string    `json:"role,omitempty" bson:"-"`        // Role of the authenticated Complejo in the group, when a member (computed when the group is read)
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`   // When it was created (assigned by the server)
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`   // When it was last changed (assigned by the server)
+}
+
+// GroupInput is the payload creating a Group or replacing its details.
+type GroupInput struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=2000"`
+}
+
+// GroupFilter searches and paginates the groups.
+// It is bound from the `?q=&mine=&page=&limit=` query string of GET /groups.
+type GroupFilter struct {
+	Query string `json:"q" form:"q" validate:"max=100"`                         // Case-insensitive part of the name (optional)
+	Mine  bool   `json:"mine" form:"mine"`                                      // Only the groups of the authenticated Complejo
+	Page  int    `json:"page" form:"page" validate:"omitempty,min=1"`           // 1-based page number (default: 1)
+	Limit int    `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // Page size (default: 20, at most 100)
+}
+
+// Normalize fills in the default page and page size.
+func (f *GroupFilter) Normalize() {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit < 1 {
+		f.Limit = DefaultGroupLimit
+	}
+	if f.Limit > MaxGroupLimit {
+		f.Limit = MaxGroupLimit
+	}
+}
+
+// Offset returns the number of groups skipped before the requested page.
+func (f GroupFilter) Offset() int {
+	return (f.Page - 1) * f.Limit
+}
+
+// GroupMembership is the role of a Complejo in a Group.
+type GroupMembership struct {
+	ID         string    `json:"-" bson:"_id"`                   // Unique identifier (assigned by the server)
+	GroupID    string    `json:"group_id" bson:"group_id"`       // Group the Complejo belongs to
+	ComplejoID string    `json:"complejo_id" bson:"complejo_id"` // Member
+	Role       string    `json:"role" bson:"role"`               // "owner" or "member"
+	JoinedAt   time.Time `json:"joined_at" bson:"joined_at"`     // When it was added to the group
+}
+
+// GroupMembershipInput is the payload adding a member to a Group or changing its role.
+type GroupMembershipInput struct {
+	Role string `json:"role" validate:"omitempty,oneof=owner member"` // "owner" or "member" (default: "member")
+}
+
+// GroupMemberQuery paginates the members of a Group.
+type GroupMemberQuery struct {
+	Page  int `json:"page" form:"page" validate:"omitempty,min=1"`           // 1-based page number (default: 1)
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // Page size (default: 20, at most 100)
+}
+
+// Normalize fills in the default page and page size.
+func (q *GroupMemberQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultGroupLimit
+	}
+	if q.Limit > MaxGroupLimit {
+		q.Limit = MaxGroupLimit
+	}
+}
+
+// Offset returns the number of members skipped before the requested page.
+func (q GroupMemberQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// GroupMember is a live member of a Group with its current username.
+type GroupMember struct {
+	ComplejoID string    `json:"complejo_id" bson:"_id"`     // Member
+	Username   string    `json:"username" bson:"username"`   // Current username of the member
+	Role       string    `json:"role" bson:"role"`           // "owner" or "member"
+	JoinedAt   time.Time `json:"joined_at" bson:"joined_at"` // When it was added to the group
+}
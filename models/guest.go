@@ -0,0 +1,55 @@
+// guest.go
+package models
+
+import "time"
+
+// Guest is a friend a member brings to an Event. Guests have no account: they are only listed on the roster
+// of the Event and count against its capacity.
+type Guest struct {
+	ID           string    `json:"_id" bson:"_id"`                     // Unique identifier (assigned by the server)
+	Name         string    `json:"name" bson:"name"`                   // Name of the guest
+	HostID       string    `json:"host_id" bson:"host_id"`             // Complejo that registered the guest
+	HostUsername string    `json:"host_username" bson:"host_username"` // Username of the host when it registered the guest
+	CreatedAt    time.Time `json:"created_at" bson:"created_at"`       // When the guest was registered (counts against the host's monthly passes)
+}
+
+// GuestInput is the payload of POST /event/:id/guest.
+type GuestInput struct {
+	Name string `json:"name" validate:"required,max=80"` // Name of the guest
+}
+
+// GuestInvitation invites a guest to join as a Complejo. Only a hash of its token is stored: the token is
+// handed once to the host, who shares the link with the guest.
+type GuestInvitation struct {
+	ID         string     `json:"_id" bson:"_id"`                                     // Unique identifier
+	TokenHash  string     `json:"-" bson:"token_hash"`                                // SHA-256 of the token of the link (hex)
+	EventID    string     `json:"event_id" bson:"event_id"`                           // Event the guest was invited from
+	GuestID    string     `json:"guest_id" bson:"guest_id"`                           // Guest invited
+	Name       string     `json:"name" bson:"name"`                                   // Name of the guest
+	HostID     string     `json:"host_id" bson:"host_id"`                             // Complejo that brought the guest
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`                       // When the invitation was made
+	ExpiresAt  time.Time  `json:"expires_at" bson:"expires_at"`                       // When the link stops working
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" bson:"accepted_at,omitempty"` // When the guest joined
+	ComplejoID string     `json:"complejo_id,omitempty" bson:"complejo_id,omitempty"` // Complejo created for the guest
+}
+
+// InvitationLink is returned to the host inviting a guest; the token is not shown again.
+type InvitationLink struct {
+	Token     string    `json:"token"`      // Token of the invitation
+	Link      string    `json:"link"`       // Path of the invitation (GET to preview, POST to join)
+	ExpiresAt time.Time `json:"expires_at"` // When the link stops working
+}
+
+// AttendedEvent is an Event a guest was brought to, carried over to the Complejo it joins as.
+type AttendedEvent struct {
+	ID    string    `json:"_id"`   // ID of the Event
+	Title string    `json:"title"` // Title of the Event
+	Date  time.Time `json:"date"`  // Date of the Event
+}
+
+// InvitationPreview pre-fills the sign-up of an invited guest.
+type InvitationPreview struct {
+	Name      string          `json:"name"`       // Name the host registered the guest with
+	ExpiresAt time.Time       `json:"expires_at"` // When the link stops working
+	Events    []AttendedEvent `json:"events"`     // Events the guest was brought to by the host, by date
+}
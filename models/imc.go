@@ -0,0 +1,8 @@
+// imc.go
+package models
+
+// IMC is the Body Mass Index of a Complejo, calculated from its weight and height.
+type IMC struct {
+	Value    float64 `json:"value" bson:"value"`       // Weight divided by the square of the height (kg/m²), rounded to one decimal
+	Category string  `json:"category" bson:"category"` // Name of the range of the value (configured with IMC_CATEGORIES)
+}
@@ -0,0 +1,48 @@
+// import.go
+package models
+
+// Kinds of records imported by POST /admin/import.
+const (
+	ImportComplejos = "complejos" // Members, created like by POST /complejo
+	ImportEvents    = "events"    // Events, created like by POST /event
+)
+
+// Formats of the files imported by POST /admin/import, chosen by their Content-Type.
+const (
+	ImportCSV  = "csv"  // A header row naming the columns, then a row per record
+	ImportJSON = "json" // An array of the payloads of the create endpoint
+)
+
+// Outcomes of importing a single row.
+const (
+	ImportCreated = "created" // The record was created (or would be, in a dry run)
+	ImportSkipped = "skipped" // The record duplicates a stored one or an earlier row
+	ImportFailed  = "failed"  // The row could not be read or failed validation
+)
+
+// MaxImportSize is the largest file accepted by POST /admin/import, in bytes.
+const MaxImportSize = 5 << 20
+
+// ImportQuery is bound from the query string of POST /admin/import.
+type ImportQuery struct {
+	Kind   string `json:"kind" form:"kind" validate:"required,oneof=complejos events"` // Kind of the records: "complejos" or "events" (required)
+	DryRun bool   `json:"dry_run" form:"dry_run"`                                      // Report what would happen without creating anything
+}
+
+// ImportResult reports what happened to one imported row.
+type ImportResult struct {
+	Row     int         `json:"row"`               // Line of the row in a CSV file (the header is line 1), or position in a JSON array from 1
+	Key     string      `json:"key,omitempty"`     // What duplicates are detected by: the username, or the title and date of the event
+	Status  string      `json:"status"`            // One of the Import* outcomes
+	ID      string      `json:"id,omitempty"`      // ID of the created record (not in a dry run)
+	Reason  string      `json:"reason,omitempty"`  // Why the row was skipped or failed
+	Details interface{} `json:"details,omitempty"` // Per-field errors of a failed row, like the validation errors of the API
+}
+
+// ImportReport summarizes a whole import.
+type ImportReport struct {
+	Kind    string         `json:"kind"`    // Kind of the records imported
+	DryRun  bool           `json:"dry_run"` // Whether nothing was created
+	Summary map[string]int `json:"summary"` // Number of rows per outcome
+	Results []ImportResult `json:"results"` // One result per row, in file order
+}
@@ -0,0 +1,38 @@
+// ingest.go
+package models
+
+import "time"
+
+// Outcomes of ingesting a single event definition.
+const (
+	IngestCreated   = "created"   // A new Event was created
+	IngestUpdated   = "updated"   // The existing Event was updated
+	IngestUnchanged = "unchanged" // The existing Event already matched the definition
+	IngestConflict  = "conflict"  // The definition was rejected because it conflicts with stored data
+	IngestInvalid   = "invalid"   // The definition is missing required fields
+)
+
+// IngestEvent is an event definition pushed by a trusted external producer.
+type IngestEvent struct {
+	ExternalID  string    `json:"external_id"` // Stable ID of the event at the producer (required)
+	Title       string    `json:"title"`       // Title of the event (required)
+	Description string    `json:"description"` // Description of the event
+	Date        time.Time `json:"date"`        // Date of the event (required)
+	Image       *string   `json:"image,omitempty"`
+	Location    string    `json:"location"`   // Location of the event
+	UpdatedAt   time.Time `json:"updated_at"` // When the producer last changed the definition (required)
+}
+
+// IngestResult reports what happened to one ingested definition.
+type IngestResult struct {
+	ExternalID string `json:"external_id"`
+	Status     string `json:"status"`             // One of the Ingest* constants
+	EventID    string `json:"event_id,omitempty"` // Local Event ID when one exists
+	Reason     string `json:"reason,omitempty"`   // Why the definition was rejected
+}
+
+// IngestReport summarizes a whole ingestion batch.
+type IngestReport struct {
+	Summary map[string]int `json:"summary"` // Number of results per status
+	Results []IngestResult `json:"results"` // One result per definition, in request order
+}
@@ -0,0 +1,72 @@
+// inventory.go
+package models
+
+import "time"
+
+// InventoryItem is a piece of club equipment lent to Complejos (e.g. bars, plates or lifting belts).
+type InventoryItem struct {
+	ID          string    `json:"_id" bson:"_id"`                 // Unique identifier (assigned by the server)
+	Name        string    `json:"name" bson:"name"`               // Name of the item (e.g. "20 kg Olympic bar")
+	Category    string    `json:"category" bson:"category"`       // "bar", "plates", "belt" or "other"
+	Description string    `json:"description" bson:"description"` // Optional details (size, condition, ...)
+	Quantity    int       `json:"quantity" bson:"quantity"`       // Units owned by the club
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`   // When it was added (assigned by the server)
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`   // When it was last changed (assigned by the server)
+}
+
+// InventoryItemInput is the payload creating or replacing an InventoryItem.
+type InventoryItemInput struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Category    string `json:"category" validate:"required,oneof=bar plates belt other"`
+	Description string `json:"description" validate:"max=500"`
+	Quantity    int    `json:"quantity" validate:"gte=1,lte=1000"`
+}
+
+// ItemAvailability is an InventoryItem with how many of its units are on loan and how many can be lent.
+type ItemAvailability struct {
+	InventoryItem
+	OnLoan    int `json:"on_loan"`
+	Available int `json:"available"`
+}
+
+// Loan records units of an InventoryItem lent to a Complejo until they are returned.
+type Loan struct {
+	ID         string     `json:"_id" bson:"_id"`                                     // Unique identifier (assigned by the server)
+	ItemID     string     `json:"item_id" bson:"item_id"`                             // Item lent
+	ComplejoID string     `json:"complejo_id" bson:"complejo_id"`                     // Complejo the item is lent to
+	Username   string     `json:"username" bson:"username"`                           // Username of the Complejo when it was lent
+	Quantity   int        `json:"quantity" bson:"quantity"`                           // Units lent
+	LentBy     string     `json:"lent_by" bson:"lent_by"`                             // ID of the admin who recorded the loan
+	LentAt     time.Time  `json:"lent_at" bson:"lent_at"`                             // When the item was lent
+	DueAt      time.Time  `json:"due_at" bson:"due_at"`                               // When the item must be returned
+	ReturnedAt *time.Time `json:"returned_at,omitempty" bson:"returned_at,omitempty"` // When the item was returned
+	RemindedAt *time.Time `json:"reminded_at,omitempty" bson:"reminded_at,omitempty"` // Last overdue reminder sent
+}
+
+// IsOverdue reports whether the loan is still open after its due date at the given time.
+func (l Loan) IsOverdue(now time.Time) bool {
+	return l.ReturnedAt == nil && l.DueAt.Before(now)
+}
+
+// LoanInput is the payload lending an InventoryItem to a Complejo.
+type LoanInput struct {
+	ItemID     string    `json:"item_id" validate:"required"`
+	ComplejoID string    `json:"complejo_id" validate:"required"`
+	Quantity   int       `json:"quantity" validate:"omitempty,gte=1"` // Default: 1
+	DueAt      time.Time `json:"due_at" validate:"required,future"`
+}
+
+// Statuses a loan listing can be filtered by.
+const (
+	LoanOpen     = "open"     // Not returned yet (including overdue loans)
+	LoanOverdue  = "overdue"  // Not returned after the due date
+	LoanReturned = "returned" // Returned
+)
+
+// LoanFilter narrows down a loan listing.
+// It is bound from the `?status=&item_id=&complejo_id=` query string of GET /inventory/loans.
+type LoanFilter struct {
+	Status     string `json:"status" form:"status" validate:"omitempty,oneof=open overdue returned"`
+	ItemID     string `json:"item_id" form:"item_id"`
+	ComplejoID string `json:"complejo_id" form:"complejo_id"`
+}
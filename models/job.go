@@ -0,0 +1,28 @@
+// job.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Statuses of the Jobs.
+const (
+	JobPending = "pending" // Waiting for a worker, or for its next attempt
+	JobDone    = "done"    // Ran successfully
+	JobFailed  = "failed"  // Given up after its last attempt
+)
+
+// Job is work done in the background by the job queue instead of during a request (e.g. sending the emails of
+// a domain event or making the thumbnail of a photo). Failed jobs are attempted again later.
+type Job struct {
+	ID         string          `json:"_id" bson:"_id"`                                   // Unique identifier; jobs enqueued again with the same ID are skipped
+	Kind       string          `json:"kind" bson:"kind"`                                 // What to do (e.g. "photo.thumbnail"), selecting the handler
+	Payload    json.RawMessage `json:"payload" bson:"payload"`                           // JSON arguments of the handler
+	Status     string          `json:"status" bson:"status"`                             // "pending", "done" or "failed"
+	Attempts   int             `json:"attempts" bson:"attempts"`                         // Number of attempts so far
+	LastError  string          `json:"last_error,omitempty" bson:"last_error,omitempty"` // Error of the last failed attempt
+	CreatedAt  time.Time       `json:"created_at" bson:"created_at"`                     // When the job was enqueued
+	RunAt      time.Time       `json:"run_at" bson:"run_at"`                             // Earliest time of the next attempt
+	FinishedAt *time.Time      `json:"finished_at" bson:"finished_at"`                   // When the job was done or given up (nil while pending)
+}
@@ -0,0 +1,19 @@
+// journal_entry.go
+package models
+
+import "time"
+
+// JournalEntry is the anonymized envelope of a request that failed with a 5xx status.
+// It keeps the shape of the request (never its values or the caller's identity) so the failure can be replayed.
+type JournalEntry struct {
+	ID             string    `json:"_id" bson:"_id"`                           // Unique identifier
+	Method         string    `json:"method" bson:"method"`                     // HTTP method
+	Route          string    `json:"route" bson:"route"`                       // Matched route template (e.g. "/event/:id")
+	Path           string    `json:"path" bson:"path"`                         // Requested path
+	BodySchema     string    `json:"body_schema" bson:"body_schema"`           // JSON structure of the body with every value replaced by its type
+	BodySchemaHash string    `json:"body_schema_hash" bson:"body_schema_hash"` // SHA-256 of BodySchema, to group identical failures
+	Role           string    `json:"role,omitempty" bson:"role,omitempty"`     // Role of the caller, when authenticated
+	Status         int       `json:"status" bson:"status"`                     // HTTP status of the response
+	Error          string    `json:"error,omitempty" bson:"error,omitempty"`   // Machine-readable error code
+	OccurredAt     time.Time `json:"occurred_at" bson:"occurred_at"`           // When the request was served
+}
@@ -0,0 +1,105 @@
+// leaderboard.go
+package models
+
+// Lifts a leaderboard ranks by.
+const (
+	LiftBench = "bench" // Bench press
+	LiftSquad = "squad" // Squat
+	LiftDL    = "dl"    // Deadlift
+	LiftTotal = "total" // Sum of the three, for the Complejos that recorded all three
+)
+
+// Scorings of a leaderboard relative to bodyweight.
+const (
+	ScoringWilks = "wilks" // Wilks coefficient (original formula)
+	ScoringDOTS  = "dots"  // DOTS coefficient
+)
+
+// Default and maximum page sizes of a leaderboard.
+const (
+	DefaultLeaderboardLimit = 20
+	MaxLeaderboardLimit     = 100
+)
+
+// LeaderboardQuery selects and paginates a leaderboard.
+// It is bound from the `?lift=&gender=&scoring=&page=&limit=` query string of GET /leaderboard.
+type LeaderboardQuery struct {
+	Lift    string `json:"lift" form:"lift" validate:"omitempty,oneof=bench squad dl total"` // Lift ranked by (default: "total")
+	Gender  string `json:"gender" form:"gender" validate:"omitempty,gender"`                 // Only the Complejos of this gender (default: every gender)
+	Scoring string `json:"scoring" form:"scoring" validate:"omitempty,oneof=wilks dots"`     // Rank by the score relative to bodyweight instead of the kilos (optional)
+	Page    int    `json:"page" form:"page" validate:"omitempty,min=1"`                      // 1-based page number (default: 1)
+	Limit   int    `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"`            // Page size (default: 20, at most 100)
+
+	Members []string `json:"-" form:"-"` // Only the Complejos with these IDs, such as the members of a group (default: every Complejo)
+}
+
+// Normalize fills in the default lift, page and page size.
+func (q *LeaderboardQuery) Normalize() {
+	if q.Lift == "" {
+		q.Lift = LiftTotal
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultLeaderboardLimit
+	}
+	if q.Limit > MaxLeaderboardLimit {
+		q.Limit = MaxLeaderboardLimit
+	}
+}
+
+// Offset returns the number of entries skipped before the requested page.
+func (q LeaderboardQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// LeaderboardEntry is the place of a Complejo in a leaderboard. Complejos with the same kilos (or score) share
+// their rank.
+type LeaderboardEntry struct {
+	Rank       int64    `json:"rank" bson:"rank"`                       // 1-based rank
+	ComplejoID string   `json:"complejo_id" bson:"_id"`                 // Complejo ranked
+	Username   string   `json:"username" bson:"username"`               // Current username of the Complejo
+	Gender     string   `json:"gender" bson:"gender"`                   // "male", "female" or "other"
+	Kilos      float64  `json:"kilos" bson:"kilos"`                     // Weight of the lift (or total) in kilograms
+	Score      *float64 `json:"score,omitempty" bson:"score,omitempty"` // Wilks or DOTS score, when ranked by one
+}
+
+// Leaderboard is a page of the ranking of the Complejos by a lift, with the place of the caller.
+type Leaderboard struct {
+	Lift    string             `json:"lift"`              // "bench", "squad", "dl" or "total"
+	Gender  string             `json:"gender,omitempty"`  // Gender ranked, when restricted to one
+	Scoring string             `json:"scoring,omitempty"` // "wilks" or "dots", when ranked by a score
+	Entries []LeaderboardEntry `json:"entries"`           // Entries of the page, best first
+	Me      *LeaderboardEntry  `json:"me,omitempty"`      // Place of the authenticated caller, when ranked
+}
+
+// ScoringFormula turns a lift into a score relative to bodyweight: the lift times 500 divided by the polynomial
+// of the bodyweight (kilograms, clamped to [MinBodyweight, MaxBodyweight]) with the given coefficients, lowest
+// degree first.
+type ScoringFormula struct {
+	MinBodyweight float64
+	MaxBodyweight float64
+	Coefficients  []float64
+}
+
+// ScoringFormulas are the formulas of each scoring by gender. They are only defined for "male" and "female":
+// Complejos of another gender, or without a recorded bodyweight, are left out of the scored leaderboards.
+var ScoringFormulas = map[string]map[string]ScoringFormula{
+	ScoringWilks: {
+		"male": {MinBodyweight: 40, MaxBodyweight: 201.9, Coefficients: []float64{
+			-216.0475144, 16.2606339, -0.002388645, -0.00113732, 7.01863e-06, -1.291e-08,
+		}},
+		"female": {MinBodyweight: 26.51, MaxBodyweight: 154.53, Coefficients: []float64{
+			594.31747775582, -27.23842536447, 0.82112226871, -0.00930733913, 4.731582e-05, -9.054e-08,
+		}},
+	},
+	ScoringDOTS: {
+		"male": {MinBodyweight: 40, MaxBodyweight: 210, Coefficients: []float64{
+			-307.75076, 24.0900756, -0.1918759221, 0.0007391293, -0.000001093,
+		}},
+		"female": {MinBodyweight: 40, MaxBodyweight: 150, Coefficients: []float64{
+			-57.96288, 13.6175032, -0.1126655495, 0.0005158568, -0.0000010706,
+		}},
+	},
+}
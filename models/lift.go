@@ -0,0 +1,84 @@
+// lift.go
+package models
+
+import "time"
+
+// LiftEntry is a lift performed by a Complejo, in its lift history. The best weight of each lift is also kept
+// on the profile of the Complejo (bench, squad and dl), for fast reads.
+type LiftEntry struct {
+	ID         string    `json:"_id" bson:"_id"`                                   // Unique identifier (assigned by the server)
+	ComplejoID string    `json:"complejo_id" bson:"complejo_id"`                   // Complejo that performed the lift
+	Lift       string    `json:"lift" bson:"lift"`                                 // "bench", "squad" or "dl"
+	Weight     float64   `json:"weight" bson:"weight"`                             // Weight lifted in kilograms
+	Reps       int       `json:"reps" bson:"reps"`                                 // Repetitions of the weight
+	E1RM       float64   `json:"e1rm" bson:"e1rm"`                                 // Estimated one-rep max in kilograms (the weight for a single)
+	Bodyweight float64   `json:"bodyweight,omitempty" bson:"bodyweight,omitempty"` // Bodyweight of the Complejo in kilograms (0 when unknown)
+	Date       time.Time `json:"date" bson:"date"`                                 // When the lift was performed
+	Record     bool      `json:"record" bson:"record"`                             // The lift beat the best of the Complejo when it was recorded
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`                     // When the lift was recorded (assigned by the server)
+}
+
+// LiftEntryInput is the payload of POST /complejo/me/lifts.
+type LiftEntryInput struct {
+	Lift       string     `json:"lift" validate:"required,oneof=bench squad dl"` // "bench", "squad" or "dl"
+	Weight     float64    `json:"weight" validate:"gt=0,lte=1000"`               // Weight lifted in kilograms
+	Reps       int        `json:"reps" validate:"omitempty,min=1,max=20"`        // Repetitions of the weight (default: 1, at most 20)
+	Bodyweight *float64   `json:"bodyweight" validate:"omitnil,gt=0,lte=500"`    // Bodyweight in kilograms (default: the weight on the profile)
+	Date       *time.Time `json:"date"`                                          // When the lift was performed (default: now, never in the future)
+}
+
+// OneRepMaxQuery is bound from the `?weight=&reps=` query string of GET /tools/1rm.
+type OneRepMaxQuery struct {
+	Weight float64 `json:"weight" form:"weight" validate:"gt=0,lte=1000"`     // Weight lifted in kilograms
+	Reps   int     `json:"reps" form:"reps" validate:"required,min=1,max=20"` // Repetitions of the weight (at most 20)
+}
+
+// OneRepMax estimates the heaviest single a lifter could perform from a set of several repetitions.
+type OneRepMax struct {
+	Weight   float64 `json:"weight"`   // Weight lifted in kilograms
+	Reps     int     `json:"reps"`     // Repetitions of the weight
+	Epley    float64 `json:"epley"`    // Estimate of the Epley formula: weight × (1 + reps / 30)
+	Brzycki  float64 `json:"brzycki"`  // Estimate of the Brzycki formula: weight × 36 / (37 − reps)
+	Estimate float64 `json:"estimate"` // Average of both estimates, kept as the e1rm of the lift entries
+}
+
+// LiftHistoryQuery is bound from the `?lift=` query string of GET /complejo/:id/lifts.
+type LiftHistoryQuery struct {
+	Lift string `json:"lift" form:"lift" validate:"omitempty,oneof=bench squad dl"` // Only the entries of this lift (default: every lift)
+}
+
+// Default and maximum number of months of the progress statistics.
+const (
+	DefaultProgressMonths = 12
+	MaxProgressMonths     = 60
+)
+
+// ProgressQuery is bound from the `?months=` query string of GET /complejo/me/progress.
+type ProgressQuery struct {
+	Months int `json:"months" form:"months" validate:"omitempty,min=1,max=60"` // Number of months up to the current one (default: 12, at most 60)
+}
+
+// LiftMonth aggregates the lift history of a Complejo over a calendar month.
+type LiftMonth struct {
+	Month      string  `bson:"_id"`        // Calendar month ("2006-01")
+	Bodyweight float64 `bson:"bodyweight"` // Average bodyweight of the entries recording one (0 when none)
+	Bench      float64 `bson:"bench"`      // Heaviest bench press of the month (0 when none)
+	Squad      float64 `bson:"squad"`      // Heaviest squat of the month (0 when none)
+	DL         float64 `bson:"dl"`         // Heaviest deadlift of the month (0 when none)
+}
+
+// ProgressMonth is a point of the monthly trends of a Complejo. Values without entries in the month are null.
+type ProgressMonth struct {
+	Month          string   `json:"month"`           // Calendar month ("2006-01")
+	Bodyweight     *float64 `json:"bodyweight"`      // Average bodyweight recorded with the lifts of the month
+	Bench          *float64 `json:"bench"`           // Heaviest bench press of the month
+	Squad          *float64 `json:"squad"`           // Heaviest squat of the month
+	DL             *float64 `json:"dl"`              // Heaviest deadlift of the month
+	EstimatedTotal *float64 `json:"estimated_total"` // Sum of the best of each lift up to the end of the month, once all three are recorded
+}
+
+// Progress are the monthly trends of the lift history of a Complejo, for charts.
+type Progress struct {
+	Timezone string          `json:"timezone"` // Time zone of the calendar months
+	Months   []ProgressMonth `json:"months"`   // Every month of the range, oldest first
+}
@@ -0,0 +1,79 @@
+// lost_found.go
+package models
+
+import "time"
+
+// Kinds of lost-and-found posts.
+const (
+	LostItemLost  = "lost"  // A member lost the item
+	LostItemFound = "found" // A member found the item at the club
+)
+
+// Statuses of a lost-and-found post.
+const (
+	LostItemHeld     = "held"     // Flagged by the content filter, hidden until an admin approves it
+	LostItemOpen     = "open"     // Waiting for its owner or finder
+	LostItemResolved = "resolved" // A claim was approved by an admin
+)
+
+// Statuses of a claim on a lost-and-found post.
+const (
+	ClaimPending  = "pending"
+	ClaimApproved = "approved"
+	ClaimRejected = "rejected"
+)
+
+// LostItem is a post of the lost-and-found board. Posts expire some time after they are published.
+type LostItem struct {
+	ID          string     `json:"_id" bson:"_id"`                                     // Unique identifier (assigned by the server)
+	Kind        string     `json:"kind" bson:"kind"`                                   // "lost" or "found"
+	Title       string     `json:"title" bson:"title"`                                 // Short description of the item
+	Description string     `json:"description" bson:"description"`                     // Details (color, brand, where it was seen...)
+	Photo       string     `json:"photo,omitempty" bson:"photo"`                       // Base64-encoded photo (optional)
+	PostedBy    string     `json:"posted_by" bson:"posted_by"`                         // ID of the Complejo that posted it
+	Username    string     `json:"username" bson:"username"`                           // Username of the Complejo that posted it
+	Status      string     `json:"status" bson:"status"`                               // "held", "open" or "resolved"
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`                       // When it was posted
+	ExpiresAt   time.Time  `json:"expires_at" bson:"expires_at"`                       // When it is removed from the board
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty" bson:"resolved_at,omitempty"` // When a claim was approved
+}
+
+// LostItemInput is the payload posting a LostItem.
+type LostItemInput struct {
+	Kind        string `json:"kind" validate:"required,oneof=lost found"`
+	Title       string `json:"title" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=1000"`
+	Photo       string `json:"photo"` // Base64 or data: URL, normalized like profile photos
+}
+
+// LostItemQuery is bound from the `?kind=&include=` query string of GET /lost-found.
+type LostItemQuery struct {
+	Kind    string `json:"kind" form:"kind" validate:"omitempty,oneof=lost found"`  // Only the posts of this kind
+	Include string `json:"include" form:"include" validate:"omitempty,oneof=photo"` // "photo" to include the photos
+}
+
+// Claim is a member's request to collect a found item, or report that they found a lost one.
+// Admins approve or reject it.
+type Claim struct {
+	ID         string     `json:"_id" bson:"_id"`                                   // Unique identifier (assigned by the server)
+	ItemID     string     `json:"item_id" bson:"item_id"`                           // Post the claim is about
+	ClaimantID string     `json:"claimant_id" bson:"claimant_id"`                   // ID of the claiming Complejo
+	Username   string     `json:"username" bson:"username"`                         // Username of the claiming Complejo
+	Message    string     `json:"message" bson:"message"`                           // Proof of ownership or where the item is
+	Status     string     `json:"status" bson:"status"`                             // "pending", "approved" or "rejected"
+	Note       string     `json:"note,omitempty" bson:"note,omitempty"`             // Admin's note on the decision
+	DecidedBy  string     `json:"decided_by,omitempty" bson:"decided_by,omitempty"` // ID of the admin who decided
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`                     // When the claim was made
+	DecidedAt  *time.Time `json:"decided_at,omitempty" bson:"decided_at,omitempty"` // When the claim was decided
+}
+
+// ClaimInput is the payload claiming a LostItem.
+type ClaimInput struct {
+	Message string `json:"message" validate:"required,max=1000"`
+}
+
+// ClaimDecision is the payload approving or rejecting a Claim.
+type ClaimDecision struct {
+	Status string `json:"status" validate:"required,oneof=approved rejected"`
+	Note   string `json:"note" validate:"max=500"`
+}
@@ -0,0 +1,11 @@
+// media.go
+package models
+
+// Media is a stored image served by GET /media/:id: a profile photo or an album photo, or one of their sizes.
+type Media struct {
+	Data        []byte // Content of the image
+	ContentType string // MIME type of Data ("image/jpeg" or "image/png")
+	ETag        string // Strong entity tag of Data, quoted
+	Private     bool   // Whether only some users may see it, so that shared caches must not keep it
+	Immutable   bool   // Whether the content under this URL never changes, so that it may be cached for good
+}
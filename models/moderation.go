@@ -0,0 +1,132 @@
+// moderation.go
+package models
+
+import "time"
+
+// Kinds of content held for review.
+const (
+	HoldUsername = "username"  // The username of a Complejo, set when signing up or updating the profile
+	HoldLostItem = "lost_item" // The title and description of a lost-and-found post
+)
+
+// Statuses of a ContentHold.
+const (
+	HoldPending  = "pending"
+	HoldApproved = "approved"
+	HoldRejected = "rejected"
+)
+
+// ContentHold is user content the content filter flagged, waiting for an admin to approve or reject it.
+type ContentHold struct {
+	ID        string     `json:"_id" bson:"_id"`                                   // Unique identifier (assigned by the server)
+	Kind      string     `json:"kind" bson:"kind"`                                 // "username" or "lost_item"
+	TargetID  string     `json:"target_id" bson:"target_id"`                       // ID of the Complejo or LostItem
+	AuthorID  string     `json:"author_id" bson:"author_id"`                       // ID of the Complejo that wrote the content
+	Text      string     `json:"text" bson:"text"`                                 // Flagged content
+	Previous  string     `json:"previous,omitempty" bson:"previous,omitempty"`     // Username restored when a renaming is rejected
+	Locale    string     `json:"locale" bson:"locale"`                             // Locale whose word list was applied
+	Reasons   []string   `json:"reasons" bson:"reasons"`                           // "profanity" and/or "link_spam"
+	Status    string     `json:"status" bson:"status"`                             // "pending", "approved" or "rejected"
+	Note      string     `json:"note,omitempty" bson:"note,omitempty"`             // Explanation of the decision
+	DecidedBy string     `json:"decided_by,omitempty" bson:"decided_by,omitempty"` // ID of the admin that decided it
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`                     // When the content was held
+	DecidedAt *time.Time `json:"decided_at,omitempty" bson:"decided_at,omitempty"` // When it was decided
+}
+
+// HoldQuery is bound from the `?status=` query string of GET /moderation/holds.
+type HoldQuery struct {
+	Status string `json:"status" form:"status" validate:"omitempty,oneof=pending approved rejected"` // Default "pending"
+}
+
+// HoldDecision is the payload approving or rejecting a ContentHold.
+type HoldDecision struct {
+	Status string `json:"status" validate:"required,oneof=approved rejected"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+// Kinds of content reported by the users.
+const (
+	ReportEvent    = "event"    // An Event
+	ReportComplejo = "complejo" // The profile of a Complejo
+)
+
+// Reasons of a ContentReport.
+const (
+	ReasonSpam          = "spam"
+	ReasonHarassment    = "harassment"
+	ReasonInappropriate = "inappropriate"
+	ReasonOther         = "other"
+)
+
+// Statuses of a ContentReport.
+const (
+	ReportPending   = "pending"   // Waiting in the moderation queue
+	ReportDismissed = "dismissed" // Reviewed, nothing done
+	ReportActioned  = "actioned"  // Reviewed, the content was removed
+)
+
+// Default and maximum page sizes of the moderation queue.
+const (
+	DefaultReportLimit = 20
+	MaxReportLimit     = 100
+)
+
+// ContentReport is content a user reported, waiting in the moderation queue for an admin to dismiss it or act
+// on it.
+type ContentReport struct {
+	ID         string     `json:"_id" bson:"_id"`                                   // Unique identifier (assigned by the server)
+	Kind       string     `json:"kind" bson:"kind"`                                 // "event" or "complejo"
+	TargetID   string     `json:"target_id" bson:"target_id"`                       // ID of the Event or Complejo
+	ReporterID string     `json:"reporter_id" bson:"reporter_id"`                   // ID of the Complejo that reported it
+	Reason     string     `json:"reason" bson:"reason"`                             // "spam", "harassment", "inappropriate" or "other"
+	Details    string     `json:"details,omitempty" bson:"details,omitempty"`       // What the reporter explained
+	Status     string     `json:"status" bson:"status"`                             // "pending", "dismissed" or "actioned"
+	Note       string     `json:"note,omitempty" bson:"note,omitempty"`             // Explanation of the decision
+	DecidedBy  string     `json:"decided_by,omitempty" bson:"decided_by,omitempty"` // ID of the admin that decided it
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`                     // When it was reported
+	DecidedAt  *time.Time `json:"decided_at,omitempty" bson:"decided_at,omitempty"` // When it was decided
+}
+
+// ContentReportInput is the payload of POST /report.
+type ContentReportInput struct {
+	Kind     string `json:"kind" validate:"required,oneof=event complejo"`                        // "event" or "complejo"
+	TargetID string `json:"target_id" validate:"required"`                                        // ID of the Event or Complejo
+	Reason   string `json:"reason" validate:"required,oneof=spam harassment inappropriate other"` // Why it is reported
+	Details  string `json:"details" validate:"max=1000"`                                          // Optional explanation
+}
+
+// ContentReportQuery selects and paginates the moderation queue.
+// It is bound from the `?status=&kind=&page=&limit=` query string of GET /moderation/reports.
+type ContentReportQuery struct {
+	Status string `json:"status" form:"status" validate:"omitempty,oneof=pending dismissed actioned"` // Default "pending"
+	Kind   string `json:"kind" form:"kind" validate:"omitempty,oneof=event complejo"`                 // Only the reports of this kind
+	Page   int    `json:"page" form:"page" validate:"omitempty,min=1"`                                // 1-based page number (default: 1)
+	Limit  int    `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"`                      // Page size (default: 20, at most 100)
+}
+
+// Normalize fills in the default status, page and page size.
+func (q *ContentReportQuery) Normalize() {
+	if q.Status == "" {
+		q.Status = ReportPending
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultReportLimit
+	}
+	if q.Limit > MaxReportLimit {
+		q.Limit = MaxReportLimit
+	}
+}
+
+// Offset returns the number of reports skipped before the requested page.
+func (q ContentReportQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// ContentReportDecision is the payload dismissing a ContentReport or acting on it.
+type ContentReportDecision struct {
+	Status string `json:"status" validate:"required,oneof=dismissed actioned"`
+	Note   string `json:"note" validate:"max=500"`
+}
@@ -0,0 +1,95 @@
+// nutrition.go
+package models
+
+import "time"
+
+// Goals of a Complejo its nutrition targets are derived from.
+const (
+	GoalLose     = "lose"     // Lose fat
+	GoalMaintain = "maintain" // Keep the current bodyweight
+	GoalGain     = "gain"     // Gain muscle
+)
+
+// Periods the nutrition logs are summarized by.
+const (
+	PeriodWeek  = "week"  // Weeks starting on Monday
+	PeriodMonth = "month" // Calendar months
+)
+
+// Default and maximum number of periods of the nutrition summaries, by period.
+var (
+	DefaultNutritionPeriods = map[string]int{PeriodWeek: 8, PeriodMonth: 6}
+	MaxNutritionPeriods     = map[string]int{PeriodWeek: 52, PeriodMonth: 24}
+)
+
+// DefaultNutritionDays is the number of days, up to today, listed by default.
+const DefaultNutritionDays = 30
+
+// NutritionLog is what a Complejo ate over a day. A Complejo has at most one log by day, and only it can read or
+// change them.
+type NutritionLog struct {
+	ID         string    `json:"_id" bson:"_id"`                 // Unique identifier (assigned by the server)
+	ComplejoID string    `json:"complejo_id" bson:"complejo_id"` // Complejo that ate
+	Date       string    `json:"date" bson:"date"`               // Day logged ("2006-01-02")
+	Calories   int       `json:"calories" bson:"calories"`       // Energy, in kcal
+	Protein    float64   `json:"protein" bson:"protein"`         // Protein, in grams
+	Carbs      float64   `json:"carbs" bson:"carbs"`             // Carbohydrates, in grams
+	Fat        float64   `json:"fat" bson:"fat"`                 // Fat, in grams
+	Notes      string    `json:"notes" bson:"notes"`             // Free notes of the day
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`   // When it was logged (assigned by the server)
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`   // When it was last changed (assigned by the server)
+}
+
+// NutritionInput is the payload logging or replacing a NutritionLog.
+type NutritionInput struct {
+	Date     string  `json:"date" validate:"omitempty,datetime=2006-01-02"` // Day logged (default: today, or unchanged on a replacement; never in the future)
+	Calories int     `json:"calories" validate:"gte=0,lte=20000"`
+	Protein  float64 `json:"protein" validate:"gte=0,lte=2000"`
+	Carbs    float64 `json:"carbs" validate:"gte=0,lte=2000"`
+	Fat      float64 `json:"fat" validate:"gte=0,lte=2000"`
+	Notes    string  `json:"notes" validate:"max=500"`
+}
+
+// NutritionQuery is bound from the `?from=&to=` query string of GET /nutrition.
+type NutritionQuery struct {
+	From string `json:"from" form:"from" validate:"omitempty,datetime=2006-01-02"` // First day (default: 29 days before the last one)
+	To   string `json:"to" form:"to" validate:"omitempty,datetime=2006-01-02"`     // Last day (default: today)
+}
+
+// NutritionSummaryQuery is bound from the `?period=&periods=` query string of GET /nutrition/summary.
+type NutritionSummaryQuery struct {
+	Period  string `json:"period" form:"period" validate:"omitempty,oneof=week month"` // "week" or "month" (default: "week")
+	Periods int    `json:"periods" form:"periods" validate:"omitempty,min=1,max=52"`   // Number of periods up to the current one (default: 8 weeks or 6 months, at most 52 weeks or 24 months)
+}
+
+// Macros are the energy and macronutrients eaten, or aimed at.
+type Macros struct {
+	Calories float64 `json:"calories"` // Energy, in kcal
+	Protein  float64 `json:"protein"`  // Protein, in grams
+	Carbs    float64 `json:"carbs"`    // Carbohydrates, in grams
+	Fat      float64 `json:"fat"`      // Fat, in grams
+}
+
+// NutritionPeriod sums the nutrition logs of a week or a month.
+type NutritionPeriod struct {
+	Start        string  `json:"start"`                   // First day of the period ("2006-01-02")
+	End          string  `json:"end"`                     // Last day of the period ("2006-01-02")
+	DaysLogged   int     `json:"days_logged"`             // Days of the period with a log
+	Total        Macros  `json:"total"`                   // Sum of the logs
+	DailyAverage *Macros `json:"daily_average,omitempty"` // Average of the days logged, rounded to one decimal (absent when none)
+}
+
+// NutritionSummary sums the nutrition logs of a Complejo by week or month, for charts.
+type NutritionSummary struct {
+	Period   string            `json:"period"`            // "week" or "month"
+	Timezone string            `json:"timezone"`          // Time zone of the current day
+	Targets  *NutritionTargets `json:"targets,omitempty"` // Daily targets of the Complejo, when its bodyweight is known
+	Periods  []NutritionPeriod `json:"periods"`           // Every period of the range, oldest first
+}
+
+// NutritionTargets are the daily targets of a Complejo, derived from its bodyweight and goal.
+type NutritionTargets struct {
+	Goal       string  `json:"goal"`       // "lose", "maintain" or "gain"
+	Bodyweight float64 `json:"bodyweight"` // Bodyweight of the profile, in kilograms
+	Macros
+}
@@ -0,0 +1,37 @@
+// offboarding.go
+package models
+
+import "time"
+
+// Statuses of the offboarding of the club.
+const (
+	OffboardingPending = "pending" // Requested, waiting for the export and the confirmation
+	OffboardingPurged  = "purged"  // Confirmed: the data of the club was removed
+)
+
+// Offboarding is the removal of every data of the club before it leaves the platform. It is requested by an admin,
+// who receives a confirmation code once, and can only be confirmed with that code after the club downloaded an
+// export and the cooling-off delay elapsed. There is at most one Offboarding, kept after the purge.
+type Offboarding struct {
+	Status      string     `json:"status" bson:"status"`                               // "pending" or "purged"
+	RequestedBy string     `json:"requested_by" bson:"requested_by"`                   // Admin that requested the offboarding
+	RequestedAt time.Time  `json:"requested_at" bson:"requested_at"`                   // When the offboarding was requested
+	PurgeAfter  time.Time  `json:"purge_after" bson:"purge_after"`                     // Earliest time the purge can be confirmed
+	CodeHash    string     `json:"-" bson:"code_hash"`                                 // SHA-256 of the confirmation code (hex)
+	ExportedAt  *time.Time `json:"exported_at,omitempty" bson:"exported_at,omitempty"` // When the data was last exported since the request
+	PurgedBy    string     `json:"purged_by,omitempty" bson:"purged_by,omitempty"`     // Admin that confirmed the purge
+	PurgedAt    *time.Time `json:"purged_at,omitempty" bson:"purged_at,omitempty"`     // When the data was purged
+
+	Code    string           `json:"confirmation_code,omitempty" bson:"-"` // Confirmation code (returned once, when requested)
+	Removed map[string]int64 `json:"removed,omitempty" bson:"-"`           // Records removed by collection or table (returned by the purge)
+}
+
+// OffboardingConfirmation is the payload of POST /admin/offboarding/confirm.
+type OffboardingConfirmation struct {
+	Code string `json:"code" validate:"required,max=64"` // Confirmation code returned when the offboarding was requested
+}
+
+// CSVExportQuery is bound from the query string of GET /admin/export/complejos.csv and GET /admin/export/events.csv.
+type CSVExportQuery struct {
+	Fields string `json:"fields" form:"fields"` // Comma-separated columns to export, in order (default: every column)
+}
@@ -0,0 +1,20 @@
+// outbox_message.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxMessage is an outgoing notification or webhook recorded in the same transaction
+// as the change that triggered it, and delivered later by the outbox dispatcher.
+type OutboxMessage struct {
+	ID            string          `json:"_id" bson:"_id"`                                   // Unique identifier of the message
+	Topic         string          `json:"topic" bson:"topic"`                               // What happened (e.g. "event.created")
+	Payload       json.RawMessage `json:"payload" bson:"payload"`                           // JSON body delivered to the handler
+	CreatedAt     time.Time       `json:"created_at" bson:"created_at"`                     // When the triggering change happened
+	NextAttemptAt time.Time       `json:"next_attempt_at" bson:"next_attempt_at"`           // Earliest time the message may be (re)claimed
+	Attempts      int             `json:"attempts" bson:"attempts"`                         // Number of delivery attempts so far
+	LastError     string          `json:"last_error,omitempty" bson:"last_error,omitempty"` // Error of the last failed attempt
+	DispatchedAt  *time.Time      `json:"dispatched_at" bson:"dispatched_at"`               // When the message was delivered (nil while pending)
+}
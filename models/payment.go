@@ -0,0 +1,67 @@
+// payment.go
+package models
+
+import "time"
+
+// Payment is a card payment recorded from Stripe webhooks. Amounts are in the smallest unit of the currency (e.g. cents).
+type Payment struct {
+	ID             string    `json:"_id" bson:"_id"`                         // Stripe charge ID
+	EventID        string    `json:"event_id" bson:"event_id"`               // Event paid for ("" when not tied to an event)
+	Amount         int64     `json:"amount" bson:"amount"`                   // Amount charged
+	AmountRefunded int64     `json:"amount_refunded" bson:"amount_refunded"` // Amount refunded so far
+	Currency       string    `json:"currency" bson:"currency"`               // Lowercase ISO currency code
+	PaidAt         time.Time `json:"paid_at" bson:"paid_at"`                 // When the charge was created
+	UpdatedAt      time.Time `json:"updated_at" bson:"updated_at"`           // When the last webhook was recorded
+}
+
+// RevenueRow aggregates the payments of an Event in a currency during a month.
+type RevenueRow struct {
+	EventID  string
+	Month    string // "2006-01", in the gym's time zone
+	Currency string
+	Payments int
+	Gross    int64
+	Refunded int64
+}
+
+// FinanceQuery is bound from the query string of GET /admin/finance/summary.
+type FinanceQuery struct {
+	From   *time.Time `json:"from" form:"from" time_format:"2006-01-02T15:04:05Z07:00"` // Payments on or after this time (default: 12 months ago)
+	To     *time.Time `json:"to" form:"to" time_format:"2006-01-02T15:04:05Z07:00"`     // Payments before this time (default: now)
+	Format string     `json:"format" form:"format" validate:"omitempty,oneof=json csv"` // "csv" to download the summary
+}
+
+// Revenue is a revenue total: refunds are netted out of the gross amount, and expenses out of the net revenue.
+type Revenue struct {
+	Currency string `json:"currency"`
+	Payments int    `json:"payments"`
+	Gross    int64  `json:"gross"`
+	Refunded int64  `json:"refunded"`
+	Net      int64  `json:"net"`
+	Expenses int64  `json:"expenses"`
+	Profit   int64  `json:"profit"` // Net revenue minus expenses (negative for a loss)
+}
+
+// EventRevenue is the revenue of a single Event.
+type EventRevenue struct {
+	EventID string `json:"event_id"` // "" for payments not tied to an event
+	Title   string `json:"title"`
+	Revenue
+}
+
+// MonthRevenue is the revenue of a calendar month.
+type MonthRevenue struct {
+	Month string `json:"month"` // "2006-01"
+	Revenue
+}
+
+// FinanceSummary is the revenue and profit of the events over a period, per event, per month and in total.
+// Amounts are in the smallest unit of their currency; currencies are never mixed.
+type FinanceSummary struct {
+	From     time.Time      `json:"from"`
+	To       time.Time      `json:"to"`
+	Timezone string         `json:"timezone"`
+	Events   []EventRevenue `json:"events"`
+	Months   []MonthRevenue `json:"months"`
+	Totals   []Revenue      `json:"totals"`
+}
@@ -0,0 +1,132 @@
+// photo.go
+package models
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// Photo-consent privacy settings of a Complejo: whether it may be tagged in the photos of event albums.
+const (
+	PhotoConsentAllow = "allow" // Tags are granted at once
+	PhotoConsentAsk   = "ask"   // Each tag waits for the Complejo to grant it (the default)
+	PhotoConsentDeny  = "deny"  // The Complejo cannot be tagged
+)
+
+// Moderation statuses of an album photo.
+const (
+	PhotoPending  = "pending"  // Uploaded by a participant, waiting for an organizer
+	PhotoApproved = "approved" // Approved by an organizer, or uploaded by one
+)
+
+// Consent of a Complejo tagged in an album photo.
+const (
+	TagPending = "pending" // Waiting for the Complejo to grant or refuse it
+	TagGranted = "granted"
+	TagRefused = "refused" // Only ever an answer: a refused photo is removed
+)
+
+// Size variants of the uploaded photos, made in the background next to the original.
+const (
+	PhotoSizeThumb  = "thumb"  // Fits in 96 pixels
+	PhotoSizeMedium = "medium" // Fits in 480 pixels
+)
+
+// PhotoSizes lists the size variants of the uploaded photos.
+var PhotoSizes = []string{PhotoSizeThumb, PhotoSizeMedium}
+
+// PhotoSizeQuery selects the size variant of a downloaded photo.
+// It is bound from the `?size=` query string of the photo downloads.
+type PhotoSizeQuery struct {
+	Size string `json:"size" form:"size" validate:"omitempty,oneof=thumb medium"` // "thumb" or "medium" (default: the original)
+}
+
+// VariantKey returns the object store key of the size variant of the photo stored under key, next to it:
+// "photos/<event ID>/<ID>.jpg" has its thumbnail under "photos/<event ID>/<ID>_thumb.jpg".
+func VariantKey(key, size string) string {
+	ext := path.Ext(key)
+	return strings.TrimSuffix(key, ext) + "_" + size + ext
+}
+
+// variantURLs returns the URLs of the size variants of the photo served at url.
+func variantURLs(url string) map[string]string {
+	urls := make(map[string]string, len(PhotoSizes))
+	for _, size := range PhotoSizes {
+		urls[size] = url + "?size=" + size
+	}
+	return urls
+}
+
+// EventPhoto is a photo of the album of an Event. It is only shown to everyone once an organizer approved it
+// and every Complejo tagged in it granted its consent.
+type EventPhoto struct {
+	ID          string            `json:"_id" bson:"_id"`                                     // Unique identifier (assigned by the server)
+	EventID     string            `json:"event_id" bson:"event_id"`                           // Event whose album the photo belongs to
+	Caption     string            `json:"caption,omitempty" bson:"caption"`                   // Caption (optional)
+	ContentType string            `json:"content_type" bson:"content_type"`                   // "image/jpeg" or "image/png"
+	Width       int               `json:"width" bson:"width"`                                 // Width of the stored photo in pixels
+	Height      int               `json:"height" bson:"height"`                               // Height of the stored photo in pixels
+	Thumbnail   string            `json:"thumbnail,omitempty" bson:"thumbnail"`               // Photo scaled down to 96 pixels, as a data URL
+	URL         string            `json:"url" bson:"-"`                                       // Path downloading the photo (set by SetURLs)
+	Sizes       map[string]string `json:"sizes" bson:"-"`                                     // Paths downloading its size variants, by size (set by SetURLs)
+	Key         string            `json:"-" bson:"key"`                                       // Key of the photo in the object store
+	Tags        []PhotoTag        `json:"tags" bson:"tags"`                                   // Complejos appearing in the photo
+	Status      string            `json:"status" bson:"status"`                               // "pending" or "approved"
+	UploadedBy  string            `json:"uploaded_by" bson:"uploaded_by"`                     // ID of the Complejo that uploaded it
+	Username    string            `json:"username" bson:"username"`                           // Username of the Complejo that uploaded it
+	CreatedAt   time.Time         `json:"created_at" bson:"created_at"`                       // When it was uploaded
+	ApprovedBy  string            `json:"approved_by,omitempty" bson:"approved_by,omitempty"` // ID of the organizer that approved it
+	ApprovedAt  *time.Time        `json:"approved_at,omitempty" bson:"approved_at,omitempty"` // When it was approved
+}
+
+// SetURLs sets the paths downloading the photo and its size variants. A variant that is not made yet is served
+// as the original.
+func (p *EventPhoto) SetURLs() {
+	p.URL = "/event/" + p.EventID + "/photos/" + p.ID
+	p.Sizes = variantURLs(p.URL)
+}
+
+// PhotoTag is a Complejo appearing in an album photo, with its consent to be shown.
+type PhotoTag struct {
+	ComplejoID string     `json:"complejo_id" bson:"complejo_id"`                   // Complejo appearing in the photo
+	Username   string     `json:"username" bson:"username"`                         // Username of the Complejo when it was tagged
+	Consent    string     `json:"consent" bson:"consent"`                           // "pending" or "granted"
+	DecidedAt  *time.Time `json:"decided_at,omitempty" bson:"decided_at,omitempty"` // When the consent was granted
+}
+
+// Published reports whether the photo is shown to everyone: approved, and granted by every Complejo tagged in it.
+func (p EventPhoto) Published() bool {
+	if p.Status != PhotoApproved {
+		return false
+	}
+	for _, tag := range p.Tags {
+		if tag.Consent != TagGranted {
+			return false
+		}
+	}
+	return true
+}
+
+// FindTag returns the tag of the Complejo, or nil when it is not tagged in the photo.
+func (p EventPhoto) FindTag(complejoID string) *PhotoTag {
+	for i := range p.Tags {
+		if p.Tags[i].ComplejoID == complejoID {
+			return &p.Tags[i]
+		}
+	}
+	return nil
+}
+
+// EventPhotoInput is the payload adding a photo to the album of an Event.
+type EventPhotoInput struct {
+	Photo   string   `json:"photo" validate:"required"`              // Base64 or data: URL, normalized like profile photos
+	Caption string   `json:"caption" validate:"max=200"`             // Caption (optional)
+	Tagged  []string `json:"tagged" validate:"max=20,dive,required"` // IDs of the Complejos appearing in the photo
+}
+
+// PhotoConsentInput is the answer of a Complejo tagged in an album photo, bound from the body of
+// PUT /event/:id/photos/:photo_id/consent.
+type PhotoConsentInput struct {
+	Consent string `json:"consent" validate:"required,oneof=granted refused"` // "granted" or "refused"
+}
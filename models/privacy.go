@@ -0,0 +1,99 @@
+// privacy.go
+package models
+
+import "time"
+
+// Audiences of a profile field: who, besides admins, sees it.
+const (
+	AudienceEveryone  = "everyone"  // Every caller, authenticated or not
+	AudienceFollowers = "followers" // The Complejo itself and its followers
+	AudienceMe        = "me"        // The Complejo itself
+)
+
+// Relations of the caller to a profile, deciding the fields it sees.
+const (
+	RelationSelf     = "self"     // The Complejo itself, or an admin: every field is seen
+	RelationFollower = "follower" // A follower of the Complejo
+	RelationOther    = "other"    // Any other caller, authenticated or not
+)
+
+// PrivacyFields are the profile fields whose audience a Complejo chooses, in the order they are listed.
+var PrivacyFields = []string{"weight", "height", "imc", "photo"}
+
+// PrivacySettings are the audiences a Complejo chose for the fields of its profile. A Complejo has at most one
+// PrivacySettings; DefaultPrivacySettings apply until it changes them.
+type PrivacySettings struct {
+	ComplejoID string     `json:"-" bson:"_id"`                           // Complejo whose profile it is
+	Weight     string     `json:"weight" bson:"weight"`                   // Audience of the weight, and of the bodyweight of the lift history
+	Height     string     `json:"height" bson:"height"`                   // Audience of the height
+	IMC        string     `json:"imc" bson:"imc"`                         // Audience of the IMC
+	Photo      string     `json:"photo" bson:"photo"`                     // Audience of the profile photo
+	UpdatedAt  *time.Time `json:"updated_at,omitempty" bson:"updated_at"` // When they were last changed
+}
+
+// DefaultPrivacySettings returns the privacy settings of a Complejo that never changed them: its weight, height
+// and IMC are seen by its followers, and its photo by everyone.
+func DefaultPrivacySettings(complejoID string) *PrivacySettings {
+	return &PrivacySettings{
+		ComplejoID: complejoID,
+		Weight:     AudienceFollowers,
+		Height:     AudienceFollowers,
+		IMC:        AudienceFollowers,
+		Photo:      AudienceEveryone,
+	}
+}
+
+// Audience returns the audience of the profile field, one of the PrivacyFields; nil settings are the default
+// ones.
+func (p *PrivacySettings) Audience(field string) string {
+	if p == nil {
+		p = DefaultPrivacySettings("")
+	}
+	switch field {
+	case "weight":
+		return p.Weight
+	case "height":
+		return p.Height
+	case "imc":
+		return p.IMC
+	case "photo":
+		return p.Photo
+	}
+	return AudienceEveryone
+}
+
+// Visible reports whether a caller with the given relation to the profile sees the field.
+func (p *PrivacySettings) Visible(field, relation string) bool {
+	switch p.Audience(field) {
+	case AudienceEveryone:
+		return true
+	case AudienceFollowers:
+		return relation == RelationSelf || relation == RelationFollower
+	}
+	return relation == RelationSelf
+}
+
+// Apply changes the audiences present in the input.
+func (p *PrivacySettings) Apply(input PrivacySettingsInput) {
+	if input.Weight != nil {
+		p.Weight = *input.Weight
+	}
+	if input.Height != nil {
+		p.Height = *input.Height
+	}
+	if input.IMC != nil {
+		p.IMC = *input.IMC
+	}
+	if input.Photo != nil {
+		p.Photo = *input.Photo
+	}
+}
+
+// PrivacySettingsInput is the payload of PUT /complejo/me/privacy: the audiences present are changed, the others
+// kept.
+type PrivacySettingsInput struct {
+	Weight *string `json:"weight" validate:"omitnil,oneof=everyone followers me"` // Audience of the weight
+	Height *string `json:"height" validate:"omitnil,oneof=everyone followers me"` // Audience of the height
+	IMC    *string `json:"imc" validate:"omitnil,oneof=everyone followers me"`    // Audience of the IMC
+	Photo  *string `json:"photo" validate:"omitnil,oneof=everyone followers me"`  // Audience of the profile photo
+}
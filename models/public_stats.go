@@ -0,0 +1,23 @@
+// public_stats.go
+package models
+
+import "time"
+
+// PersonalRecord is a lift record set by a Complejo, logged for the club totals.
+type PersonalRecord struct {
+	ID         string    `json:"_id" bson:"_id"`                 // Unique identifier, derived from the domain event that announced it
+	ComplejoID string    `json:"complejo_id" bson:"complejo_id"` // Complejo that set the record
+	Lift       string    `json:"lift" bson:"lift"`               // "bench", "squad" or "dl"
+	Previous   float64   `json:"previous" bson:"previous"`       // Previous record in kilograms (0 for the first one)
+	Value      float64   `json:"value" bson:"value"`             // New record in kilograms
+	AchievedAt time.Time `json:"achieved_at" bson:"achieved_at"` // When the record was set
+}
+
+// PublicStats are the counters of the club shown on the public homepage.
+type PublicStats struct {
+	Members              int64     `json:"members"`                 // Live Complejos
+	EventsHosted         int64     `json:"events_hosted"`           // Live Events that took place
+	KilosLiftedThisMonth float64   `json:"kilos_lifted_this_month"` // Total of the lift records set this calendar month
+	Month                string    `json:"month"`                   // Calendar month of KilosLiftedThisMonth ("2006-01", club time zone)
+	UpdatedAt            time.Time `json:"updated_at"`              // When the counters were computed
+}
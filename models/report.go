@@ -0,0 +1,76 @@
+// report.go
+package models
+
+import "time"
+
+// Default and maximum number of weeks covered by a report.
+const (
+	DefaultReportWeeks = 12
+	MaxReportWeeks     = 52
+)
+
+// ReportQuery is bound from the `?weeks=` query string of the GET /admin/analytics reports.
+type ReportQuery struct {
+	Weeks int `json:"weeks" form:"weeks" validate:"omitempty,min=1,max=52"` // Number of weeks covered (default: 12, at most 52)
+}
+
+// CohortActivity is the raw activity of the members who signed up during a week, as counted by the storage.
+type CohortActivity struct {
+	Week    time.Time // Monday (UTC) starting the sign-up week
+	Members int       // Members who signed up that week
+	Offsets []int     // One entry per member and week after sign-up (0 = sign-up week) in which the member attended an event
+}
+
+// CohortRetention is a row of the retention matrix.
+type CohortRetention struct {
+	Week     time.Time `json:"week"`     // Monday (UTC) starting the sign-up week
+	Members  int       `json:"members"`  // Members who signed up that week
+	Retained []int     `json:"retained"` // Retained[k]: members who attended an event k weeks after signing up
+	Rates    []float64 `json:"rates"`    // Rates[k]: Retained[k] / Members (0 when the cohort is empty)
+}
+
+// RetentionReport is the weekly cohort retention matrix; row i has one column per elapsed week.
+type RetentionReport struct {
+	From    time.Time         `json:"from"`    // Monday starting the first cohort
+	To      time.Time         `json:"to"`      // Time of the report
+	Cohorts []CohortRetention `json:"cohorts"` // One row per week, oldest first
+}
+
+// AttendanceSlot counts the attendees of the events held at an hour of a weekday, in the gym's time zone.
+type AttendanceSlot struct {
+	Weekday int // ISO weekday: 1 = Monday ... 7 = Sunday
+	Hour    int // 0 to 23
+	Count   int // Participants of the events starting in that slot
+}
+
+// Weekdays names the rows of a heatmap, Monday first.
+var Weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// Heatmap is the event attendance by hour and weekday.
+type Heatmap struct {
+	From     time.Time  `json:"from"`     // Start of the covered period
+	To       time.Time  `json:"to"`       // Time of the report
+	Timezone string     `json:"timezone"` // Time zone of the hours (IANA name)
+	Weekdays []string   `json:"weekdays"` // Name of each row, Monday first
+	Counts   [7][24]int `json:"counts"`   // Counts[d][h]: attendees of the events starting at hour h of weekday d
+	Max      int        `json:"max"`      // Largest count, to scale the colors
+}
+
+// Busy levels of a slot in the public busy times.
+const (
+	BusyQuiet    = "quiet"
+	BusyModerate = "moderate"
+	BusyBusy     = "busy"
+)
+
+// BusyDay is the busy level of every hour of a weekday.
+type BusyDay struct {
+	Weekday string     `json:"weekday"` // "monday" ... "sunday"
+	Levels  [24]string `json:"levels"`  // Levels[h]: "quiet", "moderate" or "busy"
+}
+
+// BusyTimes is the public summary of the heatmap: relative levels only, no attendance counts.
+type BusyTimes struct {
+	Timezone string    `json:"timezone"` // Time zone of the hours (IANA name)
+	Days     []BusyDay `json:"days"`     // Monday first
+}
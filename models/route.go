@@ -0,0 +1,23 @@
+// route.go
+package models
+
+import "time"
+
+// MaxRouteSize is the largest GPX file accepted as the route of an Event, in bytes.
+const MaxRouteSize = 5 << 20
+
+// EventRoute is the GPX route of an outdoor Event (a run or a hike), summarized when it is uploaded.
+// The GPX file itself is kept in the object store.
+type EventRoute struct {
+	Name           string    `json:"name,omitempty" bson:"name,omitempty"`                       // Name given in the GPX file
+	DistanceM      float64   `json:"distance_m" bson:"distance_m"`                               // Length of the route in metres
+	ElevationGainM float64   `json:"elevation_gain_m" bson:"elevation_gain_m"`                   // Total climb in metres
+	ElevationLossM float64   `json:"elevation_loss_m" bson:"elevation_loss_m"`                   // Total descent in metres
+	MinElevationM  *float64  `json:"min_elevation_m,omitempty" bson:"min_elevation_m,omitempty"` // Lowest point (omitted without elevation data)
+	MaxElevationM  *float64  `json:"max_elevation_m,omitempty" bson:"max_elevation_m,omitempty"` // Highest point (omitted without elevation data)
+	Points         int       `json:"points" bson:"points"`                                       // Number of points of the route
+	Size           int       `json:"size" bson:"size"`                                           // Size of the GPX file in bytes
+	Key            string    `json:"-" bson:"key"`                                               // Key of the GPX file in the object store
+	UploadedBy     string    `json:"uploaded_by" bson:"uploaded_by"`                             // ID of the Complejo that uploaded it
+	UploadedAt     time.Time `json:"uploaded_at" bson:"uploaded_at"`                             // When it was uploaded
+}
@@ -0,0 +1,88 @@
+// rsvp.go
+package models
+
+import "time"
+
+// RSVP statuses of a Complejo on an Event.
+const (
+	RSVPGoing    = "going"
+	RSVPMaybe    = "maybe"
+	RSVPDeclined = "declined"
+)
+
+// RSVP is the answer of a Complejo to an Event. Only the Complejos going count as participants.
+type RSVP struct {
+	ComplejoID  string    `json:"complejo_id" bson:"complejo_id"`   // Complejo that answered
+	Username    string    `json:"username" bson:"username"`         // Username of the Complejo when it answered
+	Status      string    `json:"status" bson:"status"`             // "going", "maybe" or "declined"
+	RespondedAt time.Time `json:"responded_at" bson:"responded_at"` // When the Complejo last changed its answer
+
+	Warning string `json:"warning,omitempty" bson:"-"` // Level-gate warning of the answer (returned only, never stored)
+}
+
+// RSVPInput is the answer of a Complejo to an Event, bound from the body of PUT /event/:id/rsvp.
+type RSVPInput struct {
+	Status string `json:"status" validate:"required,oneof=going maybe declined"` // "going", "maybe" or "declined"
+}
+
+// Participant is the public profile of a Complejo going to an Event.
+type Participant struct {
+	ComplejoID  string    `json:"complejo_id" bson:"_id"`           // Complejo going to the event
+	Username    string    `json:"username" bson:"username"`         // Current username of the Complejo
+	Photo       string    `json:"-" bson:"photo"`                   // Base64-encoded profile photo, only returned as Thumbnail
+	PhotoID     string    `json:"-" bson:"photo_id"`                // ID of the profile photo in the object store, only returned as Thumbnail
+	Thumbnail   string    `json:"thumbnail,omitempty" bson:"-"`     // Profile photo scaled down to 96 pixels
+	Bench       float64   `json:"bench" bson:"bench"`               // Bench press weight in kilograms (0 when unknown)
+	Squad       float64   `json:"squad" bson:"squad"`               // Squat weight in kilograms (0 when unknown)
+	DL          float64   `json:"dl" bson:"dl"`                     // Deadlift weight in kilograms (0 when unknown)
+	RespondedAt time.Time `json:"responded_at" bson:"responded_at"` // When the Complejo answered "going"
+}
+
+// ParticipantQuery paginates the participants of an Event.
+// It is bound from the `?page=&limit=` query string of GET /event/:id/participants.
+type ParticipantQuery struct {
+	Page  int `json:"page" form:"page" validate:"omitempty,min=1"`           // 1-based page number (default: 1)
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // Page size (default: 20, at most 100)
+}
+
+// Normalize fills in the default page and page size.
+func (q *ParticipantQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultEventPageLimit
+	}
+	if q.Limit > MaxEventPageLimit {
+		q.Limit = MaxEventPageLimit
+	}
+}
+
+// Offset returns the number of participants skipped before the requested page.
+func (q ParticipantQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// RSVPCounts counts the RSVPs of an Event by status, and its guests.
+type RSVPCounts struct {
+	Going    int `json:"going"`
+	Maybe    int `json:"maybe"`
+	Declined int `json:"declined"`
+	Guests   int `json:"guests"`
+}
+
+// CountRSVPs counts the RSVPs of the Event by status, and its guests.
+func CountRSVPs(event Event) RSVPCounts {
+	counts := RSVPCounts{Guests: len(event.Guests)}
+	for _, rsvp := range event.RSVPs {
+		switch rsvp.Status {
+		case RSVPGoing:
+			counts.Going++
+		case RSVPMaybe:
+			counts.Maybe++
+		case RSVPDeclined:
+			counts.Declined++
+		}
+	}
+	return counts
+}
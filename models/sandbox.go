@@ -0,0 +1,20 @@
+// sandbox.go
+package models
+
+import "time"
+
+// SeedSummary reports what the seed data created.
+type SeedSummary struct {
+	Complejos int    `json:"complejos"`      // Accounts created
+	Events    int    `json:"events"`         // Events created
+	Admin     string `json:"admin_username"` // Username of the seeded admin account
+	Password  string `json:"password"`       // Password of every seeded account
+}
+
+// SandboxReset is the result of a reset of the sandbox: what was removed and what was seeded again.
+type SandboxReset struct {
+	ResetBy string           `json:"reset_by"` // Admin that reset the sandbox (removed with the data)
+	ResetAt time.Time        `json:"reset_at"` // When the sandbox was reset
+	Removed map[string]int64 `json:"removed"`  // Records removed by collection or table
+	Seeded  SeedSummary      `json:"seeded"`   // Seed data created afterwards
+}
@@ -0,0 +1,70 @@
+// service_account.go
+package models
+
+import "time"
+
+// ServiceTokenPrefix starts every service token, telling them apart from the JWTs of the users.
+const ServiceTokenPrefix = "svc_"
+
+// ServiceAccount is the identity of an integration of the club (the kiosk, the Discord bot, the scheduler),
+// calling the API with service tokens instead of the JWT of a user. Its tokens rotate: each one is due for
+// rotation RotationDays after it was issued, and keeps working OverlapHours more while the integration switches
+// to the next one.
+type ServiceAccount struct {
+	ID           string         `json:"_id" bson:"_id"`                     // Unique identifier (assigned by the server), the "_id" of its requests
+	Name         string         `json:"name" bson:"name"`                   // Unique name (e.g. "discord-bot"), the "username" of its requests
+	Role         string         `json:"role" bson:"role"`                   // Role of its requests: "admin" or "user"
+	RotationDays int            `json:"rotation_days" bson:"rotation_days"` // Days a token is used before it is due for rotation
+	OverlapHours int            `json:"overlap_hours" bson:"overlap_hours"` // Hours a token keeps working once due, or once replaced
+	Active       bool           `json:"active" bson:"active"`               // The tokens of inactive accounts are refused
+	CreatedBy    string         `json:"created_by" bson:"created_by"`       // ID of the admin that created it
+	CreatedAt    time.Time      `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at" bson:"updated_at"`
+	Tokens       []ServiceToken `json:"tokens" bson:"-"` // Tokens still working, oldest first (stored apart)
+}
+
+// ServiceAccountInput is the payload creating or replacing a ServiceAccount.
+type ServiceAccountInput struct {
+	Name         string `json:"name" validate:"required,min=3,max=64"`
+	Role         string `json:"role" validate:"required,oneof=admin user"`
+	RotationDays int    `json:"rotation_days" validate:"omitempty,min=1,max=365"` // Default: 30
+	OverlapHours int    `json:"overlap_hours" validate:"omitempty,min=1,max=168"` // Default: 24
+	Active       *bool  `json:"active"`                                           // Default: true
+}
+
+// ServiceToken is a token of a ServiceAccount. Only the SHA-256 of the token is stored: the token itself is
+// returned once, when it is issued.
+type ServiceToken struct {
+	ID        string    `json:"_id" bson:"_id"`               // Unique identifier (assigned by the server)
+	AccountID string    `json:"account_id" bson:"account_id"` // ServiceAccount authenticated by the token
+	Hash      string    `json:"-" bson:"hash"`                // Hex SHA-256 of the token (never returned)
+	Hint      string    `json:"hint" bson:"hint"`             // Last characters of the token, to tell the tokens apart
+	IssuedAt  time.Time `json:"issued_at" bson:"issued_at"`
+	RotateAt  time.Time `json:"rotate_at" bson:"rotate_at"`   // From then on the responses ask for a rotation
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"` // When the token stops working
+}
+
+// IssuedServiceToken is a newly issued ServiceToken, with the token itself, returned only once.
+type IssuedServiceToken struct {
+	Token     string    `json:"token"` // Sent in the Authorization header, like a JWT
+	ID        string    `json:"_id"`
+	AccountID string    `json:"account_id"`
+	RotateAt  time.Time `json:"rotate_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreatedServiceAccount is a newly created ServiceAccount with its first token.
+type CreatedServiceAccount struct {
+	Account *ServiceAccount     `json:"account"`
+	Token   *IssuedServiceToken `json:"token"`
+}
+
+// ServiceIdentity is what a valid service token authenticates: its account, and the token itself.
+type ServiceIdentity struct {
+	AccountID string
+	Name      string
+	Role      string
+	TokenID   string
+	RotateAt  time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,34 @@
+// settings.go
+package models
+
+import "time"
+
+// Orders of the event listings by date.
+const (
+	SortAscending  = "asc"  // Oldest first
+	SortDescending = "desc" // Newest first
+)
+
+// ListingSettings are the defaults of the event listing (GET /event) chosen by the admins of the club, applied
+// when the request leaves them out. There is at most one ListingSettings; DefaultListingSettings apply until the
+// admins change them.
+type ListingSettings struct {
+	HidePastAfterDays int        `json:"hide_past_after_days" bson:"hide_past_after_days"` // Events dated more than this many days ago are hidden (0: never)
+	Sort              string     `json:"sort" bson:"sort"`                                 // Order of the events by date: "asc" or "desc"
+	PageSize          int        `json:"page_size" bson:"page_size"`                       // Events per page
+	UpdatedBy         string     `json:"updated_by,omitempty" bson:"updated_by"`           // Admin that last changed them
+	UpdatedAt         *time.Time `json:"updated_at,omitempty" bson:"updated_at"`           // When they were last changed
+}
+
+// DefaultListingSettings returns the listing settings of a club whose admins never changed them:
+// past events are listed, oldest first, 20 per page.
+func DefaultListingSettings() *ListingSettings {
+	return &ListingSettings{Sort: SortAscending, PageSize: DefaultEventPageLimit}
+}
+
+// ListingSettingsInput is the payload of PUT /admin/settings/listing, replacing every listing setting.
+type ListingSettingsInput struct {
+	HidePastAfterDays int    `json:"hide_past_after_days" validate:"min=0,max=3650"` // Events dated more than this many days ago are hidden (0: never)
+	Sort              string `json:"sort" validate:"required,oneof=asc desc"`        // Order of the events by date: "asc" or "desc"
+	PageSize          int    `json:"page_size" validate:"required,min=1,max=100"`    // Events per page (at most 100)
+}
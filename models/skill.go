@@ -0,0 +1,40 @@
+// skill.go
+package models
+
+// Skill levels of Events and Complejos.
+const (
+	SkillBeginner     = "beginner"
+	SkillIntermediate = "intermediate"
+	SkillAdvanced     = "advanced"
+)
+
+// Intensities of Events.
+const (
+	IntensityLow      = "low"
+	IntensityModerate = "moderate"
+	IntensityHigh     = "high"
+)
+
+// Level gates of Events: what happens when a beginner answers "going" to an advanced session.
+const (
+	LevelGateOff   = "off"   // Nothing (default)
+	LevelGateWarn  = "warn"  // The answer is recorded with a warning
+	LevelGateBlock = "block" // The answer is rejected
+)
+
+// SkillLevel classifies the Complejo by the total of its recorded lifts relative to its weight:
+// beginner under 3 times its weight, intermediate under 5 times, and advanced above.
+// A Complejo without a recorded weight is a beginner.
+func (c Complejo) SkillLevel() string {
+	if c.Weight <= 0 {
+		return SkillBeginner
+	}
+	switch ratio := (c.Bench + c.Squad + c.DL) / c.Weight; {
+	case ratio < 3:
+		return SkillBeginner
+	case ratio < 5:
+		return SkillIntermediate
+	default:
+		return SkillAdvanced
+	}
+}
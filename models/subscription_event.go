@@ -0,0 +1,59 @@
+// subscription_event.go
+package models
+
+import "time"
+
+// Subscription transition types recorded in the subscription history.
+const (
+	SubscriptionSubscribed   = "subscribed"   // The user joined the participants
+	SubscriptionUnsubscribed = "unsubscribed" // The user left the participants or the waitlist
+	SubscriptionWaitlisted   = "waitlisted"   // The user joined the waitlist
+	SubscriptionPromoted     = "promoted"     // The user moved from the waitlist to the participants
+)
+
+// SubscriptionEvent is an immutable record of a single subscription transition of a user on an Event.
+type SubscriptionEvent struct {
+	ID         string    `json:"_id" bson:"_id"`                 // Unique identifier of the transition
+	EventID    string    `json:"event_id" bson:"event_id"`       // Event the transition applies to
+	Username   string    `json:"username" bson:"username"`       // User that performed the transition
+	Type       string    `json:"type" bson:"type"`               // One of the Subscription* constants
+	OccurredAt time.Time `json:"occurred_at" bson:"occurred_at"` // When the transition happened
+}
+
+// SubscriptionHistory is the full transition log of an Event together with the state derived from it.
+type SubscriptionHistory struct {
+	EventID      string              `json:"event_id"`
+	Participants []string            `json:"participants"` // Current participants, derived from Events
+	Waitlist     []string            `json:"waitlist"`     // Current waitlist, derived from Events
+	Events       []SubscriptionEvent `json:"events"`       // Transitions in chronological order
+}
+
+// DeriveSubscriptionState replays the transitions in chronological order and returns
+// the resulting participants and waitlist, each in the order users entered them.
+func DeriveSubscriptionState(events []SubscriptionEvent) (participants, waitlist []string) {
+	participants, waitlist = []string{}, []string{}
+
+	for _, event := range events {
+		participants = removeString(participants, event.Username)
+		waitlist = removeString(waitlist, event.Username)
+
+		switch event.Type {
+		case SubscriptionSubscribed, SubscriptionPromoted:
+			participants = append(participants, event.Username)
+		case SubscriptionWaitlisted:
+			waitlist = append(waitlist, event.Username)
+		}
+	}
+
+	return participants, waitlist
+}
+
+// removeString returns the list without the given value.
+func removeString(list []string, value string) []string {
+	for i, item := range list {
+		if item == value {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+	return list
+}
@@ -0,0 +1,27 @@
+// terms.go
+package models
+
+// Kinds of terms documents, which members accept to use the club.
+const (
+	TermsOfService = "tos"
+	PrivacyPolicy  = "privacy"
+)
+
+// TermsKinds lists every kind of terms document.
+var TermsKinds = []string{TermsOfService, PrivacyPolicy}
+
+// IsTermsKind reports whether value is a kind of terms document.
+func IsTermsKind(value string) bool {
+	for _, kind := range TermsKinds {
+		if kind == value {
+			return true
+		}
+	}
+	return false
+}
+
+// TermsInput publishes a new version of a terms document.
+type TermsInput struct {
+	Title string `json:"title" validate:"required,max=200"`
+	Body  string `json:"body" validate:"required,max=100000"` // Markdown
+}
@@ -0,0 +1,16 @@
+// update.go
+package models
+
+// setString adds the value of a string field of a partial update to fields when it is present.
+func setString(fields map[string]interface{}, name string, value *string) {
+	if value != nil {
+		fields[name] = *value
+	}
+}
+
+// setFloat adds the value of a numeric field of a partial update to fields when it is present.
+func setFloat(fields map[string]interface{}, name string, value *float64) {
+	if value != nil {
+		fields[name] = *value
+	}
+}
@@ -0,0 +1,85 @@
+// volunteer.go
+package models
+
+import "time"
+
+// Volunteer roles of an Event.
+const (
+	VolunteerSpotter = "spotter"
+	VolunteerLoader  = "loader"
+	VolunteerScorer  = "scorer"
+)
+
+// VolunteerShift is a volunteer role of an Event, to be filled by up to Capacity Complejos.
+type VolunteerShift struct {
+	ID         string      `json:"_id" bson:"_id"`               // Unique identifier (assigned by the server)
+	EventID    string      `json:"event_id" bson:"event_id"`     // Event the shift belongs to
+	Role       string      `json:"role" bson:"role"`             // "spotter", "loader" or "scorer"
+	Capacity   int         `json:"capacity" bson:"capacity"`     // Maximum number of volunteers
+	StartsAt   time.Time   `json:"starts_at" bson:"starts_at"`   // Start of the shift
+	EndsAt     time.Time   `json:"ends_at" bson:"ends_at"`       // End of the shift
+	Volunteers []Volunteer `json:"volunteers" bson:"volunteers"` // Complejos signed up, in sign-up order
+	CreatedBy  string      `json:"created_by" bson:"created_by"` // ID of the Complejo that created the shift
+	CreatedAt  time.Time   `json:"created_at" bson:"created_at"` // When the shift was created (assigned by the server)
+}
+
+// Hours returns the length of the shift in hours.
+func (s VolunteerShift) Hours() float64 {
+	return s.EndsAt.Sub(s.StartsAt).Hours()
+}
+
+// Open returns how many volunteers can still sign up for the shift.
+func (s VolunteerShift) Open() int {
+	return max(s.Capacity-len(s.Volunteers), 0)
+}
+
+// FindVolunteer returns the sign-up of the Complejo, or nil when it has not signed up.
+func (s VolunteerShift) FindVolunteer(complejoID string) *Volunteer {
+	for i := range s.Volunteers {
+		if s.Volunteers[i].ComplejoID == complejoID {
+			return &s.Volunteers[i]
+		}
+	}
+	return nil
+}
+
+// Volunteer is the sign-up of a Complejo for a VolunteerShift.
+type Volunteer struct {
+	ComplejoID string     `json:"complejo_id" bson:"complejo_id"`                     // Complejo that signed up
+	Username   string     `json:"username" bson:"username"`                           // Username of the Complejo when it signed up
+	SignedUpAt time.Time  `json:"signed_up_at" bson:"signed_up_at"`                   // When the Complejo signed up
+	RemindedAt *time.Time `json:"reminded_at,omitempty" bson:"reminded_at,omitempty"` // When the Complejo was reminded of the shift
+}
+
+// VolunteerShiftInput is the payload creating a VolunteerShift.
+type VolunteerShiftInput struct {
+	Role     string    `json:"role" validate:"required,oneof=spotter loader scorer"`
+	Capacity int       `json:"capacity" validate:"gte=1,lte=50"`
+	StartsAt time.Time `json:"starts_at" validate:"required,future"`
+	EndsAt   time.Time `json:"ends_at" validate:"required"` // After StartsAt
+}
+
+// VolunteerShiftView is a VolunteerShift with how many volunteers can still sign up.
+type VolunteerShiftView struct {
+	VolunteerShift
+	Open int `json:"open"`
+}
+
+// VolunteerHours is the tally of the shifts a Complejo volunteered for that have ended.
+type VolunteerHours struct {
+	ComplejoID string  `json:"complejo_id" bson:"_id"`
+	Username   string  `json:"username" bson:"username"`
+	Shifts     int     `json:"shifts" bson:"shifts"`
+	Hours      float64 `json:"hours" bson:"hours"`
+}
+
+// Default and maximum sizes of the volunteer leaderboard.
+const (
+	DefaultVolunteerLeaderboardLimit = 10
+	MaxVolunteerLeaderboardLimit     = 100
+)
+
+// VolunteerLeaderboardQuery is bound from the `?limit=` query string of GET /volunteers/leaderboard.
+type VolunteerLeaderboardQuery struct {
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // Number of members (default: 10, at most 100)
+}
@@ -0,0 +1,49 @@
+// weather.go
+package models
+
+import "time"
+
+// Weather conditions of a Forecast.
+const (
+	WeatherClear  = "clear"
+	WeatherClouds = "clouds"
+	WeatherRain   = "rain"
+	WeatherSnow   = "snow"
+	WeatherStorm  = "storm"
+	WeatherFog    = "fog"
+)
+
+// Limits past which a Forecast is severe.
+const (
+	SevereWindKPH         = 60 // Wind speed at or above which outdoor sessions are unsafe
+	SeverePrecipitationMM = 10 // Precipitation over the hour of the event
+	SevereHeatC           = 35 // Temperature at or above which heat is severe
+	SevereColdC           = -5 // Temperature at or below which cold is severe
+)
+
+// Forecast is the weather forecast for the date and location of an outdoor Event, cached on the Event.
+type Forecast struct {
+	Summary             string    `json:"summary" bson:"summary"`                           // Short description from the provider, e.g. "Heavy rain"
+	Condition           string    `json:"condition" bson:"condition"`                       // "clear", "clouds", "rain", "snow", "storm" or "fog"
+	TemperatureC        float64   `json:"temperature_c" bson:"temperature_c"`               // Temperature in degrees Celsius
+	PrecipitationMM     float64   `json:"precipitation_mm" bson:"precipitation_mm"`         // Precipitation over the hour, in millimetres
+	PrecipitationChance int       `json:"precipitation_chance" bson:"precipitation_chance"` // Probability of precipitation, in percent
+	WindKPH             float64   `json:"wind_kph" bson:"wind_kph"`                         // Wind speed in km/h
+	Severe              bool      `json:"severe" bson:"severe"`                             // Whether the weather makes the event unsafe (computed when fetched)
+	Location            string    `json:"location" bson:"location"`                         // Location of the Event when the forecast was fetched
+	For                 time.Time `json:"for" bson:"for"`                                   // Date of the Event when the forecast was fetched
+	FetchedAt           time.Time `json:"fetched_at" bson:"fetched_at"`                     // When the forecast was fetched
+}
+
+// IsSevere reports whether the forecast weather makes an outdoor session unsafe: a storm or snow,
+// or wind, precipitation or a temperature past the severe limits.
+func (f Forecast) IsSevere() bool {
+	return f.Condition == WeatherStorm || f.Condition == WeatherSnow ||
+		f.WindKPH >= SevereWindKPH || f.PrecipitationMM >= SeverePrecipitationMM ||
+		f.TemperatureC >= SevereHeatC || f.TemperatureC <= SevereColdC
+}
+
+// Matches reports whether the forecast was fetched for the current date and location of the Event.
+func (f Forecast) Matches(event Event) bool {
+	return f.Location == event.Location && f.For.Equal(event.Date)
+}
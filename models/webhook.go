@@ -0,0 +1,64 @@
+// webhook.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook is a URL of an outside system receiving the domain events of some topics (e.g. "event.created"),
+// registered by an admin. Each delivery is signed with its secret.
+type Webhook struct {
+	ID        string    `json:"_id" bson:"_id"`               // Unique identifier (assigned by the server)
+	URL       string    `json:"url" bson:"url"`               // URL the domain events are POSTed to
+	Secret    string    `json:"-" bson:"secret"`              // Key of the HMAC signatures of the deliveries (never returned)
+	Topics    []string  `json:"topics" bson:"topics"`         // Topics of the domain events delivered
+	Active    bool      `json:"active" bson:"active"`         // Inactive webhooks receive nothing
+	CreatedBy string    `json:"created_by" bson:"created_by"` // ID of the admin that registered it
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// WebhookInput is the payload registering or replacing a Webhook.
+type WebhookInput struct {
+	URL    string   `json:"url" validate:"required,url,max=2048"`
+	Secret string   `json:"secret" validate:"required,min=16,max=256"`
+	Topics []string `json:"topics" validate:"required,min=1,dive,required"`
+	Active *bool    `json:"active"` // Default: true
+}
+
+// Statuses of the WebhookDeliveries.
+const (
+	DeliveryPending   = "pending"   // Not delivered yet, attempted again later
+	DeliveryDelivered = "delivered" // Accepted by the webhook (2xx)
+	DeliveryFailed    = "failed"    // Given up after the last attempt, or the webhook was disabled
+)
+
+// WebhookDelivery is a domain event to deliver to a Webhook, with the log of its attempts.
+type WebhookDelivery struct {
+	ID            string           `json:"_id" bson:"_id"`                         // Unique identifier (X-Complejos-Delivery header)
+	WebhookID     string           `json:"webhook_id" bson:"webhook_id"`           // Webhook the domain event is delivered to
+	EventID       string           `json:"event_id" bson:"event_id"`               // ID of the domain event ("id" of the payload)
+	Topic         string           `json:"topic" bson:"topic"`                     // Topic of the domain event
+	Payload       json.RawMessage  `json:"payload" bson:"payload"`                 // JSON body POSTed to the webhook
+	Status        string           `json:"status" bson:"status"`                   // "pending", "delivered" or "failed"
+	Attempts      []WebhookAttempt `json:"attempts" bson:"attempts"`               // Attempts so far, oldest first
+	CreatedAt     time.Time        `json:"created_at" bson:"created_at"`           // When the domain event was published
+	NextAttemptAt time.Time        `json:"next_attempt_at" bson:"next_attempt_at"` // Earliest time of the next attempt, while pending
+	DeliveredAt   *time.Time       `json:"delivered_at" bson:"delivered_at"`       // When the webhook accepted it (nil until then)
+}
+
+// WebhookAttempt is an attempt to deliver a WebhookDelivery.
+type WebhookAttempt struct {
+	At         time.Time `json:"at" bson:"at"`
+	StatusCode int       `json:"status_code,omitempty" bson:"status_code,omitempty"` // HTTP status of the response (none when no response was received)
+	Error      string    `json:"error,omitempty" bson:"error,omitempty"`             // Why the attempt failed
+	DurationMS int64     `json:"duration_ms" bson:"duration_ms"`                     // How long the attempt took
+}
+
+// WebhookDeliveryFilter narrows down the log of the deliveries of a Webhook.
+// It is bound from the `?status=&limit=` query string of GET /admin/webhooks/:id/deliveries.
+type WebhookDeliveryFilter struct {
+	Status string `json:"status" form:"status" validate:"omitempty,oneof=pending delivered failed"`
+	Limit  int    `json:"limit" form:"limit" validate:"omitempty,min=1,max=200"` // Default: 50
+}
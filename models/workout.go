@@ -0,0 +1,119 @@
+// workout.go
+package models
+
+import "time"
+
+// Muscle groups the exercises of the workouts train, and their volume is summarized by.
+const (
+	MuscleChest     = "chest"
+	MuscleBack      = "back"
+	MuscleShoulders = "shoulders"
+	MuscleArms      = "arms"
+	MuscleLegs      = "legs"
+	MuscleCore      = "core"
+)
+
+// MuscleGroups lists every muscle group, in the order of the volume summaries.
+var MuscleGroups = []string{MuscleChest, MuscleBack, MuscleShoulders, MuscleArms, MuscleLegs, MuscleCore}
+
+// Default and maximum page sizes of the workouts.
+const (
+	DefaultWorkoutLimit = 20
+	MaxWorkoutLimit     = 100
+)
+
+// Default and maximum number of weeks of the volume summaries.
+const (
+	DefaultVolumeWeeks = 8
+	MaxVolumeWeeks     = 52
+)
+
+// Workout is a training session logged by a Complejo. Only its author can read or change it.
+type Workout struct {
+	ID         string            `json:"_id" bson:"_id"`                 // Unique identifier (assigned by the server)
+	ComplejoID string            `json:"complejo_id" bson:"complejo_id"` // Complejo that trained
+	Date       time.Time         `json:"date" bson:"date"`               // When the workout took place
+	Exercises  []WorkoutExercise `json:"exercises" bson:"exercises"`     // Exercises performed, in order
+	Notes      string            `json:"notes" bson:"notes"`             // Free notes of the session
+	CreatedAt  time.Time         `json:"created_at" bson:"created_at"`   // When it was logged (assigned by the server)
+	UpdatedAt  time.Time         `json:"updated_at" bson:"updated_at"`   // When it was last changed (assigned by the server)
+}
+
+// WorkoutExercise is an exercise of a Workout with its sets. Its name and muscle group are copied from the
+// exercise library when it is logged, so that the workout keeps them if the exercise is changed or removed.
+type WorkoutExercise struct {
+	ExerciseID  string       `json:"exercise_id,omitempty" bson:"exercise_id,omitempty"` // Exercise of the library (absent on the workouts logged before the library)
+	Name        string       `json:"name" bson:"name"`                                   // Name of the exercise (e.g. "Romanian deadlift")
+	MuscleGroup string       `json:"muscle_group" bson:"muscle_group"`                   // Muscle group it trains the most
+	Sets        []WorkoutSet `json:"sets" bson:"sets"`                                   // Sets performed, in order
+}
+
+// WorkoutExerciseInput is an exercise of a WorkoutInput, referenced by its ID in the library.
+type WorkoutExerciseInput struct {
+	ExerciseID string       `json:"exercise_id" validate:"required"`
+	Sets       []WorkoutSet `json:"sets" validate:"required,min=1,max=50,dive"` // Sets performed, in order
+}
+
+// WorkoutSet is a set of an exercise.
+type WorkoutSet struct {
+	Reps   int      `json:"reps" bson:"reps" validate:"min=1,max=100"`                          // Repetitions
+	Weight float64  `json:"weight" bson:"weight" validate:"gte=0,lte=1000"`                     // Weight in kilograms (0 for bodyweight exercises)
+	RPE    *float64 `json:"rpe,omitempty" bson:"rpe,omitempty" validate:"omitnil,min=1,max=10"` // Rate of perceived exertion, from 1 to 10 (optional)
+}
+
+// WorkoutInput is the payload logging or replacing a Workout.
+type WorkoutInput struct {
+	Date      *time.Time             `json:"date"`                                            // When the workout took place (default: now, or unchanged on a replacement; never in the future)
+	Exercises []WorkoutExerciseInput `json:"exercises" validate:"required,min=1,max=30,dive"` // Between 1 and 30 exercises
+	Notes     string                 `json:"notes" validate:"max=2000"`
+}
+
+// WorkoutQuery is bound from the `?page=&limit=` query string of GET /workouts.
+type WorkoutQuery struct {
+	Page  int `json:"page" form:"page" validate:"omitempty,min=1"`           // 1-based page number (default: 1)
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"` // Page size (default: 20, at most 100)
+}
+
+// Normalize fills in the default page and page size.
+func (q *WorkoutQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultWorkoutLimit
+	}
+	if q.Limit > MaxWorkoutLimit {
+		q.Limit = MaxWorkoutLimit
+	}
+}
+
+// Offset returns the number of workouts skipped before the requested page.
+func (q WorkoutQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// VolumeQuery is bound from the `?weeks=` query string of GET /workouts/volume.
+type VolumeQuery struct {
+	Weeks int `json:"weeks" form:"weeks" validate:"omitempty,min=1,max=52"` // Number of weeks up to the current one (default: 8, at most 52)
+}
+
+// MuscleVolume is the training volume of a muscle group over a week.
+type MuscleVolume struct {
+	Week        string  `json:"-" bson:"week"`                    // Monday the week starts on ("2006-01-02")
+	MuscleGroup string  `json:"muscle_group" bson:"muscle_group"` // Muscle group trained
+	Sets        int     `json:"sets" bson:"sets"`                 // Sets performed
+	Reps        int     `json:"reps" bson:"reps"`                 // Repetitions performed
+	Volume      float64 `json:"volume" bson:"volume"`             // Sum of the repetitions times the weight, in kilograms
+}
+
+// WeeklyVolume is the training volume of every muscle group over a week.
+type WeeklyVolume struct {
+	Week         string         `json:"week"`          // Monday the week starts on ("2006-01-02")
+	MuscleGroups []MuscleVolume `json:"muscle_groups"` // Volume of every muscle group, untrained ones included
+}
+
+// Volume is the weekly training volume of a Complejo by muscle group, for charts.
+type Volume struct {
+	Timezone string         `json:"timezone"` // Time zone of the weeks
+	Weeks    []WeeklyVolume `json:"weeks"`    // Every week of the range, oldest first
+}
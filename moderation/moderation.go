@@ -0,0 +1,112 @@
+// moderation.go
+package moderation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Reasons a text is held for review.
+const (
+	ReasonProfanity = "profanity" // The text contains a blocked word of its locale
+	ReasonLinkSpam  = "link_spam" // The text contains more links than allowed
+)
+
+// DefaultMaxLinks is how many links a text may contain before it is held as link spam.
+const DefaultMaxLinks = 2
+
+// Filter screens user content for blocked words, per locale, and link spam.
+// It never rejects content; it returns the reasons the content should be held for review.
+type Filter struct {
+	Words    map[string]map[string]bool // Blocked words by locale, normalized
+	MaxLinks int                        // Links allowed in a text (-1 disables the check)
+}
+
+// NewFilter returns the Filter blocking the given words of each locale and the texts with more than maxLinks links.
+func NewFilter(words map[string][]string, maxLinks int) Filter {
+	filter := Filter{Words: map[string]map[string]bool{}, MaxLinks: maxLinks}
+	for loc, list := range words {
+		blocked := map[string]bool{}
+		for _, word := range list {
+			if word = normalize(strings.TrimSpace(word)); word != "" {
+				blocked[word] = true
+			}
+		}
+		filter.Words[loc] = blocked
+	}
+	return filter
+}
+
+var (
+	links = regexp.MustCompile(`(?i)\b(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|io|info|biz|xyz|ru|top|click|link)\b`)
+
+	// leet maps the characters commonly swapped for letters to dodge word lists.
+	leet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s", "!", "i")
+)
+
+// Check returns the reasons the text should be held for review, using the word list of the locale
+// (every list when the locale is empty), or nil when it is clean.
+func (f Filter) Check(text, locale string) []string {
+	var reasons []string
+	if f.profane(text, locale) {
+		reasons = append(reasons, ReasonProfanity)
+	}
+	if f.MaxLinks >= 0 && len(links.FindAllString(text, -1)) > f.MaxLinks {
+		reasons = append(reasons, ReasonLinkSpam)
+	}
+	return reasons
+}
+
+// profane reports whether a word of the text, or the text with its separators removed, is blocked in the locale.
+func (f Filter) profane(text, locale string) bool {
+	lists := make([]map[string]bool, 0, len(f.Words))
+	if locale != "" {
+		lists = append(lists, f.Words[locale])
+	} else {
+		for _, list := range f.Words {
+			lists = append(lists, list)
+		}
+	}
+
+	// The text is checked as written and with the character swaps undone. Words are split on anything but letters
+	// and digits, so usernames like "bad_word" are checked both word by word and joined ("badword")
+	plain := normalize(text)
+	var words []string
+	for _, form := range []string{plain, leet.Replace(plain)} {
+		split := strings.FieldsFunc(form, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		words = append(words, split...)
+		words = append(words, strings.Join(split, ""))
+	}
+	for _, list := range lists {
+		if len(list) == 0 {
+			continue
+		}
+		for _, word := range words {
+			if list[word] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalize lowercases the text and strips the accents of Latin letters.
+func normalize(text string) string {
+	return strings.Map(func(r rune) rune {
+		if plain, ok := accents[r]; ok {
+			return plain
+		}
+		return r
+	}, strings.ToLower(text))
+}
+
+// accents maps the accented letters of the supported locales to their plain form.
+var accents = map[rune]rune{
+	'á': 'a', 'à': 'a', 'ä': 'a', 'â': 'a',
+	'é': 'e', 'è': 'e', 'ë': 'e', 'ê': 'e',
+	'í': 'i', 'ì': 'i', 'ï': 'i', 'î': 'i',
+	'ó': 'o', 'ò': 'o', 'ö': 'o', 'ô': 'o',
+	'ú': 'u', 'ù': 'u', 'ü': 'u', 'û': 'u',
+	'ñ': 'n', 'ç': 'c',
+}
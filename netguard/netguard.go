@@ -0,0 +1,37 @@
+// netguard.go
+package netguard
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned when a URL given by a user points to a loopback, private or otherwise non-public
+// address, so the server cannot be used to reach the internal network.
+var ErrPrivateAddress = errors.New("URL does not point to a public address")
+
+// Client returns an HTTP client giving up after the timeout that never connects to non-public addresses and
+// ignores the proxy settings of the environment, for requests to URLs given by users.
+func Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicOnly}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: timeout},
+	}
+}
+
+// publicOnly refuses connections to non-public addresses; it runs after name resolution, for every address tried.
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return ErrPrivateAddress
+	}
+	return nil
+}
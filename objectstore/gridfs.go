@@ -0,0 +1,120 @@
+// gridfs.go
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GridFS is a Store keeping each object in a GridFS bucket of a MongoDB database, the key being the file name.
+type GridFS struct {
+	db     *mongo.Database
+	bucket string
+}
+
+// NewGridFS creates a GridFS storing the objects in the named bucket of the database
+// (the "<bucket>.files" and "<bucket>.chunks" collections).
+func NewGridFS(db *mongo.Database, bucket string) *GridFS {
+	return &GridFS{db: db, bucket: bucket}
+}
+
+// Put stores the data under the key. The new file is uploaded before the previous ones are removed,
+// so readers see either of them and never none.
+func (g *GridFS) Put(ctx context.Context, key string, data []byte) error {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+	bucket, err := g.open(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := bucket.UploadFromStream(key, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	return g.remove(ctx, bucket, bson.M{"filename": key, "_id": bson.M{"$ne": id}})
+}
+
+// Get returns the data stored under the key, or ErrNotFound.
+func (g *GridFS) Get(ctx context.Context, key string) ([]byte, error) {
+	if !validKey(key) {
+		return nil, ErrInvalidKey
+	}
+	bucket, err := g.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var data bytes.Buffer
+	if _, err := bucket.DownloadToStreamByName(key, &data); err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+// Delete removes the object stored under the key.
+func (g *GridFS) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+	bucket, err := g.open(ctx)
+	if err != nil {
+		return err
+	}
+	return g.remove(ctx, bucket, bson.M{"filename": key})
+}
+
+// Clear drops the bucket.
+func (g *GridFS) Clear(ctx context.Context) error {
+	bucket, err := g.open(ctx)
+	if err != nil {
+		return err
+	}
+	return bucket.DropContext(ctx)
+}
+
+// open returns a handle on the bucket bounded by the deadline of the context: the GridFS streams
+// take deadlines rather than contexts, so each call gets its own handle.
+func (g *GridFS) open(ctx context.Context) (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(g.db, options.GridFSBucket().SetName(g.bucket))
+	if err != nil {
+		return nil, err
+	}
+	// Without a deadline, the zero time leaves the streams unbounded
+	deadline, _ := ctx.Deadline()
+	if err := bucket.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if err := bucket.SetWriteDeadline(deadline); err != nil {
+		return nil, err
+	}
+	return bucket, nil
+}
+
+// remove deletes the files matching the filter, ignoring the files removed meanwhile.
+func (g *GridFS) remove(ctx context.Context, bucket *gridfs.Bucket, filter bson.M) error {
+	cursor, err := bucket.FindContext(ctx, filter)
+	if err != nil {
+		return err
+	}
+	var files []struct {
+		ID interface{} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &files); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := bucket.DeleteContext(ctx, file.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+	}
+	return nil
+}
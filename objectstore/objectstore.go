@@ -0,0 +1,124 @@
+// objectstore.go
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when no object is stored under the key.
+	ErrNotFound = errors.New("object not found")
+	// ErrInvalidKey is returned for keys that are empty, absolute or climb out of the store ("..").
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Store keeps uploaded files (routes, photos) outside the documents, addressed by slash-separated keys
+// such as "routes/<event ID>.gpx". The files live in a directory (Dir), GridFS buckets of MongoDB (GridFS) or an
+// S3-compatible bucket (S3).
+type Store interface {
+	// Put stores the data under the key, replacing any object stored under it.
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the data stored under the key, or ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the object stored under the key; removing a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// Clear removes every object.
+	Clear(ctx context.Context) error
+}
+
+// Dir is a Store keeping each object in a file under a root directory.
+type Dir struct {
+	root string
+}
+
+// NewDir creates a Dir storing the objects under the given directory, created when needed.
+func NewDir(root string) *Dir {
+	return &Dir{root: root}
+}
+
+// Put stores the data under the key. The file is written next to its final name and renamed,
+// so readers never see a partially written object.
+func (d *Dir) Put(ctx context.Context, key string, data []byte) error {
+	name, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get returns the data stored under the key, or ErrNotFound.
+func (d *Dir) Get(ctx context.Context, key string) ([]byte, error) {
+	name, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Delete removes the object stored under the key.
+func (d *Dir) Delete(ctx context.Context, key string) error {
+	name, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Clear removes every file under the root directory, keeping the directory itself.
+func (d *Dir) Clear(ctx context.Context) error {
+	entries, err := os.ReadDir(d.root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(d.root, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// path returns the file of the object stored under the key.
+func (d *Dir) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(d.root, filepath.FromSlash(key)), nil
+}
+
+// validKey reports whether the key is a clean relative path staying within the store.
+func validKey(key string) bool {
+	return key != "" && !strings.HasPrefix(key, "/") && path.Clean(key) == key && key != "." && key != ".." && !strings.HasPrefix(key, "../")
+}
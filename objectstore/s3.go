@@ -0,0 +1,242 @@
+// s3.go
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Options configures an S3 store.
+type S3Options struct {
+	Endpoint        string // Base URL of the service, e.g. "https://s3.eu-west-1.amazonaws.com" or "http://minio:9000"
+	Bucket          string // Bucket keeping the objects, which must exist
+	Region          string // Region of the bucket (MinIO accepts any, conventionally "us-east-1")
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3 is a Store keeping each object in a bucket of an S3-compatible service (Amazon S3, MinIO...), the key being
+// the object key. The requests are signed with AWS Signature Version 4 and address the bucket in the path
+// (https://<endpoint>/<bucket>/<key>), which every S3-compatible service accepts.
+type S3 struct {
+	options S3Options
+	client  *http.Client
+}
+
+// NewS3 creates an S3 store with the given options.
+func NewS3(options S3Options) *S3 {
+	options.Endpoint = strings.TrimSuffix(options.Endpoint, "/")
+	return &S3{options: options, client: &http.Client{Timeout: time.Minute}}
+}
+
+// Put stores the data under the key.
+func (s *S3) Put(ctx context.Context, key string, data []byte) error {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+	response, err := s.do(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+// Get returns the data stored under the key, or ErrNotFound.
+func (s *S3) Get(ctx context.Context, key string) ([]byte, error) {
+	if !validKey(key) {
+		return nil, ErrInvalidKey
+	}
+	response, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	return io.ReadAll(response.Body)
+}
+
+// Delete removes the object stored under the key.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+	return s.remove(ctx, key)
+}
+
+// remove deletes the object stored under the key, which S3 reports as done even when it is missing.
+func (s *S3) remove(ctx context.Context, key string) error {
+	response, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+// listing is the part of a ListObjectsV2 response used by Clear.
+type listing struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// Clear removes every object of the bucket, page by page.
+func (s *S3) Clear(ctx context.Context) error {
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		response, err := s.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return err
+		}
+		var page listing
+		err = xml.NewDecoder(response.Body).Decode(&page)
+		response.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, object := range page.Contents {
+			if err := s.remove(ctx, object.Key); err != nil {
+				return err
+			}
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// do sends the signed request for the key of the bucket (the bucket itself when empty) and returns its response
+// when successful. A 404 is returned as ErrNotFound, other failures as an error quoting the response.
+func (s *S3) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	path := "/" + uriEncode(s.options.Bucket, true)
+	if key != "" {
+		path += "/" + uriEncode(key, false)
+	}
+	rawQuery := canonicalQuery(query)
+	target := s.options.Endpoint + path
+	if rawQuery != "" {
+		target += "?" + rawQuery
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/octet-stream")
+	}
+	s.sign(request, path, rawQuery, body, time.Now().UTC())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return response, nil
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound && key != "" {
+		return nil, ErrNotFound
+	}
+	message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+	return nil, fmt.Errorf("s3 %s %s: %s: %s", method, path, response.Status, strings.TrimSpace(string(message)))
+}
+
+// sign adds the AWS Signature Version 4 headers of the request, signing its host, date and payload hash.
+func (s *S3) sign(request *http.Request, path, rawQuery string, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	date := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	request.Header.Set("X-Amz-Date", date)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		path,
+		rawQuery,
+		"host:" + request.URL.Host + "\n" + "x-amz-content-sha256:" + payloadHash + "\n" + "x-amz-date:" + date + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.options.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + date + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.options.SecretAccessKey), day)
+	signingKey = hmacSHA256(signingKey, s.options.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.options.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery returns the query string sorted by key, encoded as Signature Version 4 expects.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes every byte but the unreserved characters of RFC 3986, and "/" unless encodeSlash.
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '.', b == '_', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of the data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data with the key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
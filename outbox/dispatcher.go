@@ -0,0 +1,134 @@
+// dispatcher.go
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// Handler delivers a single outbox message. Returning an error schedules a retry.
+type Handler func(ctx context.Context, message models.OutboxMessage) error
+
+// messageKey is the context key of the ID of the message being delivered.
+type messageKey struct{}
+
+// WithMessageID returns a copy of the context carrying the ID of the message being delivered, for the
+// consumers that deliver it later on their own.
+func WithMessageID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, messageKey{}, id)
+}
+
+// MessageID returns the ID of the message whose delivery runs with the context, or "" outside of a delivery.
+// It stays the same across the retries of the message, so consumers can recognize a message seen before.
+func MessageID(ctx context.Context) string {
+	id, _ := ctx.Value(messageKey{}).(string)
+	return id
+}
+
+// Dispatcher delivers pending outbox messages to the handler registered for their topic
+// and marks them as dispatched, retrying failed deliveries with exponential backoff.
+type Dispatcher struct {
+	repo     repository.OutboxRepository
+	clock    clock.Clock
+	logger   *slog.Logger
+	handlers map[string]Handler
+
+	PollInterval time.Duration // Wait between polls when the outbox is drained
+	Lease        time.Duration // How long a claimed message is hidden from other dispatchers
+	MaxAttempts  int           // Deliveries attempted before a message is left for inspection
+	MaxBackoff   time.Duration // Upper bound of the retry delay
+}
+
+// NewDispatcher creates a Dispatcher with sensible defaults.
+func NewDispatcher(repo repository.OutboxRepository, clk clock.Clock, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		repo:         repo,
+		clock:        clk,
+		logger:       logger,
+		handlers:     map[string]Handler{},
+		PollInterval: 2 * time.Second,
+		Lease:        time.Minute,
+		MaxAttempts:  10,
+		MaxBackoff:   30 * time.Minute,
+	}
+}
+
+// Register sets the handler that delivers messages of the given topic.
+// Messages of topics without a handler stay pending until one is registered.
+func (d *Dispatcher) Register(topic string, handler Handler) {
+	d.handlers[topic] = handler
+}
+
+// Run dispatches messages until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchPending(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Error("outbox dispatch failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending delivers every message that is currently due and returns how many were delivered.
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	topics := make([]string, 0, len(d.handlers))
+	for topic := range d.handlers {
+		topics = append(topics, topic)
+	}
+	if len(topics) == 0 {
+		return 0, nil
+	}
+
+	delivered := 0
+	for ctx.Err() == nil {
+		message, err := d.repo.Claim(ctx, topics, d.clock.Now(), d.Lease, d.MaxAttempts)
+		if errors.Is(err, repository.ErrNotFound) {
+			return delivered, nil
+		}
+		if err != nil {
+			return delivered, err
+		}
+
+		if err := d.handlers[message.Topic](WithMessageID(ctx, message.ID), *message); err != nil {
+			retryAt := d.clock.Now().Add(d.backoff(message.Attempts))
+			d.logger.Warn("outbox delivery failed", "id", message.ID, "topic", message.Topic,
+				"attempt", message.Attempts, "retry_at", retryAt, "error", err)
+			if err := d.repo.MarkFailed(ctx, message.ID, err.Error(), retryAt); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		if err := d.repo.MarkDispatched(ctx, message.ID, d.clock.Now()); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+	return delivered, ctx.Err()
+}
+
+// backoff returns the delay before the next attempt after the given number of attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempts && delay < d.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.MaxBackoff {
+		delay = d.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,176 @@
+// dispatcher_test.go
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// memoryOutbox is an in-memory repository.OutboxRepository claiming the messages like the database ones.
+type memoryOutbox struct {
+	mu       sync.Mutex
+	messages []*models.OutboxMessage
+}
+
+func (o *memoryOutbox) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = append(o.messages, message)
+	return nil
+}
+
+func (o *memoryOutbox) Claim(ctx context.Context, topics []string, now time.Time, lease time.Duration, maxAttempts int) (*models.OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, message := range o.messages {
+		if message.DispatchedAt == nil && slices.Contains(topics, message.Topic) && !message.NextAttemptAt.After(now) && message.Attempts < maxAttempts {
+			message.Attempts++
+			message.NextAttemptAt = now.Add(lease)
+			claimed := *message
+			return &claimed, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (o *memoryOutbox) MarkDispatched(ctx context.Context, id string, at time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.find(id).DispatchedAt = &at
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(ctx context.Context, id, reason string, retryAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	message := o.find(id)
+	message.LastError, message.NextAttemptAt = reason, retryAt
+	return nil
+}
+
+func (o *memoryOutbox) find(id string) *models.OutboxMessage {
+	for _, message := range o.messages {
+		if message.ID == id {
+			return message
+		}
+	}
+	return nil
+}
+
+func newTestDispatcher(repo repository.OutboxRepository, clk clock.Clock) *Dispatcher {
+	return NewDispatcher(repo, clk, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestDispatchPendingDeliversByTopic(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	repo := &memoryOutbox{}
+	for _, topic := range []string{TopicEventCreated, TopicEventDeleted, TopicEventCreated} {
+		message, _ := NewMessage(topic, map[string]string{"id": "e1"}, clk.Now())
+		repo.Enqueue(ctx, message)
+	}
+	d := newTestDispatcher(repo, clk)
+	var delivered []string
+	d.Register(TopicEventCreated, func(ctx context.Context, message models.OutboxMessage) error {
+		if MessageID(ctx) != message.ID {
+			t.Errorf("MessageID = %q, want %q", MessageID(ctx), message.ID)
+		}
+		delivered = append(delivered, message.ID)
+		return nil
+	})
+
+	n, err := d.DispatchPending(ctx)
+	if err != nil {
+		t.Fatalf("DispatchPending: %v", err)
+	}
+	if n != 2 || len(delivered) != 2 {
+		t.Fatalf("delivered %d messages (%v), want the 2 with a handler", n, delivered)
+	}
+	if repo.messages[1].DispatchedAt != nil || repo.messages[1].Attempts != 0 {
+		t.Errorf("message without handler = %+v, want it left pending and unclaimed", repo.messages[1])
+	}
+	if n, _ := d.DispatchPending(ctx); n != 0 {
+		t.Errorf("second DispatchPending delivered %d messages, want 0", n)
+	}
+}
+
+func TestDispatchPendingRetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	repo := &memoryOutbox{}
+	message, _ := NewMessage(TopicEventCreated, map[string]string{"id": "e1"}, clk.Now())
+	repo.Enqueue(ctx, message)
+	d := newTestDispatcher(repo, clk)
+	d.MaxAttempts = 3
+	failures := 2
+	d.Register(TopicEventCreated, func(ctx context.Context, message models.OutboxMessage) error {
+		if failures > 0 {
+			failures--
+			return errors.New("mailer unavailable")
+		}
+		return nil
+	})
+
+	for _, wait := range []time.Duration{time.Second, 2 * time.Second} {
+		if n, err := d.DispatchPending(ctx); n != 0 || err != nil {
+			t.Fatalf("DispatchPending = %d, %v, want a failed delivery", n, err)
+		}
+		if got := repo.messages[0].NextAttemptAt.Sub(clk.Now()); got != wait {
+			t.Fatalf("retry in %v, want %v", got, wait)
+		}
+		if repo.messages[0].LastError != "mailer unavailable" {
+			t.Errorf("LastError = %q, want the error of the handler", repo.messages[0].LastError)
+		}
+		clk.Advance(wait)
+	}
+
+	if n, err := d.DispatchPending(ctx); n != 1 || err != nil {
+		t.Fatalf("DispatchPending after the backoff = %d, %v, want 1 delivery", n, err)
+	}
+	if repo.messages[0].DispatchedAt == nil || repo.messages[0].Attempts != 3 {
+		t.Errorf("message = %+v, want dispatched at the third attempt", repo.messages[0])
+	}
+}
+
+func TestDispatchPendingStopsAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	repo := &memoryOutbox{}
+	message, _ := NewMessage(TopicEventCreated, nil, clk.Now())
+	repo.Enqueue(ctx, message)
+	d := newTestDispatcher(repo, clk)
+	d.MaxAttempts = 2
+	attempts := 0
+	d.Register(TopicEventCreated, func(ctx context.Context, message models.OutboxMessage) error {
+		attempts++
+		return errors.New("down")
+	})
+
+	for i := 0; i < 5; i++ {
+		d.DispatchPending(ctx)
+		clk.Advance(time.Hour)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want MaxAttempts (2)", attempts)
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	d := newTestDispatcher(&memoryOutbox{}, clock.NewFake(time.Now()))
+	d.MaxBackoff = 10 * time.Second
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 30: 10 * time.Second} {
+		if got := d.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// outbox.go
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"los-complejos-backend/models"
+
+	"github.com/google/uuid"
+)
+
+// Topics of the messages written to the outbox.
+const (
+	TopicComplejoRegistered = "complejo.registered"
+	TopicComplejoDeleted    = "complejo.deleted"
+	TopicComplejoPRAchieved = "complejo.pr_achieved"
+	TopicGuestConverted     = "complejo.guest_converted"
+	TopicEventCreated       = "event.created"
+	TopicEventUpdated       = "event.updated"
+	TopicEventDeleted       = "event.deleted"
+	TopicEventSubscribed    = "event.subscribed"
+	TopicEventUnsubscribed  = "event.unsubscribed"
+	TopicEventRSVPChanged   = "event.rsvp_changed"
+	TopicSevereWeather      = "event.severe_weather"
+	TopicEventReminder      = "event.reminder"
+	TopicPhotoTagged        = "event.photo_tagged"
+	TopicLoanOverdue        = "inventory.loan_overdue"
+	TopicClaimDecided       = "lost_found.claim_decided"
+	TopicShiftReminder      = "volunteer.shift_reminder"
+	TopicHoldDecided        = "moderation.hold_decided"
+	TopicAnnouncement       = "announcement.published"
+	TopicChallengeWon       = "challenge.won"
+)
+
+// NewMessage builds a pending outbox message for the topic with the JSON-encoded payload.
+func NewMessage(topic string, payload interface{}, now time.Time) (*models.OutboxMessage, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OutboxMessage{
+		ID:            uuid.NewString(),
+		Topic:         topic,
+		Payload:       body,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}, nil
+}
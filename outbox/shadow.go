@@ -0,0 +1,39 @@
+// shadow.go
+package outbox
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// ShadowKey is the key set in the gin context of the requests mirrored from another deployment
+// (see middleware.ShadowReceiver).
+const ShadowKey = "shadow_request"
+
+// Shadowed reports whether the context belongs to a request mirrored from another deployment.
+func Shadowed(ctx context.Context) bool {
+	shadowed, _ := ctx.Value(ShadowKey).(bool)
+	return shadowed
+}
+
+// ShadowRepository decorates a repository.OutboxRepository so the changes made by mirrored requests are stored
+// without their messages: the notifications, pushes, webhooks and other deliveries of the outbox were already made
+// by the primary deployment, and would reach the users and the subscribers twice.
+type ShadowRepository struct {
+	repository.OutboxRepository
+}
+
+// NewShadowRepository wraps the repository.
+func NewShadowRepository(repo repository.OutboxRepository) *ShadowRepository {
+	return &ShadowRepository{OutboxRepository: repo}
+}
+
+// Enqueue stores the message, unless the context belongs to a mirrored request.
+func (r *ShadowRepository) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	if Shadowed(ctx) {
+		return nil
+	}
+	return r.OutboxRepository.Enqueue(ctx, message)
+}
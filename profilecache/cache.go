@@ -0,0 +1,147 @@
+// cache.go
+package profilecache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+)
+
+// Stats is a snapshot of the cache metrics.
+type Stats struct {
+	Entries       int     `json:"entries"`       // Profiles currently cached across requests
+	Capacity      int     `json:"capacity"`      // Profiles that may be cached before the oldest are evicted
+	TTL           string  `json:"ttl"`           // How long a profile stays cached across requests
+	RequestHits   uint64  `json:"request_hits"`  // Reads served by the cache of their request
+	Hits          uint64  `json:"hits"`          // Reads served by the cache shared across requests
+	Misses        uint64  `json:"misses"`        // Reads that went to the database
+	HitRate       float64 `json:"hit_rate"`      // Share of the reads served by either cache
+	Invalidations uint64  `json:"invalidations"` // Profiles dropped because they were written
+	Evictions     uint64  `json:"evictions"`     // Profiles dropped to make room
+}
+
+// entry is a cached profile.
+type entry struct {
+	complejo models.Complejo
+	expires  time.Time
+}
+
+// Cache keeps the profiles of the Complejos read recently, by ID, for a short time. It is safe for concurrent use.
+type Cache struct {
+	clock    clock.Clock
+	ttl      time.Duration
+	capacity int
+
+	mu         sync.Mutex
+	entries    map[string]entry
+	generation uint64 // Incremented by every invalidation, so a read racing a write does not cache the old profile
+
+	requestHits   atomic.Uint64
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+	evictions     atomic.Uint64
+}
+
+// New creates a Cache keeping at most capacity profiles for the ttl; a zero ttl disables the cache across requests.
+func New(ttl time.Duration, capacity int, clk clock.Clock) *Cache {
+	return &Cache{
+		clock:    clk,
+		ttl:      ttl,
+		capacity: capacity,
+		entries:  make(map[string]entry),
+	}
+}
+
+// get returns a copy of the cached profile with the given ID, and the generation to pass to put when it is
+// not cached.
+func (c *Cache) get(id string) (*models.Complejo, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[id]
+	if ok && c.clock.Now().Before(e.expires) {
+		complejo := e.complejo
+		return &complejo, c.generation
+	}
+	if ok {
+		delete(c.entries, id)
+	}
+	return nil, c.generation
+}
+
+// put caches a copy of the profile read at the given generation, unless a profile was invalidated since.
+func (c *Cache) put(complejo *models.Complejo, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation || c.ttl <= 0 {
+		return
+	}
+	now := c.clock.Now()
+	if _, ok := c.entries[complejo.ID]; !ok && len(c.entries) >= c.capacity {
+		c.evict(now)
+	}
+	c.entries[complejo.ID] = entry{complejo: *complejo, expires: now.Add(c.ttl)}
+}
+
+// evict drops the expired profiles, or one profile when none expired. c.mu must be held.
+func (c *Cache) evict(now time.Time) {
+	for id, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, id)
+		}
+	}
+	for id := range c.entries {
+		if len(c.entries) < c.capacity {
+			break
+		}
+		delete(c.entries, id)
+		c.evictions.Add(1)
+	}
+}
+
+// Invalidate drops the cached profile with the given ID.
+func (c *Cache) Invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	delete(c.entries, id)
+	c.invalidations.Add(1)
+}
+
+// Clear drops every cached profile, as when the data was removed.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.invalidations.Add(uint64(len(c.entries)))
+	c.entries = make(map[string]entry)
+}
+
+// Stats returns a snapshot of the cache metrics.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+
+	stats := Stats{
+		Entries:       entries,
+		Capacity:      c.capacity,
+		TTL:           c.ttl.String(),
+		RequestHits:   c.requestHits.Load(),
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+		Evictions:     c.evictions.Load(),
+	}
+	if reads := stats.RequestHits + stats.Hits + stats.Misses; reads > 0 {
+		stats.HitRate = float64(stats.RequestHits+stats.Hits) / float64(reads)
+	}
+	return stats
+}
@@ -0,0 +1,120 @@
+// repository.go
+package profilecache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// ScopeKey is the key of the per-request Scope in the gin context (see middleware.ProfileCacheMiddleware).
+const ScopeKey = "profile_cache"
+
+// Scope caches the profiles read during one request, so a profile is read at most once per request even when
+// the shared cache is disabled or the profile expires in between. It is safe for concurrent use.
+type Scope struct {
+	mu       sync.Mutex
+	profiles map[string]models.Complejo
+}
+
+// NewScope creates an empty Scope.
+func NewScope() *Scope {
+	return &Scope{profiles: make(map[string]models.Complejo)}
+}
+
+// scopeFrom returns the Scope of the request the context belongs to, or nil outside of a request.
+func scopeFrom(ctx context.Context) *Scope {
+	scope, _ := ctx.Value(ScopeKey).(*Scope)
+	return scope
+}
+
+// Repository decorates a repository.ComplejoRepository with the caches: FindByID reads the profile from the
+// Scope of the request, then from the shared Cache, and only then from the database. The writes through the
+// Repository invalidate the written profile. A profile written by another instance of the API, or within a
+// transaction that is rolled back, may be served stale until its TTL elapses.
+type Repository struct {
+	repository.ComplejoRepository
+	cache *Cache
+}
+
+// NewRepository wraps the repository with the cache.
+func NewRepository(repo repository.ComplejoRepository, cache *Cache) *Repository {
+	return &Repository{ComplejoRepository: repo, cache: cache}
+}
+
+// FindByID returns the Complejo with the given ID, or ErrNotFound. Missing Complejos are not cached.
+func (r *Repository) FindByID(ctx context.Context, id string) (*models.Complejo, error) {
+	scope := scopeFrom(ctx)
+	if scope != nil {
+		scope.mu.Lock()
+		complejo, ok := scope.profiles[id]
+		scope.mu.Unlock()
+		if ok {
+			r.cache.requestHits.Add(1)
+			return &complejo, nil
+		}
+	}
+
+	complejo, generation := r.cache.get(id)
+	if complejo != nil {
+		r.cache.hits.Add(1)
+	} else {
+		r.cache.misses.Add(1)
+		var err error
+		if complejo, err = r.ComplejoRepository.FindByID(ctx, id); err != nil {
+			return nil, err
+		}
+		r.cache.put(complejo, generation)
+	}
+
+	if scope != nil {
+		scope.mu.Lock()
+		scope.profiles[id] = *complejo
+		scope.mu.Unlock()
+	}
+	return complejo, nil
+}
+
+// UpdateByID sets the given fields on the Complejo with the given ID and invalidates its profile.
+func (r *Repository) UpdateByID(ctx context.Context, id, role string, fields map[string]interface{}) (bool, error) {
+	defer r.invalidate(ctx, id)
+	return r.ComplejoRepository.UpdateByID(ctx, id, role, fields)
+}
+
+// SetChurnRisk stores the churn-risk score of the Complejo with the given ID and invalidates its profile.
+func (r *Repository) SetChurnRisk(ctx context.Context, id string, risk *models.ChurnRisk) (bool, error) {
+	defer r.invalidate(ctx, id)
+	return r.ComplejoRepository.SetChurnRisk(ctx, id, risk)
+}
+
+// DeleteByID marks the Complejo with the given ID as deleted and invalidates its profile.
+func (r *Repository) DeleteByID(ctx context.Context, id string, at time.Time) (bool, error) {
+	defer r.invalidate(ctx, id)
+	return r.ComplejoRepository.DeleteByID(ctx, id, at)
+}
+
+// RestoreByID clears the deletion mark of the Complejo with the given ID and invalidates its profile.
+func (r *Repository) RestoreByID(ctx context.Context, id string) (bool, error) {
+	defer r.invalidate(ctx, id)
+	return r.ComplejoRepository.RestoreByID(ctx, id)
+}
+
+// Anonymize erases the personal fields of the Complejo with the given ID and invalidates its profile.
+func (r *Repository) Anonymize(ctx context.Context, id, placeholder string, at time.Time) (bool, error) {
+	defer r.invalidate(ctx, id)
+	return r.ComplejoRepository.Anonymize(ctx, id, placeholder, at)
+}
+
+// invalidate drops the profile with the given ID from both caches. It runs once the write returned, so a read
+// racing the write cannot cache the old profile after it.
+func (r *Repository) invalidate(ctx context.Context, id string) {
+	r.cache.Invalidate(id)
+	if scope := scopeFrom(ctx); scope != nil {
+		scope.mu.Lock()
+		delete(scope.profiles, id)
+		scope.mu.Unlock()
+	}
+}
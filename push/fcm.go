@@ -0,0 +1,234 @@
+// fcm.go
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// fcmScope is the OAuth 2.0 scope of the FCM HTTP v1 API.
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// fcmEndpoint is the URL messages are sent to, formatted with the ID of the Firebase project.
+const fcmEndpoint = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+
+// FCMSender delivers push notifications to Android and iOS apps through the HTTP v1 API of Firebase Cloud
+// Messaging. It authenticates as a service account of the Firebase project, caching its access tokens until
+// shortly before they expire.
+type FCMSender struct {
+	endpoint   string
+	email      string
+	key        *rsa.PrivateKey
+	tokenURI   string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// serviceAccount holds the fields of the JSON key file of a Google service account used by FCMSender.
+type serviceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// NewFCMSender creates an FCMSender for the Firebase project with the given ID, authenticating with the JSON key
+// file of a service account of the project.
+func NewFCMSender(projectID string, credentials []byte) (*FCMSender, error) {
+	var account serviceAccount
+	if err := json.Unmarshal(credentials, &account); err != nil {
+		return nil, fmt.Errorf("invalid FCM credentials: %w", err)
+	}
+	if account.ClientEmail == "" || account.TokenURI == "" {
+		return nil, errors.New("invalid FCM credentials: client_email and token_uri are required")
+	}
+	block, _ := pem.Decode([]byte(account.PrivateKey))
+	if block == nil {
+		return nil, errors.New("invalid FCM credentials: private_key is not a PEM block")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FCM credentials: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("invalid FCM credentials: private_key is not an RSA key")
+	}
+
+	return &FCMSender{
+		endpoint:   fmt.Sprintf(fcmEndpoint, url.PathEscape(projectID)),
+		email:      account.ClientEmail,
+		key:        key,
+		tokenURI:   account.TokenURI,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// fcmMessage is the body of a request to the FCM HTTP v1 API.
+type fcmMessage struct {
+	Message struct {
+		Token        string            `json:"token"`
+		Notification Notification      `json:"notification"`
+		Data         map[string]string `json:"data,omitempty"`
+	} `json:"message"`
+}
+
+// fcmError is the body of an error response of the FCM HTTP v1 API.
+type fcmError struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// Send delivers the notification to the app with the registration token of the device.
+func (s *FCMSender) Send(ctx context.Context, device models.Device, notification Notification) error {
+	accessToken, err := s.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	var message fcmMessage
+	message.Message.Token = device.Token
+	message.Message.Notification = Notification{Title: notification.Title, Body: notification.Body}
+	message.Message.Data = notification.Data
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 300 {
+		return nil
+	}
+	var failure fcmError
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&failure)
+	for _, detail := range failure.Error.Details {
+		// The message is built here, so an invalid argument is the token given by the client
+		if detail.ErrorCode == "UNREGISTERED" || detail.ErrorCode == "INVALID_ARGUMENT" {
+			return ErrInvalidToken
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrInvalidToken
+	}
+	return fmt.Errorf("FCM responded with status %d: %s", resp.StatusCode, failure.Error.Message)
+}
+
+// token returns an access token of the service account, requesting a new one when the cached one expires
+// within a minute.
+func (s *FCMSender) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.accessToken != "" && now.Add(time.Minute).Before(s.expiresAt) {
+		return s.accessToken, nil
+	}
+
+	assertion, err := s.assertion(now)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("FCM token endpoint responded with status %d", resp.StatusCode)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("FCM token endpoint returned no access token")
+	}
+	s.accessToken = token.AccessToken
+	s.expiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	return s.accessToken, nil
+}
+
+// assertion returns the JWT, signed with the key of the service account, exchanged for an access token.
+func (s *FCMSender) assertion(now time.Time) (string, error) {
+	claims := map[string]interface{}{
+		"iss":   s.email,
+		"scope": fcmScope,
+		"aud":   s.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+	unsigned, err := jwtSigningInput("RS256", claims)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// jwtSigningInput returns the encoded header and claims of a JWT signed with the algorithm, joined by a dot.
+func jwtSigningInput(algorithm string, claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": algorithm, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload), nil
+}
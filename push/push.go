@@ -0,0 +1,26 @@
+// push.go
+package push
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/models"
+)
+
+// ErrInvalidToken is returned when the push service no longer accepts the token of a device, e.g. because the
+// app was uninstalled or the browser unsubscribed; the device should be forgotten.
+var ErrInvalidToken = errors.New("push token is no longer valid")
+
+// Notification is the content of a push notification.
+type Notification struct {
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"` // Handled by the client, e.g. {"event_id": "..."} to open the Event
+}
+
+// Sender delivers push notifications to the devices of one platform.
+type Sender interface {
+	// Send delivers the notification to the device, failing with ErrInvalidToken when its token is no longer valid.
+	Send(ctx context.Context, device models.Device, notification Notification) error
+}
@@ -0,0 +1,248 @@
+// webpush.go
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/netguard"
+)
+
+// Web Push parameters (RFC 8030, RFC 8291 and RFC 8292).
+const (
+	webPushTTL        = 24 * time.Hour // How long the push service keeps a notification for an offline browser
+	webPushRecordSize = 4096           // Record size of the aes128gcm encoding; payloads fit in a single record
+	vapidExpiry       = 12 * time.Hour // Lifetime of the VAPID tokens, at most 24 hours
+)
+
+// WebPushSender delivers push notifications to browsers through the Web Push protocol, identifying the
+// server with VAPID and encrypting the payloads for each subscription.
+type WebPushSender struct {
+	key        *ecdsa.PrivateKey
+	publicKey  []byte // Uncompressed public key
+	subject    string
+	httpClient *http.Client
+}
+
+// NewWebPushSender creates a WebPushSender with the VAPID private key (a P-256 scalar, base64url-encoded) and
+// subject (a mailto: or https: URL the push services can contact). The endpoints of the subscriptions are given
+// by the browsers, so the sender never connects to non-public addresses.
+func NewWebPushSender(privateKey, subject string) (*WebPushSender, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	ecdhKey, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	public := ecdhKey.PublicKey().Bytes()
+
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(public[1:33]),
+			Y:     new(big.Int).SetBytes(public[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}
+	return &WebPushSender{
+		key:        key,
+		publicKey:  public,
+		subject:    subject,
+		httpClient: netguard.Client(10 * time.Second),
+	}, nil
+}
+
+// PublicKey returns the VAPID public key browsers subscribe with, base64url-encoded without padding.
+func (s *WebPushSender) PublicKey() string {
+	return base64.RawURLEncoding.EncodeToString(s.publicKey)
+}
+
+// Send delivers the notification, as JSON, to the push service of the browser subscription of the device.
+func (s *WebPushSender) Send(ctx context.Context, device models.Device, notification Notification) error {
+	endpoint, err := url.Parse(device.Token)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return ErrInvalidToken
+	}
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	body, err := encrypt(payload, device.P256dh, device.Auth)
+	if err != nil {
+		// The keys were validated on registration, so they are not worth keeping
+		return ErrInvalidToken
+	}
+	authorization, err := s.authorization(endpoint.Scheme+"://"+endpoint.Host, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(webPushTTL.Seconds())))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrInvalidToken
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("push service responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// authorization returns the VAPID Authorization header for the push service at the origin (RFC 8292).
+func (s *WebPushSender) authorization(origin string, now time.Time) (string, error) {
+	unsigned, err := jwtSigningInput("ES256", map[string]interface{}{
+		"aud": origin,
+		"exp": now.Add(vapidExpiry).Unix(),
+		"sub": s.subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256([]byte(unsigned))
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return "", err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+
+	token := unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return "vapid t=" + token + ", k=" + s.PublicKey(), nil
+}
+
+// ValidateSubscriptionKeys reports whether the keys of a browser subscription, base64url-encoded, are a P-256
+// public key and a 16-byte authentication secret.
+func ValidateSubscriptionKeys(p256dh, auth string) error {
+	public, err := decodeKey(p256dh)
+	if err != nil {
+		return err
+	}
+	if _, err := ecdh.P256().NewPublicKey(public); err != nil {
+		return errors.New("p256dh is not a P-256 public key")
+	}
+	secret, err := decodeKey(auth)
+	if err != nil || len(secret) != 16 {
+		return errors.New("auth is not a 16-byte secret")
+	}
+	return nil
+}
+
+// encrypt encrypts the payload for the browser subscription with the given keys with the aes128gcm content
+// encoding (RFC 8291), with a new ephemeral key and salt.
+func encrypt(payload []byte, p256dh, auth string) ([]byte, error) {
+	public, err := decodeKey(p256dh)
+	if err != nil {
+		return nil, err
+	}
+	userAgentKey, err := ecdh.P256().NewPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := decodeKey(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return seal(payload, userAgentKey, secret, serverKey, salt)
+}
+
+// seal encrypts the payload for the browser public key and authentication secret with the ephemeral server key
+// and salt.
+func seal(payload []byte, userAgentKey *ecdh.PublicKey, secret []byte, serverKey *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	serverPublic := serverKey.PublicKey().Bytes()
+	// Push services accept bodies of at most 4096 bytes: the header, the payload, its delimiter and the tag
+	if 16+4+1+len(serverPublic)+len(payload)+1+16 > webPushRecordSize {
+		return nil, errors.New("push payload is too large")
+	}
+	shared, err := serverKey.ECDH(userAgentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	public := userAgentKey.Bytes()
+	keyInfo := append(append([]byte("WebPush: info\x00"), public...), serverPublic...)
+	ikm := hkdf(secret, shared, keyInfo, 32)
+	contentKey := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header: salt, record size, key ID length and key ID (the ephemeral public key)
+	header := make([]byte, 0, 16+4+1+len(serverPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(serverPublic)))
+	header = append(header, serverPublic...)
+
+	// A single, last record: the payload followed by the 0x02 delimiter
+	record := append(append([]byte{}, payload...), 0x02)
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+// hkdf derives length bytes (at most 32) from the input keying material with HKDF-SHA256 (RFC 5869).
+func hkdf(salt, ikm, info []byte, length int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(ikm)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	expand.Write(info)
+	expand.Write([]byte{0x01})
+	return expand.Sum(nil)[:length]
+}
+
+// decodeKey decodes a base64url key of a browser subscription, with or without padding.
+func decodeKey(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
@@ -0,0 +1,90 @@
+// errors.go
+package repository
+
+import (
+	"errors"
+	"net/http"
+
+	"los-complejos-backend/apperrors"
+)
+
+// ErrInvalid is returned when the database rejects a write breaking one of its rules (a schema validator, a
+// check, not-null or foreign-key constraint, a value out of the range of its column).
+var ErrInvalid = errors.New("invalid value")
+
+// DuplicateError is returned when a write would break the uniqueness constraint of a field. It matches
+// ErrDuplicate, and stands for a 409 naming the field when the services leave it unhandled.
+type DuplicateError struct {
+	Field string // Field (or comma-separated fields) of the constraint, empty when the driver does not tell
+	Err   error  // Error of the driver
+}
+
+// Error implements the error interface.
+func (e *DuplicateError) Error() string {
+	return ErrDuplicate.Error() + " on " + fieldName(e.Field) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error of the driver.
+func (e *DuplicateError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrDuplicate.
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicate
+}
+
+// APIError implements apperrors.Typed: a 409 naming the field, never the value nor the text of the driver.
+func (e *DuplicateError) APIError() *apperrors.Error {
+	return apperrors.New(http.StatusConflict, apperrors.CodeDuplicate, "A record with this value already exists").
+		WithDetails(map[string]interface{}{"field": e.Field})
+}
+
+// InvalidError is returned when the database rejects a write breaking one of its rules on a field. It matches
+// ErrInvalid, and stands for a 422 naming the field when the services leave it unhandled.
+type InvalidError struct {
+	Field string // Field of the rule, empty when the driver does not tell
+	Rule  string // Rule broken (e.g. "not_null", "check", "schema")
+	Err   error  // Error of the driver
+}
+
+// Error implements the error interface.
+func (e *InvalidError) Error() string {
+	return ErrInvalid.Error() + " on " + fieldName(e.Field) + " (" + e.Rule + "): " + e.Err.Error()
+}
+
+// Unwrap returns the error of the driver.
+func (e *InvalidError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalid.
+func (e *InvalidError) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+// APIError implements apperrors.Typed: a validation error on the field, shaped like the per-field errors of the
+// request validation, never carrying the text of the driver.
+func (e *InvalidError) APIError() *apperrors.Error {
+	return apperrors.Validation("Validation failed", []fieldError{{
+		Field:   e.Field,
+		Rule:    e.Rule,
+		Message: "The value was rejected by the database",
+	}})
+}
+
+// fieldError is the detail of an InvalidError, shaped like validation.FieldError (which lives above the
+// repositories).
+type fieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// fieldName names a field in the error messages.
+func fieldName(field string) string {
+	if field == "" {
+		return "unknown field"
+	}
+	return field
+}
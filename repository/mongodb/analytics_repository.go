@@ -0,0 +1,34 @@
+// analytics_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AnalyticsRepository is the MongoDB implementation of repository.AnalyticsRepository.
+type AnalyticsRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAnalyticsRepository creates an AnalyticsRepository backed by the given collection.
+func NewAnalyticsRepository(collection *mongo.Collection) *AnalyticsRepository {
+	return &AnalyticsRepository{collection: collection}
+}
+
+// InsertMany stores a batch of events.
+func (r *AnalyticsRepository) InsertMany(ctx context.Context, events []models.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(events))
+	for i := range events {
+		documents[i] = events[i]
+	}
+	_, err := r.collection.InsertMany(ctx, documents)
+	return err
+}
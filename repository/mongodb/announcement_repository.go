@@ -0,0 +1,73 @@
+// announcement_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AnnouncementRepository is the MongoDB implementation of repository.AnnouncementRepository.
+type AnnouncementRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAnnouncementRepository creates an AnnouncementRepository backed by the given collection.
+func NewAnnouncementRepository(collection *mongo.Collection) *AnnouncementRepository {
+	return &AnnouncementRepository{collection: collection}
+}
+
+// Insert stores a new Announcement.
+func (r *AnnouncementRepository) Insert(ctx context.Context, announcement *models.Announcement) error {
+	_, err := r.collection.InsertOne(ctx, announcement)
+	return rejected(err)
+}
+
+// FindRecent returns at most limit Announcements, newest first.
+func (r *AnnouncementRepository) FindRecent(ctx context.Context, limit int) ([]models.Announcement, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	announcements := []models.Announcement{}
+	if err := cursor.All(ctx, &announcements); err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
+
+// FindByID returns the Announcement with the given ID, or repository.ErrNotFound.
+func (r *AnnouncementRepository) FindByID(ctx context.Context, id string) (*models.Announcement, error) {
+	var announcement models.Announcement
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&announcement)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// Update replaces the current version of the Announcement and reports whether it was found.
+func (r *AnnouncementRepository) Update(ctx context.Context, announcement *models.Announcement) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": announcement.ID}, bson.M{"$set": bson.M{
+		"title":      announcement.Title,
+		"body":       announcement.Body,
+		"version":    announcement.Version,
+		"updated_by": announcement.UpdatedBy,
+		"updated_at": announcement.UpdatedAt,
+	}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -0,0 +1,48 @@
+// audit_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditRepository is the MongoDB implementation of repository.AuditRepository.
+type AuditRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuditRepository creates an AuditRepository backed by the given collection.
+func NewAuditRepository(collection *mongo.Collection) *AuditRepository {
+	return &AuditRepository{collection: collection}
+}
+
+// Append records a new entry.
+func (r *AuditRepository) Append(ctx context.Context, entry *models.AuditEntry) error {
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// FindRecent returns at most limit entries of the action (of every action when empty), most recent first.
+func (r *AuditRepository) FindRecent(ctx context.Context, action string, limit int) ([]models.AuditEntry, error) {
+	filter := bson.M{}
+	if action != "" {
+		filter["action"] = action
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
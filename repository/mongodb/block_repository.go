@@ -0,0 +1,124 @@
+// block_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BlockRepository is the MongoDB implementation of repository.BlockRepository.
+type BlockRepository struct {
+	blocks    *mongo.Collection
+	complejos *mongo.Collection
+}
+
+// NewBlockRepository creates a BlockRepository backed by the given collection, naming the blocked Complejos from
+// the Complejos of the other.
+func NewBlockRepository(blocks, complejos *mongo.Collection) *BlockRepository {
+	return &BlockRepository{blocks: blocks, complejos: complejos}
+}
+
+// Insert stores a new Block, or returns repository.ErrDuplicate when the blocker already blocks the blocked
+// Complejo.
+func (r *BlockRepository) Insert(ctx context.Context, block *models.Block) error {
+	_, err := r.blocks.InsertOne(ctx, block)
+	return rejected(err)
+}
+
+// Delete removes the Block of the blocked Complejo by the blocker and reports whether there was one.
+func (r *BlockRepository) Delete(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	result, err := r.blocks.DeleteOne(ctx, bson.M{"blocker_id": blockerID, "blocked_id": blockedID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// Exists reports whether the blocker blocks the blocked Complejo.
+func (r *BlockRepository) Exists(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	count, err := r.blocks.CountDocuments(ctx, bson.M{"blocker_id": blockerID, "blocked_id": blockedID},
+		options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// FindBlockerIDs returns the IDs of the Complejos blocking the Complejo.
+func (r *BlockRepository) FindBlockerIDs(ctx context.Context, blockedID string) ([]string, error) {
+	opts := options.Find().SetProjection(bson.M{"blocker_id": 1})
+	cursor, err := r.blocks.Find(ctx, bson.M{"blocked_id": blockedID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var blocks []models.Block
+	if err := cursor.All(ctx, &blocks); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		ids = append(ids, block.BlockerID)
+	}
+	return ids, nil
+}
+
+// blocksDocument is the result of the FindBlocked pipeline.
+type blocksDocument struct {
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+	Page []models.BlockEntry `bson:"page"`
+}
+
+// FindBlocked returns the page of the live Complejos the blocker blocks, latest first, and their total number.
+func (r *BlockRepository) FindBlocked(ctx context.Context, blockerID string, offset, limit int) ([]models.BlockEntry, int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"blocker_id": blockerID}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.complejos.Name(),
+			"localField":   "blocked_id",
+			"foreignField": "_id",
+			"as":           "complejo",
+		}}},
+		{{Key: "$unwind", Value: "$complejo"}},
+		{{Key: "$match", Value: bson.M{"complejo.deleted_at": nil}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: "blocked_id", Value: 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"page": bson.A{
+				bson.M{"$skip": offset},
+				bson.M{"$limit": limit},
+				bson.M{"$project": bson.M{
+					"_id":        "$blocked_id",
+					"username":   "$complejo.username",
+					"blocked_at": "$created_at",
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := r.blocks.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []blocksDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, 0, err
+	}
+
+	entries := []models.BlockEntry{}
+	var total int64
+	if len(documents) > 0 {
+		entries = append(entries, documents[0].Page...)
+		if len(documents[0].Total) > 0 {
+			total = documents[0].Total[0].Count
+		}
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,201 @@
+// challenge_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChallengeRepository is the MongoDB implementation of repository.ChallengeRepository.
+type ChallengeRepository struct {
+	challenges   *mongo.Collection
+	participants *mongo.Collection
+	complejos    *mongo.Collection
+}
+
+// NewChallengeRepository creates a ChallengeRepository backed by the challenge and participant collections,
+// naming the participants from the Complejos of the given collection.
+func NewChallengeRepository(challenges, participants, complejos *mongo.Collection) *ChallengeRepository {
+	return &ChallengeRepository{challenges: challenges, participants: participants, complejos: complejos}
+}
+
+// Insert stores a new Challenge.
+func (r *ChallengeRepository) Insert(ctx context.Context, challenge *models.Challenge) error {
+	_, err := r.challenges.InsertOne(ctx, challenge)
+	return rejected(err)
+}
+
+// FindByID returns the Challenge with the given ID, or repository.ErrNotFound.
+func (r *ChallengeRepository) FindByID(ctx context.Context, id string) (*models.Challenge, error) {
+	var challenge models.Challenge
+	err := r.challenges.FindOne(ctx, bson.M{"_id": id}).Decode(&challenge)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// Search returns the page of the normalized filter of the challenges with its status at now, latest start
+// first, and their total number.
+func (r *ChallengeRepository) Search(ctx context.Context, filter models.ChallengeFilter, now time.Time) ([]models.Challenge, int64, error) {
+	query := bson.M{}
+	switch filter.Status {
+	case models.ChallengeUpcoming:
+		query["starts_at"] = bson.M{"$gt": now}
+	case models.ChallengeActive:
+		query["starts_at"] = bson.M{"$lte": now}
+		query["ends_at"] = bson.M{"$gt": now}
+	case models.ChallengeEnded:
+		query["ends_at"] = bson.M{"$lte": now}
+	}
+
+	total, err := r.challenges.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "starts_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(filter.Offset())).
+		SetLimit(int64(filter.Limit))
+	cursor, err := r.challenges.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	challenges := []models.Challenge{}
+	if err := cursor.All(ctx, &challenges); err != nil {
+		return nil, 0, err
+	}
+	return challenges, total, nil
+}
+
+// FindScorable returns the challenges started at now that were never scored or last scored before their end.
+func (r *ChallengeRepository) FindScorable(ctx context.Context, now time.Time) ([]models.Challenge, error) {
+	filter := bson.M{
+		"starts_at": bson.M{"$lte": now},
+		"$or": bson.A{
+			bson.M{"scored_at": nil},
+			bson.M{"$expr": bson.M{"$lt": bson.A{"$scored_at", "$ends_at"}}},
+		},
+	}
+	cursor, err := r.challenges.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "starts_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	challenges := []models.Challenge{}
+	if err := cursor.All(ctx, &challenges); err != nil {
+		return nil, err
+	}
+	return challenges, nil
+}
+
+// DeleteByID removes the Challenge with the given ID and its participants, and reports whether it was found.
+func (r *ChallengeRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	result, err := r.challenges.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	if _, err := r.participants.DeleteMany(ctx, bson.M{"challenge_id": id}); err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// AddParticipant stores a new ChallengeParticipant, or returns repository.ErrDuplicate when the Complejo already
+// joined the Challenge.
+func (r *ChallengeRepository) AddParticipant(ctx context.Context, participant *models.ChallengeParticipant) error {
+	_, err := r.participants.InsertOne(ctx, participant)
+	return rejected(err)
+}
+
+// RemoveParticipant removes the Complejo from the participants of the Challenge and reports whether it was one.
+func (r *ChallengeRepository) RemoveParticipant(ctx context.Context, id, complejoID string) (bool, error) {
+	result, err := r.participants.DeleteOne(ctx, bson.M{"challenge_id": id, "complejo_id": complejoID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// FindParticipants returns the IDs of the participants of the Challenge.
+func (r *ChallengeRepository) FindParticipants(ctx context.Context, id string) ([]string, error) {
+	opts := options.Find().SetProjection(bson.M{"complejo_id": 1}).SetSort(bson.D{{Key: "joined_at", Value: 1}})
+	cursor, err := r.participants.Find(ctx, bson.M{"challenge_id": id}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var participants []models.ChallengeParticipant
+	if err := cursor.All(ctx, &participants); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		ids = append(ids, participant.ComplejoID)
+	}
+	return ids, nil
+}
+
+// SetScores stores the scores of the participants of the Challenge, by Complejo ID, and the time of the scoring.
+func (r *ChallengeRepository) SetScores(ctx context.Context, id string, scores map[string]float64, at time.Time) error {
+	for complejoID, score := range scores {
+		_, err := r.participants.UpdateOne(ctx,
+			bson.M{"challenge_id": id, "complejo_id": complejoID},
+			bson.M{"$set": bson.M{"score": score}})
+		if err != nil {
+			return err
+		}
+	}
+	_, err := r.challenges.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"scored_at": at}})
+	return err
+}
+
+// Standings returns the live participants of the Challenge with their current username, highest score first
+// (earliest joined first on a tie), without their rank.
+func (r *ChallengeRepository) Standings(ctx context.Context, id string) ([]models.ChallengeStanding, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"challenge_id": id}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.complejos.Name(),
+			"localField":   "complejo_id",
+			"foreignField": "_id",
+			"as":           "complejo",
+		}}},
+		{{Key: "$unwind", Value: "$complejo"}},
+		{{Key: "$match", Value: bson.M{"complejo.deleted_at": nil}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "joined_at", Value: 1}, {Key: "complejo_id", Value: 1}}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":       "$complejo_id",
+			"username":  "$complejo.username",
+			"score":     1,
+			"joined_at": 1,
+		}}},
+	}
+
+	cursor, err := r.participants.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	standings := []models.ChallengeStanding{}
+	if err := cursor.All(ctx, &standings); err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
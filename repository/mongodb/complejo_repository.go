@@ -0,0 +1,129 @@
+// complejo_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ComplejoRepository is the MongoDB implementation of repository.ComplejoRepository.
+type ComplejoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewComplejoRepository creates a ComplejoRepository backed by the given collection.
+func NewComplejoRepository(collection *mongo.Collection) *ComplejoRepository {
+	return &ComplejoRepository{collection: collection}
+}
+
+// Insert stores a new Complejo. It returns repository.ErrDuplicate when the username is taken.
+func (r *ComplejoRepository) Insert(ctx context.Context, complejo *models.Complejo) error {
+	_, err := r.collection.InsertOne(ctx, complejo)
+	return rejected(err)
+}
+
+// FindAll returns every stored Complejo.
+func (r *ComplejoRepository) FindAll(ctx context.Context) ([]models.Complejo, error) {
+	cursor, err := r.collection.Find(ctx, live(bson.M{}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var complejos []models.Complejo
+	if err := cursor.All(ctx, &complejos); err != nil {
+		return nil, err
+	}
+	return complejos, nil
+}
+
+// FindByID returns the Complejo with the given ID, or repository.ErrNotFound.
+func (r *ComplejoRepository) FindByID(ctx context.Context, id string) (*models.Complejo, error) {
+	var complejo models.Complejo
+	err := r.collection.FindOne(ctx, live(bson.M{"_id": id})).Decode(&complejo)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &complejo, nil
+}
+
+// UpdateByID sets the given fields on the Complejo with the given ID.
+// When role is not empty, only a Complejo with that role is updated.
+// It reports whether a matching document was found, and returns repository.ErrDuplicate when the new username is taken.
+func (r *ComplejoRepository) UpdateByID(ctx context.Context, id, role string, fields map[string]interface{}) (bool, error) {
+	filter := live(bson.M{"_id": id})
+	if role != "" {
+		filter["role"] = role
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": fields})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// SetChurnRisk stores the churn-risk score of the Complejo with the given ID and reports whether it was found.
+func (r *ComplejoRepository) SetChurnRisk(ctx context.Context, id string, risk *models.ChurnRisk) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), bson.M{"$set": bson.M{"churn_risk": risk}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteByID marks the Complejo with the given ID as deleted at the given time and reports whether it was found.
+func (r *ComplejoRepository) DeleteByID(ctx context.Context, id string, at time.Time) (bool, error) {
+	return softDelete(ctx, r.collection, id, at)
+}
+
+// RestoreByID clears the deletion mark of the Complejo with the given ID and reports whether a deleted one was found.
+// Erased Complejos cannot be restored.
+func (r *ComplejoRepository) RestoreByID(ctx context.Context, id string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}, "erased_at": nil},
+		bson.M{"$unset": bson.M{"deleted_at": ""}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// PurgeDeleted permanently removes the Complejos deleted before the given time and returns how many were removed.
+func (r *ComplejoRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return purgeDeleted(ctx, r.collection, before)
+}
+
+// Anonymize replaces the username of the Complejo with the given ID with the placeholder, clears its other
+// personal fields, and marks it as deleted and erased at the given time. It reports whether it was found.
+func (r *ComplejoRepository) Anonymize(ctx context.Context, id, placeholder string, at time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), bson.M{
+		"$set": bson.M{
+			"username":      placeholder,
+			"password":      "",
+			"weight":        0.0,
+			"height":        0.0,
+			"gender":        "other",
+			"bench":         0.0,
+			"squad":         0.0,
+			"dl":            0.0,
+			"photo":         "",
+			"photo_consent": models.PhotoConsentDeny,
+			"deleted_at":    at,
+			"erased_at":     at,
+		},
+		"$unset": bson.M{"email": "", "imc": "", "photo_id": "", "locale": "", "units": "", "goal": "", "calendar_nonce": "", "churn_risk": ""},
+	})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
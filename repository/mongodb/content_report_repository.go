@@ -0,0 +1,103 @@
+// content_report_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ContentReportRepository is the MongoDB implementation of repository.ContentReportRepository.
+type ContentReportRepository struct {
+	collection *mongo.Collection
+}
+
+// NewContentReportRepository creates a ContentReportRepository backed by the given collection.
+func NewContentReportRepository(collection *mongo.Collection) *ContentReportRepository {
+	return &ContentReportRepository{collection: collection}
+}
+
+// Insert stores a new ContentReport, or returns repository.ErrDuplicate when the reporter already has a pending
+// report of the same content.
+func (r *ContentReportRepository) Insert(ctx context.Context, report *models.ContentReport) error {
+	_, err := r.collection.InsertOne(ctx, report)
+	return rejected(err)
+}
+
+// Find returns the page of the reports with the given status, and of the given kind unless it is empty, oldest
+// first, and their total number.
+func (r *ContentReportRepository) Find(ctx context.Context, status, kind string, offset, limit int) ([]models.ContentReport, int64, error) {
+	filter := bson.M{"status": status}
+	if kind != "" {
+		filter["kind"] = kind
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	reports := []models.ContentReport{}
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, 0, err
+	}
+	return reports, total, nil
+}
+
+// FindByID returns the ContentReport with the given ID, or repository.ErrNotFound.
+func (r *ContentReportRepository) FindByID(ctx context.Context, id string) (*models.ContentReport, error) {
+	var report models.ContentReport
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&report)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Decide stores the decision of the pending ContentReport and reports whether it was found.
+func (r *ContentReportRepository) Decide(ctx context.Context, report *models.ContentReport) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": report.ID, "status": models.ReportPending}, reportDecision(report))
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DecideByTarget stores the decision on every pending report of the content and returns how many there were.
+func (r *ContentReportRepository) DecideByTarget(ctx context.Context, report *models.ContentReport) (int64, error) {
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"kind": report.Kind, "target_id": report.TargetID, "status": models.ReportPending}, reportDecision(report))
+	if err != nil {
+		return 0, rejected(err)
+	}
+	return result.MatchedCount, nil
+}
+
+// reportDecision returns the update storing the decision of the report.
+func reportDecision(report *models.ContentReport) bson.M {
+	return bson.M{"$set": bson.M{
+		"status":     report.Status,
+		"note":       report.Note,
+		"decided_by": report.DecidedBy,
+		"decided_at": report.DecidedAt,
+	}}
+}
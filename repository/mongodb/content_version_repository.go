@@ -0,0 +1,71 @@
+// content_version_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ContentVersionRepository is the MongoDB implementation of repository.ContentVersionRepository.
+// Version numbers rely on the unique content_versions_document index.
+type ContentVersionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewContentVersionRepository creates a ContentVersionRepository backed by the given collection.
+func NewContentVersionRepository(collection *mongo.Collection) *ContentVersionRepository {
+	return &ContentVersionRepository{collection: collection}
+}
+
+// Insert stores a new ContentVersion, or returns repository.ErrDuplicate when the document already has
+// a version with its number.
+func (r *ContentVersionRepository) Insert(ctx context.Context, version *models.ContentVersion) error {
+	_, err := r.collection.InsertOne(ctx, version)
+	return rejected(err)
+}
+
+// FindLatest returns the latest version of the document, or repository.ErrNotFound.
+func (r *ContentVersionRepository) FindLatest(ctx context.Context, documentType, documentID string) (*models.ContentVersion, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+	return r.findOne(ctx, bson.M{"document_type": documentType, "document_id": documentID}, opts)
+}
+
+// FindAll returns every version of the document, the latest first.
+func (r *ContentVersionRepository) FindAll(ctx context.Context, documentType, documentID string) ([]models.ContentVersion, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"document_type": documentType, "document_id": documentID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	versions := []models.ContentVersion{}
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// FindVersion returns the given version of the document, or repository.ErrNotFound.
+func (r *ContentVersionRepository) FindVersion(ctx context.Context, documentType, documentID string, version int) (*models.ContentVersion, error) {
+	return r.findOne(ctx, bson.M{"document_type": documentType, "document_id": documentID, "version": version})
+}
+
+// findOne returns the first version matching the filter, or repository.ErrNotFound.
+func (r *ContentVersionRepository) findOne(ctx context.Context, filter bson.M, opts ...*options.FindOneOptions) (*models.ContentVersion, error) {
+	var version models.ContentVersion
+	err := r.collection.FindOne(ctx, filter, opts...).Decode(&version)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
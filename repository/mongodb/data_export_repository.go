@@ -0,0 +1,93 @@
+// data_export_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DataExportRepository is the MongoDB implementation of repository.DataExportRepository.
+type DataExportRepository struct {
+	collection *mongo.Collection
+}
+
+// NewDataExportRepository creates a DataExportRepository backed by the given collection.
+func NewDataExportRepository(collection *mongo.Collection) *DataExportRepository {
+	return &DataExportRepository{collection: collection}
+}
+
+// Insert stores a new DataExport.
+func (r *DataExportRepository) Insert(ctx context.Context, export *models.DataExport) error {
+	_, err := r.collection.InsertOne(ctx, export)
+	return rejected(err)
+}
+
+// FindLatest returns the DataExport most recently requested by the Complejo, or repository.ErrNotFound.
+func (r *DataExportRepository) FindLatest(ctx context.Context, complejoID string) (*models.DataExport, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "requested_at", Value: -1}, {Key: "_id", Value: -1}})
+	return r.findOne(ctx, bson.M{"complejo_id": complejoID}, opts)
+}
+
+// FindByID returns the DataExport with the given ID, or repository.ErrNotFound.
+func (r *DataExportRepository) FindByID(ctx context.Context, id string) (*models.DataExport, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+// MarkReady records that the archive of the pending DataExport was generated, and reports whether a pending
+// DataExport was found.
+func (r *DataExportRepository) MarkReady(ctx context.Context, id string, size int64, at, expiresAt time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "status": models.DataExportPending}, bson.M{"$set": bson.M{
+		"status":       models.DataExportReady,
+		"size":         size,
+		"completed_at": at,
+		"expires_at":   expiresAt,
+	}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// FindExpired returns the exports whose archive expired before the given time.
+func (r *DataExportRepository) FindExpired(ctx context.Context, before time.Time) ([]models.DataExport, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"expires_at": bson.M{"$lt": before}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	exports := []models.DataExport{}
+	if err := cursor.All(ctx, &exports); err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// Delete removes the DataExport with the given ID and reports whether it was found.
+func (r *DataExportRepository) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// findOne returns the DataExport matching the filter, or repository.ErrNotFound.
+func (r *DataExportRepository) findOne(ctx context.Context, filter bson.M, opts ...*options.FindOneOptions) (*models.DataExport, error) {
+	var export models.DataExport
+	err := r.collection.FindOne(ctx, filter, opts...).Decode(&export)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
@@ -0,0 +1,81 @@
+// device_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DeviceRepository is the MongoDB implementation of repository.DeviceRepository.
+type DeviceRepository struct {
+	collection *mongo.Collection
+}
+
+// NewDeviceRepository creates a DeviceRepository backed by the given collection.
+func NewDeviceRepository(collection *mongo.Collection) *DeviceRepository {
+	return &DeviceRepository{collection: collection}
+}
+
+// Upsert stores the Device, or updates the Device registered with the same token (keeping its ID and
+// creation time, which are set on the given Device).
+func (r *DeviceRepository) Upsert(ctx context.Context, device *models.Device) error {
+	update := bson.M{
+		"$set": bson.M{
+			"complejo_id": device.ComplejoID,
+			"platform":    device.Platform,
+			"p256dh":      device.P256dh,
+			"auth":        device.Auth,
+			"name":        device.Name,
+			"updated_at":  device.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{"_id": device.ID, "created_at": device.CreatedAt},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	return rejected(r.collection.FindOneAndUpdate(ctx, bson.M{"token": device.Token}, update, opts).Decode(device))
+}
+
+// FindAll returns every Device.
+func (r *DeviceRepository) FindAll(ctx context.Context) ([]models.Device, error) {
+	return r.find(ctx, bson.M{})
+}
+
+// FindByComplejos returns the Devices of the Complejos with the given IDs, most recently registered first.
+func (r *DeviceRepository) FindByComplejos(ctx context.Context, complejoIDs []string) ([]models.Device, error) {
+	return r.find(ctx, bson.M{"complejo_id": bson.M{"$in": complejoIDs}})
+}
+
+// find returns the Devices matching the filter, most recently registered first.
+func (r *DeviceRepository) find(ctx context.Context, filter bson.M) ([]models.Device, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	devices := []models.Device{}
+	if err := cursor.All(ctx, &devices); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+// Delete removes the Device with the given ID of the Complejo and reports whether it was found.
+func (r *DeviceRepository) Delete(ctx context.Context, id, complejoID string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "complejo_id": complejoID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// DeleteByToken removes the Device registered with the token, if any.
+func (r *DeviceRepository) DeleteByToken(ctx context.Context, token string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"token": token})
+	return err
+}
@@ -0,0 +1,114 @@
+// erasure_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErasureRepository is the MongoDB implementation of repository.ErasureRepository. It spans the collections
+// the data of a Complejo was copied to.
+type ErasureRepository struct {
+	db *mongo.Database
+}
+
+// NewErasureRepository creates an ErasureRepository erasing the Complejos from the given database.
+func NewErasureRepository(db *mongo.Database) *ErasureRepository {
+	return &ErasureRepository{db: db}
+}
+
+// Anonymize replaces the username of the Complejo with the placeholder wherever it was copied and removes its
+// tags from the photos. It returns how many documents were changed by collection.
+func (r *ErasureRepository) Anonymize(ctx context.Context, complejoID, username, placeholder string) (map[string]int64, error) {
+	guests := options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"g.host_id": complejoID}}})
+	volunteers := options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"v.complejo_id": complejoID}}})
+	updates := []struct {
+		collection string
+		filter     bson.M
+		update     bson.M
+		opts       *options.UpdateOptions
+	}{
+		{"event", bson.M{"guests.host_id": complejoID}, bson.M{"$set": bson.M{"guests.$[g].host_username": placeholder}}, guests},
+		{"subscription_events", bson.M{"username": username}, bson.M{"$set": bson.M{"username": placeholder}}, options.Update()},
+		{"volunteer_shifts", bson.M{"volunteers.complejo_id": complejoID}, bson.M{"$set": bson.M{"volunteers.$[v].username": placeholder}}, volunteers},
+		{"loans", bson.M{"complejo_id": complejoID}, bson.M{"$set": bson.M{"username": placeholder}}, options.Update()},
+		{"lost_found_claims", bson.M{"claimant_id": complejoID}, bson.M{"$set": bson.M{"username": placeholder}}, options.Update()},
+		{"event_photos", bson.M{"tags.complejo_id": complejoID}, bson.M{"$pull": bson.M{"tags": bson.M{"complejo_id": complejoID}}}, options.Update()},
+	}
+
+	changed := make(map[string]int64, len(updates))
+	for _, u := range updates {
+		result, err := r.db.Collection(u.collection).UpdateMany(ctx, u.filter, u.update, u.opts)
+		if err != nil {
+			return changed, err
+		}
+		changed[u.collection] += result.ModifiedCount
+	}
+	return changed, nil
+}
+
+// RemoveContent removes the photos the Complejo uploaded, its lost-and-found posts with their claims, its
+// content held for review, its devices, its lift history, its workouts, its nutrition logs, its challenge
+// participations, its group memberships, its follows and blocks, both ways, its activity in the feed and the
+// content it reported. It returns the object store keys of the removed photos and how many documents were removed by collection.
+func (r *ErasureRepository) RemoveContent(ctx context.Context, complejoID string) ([]string, map[string]int64, error) {
+	removed := map[string]int64{}
+
+	photos, err := r.values(ctx, "event_photos", bson.M{"uploaded_by": complejoID}, "key")
+	if err != nil {
+		return nil, removed, err
+	}
+	items, err := r.values(ctx, "lost_found", bson.M{"posted_by": complejoID}, "_id")
+	if err != nil {
+		return nil, removed, err
+	}
+
+	deletions := []struct {
+		collection string
+		filter     bson.M
+	}{
+		{"event_photos", bson.M{"uploaded_by": complejoID}},
+		{"lost_found_claims", bson.M{"item_id": bson.M{"$in": items}}},
+		{"lost_found", bson.M{"posted_by": complejoID}},
+		{"content_holds", bson.M{"author_id": complejoID}},
+		{"devices", bson.M{"complejo_id": complejoID}},
+		{"lift_entries", bson.M{"complejo_id": complejoID}},
+		{"workouts", bson.M{"complejo_id": complejoID}},
+		{"nutrition_logs", bson.M{"complejo_id": complejoID}},
+		{"challenge_participants", bson.M{"complejo_id": complejoID}},
+		{"group_memberships", bson.M{"complejo_id": complejoID}},
+		{"follows", bson.M{"$or": bson.A{bson.M{"follower_id": complejoID}, bson.M{"followee_id": complejoID}}}},
+		{"feed_items", bson.M{"complejo_id": complejoID}},
+		{"blocks", bson.M{"$or": bson.A{bson.M{"blocker_id": complejoID}, bson.M{"blocked_id": complejoID}}}},
+		{"content_reports", bson.M{"reporter_id": complejoID}},
+	}
+	for _, d := range deletions {
+		result, err := r.db.Collection(d.collection).DeleteMany(ctx, d.filter)
+		if err != nil {
+			return nil, removed, err
+		}
+		removed[d.collection] += result.DeletedCount
+	}
+	return photos, removed, nil
+}
+
+// values returns the string field of the documents of the collection matching the filter.
+func (r *ErasureRepository) values(ctx context.Context, collection string, filter bson.M, field string) ([]string, error) {
+	cursor, err := r.db.Collection(collection).Find(ctx, filter, options.Find().SetProjection(bson.M{field: 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	values := []string{}
+	for cursor.Next(ctx) {
+		value, ok := cursor.Current.Lookup(field).StringValueOK()
+		if ok {
+			values = append(values, value)
+		}
+	}
+	return values, cursor.Err()
+}
@@ -0,0 +1,128 @@
+// errors.go
+package mongodb
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// documentValidationFailure is the MongoDB error code of a document failing the validator of its collection.
+const documentValidationFailure = 121
+
+// dupKeyField matches the first field of the key in the message of a duplicate-key error, for servers not
+// sending the keyPattern of the index.
+var dupKeyField = regexp.MustCompile(`dup key: \{ ?"?([^:"\s]+)"?\s*:`)
+
+// rejected turns a write rejected by MongoDB into a repository error naming the field: a duplicate key into a
+// repository.DuplicateError, a document failing the validator of its collection into a repository.InvalidError.
+// It returns other errors unchanged.
+func rejected(err error) error {
+	if err == nil {
+		return nil
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return &repository.DuplicateError{Field: duplicateField(err), Err: err}
+	}
+	var server mongo.ServerError
+	if errors.As(err, &server) && server.HasErrorCode(documentValidationFailure) {
+		return &repository.InvalidError{Field: invalidField(err), Rule: "schema", Err: err}
+	}
+	return err
+}
+
+// duplicateField returns the fields of the index broken by a duplicate-key error, comma-separated, or an empty
+// string when the server does not tell.
+func duplicateField(err error) string {
+	for _, doc := range serverErrors(err) {
+		if pattern, ok := doc.Lookup("keyPattern").DocumentOK(); ok {
+			elements, _ := pattern.Elements()
+			fields := make([]string, len(elements))
+			for i, element := range elements {
+				fields[i] = element.Key()
+			}
+			return strings.Join(fields, ", ")
+		}
+		if message, ok := doc.Lookup("errmsg").StringValueOK(); ok {
+			if match := dupKeyField.FindStringSubmatch(message); match != nil {
+				return match[1]
+			}
+		}
+	}
+	return ""
+}
+
+// invalidField returns the first property failing the validator in the details of a document validation
+// error, or an empty string when the server does not tell.
+func invalidField(err error) string {
+	for _, doc := range serverErrors(err) {
+		if info, ok := doc.Lookup("errInfo").DocumentOK(); ok {
+			if field := propertyName(info); field != "" {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+// propertyName returns the first property named by the details of a failed validation, searching doc depth
+// first.
+func propertyName(doc bson.Raw) string {
+	elements, _ := doc.Elements()
+	for _, element := range elements {
+		value := element.Value()
+		switch element.Key() {
+		case "propertyName":
+			if name, ok := value.StringValueOK(); ok {
+				return name
+			}
+		case "missingProperties":
+			if missing, ok := value.ArrayOK(); ok {
+				if first, err := missing.IndexErr(0); err == nil {
+					if name, ok := first.Value().StringValueOK(); ok {
+						return name
+					}
+				}
+			}
+		}
+		if nested, ok := value.DocumentOK(); ok {
+			if name := propertyName(nested); name != "" {
+				return name
+			}
+		}
+		if nested, ok := value.ArrayOK(); ok {
+			if name := propertyName(nested); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// serverErrors returns the error documents sent by the server for err, whether it is a write, bulk write or
+// command error.
+func serverErrors(err error) []bson.Raw {
+	var docs []bson.Raw
+	var write mongo.WriteException
+	if errors.As(err, &write) {
+		for _, we := range write.WriteErrors {
+			docs = append(docs, we.Raw)
+		}
+	}
+	var bulk mongo.BulkWriteException
+	if errors.As(err, &bulk) {
+		for _, we := range bulk.WriteErrors {
+			docs = append(docs, we.Raw)
+		}
+	}
+	var command mongo.CommandError
+	if errors.As(err, &command) {
+		docs = append(docs, command.Raw)
+	}
+	return docs
+}
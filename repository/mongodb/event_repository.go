@@ -0,0 +1,476 @@
+// event_repository.go
+package mongodb
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EventRepository is the MongoDB implementation of repository.EventRepository.
+type EventRepository struct {
+	collection *mongo.Collection
+	complejos  *mongo.Collection
+}
+
+// NewEventRepository creates an EventRepository backed by the given Event collection.
+// Participants are looked up in the Complejo collection by name, so both must share the database.
+func NewEventRepository(collection, complejos *mongo.Collection) *EventRepository {
+	return &EventRepository{collection: collection, complejos: complejos}
+}
+
+// Insert stores a new Event.
+func (r *EventRepository) Insert(ctx context.Context, event *models.Event) error {
+	_, err := r.collection.InsertOne(ctx, event)
+	return rejected(err)
+}
+
+// FindAll returns every stored Event, the ones pinned at now first, then by date.
+func (r *EventRepository) FindAll(ctx context.Context, now time.Time) ([]models.Event, error) {
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: live(bson.M{})}}}, pinnedFirst(now, 1)...)
+	return r.aggregate(ctx, pipeline)
+}
+
+// Search returns the page of Events matching the filter, the ones pinned at now first, then by date in the order
+// of the filter, and the total number of matches.
+func (r *EventRepository) Search(ctx context.Context, filter models.EventFilter, now time.Time) ([]models.Event, int64, error) {
+	query := live(bson.M{})
+	date := bson.M{}
+	if filter.From != nil {
+		date["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		date["$lte"] = *filter.To
+	}
+	if len(date) > 0 {
+		query["date"] = date
+	}
+	if filter.Location != "" {
+		query["location"] = primitive.Regex{Pattern: regexp.QuoteMeta(filter.Location), Options: "i"}
+	}
+	if filter.Group != "" {
+		query["group_id"] = filter.Group
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	order := 1
+	if filter.Sort == models.SortDescending {
+		order = -1
+	}
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: query}}}, pinnedFirst(now, order)...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$skip", Value: int64(filter.Offset())}},
+		bson.D{{Key: "$limit", Value: int64(filter.Limit)}})
+	events, err := r.aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// FindFeatured returns at most limit featured Events dated from the given time on, the ones pinned at that
+// time first, then by date.
+func (r *EventRepository) FindFeatured(ctx context.Context, from time.Time, limit int) ([]models.Event, error) {
+	pipeline := append(mongo.Pipeline{
+		{{Key: "$match", Value: live(bson.M{"featured": true, "date": bson.M{"$gte": from}})}},
+	}, pinnedFirst(from, 1)...)
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: int64(limit)}})
+	return r.aggregate(ctx, pipeline)
+}
+
+// pinnedFirst returns the stages sorting the Events pinned at now (their pin expires after it) first, then by date
+// in the order (1 for oldest first, -1 for newest first). A missing or null pin sorts below any date.
+func pinnedFirst(now time.Time, order int) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$addFields", Value: bson.M{"pinned": bson.M{"$gt": bson.A{"$pinned_until", now}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "pinned", Value: -1}, {Key: "date", Value: order}, {Key: "_id", Value: order}}}},
+		{{Key: "$project", Value: bson.M{"pinned": 0}}},
+	}
+}
+
+// aggregate returns the Events produced by the pipeline.
+func (r *EventRepository) aggregate(ctx context.Context, pipeline mongo.Pipeline) ([]models.Event, error) {
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// TextSearch returns at most limit Events matching the full-text query, most relevant first.
+func (r *EventRepository) TextSearch(ctx context.Context, query string, limit int) ([]models.ScoredEvent, error) {
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "date", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, live(bson.M{"$text": bson.M{"$search": query}}), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.ScoredEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// FindByID returns the Event with the given ID, or repository.ErrNotFound.
+func (r *EventRepository) FindByID(ctx context.Context, id string) (*models.Event, error) {
+	var event models.Event
+	err := r.collection.FindOne(ctx, live(bson.M{"_id": id})).Decode(&event)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// FindByExternalID returns the Event ingested with the given external ID, or repository.ErrNotFound.
+func (r *EventRepository) FindByExternalID(ctx context.Context, externalID string) (*models.Event, error) {
+	var event models.Event
+	err := r.collection.FindOne(ctx, bson.M{"external_id": externalID}).Decode(&event)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// UpdateByID sets the given fields on the Event with the given ID.
+// It reports whether a matching document was found.
+func (r *EventRepository) UpdateByID(ctx context.Context, id string, fields map[string]interface{}) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), bson.M{"$set": fields})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteByID marks the Event with the given ID as deleted at the given time and reports whether it was found.
+func (r *EventRepository) DeleteByID(ctx context.Context, id string, at time.Time) (bool, error) {
+	return softDelete(ctx, r.collection, id, at)
+}
+
+// RestoreByID clears the deletion mark of the Event with the given ID and reports whether a deleted one was found.
+func (r *EventRepository) RestoreByID(ctx context.Context, id string) (bool, error) {
+	return restore(ctx, r.collection, id)
+}
+
+// PurgeDeleted permanently removes the Events deleted before the given time and returns how many were removed.
+func (r *EventRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return purgeDeleted(ctx, r.collection, before)
+}
+
+// participantsDocument is the result of the FindParticipants pipeline.
+type participantsDocument struct {
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+	Page []models.Participant `bson:"page"`
+}
+
+// FindParticipants returns the page (skipping offset, at most limit) of the profiles of the live Complejos
+// going to the Event, in the order they answered, and their total number.
+func (r *EventRepository) FindParticipants(ctx context.Context, id string, offset, limit int) ([]models.Participant, int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: live(bson.M{"_id": id})}},
+		{{Key: "$unwind", Value: "$rsvps"}},
+		{{Key: "$match", Value: bson.M{"rsvps.status": models.RSVPGoing}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.complejos.Name(),
+			"localField":   "rsvps.complejo_id",
+			"foreignField": "_id",
+			"as":           "complejo",
+		}}},
+		{{Key: "$unwind", Value: "$complejo"}},
+		{{Key: "$match", Value: bson.M{"complejo.deleted_at": nil}}},
+		{{Key: "$sort", Value: bson.D{{Key: "rsvps.responded_at", Value: 1}, {Key: "rsvps.complejo_id", Value: 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"page": bson.A{
+				bson.M{"$skip": offset},
+				bson.M{"$limit": limit},
+				bson.M{"$project": bson.M{
+					"_id":          "$complejo._id",
+					"username":     "$complejo.username",
+					"photo":        "$complejo.photo",
+					"photo_id":     "$complejo.photo_id",
+					"bench":        "$complejo.bench",
+					"squad":        "$complejo.squad",
+					"dl":           "$complejo.dl",
+					"responded_at": "$rsvps.responded_at",
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []participantsDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, 0, err
+	}
+
+	participants := []models.Participant{}
+	var total int64
+	if len(documents) > 0 {
+		participants = append(participants, documents[0].Page...)
+		if len(documents[0].Total) > 0 {
+			total = documents[0].Total[0].Count
+		}
+	}
+	return participants, total, nil
+}
+
+// RemoveRSVPsFromAll removes the RSVPs of the Complejo from every Event
+// and returns the IDs of the Events it was going to.
+func (r *EventRepository) RemoveRSVPsFromAll(ctx context.Context, complejoID string) ([]string, error) {
+	going := bson.M{"rsvps": bson.M{"$elemMatch": bson.M{"complejo_id": complejoID, "status": models.RSVPGoing}}}
+	cursor, err := r.collection.Find(ctx, going, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var matches []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, err
+	}
+
+	_, err = r.collection.UpdateMany(ctx, bson.M{"rsvps.complejo_id": complejoID}, bson.M{
+		"$pull": bson.M{"rsvps": bson.M{"complejo_id": complejoID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+	return ids, nil
+}
+
+// SetRSVP replaces the RSVP of the Complejo on the Event with the given one, moving it to the end of the list.
+// It reports whether the Event was found.
+func (r *EventRepository) SetRSVP(ctx context.Context, id string, rsvp models.RSVP) (bool, error) {
+	others := bson.M{"$filter": bson.M{
+		"input": bson.M{"$ifNull": bson.A{"$rsvps", bson.A{}}},
+		"cond":  bson.M{"$ne": bson.A{"$$this.complejo_id", rsvp.ComplejoID}},
+	}}
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"rsvps": bson.M{"$concatArrays": bson.A{others, bson.A{bson.M{"$literal": rsvp}}}}}}},
+	})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RemoveRSVP removes the RSVP of the Complejo from the Event using `$pull`.
+// It reports whether the Event was found and whether the Complejo had answered.
+func (r *EventRepository) RemoveRSVP(ctx context.Context, id, complejoID string) (matched, modified bool, err error) {
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), bson.M{
+		"$pull": bson.M{"rsvps": bson.M{"complejo_id": complejoID}},
+	})
+	if err != nil {
+		return false, false, rejected(err)
+	}
+	return result.MatchedCount > 0, result.ModifiedCount > 0, nil
+}
+
+// AddGuest registers the guest for the Event (`$push`) and reports whether the Event was found.
+func (r *EventRepository) AddGuest(ctx context.Context, id string, guest models.Guest) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), bson.M{"$push": bson.M{"guests": guest}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RemoveGuest removes the guest from the Event (`$pull`) and reports whether it was registered.
+func (r *EventRepository) RemoveGuest(ctx context.Context, id, guestID string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		live(bson.M{"_id": id, "guests._id": guestID}),
+		bson.M{"$pull": bson.M{"guests": bson.M{"_id": guestID}}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// FindByGuest returns the Events, by date, the Complejo brought a guest with the given name to.
+func (r *EventRepository) FindByGuest(ctx context.Context, hostID, name string) ([]models.Event, error) {
+	filter := live(bson.M{"guests": bson.M{"$elemMatch": bson.M{"host_id": hostID, "name": name}}})
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// CountGuests returns how many guests the Complejo registered in [from, to), deleted Events included.
+func (r *EventRepository) CountGuests(ctx context.Context, hostID string, from, to time.Time) (int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"guests.host_id": hostID}}},
+		{{Key: "$unwind", Value: "$guests"}},
+		{{Key: "$match", Value: bson.M{"guests.host_id": hostID, "guests.created_at": bson.M{"$gte": from, "$lt": to}}}},
+		{{Key: "$count", Value: "count"}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &result); err != nil || len(result) == 0 {
+		return 0, err
+	}
+	return result[0].Count, nil
+}
+
+// SetLike adds the Complejo to the likes of the Event (`$addToSet`), or removes it (`$pull`) when liked is false.
+// It reports whether the Event was found.
+func (r *EventRepository) SetLike(ctx context.Context, id, complejoID string, liked bool) (bool, error) {
+	update := bson.M{"$pull": bson.M{"likes": complejoID}}
+	if liked {
+		update = bson.M{"$addToSet": bson.M{"likes": complejoID}}
+	}
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), update)
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// SetLevelOverride lets the Complejo through the level gate of the Event (`$addToSet`), or withdraws the override
+// (`$pull`) when allowed is false. It reports whether the Event was found.
+func (r *EventRepository) SetLevelOverride(ctx context.Context, id, complejoID string, allowed bool) (bool, error) {
+	update := bson.M{"$pull": bson.M{"level_overrides": complejoID}}
+	if allowed {
+		update = bson.M{"$addToSet": bson.M{"level_overrides": complejoID}}
+	}
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), update)
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// FindByRSVP returns the Events dated from the given time on that the Complejo answered with the status, by date.
+func (r *EventRepository) FindByRSVP(ctx context.Context, complejoID, status string, from time.Time) ([]models.Event, error) {
+	filter := live(bson.M{
+		"rsvps": bson.M{"$elemMatch": bson.M{"complejo_id": complejoID, "status": status}},
+		"date":  bson.M{"$gte": from},
+	})
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// FindUnwarnedOutdoor returns the outdoor Events dated between from and to whose participants were not
+// warned of severe weather, by date.
+func (r *EventRepository) FindUnwarnedOutdoor(ctx context.Context, from, to time.Time) ([]models.Event, error) {
+	filter := live(bson.M{
+		"outdoor":           true,
+		"date":              bson.M{"$gte": from, "$lte": to},
+		"weather_warned_at": nil,
+	})
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// FindUnreminded returns the Events dated between from and to whose participants were not reminded of them, by date.
+func (r *EventRepository) FindUnreminded(ctx context.Context, from, to time.Time) ([]models.Event, error) {
+	filter := live(bson.M{
+		"date":        bson.M{"$gte": from, "$lte": to},
+		"reminded_at": nil,
+	})
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// SetRoute sets the GPX route of the Event, or removes it when route is nil, and reports whether the Event was found.
+func (r *EventRepository) SetRoute(ctx context.Context, id string, route *models.EventRoute) (bool, error) {
+	update := bson.M{"$unset": bson.M{"route": ""}}
+	if route != nil {
+		update = bson.M{"$set": bson.M{"route": route}}
+	}
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), update)
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// SetForecast caches the weather forecast on the Event and reports whether the Event was found.
+func (r *EventRepository) SetForecast(ctx context.Context, id string, forecast models.Forecast) (bool, error) {
+	return r.UpdateByID(ctx, id, map[string]interface{}{"weather": forecast})
+}
@@ -0,0 +1,158 @@
+// event_watcher.go
+package mongodb
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Server error codes of a change stream that cannot resume after the given token.
+const (
+	codeFailedToParse         = 9
+	codeInvalidResumeToken    = 260
+	codeChangeStreamFatal     = 280
+	codeChangeStreamHistory   = 286
+	codeBadResumeTokenVersion = 50811
+)
+
+// EventWatcher is the MongoDB implementation of repository.EventWatcher, on change streams. Change streams need
+// a replica set or a sharded cluster.
+type EventWatcher struct {
+	collection *mongo.Collection
+
+	PollInterval time.Duration // Longest wait of Next for a change
+}
+
+// NewEventWatcher creates an EventWatcher following the given collection, polling every 10 seconds.
+func NewEventWatcher(collection *mongo.Collection) *EventWatcher {
+	return &EventWatcher{collection: collection, PollInterval: 10 * time.Second}
+}
+
+// Watch opens a stream of the changes of the Events made after the change with the given resume token, or from
+// now when it is empty. Resume tokens are the hex _data of the MongoDB resume tokens.
+func (w *EventWatcher) Watch(ctx context.Context, resumeAfter string) (repository.EventChangeStream, error) {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{
+		"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}},
+	}}}}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup).SetMaxAwaitTime(w.PollInterval)
+	if resumeAfter != "" {
+		if _, err := hex.DecodeString(resumeAfter); err != nil {
+			return nil, repository.ErrInvalidResumeToken
+		}
+		opts.SetResumeAfter(bson.M{"_data": resumeAfter})
+	}
+
+	stream, err := w.collection.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return nil, resumeError(err)
+	}
+	return &eventChangeStream{stream: stream}, nil
+}
+
+// eventChangeStream is a repository.EventChangeStream over a MongoDB change stream.
+type eventChangeStream struct {
+	stream *mongo.ChangeStream
+}
+
+// changeEvent is a change stream event of the event collection.
+type changeEvent struct {
+	ID            bson.Raw            `bson:"_id"`
+	OperationType string              `bson:"operationType"`
+	ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	WallTime      time.Time           `bson:"wallTime"` // Set by MongoDB 6.0 and later
+	DocumentKey   struct {
+		ID string `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument      *models.Event `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.M `bson:"updatedFields"`
+	} `bson:"updateDescription"`
+}
+
+// Next waits for the next change. Soft deletions are reported as deletions, and changes of deleted Events and of
+// Events removed since are skipped.
+func (s *eventChangeStream) Next(ctx context.Context) (*models.EventChange, error) {
+	for s.stream.TryNext(ctx) {
+		var event changeEvent
+		if err := s.stream.Decode(&event); err != nil {
+			return nil, err
+		}
+		if change := event.change(); change != nil {
+			return change, nil
+		}
+	}
+	return nil, resumeError(s.stream.Err())
+}
+
+// ResumeToken returns the token resuming the stream after the last change it received or skipped.
+func (s *eventChangeStream) ResumeToken() string {
+	return tokenData(s.stream.ResumeToken())
+}
+
+// Close closes the stream.
+func (s *eventChangeStream) Close(ctx context.Context) error {
+	return s.stream.Close(ctx)
+}
+
+// change returns the EventChange of the change stream event, or nil when it is skipped.
+func (e *changeEvent) change() *models.EventChange {
+	change := &models.EventChange{
+		Token:   tokenData(e.ID),
+		EventID: e.DocumentKey.ID,
+		At:      e.WallTime,
+	}
+	if change.At.IsZero() {
+		change.At = time.Unix(int64(e.ClusterTime.T), 0).UTC()
+	}
+
+	switch {
+	case e.OperationType == "delete":
+		change.Operation = models.EventDeleted
+	case e.FullDocument == nil:
+		// Removed before the change was read
+		return nil
+	case e.FullDocument.DeletedAt != nil:
+		if _, softDeleted := e.UpdateDescription.UpdatedFields["deleted_at"]; !softDeleted && e.OperationType != "replace" {
+			return nil
+		}
+		change.Operation = models.EventDeleted
+	case e.OperationType == "insert":
+		change.Operation = models.EventInserted
+		change.Event = e.FullDocument
+	default:
+		change.Operation = models.EventUpdated
+		change.Event = e.FullDocument
+	}
+	return change
+}
+
+// tokenData returns the _data of a resume token.
+func tokenData(token bson.Raw) string {
+	data, _ := token.Lookup("_data").StringValueOK()
+	return data
+}
+
+// resumeError turns the errors of a change stream that cannot resume into repository.ErrInvalidResumeToken and
+// returns other errors unchanged.
+func resumeError(err error) error {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		for _, code := range []int{codeFailedToParse, codeInvalidResumeToken, codeChangeStreamFatal, codeChangeStreamHistory, codeBadResumeTokenVersion} {
+			if serverErr.HasErrorCode(code) {
+				return fmt.Errorf("%w: %v", repository.ErrInvalidResumeToken, err)
+			}
+		}
+	}
+	return err
+}
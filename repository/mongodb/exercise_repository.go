@@ -0,0 +1,114 @@
+// exercise_repository.go
+package mongodb
+
+import (
+	"context"
+	"regexp"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExerciseRepository is the MongoDB implementation of repository.ExerciseRepository.
+type ExerciseRepository struct {
+	collection *mongo.Collection
+}
+
+// NewExerciseRepository creates an ExerciseRepository backed by the given collection.
+func NewExerciseRepository(collection *mongo.Collection) *ExerciseRepository {
+	return &ExerciseRepository{collection: collection}
+}
+
+// Insert stores a new Exercise, or returns repository.ErrDuplicate when its name is taken.
+func (r *ExerciseRepository) Insert(ctx context.Context, exercise *models.Exercise) error {
+	_, err := r.collection.InsertOne(ctx, exercise)
+	return rejected(err)
+}
+
+// FindByID returns the Exercise with the given ID, or repository.ErrNotFound.
+func (r *ExerciseRepository) FindByID(ctx context.Context, id string) (*models.Exercise, error) {
+	var exercise models.Exercise
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&exercise)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &exercise, nil
+}
+
+// FindByIDs returns the exercises with the given IDs that exist, in no particular order.
+func (r *ExerciseRepository) FindByIDs(ctx context.Context, ids []string) ([]models.Exercise, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	exercises := []models.Exercise{}
+	if err := cursor.All(ctx, &exercises); err != nil {
+		return nil, err
+	}
+	return exercises, nil
+}
+
+// Search returns the page of the normalized filter of the exercises matching it, ordered by name, and their
+// total number.
+func (r *ExerciseRepository) Search(ctx context.Context, filter models.ExerciseFilter) ([]models.Exercise, int64, error) {
+	query := bson.M{}
+	if filter.Query != "" {
+		query["name"] = primitive.Regex{Pattern: regexp.QuoteMeta(filter.Query), Options: "i"}
+	}
+	if filter.MuscleGroup != "" {
+		query["muscle_groups"] = filter.MuscleGroup
+	}
+	if filter.Equipment != "" {
+		query["equipment"] = filter.Equipment
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(filter.Offset())).
+		SetLimit(int64(filter.Limit))
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	exercises := []models.Exercise{}
+	if err := cursor.All(ctx, &exercises); err != nil {
+		return nil, 0, err
+	}
+	return exercises, total, nil
+}
+
+// Replace overwrites the stored Exercise with the same ID and reports whether it was found, or returns
+// repository.ErrDuplicate when its new name is taken.
+func (r *ExerciseRepository) Replace(ctx context.Context, exercise *models.Exercise) (bool, error) {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": exercise.ID}, exercise)
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteByID removes the Exercise with the given ID and reports whether it was found.
+func (r *ExerciseRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
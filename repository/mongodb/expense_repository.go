@@ -0,0 +1,124 @@
+// expense_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExpenseRepository is the MongoDB implementation of repository.ExpenseRepository.
+type ExpenseRepository struct {
+	collection *mongo.Collection
+}
+
+// NewExpenseRepository creates an ExpenseRepository backed by the given collection.
+func NewExpenseRepository(collection *mongo.Collection) *ExpenseRepository {
+	return &ExpenseRepository{collection: collection}
+}
+
+// Insert stores a new Expense.
+func (r *ExpenseRepository) Insert(ctx context.Context, expense *models.Expense) error {
+	_, err := r.collection.InsertOne(ctx, expense)
+	return rejected(err)
+}
+
+// FindByEvent returns the expenses of the Event, oldest first.
+func (r *ExpenseRepository) FindByEvent(ctx context.Context, eventID string) ([]models.Expense, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "incurred_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"event_id": eventID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	expenses := []models.Expense{}
+	if err := cursor.All(ctx, &expenses); err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+// FindByID returns the Expense of the Event with the given ID, or repository.ErrNotFound.
+func (r *ExpenseRepository) FindByID(ctx context.Context, eventID, id string) (*models.Expense, error) {
+	var expense models.Expense
+	err := r.collection.FindOne(ctx, bson.M{"_id": id, "event_id": eventID}).Decode(&expense)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// Replace overwrites the stored Expense with the same ID and Event, and reports whether it was found.
+func (r *ExpenseRepository) Replace(ctx context.Context, expense *models.Expense) (bool, error) {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": expense.ID, "event_id": expense.EventID}, expense)
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteByID removes the Expense of the Event with the given ID and reports whether it was found.
+func (r *ExpenseRepository) DeleteByID(ctx context.Context, eventID, id string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "event_id": eventID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// expenseDocument is a group produced by the Totals pipeline.
+type expenseDocument struct {
+	Group struct {
+		EventID  string `bson:"event_id"`
+		Month    string `bson:"month"`
+		Currency string `bson:"currency"`
+	} `bson:"_id"`
+	Amount int64 `bson:"amount"`
+}
+
+// Totals sums the expenses incurred between from and to by Event, month (in the given location) and currency.
+func (r *ExpenseRepository) Totals(ctx context.Context, from, to time.Time, location *time.Location) ([]models.ExpenseRow, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"incurred_at": bson.M{"$gte": from, "$lt": to}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"event_id": "$event_id",
+				"month":    bson.M{"$dateToString": bson.M{"date": "$incurred_at", "format": "%Y-%m", "timezone": location.String()}},
+				"currency": "$currency",
+			},
+			"amount": bson.M{"$sum": "$amount"},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []expenseDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	totals := make([]models.ExpenseRow, 0, len(documents))
+	for _, document := range documents {
+		totals = append(totals, models.ExpenseRow{
+			EventID:  document.Group.EventID,
+			Month:    document.Group.Month,
+			Currency: document.Group.Currency,
+			Amount:   document.Amount,
+		})
+	}
+	return totals, nil
+}
@@ -0,0 +1,57 @@
+// federated_event_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FederatedEventRepository is the MongoDB implementation of repository.FederatedEventRepository.
+type FederatedEventRepository struct {
+	collection *mongo.Collection
+}
+
+// NewFederatedEventRepository creates a FederatedEventRepository backed by the given collection.
+func NewFederatedEventRepository(collection *mongo.Collection) *FederatedEventRepository {
+	return &FederatedEventRepository{collection: collection}
+}
+
+// ReplaceClub upserts the given events and removes the club's events missing from them.
+func (r *FederatedEventRepository) ReplaceClub(ctx context.Context, club string, events []models.FederatedEvent) error {
+	ids := make([]string, 0, len(events))
+	writes := make([]mongo.WriteModel, 0, len(events)+1)
+	for _, event := range events {
+		ids = append(ids, event.ID)
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": event.ID}).
+			SetReplacement(event).
+			SetUpsert(true))
+	}
+	writes = append(writes, mongo.NewDeleteManyModel().
+		SetFilter(bson.M{"club": club, "_id": bson.M{"$nin": ids}}))
+
+	_, err := r.collection.BulkWrite(ctx, writes)
+	return err
+}
+
+// FindUpcoming returns the imported events taking place at or after from, ordered by date.
+func (r *FederatedEventRepository) FindUpcoming(ctx context.Context, from time.Time) ([]models.FederatedEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"date": bson.M{"$gte": from}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.FederatedEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
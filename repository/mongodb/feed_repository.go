@@ -0,0 +1,80 @@
+// feed_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FeedRepository is the MongoDB implementation of repository.FeedRepository.
+type FeedRepository struct {
+	collection *mongo.Collection
+}
+
+// NewFeedRepository creates a FeedRepository backed by the given collection.
+func NewFeedRepository(collection *mongo.Collection) *FeedRepository {
+	return &FeedRepository{collection: collection}
+}
+
+// Insert stores a new FeedItem, or does nothing when one with the same ID is already stored.
+func (r *FeedRepository) Insert(ctx context.Context, item *models.FeedItem) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": item.ID}, bson.M{"$setOnInsert": item}, options.Update().SetUpsert(true))
+	return err
+}
+
+// Find returns the page of the items of the Complejos or of the groups with the given IDs, latest first, and
+// their total number.
+func (r *FeedRepository) Find(ctx context.Context, complejoIDs, groupIDs []string, offset, limit int) ([]models.FeedItem, int64, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"complejo_id": bson.M{"$in": complejoIDs}},
+		bson.M{"group_id": bson.M{"$in": groupIDs}},
+	}}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	items := []models.FeedItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// DeleteByEvent removes the items of the Event of the given kind, of the Complejo with the given ID or of every
+// one when it is empty, and returns how many were removed.
+func (r *FeedRepository) DeleteByEvent(ctx context.Context, eventID, kind, complejoID string) (int64, error) {
+	filter := bson.M{"event_id": eventID, "kind": kind}
+	if complejoID != "" {
+		filter["complejo_id"] = complejoID
+	}
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// DeleteByComplejo removes the items of the Complejo and returns how many were removed.
+func (r *FeedRepository) DeleteByComplejo(ctx context.Context, complejoID string) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"complejo_id": complejoID})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,175 @@
+// follow_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FollowRepository is the MongoDB implementation of repository.FollowRepository.
+type FollowRepository struct {
+	follows   *mongo.Collection
+	complejos *mongo.Collection
+}
+
+// NewFollowRepository creates a FollowRepository backed by the given collection, naming the followers and the
+// followed Complejos from the Complejos of the other.
+func NewFollowRepository(follows, complejos *mongo.Collection) *FollowRepository {
+	return &FollowRepository{follows: follows, complejos: complejos}
+}
+
+// Insert stores a new Follow, or returns repository.ErrDuplicate when the follower already follows the followee.
+func (r *FollowRepository) Insert(ctx context.Context, follow *models.Follow) error {
+	_, err := r.follows.InsertOne(ctx, follow)
+	return rejected(err)
+}
+
+// Delete removes the Follow of the followee by the follower and reports whether there was one.
+func (r *FollowRepository) Delete(ctx context.Context, followerID, followeeID string) (bool, error) {
+	result, err := r.follows.DeleteOne(ctx, bson.M{"follower_id": followerID, "followee_id": followeeID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// Exists reports whether the follower follows the followee.
+func (r *FollowRepository) Exists(ctx context.Context, followerID, followeeID string) (bool, error) {
+	count, err := r.follows.CountDocuments(ctx, bson.M{"follower_id": followerID, "followee_id": followeeID},
+		options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// FindFolloweeIDs returns the IDs of the Complejos the follower follows.
+func (r *FollowRepository) FindFolloweeIDs(ctx context.Context, followerID string) ([]string, error) {
+	opts := options.Find().SetProjection(bson.M{"followee_id": 1})
+	cursor, err := r.follows.Find(ctx, bson.M{"follower_id": followerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var follows []models.Follow
+	if err := cursor.All(ctx, &follows); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(follows))
+	for _, follow := range follows {
+		ids = append(ids, follow.FolloweeID)
+	}
+	return ids, nil
+}
+
+// FindFollowers returns the page of the live followers of the Complejo, latest first, and their total number.
+func (r *FollowRepository) FindFollowers(ctx context.Context, complejoID string, offset, limit int) ([]models.FollowEntry, int64, error) {
+	return r.list(ctx, bson.M{"followee_id": complejoID}, "follower_id", offset, limit)
+}
+
+// FindFollowing returns the page of the live Complejos the Complejo follows, latest first, and their total
+// number.
+func (r *FollowRepository) FindFollowing(ctx context.Context, complejoID string, offset, limit int) ([]models.FollowEntry, int64, error) {
+	return r.list(ctx, bson.M{"follower_id": complejoID}, "followee_id", offset, limit)
+}
+
+// Count returns the numbers of live followers of the Complejo and of live Complejos it follows.
+func (r *FollowRepository) Count(ctx context.Context, complejoID string) (int64, int64, error) {
+	followers, err := r.count(ctx, bson.M{"followee_id": complejoID}, "follower_id")
+	if err != nil {
+		return 0, 0, err
+	}
+	following, err := r.count(ctx, bson.M{"follower_id": complejoID}, "followee_id")
+	if err != nil {
+		return 0, 0, err
+	}
+	return followers, following, nil
+}
+
+// followsDocument is the result of the list pipeline.
+type followsDocument struct {
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+	Page []models.FollowEntry `bson:"page"`
+}
+
+// joined returns the stages keeping the follows matching the filter whose Complejo named by the field is live,
+// joined as "complejo".
+func (r *FollowRepository) joined(filter bson.M, field string) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.complejos.Name(),
+			"localField":   field,
+			"foreignField": "_id",
+			"as":           "complejo",
+		}}},
+		{{Key: "$unwind", Value: "$complejo"}},
+		{{Key: "$match", Value: bson.M{"complejo.deleted_at": nil}}},
+	}
+}
+
+// list returns the page of the live Complejos named by the field of the follows matching the filter, latest
+// first, and their total number.
+func (r *FollowRepository) list(ctx context.Context, filter bson.M, field string, offset, limit int) ([]models.FollowEntry, int64, error) {
+	pipeline := append(r.joined(filter, field),
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: field, Value: 1}}}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"page": bson.A{
+				bson.M{"$skip": offset},
+				bson.M{"$limit": limit},
+				bson.M{"$project": bson.M{
+					"_id":         "$" + field,
+					"username":    "$complejo.username",
+					"followed_at": "$created_at",
+				}},
+			},
+		}}})
+
+	cursor, err := r.follows.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []followsDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, 0, err
+	}
+
+	entries := []models.FollowEntry{}
+	var total int64
+	if len(documents) > 0 {
+		entries = append(entries, documents[0].Page...)
+		if len(documents[0].Total) > 0 {
+			total = documents[0].Total[0].Count
+		}
+	}
+	return entries, total, nil
+}
+
+// count returns the number of live Complejos named by the field of the follows matching the filter.
+func (r *FollowRepository) count(ctx context.Context, filter bson.M, field string) (int64, error) {
+	pipeline := append(r.joined(filter, field), bson.D{{Key: "$count", Value: "count"}})
+	cursor, err := r.follows.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []struct {
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return 0, err
+	}
+	if len(documents) == 0 {
+		return 0, nil
+	}
+	return documents[0].Count, nil
+}
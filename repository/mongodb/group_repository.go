@@ -0,0 +1,256 @@
+// group_repository.go
+package mongodb
+
+import (
+	"context"
+	"regexp"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GroupRepository is the MongoDB implementation of repository.GroupRepository.
+type GroupRepository struct {
+	groups      *mongo.Collection
+	memberships *mongo.Collection
+	complejos   *mongo.Collection
+}
+
+// NewGroupRepository creates a GroupRepository backed by the group and membership collections, naming the
+// members from the Complejos of the given collection.
+func NewGroupRepository(groups, memberships, complejos *mongo.Collection) *GroupRepository {
+	return &GroupRepository{groups: groups, memberships: memberships, complejos: complejos}
+}
+
+// Insert stores a new Group.
+func (r *GroupRepository) Insert(ctx context.Context, group *models.Group) error {
+	_, err := r.groups.InsertOne(ctx, group)
+	return rejected(err)
+}
+
+// FindByID returns the Group with the given ID, or repository.ErrNotFound.
+func (r *GroupRepository) FindByID(ctx context.Context, id string) (*models.Group, error) {
+	var group models.Group
+	err := r.groups.FindOne(ctx, bson.M{"_id": id}).Decode(&group)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// Search returns the page of the normalized filter of the groups by name, and their total number. Only the
+// groups of the Complejo with the given ID are returned when the filter asks for them.
+func (r *GroupRepository) Search(ctx context.Context, filter models.GroupFilter, complejoID string) ([]models.Group, int64, error) {
+	query := bson.M{}
+	if filter.Query != "" {
+		query["name"] = primitive.Regex{Pattern: regexp.QuoteMeta(filter.Query), Options: "i"}
+	}
+	if filter.Mine {
+		roles, err := r.FindRoles(ctx, complejoID)
+		if err != nil {
+			return nil, 0, err
+		}
+		ids := make([]string, 0, len(roles))
+		for id := range roles {
+			ids = append(ids, id)
+		}
+		query["_id"] = bson.M{"$in": ids}
+	}
+
+	total, err := r.groups.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(filter.Offset())).
+		SetLimit(int64(filter.Limit))
+	cursor, err := r.groups.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	groups := []models.Group{}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+// Replace overwrites the name, description and update time of the Group, or returns repository.ErrNotFound.
+func (r *GroupRepository) Replace(ctx context.Context, group *models.Group) error {
+	result, err := r.groups.UpdateOne(ctx, bson.M{"_id": group.ID}, bson.M{"$set": bson.M{
+		"name":        group.Name,
+		"description": group.Description,
+		"updated_at":  group.UpdatedAt,
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByID removes the Group with the given ID and its memberships, and reports whether it was found.
+func (r *GroupRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	result, err := r.groups.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	if _, err := r.memberships.DeleteMany(ctx, bson.M{"group_id": id}); err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// FindMembership returns the membership of the Complejo in the Group, or repository.ErrNotFound.
+func (r *GroupRepository) FindMembership(ctx context.Context, id, complejoID string) (*models.GroupMembership, error) {
+	var membership models.GroupMembership
+	err := r.memberships.FindOne(ctx, bson.M{"group_id": id, "complejo_id": complejoID}).Decode(&membership)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// FindRoles returns the roles of the Complejo in its groups, by group ID.
+func (r *GroupRepository) FindRoles(ctx context.Context, complejoID string) (map[string]string, error) {
+	opts := options.Find().SetProjection(bson.M{"group_id": 1, "role": 1})
+	cursor, err := r.memberships.Find(ctx, bson.M{"complejo_id": complejoID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var memberships []models.GroupMembership
+	if err := cursor.All(ctx, &memberships); err != nil {
+		return nil, err
+	}
+	roles := make(map[string]string, len(memberships))
+	for _, membership := range memberships {
+		roles[membership.GroupID] = membership.Role
+	}
+	return roles, nil
+}
+
+// SetMembership stores the membership of a Complejo in a Group, or changes the role of the stored one (keeping
+// its ID and join time).
+func (r *GroupRepository) SetMembership(ctx context.Context, membership *models.GroupMembership) error {
+	_, err := r.memberships.UpdateOne(ctx,
+		bson.M{"group_id": membership.GroupID, "complejo_id": membership.ComplejoID},
+		bson.M{
+			"$set":         bson.M{"role": membership.Role},
+			"$setOnInsert": bson.M{"_id": membership.ID, "joined_at": membership.JoinedAt},
+		},
+		options.Update().SetUpsert(true))
+	return rejected(err)
+}
+
+// RemoveMembership removes the Complejo from the members of the Group and reports whether it was one.
+func (r *GroupRepository) RemoveMembership(ctx context.Context, id, complejoID string) (bool, error) {
+	result, err := r.memberships.DeleteOne(ctx, bson.M{"group_id": id, "complejo_id": complejoID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// CountOwners returns the number of owners of the Group.
+func (r *GroupRepository) CountOwners(ctx context.Context, id string) (int64, error) {
+	return r.memberships.CountDocuments(ctx, bson.M{"group_id": id, "role": models.GroupRoleOwner})
+}
+
+// membersDocument is the result of the FindMembers pipeline.
+type membersDocument struct {
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+	Page []models.GroupMember `bson:"page"`
+}
+
+// FindMembers returns the page of the live members of the Group with their current username, owners first then
+// by join time, and their total number.
+func (r *GroupRepository) FindMembers(ctx context.Context, id string, offset, limit int) ([]models.GroupMember, int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"group_id": id}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.complejos.Name(),
+			"localField":   "complejo_id",
+			"foreignField": "_id",
+			"as":           "complejo",
+		}}},
+		{{Key: "$unwind", Value: "$complejo"}},
+		{{Key: "$match", Value: bson.M{"complejo.deleted_at": nil}}},
+		{{Key: "$addFields", Value: bson.M{"owner": bson.M{"$eq": bson.A{"$role", models.GroupRoleOwner}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "owner", Value: -1}, {Key: "joined_at", Value: 1}, {Key: "complejo_id", Value: 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"page": bson.A{
+				bson.M{"$skip": offset},
+				bson.M{"$limit": limit},
+				bson.M{"$project": bson.M{
+					"_id":       "$complejo_id",
+					"username":  "$complejo.username",
+					"role":      1,
+					"joined_at": 1,
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := r.memberships.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []membersDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, 0, err
+	}
+
+	members := []models.GroupMember{}
+	var total int64
+	if len(documents) > 0 {
+		members = append(members, documents[0].Page...)
+		if len(documents[0].Total) > 0 {
+			total = documents[0].Total[0].Count
+		}
+	}
+	return members, total, nil
+}
+
+// FindMemberIDs returns the IDs of the members of the Group.
+func (r *GroupRepository) FindMemberIDs(ctx context.Context, id string) ([]string, error) {
+	opts := options.Find().SetProjection(bson.M{"complejo_id": 1})
+	cursor, err := r.memberships.Find(ctx, bson.M{"group_id": id}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var memberships []models.GroupMembership
+	if err := cursor.All(ctx, &memberships); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(memberships))
+	for _, membership := range memberships {
+		ids = append(ids, membership.ComplejoID)
+	}
+	return ids, nil
+}
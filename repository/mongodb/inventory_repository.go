@@ -0,0 +1,145 @@
+// inventory_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InventoryRepository is the MongoDB implementation of repository.InventoryRepository.
+type InventoryRepository struct {
+	items *mongo.Collection
+	loans *mongo.Collection
+}
+
+// NewInventoryRepository creates an InventoryRepository backed by the item and loan collections.
+func NewInventoryRepository(items, loans *mongo.Collection) *InventoryRepository {
+	return &InventoryRepository{items: items, loans: loans}
+}
+
+// InsertItem stores a new InventoryItem.
+func (r *InventoryRepository) InsertItem(ctx context.Context, item *models.InventoryItem) error {
+	_, err := r.items.InsertOne(ctx, item)
+	return rejected(err)
+}
+
+// FindItems returns every InventoryItem, ordered by name.
+func (r *InventoryRepository) FindItems(ctx context.Context) ([]models.InventoryItem, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.items.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	items := []models.InventoryItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindItemByID returns the InventoryItem with the given ID, or repository.ErrNotFound.
+func (r *InventoryRepository) FindItemByID(ctx context.Context, id string) (*models.InventoryItem, error) {
+	var item models.InventoryItem
+	err := r.items.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ReplaceItem overwrites the stored InventoryItem with the same ID and reports whether it was found.
+func (r *InventoryRepository) ReplaceItem(ctx context.Context, item *models.InventoryItem) (bool, error) {
+	result, err := r.items.ReplaceOne(ctx, bson.M{"_id": item.ID}, item)
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteItem removes the InventoryItem with the given ID and reports whether it was found.
+func (r *InventoryRepository) DeleteItem(ctx context.Context, id string) (bool, error) {
+	result, err := r.items.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// InsertLoan stores a new Loan.
+func (r *InventoryRepository) InsertLoan(ctx context.Context, loan *models.Loan) error {
+	_, err := r.loans.InsertOne(ctx, loan)
+	return rejected(err)
+}
+
+// FindLoans returns the loans matching the filter at the given time, most recently lent first.
+func (r *InventoryRepository) FindLoans(ctx context.Context, filter models.LoanFilter, now time.Time) ([]models.Loan, error) {
+	query := bson.M{}
+	switch filter.Status {
+	case models.LoanOpen:
+		query["returned_at"] = nil
+	case models.LoanOverdue:
+		query["returned_at"] = nil
+		query["due_at"] = bson.M{"$lt": now}
+	case models.LoanReturned:
+		query["returned_at"] = bson.M{"$ne": nil}
+	}
+	if filter.ItemID != "" {
+		query["item_id"] = filter.ItemID
+	}
+	if filter.ComplejoID != "" {
+		query["complejo_id"] = filter.ComplejoID
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "lent_at", Value: -1}, {Key: "_id", Value: 1}})
+	cursor, err := r.loans.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	loans := []models.Loan{}
+	if err := cursor.All(ctx, &loans); err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+// FindLoanByID returns the Loan with the given ID, or repository.ErrNotFound.
+func (r *InventoryRepository) FindLoanByID(ctx context.Context, id string) (*models.Loan, error) {
+	var loan models.Loan
+	err := r.loans.FindOne(ctx, bson.M{"_id": id}).Decode(&loan)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+// MarkReturned records the return of the open Loan with the given ID and reports whether it was found.
+func (r *InventoryRepository) MarkReturned(ctx context.Context, id string, at time.Time) (bool, error) {
+	result, err := r.loans.UpdateOne(ctx, bson.M{"_id": id, "returned_at": nil}, bson.M{"$set": bson.M{"returned_at": at}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// MarkReminded records that an overdue reminder of the Loan was sent at the given time.
+func (r *InventoryRepository) MarkReminded(ctx context.Context, id string, at time.Time) error {
+	_, err := r.loans.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"reminded_at": at}})
+	return rejected(err)
+}
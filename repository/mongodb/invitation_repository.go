@@ -0,0 +1,64 @@
+// invitation_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// InvitationRepository is the MongoDB implementation of repository.InvitationRepository.
+type InvitationRepository struct {
+	collection *mongo.Collection
+}
+
+// NewInvitationRepository creates an InvitationRepository backed by the given collection.
+func NewInvitationRepository(collection *mongo.Collection) *InvitationRepository {
+	return &InvitationRepository{collection: collection}
+}
+
+// Insert stores a new GuestInvitation.
+func (r *InvitationRepository) Insert(ctx context.Context, invitation *models.GuestInvitation) error {
+	_, err := r.collection.InsertOne(ctx, invitation)
+	return rejected(err)
+}
+
+// FindByTokenHash returns the GuestInvitation with the given token hash, or repository.ErrNotFound.
+func (r *InvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*models.GuestInvitation, error) {
+	var invitation models.GuestInvitation
+	err := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&invitation)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// Accept records that the invitation was accepted by the Complejo at the given time,
+// unless it already was, and reports whether it was accepted.
+func (r *InvitationRepository) Accept(ctx context.Context, id, complejoID string, at time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "accepted_at": nil},
+		bson.M{"$set": bson.M{"accepted_at": at, "complejo_id": complejoID}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// PurgeExpired removes the invitations never accepted that expired before the given time and returns how
+// many were removed.
+func (r *InvitationRepository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"accepted_at": nil, "expires_at": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
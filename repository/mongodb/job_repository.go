@@ -0,0 +1,94 @@
+// job_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JobRepository is the MongoDB implementation of repository.JobRepository.
+type JobRepository struct {
+	collection *mongo.Collection
+}
+
+// NewJobRepository creates a JobRepository backed by the given collection.
+func NewJobRepository(collection *mongo.Collection) *JobRepository {
+	return &JobRepository{collection: collection}
+}
+
+// Enqueue stores a new pending Job, or does nothing when a Job with the same ID is already stored.
+// It upserts rather than inserts, since a duplicate key would abort the surrounding transaction.
+func (r *JobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": job.ID}, bson.M{"$setOnInsert": job}, options.Update().SetUpsert(true))
+	return err
+}
+
+// Claim atomically picks the pending Job of the given kinds due the longest and leases it.
+func (r *JobRepository) Claim(ctx context.Context, kinds []string, now time.Time, lease time.Duration) (*models.Job, error) {
+	filter := bson.M{
+		"status": models.JobPending,
+		"kind":   bson.M{"$in": kinds},
+		"run_at": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{"run_at": now.Add(lease)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job models.Job
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Complete marks the Job done.
+func (r *JobRepository) Complete(ctx context.Context, id string, at time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"status": models.JobDone, "finished_at": at},
+		"$unset": bson.M{"last_error": ""},
+	})
+	return err
+}
+
+// Retry records the failure of the Job and schedules its next attempt.
+func (r *JobRepository) Retry(ctx context.Context, id, reason string, runAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"last_error": reason, "run_at": runAt},
+	})
+	return err
+}
+
+// Fail records the failure of the Job and gives it up.
+func (r *JobRepository) Fail(ctx context.Context, id, reason string, at time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"status": models.JobFailed, "last_error": reason, "finished_at": at},
+	})
+	return err
+}
+
+// Purge removes the done and failed Jobs finished before the given time and returns how many were removed.
+func (r *JobRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{
+		"status":      bson.M{"$ne": models.JobPending},
+		"finished_at": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,58 @@
+// journal_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JournalRepository is the MongoDB implementation of repository.JournalRepository.
+type JournalRepository struct {
+	collection *mongo.Collection
+}
+
+// NewJournalRepository creates a JournalRepository backed by the given collection.
+func NewJournalRepository(collection *mongo.Collection) *JournalRepository {
+	return &JournalRepository{collection: collection}
+}
+
+// Append records a new entry.
+func (r *JournalRepository) Append(ctx context.Context, entry *models.JournalEntry) error {
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// FindRecent returns at most limit entries, most recent first.
+func (r *JournalRepository) FindRecent(ctx context.Context, limit int) ([]models.JournalEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.JournalEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindByID returns the entry with the given ID, or repository.ErrNotFound.
+func (r *JournalRepository) FindByID(ctx context.Context, id string) (*models.JournalEntry, error) {
+	var entry models.JournalEntry
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
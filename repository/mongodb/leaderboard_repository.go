@@ -0,0 +1,143 @@
+// leaderboard_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// LeaderboardRepository is the MongoDB implementation of repository.LeaderboardRepository.
+type LeaderboardRepository struct {
+	collection *mongo.Collection
+}
+
+// NewLeaderboardRepository creates a LeaderboardRepository ranking the Complejos of the given collection.
+func NewLeaderboardRepository(collection *mongo.Collection) *LeaderboardRepository {
+	return &LeaderboardRepository{collection: collection}
+}
+
+// leaderboardDocument is the result of the Rank pipeline.
+type leaderboardDocument struct {
+	Entries []models.LeaderboardEntry `bson:"entries"`
+	Me      []models.LeaderboardEntry `bson:"me"`
+	Total   []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+// Rank ranks the live Complejos matching the normalized query by the kilos of its lift, or by their score when it
+// has a scoring, best first. It requires MongoDB 5.0 or later ($setWindowFields).
+func (r *LeaderboardRepository) Rank(ctx context.Context, query models.LeaderboardQuery, complejoID string) ([]models.LeaderboardEntry, *models.LeaderboardEntry, int64, error) {
+	filter := live(bson.M{})
+	if query.Gender != "" {
+		filter["gender"] = query.Gender
+	}
+	if query.Members != nil {
+		filter["_id"] = bson.M{"$in": query.Members}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$project", Value: bson.M{"username": 1, "gender": 1, "weight": 1, "kilos": liftKilos(query.Lift)}}},
+	}
+	key := "kilos"
+	if query.Scoring != "" {
+		key = "score"
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"score": liftScore(query.Scoring)}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$match", Value: bson.M{key: bson.M{"$gt": 0}}}},
+		bson.D{{Key: "$project", Value: bson.M{"weight": 0}}},
+		bson.D{{Key: "$setWindowFields", Value: bson.M{
+			"sortBy": bson.M{key: -1},
+			"output": bson.M{"rank": bson.M{"$rank": bson.M{}}},
+		}}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"entries": bson.A{
+				bson.M{"$sort": bson.D{{Key: "rank", Value: 1}, {Key: "username", Value: 1}, {Key: "_id", Value: 1}}},
+				bson.M{"$skip": int64(query.Offset())},
+				bson.M{"$limit": int64(query.Limit)},
+			},
+			"me":    bson.A{bson.M{"$match": bson.M{"_id": complejoID}}},
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []leaderboardDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, nil, 0, err
+	}
+	if len(documents) == 0 {
+		return []models.LeaderboardEntry{}, nil, 0, nil
+	}
+
+	document := documents[0]
+	entries := document.Entries
+	if entries == nil {
+		entries = []models.LeaderboardEntry{}
+	}
+	var me *models.LeaderboardEntry
+	if len(document.Me) > 0 {
+		me = &document.Me[0]
+	}
+	var total int64
+	if len(document.Total) > 0 {
+		total = document.Total[0].Count
+	}
+	return entries, me, total, nil
+}
+
+// liftKilos returns the expression of the kilos of the lift: its field, or the sum of the three lifts when all
+// three are recorded (0 otherwise) for the total.
+func liftKilos(lift string) interface{} {
+	if lift != models.LiftTotal {
+		return bson.M{"$ifNull": bson.A{"$" + lift, 0}}
+	}
+	lifts := bson.A{"$bench", "$squad", "$dl"}
+	return bson.M{"$cond": bson.A{
+		bson.M{"$and": bson.A{
+			bson.M{"$gt": bson.A{"$bench", 0}},
+			bson.M{"$gt": bson.A{"$squad", 0}},
+			bson.M{"$gt": bson.A{"$dl", 0}},
+		}},
+		bson.M{"$add": lifts},
+		0,
+	}}
+}
+
+// liftScore returns the expression of the score of the kilos of the projected Complejo with the scoring, rounded
+// to two decimals, or null when there is no formula for its gender or it has no recorded bodyweight.
+func liftScore(scoring string) interface{} {
+	branches := bson.A{}
+	for _, gender := range []string{"male", "female"} {
+		formula := models.ScoringFormulas[scoring][gender]
+		bodyweight := bson.M{"$min": bson.A{bson.M{"$max": bson.A{"$weight", formula.MinBodyweight}}, formula.MaxBodyweight}}
+		terms := bson.A{}
+		for degree, coefficient := range formula.Coefficients {
+			terms = append(terms, bson.M{"$multiply": bson.A{coefficient, bson.M{"$pow": bson.A{"$$bodyweight", degree}}}})
+		}
+		branches = append(branches, bson.M{
+			"case": bson.M{"$and": bson.A{
+				bson.M{"$eq": bson.A{"$gender", gender}},
+				bson.M{"$gt": bson.A{"$weight", 0}},
+			}},
+			"then": bson.M{"$let": bson.M{
+				"vars": bson.M{"bodyweight": bodyweight},
+				"in": bson.M{"$round": bson.A{
+					bson.M{"$divide": bson.A{bson.M{"$multiply": bson.A{"$kilos", 500}}, bson.M{"$add": terms}}},
+					2,
+				}},
+			}},
+		})
+	}
+	return bson.M{"$switch": bson.M{"branches": branches, "default": nil}}
+}
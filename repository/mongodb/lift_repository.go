@@ -0,0 +1,90 @@
+// lift_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LiftRepository is the MongoDB implementation of repository.LiftRepository.
+type LiftRepository struct {
+	collection *mongo.Collection
+}
+
+// NewLiftRepository creates a LiftRepository backed by the given collection.
+func NewLiftRepository(collection *mongo.Collection) *LiftRepository {
+	return &LiftRepository{collection: collection}
+}
+
+// Insert stores a new LiftEntry.
+func (r *LiftRepository) Insert(ctx context.Context, entry *models.LiftEntry) error {
+	_, err := r.collection.InsertOne(ctx, entry)
+	return rejected(err)
+}
+
+// FindByComplejo returns the entries of the Complejo with the given ID, of the lift (of every lift when empty),
+// oldest first.
+func (r *LiftRepository) FindByComplejo(ctx context.Context, complejoID, lift string) ([]models.LiftEntry, error) {
+	filter := bson.M{"complejo_id": complejoID}
+	if lift != "" {
+		filter["lift"] = lift
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.LiftEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// MonthlyProgress aggregates the entries of the Complejo with the given ID by calendar month in the given time
+// zone, for the months with entries, oldest first.
+func (r *LiftRepository) MonthlyProgress(ctx context.Context, complejoID string, location *time.Location) ([]models.LiftMonth, error) {
+	// heaviest returns the accumulator of the heaviest entry of the lift.
+	heaviest := func(lift string) bson.M {
+		return bson.M{"$max": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$lift", lift}}, "$weight", 0}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"complejo_id": complejoID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$date", "timezone": location.String()}},
+			// $avg skips the nulls of the entries without a bodyweight
+			"bodyweight": bson.M{"$avg": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$bodyweight", 0}}, "$bodyweight", nil}}},
+			"bench":      heaviest(models.LiftBench),
+			"squad":      heaviest(models.LiftSquad),
+			"dl":         heaviest(models.LiftDL),
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"bodyweight": bson.M{"$round": bson.A{bson.M{"$ifNull": bson.A{"$bodyweight", 0}}, 1}},
+			"bench":      1,
+			"squad":      1,
+			"dl":         1,
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	months := []models.LiftMonth{}
+	if err := cursor.All(ctx, &months); err != nil {
+		return nil, err
+	}
+	return months, nil
+}
@@ -0,0 +1,184 @@
+// lost_found_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LostFoundRepository is the MongoDB implementation of repository.LostFoundRepository.
+type LostFoundRepository struct {
+	items  *mongo.Collection
+	claims *mongo.Collection
+}
+
+// NewLostFoundRepository creates a LostFoundRepository backed by the post and claim collections.
+func NewLostFoundRepository(items, claims *mongo.Collection) *LostFoundRepository {
+	return &LostFoundRepository{items: items, claims: claims}
+}
+
+// Insert stores a new LostItem.
+func (r *LostFoundRepository) Insert(ctx context.Context, item *models.LostItem) error {
+	_, err := r.items.InsertOne(ctx, item)
+	return rejected(err)
+}
+
+// FindOpen returns the open posts not expired at now (of the given kind when not empty), newest first.
+func (r *LostFoundRepository) FindOpen(ctx context.Context, kind string, now time.Time) ([]models.LostItem, error) {
+	filter := bson.M{"status": models.LostItemOpen, "expires_at": bson.M{"$gt": now}}
+	if kind != "" {
+		filter["kind"] = kind
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}})
+	cursor, err := r.items.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	items := []models.LostItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindByID returns the LostItem with the given ID, or repository.ErrNotFound.
+func (r *LostFoundRepository) FindByID(ctx context.Context, id string) (*models.LostItem, error) {
+	var item models.LostItem
+	err := r.items.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Release opens the held LostItem with the given ID and reports whether it was found.
+func (r *LostFoundRepository) Release(ctx context.Context, id string) (bool, error) {
+	result, err := r.items.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.LostItemHeld},
+		bson.M{"$set": bson.M{"status": models.LostItemOpen}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Resolve marks the open LostItem with the given ID as resolved and reports whether it was found.
+func (r *LostFoundRepository) Resolve(ctx context.Context, id string, at time.Time) (bool, error) {
+	result, err := r.items.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.LostItemOpen},
+		bson.M{"$set": bson.M{"status": models.LostItemResolved, "resolved_at": at}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteByID removes the LostItem with the given ID and its claims, and reports whether it was found.
+func (r *LostFoundRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	result, err := r.items.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	if _, err := r.claims.DeleteMany(ctx, bson.M{"item_id": id}); err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// PurgeExpired removes the posts expired at now and their claims, and returns how many posts were removed.
+func (r *LostFoundRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	filter := bson.M{"expires_at": bson.M{"$lte": now}}
+	cursor, err := r.items.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return 0, err
+	}
+	var expired []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &expired); err != nil {
+		return 0, err
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(expired))
+	for _, item := range expired {
+		ids = append(ids, item.ID)
+	}
+	if _, err := r.claims.DeleteMany(ctx, bson.M{"item_id": bson.M{"$in": ids}}); err != nil {
+		return 0, err
+	}
+	result, err := r.items.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// InsertClaim stores a new Claim.
+func (r *LostFoundRepository) InsertClaim(ctx context.Context, claim *models.Claim) error {
+	_, err := r.claims.InsertOne(ctx, claim)
+	return rejected(err)
+}
+
+// FindClaims returns the claims of the LostItem, oldest first.
+func (r *LostFoundRepository) FindClaims(ctx context.Context, itemID string) ([]models.Claim, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.claims.Find(ctx, bson.M{"item_id": itemID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	claims := []models.Claim{}
+	if err := cursor.All(ctx, &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// DecideClaim stores the decision of the pending Claim and reports whether it was found.
+func (r *LostFoundRepository) DecideClaim(ctx context.Context, claim *models.Claim) (bool, error) {
+	result, err := r.claims.UpdateOne(ctx,
+		bson.M{"_id": claim.ID, "item_id": claim.ItemID, "status": models.ClaimPending},
+		bson.M{"$set": bson.M{
+			"status":     claim.Status,
+			"note":       claim.Note,
+			"decided_by": claim.DecidedBy,
+			"decided_at": claim.DecidedAt,
+		}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RejectPending rejects the pending claims of the LostItem and returns how many were rejected.
+func (r *LostFoundRepository) RejectPending(ctx context.Context, itemID, decidedBy, note string, at time.Time) (int64, error) {
+	result, err := r.claims.UpdateMany(ctx,
+		bson.M{"item_id": itemID, "status": models.ClaimPending},
+		bson.M{"$set": bson.M{
+			"status":     models.ClaimRejected,
+			"note":       note,
+			"decided_by": decidedBy,
+			"decided_at": at,
+		}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,74 @@
+// moderation_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ModerationRepository is the MongoDB implementation of repository.ModerationRepository.
+type ModerationRepository struct {
+	collection *mongo.Collection
+}
+
+// NewModerationRepository creates a ModerationRepository backed by the given collection.
+func NewModerationRepository(collection *mongo.Collection) *ModerationRepository {
+	return &ModerationRepository{collection: collection}
+}
+
+// Insert stores a new ContentHold.
+func (r *ModerationRepository) Insert(ctx context.Context, hold *models.ContentHold) error {
+	_, err := r.collection.InsertOne(ctx, hold)
+	return rejected(err)
+}
+
+// FindByStatus returns the holds with the given status, oldest first.
+func (r *ModerationRepository) FindByStatus(ctx context.Context, status string) ([]models.ContentHold, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"status": status}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	holds := []models.ContentHold{}
+	if err := cursor.All(ctx, &holds); err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// FindByID returns the ContentHold with the given ID, or repository.ErrNotFound.
+func (r *ModerationRepository) FindByID(ctx context.Context, id string) (*models.ContentHold, error) {
+	var hold models.ContentHold
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&hold)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// Decide stores the decision of the pending ContentHold and reports whether it was found.
+func (r *ModerationRepository) Decide(ctx context.Context, hold *models.ContentHold) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": hold.ID, "status": models.HoldPending},
+		bson.M{"$set": bson.M{
+			"status":     hold.Status,
+			"note":       hold.Note,
+			"decided_by": hold.DecidedBy,
+			"decided_at": hold.DecidedAt,
+		}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -0,0 +1,89 @@
+// nutrition_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NutritionRepository is the MongoDB implementation of repository.NutritionRepository.
+type NutritionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewNutritionRepository creates a NutritionRepository backed by the given collection.
+func NewNutritionRepository(collection *mongo.Collection) *NutritionRepository {
+	return &NutritionRepository{collection: collection}
+}
+
+// Insert stores a new NutritionLog, or returns repository.ErrDuplicate when the Complejo already logged the day.
+func (r *NutritionRepository) Insert(ctx context.Context, log *models.NutritionLog) error {
+	_, err := r.collection.InsertOne(ctx, log)
+	return rejected(err)
+}
+
+// FindByID returns the NutritionLog with the given ID, or repository.ErrNotFound.
+func (r *NutritionRepository) FindByID(ctx context.Context, id string) (*models.NutritionLog, error) {
+	var log models.NutritionLog
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&log)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+// FindByComplejo returns the nutrition logs of the Complejo with the given ID from the day from to the day to
+// ("2006-01-02", unbounded when empty), oldest first.
+func (r *NutritionRepository) FindByComplejo(ctx context.Context, complejoID, from, to string) ([]models.NutritionLog, error) {
+	filter := bson.M{"complejo_id": complejoID}
+	days := bson.M{}
+	if from != "" {
+		days["$gte"] = from
+	}
+	if to != "" {
+		days["$lte"] = to
+	}
+	if len(days) > 0 {
+		filter["date"] = days
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	logs := []models.NutritionLog{}
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// Replace overwrites the stored NutritionLog with the same ID and reports whether it was found, or returns
+// repository.ErrDuplicate when the Complejo already logged its new day.
+func (r *NutritionRepository) Replace(ctx context.Context, log *models.NutritionLog) (bool, error) {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": log.ID}, log)
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteByID removes the NutritionLog with the given ID and reports whether it was found.
+func (r *NutritionRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
@@ -0,0 +1,85 @@
+// offboarding_repository.go
+package mongodb
+
+import (
+	"context"
+	"strings"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// offboardingCollection keeps the single Offboarding document, under offboardingID.
+const (
+	offboardingCollection = "offboarding"
+	offboardingID         = "offboarding"
+)
+
+// migrationsCollection records the applied data migrations (see migrations.Collection); it survives the purge
+// so the migrations are not applied again to the emptied database.
+const migrationsCollection = "schema_migrations"
+
+// OffboardingRepository is the MongoDB implementation of repository.OffboardingRepository.
+type OffboardingRepository struct {
+	db *mongo.Database
+}
+
+// NewOffboardingRepository creates an OffboardingRepository purging the given database.
+func NewOffboardingRepository(db *mongo.Database) *OffboardingRepository {
+	return &OffboardingRepository{db: db}
+}
+
+// Find returns the Offboarding, or ErrNotFound.
+func (r *OffboardingRepository) Find(ctx context.Context) (*models.Offboarding, error) {
+	var offboarding models.Offboarding
+	err := r.db.Collection(offboardingCollection).FindOne(ctx, bson.M{"_id": offboardingID}).Decode(&offboarding)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &offboarding, nil
+}
+
+// Save stores the Offboarding, replacing the current one.
+func (r *OffboardingRepository) Save(ctx context.Context, offboarding *models.Offboarding) error {
+	_, err := r.db.Collection(offboardingCollection).ReplaceOne(ctx, bson.M{"_id": offboardingID}, offboarding,
+		options.Replace().SetUpsert(true))
+	return rejected(err)
+}
+
+// Delete removes the Offboarding and reports whether there was one.
+func (r *OffboardingRepository) Delete(ctx context.Context) (bool, error) {
+	result, err := r.db.Collection(offboardingCollection).DeleteOne(ctx, bson.M{"_id": offboardingID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// Purge empties every collection of the database but the offboarding, the applied migrations and the system
+// collections. The collections and their indexes are kept.
+func (r *OffboardingRepository) Purge(ctx context.Context) (map[string]int64, error) {
+	names, err := r.db.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make(map[string]int64, len(names))
+	for _, name := range names {
+		if name == offboardingCollection || name == migrationsCollection || strings.HasPrefix(name, "system.") {
+			continue
+		}
+		result, err := r.db.Collection(name).DeleteMany(ctx, bson.M{})
+		if err != nil {
+			return removed, err
+		}
+		removed[name] = result.DeletedCount
+	}
+	return removed, nil
+}
@@ -0,0 +1,74 @@
+// outbox_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OutboxRepository is the MongoDB implementation of repository.OutboxRepository.
+type OutboxRepository struct {
+	collection *mongo.Collection
+}
+
+// NewOutboxRepository creates an OutboxRepository backed by the given collection.
+func NewOutboxRepository(collection *mongo.Collection) *OutboxRepository {
+	return &OutboxRepository{collection: collection}
+}
+
+// Enqueue stores a new pending message.
+func (r *OutboxRepository) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	_, err := r.collection.InsertOne(ctx, message)
+	return err
+}
+
+// Claim atomically picks the oldest due message of the given topics and leases it.
+func (r *OutboxRepository) Claim(ctx context.Context, topics []string, now time.Time, lease time.Duration, maxAttempts int) (*models.OutboxMessage, error) {
+	filter := bson.M{
+		"topic":           bson.M{"$in": topics},
+		"dispatched_at":   nil,
+		"next_attempt_at": bson.M{"$lte": now},
+		"attempts":        bson.M{"$lt": maxAttempts},
+	}
+	update := bson.M{
+		"$set": bson.M{"next_attempt_at": now.Add(lease)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var message models.OutboxMessage
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&message)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// MarkDispatched records the successful delivery of the message.
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, id string, at time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"dispatched_at": at},
+		"$unset": bson.M{"last_error": ""},
+	})
+	return err
+}
+
+// MarkFailed records a failed delivery and schedules the next attempt.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id, reason string, retryAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"last_error": reason, "next_attempt_at": retryAt},
+	})
+	return err
+}
@@ -0,0 +1,92 @@
+// payment_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PaymentRepository is the MongoDB implementation of repository.PaymentRepository.
+type PaymentRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPaymentRepository creates a PaymentRepository backed by the given collection.
+func NewPaymentRepository(collection *mongo.Collection) *PaymentRepository {
+	return &PaymentRepository{collection: collection}
+}
+
+// Upsert stores the payment, or updates the stored one with the same ID. The refunded amount never decreases,
+// so webhooks delivered out of order cannot undo a refund.
+func (r *PaymentRepository) Upsert(ctx context.Context, payment *models.Payment) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": payment.ID}, bson.M{
+		"$set": bson.M{
+			"event_id":   payment.EventID,
+			"amount":     payment.Amount,
+			"currency":   payment.Currency,
+			"paid_at":    payment.PaidAt,
+			"updated_at": payment.UpdatedAt,
+		},
+		"$max": bson.M{"amount_refunded": payment.AmountRefunded},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+// revenueDocument is a group produced by the Revenue pipeline.
+type revenueDocument struct {
+	Group struct {
+		EventID  string `bson:"event_id"`
+		Month    string `bson:"month"`
+		Currency string `bson:"currency"`
+	} `bson:"_id"`
+	Payments int   `bson:"payments"`
+	Gross    int64 `bson:"gross"`
+	Refunded int64 `bson:"refunded"`
+}
+
+// Revenue aggregates the payments made between from and to by Event, month (in the given location) and currency.
+func (r *PaymentRepository) Revenue(ctx context.Context, from, to time.Time, location *time.Location) ([]models.RevenueRow, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"paid_at": bson.M{"$gte": from, "$lt": to}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"event_id": "$event_id",
+				"month":    bson.M{"$dateToString": bson.M{"date": "$paid_at", "format": "%Y-%m", "timezone": location.String()}},
+				"currency": "$currency",
+			},
+			"payments": bson.M{"$sum": 1},
+			"gross":    bson.M{"$sum": "$amount"},
+			"refunded": bson.M{"$sum": "$amount_refunded"},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []revenueDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	revenue := make([]models.RevenueRow, 0, len(documents))
+	for _, document := range documents {
+		revenue = append(revenue, models.RevenueRow{
+			EventID:  document.Group.EventID,
+			Month:    document.Group.Month,
+			Currency: document.Group.Currency,
+			Payments: document.Payments,
+			Gross:    document.Gross,
+			Refunded: document.Refunded,
+		})
+	}
+	return revenue, nil
+}
@@ -0,0 +1,49 @@
+// personal_record_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PersonalRecordRepository is the MongoDB implementation of repository.PersonalRecordRepository.
+type PersonalRecordRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPersonalRecordRepository creates a PersonalRecordRepository backed by the given collection.
+func NewPersonalRecordRepository(collection *mongo.Collection) *PersonalRecordRepository {
+	return &PersonalRecordRepository{collection: collection}
+}
+
+// Insert stores a new PersonalRecord, or does nothing when one with the same ID is already stored.
+func (r *PersonalRecordRepository) Insert(ctx context.Context, record *models.PersonalRecord) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": record.ID}, bson.M{"$setOnInsert": record}, options.Update().SetUpsert(true))
+	return err
+}
+
+// TotalSince returns the total in kilograms of the records set from the given time on.
+func (r *PersonalRecordRepository) TotalSince(ctx context.Context, since time.Time) (float64, error) {
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"achieved_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$value"}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var totals []struct {
+		Total float64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil || len(totals) == 0 {
+		return 0, err
+	}
+	return totals[0].Total, nil
+}
@@ -0,0 +1,104 @@
+// photo_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PhotoRepository is the MongoDB implementation of repository.PhotoRepository.
+// The tags are embedded in their photo.
+type PhotoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPhotoRepository creates a PhotoRepository backed by the given collection.
+func NewPhotoRepository(collection *mongo.Collection) *PhotoRepository {
+	return &PhotoRepository{collection: collection}
+}
+
+// Insert stores a new EventPhoto with its tags.
+func (r *PhotoRepository) Insert(ctx context.Context, photo *models.EventPhoto) error {
+	_, err := r.collection.InsertOne(ctx, photo)
+	return rejected(err)
+}
+
+// FindByEvent returns the photos of the album of the Event, oldest first.
+func (r *PhotoRepository) FindByEvent(ctx context.Context, eventID string) ([]models.EventPhoto, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"event_id": eventID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	photos := []models.EventPhoto{}
+	if err := cursor.All(ctx, &photos); err != nil {
+		return nil, err
+	}
+	return photos, nil
+}
+
+// FindByID returns the EventPhoto with the given ID, or repository.ErrNotFound.
+func (r *PhotoRepository) FindByID(ctx context.Context, id string) (*models.EventPhoto, error) {
+	var photo models.EventPhoto
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&photo)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// Approve approves the pending photo on behalf of the organizer and reports whether a pending photo was found.
+func (r *PhotoRepository) Approve(ctx context.Context, id, approvedBy string, at time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.PhotoPending},
+		bson.M{"$set": bson.M{"status": models.PhotoApproved, "approved_by": approvedBy, "approved_at": at}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// SetThumbnail stores the thumbnail of the photo and reports whether it was found.
+func (r *PhotoRepository) SetThumbnail(ctx context.Context, id, thumbnail string) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"thumbnail": thumbnail}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// GrantTag records that the Complejo tagged in the photo granted its consent, and reports whether
+// a pending tag was found.
+func (r *PhotoRepository) GrantTag(ctx context.Context, photoID, complejoID string, at time.Time) (bool, error) {
+	filter := bson.M{
+		"_id":  photoID,
+		"tags": bson.M{"$elemMatch": bson.M{"complejo_id": complejoID, "consent": models.TagPending}},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter,
+		bson.M{"$set": bson.M{"tags.$.consent": models.TagGranted, "tags.$.decided_at": at}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// Delete removes the EventPhoto with the given ID and its tags, and reports whether it was found.
+func (r *PhotoRepository) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
@@ -0,0 +1,58 @@
+// privacy_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PrivacyRepository is the MongoDB implementation of repository.PrivacyRepository.
+type PrivacyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPrivacyRepository creates a PrivacyRepository backed by the given collection, keeping one document per
+// Complejo under its ID.
+func NewPrivacyRepository(collection *mongo.Collection) *PrivacyRepository {
+	return &PrivacyRepository{collection: collection}
+}
+
+// FindByComplejo returns the PrivacySettings of the Complejo, or ErrNotFound when it never changed them.
+func (r *PrivacyRepository) FindByComplejo(ctx context.Context, complejoID string) (*models.PrivacySettings, error) {
+	var settings models.PrivacySettings
+	err := r.collection.FindOne(ctx, bson.M{"_id": complejoID}).Decode(&settings)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// FindByComplejos returns the PrivacySettings of those of the Complejos with the given IDs that changed them.
+func (r *PrivacyRepository) FindByComplejos(ctx context.Context, complejoIDs []string) ([]models.PrivacySettings, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": complejoIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	settings := []models.PrivacySettings{}
+	if err := cursor.All(ctx, &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Save stores the PrivacySettings of their Complejo, replacing the current ones.
+func (r *PrivacyRepository) Save(ctx context.Context, settings *models.PrivacySettings) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": settings.ComplejoID}, settings, options.Replace().SetUpsert(true))
+	return rejected(err)
+}
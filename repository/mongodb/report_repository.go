@@ -0,0 +1,238 @@
+// report_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// weekMillis is the length of a week in milliseconds, the unit of date differences in pipelines.
+const weekMillis = 7 * 24 * 60 * 60 * 1000
+
+// goingIDs is the expression of the IDs of the Complejos going to the Event of the pipeline stage.
+var goingIDs = bson.M{"$map": bson.M{
+	"input": bson.M{"$filter": bson.M{
+		"input": bson.M{"$ifNull": bson.A{"$rsvps", bson.A{}}},
+		"cond":  bson.M{"$eq": bson.A{"$$this.status", models.RSVPGoing}},
+	}},
+	"in": "$$this.complejo_id",
+}}
+
+// ReportRepository is the MongoDB implementation of repository.ReportRepository.
+type ReportRepository struct {
+	complejos     *mongo.Collection
+	events        *mongo.Collection
+	subscriptions *mongo.Collection
+}
+
+// NewReportRepository creates a ReportRepository backed by the given Complejo, Event and subscription log collections.
+// The pipelines look up the other collections by name, so all three must share the database.
+func NewReportRepository(complejos, events, subscriptions *mongo.Collection) *ReportRepository {
+	return &ReportRepository{complejos: complejos, events: events, subscriptions: subscriptions}
+}
+
+// cohortDocument is a cohort produced by the CohortActivity pipeline.
+type cohortDocument struct {
+	Week    time.Time `bson:"_id"`
+	Members int       `bson:"members"`
+	Offsets []int     `bson:"offsets"`
+}
+
+// CohortActivity groups the Complejos that signed up between from and to by week,
+// with the weeks after sign-up in which each one attended an Event dated before to.
+// It requires MongoDB 5.0 or later ($dateTrunc).
+func (r *ReportRepository) CohortActivity(ctx context.Context, from, to time.Time) ([]models.CohortActivity, error) {
+	week := func(date interface{}) bson.M {
+		return bson.M{"$dateTrunc": bson.M{"date": date, "unit": "week", "startOfWeek": "monday"}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: live(bson.M{"created_at": bson.M{"$gte": from, "$lt": to}})}},
+		{{Key: "$project", Value: bson.M{"cohort": week("$created_at")}}},
+		// Weeks after sign-up (0 = sign-up week) in which the member attended an event
+		{{Key: "$lookup", Value: bson.M{
+			"from": r.events.Name(),
+			"let":  bson.M{"complejo": "$_id", "cohort": "$cohort"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"deleted_at": nil,
+					"$expr": bson.M{"$and": bson.A{
+						bson.M{"$in": bson.A{"$$complejo", goingIDs}},
+						bson.M{"$gte": bson.A{"$date", "$$cohort"}},
+						bson.M{"$lt": bson.A{"$date", to}},
+					}},
+				}},
+				bson.M{"$project": bson.M{"_id": 0, "offset": bson.M{"$toInt": bson.M{
+					"$divide": bson.A{bson.M{"$subtract": bson.A{week("$date"), "$$cohort"}}, weekMillis},
+				}}}},
+			},
+			"as": "attended",
+		}}},
+		{{Key: "$project", Value: bson.M{"cohort": 1, "offsets": bson.M{"$setUnion": bson.A{"$attended.offset", bson.A{}}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$cohort", "members": bson.M{"$sum": 1}, "offsets": bson.M{"$push": "$offsets"}}}},
+		{{Key: "$project", Value: bson.M{"members": 1, "offsets": bson.M{"$reduce": bson.M{
+			"input":        "$offsets",
+			"initialValue": bson.A{},
+			"in":           bson.M{"$concatArrays": bson.A{"$$value", "$$this"}},
+		}}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.complejos.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []cohortDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	cohorts := make([]models.CohortActivity, 0, len(documents))
+	for _, document := range documents {
+		cohorts = append(cohorts, models.CohortActivity{
+			Week:    document.Week.UTC(),
+			Members: document.Members,
+			Offsets: document.Offsets,
+		})
+	}
+	return cohorts, nil
+}
+
+// attendanceDocument is a slot produced by the AttendanceByHour pipeline.
+type attendanceDocument struct {
+	Slot struct {
+		Weekday int `bson:"weekday"`
+		Hour    int `bson:"hour"`
+	} `bson:"_id"`
+	Count int `bson:"count"`
+}
+
+// AttendanceByHour counts the Complejos going to the Events dated between from and to by weekday and hour.
+func (r *ReportRepository) AttendanceByHour(ctx context.Context, from, to time.Time, location *time.Location) ([]models.AttendanceSlot, error) {
+	timezone := location.String()
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: live(bson.M{"date": bson.M{"$gte": from, "$lt": to}, "rsvps.status": models.RSVPGoing})}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"weekday": bson.M{"$isoDayOfWeek": bson.M{"date": "$date", "timezone": timezone}},
+				"hour":    bson.M{"$hour": bson.M{"date": "$date", "timezone": timezone}},
+			},
+			"count": bson.M{"$sum": bson.M{"$size": goingIDs}},
+		}}},
+	}
+
+	cursor, err := r.events.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []attendanceDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	slots := make([]models.AttendanceSlot, 0, len(documents))
+	for _, document := range documents {
+		slots = append(slots, models.AttendanceSlot{Weekday: document.Slot.Weekday, Hour: document.Slot.Hour, Count: document.Count})
+	}
+	return slots, nil
+}
+
+// activityDocument is a member produced by the MemberActivity pipeline.
+type activityDocument struct {
+	ID                    string     `bson:"_id"`
+	Username              string     `bson:"username"`
+	CreatedAt             *time.Time `bson:"created_at"`
+	PreviousAttendance    int        `bson:"previous_attendance"`
+	RecentAttendance      int        `bson:"recent_attendance"`
+	PreviousSubscriptions int        `bson:"previous_subscriptions"`
+	RecentSubscriptions   int        `bson:"recent_subscriptions"`
+}
+
+// MemberActivity counts, for every live Complejo with the "user" role, the past Events attended and the
+// subscription transitions in the previous window [since, split) and the recent window [split, until).
+func (r *ReportRepository) MemberActivity(ctx context.Context, since, split, until time.Time) ([]models.MemberActivity, error) {
+	// count returns the number of dates of the looked-up array falling in [from, to).
+	count := func(array, field string, from, to time.Time) bson.M {
+		return bson.M{"$size": bson.M{"$filter": bson.M{
+			"input": array,
+			"cond": bson.M{"$and": bson.A{
+				bson.M{"$gte": bson.A{"$$this." + field, from}},
+				bson.M{"$lt": bson.A{"$$this." + field, to}},
+			}},
+		}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: live(bson.M{"role": "user"})}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": r.events.Name(),
+			"let":  bson.M{"complejo": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"deleted_at": nil,
+					"date":       bson.M{"$gte": since, "$lt": until},
+					"$expr":      bson.M{"$in": bson.A{"$$complejo", goingIDs}},
+				}},
+				bson.M{"$project": bson.M{"_id": 0, "date": 1}},
+			},
+			"as": "attended",
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": r.subscriptions.Name(),
+			"let":  bson.M{"username": "$username"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"occurred_at": bson.M{"$gte": since, "$lt": until},
+					"$expr":       bson.M{"$eq": bson.A{"$username", "$$username"}},
+				}},
+				bson.M{"$project": bson.M{"_id": 0, "occurred_at": 1}},
+			},
+			"as": "subscriptions",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"username":               1,
+			"created_at":             1,
+			"previous_attendance":    count("$attended", "date", since, split),
+			"recent_attendance":      count("$attended", "date", split, until),
+			"previous_subscriptions": count("$subscriptions", "occurred_at", since, split),
+			"recent_subscriptions":   count("$subscriptions", "occurred_at", split, until),
+		}}},
+	}
+
+	cursor, err := r.complejos.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []activityDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	members := make([]models.MemberActivity, 0, len(documents))
+	for _, document := range documents {
+		members = append(members, models.MemberActivity(document))
+	}
+	return members, nil
+}
+
+// ClubTotals counts the live Complejos and the live Events dated before the given time.
+func (r *ReportRepository) ClubTotals(ctx context.Context, before time.Time) (members, events int64, err error) {
+	if members, err = r.complejos.CountDocuments(ctx, live(bson.M{})); err != nil {
+		return 0, 0, err
+	}
+	if events, err = r.events.CountDocuments(ctx, live(bson.M{"date": bson.M{"$lt": before}})); err != nil {
+		return 0, 0, err
+	}
+	return members, events, nil
+}
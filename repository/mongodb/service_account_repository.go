@@ -0,0 +1,141 @@
+// service_account_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ServiceAccountRepository is the MongoDB implementation of repository.ServiceAccountRepository.
+type ServiceAccountRepository struct {
+	accounts *mongo.Collection
+	tokens   *mongo.Collection
+}
+
+// NewServiceAccountRepository creates a ServiceAccountRepository backed by the given collections.
+func NewServiceAccountRepository(accounts, tokens *mongo.Collection) *ServiceAccountRepository {
+	return &ServiceAccountRepository{accounts: accounts, tokens: tokens}
+}
+
+// Insert stores a new ServiceAccount, or returns repository.ErrDuplicate when its name is taken.
+func (r *ServiceAccountRepository) Insert(ctx context.Context, account *models.ServiceAccount) error {
+	_, err := r.accounts.InsertOne(ctx, account)
+	return rejected(err)
+}
+
+// FindAll returns every ServiceAccount, oldest first, without their tokens.
+func (r *ServiceAccountRepository) FindAll(ctx context.Context) ([]models.ServiceAccount, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.accounts.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	accounts := []models.ServiceAccount{}
+	if err := cursor.All(ctx, &accounts); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// FindByID returns the ServiceAccount with the given ID without its tokens, or repository.ErrNotFound.
+func (r *ServiceAccountRepository) FindByID(ctx context.Context, id string) (*models.ServiceAccount, error) {
+	var account models.ServiceAccount
+	err := r.accounts.FindOne(ctx, bson.M{"_id": id}).Decode(&account)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Update replaces the ServiceAccount and reports whether it was found, or returns repository.ErrDuplicate
+// when its name is taken.
+func (r *ServiceAccountRepository) Update(ctx context.Context, account *models.ServiceAccount) (bool, error) {
+	result, err := r.accounts.UpdateOne(ctx, bson.M{"_id": account.ID}, bson.M{"$set": bson.M{
+		"name":          account.Name,
+		"role":          account.Role,
+		"rotation_days": account.RotationDays,
+		"overlap_hours": account.OverlapHours,
+		"active":        account.Active,
+		"updated_at":    account.UpdatedAt,
+	}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Delete removes the ServiceAccount with its tokens and reports whether it was found.
+func (r *ServiceAccountRepository) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := r.accounts.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	if _, err := r.tokens.DeleteMany(ctx, bson.M{"account_id": id}); err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// InsertToken stores a new ServiceToken.
+func (r *ServiceAccountRepository) InsertToken(ctx context.Context, token *models.ServiceToken) error {
+	_, err := r.tokens.InsertOne(ctx, token)
+	return rejected(err)
+}
+
+// FindTokens returns the tokens of the ServiceAccount expiring after the given time, oldest first.
+func (r *ServiceAccountRepository) FindTokens(ctx context.Context, accountID string, after time.Time) ([]models.ServiceToken, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "issued_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.tokens.Find(ctx, bson.M{"account_id": accountID, "expires_at": bson.M{"$gt": after}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	tokens := []models.ServiceToken{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// FindTokenByHash returns the ServiceToken with the given hash, or repository.ErrNotFound.
+func (r *ServiceAccountRepository) FindTokenByHash(ctx context.Context, hash string) (*models.ServiceToken, error) {
+	var token models.ServiceToken
+	err := r.tokens.FindOne(ctx, bson.M{"hash": hash}).Decode(&token)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ExpireTokens brings the expiry of the tokens of the ServiceAccount expiring after the given time forward to it.
+func (r *ServiceAccountRepository) ExpireTokens(ctx context.Context, accountID string, at time.Time) error {
+	_, err := r.tokens.UpdateMany(ctx,
+		bson.M{"account_id": accountID, "expires_at": bson.M{"$gt": at}},
+		bson.M{"$set": bson.M{"expires_at": at}})
+	return err
+}
+
+// PurgeExpiredTokens removes the tokens expired before the given time and returns how many were removed.
+func (r *ServiceAccountRepository) PurgeExpiredTokens(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.tokens.DeleteMany(ctx, bson.M{"expires_at": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
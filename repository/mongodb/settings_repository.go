@@ -0,0 +1,46 @@
+// settings_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// listingSettingsID is the ID of the single ListingSettings document of the settings collection.
+const listingSettingsID = "listing"
+
+// SettingsRepository is the MongoDB implementation of repository.SettingsRepository.
+type SettingsRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSettingsRepository creates a SettingsRepository backed by the given collection, keeping one document per
+// group of settings.
+func NewSettingsRepository(collection *mongo.Collection) *SettingsRepository {
+	return &SettingsRepository{collection: collection}
+}
+
+// FindListing returns the ListingSettings, or ErrNotFound when the admins never changed them.
+func (r *SettingsRepository) FindListing(ctx context.Context) (*models.ListingSettings, error) {
+	var settings models.ListingSettings
+	err := r.collection.FindOne(ctx, bson.M{"_id": listingSettingsID}).Decode(&settings)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveListing stores the ListingSettings, replacing the current ones.
+func (r *SettingsRepository) SaveListing(ctx context.Context, settings *models.ListingSettings) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": listingSettingsID}, settings, options.Replace().SetUpsert(true))
+	return rejected(err)
+}
@@ -0,0 +1,44 @@
+// soft_delete.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// live restricts a filter to documents that are not marked as deleted.
+func live(filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+	return filter
+}
+
+// softDelete marks the live document with the given ID as deleted at the given time.
+func softDelete(ctx context.Context, collection *mongo.Collection, id string, at time.Time) (bool, error) {
+	result, err := collection.UpdateOne(ctx, live(bson.M{"_id": id}), bson.M{"$set": bson.M{"deleted_at": at}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// restore clears the deletion mark of the deleted document with the given ID.
+func restore(ctx context.Context, collection *mongo.Collection, id string) (bool, error) {
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{"$unset": bson.M{"deleted_at": ""}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// purgeDeleted permanently removes the documents deleted before the given time.
+func purgeDeleted(ctx context.Context, collection *mongo.Collection, before time.Time) (int64, error) {
+	result, err := collection.DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
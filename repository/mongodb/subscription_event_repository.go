@@ -0,0 +1,54 @@
+// subscription_event_repository.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SubscriptionEventRepository is the MongoDB implementation of repository.SubscriptionEventRepository.
+type SubscriptionEventRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSubscriptionEventRepository creates a SubscriptionEventRepository backed by the given collection.
+func NewSubscriptionEventRepository(collection *mongo.Collection) *SubscriptionEventRepository {
+	return &SubscriptionEventRepository{collection: collection}
+}
+
+// Append records a new transition.
+func (r *SubscriptionEventRepository) Append(ctx context.Context, event *models.SubscriptionEvent) error {
+	_, err := r.collection.InsertOne(ctx, event)
+	return err
+}
+
+// FindByEvent returns the transitions of the Event in chronological order.
+func (r *SubscriptionEventRepository) FindByEvent(ctx context.Context, eventID string) ([]models.SubscriptionEvent, error) {
+	return r.find(ctx, bson.M{"event_id": eventID})
+}
+
+// FindByUsername returns the transitions of the user with the given username in chronological order.
+func (r *SubscriptionEventRepository) FindByUsername(ctx context.Context, username string) ([]models.SubscriptionEvent, error) {
+	return r.find(ctx, bson.M{"username": username})
+}
+
+// find returns the transitions matching the filter in chronological order.
+func (r *SubscriptionEventRepository) find(ctx context.Context, filter bson.M) ([]models.SubscriptionEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.SubscriptionEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
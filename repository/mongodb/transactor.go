@@ -0,0 +1,51 @@
+// transactor.go
+package mongodb
+
+import (
+	"context"
+
+	"los-complejos-backend/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Transactor is the MongoDB implementation of repository.Transactor.
+//
+// Multi-document transactions require a replica set or a sharded cluster. On a standalone
+// server (the usual local development setup) the function runs without a transaction.
+type Transactor struct {
+	client    *mongo.Client
+	supported bool
+}
+
+// NewTransactor creates a Transactor for the given client, detecting whether the deployment supports transactions.
+func NewTransactor(ctx context.Context, client *mongo.Client) (*Transactor, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transactor{
+		client:    client,
+		supported: hello.SetName != "" || hello.Msg == "isdbgrid",
+	}, nil
+}
+
+// Supported reports whether functions run inside real transactions.
+func (t *Transactor) Supported() bool {
+	return t.supported
+}
+
+// WithinTransaction runs fn inside a MongoDB transaction (see database.WithTransaction), committing when fn returns nil.
+// Calls nested in an existing transaction reuse it.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !t.supported {
+		return fn(ctx)
+	}
+	return database.WithTransaction(ctx, t.client, fn)
+}
@@ -0,0 +1,208 @@
+// volunteer_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// VolunteerRepository is the MongoDB implementation of repository.VolunteerRepository.
+// The sign-ups are embedded in their shift.
+type VolunteerRepository struct {
+	shifts    *mongo.Collection
+	events    *mongo.Collection
+	complejos *mongo.Collection
+}
+
+// NewVolunteerRepository creates a VolunteerRepository backed by the shift collection,
+// joining the event and Complejo collections to leave out deleted ones.
+func NewVolunteerRepository(shifts, events, complejos *mongo.Collection) *VolunteerRepository {
+	return &VolunteerRepository{shifts: shifts, events: events, complejos: complejos}
+}
+
+// InsertShift stores a new VolunteerShift.
+func (r *VolunteerRepository) InsertShift(ctx context.Context, shift *models.VolunteerShift) error {
+	_, err := r.shifts.InsertOne(ctx, shift)
+	return rejected(err)
+}
+
+// FindShifts returns the shifts of the Event, ordered by start and role.
+func (r *VolunteerRepository) FindShifts(ctx context.Context, eventID string) ([]models.VolunteerShift, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "starts_at", Value: 1}, {Key: "role", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.shifts.Find(ctx, bson.M{"event_id": eventID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	shifts := []models.VolunteerShift{}
+	if err := cursor.All(ctx, &shifts); err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+// FindShiftByID returns the VolunteerShift with the given ID, or repository.ErrNotFound.
+func (r *VolunteerRepository) FindShiftByID(ctx context.Context, id string) (*models.VolunteerShift, error) {
+	var shift models.VolunteerShift
+	err := r.shifts.FindOne(ctx, bson.M{"_id": id}).Decode(&shift)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+// DeleteShift removes the VolunteerShift with the given ID and its sign-ups, and reports whether it was found.
+func (r *VolunteerRepository) DeleteShift(ctx context.Context, id string) (bool, error) {
+	result, err := r.shifts.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// AddVolunteer signs the Complejo up for the shift, unless the shift is full or the Complejo already signed up,
+// and reports whether it was signed up. Both conditions are part of the update filter, so concurrent
+// sign-ups never exceed the capacity.
+func (r *VolunteerRepository) AddVolunteer(ctx context.Context, shiftID string, volunteer models.Volunteer) (bool, error) {
+	filter := bson.M{
+		"_id":                    shiftID,
+		"volunteers.complejo_id": bson.M{"$ne": volunteer.ComplejoID},
+		"$expr":                  bson.M{"$lt": bson.A{bson.M{"$size": "$volunteers"}, "$capacity"}},
+	}
+	result, err := r.shifts.UpdateOne(ctx, filter, bson.M{"$push": bson.M{"volunteers": volunteer}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// RemoveVolunteer withdraws the Complejo from the shift and reports whether it had signed up.
+func (r *VolunteerRepository) RemoveVolunteer(ctx context.Context, shiftID, complejoID string) (bool, error) {
+	result, err := r.shifts.UpdateOne(ctx,
+		bson.M{"_id": shiftID},
+		bson.M{"$pull": bson.M{"volunteers": bson.M{"complejo_id": complejoID}}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// FindStartingBetween returns the shifts of live events starting in [from, to), ordered by start.
+func (r *VolunteerRepository) FindStartingBetween(ctx context.Context, from, to time.Time) ([]models.VolunteerShift, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"starts_at": bson.M{"$gte": from, "$lt": to}}}},
+	}
+	pipeline = append(pipeline, r.liveEvent()...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$project", Value: bson.M{"event": 0}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "starts_at", Value: 1}, {Key: "_id", Value: 1}}}},
+	)
+
+	cursor, err := r.shifts.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	shifts := []models.VolunteerShift{}
+	if err := cursor.All(ctx, &shifts); err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+// MarkReminded records that the Complejo was reminded of the shift at the given time.
+func (r *VolunteerRepository) MarkReminded(ctx context.Context, shiftID, complejoID string, at time.Time) error {
+	_, err := r.shifts.UpdateOne(ctx,
+		bson.M{"_id": shiftID, "volunteers.complejo_id": complejoID},
+		bson.M{"$set": bson.M{"volunteers.$.reminded_at": at}})
+	return rejected(err)
+}
+
+// Hours returns the hours the Complejo volunteered in shifts of live events ended before the given time.
+func (r *VolunteerRepository) Hours(ctx context.Context, complejoID string, before time.Time) (float64, error) {
+	tally, err := r.tally(ctx, before, bson.M{"volunteers.complejo_id": complejoID}, 1)
+	if err != nil || len(tally) == 0 {
+		return 0, err
+	}
+	return tally[0].Hours, nil
+}
+
+// Leaderboard returns at most limit live Complejos with the most hours volunteered in shifts of live events
+// ended before the given time, most hours first.
+func (r *VolunteerRepository) Leaderboard(ctx context.Context, before time.Time, limit int) ([]models.VolunteerHours, error) {
+	return r.tally(ctx, before, bson.M{}, limit)
+}
+
+// tally sums, by live Complejo, the shifts of live events ended before the given time and their hours,
+// keeping the sign-ups matching filter, most hours first.
+func (r *VolunteerRepository) tally(ctx context.Context, before time.Time, filter bson.M, limit int) ([]models.VolunteerHours, error) {
+	match := bson.M{"ends_at": bson.M{"$lt": before}}
+	for key, value := range filter {
+		match[key] = value
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	pipeline = append(pipeline, r.liveEvent()...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$unwind", Value: "$volunteers"}},
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":    "$volunteers.complejo_id",
+			"shifts": bson.M{"$sum": 1},
+			"hours": bson.M{"$sum": bson.M{"$divide": bson.A{
+				bson.M{"$subtract": bson.A{"$ends_at", "$starts_at"}},
+				time.Hour.Milliseconds(),
+			}}},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         r.complejos.Name(),
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "complejo",
+		}}},
+		bson.D{{Key: "$unwind", Value: "$complejo"}},
+		bson.D{{Key: "$match", Value: bson.M{"complejo.deleted_at": nil}}},
+		bson.D{{Key: "$project", Value: bson.M{"username": "$complejo.username", "shifts": 1, "hours": 1}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "hours", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+
+	cursor, err := r.shifts.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	tally := []models.VolunteerHours{}
+	if err := cursor.All(ctx, &tally); err != nil {
+		return nil, err
+	}
+	return tally, nil
+}
+
+// liveEvent returns the pipeline stages keeping the shifts whose event exists and is not deleted.
+// They leave the event in the "event" field.
+func (r *VolunteerRepository) liveEvent() []bson.D {
+	return []bson.D{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.events.Name(),
+			"localField":   "event_id",
+			"foreignField": "_id",
+			"as":           "event",
+		}}},
+		{{Key: "$unwind", Value: "$event"}},
+		{{Key: "$match", Value: bson.M{"event.deleted_at": nil}}},
+	}
+}
@@ -0,0 +1,187 @@
+// webhook_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WebhookRepository is the MongoDB implementation of repository.WebhookRepository.
+type WebhookRepository struct {
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+// NewWebhookRepository creates a WebhookRepository backed by the given collections.
+func NewWebhookRepository(webhooks, deliveries *mongo.Collection) *WebhookRepository {
+	return &WebhookRepository{webhooks: webhooks, deliveries: deliveries}
+}
+
+// Insert stores a new Webhook.
+func (r *WebhookRepository) Insert(ctx context.Context, webhook *models.Webhook) error {
+	_, err := r.webhooks.InsertOne(ctx, webhook)
+	return rejected(err)
+}
+
+// FindAll returns every Webhook, oldest first.
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]models.Webhook, error) {
+	return r.find(ctx, bson.M{})
+}
+
+// FindByID returns the Webhook with the given ID, or repository.ErrNotFound.
+func (r *WebhookRepository) FindByID(ctx context.Context, id string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.webhooks.FindOne(ctx, bson.M{"_id": id}).Decode(&webhook)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// FindByTopic returns the active Webhooks receiving the topic.
+func (r *WebhookRepository) FindByTopic(ctx context.Context, topic string) ([]models.Webhook, error) {
+	return r.find(ctx, bson.M{"topics": topic, "active": true})
+}
+
+// find returns the Webhooks matching the filter, oldest first.
+func (r *WebhookRepository) find(ctx context.Context, filter bson.M) ([]models.Webhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.webhooks.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := []models.Webhook{}
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Update replaces the Webhook and reports whether it was found.
+func (r *WebhookRepository) Update(ctx context.Context, webhook *models.Webhook) (bool, error) {
+	result, err := r.webhooks.UpdateOne(ctx, bson.M{"_id": webhook.ID}, bson.M{"$set": bson.M{
+		"url":        webhook.URL,
+		"secret":     webhook.Secret,
+		"topics":     webhook.Topics,
+		"active":     webhook.Active,
+		"updated_at": webhook.UpdatedAt,
+	}})
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Delete removes the Webhook with its deliveries and reports whether it was found.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := r.webhooks.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	if _, err := r.deliveries.DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// Enqueue stores the new deliveries, skipping those already stored with the same ID.
+func (r *WebhookRepository) Enqueue(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	documents := make([]interface{}, len(deliveries))
+	for i := range deliveries {
+		documents[i] = deliveries[i]
+	}
+	_, err := r.deliveries.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// Claim atomically picks the pending delivery due the longest and leases it.
+func (r *WebhookRepository) Claim(ctx context.Context, now time.Time, lease time.Duration) (*models.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          models.DeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery models.WebhookDelivery
+	err := r.deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// RecordAttempt appends the attempt to the delivery and sets its status, and the time of its next attempt
+// while pending or of its delivery once delivered.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, id string, attempt models.WebhookAttempt, status string, at time.Time) error {
+	set := bson.M{"status": status}
+	switch status {
+	case models.DeliveryPending:
+		set["next_attempt_at"] = at
+	case models.DeliveryDelivered:
+		set["delivered_at"] = at
+	}
+	_, err := r.deliveries.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":  set,
+		"$push": bson.M{"attempts": attempt},
+	})
+	return err
+}
+
+// FindDeliveries returns the deliveries of the Webhook matching the filter, at most filter.Limit, newest first.
+func (r *WebhookRepository) FindDeliveries(ctx context.Context, webhookID string, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error) {
+	query := bson.M{"webhook_id": webhookID}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(filter.Limit))
+	cursor, err := r.deliveries.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []models.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// PurgeDeliveries removes the delivered and failed deliveries created before the given time and returns how
+// many were removed.
+func (r *WebhookRepository) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.deliveries.DeleteMany(ctx, bson.M{
+		"status":     bson.M{"$ne": models.DeliveryPending},
+		"created_at": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
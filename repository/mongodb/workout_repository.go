@@ -0,0 +1,129 @@
+// workout_repository.go
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WorkoutRepository is the MongoDB implementation of repository.WorkoutRepository.
+type WorkoutRepository struct {
+	collection *mongo.Collection
+}
+
+// NewWorkoutRepository creates a WorkoutRepository backed by the given collection.
+func NewWorkoutRepository(collection *mongo.Collection) *WorkoutRepository {
+	return &WorkoutRepository{collection: collection}
+}
+
+// Insert stores a new Workout.
+func (r *WorkoutRepository) Insert(ctx context.Context, workout *models.Workout) error {
+	_, err := r.collection.InsertOne(ctx, workout)
+	return rejected(err)
+}
+
+// FindByID returns the Workout with the given ID, or repository.ErrNotFound.
+func (r *WorkoutRepository) FindByID(ctx context.Context, id string) (*models.Workout, error) {
+	var workout models.Workout
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&workout)
+	if err == mongo.ErrNoDocuments {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &workout, nil
+}
+
+// FindByComplejo returns the page (skipping offset, at most limit, every one when 0) of the workouts of the
+// Complejo with the given ID, newest first, and their total number.
+func (r *WorkoutRepository) FindByComplejo(ctx context.Context, complejoID string, offset, limit int) ([]models.Workout, int64, error) {
+	filter := bson.M{"complejo_id": complejoID}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	workouts := []models.Workout{}
+	if err := cursor.All(ctx, &workouts); err != nil {
+		return nil, 0, err
+	}
+	return workouts, total, nil
+}
+
+// Replace overwrites the stored Workout with the same ID and reports whether it was found.
+func (r *WorkoutRepository) Replace(ctx context.Context, workout *models.Workout) (bool, error) {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": workout.ID}, workout)
+	if err != nil {
+		return false, rejected(err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// DeleteByID removes the Workout with the given ID and reports whether it was found.
+func (r *WorkoutRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// WeeklyVolume sums the sets, repetitions and volume of the workouts of the Complejo with the given ID since the
+// given time by week (starting on Monday in the given time zone) and muscle group, for the weeks and muscle
+// groups trained, oldest first. It requires MongoDB 5.0 or later ($dateTrunc).
+func (r *WorkoutRepository) WeeklyVolume(ctx context.Context, complejoID string, since time.Time, location *time.Location) ([]models.MuscleVolume, error) {
+	week := bson.M{"$dateTrunc": bson.M{"date": "$date", "unit": "week", "startOfWeek": "monday", "timezone": location.String()}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"complejo_id": complejoID, "date": bson.M{"$gte": since}}}},
+		{{Key: "$unwind", Value: "$exercises"}},
+		{{Key: "$unwind", Value: "$exercises.sets"}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"week":         bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": week, "timezone": location.String()}},
+				"muscle_group": "$exercises.muscle_group",
+			},
+			"sets":   bson.M{"$sum": 1},
+			"reps":   bson.M{"$sum": "$exercises.sets.reps"},
+			"volume": bson.M{"$sum": bson.M{"$multiply": bson.A{"$exercises.sets.reps", "$exercises.sets.weight"}}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":          0,
+			"week":         "$_id.week",
+			"muscle_group": "$_id.muscle_group",
+			"sets":         1,
+			"reps":         1,
+			"volume":       1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "week", Value: 1}, {Key: "muscle_group", Value: 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	volumes := []models.MuscleVolume{}
+	if err := cursor.All(ctx, &volumes); err != nil {
+		return nil, err
+	}
+	return volumes, nil
+}
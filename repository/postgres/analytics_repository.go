@@ -0,0 +1,41 @@
+// analytics_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"los-complejos-backend/models"
+)
+
+// AnalyticsRepository is the PostgreSQL implementation of repository.AnalyticsRepository.
+type AnalyticsRepository struct {
+	db *sql.DB
+}
+
+// NewAnalyticsRepository creates an AnalyticsRepository backed by the given database.
+func NewAnalyticsRepository(db *sql.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// InsertMany stores a batch of events in a single transaction.
+func (r *AnalyticsRepository) InsertMany(ctx context.Context, events []models.AnalyticsEvent) error {
+	return NewTransactor(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, event := range events {
+			properties, err := json.Marshal(event.Properties)
+			if err != nil {
+				return err
+			}
+			_, err = conn(ctx, r.db).ExecContext(ctx, `INSERT INTO analytics_events
+				(id, type, name, properties, session_id, occurred_at, tenant, complejo_id, received_at, sample_rate)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+				event.ID, event.Type, event.Name, properties, event.SessionID, event.OccurredAt,
+				event.Tenant, event.ComplejoID, event.ReceivedAt, event.SampleRate)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+// announcement_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const announcementSelect = `SELECT id, title, body, created_by, created_at, version, updated_by, updated_at FROM announcements`
+
+// AnnouncementRepository is the PostgreSQL implementation of repository.AnnouncementRepository.
+type AnnouncementRepository struct {
+	db *sql.DB
+}
+
+// NewAnnouncementRepository creates an AnnouncementRepository backed by the given database.
+func NewAnnouncementRepository(db *sql.DB) *AnnouncementRepository {
+	return &AnnouncementRepository{db: db}
+}
+
+// Insert stores a new Announcement.
+func (r *AnnouncementRepository) Insert(ctx context.Context, announcement *models.Announcement) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO announcements
+		(id, title, body, created_by, created_at, version, updated_by, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		announcement.ID, announcement.Title, announcement.Body, announcement.CreatedBy, announcement.CreatedAt,
+		announcement.Version, announcement.UpdatedBy, announcement.UpdatedAt)
+	return rejected(err)
+}
+
+// FindRecent returns at most limit Announcements, newest first.
+func (r *AnnouncementRepository) FindRecent(ctx context.Context, limit int) ([]models.Announcement, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, announcementSelect+` ORDER BY created_at DESC, id LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	announcements := []models.Announcement{}
+	for rows.Next() {
+		announcement, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, err
+		}
+		announcements = append(announcements, *announcement)
+	}
+	return announcements, rows.Err()
+}
+
+// FindByID returns the Announcement with the given ID, or repository.ErrNotFound.
+func (r *AnnouncementRepository) FindByID(ctx context.Context, id string) (*models.Announcement, error) {
+	announcement, err := scanAnnouncement(conn(ctx, r.db).QueryRowContext(ctx, announcementSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return announcement, err
+}
+
+// Update replaces the current version of the Announcement and reports whether it was found.
+func (r *AnnouncementRepository) Update(ctx context.Context, announcement *models.Announcement) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE announcements SET title = $2, body = $3, version = $4, updated_by = $5, updated_at = $6 WHERE id = $1`,
+		announcement.ID, announcement.Title, announcement.Body, announcement.Version, announcement.UpdatedBy, announcement.UpdatedAt))
+}
+
+// scanAnnouncement reads an Announcement from a row produced by announcementSelect.
+func scanAnnouncement(row rowScanner) (*models.Announcement, error) {
+	var a models.Announcement
+	var updatedAt sql.NullTime
+	if err := row.Scan(&a.ID, &a.Title, &a.Body, &a.CreatedBy, &a.CreatedAt, &a.Version, &a.UpdatedBy, &updatedAt); err != nil {
+		return nil, err
+	}
+	if updatedAt.Valid {
+		a.UpdatedAt = &updatedAt.Time
+	}
+	return &a, nil
+}
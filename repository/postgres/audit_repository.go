@@ -0,0 +1,58 @@
+// audit_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"los-complejos-backend/models"
+)
+
+const auditSelect = `SELECT id, action, actor_id, target_id, details, occurred_at FROM audit_log`
+
+// AuditRepository is the PostgreSQL implementation of repository.AuditRepository.
+type AuditRepository struct {
+	db *sql.DB
+}
+
+// NewAuditRepository creates an AuditRepository backed by the given database.
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Append records a new entry.
+func (r *AuditRepository) Append(ctx context.Context, entry *models.AuditEntry) error {
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return err
+	}
+	_, err = conn(ctx, r.db).ExecContext(ctx, `INSERT INTO audit_log
+		(id, action, actor_id, target_id, details, occurred_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		entry.ID, entry.Action, entry.ActorID, entry.TargetID, details, entry.OccurredAt)
+	return err
+}
+
+// FindRecent returns at most limit entries of the action (of every action when empty), most recent first.
+func (r *AuditRepository) FindRecent(ctx context.Context, action string, limit int) ([]models.AuditEntry, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, auditSelect+` WHERE $1 = '' OR action = $1
+		ORDER BY occurred_at DESC LIMIT $2`, action, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var e models.AuditEntry
+		var details []byte
+		if err := rows.Scan(&e.ID, &e.Action, &e.ActorID, &e.TargetID, &details, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(details, &e.Details); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
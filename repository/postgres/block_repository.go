@@ -0,0 +1,90 @@
+// block_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+)
+
+// blockJoin joins the blocks with the live Complejo blocked.
+const blockJoin = ` FROM blocks b JOIN complejos c ON c.id = b.blocked_id AND c.deleted_at IS NULL WHERE b.blocker_id = $1`
+
+// BlockRepository is the PostgreSQL implementation of repository.BlockRepository.
+type BlockRepository struct {
+	db *sql.DB
+}
+
+// NewBlockRepository creates a BlockRepository backed by the given database.
+func NewBlockRepository(db *sql.DB) *BlockRepository {
+	return &BlockRepository{db: db}
+}
+
+// Insert stores a new Block, or returns repository.ErrDuplicate when the blocker already blocks the blocked
+// Complejo.
+func (r *BlockRepository) Insert(ctx context.Context, block *models.Block) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO blocks (id, blocker_id, blocked_id, created_at)
+		VALUES ($1, $2, $3, $4)`, block.ID, block.BlockerID, block.BlockedID, block.CreatedAt)
+	return rejected(err)
+}
+
+// Delete removes the Block of the blocked Complejo by the blocker and reports whether there was one.
+func (r *BlockRepository) Delete(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`, blockerID, blockedID))
+}
+
+// Exists reports whether the blocker blocks the blocked Complejo.
+func (r *BlockRepository) Exists(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM blocks WHERE blocker_id = $1 AND blocked_id = $2)`, blockerID, blockedID).Scan(&exists)
+	return exists, err
+}
+
+// FindBlockerIDs returns the IDs of the Complejos blocking the Complejo.
+func (r *BlockRepository) FindBlockerIDs(ctx context.Context, blockedID string) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT blocker_id FROM blocks WHERE blocked_id = $1`, blockedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// FindBlocked returns the page of the live Complejos the blocker blocks, latest first, and their total number.
+func (r *BlockRepository) FindBlocked(ctx context.Context, blockerID string, offset, limit int) ([]models.BlockEntry, int64, error) {
+	db := conn(ctx, r.db)
+
+	var total int64
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*)`+blockJoin, blockerID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT c.id, c.username, b.created_at`+blockJoin+
+		` ORDER BY b.created_at DESC, c.id LIMIT $2 OFFSET $3`, blockerID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []models.BlockEntry{}
+	for rows.Next() {
+		var e models.BlockEntry
+		if err := rows.Scan(&e.ComplejoID, &e.Username, &e.BlockedAt); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, total, rows.Err()
+}
@@ -0,0 +1,180 @@
+// challenge_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const challengeSelect = `SELECT id, title, description, metric, starts_at, ends_at, created_by, scored_at, created_at FROM challenges`
+
+// challengeMatch filters the challenges on their status "upcoming", "active" or "ended" $1 at the time $2,
+// ignored when empty.
+const challengeMatch = ` WHERE ($1 = ''
+	OR ($1 = 'upcoming' AND starts_at > $2)
+	OR ($1 = 'active' AND starts_at <= $2 AND ends_at > $2)
+	OR ($1 = 'ended' AND ends_at <= $2))`
+
+// ChallengeRepository is the PostgreSQL implementation of repository.ChallengeRepository.
+type ChallengeRepository struct {
+	db *sql.DB
+}
+
+// NewChallengeRepository creates a ChallengeRepository backed by the given database.
+func NewChallengeRepository(db *sql.DB) *ChallengeRepository {
+	return &ChallengeRepository{db: db}
+}
+
+// Insert stores a new Challenge.
+func (r *ChallengeRepository) Insert(ctx context.Context, challenge *models.Challenge) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO challenges
+		(id, title, description, metric, starts_at, ends_at, created_by, scored_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		challenge.ID, challenge.Title, challenge.Description, challenge.Metric, challenge.StartsAt, challenge.EndsAt,
+		challenge.CreatedBy, challenge.ScoredAt, challenge.CreatedAt)
+	return rejected(err)
+}
+
+// FindByID returns the Challenge with the given ID, or repository.ErrNotFound.
+func (r *ChallengeRepository) FindByID(ctx context.Context, id string) (*models.Challenge, error) {
+	challenge, err := scanChallenge(conn(ctx, r.db).QueryRowContext(ctx, challengeSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return challenge, err
+}
+
+// Search returns the page of the normalized filter of the challenges with its status at now, latest start
+// first, and their total number.
+func (r *ChallengeRepository) Search(ctx context.Context, filter models.ChallengeFilter, now time.Time) ([]models.Challenge, int64, error) {
+	var total int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM challenges`+challengeMatch, filter.Status, now).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	challenges, err := r.query(ctx, challengeSelect+challengeMatch+` ORDER BY starts_at DESC, id LIMIT $3 OFFSET $4`,
+		filter.Status, now, filter.Limit, filter.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	return challenges, total, nil
+}
+
+// FindScorable returns the challenges started at now that were never scored or last scored before their end.
+func (r *ChallengeRepository) FindScorable(ctx context.Context, now time.Time) ([]models.Challenge, error) {
+	return r.query(ctx, challengeSelect+` WHERE starts_at <= $1 AND (scored_at IS NULL OR scored_at < ends_at)
+		ORDER BY starts_at`, now)
+}
+
+// DeleteByID removes the Challenge with the given ID and its participants (ON DELETE CASCADE), and reports
+// whether it was found.
+func (r *ChallengeRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM challenges WHERE id = $1`, id))
+}
+
+// AddParticipant stores a new ChallengeParticipant, or returns repository.ErrDuplicate when the Complejo already
+// joined the Challenge.
+func (r *ChallengeRepository) AddParticipant(ctx context.Context, participant *models.ChallengeParticipant) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO challenge_participants
+		(id, challenge_id, complejo_id, score, joined_at) VALUES ($1, $2, $3, $4, $5)`,
+		participant.ID, participant.ChallengeID, participant.ComplejoID, participant.Score, participant.JoinedAt)
+	return rejected(err)
+}
+
+// RemoveParticipant removes the Complejo from the participants of the Challenge and reports whether it was one.
+func (r *ChallengeRepository) RemoveParticipant(ctx context.Context, id, complejoID string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM challenge_participants WHERE challenge_id = $1 AND complejo_id = $2`, id, complejoID))
+}
+
+// FindParticipants returns the IDs of the participants of the Challenge.
+func (r *ChallengeRepository) FindParticipants(ctx context.Context, id string) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT complejo_id FROM challenge_participants WHERE challenge_id = $1 ORDER BY joined_at`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var complejoID string
+		if err := rows.Scan(&complejoID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, complejoID)
+	}
+	return ids, rows.Err()
+}
+
+// SetScores stores the scores of the participants of the Challenge, by Complejo ID, and the time of the scoring.
+func (r *ChallengeRepository) SetScores(ctx context.Context, id string, scores map[string]float64, at time.Time) error {
+	db := conn(ctx, r.db)
+	for complejoID, score := range scores {
+		_, err := db.ExecContext(ctx, `UPDATE challenge_participants SET score = $3
+			WHERE challenge_id = $1 AND complejo_id = $2`, id, complejoID, score)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := db.ExecContext(ctx, `UPDATE challenges SET scored_at = $2 WHERE id = $1`, id, at)
+	return err
+}
+
+// Standings returns the live participants of the Challenge with their current username, highest score first
+// (earliest joined first on a tie), without their rank.
+func (r *ChallengeRepository) Standings(ctx context.Context, id string) ([]models.ChallengeStanding, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT p.complejo_id, c.username, p.score, p.joined_at
+		FROM challenge_participants p
+		JOIN complejos c ON c.id = p.complejo_id AND c.deleted_at IS NULL
+		WHERE p.challenge_id = $1
+		ORDER BY p.score DESC, p.joined_at, p.complejo_id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	standings := []models.ChallengeStanding{}
+	for rows.Next() {
+		var s models.ChallengeStanding
+		if err := rows.Scan(&s.ComplejoID, &s.Username, &s.Score, &s.JoinedAt); err != nil {
+			return nil, err
+		}
+		standings = append(standings, s)
+	}
+	return standings, rows.Err()
+}
+
+// query returns the Challenges selected by a query built on challengeSelect.
+func (r *ChallengeRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.Challenge, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	challenges := []models.Challenge{}
+	for rows.Next() {
+		challenge, err := scanChallenge(rows)
+		if err != nil {
+			return nil, err
+		}
+		challenges = append(challenges, *challenge)
+	}
+	return challenges, rows.Err()
+}
+
+// scanChallenge reads a Challenge from a row produced by challengeSelect.
+func scanChallenge(row rowScanner) (*models.Challenge, error) {
+	var c models.Challenge
+	err := row.Scan(&c.ID, &c.Title, &c.Description, &c.Metric, &c.StartsAt, &c.EndsAt, &c.CreatedBy, &c.ScoredAt, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
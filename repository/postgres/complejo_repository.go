@@ -0,0 +1,202 @@
+// complejo_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// complejoColumns maps the JSON/BSON field names of a Complejo to their columns.
+var complejoColumns = map[string]string{
+	"username": "username",
+	"password": "password",
+	"role":     "role",
+	"weight":   "weight",
+	"height":   "height",
+	"imc":      "imc",
+	"gender":   "gender",
+	"bench":    "bench",
+	"squad":    "squad",
+	"dl":       "dl",
+	"photo":    "photo",
+	"photo_id": "photo_id",
+	"email":    "email",
+	"locale":   "locale",
+	"units":    "units",
+
+	"photo_consent": "photo_consent",
+	"goal":          "goal",
+
+	"calendar_nonce": "calendar_nonce",
+}
+
+const complejoSelect = `SELECT id, username, password, role, weight, height, imc, gender, bench, squad, dl, photo, photo_id, email, locale, units, photo_consent, goal, calendar_nonce, created_at, churn_risk FROM complejos`
+
+// ComplejoRepository is the PostgreSQL implementation of repository.ComplejoRepository.
+type ComplejoRepository struct {
+	db *sql.DB
+}
+
+// NewComplejoRepository creates a ComplejoRepository backed by the given database.
+func NewComplejoRepository(db *sql.DB) *ComplejoRepository {
+	return &ComplejoRepository{db: db}
+}
+
+// Insert stores a new Complejo. It returns repository.ErrDuplicate when the username is taken.
+func (r *ComplejoRepository) Insert(ctx context.Context, complejo *models.Complejo) error {
+	imc, err := imcColumn(complejo.IMC)
+	if err != nil {
+		return err
+	}
+	_, err = conn(ctx, r.db).ExecContext(ctx, `INSERT INTO complejos
+		(id, username, password, role, weight, height, imc, gender, bench, squad, dl, photo, photo_id, email, locale, units, photo_consent, goal, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+		complejo.ID, complejo.Username, complejo.Password, complejo.Role, complejo.Weight, complejo.Height,
+		imc, complejo.Gender, complejo.Bench, complejo.Squad, complejo.DL, complejo.Photo, complejo.PhotoID,
+		complejo.Email, complejo.Locale, complejo.Units, complejo.PhotoConsent, complejo.Goal, complejo.CreatedAt)
+	return rejected(err)
+}
+
+// FindAll returns every stored Complejo.
+func (r *ComplejoRepository) FindAll(ctx context.Context) ([]models.Complejo, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, complejoSelect+` WHERE deleted_at IS NULL ORDER BY username`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var complejos []models.Complejo
+	for rows.Next() {
+		complejo, err := scanComplejo(rows)
+		if err != nil {
+			return nil, err
+		}
+		complejos = append(complejos, *complejo)
+	}
+	return complejos, rows.Err()
+}
+
+// FindByID returns the Complejo with the given ID, or repository.ErrNotFound.
+func (r *ComplejoRepository) FindByID(ctx context.Context, id string) (*models.Complejo, error) {
+	complejo, err := scanComplejo(conn(ctx, r.db).QueryRowContext(ctx, complejoSelect+` WHERE id = $1 AND deleted_at IS NULL`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return complejo, err
+}
+
+// UpdateByID sets the given fields on the Complejo with the given ID.
+// When role is not empty, only a Complejo with that role is updated.
+// It reports whether a matching Complejo was found, and returns repository.ErrDuplicate when the new username is taken.
+func (r *ComplejoRepository) UpdateByID(ctx context.Context, id, role string, fields map[string]interface{}) (bool, error) {
+	values := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if imc, ok := value.(*models.IMC); ok {
+			// The IMC is stored as JSON, NULL when unknown
+			column, err := imcColumn(imc)
+			if err != nil {
+				return false, err
+			}
+			values[key] = column
+			continue
+		}
+		values[key] = fmt.Sprint(value)
+	}
+
+	set, args, err := buildSet(values, complejoColumns, 3)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE complejos SET ` + set + ` WHERE id = $1 AND deleted_at IS NULL AND ($2 = '' OR role = $2)`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, append([]interface{}{id, role}, args...)...)
+	if err != nil {
+		return false, rejected(err)
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// SetChurnRisk stores the churn-risk score of the Complejo with the given ID and reports whether it was found.
+func (r *ComplejoRepository) SetChurnRisk(ctx context.Context, id string, risk *models.ChurnRisk) (bool, error) {
+	value, err := json.Marshal(risk)
+	if err != nil {
+		return false, err
+	}
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE complejos SET churn_risk = $2 WHERE id = $1 AND deleted_at IS NULL`, id, value))
+}
+
+// DeleteByID marks the Complejo with the given ID as deleted at the given time and reports whether it was found.
+func (r *ComplejoRepository) DeleteByID(ctx context.Context, id string, at time.Time) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE complejos SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`, id, at))
+}
+
+// RestoreByID clears the deletion mark of the Complejo with the given ID and reports whether a deleted one was found.
+// Erased Complejos cannot be restored.
+func (r *ComplejoRepository) RestoreByID(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE complejos SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL AND erased_at IS NULL`, id))
+}
+
+// Anonymize replaces the username of the Complejo with the given ID with the placeholder, clears its other
+// personal fields, and marks it as deleted and erased at the given time. It reports whether it was found.
+func (r *ComplejoRepository) Anonymize(ctx context.Context, id, placeholder string, at time.Time) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE complejos SET username = $2, password = '', email = '',
+		weight = 0, height = 0, imc = NULL, gender = 'other', bench = 0, squad = 0, dl = 0, photo = '', photo_id = '',
+		locale = '', units = '', photo_consent = $3, goal = '', calendar_nonce = '', churn_risk = NULL, deleted_at = $4, erased_at = $4
+		WHERE id = $1 AND deleted_at IS NULL`, id, placeholder, models.PhotoConsentDeny, at))
+}
+
+// PurgeDeleted permanently removes the Complejos deleted before the given time and returns how many were removed.
+func (r *ComplejoRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM complejos WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanComplejo reads a Complejo from a row produced by complejoSelect.
+func scanComplejo(row rowScanner) (*models.Complejo, error) {
+	var c models.Complejo
+	var imc, churnRisk []byte
+	err := row.Scan(&c.ID, &c.Username, &c.Password, &c.Role, &c.Weight, &c.Height,
+		&imc, &c.Gender, &c.Bench, &c.Squad, &c.DL, &c.Photo, &c.PhotoID, &c.Email, &c.Locale, &c.Units, &c.PhotoConsent, &c.Goal, &c.CalendarNonce, &c.CreatedAt, &churnRisk)
+	if err != nil {
+		return nil, err
+	}
+	if imc != nil {
+		if err := json.Unmarshal(imc, &c.IMC); err != nil {
+			return nil, err
+		}
+	}
+	if churnRisk != nil {
+		if err := json.Unmarshal(churnRisk, &c.ChurnRisk); err != nil {
+			return nil, err
+		}
+	}
+	return &c, nil
+}
+
+// imcColumn encodes the IMC of a Complejo for its JSONB column, nil (NULL) when unknown.
+func imcColumn(imc *models.IMC) (interface{}, error) {
+	if imc == nil {
+		return nil, nil
+	}
+	value, err := json.Marshal(imc)
+	if err != nil {
+		return nil, err
+	}
+	return string(value), nil
+}
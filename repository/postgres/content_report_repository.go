@@ -0,0 +1,114 @@
+// content_report_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const contentReportSelect = `SELECT id, kind, target_id, reporter_id, reason, details, status, note, decided_by,
+	created_at, decided_at FROM content_reports`
+
+// ContentReportRepository is the PostgreSQL implementation of repository.ContentReportRepository.
+type ContentReportRepository struct {
+	db *sql.DB
+}
+
+// NewContentReportRepository creates a ContentReportRepository backed by the given database.
+func NewContentReportRepository(db *sql.DB) *ContentReportRepository {
+	return &ContentReportRepository{db: db}
+}
+
+// Insert stores a new ContentReport, or returns repository.ErrDuplicate when the reporter already has a pending
+// report of the same content.
+func (r *ContentReportRepository) Insert(ctx context.Context, report *models.ContentReport) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO content_reports
+		(id, kind, target_id, reporter_id, reason, details, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		report.ID, report.Kind, report.TargetID, report.ReporterID, report.Reason, report.Details, report.Status,
+		report.CreatedAt)
+	return rejected(err)
+}
+
+// Find returns the page of the reports with the given status, and of the given kind unless it is empty, oldest
+// first, and their total number.
+func (r *ContentReportRepository) Find(ctx context.Context, status, kind string, offset, limit int) ([]models.ContentReport, int64, error) {
+	db := conn(ctx, r.db)
+	where := ` WHERE status = $1`
+	args := []interface{}{status}
+	if kind != "" {
+		args = append(args, kind)
+		where += ` AND kind = $2`
+	}
+
+	var total int64
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM content_reports`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, limit, offset)
+	rows, err := db.QueryContext(ctx, contentReportSelect+where+` ORDER BY created_at, id`+
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	reports := []models.ContentReport{}
+	for rows.Next() {
+		report, err := scanContentReport(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		reports = append(reports, *report)
+	}
+	return reports, total, rows.Err()
+}
+
+// FindByID returns the ContentReport with the given ID, or repository.ErrNotFound.
+func (r *ContentReportRepository) FindByID(ctx context.Context, id string) (*models.ContentReport, error) {
+	report, err := scanContentReport(conn(ctx, r.db).QueryRowContext(ctx, contentReportSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return report, err
+}
+
+// Decide stores the decision of the pending ContentReport and reports whether it was found.
+func (r *ContentReportRepository) Decide(ctx context.Context, report *models.ContentReport) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE content_reports
+		SET status = $3, note = $4, decided_by = $5, decided_at = $6
+		WHERE id = $1 AND status = $2`,
+		report.ID, models.ReportPending, report.Status, report.Note, report.DecidedBy, report.DecidedAt))
+}
+
+// DecideByTarget stores the decision on every pending report of the content and returns how many there were.
+func (r *ContentReportRepository) DecideByTarget(ctx context.Context, report *models.ContentReport) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE content_reports
+		SET status = $4, note = $5, decided_by = $6, decided_at = $7
+		WHERE kind = $1 AND target_id = $2 AND status = $3`,
+		report.Kind, report.TargetID, models.ReportPending, report.Status, report.Note, report.DecidedBy, report.DecidedAt)
+	if err != nil {
+		return 0, rejected(err)
+	}
+	return result.RowsAffected()
+}
+
+// scanContentReport reads a ContentReport from a row produced by contentReportSelect.
+func scanContentReport(row rowScanner) (*models.ContentReport, error) {
+	var c models.ContentReport
+	var decidedAt sql.NullTime
+	err := row.Scan(&c.ID, &c.Kind, &c.TargetID, &c.ReporterID, &c.Reason, &c.Details, &c.Status, &c.Note,
+		&c.DecidedBy, &c.CreatedAt, &decidedAt)
+	if err != nil {
+		return nil, err
+	}
+	if decidedAt.Valid {
+		c.DecidedAt = &decidedAt.Time
+	}
+	return &c, nil
+}
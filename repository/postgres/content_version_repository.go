@@ -0,0 +1,86 @@
+// content_version_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const contentVersionSelect = `SELECT id, document_type, document_id, version, title, body, diff, created_by, created_at,
+	restored_from FROM content_versions`
+
+// ContentVersionRepository is the PostgreSQL implementation of repository.ContentVersionRepository.
+type ContentVersionRepository struct {
+	db *sql.DB
+}
+
+// NewContentVersionRepository creates a ContentVersionRepository backed by the given database.
+func NewContentVersionRepository(db *sql.DB) *ContentVersionRepository {
+	return &ContentVersionRepository{db: db}
+}
+
+// Insert stores a new ContentVersion, or returns repository.ErrDuplicate when the document already has
+// a version with its number.
+func (r *ContentVersionRepository) Insert(ctx context.Context, version *models.ContentVersion) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO content_versions
+		(id, document_type, document_id, version, title, body, diff, created_by, created_at, restored_from)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		version.ID, version.DocumentType, version.DocumentID, version.Version, version.Title, version.Body, version.Diff,
+		version.CreatedBy, version.CreatedAt, version.RestoredFrom)
+	return rejected(err)
+}
+
+// FindLatest returns the latest version of the document, or repository.ErrNotFound.
+func (r *ContentVersionRepository) FindLatest(ctx context.Context, documentType, documentID string) (*models.ContentVersion, error) {
+	return r.findOne(ctx, contentVersionSelect+` WHERE document_type = $1 AND document_id = $2 ORDER BY version DESC LIMIT 1`,
+		documentType, documentID)
+}
+
+// FindAll returns every version of the document, the latest first.
+func (r *ContentVersionRepository) FindAll(ctx context.Context, documentType, documentID string) ([]models.ContentVersion, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, contentVersionSelect+` WHERE document_type = $1 AND document_id = $2
+		ORDER BY version DESC`, documentType, documentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []models.ContentVersion{}
+	for rows.Next() {
+		version, err := scanContentVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *version)
+	}
+	return versions, rows.Err()
+}
+
+// FindVersion returns the given version of the document, or repository.ErrNotFound.
+func (r *ContentVersionRepository) FindVersion(ctx context.Context, documentType, documentID string, version int) (*models.ContentVersion, error) {
+	return r.findOne(ctx, contentVersionSelect+` WHERE document_type = $1 AND document_id = $2 AND version = $3`,
+		documentType, documentID, version)
+}
+
+// findOne returns the version selected by the query, or repository.ErrNotFound.
+func (r *ContentVersionRepository) findOne(ctx context.Context, query string, args ...interface{}) (*models.ContentVersion, error) {
+	version, err := scanContentVersion(conn(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return version, err
+}
+
+// scanContentVersion reads a ContentVersion from a row produced by contentVersionSelect.
+func scanContentVersion(row rowScanner) (*models.ContentVersion, error) {
+	var v models.ContentVersion
+	err := row.Scan(&v.ID, &v.DocumentType, &v.DocumentID, &v.Version, &v.Title, &v.Body, &v.Diff, &v.CreatedBy,
+		&v.CreatedAt, &v.RestoredFrom)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
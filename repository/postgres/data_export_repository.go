@@ -0,0 +1,99 @@
+// data_export_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const dataExportSelect = `SELECT id, complejo_id, status, requested_at, completed_at, expires_at, size FROM data_exports`
+
+// DataExportRepository is the PostgreSQL implementation of repository.DataExportRepository.
+type DataExportRepository struct {
+	db *sql.DB
+}
+
+// NewDataExportRepository creates a DataExportRepository backed by the given database.
+func NewDataExportRepository(db *sql.DB) *DataExportRepository {
+	return &DataExportRepository{db: db}
+}
+
+// Insert stores a new DataExport.
+func (r *DataExportRepository) Insert(ctx context.Context, export *models.DataExport) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO data_exports
+		(id, complejo_id, status, requested_at, completed_at, expires_at, size) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		export.ID, export.ComplejoID, export.Status, export.RequestedAt, export.CompletedAt, export.ExpiresAt, export.Size)
+	return rejected(err)
+}
+
+// FindLatest returns the DataExport most recently requested by the Complejo, or repository.ErrNotFound.
+func (r *DataExportRepository) FindLatest(ctx context.Context, complejoID string) (*models.DataExport, error) {
+	export, err := scanDataExport(conn(ctx, r.db).QueryRowContext(ctx, dataExportSelect+` WHERE complejo_id = $1
+		ORDER BY requested_at DESC, id DESC LIMIT 1`, complejoID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return export, err
+}
+
+// FindByID returns the DataExport with the given ID, or repository.ErrNotFound.
+func (r *DataExportRepository) FindByID(ctx context.Context, id string) (*models.DataExport, error) {
+	export, err := scanDataExport(conn(ctx, r.db).QueryRowContext(ctx, dataExportSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return export, err
+}
+
+// MarkReady records that the archive of the pending DataExport was generated, and reports whether a pending
+// DataExport was found.
+func (r *DataExportRepository) MarkReady(ctx context.Context, id string, size int64, at, expiresAt time.Time) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE data_exports SET status = $3, size = $4,
+		completed_at = $5, expires_at = $6 WHERE id = $1 AND status = $2`,
+		id, models.DataExportPending, models.DataExportReady, size, at, expiresAt))
+}
+
+// FindExpired returns the exports whose archive expired before the given time.
+func (r *DataExportRepository) FindExpired(ctx context.Context, before time.Time) ([]models.DataExport, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, dataExportSelect+` WHERE expires_at < $1`, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports := []models.DataExport{}
+	for rows.Next() {
+		export, err := scanDataExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, *export)
+	}
+	return exports, rows.Err()
+}
+
+// Delete removes the DataExport with the given ID and reports whether it was found.
+func (r *DataExportRepository) Delete(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM data_exports WHERE id = $1`, id))
+}
+
+// scanDataExport reads a DataExport from a row produced by dataExportSelect.
+func scanDataExport(row rowScanner) (*models.DataExport, error) {
+	var e models.DataExport
+	var completedAt, expiresAt sql.NullTime
+	err := row.Scan(&e.ID, &e.ComplejoID, &e.Status, &e.RequestedAt, &completedAt, &expiresAt, &e.Size)
+	if err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		e.CompletedAt = &completedAt.Time
+	}
+	if expiresAt.Valid {
+		e.ExpiresAt = &expiresAt.Time
+	}
+	return &e, nil
+}
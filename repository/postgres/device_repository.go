@@ -0,0 +1,78 @@
+// device_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+
+	"github.com/lib/pq"
+)
+
+const deviceSelect = `SELECT id, complejo_id, platform, token, p256dh, auth, name, created_at, updated_at FROM devices`
+
+// DeviceRepository is the PostgreSQL implementation of repository.DeviceRepository.
+type DeviceRepository struct {
+	db *sql.DB
+}
+
+// NewDeviceRepository creates a DeviceRepository backed by the given database.
+func NewDeviceRepository(db *sql.DB) *DeviceRepository {
+	return &DeviceRepository{db: db}
+}
+
+// Upsert stores the Device, or updates the Device registered with the same token (keeping its ID and
+// creation time, which are set on the given Device).
+func (r *DeviceRepository) Upsert(ctx context.Context, device *models.Device) error {
+	err := conn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO devices
+		(id, complejo_id, platform, token, p256dh, auth, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (token) DO UPDATE SET complejo_id = EXCLUDED.complejo_id, platform = EXCLUDED.platform,
+		p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth, name = EXCLUDED.name, updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at`,
+		device.ID, device.ComplejoID, device.Platform, device.Token, device.P256dh, device.Auth, device.Name,
+		device.CreatedAt, device.UpdatedAt).Scan(&device.ID, &device.CreatedAt)
+	return rejected(err)
+}
+
+// FindAll returns every Device.
+func (r *DeviceRepository) FindAll(ctx context.Context) ([]models.Device, error) {
+	return r.query(ctx, deviceSelect+` ORDER BY updated_at DESC, id`)
+}
+
+// FindByComplejos returns the Devices of the Complejos with the given IDs, most recently registered first.
+func (r *DeviceRepository) FindByComplejos(ctx context.Context, complejoIDs []string) ([]models.Device, error) {
+	return r.query(ctx, deviceSelect+` WHERE complejo_id = ANY($1) ORDER BY updated_at DESC, id`, pq.Array(complejoIDs))
+}
+
+// Delete removes the Device with the given ID of the Complejo and reports whether it was found.
+func (r *DeviceRepository) Delete(ctx context.Context, id, complejoID string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM devices WHERE id = $1 AND complejo_id = $2`, id, complejoID))
+}
+
+// DeleteByToken removes the Device registered with the token, if any.
+func (r *DeviceRepository) DeleteByToken(ctx context.Context, token string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM devices WHERE token = $1`, token)
+	return err
+}
+
+// query returns the Devices selected by the query.
+func (r *DeviceRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.Device, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := []models.Device{}
+	for rows.Next() {
+		var d models.Device
+		err := rows.Scan(&d.ID, &d.ComplejoID, &d.Platform, &d.Token, &d.P256dh, &d.Auth, &d.Name, &d.CreatedAt, &d.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, d)
+	}
+	return devices, rows.Err()
+}
@@ -0,0 +1,102 @@
+// erasure_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ErasureRepository is the PostgreSQL implementation of repository.ErasureRepository. It spans the tables the
+// data of a Complejo was copied to.
+type ErasureRepository struct {
+	db *sql.DB
+}
+
+// NewErasureRepository creates an ErasureRepository backed by the given database.
+func NewErasureRepository(db *sql.DB) *ErasureRepository {
+	return &ErasureRepository{db: db}
+}
+
+// Anonymize replaces the username of the Complejo with the placeholder wherever it was copied and removes its
+// tags from the photos. It returns how many rows were changed by table.
+func (r *ErasureRepository) Anonymize(ctx context.Context, complejoID, username, placeholder string) (map[string]int64, error) {
+	statements := []erasureStatement{
+		{"event_guests", `UPDATE event_guests SET host_username = $2 WHERE host_id = $1`, []interface{}{complejoID, placeholder}},
+		{"event_participants", `UPDATE event_participants SET username = $2 WHERE username = $1`, []interface{}{username, placeholder}},
+		{"subscription_events", `UPDATE subscription_events SET username = $2 WHERE username = $1`, []interface{}{username, placeholder}},
+		{"volunteer_signups", `UPDATE volunteer_signups SET username = $2 WHERE complejo_id = $1`, []interface{}{complejoID, placeholder}},
+		{"loans", `UPDATE loans SET username = $2 WHERE complejo_id = $1`, []interface{}{complejoID, placeholder}},
+		{"lost_item_claims", `UPDATE lost_item_claims SET username = $2 WHERE claimant_id = $1`, []interface{}{complejoID, placeholder}},
+		{"event_photo_tags", `DELETE FROM event_photo_tags WHERE complejo_id = $1`, []interface{}{complejoID}},
+	}
+	return execAll(ctx, conn(ctx, r.db), statements)
+}
+
+// RemoveContent removes the photos the Complejo uploaded, its lost-and-found posts with their claims, its
+// content held for review, its devices, its lift history, its workouts, its nutrition logs, its challenge
+// participations, its group memberships, its follows and blocks, both ways, its activity in the feed and the
+// content it reported. It returns the object store keys of the removed photos and how many rows were removed by table.
+func (r *ErasureRepository) RemoveContent(ctx context.Context, complejoID string) ([]string, map[string]int64, error) {
+	db := conn(ctx, r.db)
+	rows, err := db.QueryContext(ctx, `SELECT key FROM event_photos WHERE uploaded_by = $1`, complejoID)
+	if err != nil {
+		return nil, map[string]int64{}, err
+	}
+	defer rows.Close()
+	photos := []string{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, map[string]int64{}, err
+		}
+		photos = append(photos, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, map[string]int64{}, err
+	}
+
+	// The tags of the photos and the claims of the posts are removed with them (ON DELETE CASCADE).
+	removed, err := execAll(ctx, db, []erasureStatement{
+		{"event_photos", `DELETE FROM event_photos WHERE uploaded_by = $1`, []interface{}{complejoID}},
+		{"lost_items", `DELETE FROM lost_items WHERE posted_by = $1`, []interface{}{complejoID}},
+		{"content_holds", `DELETE FROM content_holds WHERE author_id = $1`, []interface{}{complejoID}},
+		{"devices", `DELETE FROM devices WHERE complejo_id = $1`, []interface{}{complejoID}},
+		{"lift_entries", `DELETE FROM lift_entries WHERE complejo_id = $1`, []interface{}{complejoID}},
+		{"workouts", `DELETE FROM workouts WHERE complejo_id = $1`, []interface{}{complejoID}},
+		{"nutrition_logs", `DELETE FROM nutrition_logs WHERE complejo_id = $1`, []interface{}{complejoID}},
+		{"challenge_participants", `DELETE FROM challenge_participants WHERE complejo_id = $1`, []interface{}{complejoID}},
+		{"group_memberships", `DELETE FROM group_memberships WHERE complejo_id = $1`, []interface{}{complejoID}},
+		{"follows", `DELETE FROM follows WHERE follower_id = $1 OR followee_id = $1`, []interface{}{complejoID}},
+		{"feed_items", `DELETE FROM feed_items WHERE complejo_id = $1`, []interface{}{complejoID}},
+		{"blocks", `DELETE FROM blocks WHERE blocker_id = $1 OR blocked_id = $1`, []interface{}{complejoID}},
+		{"content_reports", `DELETE FROM content_reports WHERE reporter_id = $1`, []interface{}{complejoID}},
+	})
+	if err != nil {
+		return nil, removed, err
+	}
+	return photos, removed, nil
+}
+
+// erasureStatement is a statement of an erasure, changing the rows of a table.
+type erasureStatement struct {
+	table string
+	query string
+	args  []interface{}
+}
+
+// execAll runs the statements in order and returns how many rows each changed, by table.
+func execAll(ctx context.Context, db executor, statements []erasureStatement) (map[string]int64, error) {
+	changed := make(map[string]int64, len(statements))
+	for _, s := range statements {
+		result, err := db.ExecContext(ctx, s.query, s.args...)
+		if err != nil {
+			return changed, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return changed, err
+		}
+		changed[s.table] += n
+	}
+	return changed, nil
+}
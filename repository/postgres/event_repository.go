@@ -0,0 +1,498 @@
+// event_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/lib/pq"
+)
+
+// eventColumns maps the JSON/BSON field names of an Event to their columns.
+var eventColumns = map[string]string{
+	"title":       "title",
+	"description": "description",
+	"date":        "date",
+	"image":       "image",
+	"location":    "location",
+	"created_by":  "created_by",
+	"group_id":    "group_id",
+	"capacity":    "capacity",
+	"level":       "level",
+	"intensity":   "intensity",
+	"level_gate":  "level_gate",
+
+	"pinned_until": "pinned_until",
+	"featured":     "featured",
+
+	"outdoor":           "outdoor",
+	"weather_warned_at": "weather_warned_at",
+	"reminded_at":       "reminded_at",
+
+	"external_id":         "external_id",
+	"external_updated_at": "external_updated_at",
+}
+
+const (
+	eventFields = `SELECT e.id, e.title, e.description, e.date, e.image, e.location, e.created_by, e.capacity,
+	e.level, e.intensity, e.level_gate, e.level_overrides,
+	e.external_id, e.external_updated_at, e.deleted_at, e.pinned_until, e.featured,
+	e.outdoor, e.weather, e.weather_warned_at, e.reminded_at, e.route, e.group_id,
+	COALESCE(json_agg(json_build_object('complejo_id', r.complejo_id, 'username', r.username, 'status', r.status,
+	'responded_at', r.responded_at) ORDER BY r.responded_at, r.complejo_id) FILTER (WHERE r.complejo_id IS NOT NULL), '[]'),
+	(SELECT COALESCE(json_agg(json_build_object('_id', g.id, 'name', g.name, 'host_id', g.host_id,
+	'host_username', g.host_username, 'created_at', g.created_at) ORDER BY g.created_at, g.id), '[]')
+	FROM event_guests g WHERE g.event_id = e.id),
+	(SELECT COALESCE(array_agg(l.complejo_id ORDER BY l.liked_at, l.complejo_id), '{}') FROM event_likes l WHERE l.event_id = e.id)`
+	eventFrom   = ` FROM events e LEFT JOIN event_rsvps r ON r.event_id = e.id`
+	eventSelect = eventFields + eventFrom
+
+	// pinnedFirst orders the Events pinned at the time of the parameter it is formatted with first, then by date.
+	pinnedFirst = ` ORDER BY COALESCE(e.pinned_until > $%d, FALSE) DESC, e.date, e.id`
+	// pinnedFirstLatest is pinnedFirst with the newest Events first.
+	pinnedFirstLatest = ` ORDER BY COALESCE(e.pinned_until > $%d, FALSE) DESC, e.date DESC, e.id DESC`
+)
+
+// EventRepository is the PostgreSQL implementation of repository.EventRepository.
+type EventRepository struct {
+	db *sql.DB
+}
+
+// NewEventRepository creates an EventRepository backed by the given database.
+func NewEventRepository(db *sql.DB) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+// Insert stores a new Event together with its initial RSVPs.
+func (r *EventRepository) Insert(ctx context.Context, event *models.Event) error {
+	return NewTransactor(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		tx := conn(ctx, r.db)
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO events (id, title, description, date, image, location, created_by,
+			capacity, level, intensity, level_gate, level_overrides, external_id, external_updated_at, outdoor, group_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, COALESCE($12::TEXT[], '{}'), NULLIF($13, ''), $14, $15, $16)`,
+			event.ID, event.Title, event.Description, event.Date, event.Image, event.Location, event.CreatedBy,
+			event.Capacity, event.Level, event.Intensity, event.LevelGate, pq.Array(event.LevelOverrides), event.ExternalID, event.ExternalUpdatedAt,
+			event.Outdoor, event.GroupID)
+		if err != nil {
+			return rejected(err)
+		}
+
+		for _, rsvp := range event.RSVPs {
+			_, err := tx.ExecContext(ctx, `INSERT INTO event_rsvps (event_id, complejo_id, username, status, responded_at)
+				VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`, event.ID, rsvp.ComplejoID, rsvp.Username, rsvp.Status, rsvp.RespondedAt)
+			if err != nil {
+				return rejected(err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindAll returns every stored Event, the ones pinned at now first, then by date.
+func (r *EventRepository) FindAll(ctx context.Context, now time.Time) ([]models.Event, error) {
+	return r.query(ctx, eventSelect+` WHERE e.deleted_at IS NULL GROUP BY e.id`+fmt.Sprintf(pinnedFirst, 1), now)
+}
+
+// FindFeatured returns at most limit featured Events dated from the given time on, the ones pinned at that
+// time first, then by date.
+func (r *EventRepository) FindFeatured(ctx context.Context, from time.Time, limit int) ([]models.Event, error) {
+	return r.query(ctx, eventSelect+` WHERE e.featured AND e.date >= $1 AND e.deleted_at IS NULL GROUP BY e.id`+
+		fmt.Sprintf(pinnedFirst, 1)+` LIMIT $2`, from, limit)
+}
+
+// Search returns the page of Events matching the filter, the ones pinned at now first, then by date in the order
+// of the filter, and the total number of matches.
+func (r *EventRepository) Search(ctx context.Context, filter models.EventFilter, now time.Time) ([]models.Event, int64, error) {
+	conditions := []string{"e.deleted_at IS NULL"}
+	var args []interface{}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("e.date >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("e.date <= $%d", len(args)))
+	}
+	if filter.Location != "" {
+		args = append(args, filter.Location)
+		conditions = append(conditions, fmt.Sprintf("strpos(lower(e.location), lower($%d)) > 0", len(args)))
+	}
+	if filter.Group != "" {
+		args = append(args, filter.Group)
+		conditions = append(conditions, fmt.Sprintf("e.group_id = $%d", len(args)))
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	db := conn(ctx, r.db)
+
+	var total int64
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM events e`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order := pinnedFirst
+	if filter.Sort == models.SortDescending {
+		order = pinnedFirstLatest
+	}
+	args = append(args, now, filter.Limit, filter.Offset())
+	events, err := r.query(ctx, eventSelect+where+` GROUP BY e.id`+fmt.Sprintf(order, len(args)-2)+
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// TextSearch returns at most limit Events matching the full-text query, most relevant first.
+func (r *EventRepository) TextSearch(ctx context.Context, query string, limit int) ([]models.ScoredEvent, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, eventFields+`, ts_rank(e.search, plainto_tsquery('simple', $1))`+eventFrom+
+		` WHERE e.search @@ plainto_tsquery('simple', $1) AND e.deleted_at IS NULL GROUP BY e.id ORDER BY 27 DESC, e.date LIMIT $2`, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.ScoredEvent{}
+	for rows.Next() {
+		var scored models.ScoredEvent
+		event, err := scanEvent(scoredRow{rows: rows, score: &scored.Score})
+		if err != nil {
+			return nil, err
+		}
+		scored.Event = *event
+		events = append(events, scored)
+	}
+	return events, rows.Err()
+}
+
+// FindByID returns the Event with the given ID, or repository.ErrNotFound.
+func (r *EventRepository) FindByID(ctx context.Context, id string) (*models.Event, error) {
+	event, err := scanEvent(conn(ctx, r.db).QueryRowContext(ctx, eventSelect+` WHERE e.id = $1 AND e.deleted_at IS NULL GROUP BY e.id`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return event, err
+}
+
+// FindByExternalID returns the Event ingested with the given external ID, or repository.ErrNotFound.
+func (r *EventRepository) FindByExternalID(ctx context.Context, externalID string) (*models.Event, error) {
+	event, err := scanEvent(conn(ctx, r.db).QueryRowContext(ctx, eventSelect+` WHERE e.external_id = $1 GROUP BY e.id`, externalID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return event, err
+}
+
+// UpdateByID sets the given fields on the Event with the given ID.
+// It reports whether a matching Event was found.
+func (r *EventRepository) UpdateByID(ctx context.Context, id string, fields map[string]interface{}) (bool, error) {
+	set, args, err := buildSet(fields, eventColumns, 2)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE events SET `+set+` WHERE id = $1 AND deleted_at IS NULL`, append([]interface{}{id}, args...)...)
+	if err != nil {
+		return false, rejected(err)
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// DeleteByID marks the Event with the given ID as deleted at the given time and reports whether it was found.
+func (r *EventRepository) DeleteByID(ctx context.Context, id string, at time.Time) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE events SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`, id, at))
+}
+
+// RestoreByID clears the deletion mark of the Event with the given ID and reports whether a deleted one was found.
+func (r *EventRepository) RestoreByID(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE events SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`, id))
+}
+
+// PurgeDeleted permanently removes the Events deleted before the given time (their RSVPs are removed
+// by the foreign key cascade) and returns how many were removed.
+func (r *EventRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM events WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// participantsFrom joins the Complejos going to the Event $1 with their profiles.
+const participantsFrom = ` FROM event_rsvps r
+	JOIN complejos c ON c.id = r.complejo_id AND c.deleted_at IS NULL
+	WHERE r.event_id = $1 AND r.status = 'going'`
+
+// FindParticipants returns the page (skipping offset, at most limit) of the profiles of the live Complejos
+// going to the Event, in the order they answered, and their total number.
+func (r *EventRepository) FindParticipants(ctx context.Context, id string, offset, limit int) ([]models.Participant, int64, error) {
+	db := conn(ctx, r.db)
+
+	var total int64
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*)`+participantsFrom, id).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT c.id, c.username, c.photo, c.photo_id, c.bench, c.squad, c.dl, r.responded_at`+participantsFrom+
+		` ORDER BY r.responded_at, r.complejo_id LIMIT $2 OFFSET $3`, id, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	participants := []models.Participant{}
+	for rows.Next() {
+		var p models.Participant
+		if err := rows.Scan(&p.ComplejoID, &p.Username, &p.Photo, &p.PhotoID, &p.Bench, &p.Squad, &p.DL, &p.RespondedAt); err != nil {
+			return nil, 0, err
+		}
+		participants = append(participants, p)
+	}
+	return participants, total, rows.Err()
+}
+
+// RemoveRSVPsFromAll removes the RSVPs of the Complejo from every Event
+// and returns the IDs of the Events it was going to.
+func (r *EventRepository) RemoveRSVPsFromAll(ctx context.Context, complejoID string) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `DELETE FROM event_rsvps WHERE complejo_id = $1 RETURNING event_id, status`, complejoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id, status string
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, err
+		}
+		if status == models.RSVPGoing {
+			ids = append(ids, id)
+		}
+	}
+	return ids, rows.Err()
+}
+
+// SetRSVP replaces the RSVP of the Complejo on the Event with the given one.
+// It reports whether the Event was found.
+func (r *EventRepository) SetRSVP(ctx context.Context, id string, rsvp models.RSVP) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `INSERT INTO event_rsvps (event_id, complejo_id, username, status, responded_at)
+		SELECT id, $2, $3, $4, $5 FROM events WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT (event_id, complejo_id) DO UPDATE
+		SET username = EXCLUDED.username, status = EXCLUDED.status, responded_at = EXCLUDED.responded_at`,
+		id, rsvp.ComplejoID, rsvp.Username, rsvp.Status, rsvp.RespondedAt))
+}
+
+// RemoveRSVP removes the RSVP of the Complejo from the Event.
+// It reports whether the Event was found and whether the Complejo had answered.
+func (r *EventRepository) RemoveRSVP(ctx context.Context, id, complejoID string) (matched, modified bool, err error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM event_rsvps r USING events e
+		WHERE r.event_id = e.id AND e.deleted_at IS NULL AND r.event_id = $1 AND r.complejo_id = $2`, id, complejoID)
+	if err != nil {
+		return false, false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, false, err
+	}
+	if affected > 0 {
+		return true, true, nil
+	}
+
+	matched, err = r.exists(ctx, id)
+	return matched, false, err
+}
+
+// SetLike adds the Complejo to the likes of the Event, or removes it when liked is false.
+// It reports whether the Event was found.
+func (r *EventRepository) SetLike(ctx context.Context, id, complejoID string, liked bool) (bool, error) {
+	query := `DELETE FROM event_likes WHERE event_id = $1 AND complejo_id = $2`
+	args := []interface{}{id, complejoID}
+	if liked {
+		query = `INSERT INTO event_likes (event_id, complejo_id, liked_at)
+			SELECT id, $2, NOW() FROM events WHERE id = $1 AND deleted_at IS NULL ON CONFLICT DO NOTHING`
+	}
+	changed, err := affected(conn(ctx, r.db).ExecContext(ctx, query, args...))
+	if err != nil || changed {
+		return changed, err
+	}
+	// Nothing changed: the Event is missing, or the like was already (un)set
+	return r.exists(ctx, id)
+}
+
+// SetLevelOverride lets the Complejo through the level gate of the Event, or withdraws the override
+// when allowed is false. It reports whether the Event was found.
+func (r *EventRepository) SetLevelOverride(ctx context.Context, id, complejoID string, allowed bool) (bool, error) {
+	overrides := `array_remove(level_overrides, $2)`
+	if allowed {
+		overrides = `array_append(array_remove(level_overrides, $2), $2)`
+	}
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE events SET level_overrides = `+overrides+` WHERE id = $1 AND deleted_at IS NULL`, id, complejoID))
+}
+
+// FindByRSVP returns the Events dated from the given time on that the Complejo answered with the status, by date.
+func (r *EventRepository) FindByRSVP(ctx context.Context, complejoID, status string, from time.Time) ([]models.Event, error) {
+	return r.query(ctx, eventSelect+` WHERE e.deleted_at IS NULL AND e.date >= $3
+		AND EXISTS (SELECT 1 FROM event_rsvps a WHERE a.event_id = e.id AND a.complejo_id = $1 AND a.status = $2)
+		GROUP BY e.id ORDER BY e.date, e.id`, complejoID, status, from)
+}
+
+// FindUnwarnedOutdoor returns the outdoor Events dated between from and to whose participants were not
+// warned of severe weather, by date.
+func (r *EventRepository) FindUnwarnedOutdoor(ctx context.Context, from, to time.Time) ([]models.Event, error) {
+	return r.query(ctx, eventSelect+` WHERE e.outdoor AND e.date >= $1 AND e.date <= $2 AND e.weather_warned_at IS NULL
+		AND e.deleted_at IS NULL GROUP BY e.id ORDER BY e.date, e.id`, from, to)
+}
+
+// FindUnreminded returns the Events dated between from and to whose participants were not reminded of them, by date.
+func (r *EventRepository) FindUnreminded(ctx context.Context, from, to time.Time) ([]models.Event, error) {
+	return r.query(ctx, eventSelect+` WHERE e.date >= $1 AND e.date <= $2 AND e.reminded_at IS NULL
+		AND e.deleted_at IS NULL GROUP BY e.id ORDER BY e.date, e.id`, from, to)
+}
+
+// SetRoute sets the GPX route of the Event, or removes it when route is nil, and reports whether the Event was found.
+func (r *EventRepository) SetRoute(ctx context.Context, id string, route *models.EventRoute) (bool, error) {
+	var value []byte
+	if route != nil {
+		var err error
+		if value, err = json.Marshal(route); err != nil {
+			return false, err
+		}
+	}
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE events SET route = $2 WHERE id = $1 AND deleted_at IS NULL`, id, value))
+}
+
+// SetForecast caches the weather forecast on the Event and reports whether the Event was found.
+func (r *EventRepository) SetForecast(ctx context.Context, id string, forecast models.Forecast) (bool, error) {
+	weather, err := json.Marshal(forecast)
+	if err != nil {
+		return false, err
+	}
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE events SET weather = $2 WHERE id = $1 AND deleted_at IS NULL`, id, weather))
+}
+
+// AddGuest registers the guest for the Event and reports whether the Event was found.
+func (r *EventRepository) AddGuest(ctx context.Context, id string, guest models.Guest) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `INSERT INTO event_guests (id, event_id, name, host_id, host_username, created_at)
+		SELECT $2, e.id, $3, $4, $5, $6 FROM events e WHERE e.id = $1 AND e.deleted_at IS NULL`,
+		id, guest.ID, guest.Name, guest.HostID, guest.HostUsername, guest.CreatedAt))
+}
+
+// RemoveGuest removes the guest from the Event and reports whether it was registered.
+func (r *EventRepository) RemoveGuest(ctx context.Context, id, guestID string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM event_guests g USING events e
+		WHERE g.event_id = e.id AND e.deleted_at IS NULL AND g.event_id = $1 AND g.id = $2`, id, guestID))
+}
+
+// FindByGuest returns the Events, by date, the Complejo brought a guest with the given name to.
+func (r *EventRepository) FindByGuest(ctx context.Context, hostID, name string) ([]models.Event, error) {
+	return r.query(ctx, eventSelect+` WHERE e.deleted_at IS NULL
+		AND EXISTS (SELECT 1 FROM event_guests g WHERE g.event_id = e.id AND g.host_id = $1 AND g.name = $2)
+		GROUP BY e.id ORDER BY e.date, e.id`, hostID, name)
+}
+
+// CountGuests returns how many guests the Complejo registered in [from, to).
+func (r *EventRepository) CountGuests(ctx context.Context, hostID string, from, to time.Time) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM event_guests
+		WHERE host_id = $1 AND created_at >= $2 AND created_at < $3`, hostID, from, to).Scan(&count)
+	return count, err
+}
+
+// exists reports whether an Event with the given ID is stored.
+func (r *EventRepository) exists(ctx context.Context, id string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM events WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&exists)
+	return exists, err
+}
+
+// query returns the Events selected by a query built on eventSelect.
+func (r *EventRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.Event, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.Event{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	return events, rows.Err()
+}
+
+// scoredRow reads the relevance score that follows the eventSelect columns of a search row.
+type scoredRow struct {
+	rows  *sql.Rows
+	score *float64
+}
+
+// Scan implements rowScanner.
+func (s scoredRow) Scan(dest ...interface{}) error {
+	return s.rows.Scan(append(dest, s.score)...)
+}
+
+// scanEvent reads an Event from a row produced by eventSelect.
+func scanEvent(row rowScanner) (*models.Event, error) {
+	var e models.Event
+	var image, externalID sql.NullString
+	var externalUpdatedAt, deletedAt, pinnedUntil, weatherWarnedAt, remindedAt sql.NullTime
+	var weather, route, rsvps, guests []byte
+	err := row.Scan(&e.ID, &e.Title, &e.Description, &e.Date, &image, &e.Location, &e.CreatedBy, &e.Capacity,
+		&e.Level, &e.Intensity, &e.LevelGate, pq.Array(&e.LevelOverrides), &externalID, &externalUpdatedAt, &deletedAt,
+		&pinnedUntil, &e.Featured, &e.Outdoor, &weather, &weatherWarnedAt, &remindedAt, &route, &e.GroupID, &rsvps, &guests, pq.Array(&e.Likes))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rsvps, &e.RSVPs); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(guests, &e.Guests); err != nil {
+		return nil, err
+	}
+	if image.Valid {
+		e.Image = &image.String
+	}
+	e.ExternalID = externalID.String
+	if externalUpdatedAt.Valid {
+		e.ExternalUpdatedAt = &externalUpdatedAt.Time
+	}
+	if deletedAt.Valid {
+		e.DeletedAt = &deletedAt.Time
+	}
+	if pinnedUntil.Valid {
+		e.PinnedUntil = &pinnedUntil.Time
+	}
+	if weather != nil {
+		if err := json.Unmarshal(weather, &e.Weather); err != nil {
+			return nil, err
+		}
+	}
+	if route != nil {
+		if err := json.Unmarshal(route, &e.Route); err != nil {
+			return nil, err
+		}
+	}
+	if weatherWarnedAt.Valid {
+		e.WeatherWarnedAt = &weatherWarnedAt.Time
+	}
+	if remindedAt.Valid {
+		e.RemindedAt = &remindedAt.Time
+	}
+	return &e, nil
+}
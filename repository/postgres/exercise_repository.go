@@ -0,0 +1,114 @@
+// exercise_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/lib/pq"
+)
+
+const exerciseSelect = `SELECT id, name, muscle_groups, equipment, video_url, created_at, updated_at FROM exercises`
+
+// exerciseMatch filters the exercises on the part of their name $1, the muscle group $2 and the equipment $3,
+// each ignored when empty.
+const exerciseMatch = ` WHERE strpos(lower(name), lower($1)) > 0
+	AND ($2 = '' OR $2 = ANY(muscle_groups)) AND ($3 = '' OR equipment = $3)`
+
+// ExerciseRepository is the PostgreSQL implementation of repository.ExerciseRepository.
+type ExerciseRepository struct {
+	db *sql.DB
+}
+
+// NewExerciseRepository creates an ExerciseRepository backed by the given database.
+func NewExerciseRepository(db *sql.DB) *ExerciseRepository {
+	return &ExerciseRepository{db: db}
+}
+
+// Insert stores a new Exercise, or returns repository.ErrDuplicate when its name is taken.
+func (r *ExerciseRepository) Insert(ctx context.Context, exercise *models.Exercise) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO exercises
+		(id, name, muscle_groups, equipment, video_url, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		exercise.ID, exercise.Name, pq.Array(exercise.MuscleGroups), exercise.Equipment, exercise.VideoURL,
+		exercise.CreatedAt, exercise.UpdatedAt)
+	return rejected(err)
+}
+
+// FindByID returns the Exercise with the given ID, or repository.ErrNotFound.
+func (r *ExerciseRepository) FindByID(ctx context.Context, id string) (*models.Exercise, error) {
+	exercise, err := scanExercise(conn(ctx, r.db).QueryRowContext(ctx, exerciseSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return exercise, err
+}
+
+// FindByIDs returns the exercises with the given IDs that exist, in no particular order.
+func (r *ExerciseRepository) FindByIDs(ctx context.Context, ids []string) ([]models.Exercise, error) {
+	return r.query(ctx, exerciseSelect+` WHERE id = ANY($1)`, pq.Array(ids))
+}
+
+// Search returns the page of the normalized filter of the exercises matching it, ordered by name, and their
+// total number.
+func (r *ExerciseRepository) Search(ctx context.Context, filter models.ExerciseFilter) ([]models.Exercise, int64, error) {
+	var total int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM exercises`+exerciseMatch,
+		filter.Query, filter.MuscleGroup, filter.Equipment).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	exercises, err := r.query(ctx, exerciseSelect+exerciseMatch+` ORDER BY name, id LIMIT $4 OFFSET $5`,
+		filter.Query, filter.MuscleGroup, filter.Equipment, filter.Limit, filter.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	return exercises, total, nil
+}
+
+// Replace overwrites the stored Exercise with the same ID and reports whether it was found, or returns
+// repository.ErrDuplicate when its new name is taken.
+func (r *ExerciseRepository) Replace(ctx context.Context, exercise *models.Exercise) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE exercises
+		SET name = $2, muscle_groups = $3, equipment = $4, video_url = $5, updated_at = $6
+		WHERE id = $1`,
+		exercise.ID, exercise.Name, pq.Array(exercise.MuscleGroups), exercise.Equipment, exercise.VideoURL,
+		exercise.UpdatedAt))
+}
+
+// DeleteByID removes the Exercise with the given ID and reports whether it was found.
+func (r *ExerciseRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM exercises WHERE id = $1`, id))
+}
+
+// query returns the Exercises selected by a query built on exerciseSelect.
+func (r *ExerciseRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.Exercise, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exercises := []models.Exercise{}
+	for rows.Next() {
+		exercise, err := scanExercise(rows)
+		if err != nil {
+			return nil, err
+		}
+		exercises = append(exercises, *exercise)
+	}
+	return exercises, rows.Err()
+}
+
+// scanExercise reads an Exercise from a row produced by exerciseSelect.
+func scanExercise(row rowScanner) (*models.Exercise, error) {
+	var e models.Exercise
+	err := row.Scan(&e.ID, &e.Name, pq.Array(&e.MuscleGroups), &e.Equipment, &e.VideoURL, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,109 @@
+// expense_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const expenseSelect = `SELECT id, event_id, category, description, amount, currency, incurred_at, created_by, created_at, updated_at FROM expenses`
+
+// ExpenseRepository is the PostgreSQL implementation of repository.ExpenseRepository.
+type ExpenseRepository struct {
+	db *sql.DB
+}
+
+// NewExpenseRepository creates an ExpenseRepository backed by the given database.
+func NewExpenseRepository(db *sql.DB) *ExpenseRepository {
+	return &ExpenseRepository{db: db}
+}
+
+// Insert stores a new Expense.
+func (r *ExpenseRepository) Insert(ctx context.Context, expense *models.Expense) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO expenses
+		(id, event_id, category, description, amount, currency, incurred_at, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		expense.ID, expense.EventID, expense.Category, expense.Description, expense.Amount, expense.Currency,
+		expense.IncurredAt, expense.CreatedBy, expense.CreatedAt, expense.UpdatedAt)
+	return rejected(err)
+}
+
+// FindByEvent returns the expenses of the Event, oldest first.
+func (r *ExpenseRepository) FindByEvent(ctx context.Context, eventID string) ([]models.Expense, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, expenseSelect+` WHERE event_id = $1 ORDER BY incurred_at, id`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expenses := []models.Expense{}
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, *expense)
+	}
+	return expenses, rows.Err()
+}
+
+// FindByID returns the Expense of the Event with the given ID, or repository.ErrNotFound.
+func (r *ExpenseRepository) FindByID(ctx context.Context, eventID, id string) (*models.Expense, error) {
+	expense, err := scanExpense(conn(ctx, r.db).QueryRowContext(ctx, expenseSelect+` WHERE id = $1 AND event_id = $2`, id, eventID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return expense, err
+}
+
+// Replace overwrites the stored Expense with the same ID and Event, and reports whether it was found.
+func (r *ExpenseRepository) Replace(ctx context.Context, expense *models.Expense) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE expenses
+		SET category = $3, description = $4, amount = $5, currency = $6, incurred_at = $7, updated_at = $8
+		WHERE id = $1 AND event_id = $2`,
+		expense.ID, expense.EventID, expense.Category, expense.Description, expense.Amount, expense.Currency,
+		expense.IncurredAt, expense.UpdatedAt))
+}
+
+// DeleteByID removes the Expense of the Event with the given ID and reports whether it was found.
+func (r *ExpenseRepository) DeleteByID(ctx context.Context, eventID, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM expenses WHERE id = $1 AND event_id = $2`, id, eventID))
+}
+
+// Totals sums the expenses incurred between from and to by Event, month (in the given location) and currency.
+func (r *ExpenseRepository) Totals(ctx context.Context, from, to time.Time, location *time.Location) ([]models.ExpenseRow, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT event_id, to_char(incurred_at AT TIME ZONE $3, 'YYYY-MM'), currency, SUM(amount)
+		FROM expenses
+		WHERE incurred_at >= $1 AND incurred_at < $2
+		GROUP BY 1, 2, 3`, from, to, location.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []models.ExpenseRow
+	for rows.Next() {
+		var row models.ExpenseRow
+		if err := rows.Scan(&row.EventID, &row.Month, &row.Currency, &row.Amount); err != nil {
+			return nil, err
+		}
+		totals = append(totals, row)
+	}
+	return totals, rows.Err()
+}
+
+// scanExpense reads an Expense from a row produced by expenseSelect.
+func scanExpense(row rowScanner) (*models.Expense, error) {
+	var e models.Expense
+	err := row.Scan(&e.ID, &e.EventID, &e.Category, &e.Description, &e.Amount, &e.Currency,
+		&e.IncurredAt, &e.CreatedBy, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
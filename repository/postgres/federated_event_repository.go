@@ -0,0 +1,66 @@
+// federated_event_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+
+	"github.com/lib/pq"
+)
+
+// FederatedEventRepository is the PostgreSQL implementation of repository.FederatedEventRepository.
+type FederatedEventRepository struct {
+	db *sql.DB
+}
+
+// NewFederatedEventRepository creates a FederatedEventRepository backed by the given database.
+func NewFederatedEventRepository(db *sql.DB) *FederatedEventRepository {
+	return &FederatedEventRepository{db: db}
+}
+
+// ReplaceClub upserts the given events and removes the club's events missing from them.
+func (r *FederatedEventRepository) ReplaceClub(ctx context.Context, club string, events []models.FederatedEvent) error {
+	return NewTransactor(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		tx := conn(ctx, r.db)
+
+		ids := make([]string, 0, len(events))
+		for _, e := range events {
+			ids = append(ids, e.ID)
+			_, err := tx.ExecContext(ctx, `INSERT INTO federated_events
+				(id, club, external_id, title, description, date, location, imported_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				ON CONFLICT (id) DO UPDATE SET title = EXCLUDED.title, description = EXCLUDED.description,
+					date = EXCLUDED.date, location = EXCLUDED.location, imported_at = EXCLUDED.imported_at`,
+				e.ID, e.Club, e.ExternalID, e.Title, e.Description, e.Date, e.Location, e.ImportedAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `DELETE FROM federated_events WHERE club = $1 AND NOT (id = ANY($2))`, club, pq.Array(ids))
+		return err
+	})
+}
+
+// FindUpcoming returns the imported events taking place at or after from, ordered by date.
+func (r *FederatedEventRepository) FindUpcoming(ctx context.Context, from time.Time) ([]models.FederatedEvent, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT id, club, external_id, title, description, date, location, imported_at
+		FROM federated_events WHERE date >= $1 ORDER BY date`, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.FederatedEvent{}
+	for rows.Next() {
+		var e models.FederatedEvent
+		if err := rows.Scan(&e.ID, &e.Club, &e.ExternalID, &e.Title, &e.Description, &e.Date, &e.Location, &e.ImportedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
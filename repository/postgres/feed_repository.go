@@ -0,0 +1,88 @@
+// feed_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+
+	"github.com/lib/pq"
+)
+
+// feedSelect selects the columns of the feed items, in the order Find scans them.
+const feedSelect = `SELECT id, kind, complejo_id, username, group_id, event_id, challenge_id, title, lift, value, created_at
+	FROM feed_items`
+
+// FeedRepository is the PostgreSQL implementation of repository.FeedRepository.
+type FeedRepository struct {
+	db *sql.DB
+}
+
+// NewFeedRepository creates a FeedRepository backed by the given database.
+func NewFeedRepository(db *sql.DB) *FeedRepository {
+	return &FeedRepository{db: db}
+}
+
+// Insert stores a new FeedItem, or does nothing when one with the same ID is already stored.
+func (r *FeedRepository) Insert(ctx context.Context, item *models.FeedItem) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO feed_items
+		(id, kind, complejo_id, username, group_id, event_id, challenge_id, title, lift, value, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (id) DO NOTHING`,
+		item.ID, item.Kind, item.ComplejoID, item.Username, item.GroupID, item.EventID, item.ChallengeID,
+		item.Title, item.Lift, item.Value, item.CreatedAt)
+	return err
+}
+
+// Find returns the page of the items of the Complejos or of the groups with the given IDs, latest first, and
+// their total number.
+func (r *FeedRepository) Find(ctx context.Context, complejoIDs, groupIDs []string, offset, limit int) ([]models.FeedItem, int64, error) {
+	db := conn(ctx, r.db)
+	where := ` WHERE (complejo_id <> '' AND complejo_id = ANY($1)) OR (group_id <> '' AND group_id = ANY($2))`
+
+	var total int64
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM feed_items`+where,
+		pq.Array(complejoIDs), pq.Array(groupIDs)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, feedSelect+where+` ORDER BY created_at DESC, id LIMIT $3 OFFSET $4`,
+		pq.Array(complejoIDs), pq.Array(groupIDs), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.FeedItem{}
+	for rows.Next() {
+		var item models.FeedItem
+		err := rows.Scan(&item.ID, &item.Kind, &item.ComplejoID, &item.Username, &item.GroupID, &item.EventID,
+			&item.ChallengeID, &item.Title, &item.Lift, &item.Value, &item.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+	return items, total, rows.Err()
+}
+
+// DeleteByEvent removes the items of the Event of the given kind, of the Complejo with the given ID or of every
+// one when it is empty, and returns how many were removed.
+func (r *FeedRepository) DeleteByEvent(ctx context.Context, eventID, kind, complejoID string) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM feed_items
+		WHERE event_id = $1 AND kind = $2 AND ($3 = '' OR complejo_id = $3)`, eventID, kind, complejoID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DeleteByComplejo removes the items of the Complejo and returns how many were removed.
+func (r *FeedRepository) DeleteByComplejo(ctx context.Context, complejoID string) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM feed_items WHERE complejo_id = $1`, complejoID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,113 @@
+// follow_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"los-complejos-backend/models"
+)
+
+// followJoin joins the follows with the live Complejo of the column it is formatted with.
+const followJoin = ` FROM follows f JOIN complejos c ON c.id = f.%s AND c.deleted_at IS NULL`
+
+// FollowRepository is the PostgreSQL implementation of repository.FollowRepository.
+type FollowRepository struct {
+	db *sql.DB
+}
+
+// NewFollowRepository creates a FollowRepository backed by the given database.
+func NewFollowRepository(db *sql.DB) *FollowRepository {
+	return &FollowRepository{db: db}
+}
+
+// Insert stores a new Follow, or returns repository.ErrDuplicate when the follower already follows the followee.
+func (r *FollowRepository) Insert(ctx context.Context, follow *models.Follow) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO follows (id, follower_id, followee_id, created_at)
+		VALUES ($1, $2, $3, $4)`, follow.ID, follow.FollowerID, follow.FolloweeID, follow.CreatedAt)
+	return rejected(err)
+}
+
+// Delete removes the Follow of the followee by the follower and reports whether there was one.
+func (r *FollowRepository) Delete(ctx context.Context, followerID, followeeID string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2`, followerID, followeeID))
+}
+
+// Exists reports whether the follower follows the followee.
+func (r *FollowRepository) Exists(ctx context.Context, followerID, followeeID string) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND followee_id = $2)`, followerID, followeeID).Scan(&exists)
+	return exists, err
+}
+
+// FindFolloweeIDs returns the IDs of the Complejos the follower follows.
+func (r *FollowRepository) FindFolloweeIDs(ctx context.Context, followerID string) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT followee_id FROM follows WHERE follower_id = $1`, followerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// FindFollowers returns the page of the live followers of the Complejo, latest first, and their total number.
+func (r *FollowRepository) FindFollowers(ctx context.Context, complejoID string, offset, limit int) ([]models.FollowEntry, int64, error) {
+	return r.list(ctx, "followee_id", "follower_id", complejoID, offset, limit)
+}
+
+// FindFollowing returns the page of the live Complejos the Complejo follows, latest first, and their total
+// number.
+func (r *FollowRepository) FindFollowing(ctx context.Context, complejoID string, offset, limit int) ([]models.FollowEntry, int64, error) {
+	return r.list(ctx, "follower_id", "followee_id", complejoID, offset, limit)
+}
+
+// Count returns the numbers of live followers of the Complejo and of live Complejos it follows.
+func (r *FollowRepository) Count(ctx context.Context, complejoID string) (int64, int64, error) {
+	var followers, following int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT
+		(SELECT COUNT(*)`+fmt.Sprintf(followJoin, "follower_id")+` WHERE f.followee_id = $1),
+		(SELECT COUNT(*)`+fmt.Sprintf(followJoin, "followee_id")+` WHERE f.follower_id = $1)`,
+		complejoID).Scan(&followers, &following)
+	return followers, following, err
+}
+
+// list returns the page of the live Complejos of the column listed of the follows whose column matched is the
+// Complejo, latest first, and their total number.
+func (r *FollowRepository) list(ctx context.Context, matched, listed, complejoID string, offset, limit int) ([]models.FollowEntry, int64, error) {
+	db := conn(ctx, r.db)
+	from := fmt.Sprintf(followJoin, listed) + ` WHERE f.` + matched + ` = $1`
+
+	var total int64
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*)`+from, complejoID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT c.id, c.username, f.created_at`+from+
+		` ORDER BY f.created_at DESC, c.id LIMIT $2 OFFSET $3`, complejoID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []models.FollowEntry{}
+	for rows.Next() {
+		var e models.FollowEntry
+		if err := rows.Scan(&e.ComplejoID, &e.Username, &e.FollowedAt); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, total, rows.Err()
+}
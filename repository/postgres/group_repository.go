@@ -0,0 +1,216 @@
+// group_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const groupSelect = `SELECT id, name, description, created_by, created_at, updated_at FROM groups`
+
+// groupMatch filters the groups on the case-insensitive part $1 of their name and, when $2, on the memberships
+// of the Complejo $3. Both are ignored when empty or false.
+const groupMatch = ` WHERE ($1 = '' OR strpos(lower(name), lower($1)) > 0)
+	AND (NOT $2 OR id IN (SELECT group_id FROM group_memberships WHERE complejo_id = $3))`
+
+// GroupRepository is the PostgreSQL implementation of repository.GroupRepository.
+type GroupRepository struct {
+	db *sql.DB
+}
+
+// NewGroupRepository creates a GroupRepository backed by the given database.
+func NewGroupRepository(db *sql.DB) *GroupRepository {
+	return &GroupRepository{db: db}
+}
+
+// Insert stores a new Group.
+func (r *GroupRepository) Insert(ctx context.Context, group *models.Group) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO groups
+		(id, name, description, created_by, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		group.ID, group.Name, group.Description, group.CreatedBy, group.CreatedAt, group.UpdatedAt)
+	return rejected(err)
+}
+
+// FindByID returns the Group with the given ID, or repository.ErrNotFound.
+func (r *GroupRepository) FindByID(ctx context.Context, id string) (*models.Group, error) {
+	group, err := scanGroup(conn(ctx, r.db).QueryRowContext(ctx, groupSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return group, err
+}
+
+// Search returns the page of the normalized filter of the groups by name, and their total number. Only the
+// groups of the Complejo with the given ID are returned when the filter asks for them.
+func (r *GroupRepository) Search(ctx context.Context, filter models.GroupFilter, complejoID string) ([]models.Group, int64, error) {
+	db := conn(ctx, r.db)
+
+	var total int64
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM groups`+groupMatch, filter.Query, filter.Mine, complejoID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, groupSelect+groupMatch+` ORDER BY name, id LIMIT $4 OFFSET $5`,
+		filter.Query, filter.Mine, complejoID, filter.Limit, filter.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	groups := []models.Group{}
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		groups = append(groups, *group)
+	}
+	return groups, total, rows.Err()
+}
+
+// Replace overwrites the name, description and update time of the Group, or returns repository.ErrNotFound.
+func (r *GroupRepository) Replace(ctx context.Context, group *models.Group) error {
+	found, err := affected(conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE groups SET name = $2, description = $3, updated_at = $4 WHERE id = $1`,
+		group.ID, group.Name, group.Description, group.UpdatedAt))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByID removes the Group with the given ID and its memberships (ON DELETE CASCADE), and reports whether it
+// was found.
+func (r *GroupRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM groups WHERE id = $1`, id))
+}
+
+// FindMembership returns the membership of the Complejo in the Group, or repository.ErrNotFound.
+func (r *GroupRepository) FindMembership(ctx context.Context, id, complejoID string) (*models.GroupMembership, error) {
+	var m models.GroupMembership
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, group_id, complejo_id, role, joined_at
+		FROM group_memberships WHERE group_id = $1 AND complejo_id = $2`, id, complejoID).
+		Scan(&m.ID, &m.GroupID, &m.ComplejoID, &m.Role, &m.JoinedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// FindRoles returns the roles of the Complejo in its groups, by group ID.
+func (r *GroupRepository) FindRoles(ctx context.Context, complejoID string) (map[string]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT group_id, role FROM group_memberships WHERE complejo_id = $1`, complejoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := map[string]string{}
+	for rows.Next() {
+		var groupID, role string
+		if err := rows.Scan(&groupID, &role); err != nil {
+			return nil, err
+		}
+		roles[groupID] = role
+	}
+	return roles, rows.Err()
+}
+
+// SetMembership stores the membership of a Complejo in a Group, or changes the role of the stored one (keeping
+// its ID and join time).
+func (r *GroupRepository) SetMembership(ctx context.Context, membership *models.GroupMembership) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO group_memberships
+		(id, group_id, complejo_id, role, joined_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (group_id, complejo_id) DO UPDATE SET role = EXCLUDED.role`,
+		membership.ID, membership.GroupID, membership.ComplejoID, membership.Role, membership.JoinedAt)
+	return rejected(err)
+}
+
+// RemoveMembership removes the Complejo from the members of the Group and reports whether it was one.
+func (r *GroupRepository) RemoveMembership(ctx context.Context, id, complejoID string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM group_memberships WHERE group_id = $1 AND complejo_id = $2`, id, complejoID))
+}
+
+// CountOwners returns the number of owners of the Group.
+func (r *GroupRepository) CountOwners(ctx context.Context, id string) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM group_memberships WHERE group_id = $1 AND role = $2`, id, models.GroupRoleOwner).Scan(&count)
+	return count, err
+}
+
+// FindMembers returns the page of the live members of the Group with their current username, owners first then
+// by join time, and their total number.
+func (r *GroupRepository) FindMembers(ctx context.Context, id string, offset, limit int) ([]models.GroupMember, int64, error) {
+	db := conn(ctx, r.db)
+
+	var total int64
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM group_memberships m
+		JOIN complejos c ON c.id = m.complejo_id AND c.deleted_at IS NULL
+		WHERE m.group_id = $1`, id).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT m.complejo_id, c.username, m.role, m.joined_at
+		FROM group_memberships m
+		JOIN complejos c ON c.id = m.complejo_id AND c.deleted_at IS NULL
+		WHERE m.group_id = $1
+		ORDER BY m.role = $2 DESC, m.joined_at, m.complejo_id LIMIT $3 OFFSET $4`,
+		id, models.GroupRoleOwner, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	members := []models.GroupMember{}
+	for rows.Next() {
+		var m models.GroupMember
+		if err := rows.Scan(&m.ComplejoID, &m.Username, &m.Role, &m.JoinedAt); err != nil {
+			return nil, 0, err
+		}
+		members = append(members, m)
+	}
+	return members, total, rows.Err()
+}
+
+// FindMemberIDs returns the IDs of the members of the Group.
+func (r *GroupRepository) FindMemberIDs(ctx context.Context, id string) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT complejo_id FROM group_memberships WHERE group_id = $1 ORDER BY joined_at`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var complejoID string
+		if err := rows.Scan(&complejoID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, complejoID)
+	}
+	return ids, rows.Err()
+}
+
+// scanGroup reads a Group from a row produced by groupSelect.
+func scanGroup(row rowScanner) (*models.Group, error) {
+	var g models.Group
+	if err := row.Scan(&g.ID, &g.Name, &g.Description, &g.CreatedBy, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
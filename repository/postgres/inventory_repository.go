@@ -0,0 +1,175 @@
+// inventory_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const (
+	itemSelect = `SELECT id, name, category, description, quantity, created_at, updated_at FROM inventory_items`
+	loanSelect = `SELECT id, item_id, complejo_id, username, quantity, lent_by, lent_at, due_at, returned_at, reminded_at FROM loans`
+)
+
+// InventoryRepository is the PostgreSQL implementation of repository.InventoryRepository.
+type InventoryRepository struct {
+	db *sql.DB
+}
+
+// NewInventoryRepository creates an InventoryRepository backed by the given database.
+func NewInventoryRepository(db *sql.DB) *InventoryRepository {
+	return &InventoryRepository{db: db}
+}
+
+// InsertItem stores a new InventoryItem.
+func (r *InventoryRepository) InsertItem(ctx context.Context, item *models.InventoryItem) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO inventory_items
+		(id, name, category, description, quantity, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		item.ID, item.Name, item.Category, item.Description, item.Quantity, item.CreatedAt, item.UpdatedAt)
+	return rejected(err)
+}
+
+// FindItems returns every InventoryItem, ordered by name.
+func (r *InventoryRepository) FindItems(ctx context.Context) ([]models.InventoryItem, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, itemSelect+` ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.InventoryItem{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	return items, rows.Err()
+}
+
+// FindItemByID returns the InventoryItem with the given ID, or repository.ErrNotFound.
+func (r *InventoryRepository) FindItemByID(ctx context.Context, id string) (*models.InventoryItem, error) {
+	item, err := scanItem(conn(ctx, r.db).QueryRowContext(ctx, itemSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return item, err
+}
+
+// ReplaceItem overwrites the stored InventoryItem with the same ID and reports whether it was found.
+func (r *InventoryRepository) ReplaceItem(ctx context.Context, item *models.InventoryItem) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE inventory_items
+		SET name = $2, category = $3, description = $4, quantity = $5, updated_at = $6
+		WHERE id = $1`,
+		item.ID, item.Name, item.Category, item.Description, item.Quantity, item.UpdatedAt))
+}
+
+// DeleteItem removes the InventoryItem with the given ID and reports whether it was found.
+func (r *InventoryRepository) DeleteItem(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM inventory_items WHERE id = $1`, id))
+}
+
+// InsertLoan stores a new Loan.
+func (r *InventoryRepository) InsertLoan(ctx context.Context, loan *models.Loan) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO loans
+		(id, item_id, complejo_id, username, quantity, lent_by, lent_at, due_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		loan.ID, loan.ItemID, loan.ComplejoID, loan.Username, loan.Quantity, loan.LentBy, loan.LentAt, loan.DueAt)
+	return rejected(err)
+}
+
+// FindLoans returns the loans matching the filter at the given time, most recently lent first.
+func (r *InventoryRepository) FindLoans(ctx context.Context, filter models.LoanFilter, now time.Time) ([]models.Loan, error) {
+	conditions := []string{"TRUE"}
+	var args []interface{}
+	switch filter.Status {
+	case models.LoanOpen:
+		conditions = append(conditions, "returned_at IS NULL")
+	case models.LoanOverdue:
+		args = append(args, now)
+		conditions = append(conditions, fmt.Sprintf("returned_at IS NULL AND due_at < $%d", len(args)))
+	case models.LoanReturned:
+		conditions = append(conditions, "returned_at IS NOT NULL")
+	}
+	if filter.ItemID != "" {
+		args = append(args, filter.ItemID)
+		conditions = append(conditions, fmt.Sprintf("item_id = $%d", len(args)))
+	}
+	if filter.ComplejoID != "" {
+		args = append(args, filter.ComplejoID)
+		conditions = append(conditions, fmt.Sprintf("complejo_id = $%d", len(args)))
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		loanSelect+` WHERE `+strings.Join(conditions, " AND ")+` ORDER BY lent_at DESC, id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	loans := []models.Loan{}
+	for rows.Next() {
+		loan, err := scanLoan(rows)
+		if err != nil {
+			return nil, err
+		}
+		loans = append(loans, *loan)
+	}
+	return loans, rows.Err()
+}
+
+// FindLoanByID returns the Loan with the given ID, or repository.ErrNotFound.
+func (r *InventoryRepository) FindLoanByID(ctx context.Context, id string) (*models.Loan, error) {
+	loan, err := scanLoan(conn(ctx, r.db).QueryRowContext(ctx, loanSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return loan, err
+}
+
+// MarkReturned records the return of the open Loan with the given ID and reports whether it was found.
+func (r *InventoryRepository) MarkReturned(ctx context.Context, id string, at time.Time) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE loans SET returned_at = $2 WHERE id = $1 AND returned_at IS NULL`, id, at))
+}
+
+// MarkReminded records that an overdue reminder of the Loan was sent at the given time.
+func (r *InventoryRepository) MarkReminded(ctx context.Context, id string, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE loans SET reminded_at = $2 WHERE id = $1`, id, at)
+	return rejected(err)
+}
+
+// scanItem reads an InventoryItem from a row produced by itemSelect.
+func scanItem(row rowScanner) (*models.InventoryItem, error) {
+	var i models.InventoryItem
+	err := row.Scan(&i.ID, &i.Name, &i.Category, &i.Description, &i.Quantity, &i.CreatedAt, &i.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+// scanLoan reads a Loan from a row produced by loanSelect.
+func scanLoan(row rowScanner) (*models.Loan, error) {
+	var l models.Loan
+	var returnedAt, remindedAt sql.NullTime
+	err := row.Scan(&l.ID, &l.ItemID, &l.ComplejoID, &l.Username, &l.Quantity, &l.LentBy, &l.LentAt, &l.DueAt,
+		&returnedAt, &remindedAt)
+	if err != nil {
+		return nil, err
+	}
+	if returnedAt.Valid {
+		l.ReturnedAt = &returnedAt.Time
+	}
+	if remindedAt.Valid {
+		l.RemindedAt = &remindedAt.Time
+	}
+	return &l, nil
+}
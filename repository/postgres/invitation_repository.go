@@ -0,0 +1,67 @@
+// invitation_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// InvitationRepository is the PostgreSQL implementation of repository.InvitationRepository.
+type InvitationRepository struct {
+	db *sql.DB
+}
+
+// NewInvitationRepository creates an InvitationRepository backed by the given database.
+func NewInvitationRepository(db *sql.DB) *InvitationRepository {
+	return &InvitationRepository{db: db}
+}
+
+// Insert stores a new GuestInvitation.
+func (r *InvitationRepository) Insert(ctx context.Context, invitation *models.GuestInvitation) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO guest_invitations
+		(id, token_hash, event_id, guest_id, name, host_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		invitation.ID, invitation.TokenHash, invitation.EventID, invitation.GuestID, invitation.Name, invitation.HostID,
+		invitation.CreatedAt, invitation.ExpiresAt)
+	return rejected(err)
+}
+
+// FindByTokenHash returns the GuestInvitation with the given token hash, or repository.ErrNotFound.
+func (r *InvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*models.GuestInvitation, error) {
+	var i models.GuestInvitation
+	var acceptedAt sql.NullTime
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT id, token_hash, event_id, guest_id, name, host_id,
+		created_at, expires_at, accepted_at, complejo_id FROM guest_invitations WHERE token_hash = $1`, tokenHash).
+		Scan(&i.ID, &i.TokenHash, &i.EventID, &i.GuestID, &i.Name, &i.HostID, &i.CreatedAt, &i.ExpiresAt, &acceptedAt, &i.ComplejoID)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if acceptedAt.Valid {
+		i.AcceptedAt = &acceptedAt.Time
+	}
+	return &i, nil
+}
+
+// Accept records that the invitation was accepted by the Complejo at the given time,
+// unless it already was, and reports whether it was accepted.
+func (r *InvitationRepository) Accept(ctx context.Context, id, complejoID string, at time.Time) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE guest_invitations SET accepted_at = $3, complejo_id = $2
+		WHERE id = $1 AND accepted_at IS NULL`, id, complejoID, at))
+}
+
+// PurgeExpired removes the invitations never accepted that expired before the given time and returns how
+// many were removed.
+func (r *InvitationRepository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM guest_invitations WHERE accepted_at IS NULL AND expires_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,89 @@
+// job_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/lib/pq"
+)
+
+// JobRepository is the PostgreSQL implementation of repository.JobRepository.
+type JobRepository struct {
+	db *sql.DB
+}
+
+// NewJobRepository creates a JobRepository backed by the given database.
+func NewJobRepository(db *sql.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+// Enqueue stores a new pending Job, or does nothing when a Job with the same ID is already stored.
+func (r *JobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO jobs (id, kind, payload, status, attempts, created_at, run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (id) DO NOTHING`,
+		job.ID, job.Kind, []byte(job.Payload), job.Status, job.Attempts, job.CreatedAt, job.RunAt)
+	return err
+}
+
+// Claim atomically picks the pending Job of the given kinds due the longest and leases it.
+func (r *JobRepository) Claim(ctx context.Context, kinds []string, now time.Time, lease time.Duration) (*models.Job, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE jobs SET attempts = attempts + 1, run_at = $3
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = 'pending' AND kind = ANY($1) AND run_at <= $2
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, kind, payload, status, attempts, last_error, created_at, run_at, finished_at`,
+		pq.Array(kinds), now, now.Add(lease))
+
+	var job models.Job
+	var payload []byte
+	var finishedAt sql.NullTime
+	err := row.Scan(&job.ID, &job.Kind, &payload, &job.Status, &job.Attempts, &job.LastError, &job.CreatedAt,
+		&job.RunAt, &finishedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	job.Payload = payload
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	return &job, nil
+}
+
+// Complete marks the Job done.
+func (r *JobRepository) Complete(ctx context.Context, id string, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE jobs SET status = 'done', last_error = '', finished_at = $2 WHERE id = $1`, id, at)
+	return err
+}
+
+// Retry records the failure of the Job and schedules its next attempt.
+func (r *JobRepository) Retry(ctx context.Context, id, reason string, runAt time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE jobs SET last_error = $2, run_at = $3 WHERE id = $1`, id, reason, runAt)
+	return err
+}
+
+// Fail records the failure of the Job and gives it up.
+func (r *JobRepository) Fail(ctx context.Context, id, reason string, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE jobs SET status = 'failed', last_error = $2, finished_at = $3 WHERE id = $1`, id, reason, at)
+	return err
+}
+
+// Purge removes the done and failed Jobs finished before the given time and returns how many were removed.
+func (r *JobRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM jobs WHERE status <> 'pending' AND finished_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,71 @@
+// journal_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const journalSelect = `SELECT id, method, route, path, body_schema, body_schema_hash, role, status, error, occurred_at FROM request_journal`
+
+// JournalRepository is the PostgreSQL implementation of repository.JournalRepository.
+type JournalRepository struct {
+	db *sql.DB
+}
+
+// NewJournalRepository creates a JournalRepository backed by the given database.
+func NewJournalRepository(db *sql.DB) *JournalRepository {
+	return &JournalRepository{db: db}
+}
+
+// Append records a new entry.
+func (r *JournalRepository) Append(ctx context.Context, entry *models.JournalEntry) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO request_journal
+		(id, method, route, path, body_schema, body_schema_hash, role, status, error, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		entry.ID, entry.Method, entry.Route, entry.Path, entry.BodySchema, entry.BodySchemaHash,
+		entry.Role, entry.Status, entry.Error, entry.OccurredAt)
+	return err
+}
+
+// FindRecent returns at most limit entries, most recent first.
+func (r *JournalRepository) FindRecent(ctx context.Context, limit int) ([]models.JournalEntry, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, journalSelect+` ORDER BY occurred_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.JournalEntry{}
+	for rows.Next() {
+		entry, err := scanJournalEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, rows.Err()
+}
+
+// FindByID returns the entry with the given ID, or repository.ErrNotFound.
+func (r *JournalRepository) FindByID(ctx context.Context, id string) (*models.JournalEntry, error) {
+	entry, err := scanJournalEntry(conn(ctx, r.db).QueryRowContext(ctx, journalSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return entry, err
+}
+
+// scanJournalEntry reads an entry from a row produced by journalSelect.
+func scanJournalEntry(row rowScanner) (*models.JournalEntry, error) {
+	var e models.JournalEntry
+	err := row.Scan(&e.ID, &e.Method, &e.Route, &e.Path, &e.BodySchema, &e.BodySchemaHash,
+		&e.Role, &e.Status, &e.Error, &e.OccurredAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
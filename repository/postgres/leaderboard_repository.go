@@ -0,0 +1,131 @@
+// leaderboard_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"los-complejos-backend/models"
+
+	"github.com/lib/pq"
+)
+
+// liftColumns are the expressions of the kilos of each lift; the total only counts the Complejos that recorded
+// all three lifts.
+var liftColumns = map[string]string{
+	models.LiftBench: "bench",
+	models.LiftSquad: "squad",
+	models.LiftDL:    "dl",
+	models.LiftTotal: "CASE WHEN bench > 0 AND squad > 0 AND dl > 0 THEN bench + squad + dl ELSE 0 END",
+}
+
+// leaderboardQuery ranks the Complejos by the key (kilos or score) of the ranked subquery, and returns the page
+// ($2 skipped, $3 returned) and the row of the Complejo $4, or a single row of NULLs with the total when there
+// are none. The subquery filters on the gender $1 (every gender when empty) and the IDs $5 (every Complejo when
+// NULL).
+const leaderboardQuery = `
+WITH ranked AS (
+    SELECT id, username, gender, kilos, score,
+           RANK() OVER (ORDER BY %[1]s DESC) AS rank,
+           ROW_NUMBER() OVER (ORDER BY %[1]s DESC, username, id) AS position
+    FROM (
+        SELECT id, username, gender, kilos, %[2]s AS score
+        FROM (
+            SELECT id, username, gender, weight, %[3]s AS kilos
+            FROM complejos
+            WHERE deleted_at IS NULL AND ($1 = '' OR gender = $1) AND ($5::TEXT[] IS NULL OR id = ANY($5))
+        ) lifts
+    ) scored
+    WHERE %[1]s > 0
+)
+SELECT r.rank, r.id, r.username, r.gender, r.kilos, r.score, r.position, total.count
+FROM (SELECT COUNT(*) AS count FROM ranked) total
+LEFT JOIN ranked r ON (r.position > $2::BIGINT AND r.position <= $2::BIGINT + $3::BIGINT) OR r.id = $4
+ORDER BY r.position`
+
+// LeaderboardRepository is the PostgreSQL implementation of repository.LeaderboardRepository.
+type LeaderboardRepository struct {
+	db *sql.DB
+}
+
+// NewLeaderboardRepository creates a LeaderboardRepository backed by the given database.
+func NewLeaderboardRepository(db *sql.DB) *LeaderboardRepository {
+	return &LeaderboardRepository{db: db}
+}
+
+// Rank ranks the live Complejos matching the normalized query by the kilos of its lift, or by their score when it
+// has a scoring, best first.
+func (r *LeaderboardRepository) Rank(ctx context.Context, query models.LeaderboardQuery, complejoID string) ([]models.LeaderboardEntry, *models.LeaderboardEntry, int64, error) {
+	key, score := "kilos", "NULL::DOUBLE PRECISION"
+	if query.Scoring != "" {
+		key, score = "score", scoreColumn(query.Scoring)
+	}
+	statement := fmt.Sprintf(leaderboardQuery, key, score, liftColumns[query.Lift])
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, statement, query.Gender, query.Offset(), query.Limit, complejoID,
+		pq.Array(query.Members))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []models.LeaderboardEntry{}
+	var me *models.LeaderboardEntry
+	var total int64
+	for rows.Next() {
+		var rank, position sql.NullInt64
+		var id, username, gender sql.NullString
+		var kilos, score sql.NullFloat64
+		if err := rows.Scan(&rank, &id, &username, &gender, &kilos, &score, &position, &total); err != nil {
+			return nil, nil, 0, err
+		}
+		if !id.Valid {
+			continue
+		}
+		entry := models.LeaderboardEntry{
+			Rank:       rank.Int64,
+			ComplejoID: id.String,
+			Username:   username.String,
+			Gender:     gender.String,
+			Kilos:      kilos.Float64,
+		}
+		if score.Valid {
+			entry.Score = &score.Float64
+		}
+		if entry.ComplejoID == complejoID {
+			mine := entry
+			me = &mine
+		}
+		if position.Int64 > int64(query.Offset()) && position.Int64 <= int64(query.Offset()+query.Limit) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, me, total, rows.Err()
+}
+
+// scoreColumn returns the expression of the score of the kilos with the scoring, rounded to two decimals, or
+// NULL when there is no formula for the gender or no recorded bodyweight.
+func scoreColumn(scoring string) string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, gender := range []string{"male", "female"} {
+		formula := models.ScoringFormulas[scoring][gender]
+		bodyweight := fmt.Sprintf("LEAST(GREATEST(weight, %s), %s)", literal(formula.MinBodyweight), literal(formula.MaxBodyweight))
+		terms := make([]string, 0, len(formula.Coefficients))
+		for degree, coefficient := range formula.Coefficients {
+			terms = append(terms, fmt.Sprintf("%s * POWER(%s, %d)", literal(coefficient), bodyweight, degree))
+		}
+		fmt.Fprintf(&b, " WHEN gender = '%s' AND weight > 0 THEN ROUND((kilos * 500 / (%s))::NUMERIC, 2)::DOUBLE PRECISION",
+			gender, strings.Join(terms, " + "))
+	}
+	b.WriteString(" END")
+	return b.String()
+}
+
+// literal formats the constant as an SQL literal.
+func literal(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
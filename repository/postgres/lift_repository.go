@@ -0,0 +1,75 @@
+// lift_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// LiftRepository is the PostgreSQL implementation of repository.LiftRepository.
+type LiftRepository struct {
+	db *sql.DB
+}
+
+// NewLiftRepository creates a LiftRepository backed by the given database.
+func NewLiftRepository(db *sql.DB) *LiftRepository {
+	return &LiftRepository{db: db}
+}
+
+// Insert stores a new LiftEntry.
+func (r *LiftRepository) Insert(ctx context.Context, entry *models.LiftEntry) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO lift_entries
+		(id, complejo_id, lift, weight, reps, e1rm, bodyweight, date, record, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		entry.ID, entry.ComplejoID, entry.Lift, entry.Weight, entry.Reps, entry.E1RM, entry.Bodyweight, entry.Date, entry.Record, entry.CreatedAt)
+	return rejected(err)
+}
+
+// FindByComplejo returns the entries of the Complejo with the given ID, of the lift (of every lift when empty),
+// oldest first.
+func (r *LiftRepository) FindByComplejo(ctx context.Context, complejoID, lift string) ([]models.LiftEntry, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT id, complejo_id, lift, weight, reps, e1rm, bodyweight, date, record, created_at
+		FROM lift_entries WHERE complejo_id = $1 AND ($2 = '' OR lift = $2) ORDER BY date, id`, complejoID, lift)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.LiftEntry{}
+	for rows.Next() {
+		var e models.LiftEntry
+		if err := rows.Scan(&e.ID, &e.ComplejoID, &e.Lift, &e.Weight, &e.Reps, &e.E1RM, &e.Bodyweight, &e.Date, &e.Record, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// MonthlyProgress aggregates the entries of the Complejo with the given ID by calendar month in the given time
+// zone, for the months with entries, oldest first.
+func (r *LiftRepository) MonthlyProgress(ctx context.Context, complejoID string, location *time.Location) ([]models.LiftMonth, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT to_char(date AT TIME ZONE $2, 'YYYY-MM') AS month,
+		COALESCE(ROUND(AVG(NULLIF(bodyweight, 0))::NUMERIC, 1)::DOUBLE PRECISION, 0),
+		MAX(CASE WHEN lift = 'bench' THEN weight ELSE 0 END),
+		MAX(CASE WHEN lift = 'squad' THEN weight ELSE 0 END),
+		MAX(CASE WHEN lift = 'dl' THEN weight ELSE 0 END)
+		FROM lift_entries WHERE complejo_id = $1 GROUP BY month ORDER BY month`, complejoID, location.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	months := []models.LiftMonth{}
+	for rows.Next() {
+		var m models.LiftMonth
+		if err := rows.Scan(&m.Month, &m.Bodyweight, &m.Bench, &m.Squad, &m.DL); err != nil {
+			return nil, err
+		}
+		months = append(months, m)
+	}
+	return months, rows.Err()
+}
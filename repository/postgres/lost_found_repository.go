@@ -0,0 +1,172 @@
+// lost_found_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const (
+	lostItemSelect = `SELECT id, kind, title, description, photo, posted_by, username, status, created_at, expires_at, resolved_at FROM lost_items`
+	claimSelect    = `SELECT id, item_id, claimant_id, username, message, status, note, decided_by, created_at, decided_at FROM lost_item_claims`
+)
+
+// LostFoundRepository is the PostgreSQL implementation of repository.LostFoundRepository.
+// Claims are removed with their post by the foreign key cascade.
+type LostFoundRepository struct {
+	db *sql.DB
+}
+
+// NewLostFoundRepository creates a LostFoundRepository backed by the given database.
+func NewLostFoundRepository(db *sql.DB) *LostFoundRepository {
+	return &LostFoundRepository{db: db}
+}
+
+// Insert stores a new LostItem.
+func (r *LostFoundRepository) Insert(ctx context.Context, item *models.LostItem) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO lost_items
+		(id, kind, title, description, photo, posted_by, username, status, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		item.ID, item.Kind, item.Title, item.Description, item.Photo, item.PostedBy, item.Username, item.Status,
+		item.CreatedAt, item.ExpiresAt)
+	return rejected(err)
+}
+
+// FindOpen returns the open posts not expired at now (of the given kind when not empty), newest first.
+func (r *LostFoundRepository) FindOpen(ctx context.Context, kind string, now time.Time) ([]models.LostItem, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, lostItemSelect+`
+		WHERE status = $1 AND expires_at > $2 AND ($3 = '' OR kind = $3)
+		ORDER BY created_at DESC, id`, models.LostItemOpen, now, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.LostItem{}
+	for rows.Next() {
+		item, err := scanLostItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	return items, rows.Err()
+}
+
+// FindByID returns the LostItem with the given ID, or repository.ErrNotFound.
+func (r *LostFoundRepository) FindByID(ctx context.Context, id string) (*models.LostItem, error) {
+	item, err := scanLostItem(conn(ctx, r.db).QueryRowContext(ctx, lostItemSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return item, err
+}
+
+// Release opens the held LostItem with the given ID and reports whether it was found.
+func (r *LostFoundRepository) Release(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE lost_items SET status = $3 WHERE id = $1 AND status = $2`, id, models.LostItemHeld, models.LostItemOpen))
+}
+
+// Resolve marks the open LostItem with the given ID as resolved and reports whether it was found.
+func (r *LostFoundRepository) Resolve(ctx context.Context, id string, at time.Time) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE lost_items SET status = $3, resolved_at = $4 WHERE id = $1 AND status = $2`,
+		id, models.LostItemOpen, models.LostItemResolved, at))
+}
+
+// DeleteByID removes the LostItem with the given ID and its claims, and reports whether it was found.
+func (r *LostFoundRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM lost_items WHERE id = $1`, id))
+}
+
+// PurgeExpired removes the posts expired at now and their claims, and returns how many posts were removed.
+func (r *LostFoundRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM lost_items WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// InsertClaim stores a new Claim.
+func (r *LostFoundRepository) InsertClaim(ctx context.Context, claim *models.Claim) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO lost_item_claims
+		(id, item_id, claimant_id, username, message, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		claim.ID, claim.ItemID, claim.ClaimantID, claim.Username, claim.Message, claim.Status, claim.CreatedAt)
+	return rejected(err)
+}
+
+// FindClaims returns the claims of the LostItem, oldest first.
+func (r *LostFoundRepository) FindClaims(ctx context.Context, itemID string) ([]models.Claim, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, claimSelect+` WHERE item_id = $1 ORDER BY created_at, id`, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claims := []models.Claim{}
+	for rows.Next() {
+		claim, err := scanClaim(rows)
+		if err != nil {
+			return nil, err
+		}
+		claims = append(claims, *claim)
+	}
+	return claims, rows.Err()
+}
+
+// DecideClaim stores the decision of the pending Claim and reports whether it was found.
+func (r *LostFoundRepository) DecideClaim(ctx context.Context, claim *models.Claim) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE lost_item_claims
+		SET status = $4, note = $5, decided_by = $6, decided_at = $7
+		WHERE id = $1 AND item_id = $2 AND status = $3`,
+		claim.ID, claim.ItemID, models.ClaimPending, claim.Status, claim.Note, claim.DecidedBy, claim.DecidedAt))
+}
+
+// RejectPending rejects the pending claims of the LostItem and returns how many were rejected.
+func (r *LostFoundRepository) RejectPending(ctx context.Context, itemID, decidedBy, note string, at time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE lost_item_claims
+		SET status = $3, note = $4, decided_by = $5, decided_at = $6
+		WHERE item_id = $1 AND status = $2`,
+		itemID, models.ClaimPending, models.ClaimRejected, note, decidedBy, at)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// scanLostItem reads a LostItem from a row produced by lostItemSelect.
+func scanLostItem(row rowScanner) (*models.LostItem, error) {
+	var i models.LostItem
+	var resolvedAt sql.NullTime
+	err := row.Scan(&i.ID, &i.Kind, &i.Title, &i.Description, &i.Photo, &i.PostedBy, &i.Username, &i.Status,
+		&i.CreatedAt, &i.ExpiresAt, &resolvedAt)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedAt.Valid {
+		i.ResolvedAt = &resolvedAt.Time
+	}
+	return &i, nil
+}
+
+// scanClaim reads a Claim from a row produced by claimSelect.
+func scanClaim(row rowScanner) (*models.Claim, error) {
+	var c models.Claim
+	var decidedAt sql.NullTime
+	err := row.Scan(&c.ID, &c.ItemID, &c.ClaimantID, &c.Username, &c.Message, &c.Status, &c.Note, &c.DecidedBy,
+		&c.CreatedAt, &decidedAt)
+	if err != nil {
+		return nil, err
+	}
+	if decidedAt.Valid {
+		c.DecidedAt = &decidedAt.Time
+	}
+	return &c, nil
+}
@@ -0,0 +1,35 @@
+-- 0001_init.sql
+-- Core tables for Complejos and Events, mirroring the MongoDB documents.
+
+CREATE TABLE IF NOT EXISTS complejos (
+    id       TEXT PRIMARY KEY,
+    username TEXT NOT NULL,
+    password TEXT NOT NULL,
+    role     TEXT NOT NULL,
+    weight   TEXT NOT NULL DEFAULT '',
+    height   TEXT NOT NULL DEFAULT '',
+    imc      TEXT NOT NULL DEFAULT '',
+    gender   TEXT NOT NULL DEFAULT '',
+    bench    TEXT NOT NULL DEFAULT '',
+    squad    TEXT NOT NULL DEFAULT '',
+    dl       TEXT NOT NULL DEFAULT '',
+    photo    TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS events (
+    id          TEXT PRIMARY KEY,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL,
+    date        TIMESTAMPTZ NOT NULL,
+    image       TEXT,
+    location    TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS event_participants (
+    event_id TEXT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    username TEXT NOT NULL,
+    PRIMARY KEY (event_id, username)
+);
+
+CREATE INDEX IF NOT EXISTS events_date_idx ON events (date);
+CREATE INDEX IF NOT EXISTS event_participants_username_idx ON event_participants (username);
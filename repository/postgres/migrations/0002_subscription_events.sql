@@ -0,0 +1,12 @@
+-- 0002_subscription_events.sql
+-- Append-only log of subscription transitions.
+
+CREATE TABLE IF NOT EXISTS subscription_events (
+    id          TEXT PRIMARY KEY,
+    event_id    TEXT NOT NULL,
+    username    TEXT NOT NULL,
+    type        TEXT NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS subscription_events_event_idx ON subscription_events (event_id, occurred_at);
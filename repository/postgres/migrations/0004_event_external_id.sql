@@ -0,0 +1,7 @@
+-- 0004_event_external_id.sql
+-- Events pushed by external producers are keyed by their external ID.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS external_id TEXT;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS external_updated_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS events_external_id_idx ON events (external_id) WHERE external_id IS NOT NULL;
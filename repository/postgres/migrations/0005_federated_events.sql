@@ -0,0 +1,16 @@
+-- 0005_federated_events.sql
+-- Public events of other clubs imported from the federation feed.
+
+CREATE TABLE IF NOT EXISTS federated_events (
+    id          TEXT PRIMARY KEY,
+    club        TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL,
+    date        TIMESTAMPTZ NOT NULL,
+    location    TEXT NOT NULL,
+    imported_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS federated_events_date_idx ON federated_events (date);
+CREATE INDEX IF NOT EXISTS federated_events_club_idx ON federated_events (club);
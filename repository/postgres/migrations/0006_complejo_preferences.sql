@@ -0,0 +1,5 @@
+-- 0006_complejo_preferences.sql
+-- Preferred locale and unit system of each Complejo.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT '';
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS units  TEXT NOT NULL DEFAULT '';
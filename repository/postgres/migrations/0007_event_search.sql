@@ -0,0 +1,10 @@
+-- 0007_event_search.sql
+-- Weighted full-text search over the title, location and description of events.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS search TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', location), 'B') ||
+    setweight(to_tsvector('simple', description), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS events_search_idx ON events USING GIN (search);
@@ -0,0 +1,4 @@
+-- 0008_event_created_by.sql
+-- Complejo that created each event, used to authorize deletions.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS created_by TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,17 @@
+-- 0009_request_journal.sql
+-- Anonymized envelopes of requests that failed with a 5xx status.
+
+CREATE TABLE IF NOT EXISTS request_journal (
+    id               TEXT PRIMARY KEY,
+    method           TEXT NOT NULL,
+    route            TEXT NOT NULL,
+    path             TEXT NOT NULL,
+    body_schema      TEXT NOT NULL,
+    body_schema_hash TEXT NOT NULL,
+    role             TEXT NOT NULL DEFAULT '',
+    status           INTEGER NOT NULL,
+    error            TEXT NOT NULL DEFAULT '',
+    occurred_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS request_journal_occurred_at_idx ON request_journal (occurred_at DESC);
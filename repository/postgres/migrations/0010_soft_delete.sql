@@ -0,0 +1,8 @@
+-- 0010_soft_delete.sql
+-- Deletion marks of Complejos and events, kept until the purge job removes them.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS complejos_deleted_at_idx ON complejos (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS events_deleted_at_idx ON events (deleted_at) WHERE deleted_at IS NOT NULL;
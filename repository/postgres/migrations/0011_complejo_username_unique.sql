@@ -0,0 +1,4 @@
+-- 0011_complejo_username_unique.sql
+-- Usernames are unique, including deleted Complejos so that a restore never clashes.
+
+CREATE UNIQUE INDEX IF NOT EXISTS complejos_username_key ON complejos (username);
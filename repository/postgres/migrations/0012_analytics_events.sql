@@ -0,0 +1,17 @@
+-- 0012_analytics_events.sql
+-- Lightweight client events (screen views, feature usage) tracked through POST /analytics/track.
+
+CREATE TABLE IF NOT EXISTS analytics_events (
+    id          TEXT PRIMARY KEY,
+    type        TEXT NOT NULL,
+    name        TEXT NOT NULL,
+    properties  JSONB NOT NULL DEFAULT '{}',
+    session_id  TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL,
+    tenant      TEXT NOT NULL,
+    complejo_id TEXT NOT NULL DEFAULT '',
+    received_at TIMESTAMPTZ NOT NULL,
+    sample_rate DOUBLE PRECISION NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS analytics_events_tenant_occurred_at_idx ON analytics_events (tenant, occurred_at);
@@ -0,0 +1,13 @@
+-- 0013_complejo_created_at.sql
+-- Sign-up time of Complejos, backfilled from their registration announcement in the outbox when available.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ;
+
+UPDATE complejos c
+SET created_at = o.created_at
+FROM outbox o
+WHERE o.topic = 'complejo.registered'
+  AND o.payload->>'_id' = c.id
+  AND c.created_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS complejos_created_at_idx ON complejos (created_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,6 @@
+-- 0014_complejo_churn_risk.sql
+-- Latest churn-risk score of each member, written by the scoring job.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS churn_risk JSONB;
+
+CREATE INDEX IF NOT EXISTS subscription_events_username_idx ON subscription_events (username, occurred_at);
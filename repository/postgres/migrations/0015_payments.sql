@@ -0,0 +1,14 @@
+-- 0015_payments.sql
+-- Card payments recorded from Stripe webhooks, for the finance summary.
+
+CREATE TABLE IF NOT EXISTS payments (
+    id              TEXT PRIMARY KEY,
+    event_id        TEXT NOT NULL DEFAULT '',
+    amount          BIGINT NOT NULL,
+    amount_refunded BIGINT NOT NULL DEFAULT 0,
+    currency        TEXT NOT NULL,
+    paid_at         TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS payments_paid_at_idx ON payments (paid_at);
@@ -0,0 +1,31 @@
+-- 0016_complejo_numeric_fields.sql
+-- Store the weight, height and lifts of Complejos as numbers. Empty or unparsable values become 0 (unknown).
+
+CREATE OR REPLACE FUNCTION pg_temp.to_number_or_zero(value TEXT) RETURNS DOUBLE PRECISION AS $$
+    SELECT CASE WHEN replace(trim(value), ',', '.') ~ '^[0-9]+(\.[0-9]+)?$'
+                THEN replace(trim(value), ',', '.')::DOUBLE PRECISION
+                ELSE 0 END
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE complejos
+    ALTER COLUMN weight DROP DEFAULT,
+    ALTER COLUMN height DROP DEFAULT,
+    ALTER COLUMN bench DROP DEFAULT,
+    ALTER COLUMN squad DROP DEFAULT,
+    ALTER COLUMN dl DROP DEFAULT;
+
+ALTER TABLE complejos
+    ALTER COLUMN weight TYPE DOUBLE PRECISION USING pg_temp.to_number_or_zero(weight),
+    ALTER COLUMN height TYPE DOUBLE PRECISION USING pg_temp.to_number_or_zero(height),
+    ALTER COLUMN bench TYPE DOUBLE PRECISION USING pg_temp.to_number_or_zero(bench),
+    ALTER COLUMN squad TYPE DOUBLE PRECISION USING pg_temp.to_number_or_zero(squad),
+    ALTER COLUMN dl TYPE DOUBLE PRECISION USING pg_temp.to_number_or_zero(dl);
+
+ALTER TABLE complejos
+    ALTER COLUMN weight SET DEFAULT 0,
+    ALTER COLUMN height SET DEFAULT 0,
+    ALTER COLUMN bench SET DEFAULT 0,
+    ALTER COLUMN squad SET DEFAULT 0,
+    ALTER COLUMN dl SET DEFAULT 0;
+
+DROP FUNCTION pg_temp.to_number_or_zero(TEXT);
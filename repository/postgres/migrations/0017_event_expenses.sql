@@ -0,0 +1,18 @@
+-- 0017_event_expenses.sql
+-- Expenses of events, netted against their payments in the finance summary.
+
+CREATE TABLE IF NOT EXISTS expenses (
+    id          TEXT PRIMARY KEY,
+    event_id    TEXT NOT NULL,
+    category    TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    amount      BIGINT NOT NULL,
+    currency    TEXT NOT NULL,
+    incurred_at TIMESTAMPTZ NOT NULL,
+    created_by  TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS expenses_event_idx ON expenses (event_id, incurred_at);
+CREATE INDEX IF NOT EXISTS expenses_incurred_at_idx ON expenses (incurred_at);
@@ -0,0 +1,31 @@
+-- 0018_inventory.sql
+-- Club equipment and the loans of it to Complejos.
+
+CREATE TABLE IF NOT EXISTS inventory_items (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    category    TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    quantity    INTEGER NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS inventory_items_name_idx ON inventory_items (name);
+
+CREATE TABLE IF NOT EXISTS loans (
+    id          TEXT PRIMARY KEY,
+    item_id     TEXT NOT NULL,
+    complejo_id TEXT NOT NULL,
+    username    TEXT NOT NULL,
+    quantity    INTEGER NOT NULL,
+    lent_by     TEXT NOT NULL DEFAULT '',
+    lent_at     TIMESTAMPTZ NOT NULL,
+    due_at      TIMESTAMPTZ NOT NULL,
+    returned_at TIMESTAMPTZ,
+    reminded_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS loans_open_idx ON loans (due_at) WHERE returned_at IS NULL;
+CREATE INDEX IF NOT EXISTS loans_item_idx ON loans (item_id, lent_at);
+CREATE INDEX IF NOT EXISTS loans_complejo_idx ON loans (complejo_id, lent_at);
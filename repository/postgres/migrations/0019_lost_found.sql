@@ -0,0 +1,34 @@
+-- 0019_lost_found.sql
+-- Lost-and-found board: posts expire after a while, and admins decide the claims on them.
+
+CREATE TABLE IF NOT EXISTS lost_items (
+    id          TEXT PRIMARY KEY,
+    kind        TEXT NOT NULL,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    photo       TEXT NOT NULL DEFAULT '',
+    posted_by   TEXT NOT NULL,
+    username    TEXT NOT NULL,
+    status      TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS lost_items_open_idx ON lost_items (status, created_at);
+CREATE INDEX IF NOT EXISTS lost_items_expires_at_idx ON lost_items (expires_at);
+
+CREATE TABLE IF NOT EXISTS lost_item_claims (
+    id          TEXT PRIMARY KEY,
+    item_id     TEXT NOT NULL REFERENCES lost_items (id) ON DELETE CASCADE,
+    claimant_id TEXT NOT NULL,
+    username    TEXT NOT NULL,
+    message     TEXT NOT NULL,
+    status      TEXT NOT NULL,
+    note        TEXT NOT NULL DEFAULT '',
+    decided_by  TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    decided_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS lost_item_claims_item_idx ON lost_item_claims (item_id, created_at);
@@ -0,0 +1,22 @@
+-- 0020_event_rsvps.sql
+-- Replace the participants of events (usernames) with RSVPs of Complejos: going, maybe or declined.
+-- Existing participants become "going" RSVPs; usernames no Complejo uses anymore are dropped.
+
+CREATE TABLE IF NOT EXISTS event_rsvps (
+    event_id     TEXT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    complejo_id  TEXT NOT NULL,
+    username     TEXT NOT NULL,
+    status       TEXT NOT NULL,
+    responded_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (event_id, complejo_id)
+);
+
+CREATE INDEX IF NOT EXISTS event_rsvps_complejo_idx ON event_rsvps (complejo_id);
+
+INSERT INTO event_rsvps (event_id, complejo_id, username, status, responded_at)
+SELECT p.event_id, c.id, p.username, 'going', now()
+FROM event_participants p
+JOIN complejos c ON c.username = p.username
+ON CONFLICT DO NOTHING;
+
+DROP TABLE event_participants;
@@ -0,0 +1,27 @@
+-- 0021_volunteers.sql
+-- Volunteer shifts of events (spotter, loader, scorer) and the Complejos signed up for them.
+
+CREATE TABLE IF NOT EXISTS volunteer_shifts (
+    id         TEXT PRIMARY KEY,
+    event_id   TEXT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    role       TEXT NOT NULL,
+    capacity   INTEGER NOT NULL,
+    starts_at  TIMESTAMPTZ NOT NULL,
+    ends_at    TIMESTAMPTZ NOT NULL,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS volunteer_shifts_event_idx ON volunteer_shifts (event_id, starts_at);
+CREATE INDEX IF NOT EXISTS volunteer_shifts_starts_at_idx ON volunteer_shifts (starts_at);
+
+CREATE TABLE IF NOT EXISTS volunteer_signups (
+    shift_id     TEXT NOT NULL REFERENCES volunteer_shifts (id) ON DELETE CASCADE,
+    complejo_id  TEXT NOT NULL,
+    username     TEXT NOT NULL,
+    signed_up_at TIMESTAMPTZ NOT NULL,
+    reminded_at  TIMESTAMPTZ,
+    PRIMARY KEY (shift_id, complejo_id)
+);
+
+CREATE INDEX IF NOT EXISTS volunteer_signups_complejo_idx ON volunteer_signups (complejo_id);
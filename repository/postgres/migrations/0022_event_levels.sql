@@ -0,0 +1,8 @@
+-- 0022_event_levels.sql
+-- Skill level and intensity of events, and the level gate warning or blocking beginners from advanced sessions
+-- unless the organizer lets them through.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS level TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS intensity TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS level_gate TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS level_overrides TEXT[] NOT NULL DEFAULT '{}';
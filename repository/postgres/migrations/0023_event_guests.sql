@@ -0,0 +1,17 @@
+-- 0023_event_guests.sql
+-- Capacity of events, and the guests members bring to them (counted against the capacity
+-- and the monthly guest passes of their host).
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS capacity INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS event_guests (
+    id            TEXT PRIMARY KEY,
+    event_id      TEXT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    name          TEXT NOT NULL,
+    host_id       TEXT NOT NULL,
+    host_username TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS event_guests_event_idx ON event_guests (event_id, created_at);
+CREATE INDEX IF NOT EXISTS event_guests_host_idx ON event_guests (host_id, created_at);
@@ -0,0 +1,15 @@
+-- 0024_guest_invitations.sql
+-- Invitations of guests to join as Complejos, looked up by the hash of the token of their link.
+
+CREATE TABLE IF NOT EXISTS guest_invitations (
+    id          TEXT PRIMARY KEY,
+    token_hash  TEXT NOT NULL UNIQUE,
+    event_id    TEXT NOT NULL,
+    guest_id    TEXT NOT NULL,
+    name        TEXT NOT NULL,
+    host_id     TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    complejo_id TEXT NOT NULL DEFAULT ''
+);
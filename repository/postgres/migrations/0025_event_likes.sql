@@ -0,0 +1,9 @@
+-- 0025_event_likes.sql
+-- Likes of events by Complejos.
+
+CREATE TABLE IF NOT EXISTS event_likes (
+    event_id    TEXT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    complejo_id TEXT NOT NULL,
+    liked_at    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (event_id, complejo_id)
+);
@@ -0,0 +1,20 @@
+-- 0026_content_holds.sql
+-- User content flagged by the content filter, waiting for an admin to approve or reject it.
+
+CREATE TABLE IF NOT EXISTS content_holds (
+    id         TEXT PRIMARY KEY,
+    kind       TEXT NOT NULL,
+    target_id  TEXT NOT NULL,
+    author_id  TEXT NOT NULL,
+    text       TEXT NOT NULL,
+    previous   TEXT NOT NULL DEFAULT '',
+    locale     TEXT NOT NULL,
+    reasons    TEXT[] NOT NULL DEFAULT '{}',
+    status     TEXT NOT NULL,
+    note       TEXT NOT NULL DEFAULT '',
+    decided_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    decided_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS content_holds_status_idx ON content_holds (status, created_at);
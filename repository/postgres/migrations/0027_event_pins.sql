@@ -0,0 +1,7 @@
+-- 0027_event_pins.sql
+-- Admins pin events to the top of the listings until the pin expires, and feature them on the public site.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS pinned_until TIMESTAMPTZ;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS featured BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS events_featured_idx ON events (date) WHERE featured AND deleted_at IS NULL;
@@ -0,0 +1,9 @@
+-- 0028_event_weather.sql
+-- Outdoor events cache the weather forecast of their date and location, and remember when their participants
+-- were warned of severe weather.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS outdoor BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS weather JSONB;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS weather_warned_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS events_outdoor_idx ON events (date) WHERE outdoor AND weather_warned_at IS NULL AND deleted_at IS NULL;
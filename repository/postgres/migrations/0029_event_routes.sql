@@ -0,0 +1,4 @@
+-- 0029_event_routes.sql
+-- Summary of the GPX route of outdoor events; the GPX file itself is kept in the object store.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS route JSONB;
@@ -0,0 +1,32 @@
+-- 0030_event_photos.sql
+-- Photo albums of events, the Complejos tagged in their photos and whether Complejos may be tagged at all.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS photo_consent TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS event_photos (
+    id           TEXT PRIMARY KEY,
+    event_id     TEXT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    caption      TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL,
+    width        INTEGER NOT NULL,
+    height       INTEGER NOT NULL,
+    thumbnail    TEXT NOT NULL DEFAULT '',
+    key          TEXT NOT NULL,
+    status       TEXT NOT NULL,
+    uploaded_by  TEXT NOT NULL,
+    username     TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    approved_by  TEXT NOT NULL DEFAULT '',
+    approved_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS event_photos_event_idx ON event_photos (event_id, created_at);
+
+CREATE TABLE IF NOT EXISTS event_photo_tags (
+    photo_id    TEXT NOT NULL REFERENCES event_photos (id) ON DELETE CASCADE,
+    complejo_id TEXT NOT NULL,
+    username    TEXT NOT NULL,
+    consent     TEXT NOT NULL,
+    decided_at  TIMESTAMPTZ,
+    PRIMARY KEY (photo_id, complejo_id)
+);
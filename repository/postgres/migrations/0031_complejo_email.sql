@@ -0,0 +1,4 @@
+-- 0031_complejo_email.sql
+-- Email address of the Complejos, where the notification emails are sent.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS email TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,30 @@
+-- 0032_push.sql
+-- Devices registered for push notifications, announcements to every Complejo and event reminders.
+
+CREATE TABLE IF NOT EXISTS devices (
+    id          TEXT PRIMARY KEY,
+    complejo_id TEXT NOT NULL,
+    platform    TEXT NOT NULL,
+    token       TEXT NOT NULL UNIQUE,
+    p256dh      TEXT NOT NULL DEFAULT '',
+    auth        TEXT NOT NULL DEFAULT '',
+    name        TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS devices_complejo_idx ON devices (complejo_id);
+
+CREATE TABLE IF NOT EXISTS announcements (
+    id         TEXT PRIMARY KEY,
+    title      TEXT NOT NULL,
+    body       TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS announcements_created_at_idx ON announcements (created_at DESC);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS events_unreminded_idx ON events (date) WHERE reminded_at IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,25 @@
+-- 0033_content_versions.sql
+-- Version history of the announcements and terms documents.
+
+ALTER TABLE announcements ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE announcements ADD COLUMN IF NOT EXISTS updated_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE announcements ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS content_versions (
+    id            TEXT PRIMARY KEY,
+    document_type TEXT NOT NULL,
+    document_id   TEXT NOT NULL,
+    version       INTEGER NOT NULL,
+    title         TEXT NOT NULL,
+    body          TEXT NOT NULL,
+    diff          TEXT NOT NULL DEFAULT '',
+    created_by    TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL,
+    restored_from INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (document_type, document_id, version)
+);
+
+-- The versions of each announcement published before versioning start from its current content
+INSERT INTO content_versions (id, document_type, document_id, version, title, body, created_by, created_at)
+SELECT 'announcement-' || id, 'announcement', id, 1, title, body, created_by, created_at FROM announcements
+ON CONFLICT DO NOTHING;
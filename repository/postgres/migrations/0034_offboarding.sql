@@ -0,0 +1,14 @@
+-- 0034_offboarding.sql
+-- Offboarding of the club: a single row, kept when the other tables are purged.
+
+CREATE TABLE IF NOT EXISTS offboarding (
+    id           BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    status       TEXT NOT NULL,
+    requested_by TEXT NOT NULL,
+    requested_at TIMESTAMPTZ NOT NULL,
+    purge_after  TIMESTAMPTZ NOT NULL,
+    code_hash    TEXT NOT NULL,
+    exported_at  TIMESTAMPTZ,
+    purged_by    TEXT NOT NULL DEFAULT '',
+    purged_at    TIMESTAMPTZ
+);
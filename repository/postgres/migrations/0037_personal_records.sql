@@ -0,0 +1,13 @@
+-- 0037_personal_records.sql
+-- Log of the lift records set by the Complejos, totalled by month for the public homepage.
+
+CREATE TABLE IF NOT EXISTS personal_records (
+    id          TEXT PRIMARY KEY,
+    complejo_id TEXT NOT NULL,
+    lift        TEXT NOT NULL,
+    previous    DOUBLE PRECISION NOT NULL,
+    value       DOUBLE PRECISION NOT NULL,
+    achieved_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS personal_records_achieved_at_idx ON personal_records (achieved_at);
@@ -0,0 +1,4 @@
+-- 0038_complejo_photo_id.sql
+-- Profile photos kept in the object store, referenced by ID instead of stored base64-encoded in the row.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS photo_id TEXT NOT NULL DEFAULT '';
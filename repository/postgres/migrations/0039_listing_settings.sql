@@ -0,0 +1,11 @@
+-- 0039_listing_settings.sql
+-- Defaults of the event listing chosen by the admins of the club: a single row.
+
+CREATE TABLE IF NOT EXISTS listing_settings (
+    id                   BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    hide_past_after_days INTEGER NOT NULL,
+    sort                 TEXT NOT NULL,
+    page_size            INTEGER NOT NULL,
+    updated_by           TEXT NOT NULL,
+    updated_at           TIMESTAMPTZ
+);
@@ -0,0 +1,26 @@
+-- 0040_service_accounts.sql
+-- Service accounts of the integrations of the club and their rotating tokens, stored hashed.
+
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id            TEXT PRIMARY KEY,
+    name          TEXT NOT NULL UNIQUE,
+    role          TEXT NOT NULL,
+    rotation_days INTEGER NOT NULL,
+    overlap_hours INTEGER NOT NULL,
+    active        BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by    TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS service_tokens (
+    id         TEXT PRIMARY KEY,
+    account_id TEXT NOT NULL REFERENCES service_accounts (id) ON DELETE CASCADE,
+    hash       TEXT NOT NULL UNIQUE,
+    hint       TEXT NOT NULL,
+    issued_at  TIMESTAMPTZ NOT NULL,
+    rotate_at  TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS service_tokens_account_idx ON service_tokens (account_id, expires_at);
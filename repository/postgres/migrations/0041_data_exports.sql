@@ -0,0 +1,15 @@
+-- 0041_data_exports.sql
+-- Exports of their data requested by the Complejos; the archives are kept in the object store until they expire.
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id           TEXT PRIMARY KEY,
+    complejo_id  TEXT NOT NULL,
+    status       TEXT NOT NULL,
+    requested_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ,
+    size         BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS data_exports_complejo_idx ON data_exports (complejo_id, requested_at);
+CREATE INDEX IF NOT EXISTS data_exports_expires_idx ON data_exports (expires_at);
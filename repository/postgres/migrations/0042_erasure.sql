@@ -0,0 +1,17 @@
+-- 0042_erasure.sql
+-- Erasure of the personal data of Complejos: erased Complejos are marked so they cannot be restored, and the
+-- erasures are recorded in the audit log, which is kept after the data it concerns is gone.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS erased_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          TEXT PRIMARY KEY,
+    action      TEXT NOT NULL,
+    actor_id    TEXT NOT NULL,
+    target_id   TEXT NOT NULL,
+    details     JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_action_idx ON audit_log (action, occurred_at);
+CREATE INDEX IF NOT EXISTS audit_log_occurred_at_idx ON audit_log (occurred_at);
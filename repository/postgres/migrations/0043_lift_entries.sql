@@ -0,0 +1,14 @@
+-- 0043_lift_entries.sql
+-- Lift history of the Complejos; the best weight of each lift stays on the complejos row.
+
+CREATE TABLE IF NOT EXISTS lift_entries (
+    id          TEXT PRIMARY KEY,
+    complejo_id TEXT NOT NULL,
+    lift        TEXT NOT NULL CHECK (lift IN ('bench', 'squad', 'dl')),
+    weight      DOUBLE PRECISION NOT NULL CHECK (weight > 0),
+    date        TIMESTAMPTZ NOT NULL,
+    record      BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS lift_entries_complejo_idx ON lift_entries (complejo_id, lift, date);
@@ -0,0 +1,4 @@
+-- 0044_lift_bodyweight.sql
+-- Bodyweight of the Complejos recorded with their lifts, for the monthly progress statistics.
+
+ALTER TABLE lift_entries ADD COLUMN IF NOT EXISTS bodyweight DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+-- 0045_complejo_imc.sql
+-- Store the IMC of Complejos as JSON with its value and category, NULL when the weight or height is unknown.
+-- The category stored with the value is kept.
+
+ALTER TABLE complejos
+    ALTER COLUMN imc DROP DEFAULT,
+    ALTER COLUMN imc DROP NOT NULL;
+
+ALTER TABLE complejos
+    ALTER COLUMN imc TYPE JSONB USING CASE
+        WHEN weight > 0 AND height > 0 AND imc NOT IN ('', 'N/A')
+        THEN jsonb_build_object('value', ROUND((weight / (height * height))::NUMERIC, 1), 'category', imc)
+    END;
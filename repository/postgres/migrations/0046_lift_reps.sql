@@ -0,0 +1,10 @@
+-- 0046_lift_reps.sql
+-- Repetitions and estimated one-rep max of the lifts; the lifts recorded before were singles.
+
+ALTER TABLE lift_entries
+    ADD COLUMN IF NOT EXISTS reps INTEGER NOT NULL DEFAULT 1 CHECK (reps > 0),
+    ADD COLUMN IF NOT EXISTS e1rm DOUBLE PRECISION;
+
+UPDATE lift_entries SET e1rm = weight WHERE e1rm IS NULL;
+
+ALTER TABLE lift_entries ALTER COLUMN e1rm SET NOT NULL;
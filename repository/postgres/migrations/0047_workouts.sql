@@ -0,0 +1,14 @@
+-- 0047_workouts.sql
+-- Workouts logged by the Complejos, with their exercises and sets as JSON.
+
+CREATE TABLE IF NOT EXISTS workouts (
+    id          TEXT PRIMARY KEY,
+    complejo_id TEXT NOT NULL,
+    date        TIMESTAMPTZ NOT NULL,
+    exercises   JSONB NOT NULL DEFAULT '[]',
+    notes       TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS workouts_complejo_idx ON workouts (complejo_id, date);
@@ -0,0 +1,12 @@
+-- 0048_exercises.sql
+-- Exercise library the workouts are logged with.
+
+CREATE TABLE IF NOT EXISTS exercises (
+    id            TEXT PRIMARY KEY,
+    name          TEXT NOT NULL UNIQUE,
+    muscle_groups TEXT[] NOT NULL DEFAULT '{}',
+    equipment     TEXT NOT NULL,
+    video_url     TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,18 @@
+-- 0049_nutrition.sql
+-- Daily nutrition logs of the Complejos, and the goal of their nutrition targets.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS goal TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS nutrition_logs (
+    id          TEXT PRIMARY KEY,
+    complejo_id TEXT NOT NULL,
+    date        TEXT NOT NULL,
+    calories    INTEGER NOT NULL DEFAULT 0,
+    protein     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    carbs       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fat         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    notes       TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL,
+    UNIQUE (complejo_id, date)
+);
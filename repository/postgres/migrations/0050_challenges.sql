@@ -0,0 +1,25 @@
+-- 0050_challenges.sql
+-- Challenges between the Complejos, and their participants with their scores.
+
+CREATE TABLE IF NOT EXISTS challenges (
+    id          TEXT PRIMARY KEY,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    metric      TEXT NOT NULL,
+    starts_at   TIMESTAMPTZ NOT NULL,
+    ends_at     TIMESTAMPTZ NOT NULL,
+    created_by  TEXT NOT NULL,
+    scored_at   TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS challenges_starts_at_idx ON challenges (starts_at DESC);
+
+CREATE TABLE IF NOT EXISTS challenge_participants (
+    id           TEXT PRIMARY KEY,
+    challenge_id TEXT NOT NULL REFERENCES challenges (id) ON DELETE CASCADE,
+    complejo_id  TEXT NOT NULL,
+    score        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    joined_at    TIMESTAMPTZ NOT NULL,
+    UNIQUE (challenge_id, complejo_id)
+);
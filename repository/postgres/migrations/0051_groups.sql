@@ -0,0 +1,28 @@
+-- 0051_groups.sql
+-- Groups of Complejos with the roles of their members, and the group each event belongs to.
+
+CREATE TABLE IF NOT EXISTS groups (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by  TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS groups_name_idx ON groups (name);
+
+CREATE TABLE IF NOT EXISTS group_memberships (
+    id          TEXT PRIMARY KEY,
+    group_id    TEXT NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+    complejo_id TEXT NOT NULL,
+    role        TEXT NOT NULL,
+    joined_at   TIMESTAMPTZ NOT NULL,
+    UNIQUE (group_id, complejo_id)
+);
+
+CREATE INDEX IF NOT EXISTS group_memberships_complejo_id_idx ON group_memberships (complejo_id);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS group_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS events_group_id_idx ON events (group_id) WHERE group_id <> '';
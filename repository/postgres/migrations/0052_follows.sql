@@ -0,0 +1,12 @@
+-- 0052_follows.sql
+-- Complejos following other Complejos.
+
+CREATE TABLE IF NOT EXISTS follows (
+    id          TEXT PRIMARY KEY,
+    follower_id TEXT NOT NULL,
+    followee_id TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    UNIQUE (follower_id, followee_id)
+);
+
+CREATE INDEX IF NOT EXISTS follows_followee_id_idx ON follows (followee_id, created_at DESC);
@@ -0,0 +1,20 @@
+-- 0053_feed_items.sql
+-- Activity feed of the Complejos and of the groups, denormalized from the domain events.
+
+CREATE TABLE IF NOT EXISTS feed_items (
+    id           TEXT PRIMARY KEY,
+    kind         TEXT NOT NULL,
+    complejo_id  TEXT NOT NULL DEFAULT '',
+    username     TEXT NOT NULL DEFAULT '',
+    group_id     TEXT NOT NULL DEFAULT '',
+    event_id     TEXT NOT NULL DEFAULT '',
+    challenge_id TEXT NOT NULL DEFAULT '',
+    title        TEXT NOT NULL DEFAULT '',
+    lift         TEXT NOT NULL DEFAULT '',
+    value        DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS feed_items_complejo_id_idx ON feed_items (complejo_id, created_at DESC) WHERE complejo_id <> '';
+CREATE INDEX IF NOT EXISTS feed_items_group_id_idx ON feed_items (group_id, created_at DESC) WHERE group_id <> '';
+CREATE INDEX IF NOT EXISTS feed_items_event_id_idx ON feed_items (event_id) WHERE event_id <> '';
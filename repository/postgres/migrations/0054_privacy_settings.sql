@@ -0,0 +1,11 @@
+-- 0054_privacy_settings.sql
+-- Audiences the Complejos chose for the fields of their profile.
+
+CREATE TABLE IF NOT EXISTS privacy_settings (
+    complejo_id TEXT PRIMARY KEY,
+    weight      TEXT NOT NULL,
+    height      TEXT NOT NULL,
+    imc         TEXT NOT NULL,
+    photo       TEXT NOT NULL,
+    updated_at  TIMESTAMPTZ
+);
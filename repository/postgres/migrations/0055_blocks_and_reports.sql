@@ -0,0 +1,33 @@
+-- 0055_blocks_and_reports.sql
+-- Complejos blocking other Complejos, and content reported by the users waiting for an admin to review it.
+
+CREATE TABLE IF NOT EXISTS blocks (
+    id         TEXT PRIMARY KEY,
+    blocker_id TEXT NOT NULL,
+    blocked_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (blocker_id, blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS blocks_blocked_id_idx ON blocks (blocked_id);
+
+CREATE TABLE IF NOT EXISTS content_reports (
+    id          TEXT PRIMARY KEY,
+    kind        TEXT NOT NULL,
+    target_id   TEXT NOT NULL,
+    reporter_id TEXT NOT NULL,
+    reason      TEXT NOT NULL,
+    details     TEXT NOT NULL DEFAULT '',
+    status      TEXT NOT NULL,
+    note        TEXT NOT NULL DEFAULT '',
+    decided_by  TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    decided_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS content_reports_status_idx ON content_reports (status, created_at);
+CREATE INDEX IF NOT EXISTS content_reports_reporter_id_idx ON content_reports (reporter_id);
+
+-- A reporter has at most one pending report of the same content
+CREATE UNIQUE INDEX IF NOT EXISTS content_reports_pending_idx ON content_reports (reporter_id, kind, target_id)
+    WHERE status = 'pending';
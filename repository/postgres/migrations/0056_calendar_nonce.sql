@@ -0,0 +1,4 @@
+-- 0056_calendar_nonce.sql
+-- Random part of the personal calendar feed token of each Complejo, replaced to revoke the feed links.
+
+ALTER TABLE complejos ADD COLUMN IF NOT EXISTS calendar_nonce TEXT NOT NULL DEFAULT '';
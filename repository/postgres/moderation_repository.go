@@ -0,0 +1,86 @@
+// moderation_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/lib/pq"
+)
+
+const holdSelect = `SELECT id, kind, target_id, author_id, text, previous, locale, reasons, status, note, decided_by,
+	created_at, decided_at FROM content_holds`
+
+// ModerationRepository is the PostgreSQL implementation of repository.ModerationRepository.
+type ModerationRepository struct {
+	db *sql.DB
+}
+
+// NewModerationRepository creates a ModerationRepository backed by the given database.
+func NewModerationRepository(db *sql.DB) *ModerationRepository {
+	return &ModerationRepository{db: db}
+}
+
+// Insert stores a new ContentHold.
+func (r *ModerationRepository) Insert(ctx context.Context, hold *models.ContentHold) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO content_holds
+		(id, kind, target_id, author_id, text, previous, locale, reasons, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		hold.ID, hold.Kind, hold.TargetID, hold.AuthorID, hold.Text, hold.Previous, hold.Locale, pq.Array(hold.Reasons),
+		hold.Status, hold.CreatedAt)
+	return rejected(err)
+}
+
+// FindByStatus returns the holds with the given status, oldest first.
+func (r *ModerationRepository) FindByStatus(ctx context.Context, status string) ([]models.ContentHold, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, holdSelect+` WHERE status = $1 ORDER BY created_at, id`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holds := []models.ContentHold{}
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, *hold)
+	}
+	return holds, rows.Err()
+}
+
+// FindByID returns the ContentHold with the given ID, or repository.ErrNotFound.
+func (r *ModerationRepository) FindByID(ctx context.Context, id string) (*models.ContentHold, error) {
+	hold, err := scanHold(conn(ctx, r.db).QueryRowContext(ctx, holdSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return hold, err
+}
+
+// Decide stores the decision of the pending ContentHold and reports whether it was found.
+func (r *ModerationRepository) Decide(ctx context.Context, hold *models.ContentHold) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE content_holds
+		SET status = $3, note = $4, decided_by = $5, decided_at = $6
+		WHERE id = $1 AND status = $2`,
+		hold.ID, models.HoldPending, hold.Status, hold.Note, hold.DecidedBy, hold.DecidedAt))
+}
+
+// scanHold reads a ContentHold from a row produced by holdSelect.
+func scanHold(row rowScanner) (*models.ContentHold, error) {
+	var h models.ContentHold
+	var decidedAt sql.NullTime
+	err := row.Scan(&h.ID, &h.Kind, &h.TargetID, &h.AuthorID, &h.Text, &h.Previous, &h.Locale, pq.Array(&h.Reasons),
+		&h.Status, &h.Note, &h.DecidedBy, &h.CreatedAt, &decidedAt)
+	if err != nil {
+		return nil, err
+	}
+	if decidedAt.Valid {
+		h.DecidedAt = &decidedAt.Time
+	}
+	return &h, nil
+}
@@ -0,0 +1,85 @@
+// nutrition_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const nutritionSelect = `SELECT id, complejo_id, date, calories, protein, carbs, fat, notes, created_at, updated_at FROM nutrition_logs`
+
+// NutritionRepository is the PostgreSQL implementation of repository.NutritionRepository.
+type NutritionRepository struct {
+	db *sql.DB
+}
+
+// NewNutritionRepository creates a NutritionRepository backed by the given database.
+func NewNutritionRepository(db *sql.DB) *NutritionRepository {
+	return &NutritionRepository{db: db}
+}
+
+// Insert stores a new NutritionLog, or returns repository.ErrDuplicate when the Complejo already logged the day.
+func (r *NutritionRepository) Insert(ctx context.Context, log *models.NutritionLog) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO nutrition_logs
+		(id, complejo_id, date, calories, protein, carbs, fat, notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		log.ID, log.ComplejoID, log.Date, log.Calories, log.Protein, log.Carbs, log.Fat, log.Notes, log.CreatedAt, log.UpdatedAt)
+	return rejected(err)
+}
+
+// FindByID returns the NutritionLog with the given ID, or repository.ErrNotFound.
+func (r *NutritionRepository) FindByID(ctx context.Context, id string) (*models.NutritionLog, error) {
+	log, err := scanNutritionLog(conn(ctx, r.db).QueryRowContext(ctx, nutritionSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return log, err
+}
+
+// FindByComplejo returns the nutrition logs of the Complejo with the given ID from the day from to the day to
+// ("2006-01-02", unbounded when empty), oldest first.
+func (r *NutritionRepository) FindByComplejo(ctx context.Context, complejoID, from, to string) ([]models.NutritionLog, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, nutritionSelect+` WHERE complejo_id = $1
+		AND ($2 = '' OR date >= $2) AND ($3 = '' OR date <= $3) ORDER BY date`, complejoID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []models.NutritionLog{}
+	for rows.Next() {
+		log, err := scanNutritionLog(rows)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, *log)
+	}
+	return logs, rows.Err()
+}
+
+// Replace overwrites the stored NutritionLog with the same ID and reports whether it was found, or returns
+// repository.ErrDuplicate when the Complejo already logged its new day.
+func (r *NutritionRepository) Replace(ctx context.Context, log *models.NutritionLog) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE nutrition_logs
+		SET date = $2, calories = $3, protein = $4, carbs = $5, fat = $6, notes = $7, updated_at = $8
+		WHERE id = $1`,
+		log.ID, log.Date, log.Calories, log.Protein, log.Carbs, log.Fat, log.Notes, log.UpdatedAt))
+}
+
+// DeleteByID removes the NutritionLog with the given ID and reports whether it was found.
+func (r *NutritionRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM nutrition_logs WHERE id = $1`, id))
+}
+
+// scanNutritionLog reads a NutritionLog from a row produced by nutritionSelect.
+func scanNutritionLog(row rowScanner) (*models.NutritionLog, error) {
+	var l models.NutritionLog
+	err := row.Scan(&l.ID, &l.ComplejoID, &l.Date, &l.Calories, &l.Protein, &l.Carbs, &l.Fat, &l.Notes, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
@@ -0,0 +1,111 @@
+// offboarding_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/lib/pq"
+)
+
+// OffboardingRepository is the PostgreSQL implementation of repository.OffboardingRepository.
+type OffboardingRepository struct {
+	db *sql.DB
+}
+
+// NewOffboardingRepository creates an OffboardingRepository backed by the given database.
+func NewOffboardingRepository(db *sql.DB) *OffboardingRepository {
+	return &OffboardingRepository{db: db}
+}
+
+// Find returns the Offboarding, or ErrNotFound.
+func (r *OffboardingRepository) Find(ctx context.Context) (*models.Offboarding, error) {
+	var o models.Offboarding
+	var exportedAt, purgedAt sql.NullTime
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT status, requested_by, requested_at, purge_after, code_hash,
+		exported_at, purged_by, purged_at FROM offboarding`).
+		Scan(&o.Status, &o.RequestedBy, &o.RequestedAt, &o.PurgeAfter, &o.CodeHash, &exportedAt, &o.PurgedBy, &purgedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if exportedAt.Valid {
+		o.ExportedAt = &exportedAt.Time
+	}
+	if purgedAt.Valid {
+		o.PurgedAt = &purgedAt.Time
+	}
+	return &o, nil
+}
+
+// Save stores the Offboarding, replacing the current one.
+func (r *OffboardingRepository) Save(ctx context.Context, o *models.Offboarding) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO offboarding
+		(status, requested_by, requested_at, purge_after, code_hash, exported_at, purged_by, purged_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET status = EXCLUDED.status, requested_by = EXCLUDED.requested_by,
+		requested_at = EXCLUDED.requested_at, purge_after = EXCLUDED.purge_after, code_hash = EXCLUDED.code_hash,
+		exported_at = EXCLUDED.exported_at, purged_by = EXCLUDED.purged_by, purged_at = EXCLUDED.purged_at`,
+		o.Status, o.RequestedBy, o.RequestedAt, o.PurgeAfter, o.CodeHash, o.ExportedAt, o.PurgedBy, o.PurgedAt)
+	return rejected(err)
+}
+
+// Delete removes the Offboarding and reports whether there was one.
+func (r *OffboardingRepository) Delete(ctx context.Context) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM offboarding`))
+}
+
+// Purge empties every table of the schema but the offboarding and the applied migrations, in a single
+// transaction. The tables and their indexes are kept.
+func (r *OffboardingRepository) Purge(ctx context.Context) (map[string]int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT table_name FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'
+		AND table_name NOT IN ('offboarding', 'schema_migrations') ORDER BY table_name`)
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	removed := make(map[string]int64, len(tables))
+	if len(tables) == 0 {
+		return removed, tx.Commit()
+	}
+	quoted := make([]string, len(tables))
+	for i, table := range tables {
+		quoted[i] = pq.QuoteIdentifier(table)
+		var count int64
+		if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM `+quoted[i]).Scan(&count); err != nil {
+			return nil, err
+		}
+		removed[table] = count
+	}
+	// A single TRUNCATE of every table is not blocked by the foreign keys between them
+	if _, err := tx.ExecContext(ctx, `TRUNCATE `+strings.Join(quoted, ", ")); err != nil {
+		return nil, err
+	}
+	return removed, tx.Commit()
+}
@@ -0,0 +1,74 @@
+// outbox_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/lib/pq"
+)
+
+// OutboxRepository is the PostgreSQL implementation of repository.OutboxRepository.
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository creates an OutboxRepository backed by the given database.
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Enqueue stores a new pending message.
+func (r *OutboxRepository) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO outbox (id, topic, payload, created_at, next_attempt_at, attempts)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		message.ID, message.Topic, []byte(message.Payload), message.CreatedAt, message.NextAttemptAt, message.Attempts)
+	return err
+}
+
+// Claim atomically picks the oldest due message of the given topics and leases it.
+func (r *OutboxRepository) Claim(ctx context.Context, topics []string, now time.Time, lease time.Duration, maxAttempts int) (*models.OutboxMessage, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE outbox SET attempts = attempts + 1, next_attempt_at = $3
+		WHERE id = (
+			SELECT id FROM outbox
+			WHERE dispatched_at IS NULL AND topic = ANY($1) AND next_attempt_at <= $2 AND attempts < $4
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, topic, payload, created_at, next_attempt_at, attempts, last_error, dispatched_at`,
+		pq.Array(topics), now, now.Add(lease), maxAttempts)
+
+	var message models.OutboxMessage
+	var payload []byte
+	var dispatchedAt sql.NullTime
+	err := row.Scan(&message.ID, &message.Topic, &payload, &message.CreatedAt, &message.NextAttemptAt,
+		&message.Attempts, &message.LastError, &dispatchedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	message.Payload = payload
+	if dispatchedAt.Valid {
+		message.DispatchedAt = &dispatchedAt.Time
+	}
+	return &message, nil
+}
+
+// MarkDispatched records the successful delivery of the message.
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, id string, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE outbox SET dispatched_at = $2, last_error = '' WHERE id = $1`, id, at)
+	return err
+}
+
+// MarkFailed records a failed delivery and schedules the next attempt.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id, reason string, retryAt time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE outbox SET last_error = $2, next_attempt_at = $3 WHERE id = $1`, id, reason, retryAt)
+	return err
+}
@@ -0,0 +1,60 @@
+// payment_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// PaymentRepository is the PostgreSQL implementation of repository.PaymentRepository.
+type PaymentRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentRepository creates a PaymentRepository backed by the given database.
+func NewPaymentRepository(db *sql.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+// Upsert stores the payment, or updates the stored one with the same ID. The refunded amount never decreases,
+// so webhooks delivered out of order cannot undo a refund.
+func (r *PaymentRepository) Upsert(ctx context.Context, payment *models.Payment) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO payments (id, event_id, amount, amount_refunded, currency, paid_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE SET
+			event_id = EXCLUDED.event_id,
+			amount = EXCLUDED.amount,
+			amount_refunded = GREATEST(payments.amount_refunded, EXCLUDED.amount_refunded),
+			currency = EXCLUDED.currency,
+			paid_at = EXCLUDED.paid_at,
+			updated_at = EXCLUDED.updated_at`,
+		payment.ID, payment.EventID, payment.Amount, payment.AmountRefunded, payment.Currency, payment.PaidAt, payment.UpdatedAt)
+	return err
+}
+
+// Revenue aggregates the payments made between from and to by Event, month (in the given location) and currency.
+func (r *PaymentRepository) Revenue(ctx context.Context, from, to time.Time, location *time.Location) ([]models.RevenueRow, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT event_id, to_char(paid_at AT TIME ZONE $3, 'YYYY-MM'), currency, COUNT(*), SUM(amount), SUM(amount_refunded)
+		FROM payments
+		WHERE paid_at >= $1 AND paid_at < $2
+		GROUP BY 1, 2, 3`, from, to, location.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revenue []models.RevenueRow
+	for rows.Next() {
+		var row models.RevenueRow
+		if err := rows.Scan(&row.EventID, &row.Month, &row.Currency, &row.Payments, &row.Gross, &row.Refunded); err != nil {
+			return nil, err
+		}
+		revenue = append(revenue, row)
+	}
+	return revenue, rows.Err()
+}
@@ -0,0 +1,35 @@
+// personal_record_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// PersonalRecordRepository is the PostgreSQL implementation of repository.PersonalRecordRepository.
+type PersonalRecordRepository struct {
+	db *sql.DB
+}
+
+// NewPersonalRecordRepository creates a PersonalRecordRepository backed by the given database.
+func NewPersonalRecordRepository(db *sql.DB) *PersonalRecordRepository {
+	return &PersonalRecordRepository{db: db}
+}
+
+// Insert stores a new PersonalRecord, or does nothing when one with the same ID is already stored.
+func (r *PersonalRecordRepository) Insert(ctx context.Context, record *models.PersonalRecord) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO personal_records (id, complejo_id, lift, previous, value, achieved_at)
+		VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (id) DO NOTHING`,
+		record.ID, record.ComplejoID, record.Lift, record.Previous, record.Value, record.AchievedAt)
+	return err
+}
+
+// TotalSince returns the total in kilograms of the records set from the given time on.
+func (r *PersonalRecordRepository) TotalSince(ctx context.Context, since time.Time) (float64, error) {
+	var total float64
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COALESCE(SUM(value), 0) FROM personal_records WHERE achieved_at >= $1`, since).Scan(&total)
+	return total, err
+}
@@ -0,0 +1,123 @@
+// photo_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const photoSelect = `SELECT p.id, p.event_id, p.caption, p.content_type, p.width, p.height, p.thumbnail, p.key, p.status,
+	p.uploaded_by, p.username, p.created_at, p.approved_by, p.approved_at,
+	COALESCE(json_agg(json_build_object('complejo_id', t.complejo_id, 'username', t.username,
+	'consent', t.consent, 'decided_at', t.decided_at) ORDER BY t.username, t.complejo_id)
+	FILTER (WHERE t.complejo_id IS NOT NULL), '[]')
+	FROM event_photos p LEFT JOIN event_photo_tags t ON t.photo_id = p.id`
+
+// PhotoRepository is the PostgreSQL implementation of repository.PhotoRepository.
+type PhotoRepository struct {
+	db *sql.DB
+}
+
+// NewPhotoRepository creates a PhotoRepository backed by the given database.
+func NewPhotoRepository(db *sql.DB) *PhotoRepository {
+	return &PhotoRepository{db: db}
+}
+
+// Insert stores a new EventPhoto with its tags.
+func (r *PhotoRepository) Insert(ctx context.Context, photo *models.EventPhoto) error {
+	return NewTransactor(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		tx := conn(ctx, r.db)
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO event_photos
+			(id, event_id, caption, content_type, width, height, thumbnail, key, status, uploaded_by, username, created_at, approved_by, approved_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+			photo.ID, photo.EventID, photo.Caption, photo.ContentType, photo.Width, photo.Height, photo.Thumbnail, photo.Key,
+			photo.Status, photo.UploadedBy, photo.Username, photo.CreatedAt, photo.ApprovedBy, photo.ApprovedAt)
+		if err != nil {
+			return rejected(err)
+		}
+
+		for _, tag := range photo.Tags {
+			_, err := tx.ExecContext(ctx, `INSERT INTO event_photo_tags (photo_id, complejo_id, username, consent, decided_at)
+				VALUES ($1, $2, $3, $4, $5)`, photo.ID, tag.ComplejoID, tag.Username, tag.Consent, tag.DecidedAt)
+			if err != nil {
+				return rejected(err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindByEvent returns the photos of the album of the Event, oldest first.
+func (r *PhotoRepository) FindByEvent(ctx context.Context, eventID string) ([]models.EventPhoto, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, photoSelect+` WHERE p.event_id = $1 GROUP BY p.id ORDER BY p.created_at, p.id`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	photos := []models.EventPhoto{}
+	for rows.Next() {
+		photo, err := scanPhoto(rows)
+		if err != nil {
+			return nil, err
+		}
+		photos = append(photos, *photo)
+	}
+	return photos, rows.Err()
+}
+
+// FindByID returns the EventPhoto with the given ID, or repository.ErrNotFound.
+func (r *PhotoRepository) FindByID(ctx context.Context, id string) (*models.EventPhoto, error) {
+	photo, err := scanPhoto(conn(ctx, r.db).QueryRowContext(ctx, photoSelect+` WHERE p.id = $1 GROUP BY p.id`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return photo, err
+}
+
+// Approve approves the pending photo on behalf of the organizer and reports whether a pending photo was found.
+func (r *PhotoRepository) Approve(ctx context.Context, id, approvedBy string, at time.Time) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE event_photos SET status = $2, approved_by = $3, approved_at = $4 WHERE id = $1 AND status = $5`,
+		id, models.PhotoApproved, approvedBy, at, models.PhotoPending))
+}
+
+// SetThumbnail stores the thumbnail of the photo and reports whether it was found.
+func (r *PhotoRepository) SetThumbnail(ctx context.Context, id, thumbnail string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE event_photos SET thumbnail = $2 WHERE id = $1`, id, thumbnail))
+}
+
+// GrantTag records that the Complejo tagged in the photo granted its consent, and reports whether
+// a pending tag was found.
+func (r *PhotoRepository) GrantTag(ctx context.Context, photoID, complejoID string, at time.Time) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE event_photo_tags SET consent = $3, decided_at = $4 WHERE photo_id = $1 AND complejo_id = $2 AND consent = $5`,
+		photoID, complejoID, models.TagGranted, at, models.TagPending))
+}
+
+// Delete removes the EventPhoto with the given ID and reports whether it was found.
+// Its tags are removed by the foreign key.
+func (r *PhotoRepository) Delete(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM event_photos WHERE id = $1`, id))
+}
+
+// scanPhoto reads an EventPhoto from a row produced by photoSelect.
+func scanPhoto(row rowScanner) (*models.EventPhoto, error) {
+	var p models.EventPhoto
+	var tags []byte
+	err := row.Scan(&p.ID, &p.EventID, &p.Caption, &p.ContentType, &p.Width, &p.Height, &p.Thumbnail, &p.Key, &p.Status,
+		&p.UploadedBy, &p.Username, &p.CreatedAt, &p.ApprovedBy, &p.ApprovedAt, &tags)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(tags, &p.Tags); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,183 @@
+// postgres.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"los-complejos-backend/repository"
+
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the PostgreSQL error code of a broken unique constraint.
+const uniqueViolation = "23505"
+
+// invalidCodes are the PostgreSQL error codes of the writes rejected for a value: broken not-null, foreign-key
+// and check constraints, and values the column cannot hold.
+var invalidCodes = map[pq.ErrorCode]bool{
+	"22001": true, // string_data_right_truncation
+	"22003": true, // numeric_value_out_of_range
+	"22007": true, // invalid_datetime_format
+	"22008": true, // datetime_field_overflow
+	"22P02": true, // invalid_text_representation
+	"23502": true, // not_null_violation
+	"23503": true, // foreign_key_violation
+	"23514": true, // check_violation
+}
+
+// detailKey matches the columns of the key in the detail of a unique or foreign-key violation.
+var detailKey = regexp.MustCompile(`^Key \((.+?)\)=`)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Open connects to PostgreSQL using the given DSN and verifies the connection.
+func Open(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error opening the PostgreSQL connection: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error verifying the PostgreSQL connection: %w", err)
+	}
+	return db, nil
+}
+
+// Migrate applies every embedded migration that has not been applied yet, in file-name order.
+// Applied versions are tracked in the schema_migrations table.
+// It returns the versions applied by this call.
+func Migrate(ctx context.Context, db *sql.DB) ([]string, error) {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return nil, fmt.Errorf("error creating schema_migrations: %w", err)
+	}
+
+	names, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var applied []string
+	for _, name := range names {
+		version := name[len("migrations/"):]
+
+		var exists bool
+		err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&exists)
+		if err != nil {
+			return applied, err
+		}
+		if exists {
+			continue
+		}
+
+		script, err := migrationFiles.ReadFile(name)
+		if err != nil {
+			return applied, err
+		}
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return applied, err
+		}
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			tx.Rollback()
+			return applied, fmt.Errorf("error applying migration %s: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+			tx.Rollback()
+			return applied, err
+		}
+		if err := tx.Commit(); err != nil {
+			return applied, err
+		}
+		applied = append(applied, version)
+	}
+
+	return applied, nil
+}
+
+// buildSet turns a field map into a SET clause using the given field-to-column mapping.
+// Placeholders start at $<start>; the returned arguments follow the same order.
+func buildSet(fields map[string]interface{}, columns map[string]string, start int) (string, []interface{}, error) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	clause := ""
+	args := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		column, ok := columns[key]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s", repository.ErrUnknownField, key)
+		}
+		if clause != "" {
+			clause += ", "
+		}
+		args = append(args, fields[key])
+		clause += fmt.Sprintf("%s = $%d", column, start+len(args)-1)
+	}
+	return clause, args, nil
+}
+
+// affected reports whether the statement changed at least one row. The errors of a rejected write are
+// translated (see rejected).
+func affected(result sql.Result, err error) (bool, error) {
+	if err != nil {
+		return false, rejected(err)
+	}
+	count, err := result.RowsAffected()
+	return count > 0, err
+}
+
+// rejected turns a write rejected by PostgreSQL into a repository error naming the column: a unique-constraint
+// violation into a repository.DuplicateError, the codes of invalidCodes into a repository.InvalidError named
+// after the code. It returns other errors unchanged.
+func rejected(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	if pqErr.Code == uniqueViolation {
+		return &repository.DuplicateError{Field: column(pqErr), Err: err}
+	}
+	if invalidCodes[pqErr.Code] {
+		return &repository.InvalidError{Field: column(pqErr), Rule: pqErr.Code.Name(), Err: err}
+	}
+	return err
+}
+
+// column returns the column of a rejected write: the one reported by the server, else the columns of the key in
+// the detail, else the column of a check constraint named "<table>_<column>_check" as PostgreSQL names them.
+// It returns an empty string when none is known.
+func column(pqErr *pq.Error) string {
+	if pqErr.Column != "" {
+		return pqErr.Column
+	}
+	if match := detailKey.FindStringSubmatch(pqErr.Detail); match != nil {
+		return match[1]
+	}
+	if pqErr.Table != "" && strings.HasPrefix(pqErr.Constraint, pqErr.Table+"_") && strings.HasSuffix(pqErr.Constraint, "_check") {
+		return strings.TrimSuffix(strings.TrimPrefix(pqErr.Constraint, pqErr.Table+"_"), "_check")
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+// privacy_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/lib/pq"
+)
+
+// privacySelect selects the columns scanned by scanPrivacySettings.
+const privacySelect = `SELECT complejo_id, weight, height, imc, photo, updated_at FROM privacy_settings`
+
+// PrivacyRepository is the PostgreSQL implementation of repository.PrivacyRepository.
+type PrivacyRepository struct {
+	db *sql.DB
+}
+
+// NewPrivacyRepository creates a PrivacyRepository backed by the given database.
+func NewPrivacyRepository(db *sql.DB) *PrivacyRepository {
+	return &PrivacyRepository{db: db}
+}
+
+// FindByComplejo returns the PrivacySettings of the Complejo, or ErrNotFound when it never changed them.
+func (r *PrivacyRepository) FindByComplejo(ctx context.Context, complejoID string) (*models.PrivacySettings, error) {
+	settings, err := scanPrivacySettings(conn(ctx, r.db).QueryRowContext(ctx, privacySelect+` WHERE complejo_id = $1`, complejoID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return settings, err
+}
+
+// FindByComplejos returns the PrivacySettings of those of the Complejos with the given IDs that changed them.
+func (r *PrivacyRepository) FindByComplejos(ctx context.Context, complejoIDs []string) ([]models.PrivacySettings, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, privacySelect+` WHERE complejo_id = ANY($1)`, pq.Array(complejoIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := []models.PrivacySettings{}
+	for rows.Next() {
+		s, err := scanPrivacySettings(rows)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, *s)
+	}
+	return settings, rows.Err()
+}
+
+// Save stores the PrivacySettings of their Complejo, replacing the current ones.
+func (r *PrivacyRepository) Save(ctx context.Context, s *models.PrivacySettings) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO privacy_settings
+		(complejo_id, weight, height, imc, photo, updated_at) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (complejo_id) DO UPDATE SET weight = EXCLUDED.weight, height = EXCLUDED.height,
+		imc = EXCLUDED.imc, photo = EXCLUDED.photo, updated_at = EXCLUDED.updated_at`,
+		s.ComplejoID, s.Weight, s.Height, s.IMC, s.Photo, s.UpdatedAt)
+	return rejected(err)
+}
+
+// scanPrivacySettings reads the PrivacySettings of a row selected with privacySelect.
+func scanPrivacySettings(row rowScanner) (*models.PrivacySettings, error) {
+	var s models.PrivacySettings
+	var updatedAt sql.NullTime
+	if err := row.Scan(&s.ComplejoID, &s.Weight, &s.Height, &s.IMC, &s.Photo, &updatedAt); err != nil {
+		return nil, err
+	}
+	if updatedAt.Valid {
+		s.UpdatedAt = &updatedAt.Time
+	}
+	return &s, nil
+}
@@ -0,0 +1,135 @@
+// report_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+
+	"github.com/lib/pq"
+)
+
+// ReportRepository is the PostgreSQL implementation of repository.ReportRepository.
+type ReportRepository struct {
+	db *sql.DB
+}
+
+// NewReportRepository creates a ReportRepository backed by the given database.
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// cohortActivityQuery groups the members by sign-up week (Monday, UTC) with the week offsets of their attendance.
+const cohortActivityQuery = `
+WITH members AS (
+    SELECT id, username, date_trunc('week', created_at AT TIME ZONE 'UTC') AS cohort
+    FROM complejos
+    WHERE deleted_at IS NULL AND created_at >= $1 AND created_at < $2
+),
+attendance AS (
+    SELECT DISTINCT m.id, m.cohort,
+           (date_trunc('week', e.date AT TIME ZONE 'UTC')::date - m.cohort::date) / 7 AS week_offset
+    FROM members m
+    JOIN event_rsvps r ON r.complejo_id = m.id AND r.status = 'going'
+    JOIN events e ON e.id = r.event_id
+    WHERE e.deleted_at IS NULL AND e.date >= m.cohort AT TIME ZONE 'UTC' AND e.date < $2
+)
+SELECT m.cohort, COUNT(*),
+       COALESCE((SELECT array_agg(a.week_offset) FROM attendance a WHERE a.cohort = m.cohort), '{}')
+FROM members m
+GROUP BY m.cohort
+ORDER BY m.cohort`
+
+// CohortActivity groups the Complejos that signed up between from and to by week,
+// with the weeks after sign-up in which each one attended an Event dated before to.
+func (r *ReportRepository) CohortActivity(ctx context.Context, from, to time.Time) ([]models.CohortActivity, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, cohortActivityQuery, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cohorts []models.CohortActivity
+	for rows.Next() {
+		var cohort models.CohortActivity
+		var offsets []int64
+		if err := rows.Scan(&cohort.Week, &cohort.Members, pq.Array(&offsets)); err != nil {
+			return nil, err
+		}
+		cohort.Week = time.Date(cohort.Week.Year(), cohort.Week.Month(), cohort.Week.Day(), 0, 0, 0, 0, time.UTC)
+		for _, offset := range offsets {
+			cohort.Offsets = append(cohort.Offsets, int(offset))
+		}
+		cohorts = append(cohorts, cohort)
+	}
+	return cohorts, rows.Err()
+}
+
+// AttendanceByHour counts the Complejos going to the Events dated between from and to by weekday and hour.
+func (r *ReportRepository) AttendanceByHour(ctx context.Context, from, to time.Time, location *time.Location) ([]models.AttendanceSlot, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT EXTRACT(ISODOW FROM e.date AT TIME ZONE $3)::int, EXTRACT(HOUR FROM e.date AT TIME ZONE $3)::int, COUNT(*)
+		FROM events e
+		JOIN event_rsvps r ON r.event_id = e.id AND r.status = 'going'
+		WHERE e.deleted_at IS NULL AND e.date >= $1 AND e.date < $2
+		GROUP BY 1, 2`, from, to, location.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slots []models.AttendanceSlot
+	for rows.Next() {
+		var slot models.AttendanceSlot
+		if err := rows.Scan(&slot.Weekday, &slot.Hour, &slot.Count); err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	return slots, rows.Err()
+}
+
+// memberActivityQuery counts the attendance and subscription transitions of every member in both windows.
+const memberActivityQuery = `
+SELECT c.id, c.username, c.created_at,
+       COUNT(DISTINCT e.id) FILTER (WHERE e.date < $2),
+       COUNT(DISTINCT e.id) FILTER (WHERE e.date >= $2),
+       (SELECT COUNT(*) FROM subscription_events s WHERE s.username = c.username AND s.occurred_at >= $1 AND s.occurred_at < $2),
+       (SELECT COUNT(*) FROM subscription_events s WHERE s.username = c.username AND s.occurred_at >= $2 AND s.occurred_at < $3)
+FROM complejos c
+LEFT JOIN event_rsvps r ON r.complejo_id = c.id AND r.status = 'going'
+LEFT JOIN events e ON e.id = r.event_id AND e.deleted_at IS NULL AND e.date >= $1 AND e.date < $3
+WHERE c.deleted_at IS NULL AND c.role = 'user'
+GROUP BY c.id, c.username, c.created_at`
+
+// MemberActivity counts, for every live Complejo with the "user" role, the past Events attended and the
+// subscription transitions in the previous window [since, split) and the recent window [split, until).
+func (r *ReportRepository) MemberActivity(ctx context.Context, since, split, until time.Time) ([]models.MemberActivity, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, memberActivityQuery, since, split, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.MemberActivity
+	for rows.Next() {
+		var m models.MemberActivity
+		err := rows.Scan(&m.ID, &m.Username, &m.CreatedAt, &m.PreviousAttendance, &m.RecentAttendance,
+			&m.PreviousSubscriptions, &m.RecentSubscriptions)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// ClubTotals counts the live Complejos and the live Events dated before the given time.
+func (r *ReportRepository) ClubTotals(ctx context.Context, before time.Time) (members, events int64, err error) {
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT
+		(SELECT count(*) FROM complejos WHERE deleted_at IS NULL),
+		(SELECT count(*) FROM events WHERE deleted_at IS NULL AND date < $1)`, before).Scan(&members, &events)
+	return members, events, err
+}
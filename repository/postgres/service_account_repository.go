@@ -0,0 +1,152 @@
+// service_account_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const serviceAccountSelect = `SELECT id, name, role, rotation_days, overlap_hours, active, created_by, created_at, updated_at
+	FROM service_accounts`
+
+const serviceTokenSelect = `SELECT id, account_id, hash, hint, issued_at, rotate_at, expires_at FROM service_tokens`
+
+// ServiceAccountRepository is the PostgreSQL implementation of repository.ServiceAccountRepository.
+type ServiceAccountRepository struct {
+	db *sql.DB
+}
+
+// NewServiceAccountRepository creates a ServiceAccountRepository backed by the given database.
+func NewServiceAccountRepository(db *sql.DB) *ServiceAccountRepository {
+	return &ServiceAccountRepository{db: db}
+}
+
+// Insert stores a new ServiceAccount, or returns repository.ErrDuplicate when its name is taken.
+func (r *ServiceAccountRepository) Insert(ctx context.Context, account *models.ServiceAccount) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO service_accounts
+		(id, name, role, rotation_days, overlap_hours, active, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		account.ID, account.Name, account.Role, account.RotationDays, account.OverlapHours, account.Active,
+		account.CreatedBy, account.CreatedAt, account.UpdatedAt)
+	return rejected(err)
+}
+
+// FindAll returns every ServiceAccount, oldest first, without their tokens.
+func (r *ServiceAccountRepository) FindAll(ctx context.Context) ([]models.ServiceAccount, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, serviceAccountSelect+` ORDER BY created_at, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []models.ServiceAccount{}
+	for rows.Next() {
+		account, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *account)
+	}
+	return accounts, rows.Err()
+}
+
+// FindByID returns the ServiceAccount with the given ID without its tokens, or repository.ErrNotFound.
+func (r *ServiceAccountRepository) FindByID(ctx context.Context, id string) (*models.ServiceAccount, error) {
+	account, err := scanServiceAccount(conn(ctx, r.db).QueryRowContext(ctx, serviceAccountSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return account, err
+}
+
+// Update replaces the ServiceAccount and reports whether it was found, or returns repository.ErrDuplicate
+// when its name is taken.
+func (r *ServiceAccountRepository) Update(ctx context.Context, account *models.ServiceAccount) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE service_accounts SET name = $2, role = $3,
+		rotation_days = $4, overlap_hours = $5, active = $6, updated_at = $7 WHERE id = $1`,
+		account.ID, account.Name, account.Role, account.RotationDays, account.OverlapHours, account.Active,
+		account.UpdatedAt))
+}
+
+// Delete removes the ServiceAccount with its tokens and reports whether it was found.
+func (r *ServiceAccountRepository) Delete(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM service_accounts WHERE id = $1`, id))
+}
+
+// InsertToken stores a new ServiceToken.
+func (r *ServiceAccountRepository) InsertToken(ctx context.Context, token *models.ServiceToken) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO service_tokens
+		(id, account_id, hash, hint, issued_at, rotate_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		token.ID, token.AccountID, token.Hash, token.Hint, token.IssuedAt, token.RotateAt, token.ExpiresAt)
+	return rejected(err)
+}
+
+// FindTokens returns the tokens of the ServiceAccount expiring after the given time, oldest first.
+func (r *ServiceAccountRepository) FindTokens(ctx context.Context, accountID string, after time.Time) ([]models.ServiceToken, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, serviceTokenSelect+` WHERE account_id = $1 AND expires_at > $2
+		ORDER BY issued_at, id`, accountID, after)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []models.ServiceToken{}
+	for rows.Next() {
+		token, err := scanServiceToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+	return tokens, rows.Err()
+}
+
+// FindTokenByHash returns the ServiceToken with the given hash, or repository.ErrNotFound.
+func (r *ServiceAccountRepository) FindTokenByHash(ctx context.Context, hash string) (*models.ServiceToken, error) {
+	token, err := scanServiceToken(conn(ctx, r.db).QueryRowContext(ctx, serviceTokenSelect+` WHERE hash = $1`, hash))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return token, err
+}
+
+// ExpireTokens brings the expiry of the tokens of the ServiceAccount expiring after the given time forward to it.
+func (r *ServiceAccountRepository) ExpireTokens(ctx context.Context, accountID string, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE service_tokens SET expires_at = $2 WHERE account_id = $1 AND expires_at > $2`, accountID, at)
+	return err
+}
+
+// PurgeExpiredTokens removes the tokens expired before the given time and returns how many were removed.
+func (r *ServiceAccountRepository) PurgeExpiredTokens(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM service_tokens WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// scanServiceAccount reads a ServiceAccount from a row produced by serviceAccountSelect.
+func scanServiceAccount(row rowScanner) (*models.ServiceAccount, error) {
+	var a models.ServiceAccount
+	err := row.Scan(&a.ID, &a.Name, &a.Role, &a.RotationDays, &a.OverlapHours, &a.Active, &a.CreatedBy,
+		&a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// scanServiceToken reads a ServiceToken from a row produced by serviceTokenSelect.
+func scanServiceToken(row rowScanner) (*models.ServiceToken, error) {
+	var t models.ServiceToken
+	err := row.Scan(&t.ID, &t.AccountID, &t.Hash, &t.Hint, &t.IssuedAt, &t.RotateAt, &t.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
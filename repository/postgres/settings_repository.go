@@ -0,0 +1,48 @@
+// settings_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// SettingsRepository is the PostgreSQL implementation of repository.SettingsRepository.
+type SettingsRepository struct {
+	db *sql.DB
+}
+
+// NewSettingsRepository creates a SettingsRepository backed by the given database.
+func NewSettingsRepository(db *sql.DB) *SettingsRepository {
+	return &SettingsRepository{db: db}
+}
+
+// FindListing returns the ListingSettings, or ErrNotFound when the admins never changed them.
+func (r *SettingsRepository) FindListing(ctx context.Context) (*models.ListingSettings, error) {
+	var s models.ListingSettings
+	var updatedAt sql.NullTime
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT hide_past_after_days, sort, page_size, updated_by, updated_at
+		FROM listing_settings`).Scan(&s.HidePastAfterDays, &s.Sort, &s.PageSize, &s.UpdatedBy, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if updatedAt.Valid {
+		s.UpdatedAt = &updatedAt.Time
+	}
+	return &s, nil
+}
+
+// SaveListing stores the ListingSettings, replacing the current ones.
+func (r *SettingsRepository) SaveListing(ctx context.Context, s *models.ListingSettings) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO listing_settings
+		(hide_past_after_days, sort, page_size, updated_by, updated_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET hide_past_after_days = EXCLUDED.hide_past_after_days, sort = EXCLUDED.sort,
+		page_size = EXCLUDED.page_size, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at`,
+		s.HidePastAfterDays, s.Sort, s.PageSize, s.UpdatedBy, s.UpdatedAt)
+	return rejected(err)
+}
@@ -0,0 +1,58 @@
+// subscription_event_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"los-complejos-backend/models"
+)
+
+const subscriptionEventSelect = `SELECT id, event_id, username, type, occurred_at FROM subscription_events`
+
+// SubscriptionEventRepository is the PostgreSQL implementation of repository.SubscriptionEventRepository.
+type SubscriptionEventRepository struct {
+	db *sql.DB
+}
+
+// NewSubscriptionEventRepository creates a SubscriptionEventRepository backed by the given database.
+func NewSubscriptionEventRepository(db *sql.DB) *SubscriptionEventRepository {
+	return &SubscriptionEventRepository{db: db}
+}
+
+// Append records a new transition.
+func (r *SubscriptionEventRepository) Append(ctx context.Context, event *models.SubscriptionEvent) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO subscription_events (id, event_id, username, type, occurred_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		event.ID, event.EventID, event.Username, event.Type, event.OccurredAt)
+	return err
+}
+
+// FindByEvent returns the transitions of the Event in chronological order.
+func (r *SubscriptionEventRepository) FindByEvent(ctx context.Context, eventID string) ([]models.SubscriptionEvent, error) {
+	return r.query(ctx, subscriptionEventSelect+` WHERE event_id = $1 ORDER BY occurred_at, id`, eventID)
+}
+
+// FindByUsername returns the transitions of the user with the given username in chronological order.
+func (r *SubscriptionEventRepository) FindByUsername(ctx context.Context, username string) ([]models.SubscriptionEvent, error) {
+	return r.query(ctx, subscriptionEventSelect+` WHERE username = $1 ORDER BY occurred_at, id`, username)
+}
+
+// query returns the transitions selected by the query.
+func (r *SubscriptionEventRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.SubscriptionEvent, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.SubscriptionEvent{}
+	for rows.Next() {
+		var e models.SubscriptionEvent
+		if err := rows.Scan(&e.ID, &e.EventID, &e.Username, &e.Type, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,187 @@
+// volunteer_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const (
+	shiftFields = `SELECT s.id, s.event_id, s.role, s.capacity, s.starts_at, s.ends_at, s.created_by, s.created_at,
+	COALESCE(json_agg(json_build_object('complejo_id', v.complejo_id, 'username', v.username,
+	'signed_up_at', v.signed_up_at, 'reminded_at', v.reminded_at) ORDER BY v.signed_up_at, v.complejo_id)
+	FILTER (WHERE v.complejo_id IS NOT NULL), '[]')`
+	shiftFrom   = ` FROM volunteer_shifts s LEFT JOIN volunteer_signups v ON v.shift_id = s.id`
+	shiftGroup  = ` GROUP BY s.id`
+	shiftSelect = shiftFields + shiftFrom
+
+	// volunteerHours sums the shifts and hours of the sign-ups of live Complejos for shifts of live events
+	// ended before $1.
+	volunteerHours = `SELECT c.id, c.username, COUNT(*), COALESCE(SUM(EXTRACT(EPOCH FROM s.ends_at - s.starts_at) / 3600), 0)
+		FROM volunteer_signups v
+		JOIN volunteer_shifts s ON s.id = v.shift_id
+		JOIN events e ON e.id = s.event_id AND e.deleted_at IS NULL
+		JOIN complejos c ON c.id = v.complejo_id AND c.deleted_at IS NULL
+		WHERE s.ends_at < $1`
+)
+
+// VolunteerRepository is the PostgreSQL implementation of repository.VolunteerRepository.
+type VolunteerRepository struct {
+	db *sql.DB
+}
+
+// NewVolunteerRepository creates a VolunteerRepository backed by the given database.
+func NewVolunteerRepository(db *sql.DB) *VolunteerRepository {
+	return &VolunteerRepository{db: db}
+}
+
+// InsertShift stores a new VolunteerShift without sign-ups.
+func (r *VolunteerRepository) InsertShift(ctx context.Context, shift *models.VolunteerShift) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO volunteer_shifts
+		(id, event_id, role, capacity, starts_at, ends_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		shift.ID, shift.EventID, shift.Role, shift.Capacity, shift.StartsAt, shift.EndsAt, shift.CreatedBy, shift.CreatedAt)
+	return rejected(err)
+}
+
+// FindShifts returns the shifts of the Event, ordered by start and role.
+func (r *VolunteerRepository) FindShifts(ctx context.Context, eventID string) ([]models.VolunteerShift, error) {
+	return r.query(ctx, shiftSelect+` WHERE s.event_id = $1`+shiftGroup+` ORDER BY s.starts_at, s.role, s.id`, eventID)
+}
+
+// FindShiftByID returns the VolunteerShift with the given ID, or repository.ErrNotFound.
+func (r *VolunteerRepository) FindShiftByID(ctx context.Context, id string) (*models.VolunteerShift, error) {
+	shift, err := scanShift(conn(ctx, r.db).QueryRowContext(ctx, shiftSelect+` WHERE s.id = $1`+shiftGroup, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return shift, err
+}
+
+// DeleteShift removes the VolunteerShift with the given ID and reports whether it was found.
+// Its sign-ups are removed by the foreign key.
+func (r *VolunteerRepository) DeleteShift(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM volunteer_shifts WHERE id = $1`, id))
+}
+
+// AddVolunteer signs the Complejo up for the shift, unless the shift is full or the Complejo already signed up,
+// and reports whether it was signed up. The shift row is locked while its sign-ups are counted,
+// so concurrent sign-ups never exceed the capacity.
+func (r *VolunteerRepository) AddVolunteer(ctx context.Context, shiftID string, volunteer models.Volunteer) (bool, error) {
+	added := false
+	err := NewTransactor(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		tx := conn(ctx, r.db)
+
+		var capacity int
+		err := tx.QueryRowContext(ctx, `SELECT capacity FROM volunteer_shifts WHERE id = $1 FOR UPDATE`, shiftID).Scan(&capacity)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var taken int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM volunteer_signups WHERE shift_id = $1`, shiftID).Scan(&taken); err != nil {
+			return err
+		}
+		if taken >= capacity {
+			return nil
+		}
+
+		added, err = affected(tx.ExecContext(ctx, `INSERT INTO volunteer_signups (shift_id, complejo_id, username, signed_up_at)
+			VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+			shiftID, volunteer.ComplejoID, volunteer.Username, volunteer.SignedUpAt))
+		return err
+	})
+	return added, err
+}
+
+// RemoveVolunteer withdraws the Complejo from the shift and reports whether it had signed up.
+func (r *VolunteerRepository) RemoveVolunteer(ctx context.Context, shiftID, complejoID string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM volunteer_signups WHERE shift_id = $1 AND complejo_id = $2`, shiftID, complejoID))
+}
+
+// FindStartingBetween returns the shifts of live events starting in [from, to), ordered by start.
+func (r *VolunteerRepository) FindStartingBetween(ctx context.Context, from, to time.Time) ([]models.VolunteerShift, error) {
+	return r.query(ctx, shiftSelect+` JOIN events e ON e.id = s.event_id AND e.deleted_at IS NULL
+		WHERE s.starts_at >= $1 AND s.starts_at < $2`+shiftGroup+` ORDER BY s.starts_at, s.id`, from, to)
+}
+
+// MarkReminded records that the Complejo was reminded of the shift at the given time.
+func (r *VolunteerRepository) MarkReminded(ctx context.Context, shiftID, complejoID string, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE volunteer_signups SET reminded_at = $3 WHERE shift_id = $1 AND complejo_id = $2`, shiftID, complejoID, at)
+	return err
+}
+
+// Hours returns the hours the Complejo volunteered in shifts of live events ended before the given time.
+func (r *VolunteerRepository) Hours(ctx context.Context, complejoID string, before time.Time) (float64, error) {
+	var h models.VolunteerHours
+	err := conn(ctx, r.db).QueryRowContext(ctx, volunteerHours+` AND v.complejo_id = $2 GROUP BY c.id, c.username`,
+		before, complejoID).Scan(&h.ComplejoID, &h.Username, &h.Shifts, &h.Hours)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return h.Hours, err
+}
+
+// Leaderboard returns at most limit live Complejos with the most hours volunteered in shifts of live events
+// ended before the given time, most hours first.
+func (r *VolunteerRepository) Leaderboard(ctx context.Context, before time.Time, limit int) ([]models.VolunteerHours, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		volunteerHours+` GROUP BY c.id, c.username ORDER BY 4 DESC, c.id LIMIT $2`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tally := []models.VolunteerHours{}
+	for rows.Next() {
+		var h models.VolunteerHours
+		if err := rows.Scan(&h.ComplejoID, &h.Username, &h.Shifts, &h.Hours); err != nil {
+			return nil, err
+		}
+		tally = append(tally, h)
+	}
+	return tally, rows.Err()
+}
+
+// query returns the shifts selected by a query built on shiftSelect.
+func (r *VolunteerRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.VolunteerShift, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shifts := []models.VolunteerShift{}
+	for rows.Next() {
+		shift, err := scanShift(rows)
+		if err != nil {
+			return nil, err
+		}
+		shifts = append(shifts, *shift)
+	}
+	return shifts, rows.Err()
+}
+
+// scanShift reads a VolunteerShift from a row produced by shiftSelect.
+func scanShift(row rowScanner) (*models.VolunteerShift, error) {
+	var s models.VolunteerShift
+	var volunteers []byte
+	err := row.Scan(&s.ID, &s.EventID, &s.Role, &s.Capacity, &s.StartsAt, &s.EndsAt, &s.CreatedBy, &s.CreatedAt, &volunteers)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(volunteers, &s.Volunteers); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,196 @@
+// webhook_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/lib/pq"
+)
+
+const webhookSelect = `SELECT id, url, secret, topics, active, created_by, created_at, updated_at FROM webhooks`
+
+const deliverySelect = `SELECT id, webhook_id, event_id, topic, payload, status, attempts, created_at, next_attempt_at,
+	delivered_at FROM webhook_deliveries`
+
+// WebhookRepository is the PostgreSQL implementation of repository.WebhookRepository.
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository creates a WebhookRepository backed by the given database.
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Insert stores a new Webhook.
+func (r *WebhookRepository) Insert(ctx context.Context, webhook *models.Webhook) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO webhooks
+		(id, url, secret, topics, active, created_by, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		webhook.ID, webhook.URL, webhook.Secret, pq.Array(webhook.Topics), webhook.Active, webhook.CreatedBy,
+		webhook.CreatedAt, webhook.UpdatedAt)
+	return rejected(err)
+}
+
+// FindAll returns every Webhook, oldest first.
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]models.Webhook, error) {
+	return r.query(ctx, webhookSelect+` ORDER BY created_at, id`)
+}
+
+// FindByID returns the Webhook with the given ID, or repository.ErrNotFound.
+func (r *WebhookRepository) FindByID(ctx context.Context, id string) (*models.Webhook, error) {
+	webhook, err := scanWebhook(conn(ctx, r.db).QueryRowContext(ctx, webhookSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return webhook, err
+}
+
+// FindByTopic returns the active Webhooks receiving the topic.
+func (r *WebhookRepository) FindByTopic(ctx context.Context, topic string) ([]models.Webhook, error) {
+	return r.query(ctx, webhookSelect+` WHERE active AND $1 = ANY(topics) ORDER BY created_at, id`, topic)
+}
+
+// Update replaces the Webhook and reports whether it was found.
+func (r *WebhookRepository) Update(ctx context.Context, webhook *models.Webhook) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE webhooks SET url = $2, secret = $3, topics = $4, active = $5, updated_at = $6 WHERE id = $1`,
+		webhook.ID, webhook.URL, webhook.Secret, pq.Array(webhook.Topics), webhook.Active, webhook.UpdatedAt))
+}
+
+// Delete removes the Webhook with its deliveries and reports whether it was found.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id))
+}
+
+// Enqueue stores the new deliveries, skipping those already stored with the same ID.
+func (r *WebhookRepository) Enqueue(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	for _, d := range deliveries {
+		_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO webhook_deliveries
+			(id, webhook_id, event_id, topic, payload, status, created_at, next_attempt_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (id) DO NOTHING`,
+			d.ID, d.WebhookID, d.EventID, d.Topic, []byte(d.Payload), d.Status, d.CreatedAt, d.NextAttemptAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Claim atomically picks the pending delivery due the longest and leases it.
+func (r *WebhookRepository) Claim(ctx context.Context, now time.Time, lease time.Duration) (*models.WebhookDelivery, error) {
+	delivery, err := scanDelivery(conn(ctx, r.db).QueryRowContext(ctx, `UPDATE webhook_deliveries SET next_attempt_at = $2
+		WHERE id = (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, webhook_id, event_id, topic, payload, status, attempts, created_at, next_attempt_at, delivered_at`,
+		now, now.Add(lease)))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return delivery, err
+}
+
+// RecordAttempt appends the attempt to the delivery and sets its status, and the time of its next attempt
+// while pending or of its delivery once delivered.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, id string, attempt models.WebhookAttempt, status string, at time.Time) error {
+	entry, err := json.Marshal([]models.WebhookAttempt{attempt})
+	if err != nil {
+		return err
+	}
+	_, err = conn(ctx, r.db).ExecContext(ctx, `UPDATE webhook_deliveries SET attempts = attempts || $2::jsonb, status = $3,
+		next_attempt_at = CASE WHEN $3 = 'pending' THEN $4 ELSE next_attempt_at END,
+		delivered_at = CASE WHEN $3 = 'delivered' THEN $4 ELSE delivered_at END
+		WHERE id = $1`,
+		id, entry, status, at)
+	return err
+}
+
+// FindDeliveries returns the deliveries of the Webhook matching the filter, at most filter.Limit, newest first.
+func (r *WebhookRepository) FindDeliveries(ctx context.Context, webhookID string, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, deliverySelect+` WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id LIMIT $3`, webhookID, filter.Status, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// PurgeDeliveries removes the delivered and failed deliveries created before the given time and returns how
+// many were removed.
+func (r *WebhookRepository) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM webhook_deliveries WHERE status <> 'pending' AND created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// query returns the Webhooks selected by the query.
+func (r *WebhookRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.Webhook, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// scanWebhook reads a Webhook from a row produced by webhookSelect.
+func scanWebhook(row rowScanner) (*models.Webhook, error) {
+	var w models.Webhook
+	err := row.Scan(&w.ID, &w.URL, &w.Secret, pq.Array(&w.Topics), &w.Active, &w.CreatedBy, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// scanDelivery reads a WebhookDelivery from a row produced by deliverySelect.
+func scanDelivery(row rowScanner) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	var payload, attempts []byte
+	var deliveredAt sql.NullTime
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.Topic, &payload, &d.Status, &attempts, &d.CreatedAt,
+		&d.NextAttemptAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	if err := json.Unmarshal(attempts, &d.Attempts); err != nil {
+		return nil, err
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return &d, nil
+}
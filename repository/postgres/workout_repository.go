@@ -0,0 +1,132 @@
+// workout_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+const workoutSelect = `SELECT id, complejo_id, date, exercises, notes, created_at, updated_at FROM workouts`
+
+// WorkoutRepository is the PostgreSQL implementation of repository.WorkoutRepository.
+type WorkoutRepository struct {
+	db *sql.DB
+}
+
+// NewWorkoutRepository creates a WorkoutRepository backed by the given database.
+func NewWorkoutRepository(db *sql.DB) *WorkoutRepository {
+	return &WorkoutRepository{db: db}
+}
+
+// Insert stores a new Workout.
+func (r *WorkoutRepository) Insert(ctx context.Context, workout *models.Workout) error {
+	exercises, err := json.Marshal(workout.Exercises)
+	if err != nil {
+		return err
+	}
+	_, err = conn(ctx, r.db).ExecContext(ctx, `INSERT INTO workouts
+		(id, complejo_id, date, exercises, notes, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		workout.ID, workout.ComplejoID, workout.Date, exercises, workout.Notes, workout.CreatedAt, workout.UpdatedAt)
+	return rejected(err)
+}
+
+// FindByID returns the Workout with the given ID, or repository.ErrNotFound.
+func (r *WorkoutRepository) FindByID(ctx context.Context, id string) (*models.Workout, error) {
+	workout, err := scanWorkout(conn(ctx, r.db).QueryRowContext(ctx, workoutSelect+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	return workout, err
+}
+
+// FindByComplejo returns the page (skipping offset, at most limit, every one when 0) of the workouts of the
+// Complejo with the given ID, newest first, and their total number.
+func (r *WorkoutRepository) FindByComplejo(ctx context.Context, complejoID string, offset, limit int) ([]models.Workout, int64, error) {
+	db := conn(ctx, r.db)
+
+	var total int64
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM workouts WHERE complejo_id = $1`, complejoID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, workoutSelect+` WHERE complejo_id = $1
+		ORDER BY date DESC, id LIMIT NULLIF($2, 0) OFFSET $3`, complejoID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	workouts := []models.Workout{}
+	for rows.Next() {
+		workout, err := scanWorkout(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		workouts = append(workouts, *workout)
+	}
+	return workouts, total, rows.Err()
+}
+
+// Replace overwrites the stored Workout with the same ID and reports whether it was found.
+func (r *WorkoutRepository) Replace(ctx context.Context, workout *models.Workout) (bool, error) {
+	exercises, err := json.Marshal(workout.Exercises)
+	if err != nil {
+		return false, err
+	}
+	return affected(conn(ctx, r.db).ExecContext(ctx, `UPDATE workouts
+		SET date = $2, exercises = $3, notes = $4, updated_at = $5
+		WHERE id = $1`,
+		workout.ID, workout.Date, exercises, workout.Notes, workout.UpdatedAt))
+}
+
+// DeleteByID removes the Workout with the given ID and reports whether it was found.
+func (r *WorkoutRepository) DeleteByID(ctx context.Context, id string) (bool, error) {
+	return affected(conn(ctx, r.db).ExecContext(ctx, `DELETE FROM workouts WHERE id = $1`, id))
+}
+
+// WeeklyVolume sums the sets, repetitions and volume of the workouts of the Complejo with the given ID since the
+// given time by week (starting on Monday in the given time zone) and muscle group, for the weeks and muscle
+// groups trained, oldest first.
+func (r *WorkoutRepository) WeeklyVolume(ctx context.Context, complejoID string, since time.Time, location *time.Location) ([]models.MuscleVolume, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT
+		to_char(date_trunc('week', w.date AT TIME ZONE $3), 'YYYY-MM-DD') AS week,
+		e->>'muscle_group' AS muscle_group,
+		COUNT(*),
+		SUM((s->>'reps')::INTEGER),
+		SUM((s->>'reps')::INTEGER * (s->>'weight')::DOUBLE PRECISION)
+		FROM workouts w, jsonb_array_elements(w.exercises) e, jsonb_array_elements(e->'sets') s
+		WHERE w.complejo_id = $1 AND w.date >= $2
+		GROUP BY week, muscle_group ORDER BY week, muscle_group`, complejoID, since, location.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	volumes := []models.MuscleVolume{}
+	for rows.Next() {
+		var v models.MuscleVolume
+		if err := rows.Scan(&v.Week, &v.MuscleGroup, &v.Sets, &v.Reps, &v.Volume); err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, v)
+	}
+	return volumes, rows.Err()
+}
+
+// scanWorkout reads a Workout from a row produced by workoutSelect.
+func scanWorkout(row rowScanner) (*models.Workout, error) {
+	var w models.Workout
+	var exercises []byte
+	if err := row.Scan(&w.ID, &w.ComplejoID, &w.Date, &exercises, &w.Notes, &w.CreatedAt, &w.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(exercises, &w.Exercises); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
@@ -0,0 +1,746 @@
+// repository.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// ErrNotFound is returned when the requested document does not exist.
+var ErrNotFound = errors.New("document not found")
+
+// ErrDuplicate is returned when a write would break a uniqueness constraint; it is matched by a DuplicateError
+// naming the field.
+var ErrDuplicate = errors.New("duplicate key")
+
+// ErrUnknownField is returned when an update refers to a field the backend does not store.
+var ErrUnknownField = errors.New("unknown field")
+
+// ErrInvalidResumeToken is returned when a change stream cannot resume after the given token, because it is
+// malformed or the change is no longer in the history of the database.
+var ErrInvalidResumeToken = errors.New("invalid resume token")
+
+// ComplejoRepository is the storage contract for Complejo resources.
+// MongoDB (package mongodb) is the default implementation and PostgreSQL (package postgres) an alternative.
+type ComplejoRepository interface {
+	// Insert stores a new Complejo. It returns ErrDuplicate when the username is taken.
+	Insert(ctx context.Context, complejo *models.Complejo) error
+	// FindAll returns every stored Complejo.
+	FindAll(ctx context.Context) ([]models.Complejo, error)
+	// FindByID returns the Complejo with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.Complejo, error)
+	// UpdateByID sets the given fields on the Complejo with the given ID.
+	// When role is not empty, only a Complejo with that role is updated.
+	// It reports whether a matching Complejo was found, and returns ErrDuplicate when the new username is taken.
+	UpdateByID(ctx context.Context, id, role string, fields map[string]interface{}) (bool, error)
+	// SetChurnRisk stores the churn-risk score of the Complejo with the given ID and reports whether it was found.
+	SetChurnRisk(ctx context.Context, id string, risk *models.ChurnRisk) (bool, error)
+	// DeleteByID marks the Complejo with the given ID as deleted at the given time and reports whether it was found.
+	// Deleted Complejos are ignored by every other method until restored.
+	DeleteByID(ctx context.Context, id string, at time.Time) (bool, error)
+	// RestoreByID clears the deletion mark of the Complejo with the given ID and reports whether a deleted one was found.
+	// Erased Complejos cannot be restored.
+	RestoreByID(ctx context.Context, id string) (bool, error)
+	// PurgeDeleted permanently removes the Complejos deleted before the given time and returns how many were removed.
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+	// Anonymize replaces the username of the Complejo with the given ID with the placeholder, clears its other
+	// personal fields, and marks it as deleted and erased at the given time. It reports whether it was found.
+	Anonymize(ctx context.Context, id, placeholder string, at time.Time) (bool, error)
+}
+
+// EventRepository is the storage contract for Event resources.
+type EventRepository interface {
+	// Insert stores a new Event.
+	Insert(ctx context.Context, event *models.Event) error
+	// FindAll returns every stored Event, the ones pinned at now first, then by date.
+	FindAll(ctx context.Context, now time.Time) ([]models.Event, error)
+	// FindByID returns the Event with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.Event, error)
+	// Search returns the page of Events matching the filter, the ones pinned at now first, then by date,
+	// and the total number of matches.
+	Search(ctx context.Context, filter models.EventFilter, now time.Time) ([]models.Event, int64, error)
+	// FindFeatured returns at most limit featured Events dated from the given time on, the ones pinned at that
+	// time first, then by date.
+	FindFeatured(ctx context.Context, from time.Time, limit int) ([]models.Event, error)
+	// TextSearch returns at most limit Events matching the full-text query, most relevant first.
+	TextSearch(ctx context.Context, query string, limit int) ([]models.ScoredEvent, error)
+	// FindByExternalID returns the Event ingested with the given external ID, or ErrNotFound.
+	// Deleted Events are returned too, so producers cannot re-create an Event an admin deleted.
+	FindByExternalID(ctx context.Context, externalID string) (*models.Event, error)
+	// UpdateByID sets the given fields on the Event with the given ID.
+	// It reports whether a matching Event was found.
+	UpdateByID(ctx context.Context, id string, fields map[string]interface{}) (bool, error)
+	// DeleteByID marks the Event with the given ID as deleted at the given time and reports whether it was found.
+	// Deleted Events are ignored by every other method (except FindByExternalID) until restored.
+	DeleteByID(ctx context.Context, id string, at time.Time) (bool, error)
+	// RestoreByID clears the deletion mark of the Event with the given ID and reports whether a deleted one was found.
+	RestoreByID(ctx context.Context, id string) (bool, error)
+	// PurgeDeleted permanently removes the Events deleted before the given time and returns how many were removed.
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+	// FindParticipants returns the page (skipping offset, at most limit) of the profiles of the live Complejos
+	// going to the Event, in the order they answered, and their total number.
+	FindParticipants(ctx context.Context, id string, offset, limit int) ([]models.Participant, int64, error)
+	// RemoveRSVPsFromAll removes the RSVPs of the Complejo from every Event
+	// and returns the IDs of the Events it was going to.
+	RemoveRSVPsFromAll(ctx context.Context, complejoID string) ([]string, error)
+	// SetRSVP replaces the RSVP of the Complejo on the Event with the given one.
+	// It reports whether the Event was found.
+	SetRSVP(ctx context.Context, id string, rsvp models.RSVP) (bool, error)
+	// RemoveRSVP removes the RSVP of the Complejo from the Event.
+	// It reports whether the Event was found and whether the Complejo had answered.
+	RemoveRSVP(ctx context.Context, id, complejoID string) (matched, modified bool, err error)
+	// AddGuest registers the guest for the Event and reports whether the Event was found.
+	AddGuest(ctx context.Context, id string, guest models.Guest) (bool, error)
+	// RemoveGuest removes the guest from the Event and reports whether it was registered.
+	RemoveGuest(ctx context.Context, id, guestID string) (bool, error)
+	// FindByGuest returns the Events, by date, the Complejo brought a guest with the given name to.
+	FindByGuest(ctx context.Context, hostID, name string) ([]models.Event, error)
+	// CountGuests returns how many guests the Complejo registered in [from, to), deleted Events included.
+	CountGuests(ctx context.Context, hostID string, from, to time.Time) (int64, error)
+	// SetLike adds the Complejo to the likes of the Event, or removes it when liked is false.
+	// It reports whether the Event was found.
+	SetLike(ctx context.Context, id, complejoID string, liked bool) (bool, error)
+	// SetLevelOverride lets the Complejo through the level gate of the Event, or withdraws the override
+	// when allowed is false. It reports whether the Event was found.
+	SetLevelOverride(ctx context.Context, id, complejoID string, allowed bool) (bool, error)
+	// FindByRSVP returns the Events dated from the given time on that the Complejo answered with the status, by date.
+	FindByRSVP(ctx context.Context, complejoID, status string, from time.Time) ([]models.Event, error)
+	// FindUnwarnedOutdoor returns the outdoor Events dated between from and to whose participants were not
+	// warned of severe weather, by date.
+	FindUnwarnedOutdoor(ctx context.Context, from, to time.Time) ([]models.Event, error)
+	// SetRoute sets the GPX route of the Event, or removes it when route is nil, and reports whether the Event was found.
+	SetRoute(ctx context.Context, id string, route *models.EventRoute) (bool, error)
+	// SetForecast caches the weather forecast on the Event and reports whether the Event was found.
+	SetForecast(ctx context.Context, id string, forecast models.Forecast) (bool, error)
+	// FindUnreminded returns the Events dated between from and to whose participants were not reminded of them, by date.
+	FindUnreminded(ctx context.Context, from, to time.Time) ([]models.Event, error)
+}
+
+// EventWatcher follows the changes of the stored Events.
+type EventWatcher interface {
+	// Watch opens a stream of the changes of the Events made after the change with the given resume token, or
+	// from now when it is empty. It returns ErrInvalidResumeToken when the stream cannot resume after the token.
+	Watch(ctx context.Context, resumeAfter string) (EventChangeStream, error)
+}
+
+// EventChangeStream is an open stream of the changes of the Events.
+type EventChangeStream interface {
+	// Next waits for the next change; it returns nil when none arrived within the polling interval of the
+	// stream, so the caller can check on its client.
+	Next(ctx context.Context) (*models.EventChange, error)
+	// ResumeToken returns the token resuming the stream after the last change it received or skipped.
+	ResumeToken() string
+	// Close closes the stream.
+	Close(ctx context.Context) error
+}
+
+// InvitationRepository stores the invitations of guests to join as Complejos.
+type InvitationRepository interface {
+	// Insert stores a new GuestInvitation.
+	Insert(ctx context.Context, invitation *models.GuestInvitation) error
+	// FindByTokenHash returns the GuestInvitation with the given token hash, or ErrNotFound.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*models.GuestInvitation, error)
+	// Accept records that the invitation was accepted by the Complejo at the given time,
+	// unless it already was, and reports whether it was accepted.
+	Accept(ctx context.Context, id, complejoID string, at time.Time) (bool, error)
+	// PurgeExpired removes the invitations never accepted that expired before the given time and returns how
+	// many were removed.
+	PurgeExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SubscriptionEventRepository is the append-only storage of subscription transitions.
+type SubscriptionEventRepository interface {
+	// Append records a new transition. Stored transitions are never modified.
+	Append(ctx context.Context, event *models.SubscriptionEvent) error
+	// FindByEvent returns the transitions of the Event in chronological order.
+	FindByEvent(ctx context.Context, eventID string) ([]models.SubscriptionEvent, error)
+	// FindByUsername returns the transitions of the user with the given username in chronological order.
+	FindByUsername(ctx context.Context, username string) ([]models.SubscriptionEvent, error)
+}
+
+// Transactor runs a function inside a storage transaction.
+// Repositories called with the context passed to fn take part in the transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// OutboxRepository stores outgoing messages until they are dispatched.
+type OutboxRepository interface {
+	// Enqueue stores a new pending message.
+	Enqueue(ctx context.Context, message *models.OutboxMessage) error
+	// Claim atomically picks the oldest pending message of one of the given topics that is due at now
+	// and has been attempted fewer than maxAttempts times. The message's attempts are incremented and
+	// it is hidden from other claimers until now+lease. It returns ErrNotFound when nothing is due.
+	Claim(ctx context.Context, topics []string, now time.Time, lease time.Duration, maxAttempts int) (*models.OutboxMessage, error)
+	// MarkDispatched records the successful delivery of the message.
+	MarkDispatched(ctx context.Context, id string, at time.Time) error
+	// MarkFailed records a failed delivery and schedules the next attempt.
+	MarkFailed(ctx context.Context, id, reason string, retryAt time.Time) error
+}
+
+// FederatedEventRepository stores the events imported from other clubs.
+type FederatedEventRepository interface {
+	// ReplaceClub makes the given events the complete set stored for the club.
+	ReplaceClub(ctx context.Context, club string, events []models.FederatedEvent) error
+	// FindUpcoming returns the imported events taking place at or after from, ordered by date.
+	FindUpcoming(ctx context.Context, from time.Time) ([]models.FederatedEvent, error)
+}
+
+// ReportRepository computes aggregated reports for the admin dashboard.
+type ReportRepository interface {
+	// CohortActivity groups the live Complejos that signed up between from and to by week (starting on Monday, UTC),
+	// with the weeks after sign-up in which each one attended a live Event dated before to.
+	// Complejos without a sign-up time are ignored.
+	CohortActivity(ctx context.Context, from, to time.Time) ([]models.CohortActivity, error)
+	// AttendanceByHour counts the participants of the live Events dated between from and to
+	// by weekday and hour in the given time zone. Slots without attendance are omitted.
+	AttendanceByHour(ctx context.Context, from, to time.Time, location *time.Location) ([]models.AttendanceSlot, error)
+	// MemberActivity counts, for every live Complejo with the "user" role, the past Events attended and the
+	// subscription transitions in the previous window [since, split) and the recent window [split, until).
+	MemberActivity(ctx context.Context, since, split, until time.Time) ([]models.MemberActivity, error)
+	// ClubTotals counts the live Complejos and the live Events dated before the given time.
+	ClubTotals(ctx context.Context, before time.Time) (members, events int64, err error)
+}
+
+// LiftRepository stores the lift history of the Complejos.
+type LiftRepository interface {
+	// Insert stores a new LiftEntry.
+	Insert(ctx context.Context, entry *models.LiftEntry) error
+	// FindByComplejo returns the entries of the Complejo with the given ID, of the lift (of every lift when
+	// empty), oldest first.
+	FindByComplejo(ctx context.Context, complejoID, lift string) ([]models.LiftEntry, error)
+	// MonthlyProgress aggregates the entries of the Complejo with the given ID by calendar month in the given
+	// time zone, for the months with entries, oldest first.
+	MonthlyProgress(ctx context.Context, complejoID string, location *time.Location) ([]models.LiftMonth, error)
+}
+
+// WorkoutRepository stores the workouts logged by the Complejos.
+type WorkoutRepository interface {
+	// Insert stores a new Workout.
+	Insert(ctx context.Context, workout *models.Workout) error
+	// FindByID returns the Workout with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.Workout, error)
+	// FindByComplejo returns the page (skipping offset, at most limit, every one when 0) of the workouts of the
+	// Complejo with the given ID, newest first, and their total number.
+	FindByComplejo(ctx context.Context, complejoID string, offset, limit int) ([]models.Workout, int64, error)
+	// Replace overwrites the stored Workout with the same ID and reports whether it was found.
+	Replace(ctx context.Context, workout *models.Workout) (bool, error)
+	// DeleteByID removes the Workout with the given ID and reports whether it was found.
+	DeleteByID(ctx context.Context, id string) (bool, error)
+	// WeeklyVolume sums the sets, repetitions and volume of the workouts of the Complejo with the given ID since
+	// the given time by week (starting on Monday in the given time zone) and muscle group, for the weeks and
+	// muscle groups trained, oldest first.
+	WeeklyVolume(ctx context.Context, complejoID string, since time.Time, location *time.Location) ([]models.MuscleVolume, error)
+}
+
+// ExerciseRepository stores the exercise library.
+type ExerciseRepository interface {
+	// Insert stores a new Exercise, or returns ErrDuplicate when its name is taken.
+	Insert(ctx context.Context, exercise *models.Exercise) error
+	// FindByID returns the Exercise with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.Exercise, error)
+	// FindByIDs returns the exercises with the given IDs that exist, in no particular order.
+	FindByIDs(ctx context.Context, ids []string) ([]models.Exercise, error)
+	// Search returns the page of the normalized filter of the exercises matching it, ordered by name, and their
+	// total number.
+	Search(ctx context.Context, filter models.ExerciseFilter) ([]models.Exercise, int64, error)
+	// Replace overwrites the stored Exercise with the same ID and reports whether it was found, or returns
+	// ErrDuplicate when its new name is taken.
+	Replace(ctx context.Context, exercise *models.Exercise) (bool, error)
+	// DeleteByID removes the Exercise with the given ID and reports whether it was found.
+	DeleteByID(ctx context.Context, id string) (bool, error)
+}
+
+// NutritionRepository stores the daily nutrition logs of the Complejos.
+type NutritionRepository interface {
+	// Insert stores a new NutritionLog, or returns ErrDuplicate when the Complejo already logged the day.
+	Insert(ctx context.Context, log *models.NutritionLog) error
+	// FindByID returns the NutritionLog with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.NutritionLog, error)
+	// FindByComplejo returns the nutrition logs of the Complejo with the given ID from the day from to the day to
+	// ("2006-01-02", unbounded when empty), oldest first.
+	FindByComplejo(ctx context.Context, complejoID, from, to string) ([]models.NutritionLog, error)
+	// Replace overwrites the stored NutritionLog with the same ID and reports whether it was found, or returns
+	// ErrDuplicate when the Complejo already logged its new day.
+	Replace(ctx context.Context, log *models.NutritionLog) (bool, error)
+	// DeleteByID removes the NutritionLog with the given ID and reports whether it was found.
+	DeleteByID(ctx context.Context, id string) (bool, error)
+}
+
+// ChallengeRepository stores the challenges and their participants.
+type ChallengeRepository interface {
+	// Insert stores a new Challenge.
+	Insert(ctx context.Context, challenge *models.Challenge) error
+	// FindByID returns the Challenge with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.Challenge, error)
+	// Search returns the page of the normalized filter of the challenges with its status at now, latest start
+	// first, and their total number.
+	Search(ctx context.Context, filter models.ChallengeFilter, now time.Time) ([]models.Challenge, int64, error)
+	// FindScorable returns the challenges started at now that were never scored or last scored before their end.
+	FindScorable(ctx context.Context, now time.Time) ([]models.Challenge, error)
+	// DeleteByID removes the Challenge with the given ID and its participants, and reports whether it was found.
+	DeleteByID(ctx context.Context, id string) (bool, error)
+	// AddParticipant stores a new ChallengeParticipant, or returns ErrDuplicate when the Complejo already joined
+	// the Challenge.
+	AddParticipant(ctx context.Context, participant *models.ChallengeParticipant) error
+	// RemoveParticipant removes the Complejo from the participants of the Challenge and reports whether it was one.
+	RemoveParticipant(ctx context.Context, id, complejoID string) (bool, error)
+	// FindParticipants returns the IDs of the participants of the Challenge.
+	FindParticipants(ctx context.Context, id string) ([]string, error)
+	// SetScores stores the scores of the participants of the Challenge, by Complejo ID, and the time of the
+	// scoring.
+	SetScores(ctx context.Context, id string, scores map[string]float64, at time.Time) error
+	// Standings returns the live participants of the Challenge with their current username, highest score
+	// first (earliest joined first on a tie), without their rank.
+	Standings(ctx context.Context, id string) ([]models.ChallengeStanding, error)
+}
+
+// FollowRepository stores who follows whom among the Complejos.
+type FollowRepository interface {
+	// Insert stores a new Follow, or returns ErrDuplicate when the follower already follows the followee.
+	Insert(ctx context.Context, follow *models.Follow) error
+	// Delete removes the Follow of the followee by the follower and reports whether there was one.
+	Delete(ctx context.Context, followerID, followeeID string) (bool, error)
+	// Exists reports whether the follower follows the followee.
+	Exists(ctx context.Context, followerID, followeeID string) (bool, error)
+	// FindFolloweeIDs returns the IDs of the Complejos the follower follows.
+	FindFolloweeIDs(ctx context.Context, followerID string) ([]string, error)
+	// FindFollowers returns the page of the live followers of the Complejo, latest first, and their total
+	// number.
+	FindFollowers(ctx context.Context, complejoID string, offset, limit int) ([]models.FollowEntry, int64, error)
+	// FindFollowing returns the page of the live Complejos the Complejo follows, latest first, and their total
+	// number.
+	FindFollowing(ctx context.Context, complejoID string, offset, limit int) ([]models.FollowEntry, int64, error)
+	// Count returns the numbers of live followers of the Complejo and of live Complejos it follows.
+	Count(ctx context.Context, complejoID string) (followers, following int64, err error)
+}
+
+// BlockRepository stores the Complejos blocked by other Complejos.
+type BlockRepository interface {
+	// Insert stores a new Block, or returns ErrDuplicate when the blocker already blocks the blocked Complejo.
+	Insert(ctx context.Context, block *models.Block) error
+	// Delete removes the Block of the blocked Complejo by the blocker and reports whether there was one.
+	Delete(ctx context.Context, blockerID, blockedID string) (bool, error)
+	// Exists reports whether the blocker blocks the blocked Complejo.
+	Exists(ctx context.Context, blockerID, blockedID string) (bool, error)
+	// FindBlockerIDs returns the IDs of the Complejos blocking the Complejo.
+	FindBlockerIDs(ctx context.Context, blockedID string) ([]string, error)
+	// FindBlocked returns the page of the live Complejos the blocker blocks, latest first, and their total
+	// number.
+	FindBlocked(ctx context.Context, blockerID string, offset, limit int) ([]models.BlockEntry, int64, error)
+}
+
+// PrivacyRepository stores the privacy settings of the Complejos.
+type PrivacyRepository interface {
+	// FindByComplejo returns the PrivacySettings of the Complejo, or ErrNotFound when it never changed them.
+	FindByComplejo(ctx context.Context, complejoID string) (*models.PrivacySettings, error)
+	// FindByComplejos returns the PrivacySettings of those of the Complejos with the given IDs that changed them.
+	FindByComplejos(ctx context.Context, complejoIDs []string) ([]models.PrivacySettings, error)
+	// Save stores the PrivacySettings of their Complejo, replacing the current ones.
+	Save(ctx context.Context, settings *models.PrivacySettings) error
+}
+
+// FeedRepository stores the activity feed, denormalized from the domain events.
+type FeedRepository interface {
+	// Insert stores a new FeedItem, or does nothing when one with the same ID is already stored.
+	Insert(ctx context.Context, item *models.FeedItem) error
+	// Find returns the page of the items of the Complejos or of the groups with the given IDs, latest first, and
+	// their total number.
+	Find(ctx context.Context, complejoIDs, groupIDs []string, offset, limit int) ([]models.FeedItem, int64, error)
+	// DeleteByEvent removes the items of the Event of the given kind, of the Complejo with the given ID or of
+	// every one when it is empty, and returns how many were removed.
+	DeleteByEvent(ctx context.Context, eventID, kind, complejoID string) (int64, error)
+	// DeleteByComplejo removes the items of the Complejo and returns how many were removed.
+	DeleteByComplejo(ctx context.Context, complejoID string) (int64, error)
+}
+
+// GroupRepository stores the groups and their memberships.
+type GroupRepository interface {
+	// Insert stores a new Group.
+	Insert(ctx context.Context, group *models.Group) error
+	// FindByID returns the Group with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.Group, error)
+	// Search returns the page of the normalized filter of the groups by name, and their total number. Only the
+	// groups of the Complejo with the given ID are returned when the filter asks for them.
+	Search(ctx context.Context, filter models.GroupFilter, complejoID string) ([]models.Group, int64, error)
+	// Replace overwrites the name, description and update time of the Group, or returns ErrNotFound.
+	Replace(ctx context.Context, group *models.Group) error
+	// DeleteByID removes the Group with the given ID and its memberships, and reports whether it was found.
+	DeleteByID(ctx context.Context, id string) (bool, error)
+	// FindMembership returns the membership of the Complejo in the Group, or ErrNotFound.
+	FindMembership(ctx context.Context, id, complejoID string) (*models.GroupMembership, error)
+	// FindRoles returns the roles of the Complejo in its groups, by group ID.
+	FindRoles(ctx context.Context, complejoID string) (map[string]string, error)
+	// SetMembership stores the membership of a Complejo in a Group, or changes the role of the stored one
+	// (keeping its ID and join time).
+	SetMembership(ctx context.Context, membership *models.GroupMembership) error
+	// RemoveMembership removes the Complejo from the members of the Group and reports whether it was one.
+	RemoveMembership(ctx context.Context, id, complejoID string) (bool, error)
+	// CountOwners returns the number of owners of the Group.
+	CountOwners(ctx context.Context, id string) (int64, error)
+	// FindMembers returns the page of the live members of the Group with their current username, owners first
+	// then by join time, and their total number.
+	FindMembers(ctx context.Context, id string, offset, limit int) ([]models.GroupMember, int64, error)
+	// FindMemberIDs returns the IDs of the members of the Group.
+	FindMemberIDs(ctx context.Context, id string) ([]string, error)
+}
+
+// LeaderboardRepository ranks the live Complejos by their lifts.
+type LeaderboardRepository interface {
+	// Rank ranks the live Complejos matching the normalized query by the kilos of its lift, or by their score
+	// when it has a scoring, best first; Complejos with nothing to rank (no lift recorded, or no score) are left
+	// out. It returns the page of the query, the entry of the Complejo with the given ID (nil when it is not
+	// ranked or the ID is empty) and the number of Complejos ranked.
+	Rank(ctx context.Context, query models.LeaderboardQuery, complejoID string) ([]models.LeaderboardEntry, *models.LeaderboardEntry, int64, error)
+}
+
+// PersonalRecordRepository logs the lift records set by the Complejos.
+type PersonalRecordRepository interface {
+	// Insert stores a new PersonalRecord, or does nothing when one with the same ID is already stored.
+	Insert(ctx context.Context, record *models.PersonalRecord) error
+	// TotalSince returns the total in kilograms of the records set from the given time on.
+	TotalSince(ctx context.Context, since time.Time) (float64, error)
+}
+
+// PaymentRepository stores the payments recorded from Stripe and aggregates them into revenue.
+type PaymentRepository interface {
+	// Upsert stores the payment, or updates the stored one with the same ID. The refunded amount never decreases,
+	// so webhooks delivered out of order cannot undo a refund.
+	Upsert(ctx context.Context, payment *models.Payment) error
+	// Revenue aggregates the payments made between from and to by Event, month (in the given location) and currency.
+	Revenue(ctx context.Context, from, to time.Time, location *time.Location) ([]models.RevenueRow, error)
+}
+
+// ExpenseRepository stores the expenses of Events.
+type ExpenseRepository interface {
+	// Insert stores a new Expense.
+	Insert(ctx context.Context, expense *models.Expense) error
+	// FindByEvent returns the expenses of the Event, oldest first.
+	FindByEvent(ctx context.Context, eventID string) ([]models.Expense, error)
+	// FindByID returns the Expense of the Event with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, eventID, id string) (*models.Expense, error)
+	// Replace overwrites the stored Expense with the same ID and Event, and reports whether it was found.
+	Replace(ctx context.Context, expense *models.Expense) (bool, error)
+	// DeleteByID removes the Expense of the Event with the given ID and reports whether it was found.
+	DeleteByID(ctx context.Context, eventID, id string) (bool, error)
+	// Totals sums the expenses incurred between from and to by Event, month (in the given location) and currency.
+	Totals(ctx context.Context, from, to time.Time, location *time.Location) ([]models.ExpenseRow, error)
+}
+
+// InventoryRepository stores the club equipment and its loans.
+type InventoryRepository interface {
+	// InsertItem stores a new InventoryItem.
+	InsertItem(ctx context.Context, item *models.InventoryItem) error
+	// FindItems returns every InventoryItem, ordered by name.
+	FindItems(ctx context.Context) ([]models.InventoryItem, error)
+	// FindItemByID returns the InventoryItem with the given ID, or ErrNotFound.
+	FindItemByID(ctx context.Context, id string) (*models.InventoryItem, error)
+	// ReplaceItem overwrites the stored InventoryItem with the same ID and reports whether it was found.
+	ReplaceItem(ctx context.Context, item *models.InventoryItem) (bool, error)
+	// DeleteItem removes the InventoryItem with the given ID and reports whether it was found.
+	DeleteItem(ctx context.Context, id string) (bool, error)
+	// InsertLoan stores a new Loan.
+	InsertLoan(ctx context.Context, loan *models.Loan) error
+	// FindLoans returns the loans matching the filter at the given time, most recently lent first.
+	FindLoans(ctx context.Context, filter models.LoanFilter, now time.Time) ([]models.Loan, error)
+	// FindLoanByID returns the Loan with the given ID, or ErrNotFound.
+	FindLoanByID(ctx context.Context, id string) (*models.Loan, error)
+	// MarkReturned records the return of the open Loan with the given ID and reports whether it was found.
+	MarkReturned(ctx context.Context, id string, at time.Time) (bool, error)
+	// MarkReminded records that an overdue reminder of the Loan was sent at the given time.
+	MarkReminded(ctx context.Context, id string, at time.Time) error
+}
+
+// LostFoundRepository stores the posts of the lost-and-found board and their claims.
+type LostFoundRepository interface {
+	// Insert stores a new LostItem.
+	Insert(ctx context.Context, item *models.LostItem) error
+	// FindOpen returns the open posts not expired at now (of the given kind when not empty), newest first.
+	FindOpen(ctx context.Context, kind string, now time.Time) ([]models.LostItem, error)
+	// FindByID returns the LostItem with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.LostItem, error)
+	// Release opens the held LostItem with the given ID and reports whether it was found.
+	Release(ctx context.Context, id string) (bool, error)
+	// Resolve marks the open LostItem with the given ID as resolved and reports whether it was found.
+	Resolve(ctx context.Context, id string, at time.Time) (bool, error)
+	// DeleteByID removes the LostItem with the given ID and its claims, and reports whether it was found.
+	DeleteByID(ctx context.Context, id string) (bool, error)
+	// PurgeExpired removes the posts expired at now and their claims, and returns how many posts were removed.
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+	// InsertClaim stores a new Claim.
+	InsertClaim(ctx context.Context, claim *models.Claim) error
+	// FindClaims returns the claims of the LostItem, oldest first.
+	FindClaims(ctx context.Context, itemID string) ([]models.Claim, error)
+	// DecideClaim stores the decision of the pending Claim and reports whether it was found.
+	DecideClaim(ctx context.Context, claim *models.Claim) (bool, error)
+	// RejectPending rejects the pending claims of the LostItem and returns how many were rejected.
+	RejectPending(ctx context.Context, itemID, decidedBy, note string, at time.Time) (int64, error)
+}
+
+// VolunteerRepository stores the volunteer shifts of events and their sign-ups.
+type VolunteerRepository interface {
+	// InsertShift stores a new VolunteerShift.
+	InsertShift(ctx context.Context, shift *models.VolunteerShift) error
+	// FindShifts returns the shifts of the Event, ordered by start and role.
+	FindShifts(ctx context.Context, eventID string) ([]models.VolunteerShift, error)
+	// FindShiftByID returns the VolunteerShift with the given ID, or ErrNotFound.
+	FindShiftByID(ctx context.Context, id string) (*models.VolunteerShift, error)
+	// DeleteShift removes the VolunteerShift with the given ID and its sign-ups, and reports whether it was found.
+	DeleteShift(ctx context.Context, id string) (bool, error)
+	// AddVolunteer signs the Complejo up for the shift, unless the shift is full or the Complejo already signed up,
+	// and reports whether it was signed up.
+	AddVolunteer(ctx context.Context, shiftID string, volunteer models.Volunteer) (bool, error)
+	// RemoveVolunteer withdraws the Complejo from the shift and reports whether it had signed up.
+	RemoveVolunteer(ctx context.Context, shiftID, complejoID string) (bool, error)
+	// FindStartingBetween returns the shifts of live events starting in [from, to), ordered by start.
+	FindStartingBetween(ctx context.Context, from, to time.Time) ([]models.VolunteerShift, error)
+	// MarkReminded records that the Complejo was reminded of the shift at the given time.
+	MarkReminded(ctx context.Context, shiftID, complejoID string, at time.Time) error
+	// Hours returns the hours the Complejo volunteered in shifts of live events ended before the given time.
+	Hours(ctx context.Context, complejoID string, before time.Time) (float64, error)
+	// Leaderboard returns at most limit live Complejos with the most hours volunteered in shifts of live events
+	// ended before the given time, most hours first.
+	Leaderboard(ctx context.Context, before time.Time, limit int) ([]models.VolunteerHours, error)
+}
+
+// PhotoRepository stores the photos of the event albums and the consent of the Complejos tagged in them.
+// The photo files themselves are kept in the object store.
+type PhotoRepository interface {
+	// Insert stores a new EventPhoto with its tags.
+	Insert(ctx context.Context, photo *models.EventPhoto) error
+	// FindByEvent returns the photos of the album of the Event, oldest first.
+	FindByEvent(ctx context.Context, eventID string) ([]models.EventPhoto, error)
+	// FindByID returns the EventPhoto with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.EventPhoto, error)
+	// Approve approves the pending photo on behalf of the organizer and reports whether a pending photo was found.
+	Approve(ctx context.Context, id, approvedBy string, at time.Time) (bool, error)
+	// GrantTag records that the Complejo tagged in the photo granted its consent, and reports whether
+	// a pending tag was found.
+	GrantTag(ctx context.Context, photoID, complejoID string, at time.Time) (bool, error)
+	// SetThumbnail stores the thumbnail of the photo and reports whether it was found.
+	SetThumbnail(ctx context.Context, id, thumbnail string) (bool, error)
+	// Delete removes the EventPhoto with the given ID and its tags, and reports whether it was found.
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+// DeviceRepository stores the devices of the Complejos receiving push notifications. Tokens are unique.
+type DeviceRepository interface {
+	// Upsert stores the Device, or updates the Device registered with the same token (keeping its ID and
+	// creation time, which are set on the given Device).
+	Upsert(ctx context.Context, device *models.Device) error
+	// FindAll returns every Device.
+	FindAll(ctx context.Context) ([]models.Device, error)
+	// FindByComplejos returns the Devices of the Complejos with the given IDs, most recently registered first.
+	FindByComplejos(ctx context.Context, complejoIDs []string) ([]models.Device, error)
+	// Delete removes the Device with the given ID of the Complejo and reports whether it was found.
+	Delete(ctx context.Context, id, complejoID string) (bool, error)
+	// DeleteByToken removes the Device registered with the token, if any.
+	DeleteByToken(ctx context.Context, token string) error
+}
+
+// OffboardingRepository stores the Offboarding of the club and removes its data.
+type OffboardingRepository interface {
+	// Find returns the Offboarding, or ErrNotFound.
+	Find(ctx context.Context) (*models.Offboarding, error)
+	// Save stores the Offboarding, replacing the current one.
+	Save(ctx context.Context, offboarding *models.Offboarding) error
+	// Delete removes the Offboarding and reports whether there was one.
+	Delete(ctx context.Context) (bool, error)
+	// Purge removes every record but the Offboarding and the applied migrations, and returns how many records
+	// were removed from each collection or table.
+	Purge(ctx context.Context) (map[string]int64, error)
+}
+
+// SettingsRepository stores the settings of the club chosen by its admins.
+type SettingsRepository interface {
+	// FindListing returns the ListingSettings, or ErrNotFound when the admins never changed them.
+	FindListing(ctx context.Context) (*models.ListingSettings, error)
+	// SaveListing stores the ListingSettings, replacing the current ones.
+	SaveListing(ctx context.Context, settings *models.ListingSettings) error
+}
+
+// JobRepository stores the Jobs of the job queue. Enqueuing participates in the transaction of the context, so
+// a Job can be enqueued with the change that needs it.
+type JobRepository interface {
+	// Enqueue stores a new pending Job, or does nothing when a Job with the same ID is already stored.
+	Enqueue(ctx context.Context, job *models.Job) error
+	// Claim atomically picks the pending Job of the given kinds due the longest, counts the attempt and hides
+	// the Job from the other workers until the lease expires, or returns ErrNotFound.
+	Claim(ctx context.Context, kinds []string, now time.Time, lease time.Duration) (*models.Job, error)
+	// Complete marks the Job done.
+	Complete(ctx context.Context, id string, at time.Time) error
+	// Retry records the failure of the Job and schedules its next attempt.
+	Retry(ctx context.Context, id, reason string, runAt time.Time) error
+	// Fail records the failure of the Job and gives it up.
+	Fail(ctx context.Context, id, reason string, at time.Time) error
+	// Purge removes the done and failed Jobs finished before the given time and returns how many were removed.
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WebhookRepository stores the Webhooks and their deliveries.
+type WebhookRepository interface {
+	// Insert stores a new Webhook.
+	Insert(ctx context.Context, webhook *models.Webhook) error
+	// FindAll returns every Webhook, oldest first.
+	FindAll(ctx context.Context) ([]models.Webhook, error)
+	// FindByID returns the Webhook with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.Webhook, error)
+	// FindByTopic returns the active Webhooks receiving the topic.
+	FindByTopic(ctx context.Context, topic string) ([]models.Webhook, error)
+	// Update replaces the Webhook and reports whether it was found.
+	Update(ctx context.Context, webhook *models.Webhook) (bool, error)
+	// Delete removes the Webhook with its deliveries and reports whether it was found.
+	Delete(ctx context.Context, id string) (bool, error)
+
+	// Enqueue stores the new deliveries, skipping those already stored with the same ID.
+	Enqueue(ctx context.Context, deliveries []models.WebhookDelivery) error
+	// Claim atomically picks the pending delivery due the longest and hides it from the other workers until the
+	// lease expires, or returns ErrNotFound.
+	Claim(ctx context.Context, now time.Time, lease time.Duration) (*models.WebhookDelivery, error)
+	// RecordAttempt appends the attempt to the delivery and sets its status, and the time of its next attempt
+	// while pending or of its delivery once delivered.
+	RecordAttempt(ctx context.Context, id string, attempt models.WebhookAttempt, status string, at time.Time) error
+	// FindDeliveries returns the deliveries of the Webhook matching the filter, at most filter.Limit, newest first.
+	FindDeliveries(ctx context.Context, webhookID string, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error)
+	// PurgeDeliveries removes the delivered and failed deliveries created before the given time and returns how
+	// many were removed.
+	PurgeDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ServiceAccountRepository stores the ServiceAccounts and their tokens.
+type ServiceAccountRepository interface {
+	// Insert stores a new ServiceAccount, or returns ErrDuplicate when its name is taken.
+	Insert(ctx context.Context, account *models.ServiceAccount) error
+	// FindAll returns every ServiceAccount, oldest first, without their tokens.
+	FindAll(ctx context.Context) ([]models.ServiceAccount, error)
+	// FindByID returns the ServiceAccount with the given ID without its tokens, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.ServiceAccount, error)
+	// Update replaces the ServiceAccount and reports whether it was found, or returns ErrDuplicate when its
+	// name is taken.
+	Update(ctx context.Context, account *models.ServiceAccount) (bool, error)
+	// Delete removes the ServiceAccount with its tokens and reports whether it was found.
+	Delete(ctx context.Context, id string) (bool, error)
+
+	// InsertToken stores a new ServiceToken.
+	InsertToken(ctx context.Context, token *models.ServiceToken) error
+	// FindTokens returns the tokens of the ServiceAccount expiring after the given time, oldest first.
+	FindTokens(ctx context.Context, accountID string, after time.Time) ([]models.ServiceToken, error)
+	// FindTokenByHash returns the ServiceToken with the given hash, or ErrNotFound.
+	FindTokenByHash(ctx context.Context, hash string) (*models.ServiceToken, error)
+	// ExpireTokens brings the expiry of the tokens of the ServiceAccount expiring after the given time forward
+	// to it.
+	ExpireTokens(ctx context.Context, accountID string, at time.Time) error
+	// PurgeExpiredTokens removes the tokens expired before the given time and returns how many were removed.
+	PurgeExpiredTokens(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ErasureRepository erases a Complejo from the records of the other resources, for the right to be forgotten.
+type ErasureRepository interface {
+	// Anonymize replaces the username of the Complejo with the placeholder wherever it was copied (the guests it
+	// brought, its subscription history, volunteer sign-ups, loans and claims) and removes its tags from the
+	// photos. It returns how many records were changed by collection or table.
+	Anonymize(ctx context.Context, complejoID, username, placeholder string) (map[string]int64, error)
+	// RemoveContent removes the photos the Complejo uploaded, its lost-and-found posts with their claims, its
+	// content held for review, its devices, its lift history, its workouts, its nutrition logs, its challenge
+	// participations, its group memberships, its follows and blocks, both ways, its activity in the feed and the
+	// content it reported. It returns the object store keys of the removed photos and how many records were removed by collection or table.
+	RemoveContent(ctx context.Context, complejoID string) ([]string, map[string]int64, error)
+}
+
+// AuditRepository is the append-only storage of the audit log.
+type AuditRepository interface {
+	// Append records a new entry. Stored entries are never modified.
+	Append(ctx context.Context, entry *models.AuditEntry) error
+	// FindRecent returns at most limit entries of the action (of every action when empty), most recent first.
+	FindRecent(ctx context.Context, action string, limit int) ([]models.AuditEntry, error)
+}
+
+// DataExportRepository stores the exports of their data requested by the Complejos. The archives themselves are
+// kept in the object store.
+type DataExportRepository interface {
+	// Insert stores a new DataExport.
+	Insert(ctx context.Context, export *models.DataExport) error
+	// FindLatest returns the DataExport most recently requested by the Complejo, or ErrNotFound.
+	FindLatest(ctx context.Context, complejoID string) (*models.DataExport, error)
+	// FindByID returns the DataExport with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.DataExport, error)
+	// MarkReady records that the archive of the pending DataExport was generated, and reports whether a pending
+	// DataExport was found.
+	MarkReady(ctx context.Context, id string, size int64, at, expiresAt time.Time) (bool, error)
+	// FindExpired returns the exports whose archive expired before the given time.
+	FindExpired(ctx context.Context, before time.Time) ([]models.DataExport, error)
+	// Delete removes the DataExport with the given ID and reports whether it was found.
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+// AnnouncementRepository stores the Announcements of the admins.
+type AnnouncementRepository interface {
+	// Insert stores a new Announcement.
+	Insert(ctx context.Context, announcement *models.Announcement) error
+	// FindRecent returns at most limit Announcements, newest first.
+	FindRecent(ctx context.Context, limit int) ([]models.Announcement, error)
+	// FindByID returns the Announcement with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.Announcement, error)
+	// Update replaces the current version of the Announcement and reports whether it was found.
+	Update(ctx context.Context, announcement *models.Announcement) (bool, error)
+}
+
+// ContentVersionRepository stores the versions of the versioned documents. Versions are only added, and a
+// document cannot have two versions with the same number (ErrDuplicate).
+type ContentVersionRepository interface {
+	// Insert stores a new ContentVersion.
+	Insert(ctx context.Context, version *models.ContentVersion) error
+	// FindLatest returns the latest version of the document, or ErrNotFound.
+	FindLatest(ctx context.Context, documentType, documentID string) (*models.ContentVersion, error)
+	// FindAll returns every version of the document, the latest first.
+	FindAll(ctx context.Context, documentType, documentID string) ([]models.ContentVersion, error)
+	// FindVersion returns the given version of the document, or ErrNotFound.
+	FindVersion(ctx context.Context, documentType, documentID string, version int) (*models.ContentVersion, error)
+}
+
+// ModerationRepository stores the user content held by the content filter.
+type ModerationRepository interface {
+	// Insert stores a new ContentHold.
+	Insert(ctx context.Context, hold *models.ContentHold) error
+	// FindByStatus returns the holds with the given status, oldest first.
+	FindByStatus(ctx context.Context, status string) ([]models.ContentHold, error)
+	// FindByID returns the ContentHold with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.ContentHold, error)
+	// Decide stores the decision of the pending ContentHold and reports whether it was found.
+	Decide(ctx context.Context, hold *models.ContentHold) (bool, error)
+}
+
+// ContentReportRepository stores the content reported by the users, waiting for review.
+type ContentReportRepository interface {
+	// Insert stores a new ContentReport, or returns ErrDuplicate when the reporter already has a pending report
+	// of the same content.
+	Insert(ctx context.Context, report *models.ContentReport) error
+	// Find returns the page of the reports with the given status, and of the given kind unless it is empty,
+	// oldest first, and their total number.
+	Find(ctx context.Context, status, kind string, offset, limit int) ([]models.ContentReport, int64, error)
+	// FindByID returns the ContentReport with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.ContentReport, error)
+	// Decide stores the decision of the pending ContentReport and reports whether it was found.
+	Decide(ctx context.Context, report *models.ContentReport) (bool, error)
+	// DecideByTarget stores the decision on every pending report of the content and returns how many there were.
+	DecideByTarget(ctx context.Context, report *models.ContentReport) (int64, error)
+}
+
+// AnalyticsRepository stores client analytics events.
+type AnalyticsRepository interface {
+	// InsertMany stores a batch of events.
+	InsertMany(ctx context.Context, events []models.AnalyticsEvent) error
+}
+
+// JournalRepository stores the envelopes of failed requests.
+type JournalRepository interface {
+	// Append records a new entry.
+	Append(ctx context.Context, entry *models.JournalEntry) error
+	// FindRecent returns at most limit entries, most recent first.
+	FindRecent(ctx context.Context, limit int) ([]models.JournalEntry, error)
+	// FindByID returns the entry with the given ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*models.JournalEntry, error)
+}
@@ -0,0 +1,94 @@
+// responses.go
+package responses
+
+import (
+	"net/http"
+
+	"los-complejos-backend/apperrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Envelope is the JSON shape shared by every API response.
+type Envelope struct {
+	Status  string      `json:"status"`            // "success" or "error"
+	Code    int         `json:"code"`              // HTTP status code
+	Message string      `json:"message,omitempty"` // Human-readable message
+	Error   string      `json:"error,omitempty"`   // Machine-readable error code (errors only)
+	Details interface{} `json:"details,omitempty"` // Structured error details (errors only)
+	Data    interface{} `json:"data,omitempty"`    // Response payload (successes only)
+	Meta    interface{} `json:"meta,omitempty"`    // Extra information such as pagination
+}
+
+// Pagination is the metadata of a paginated listing.
+type Pagination struct {
+	Page  int   `json:"page"`  // 1-based page number
+	Limit int   `json:"limit"` // Page size
+	Total int64 `json:"total"` // Number of items across every page
+	Pages int64 `json:"pages"` // Number of pages
+}
+
+// NewPagination computes the pagination metadata of a page.
+func NewPagination(page, limit int, total int64) Pagination {
+	pages := int64(0)
+	if limit > 0 {
+		pages = (total + int64(limit) - 1) / int64(limit)
+	}
+	return Pagination{Page: page, Limit: limit, Total: total, Pages: pages}
+}
+
+// OK writes a 200 response with the given data.
+func OK(c *gin.Context, data interface{}) {
+	write(c, http.StatusOK, Envelope{Status: "success", Code: http.StatusOK, Data: data})
+}
+
+// OKWithMeta writes a 200 response with the given data and metadata.
+func OKWithMeta(c *gin.Context, data, meta interface{}) {
+	write(c, http.StatusOK, Envelope{Status: "success", Code: http.StatusOK, Data: data, Meta: meta})
+}
+
+// Created writes a 201 response with the created resource.
+func Created(c *gin.Context, data interface{}) {
+	write(c, http.StatusCreated, Envelope{Status: "success", Code: http.StatusCreated, Data: data})
+}
+
+// Accepted writes a 202 response for work that completes in the background.
+func Accepted(c *gin.Context, data interface{}) {
+	write(c, http.StatusAccepted, Envelope{Status: "success", Code: http.StatusAccepted, Data: data})
+}
+
+// Message writes a success response that carries only a message.
+func Message(c *gin.Context, code int, message string) {
+	write(c, code, Envelope{Status: "success", Code: code, Message: message})
+}
+
+// NoContent writes a 204 response without a body.
+func NoContent(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+// Error writes an error response with the given HTTP status.
+// Typed errors contribute their machine-readable code, message and details;
+// other errors are reported with a generic message so internals do not leak.
+func Error(c *gin.Context, code int, err error) {
+	appErr := apperrors.From(err)
+	write(c, code, Envelope{
+		Status:  "error",
+		Code:    code,
+		Message: appErr.Message,
+		Error:   appErr.Code,
+		Details: appErr.Details,
+	})
+}
+
+// write serializes the envelope with Marshal. An envelope that cannot be serialized (e.g. a NaN number) is
+// replaced by a 500 error.
+func write(c *gin.Context, code int, envelope Envelope) {
+	data, err := Marshal(envelope)
+	if err != nil {
+		c.Error(err)
+		code = http.StatusInternalServerError
+		data, _ = Marshal(Envelope{Status: "error", Code: code, Message: "Internal server error", Error: apperrors.CodeInternal})
+	}
+	c.Data(code, "application/json; charset=utf-8", data)
+}
@@ -0,0 +1,334 @@
+// serializer.go
+package responses
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// TimeLayout is the format of every timestamp of the responses: RFC 3339 in UTC with milliseconds, so timestamps
+// always have the same shape (e.g. "2026-10-16T12:00:00.000Z").
+const TimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// Field naming of the responses.
+const (
+	NamingSnakeCase = "snake_case" // Every field name in snake_case
+	NamingDeclared  = "declared"   // The names of the JSON tags, and the Go names of untagged fields
+)
+
+// JSONOptions configures how the responses are serialized. Whatever the options, empty and missing lists are
+// sent as [] and empty maps as {} (never null), unless their field is marked omitempty, and timestamps follow
+// TimeLayout.
+type JSONOptions struct {
+	Naming   string // NamingSnakeCase (default) or NamingDeclared
+	OmitNull bool   // Leave out the fields whose value is null instead of sending null
+}
+
+var (
+	optionsMu   sync.RWMutex
+	jsonOptions = JSONOptions{Naming: NamingSnakeCase}
+)
+
+// SetJSONOptions replaces the options of the serializer.
+func SetJSONOptions(opts JSONOptions) {
+	optionsMu.Lock()
+	defer optionsMu.Unlock()
+	jsonOptions = opts
+}
+
+// Marshal encodes v as JSON like encoding/json, following the JSONOptions. It is used for every response
+// body, and by the handlers writing JSON outside of an Envelope (e.g. streams).
+func Marshal(v interface{}) ([]byte, error) {
+	optionsMu.RLock()
+	e := &encoder{opts: jsonOptions}
+	optionsMu.RUnlock()
+
+	if err := e.encode(reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// encoder writes one JSON document.
+type encoder struct {
+	buf  bytes.Buffer
+	opts JSONOptions
+}
+
+// encode writes the value.
+func (e *encoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		e.buf.WriteString("null")
+		return nil
+	}
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		e.buf.WriteString("null")
+		return nil
+	}
+
+	t := v.Type()
+	switch {
+	case t.Kind() == reflect.Pointer && t.Elem() == timeType:
+		return e.encode(v.Elem())
+	case t == timeType:
+		e.buf.WriteByte('"')
+		e.buf.WriteString(v.Interface().(time.Time).UTC().Format(TimeLayout))
+		e.buf.WriteByte('"')
+		return nil
+	case t.Implements(marshalerType):
+		data, err := v.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return err
+		}
+		return json.Compact(&e.buf, data)
+	case t.Implements(textMarshalerType):
+		return e.primitive(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return e.encode(v.Elem())
+	case reflect.Struct:
+		return e.object(v)
+	case reflect.Map:
+		return e.dictionary(v)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return e.primitive(v) // Base64, as encoding/json
+		}
+		return e.list(v)
+	case reflect.Array:
+		return e.list(v)
+	default:
+		return e.primitive(v)
+	}
+}
+
+// primitive writes the value with encoding/json.
+func (e *encoder) primitive(v reflect.Value) error {
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	e.buf.Write(data)
+	return nil
+}
+
+// list writes a slice or an array; nil slices are written as [].
+func (e *encoder) list(v reflect.Value) error {
+	e.buf.WriteByte('[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err := e.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte(']')
+	return nil
+}
+
+// dictionary writes a map, its keys sorted like encoding/json; nil maps are written as {}. Keys are data, so
+// they keep their case.
+func (e *encoder) dictionary(v reflect.Value) error {
+	if v.Type().Key().Kind() != reflect.String {
+		return e.primitive(v)
+	}
+
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	e.buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key.String())
+		e.buf.Write(name)
+		e.buf.WriteByte(':')
+		if err := e.encode(v.MapIndex(key)); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+// object writes a struct.
+func (e *encoder) object(v reflect.Value) error {
+	e.buf.WriteByte('{')
+	first := true
+	for _, f := range fieldsOf(v.Type(), e.opts.Naming) {
+		value, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && isEmpty(value)) || (e.opts.OmitNull && isNull(value)) {
+			continue
+		}
+		if !first {
+			e.buf.WriteByte(',')
+		}
+		first = false
+		e.buf.Write(f.quoted)
+		e.buf.WriteByte(':')
+		if err := e.encode(value); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+// field is a serialized field of a struct.
+type field struct {
+	name      string
+	quoted    []byte // JSON-quoted name
+	index     []int  // Path of the field through the embedded structs
+	omitEmpty bool
+	tagged    bool
+}
+
+// fieldCache keeps the fields of the struct types by type and naming.
+var fieldCache sync.Map
+
+type fieldKey struct {
+	t      reflect.Type
+	naming string
+}
+
+// fieldsOf returns the serialized fields of the struct type in declaration order, with the fields of untagged
+// embedded structs promoted as encoding/json does: a name declared closer to the outer struct wins.
+func fieldsOf(t reflect.Type, naming string) []field {
+	key := fieldKey{t: t, naming: naming}
+	if cached, ok := fieldCache.Load(key); ok {
+		return cached.([]field)
+	}
+
+	var all []field
+	collectFields(t, nil, naming, &all)
+
+	// Keep the shallowest field of each name (a tagged one among equals)
+	best := make(map[string]field)
+	for _, f := range all {
+		current, seen := best[f.name]
+		if !seen || len(f.index) < len(current.index) || (len(f.index) == len(current.index) && f.tagged && !current.tagged) {
+			best[f.name] = f
+		}
+	}
+	fields := make([]field, 0, len(best))
+	for _, f := range all {
+		if chosen := best[f.name]; reflect.DeepEqual(chosen.index, f.index) {
+			fields = append(fields, f)
+		}
+	}
+
+	fieldCache.Store(key, fields)
+	return fields
+}
+
+// collectFields appends the fields of the struct type, reached through the given index path.
+func collectFields(t reflect.Type, prefix []int, naming string, out *[]field) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		index := append(append([]int(nil), prefix...), i)
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectFields(ft, index, naming, out)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		tagged := name != ""
+		if !tagged {
+			name = sf.Name
+		}
+		if naming != NamingDeclared {
+			name = snakeCase(name)
+		}
+		quoted, _ := json.Marshal(name)
+		*out = append(*out, field{
+			name:      name,
+			quoted:    quoted,
+			index:     index,
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			tagged:    tagged,
+		})
+	}
+}
+
+// fieldByIndex returns the field at the index path, or false when it is reached through a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmpty reports whether the value is empty in the sense of the omitempty option.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return v.IsZero()
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// isNull reports whether the value is written as null.
+func isNull(v reflect.Value) bool {
+	return (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil()
+}
+
+// snakeCase converts a field name to snake_case: "RSVPCounts" becomes "rsvp_counts" and "createdAt"
+// "created_at"; names already in snake_case, such as "_id", are kept.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			acronymEnd := i > 0 && unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || acronymEnd {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
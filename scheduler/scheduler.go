@@ -0,0 +1,208 @@
+// scheduler.go
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"los-complejos-backend/clock"
+)
+
+// Task is a recurring task. It returns how many items it handled (e.g. reminders sent or records removed).
+type Task func(ctx context.Context) (int64, error)
+
+// Stats is a snapshot of the metrics of a task since the server started.
+type Stats struct {
+	Name         string     `json:"name"`          // Name of the task
+	Interval     string     `json:"interval"`      // Time between two runs
+	Enabled      bool       `json:"enabled"`       // Whether the task runs on this instance
+	Running      bool       `json:"running"`       // Whether a run is in progress
+	Runs         uint64     `json:"runs"`          // Runs finished
+	Failures     uint64     `json:"failures"`      // Runs that returned an error
+	Items        int64      `json:"items"`         // Items handled by every run
+	LastRunAt    *time.Time `json:"last_run_at"`   // Start of the last finished run
+	LastDuration string     `json:"last_duration"` // Duration of the last finished run
+	LastItems    int64      `json:"last_items"`    // Items handled by the last finished run
+	LastError    string     `json:"last_error"`    // Error of the last run, empty when it succeeded
+	NextRunAt    *time.Time `json:"next_run_at"`   // When the next run is due
+}
+
+// task is a Task scheduled by the Scheduler, with its metrics.
+type task struct {
+	name     string
+	interval time.Duration
+	run      Task
+	enabled  bool
+
+	// Guarded by Scheduler.mu
+	running   bool
+	runs      uint64
+	failures  uint64
+	items     int64
+	lastRunAt *time.Time
+	lastTook  time.Duration
+	lastItems int64
+	lastError string
+	nextRunAt *time.Time
+}
+
+// Scheduler runs the recurring tasks of the API, each on its own interval, and keeps their metrics. A task runs
+// once as soon as the Scheduler starts, then every interval; a run that takes longer than the interval delays the
+// next one instead of overlapping it.
+type Scheduler struct {
+	clock  clock.Clock
+	logger *slog.Logger
+
+	mu    sync.Mutex
+	tasks []*task
+	byKey map[string]*task
+}
+
+// New creates a Scheduler without tasks.
+func New(clk clock.Clock, logger *slog.Logger) *Scheduler {
+	return &Scheduler{clock: clk, logger: logger, byKey: map[string]*task{}}
+}
+
+// Add schedules the task under the name every interval. It panics when the name is already taken or the interval
+// is not positive, which are programming errors.
+func (s *Scheduler) Add(name string, interval time.Duration, run Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, taken := s.byKey[name]; taken {
+		panic(fmt.Sprintf("scheduler: task %q added twice", name))
+	}
+	if interval <= 0 {
+		panic(fmt.Sprintf("scheduler: task %q has a non-positive interval", name))
+	}
+	t := &task{name: name, interval: interval, run: run, enabled: true}
+	s.tasks = append(s.tasks, t)
+	s.byKey[name] = t
+}
+
+// Disable keeps the named tasks from running on this instance. It returns an error naming the first unknown task.
+func (s *Scheduler) Disable(names ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, name := range names {
+		t, ok := s.byKey[name]
+		if !ok {
+			return fmt.Errorf("unknown scheduled task %q", name)
+		}
+		t.enabled = false
+	}
+	return nil
+}
+
+// Stats returns a snapshot of the metrics of every task, in the order they were added.
+func (s *Scheduler) Stats() []Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := make([]Stats, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		stat := Stats{
+			Name:      t.name,
+			Interval:  t.interval.String(),
+			Enabled:   t.enabled,
+			Running:   t.running,
+			Runs:      t.runs,
+			Failures:  t.failures,
+			Items:     t.items,
+			LastRunAt: t.lastRunAt,
+			LastItems: t.lastItems,
+			LastError: t.lastError,
+			NextRunAt: t.nextRunAt,
+		}
+		if t.lastRunAt != nil {
+			stat.LastDuration = t.lastTook.String()
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+// Run runs the enabled tasks until the context is cancelled, and waits for the runs in progress to return.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	tasks := make([]*task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		if t.enabled {
+			tasks = append(tasks, t)
+		} else {
+			s.logger.Info("scheduled task disabled", "task", t.name)
+		}
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, t := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, t)
+		}()
+	}
+	wg.Wait()
+}
+
+// loop runs the task every interval until the context is cancelled.
+func (s *Scheduler) loop(ctx context.Context, t *task) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, t)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs the task, turning a panic into a failure, and records its metrics.
+func (s *Scheduler) runOnce(ctx context.Context, t *task) {
+	start := s.clock.Now()
+	s.mu.Lock()
+	t.running = true
+	s.mu.Unlock()
+
+	items, err := func() (items int64, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = fmt.Errorf("panic: %v", recovered)
+			}
+		}()
+		return t.run(ctx)
+	}()
+
+	end := s.clock.Now()
+	next := start.Add(t.interval)
+	if next.Before(end) {
+		next = end
+	}
+	s.mu.Lock()
+	t.running = false
+	t.runs++
+	t.items += items
+	t.lastRunAt = &start
+	t.lastTook = end.Sub(start)
+	t.lastItems = items
+	t.lastError = ""
+	t.nextRunAt = &next
+	if err != nil {
+		t.failures++
+		t.lastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	switch {
+	case err != nil && ctx.Err() == nil:
+		s.logger.Error("scheduled task failed", "task", t.name, "error", err)
+	case err == nil && items > 0:
+		s.logger.Info("scheduled task done", "task", t.name, "items", items, "duration", end.Sub(start))
+	}
+}
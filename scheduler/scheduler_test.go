@@ -0,0 +1,78 @@
+// scheduler_test.go
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"los-complejos-backend/clock"
+)
+
+// newScheduler returns a Scheduler on a fake clock, discarding its logs.
+func newScheduler(t *testing.T) (*Scheduler, *clock.Fake) {
+	t.Helper()
+	clk := clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	return New(clk, slog.New(slog.NewTextHandler(io.Discard, nil))), clk
+}
+
+func TestRunOnceSchedulesTheNextRunAnIntervalAfterTheStart(t *testing.T) {
+	s, clk := newScheduler(t)
+	start := clk.Now()
+	s.Add("reminders", time.Hour, func(context.Context) (int64, error) {
+		clk.Advance(5 * time.Minute)
+		return 3, nil
+	})
+
+	s.runOnce(context.Background(), s.tasks[0])
+
+	stats := s.Stats()[0]
+	if want := start.Add(time.Hour); stats.NextRunAt == nil || !stats.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %v, want %v", stats.NextRunAt, want)
+	}
+	if stats.LastRunAt == nil || !stats.LastRunAt.Equal(start) {
+		t.Errorf("LastRunAt = %v, want %v", stats.LastRunAt, start)
+	}
+	if stats.LastDuration != "5m0s" || stats.Runs != 1 || stats.Items != 3 || stats.LastItems != 3 {
+		t.Errorf("stats = %+v, want one run of 5m0s handling 3 items", stats)
+	}
+}
+
+func TestRunOnceDelaysTheNextRunOfASlowTask(t *testing.T) {
+	s, clk := newScheduler(t)
+	s.Add("purge", time.Minute, func(context.Context) (int64, error) {
+		clk.Advance(90 * time.Second)
+		return 0, nil
+	})
+
+	s.runOnce(context.Background(), s.tasks[0])
+
+	if next, end := s.Stats()[0].NextRunAt, clk.Now(); next == nil || !next.Equal(end) {
+		t.Errorf("NextRunAt = %v, want the end of the run %v", next, end)
+	}
+}
+
+func TestRunOnceRecordsFailuresAndPanics(t *testing.T) {
+	s, _ := newScheduler(t)
+	s.Add("failing", time.Hour, func(context.Context) (int64, error) {
+		return 0, errors.New("database unavailable")
+	})
+	s.Add("panicking", time.Hour, func(context.Context) (int64, error) {
+		panic("nil map")
+	})
+
+	for _, task := range s.tasks {
+		s.runOnce(context.Background(), task)
+	}
+
+	stats := s.Stats()
+	if stats[0].Failures != 1 || stats[0].LastError != "database unavailable" {
+		t.Errorf("failing task stats = %+v, want one failure with its error", stats[0])
+	}
+	if stats[1].Failures != 1 || stats[1].LastError != "panic: nil map" || stats[1].Running {
+		t.Errorf("panicking task stats = %+v, want one finished failure naming the panic", stats[1])
+	}
+}
@@ -0,0 +1,83 @@
+// data.go
+package seed
+
+import (
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// Password of every seeded account.
+const seedPassword = "complejos"
+
+// seedComplejos are the accounts created by the seed, the admin first.
+var seedComplejos = []models.Complejo{
+	{Username: "admin", Role: "admin", Gender: "other", Locale: "es", Units: "metric"},
+	{Username: "lucia_deadlifts", Role: "user", Gender: "female", Weight: 62, Height: 1.65, Bench: 55, Squad: 95, DL: 130, Locale: "es"},
+	{Username: "marcos_bench", Role: "user", Gender: "male", Weight: 88, Height: 1.80, Bench: 140, Squad: 170, DL: 210},
+	{Username: "sara_squats", Role: "user", Gender: "female", Weight: 70, Height: 1.72, Bench: 60, Squad: 120, DL: 140, Units: "metric"},
+	{Username: "pablo_npc", Role: "user", Gender: "male", Weight: 95, Height: 1.75, Bench: 70, Squad: 90, DL: 110, Locale: "es"},
+	{Username: "irene_pr", Role: "user", Gender: "female", Weight: 58, Height: 1.60, Bench: 50, Squad: 85, DL: 115, Locale: "en", Units: "imperial"},
+	{Username: "diego_rookie", Role: "user", Gender: "male", Weight: 74, Height: 1.78},
+	{Username: "alex_cardio", Role: "user", Gender: "other", Weight: 66, Height: 1.70, Bench: 45, Squad: 70, DL: 90},
+}
+
+// seedEvent is an Event created by the seed, dated relative to the seeding time.
+type seedEvent struct {
+	Title        string
+	Description  string
+	Location     string
+	In           time.Duration // Offset from now (negative for past events)
+	Participants []string
+}
+
+// seedEvents mixes past events (with attendance) and upcoming ones.
+var seedEvents = []seedEvent{
+	{
+		Title:        "Powerlifting open day",
+		Description:  "Try the three lifts with our coaches. Beginners welcome.",
+		Location:     "Gimnasio Municipal, Sala 2",
+		In:           -21 * 24 * time.Hour,
+		Participants: []string{"lucia_deadlifts", "marcos_bench", "diego_rookie"},
+	},
+	{
+		Title:        "Deadlift technique clinic",
+		Description:  "Hip hinge drills, bracing and setup. Bring chalk.",
+		Location:     "Gimnasio Municipal, Sala 1",
+		In:           -7 * 24 * time.Hour,
+		Participants: []string{"lucia_deadlifts", "sara_squats", "irene_pr"},
+	},
+	{
+		Title:        "Sunday 10K run",
+		Description:  "Easy-paced group run along the river, coffee afterwards.",
+		Location:     "Parque del Río, main entrance",
+		In:           -2 * 24 * time.Hour,
+		Participants: []string{"alex_cardio", "pablo_npc"},
+	},
+	{
+		Title:        "Bench press meet",
+		Description:  "Internal bench press competition with three attempts per lifter.",
+		Location:     "Gimnasio Municipal, Sala 2",
+		In:           5 * 24 * time.Hour,
+		Participants: []string{"marcos_bench", "irene_pr"},
+	},
+	{
+		Title:       "Mobility and recovery session",
+		Description: "Stretching, foam rolling and breathing for heavy training weeks.",
+		Location:    "Centro Deportivo Norte",
+		In:          9 * 24 * time.Hour,
+	},
+	{
+		Title:        "Squat day",
+		Description:  "Heavy singles and paused squats, spotters provided.",
+		Location:     "Gimnasio Municipal, Sala 1",
+		In:           14 * 24 * time.Hour,
+		Participants: []string{"sara_squats"},
+	},
+	{
+		Title:       "Nutrition talk: eating for strength",
+		Description: "A sports nutritionist answers your questions about bulking and cutting.",
+		Location:    "Biblioteca Central, aula 3",
+		In:          30 * 24 * time.Hour,
+	},
+}
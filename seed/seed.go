@@ -0,0 +1,99 @@
+// seed.go
+//
+// Package seed populates a database with realistic Complejos and Events (an admin account included), for local
+// development (the seed command) and for the resets of the sandbox.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/services"
+)
+
+// Run creates the missing seed accounts and events through the services and reports what it created. It is
+// idempotent: accounts and events that already exist (by username and title) are left untouched.
+func Run(ctx context.Context, complejos *services.ComplejoService, events *services.EventService, clk clock.Clock) (models.SeedSummary, error) {
+	summary := models.SeedSummary{Admin: seedComplejos[0].Username, Password: seedPassword}
+
+	ids, created, err := seedAccounts(ctx, complejos)
+	summary.Complejos = created
+	if err != nil {
+		return summary, fmt.Errorf("seeding Complejos: %w", err)
+	}
+
+	created, err = seedAgenda(ctx, events, ids, clk)
+	summary.Events = created
+	if err != nil {
+		return summary, fmt.Errorf("seeding Events: %w", err)
+	}
+	return summary, nil
+}
+
+// seedAccounts creates the missing seed accounts and returns the IDs of the accounts by username
+// and how many were created.
+func seedAccounts(ctx context.Context, complejos *services.ComplejoService) (map[string]string, int, error) {
+	existing, err := complejos.List(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	ids := map[string]string{}
+	for _, complejo := range existing {
+		ids[complejo.Username] = complejo.ID
+	}
+
+	created := 0
+	for _, seed := range seedComplejos {
+		if _, ok := ids[seed.Username]; ok {
+			continue
+		}
+		complejo := seed
+		complejo.Password = seedPassword
+		if _, err := complejos.Create(ctx, &complejo); err != nil {
+			return nil, created, fmt.Errorf("%s: %w", seed.Username, err)
+		}
+		ids[complejo.Username] = complejo.ID
+		created++
+	}
+	return ids, created, nil
+}
+
+// seedAgenda creates the missing seed events on behalf of the admin, with their participants going,
+// and returns how many were created.
+func seedAgenda(ctx context.Context, events *services.EventService, ids map[string]string, clk clock.Clock) (int, error) {
+	existing, err := events.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	titles := map[string]bool{}
+	for _, event := range existing {
+		titles[event.Title] = true
+	}
+
+	now := clk.Now()
+	created := 0
+	for _, seed := range seedEvents {
+		if titles[seed.Title] {
+			continue
+		}
+		rsvps := []models.RSVP{}
+		for _, username := range seed.Participants {
+			rsvps = append(rsvps, models.RSVP{ComplejoID: ids[username], Username: username, Status: models.RSVPGoing, RespondedAt: now})
+		}
+		event := &models.Event{
+			Title:       seed.Title,
+			Description: seed.Description,
+			Location:    seed.Location,
+			Date:        now.Add(seed.In).Truncate(time.Hour),
+			RSVPs:       rsvps,
+		}
+		if err := events.Create(ctx, event, ids[seedComplejos[0].Username]); err != nil {
+			return created, fmt.Errorf("%s: %w", seed.Title, err)
+		}
+		created++
+	}
+	return created, nil
+}
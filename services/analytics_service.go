@@ -0,0 +1,153 @@
+// analytics_service.go
+package services
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// DefaultAnalyticsTenant is the tenant of clients that do not name one.
+const DefaultAnalyticsTenant = "default"
+
+// AnalyticsService buffers client analytics events in memory and flushes them in batches to the sink
+// (the database or an external collector). Events are sampled and can be disabled per tenant.
+//
+// Tracking never waits for the sink: when it is unavailable the buffer grows up to MaxBuffer,
+// and newer events are dropped beyond that.
+type AnalyticsService struct {
+	sink   repository.AnalyticsRepository
+	clock  clock.Clock
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	buffer []models.AnalyticsEvent
+	full   chan struct{} // Signals Run that a batch is ready
+
+	SampleRate      float64         // Share (0 to 1) of events kept
+	DisabledTenants map[string]bool // Tenants whose events are discarded
+	BatchSize       int             // Events written per flush
+	MaxBuffer       int             // Events kept in memory at most
+	FlushInterval   time.Duration   // Time between two flushes of a partial batch
+}
+
+// NewAnalyticsService creates an AnalyticsService keeping every event and flushing batches of 100 every 10 seconds.
+func NewAnalyticsService(sink repository.AnalyticsRepository, clk clock.Clock, logger *slog.Logger) *AnalyticsService {
+	return &AnalyticsService{
+		sink:            sink,
+		clock:           clk,
+		logger:          logger,
+		full:            make(chan struct{}, 1),
+		SampleRate:      1,
+		DisabledTenants: map[string]bool{},
+		BatchSize:       100,
+		MaxBuffer:       10000,
+		FlushInterval:   10 * time.Second,
+	}
+}
+
+// Track samples the events of the tenant and buffers the kept ones, completing them with an ID,
+// the tenant, the caller (empty when anonymous) and the reception time.
+func (s *AnalyticsService) Track(tenant, complejoID string, events []models.AnalyticsEvent) models.AnalyticsReceipt {
+	var receipt models.AnalyticsReceipt
+	if tenant == "" {
+		tenant = DefaultAnalyticsTenant
+	}
+	if s.DisabledTenants[tenant] {
+		receipt.Dropped = len(events)
+		return receipt
+	}
+
+	now := s.clock.Now()
+	kept := make([]models.AnalyticsEvent, 0, len(events))
+	for _, event := range events {
+		if s.SampleRate < 1 && rand.Float64() >= s.SampleRate {
+			receipt.Sampled++
+			continue
+		}
+
+		event.ID = uuid.NewString()
+		event.Tenant = tenant
+		event.ComplejoID = complejoID
+		event.ReceivedAt = now
+		event.SampleRate = s.SampleRate
+		if event.OccurredAt == nil {
+			event.OccurredAt = &now
+		}
+		kept = append(kept, event)
+	}
+
+	s.mu.Lock()
+	room := s.MaxBuffer - len(s.buffer)
+	if room < len(kept) {
+		receipt.Dropped = len(kept) - max(room, 0)
+		kept = kept[:max(room, 0)]
+	}
+	s.buffer = append(s.buffer, kept...)
+	ready := len(s.buffer) >= s.BatchSize
+	s.mu.Unlock()
+
+	receipt.Accepted = len(kept)
+	if receipt.Dropped > 0 {
+		s.logger.Warn("analytics buffer is full, events dropped", "tenant", tenant, "count", receipt.Dropped)
+	}
+	if ready {
+		select {
+		case s.full <- struct{}{}:
+		default:
+		}
+	}
+	return receipt
+}
+
+// Flush writes every buffered event to the sink, one batch at a time.
+// A batch that cannot be written is put back in the buffer (within MaxBuffer) for the next flush.
+func (s *AnalyticsService) Flush(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		size := min(len(s.buffer), s.BatchSize)
+		batch := append([]models.AnalyticsEvent(nil), s.buffer[:size]...)
+		s.buffer = s.buffer[size:]
+		s.mu.Unlock()
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := s.sink.InsertMany(ctx, batch); err != nil {
+			s.mu.Lock()
+			room := max(s.MaxBuffer-len(s.buffer), 0)
+			s.buffer = append(batch[:min(room, len(batch))], s.buffer...)
+			s.mu.Unlock()
+			return err
+		}
+	}
+}
+
+// Run flushes the buffer every FlushInterval, or as soon as a batch is complete, until the context is cancelled.
+// Events still buffered afterwards are written by a final call to Flush (see app.App.Close).
+func (s *AnalyticsService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.full:
+		}
+
+		if err := s.Flush(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("analytics flush failed", "error", err)
+		}
+	}
+}
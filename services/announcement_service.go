@@ -0,0 +1,177 @@
+// announcement_service.go
+package services
+
+import (
+	"context"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// AnnouncementService publishes the Announcements of the admins to every member. Publishing announces
+// AnnouncementPublished, which pushes the Announcement to the devices of the members. Edits and rollbacks add a
+// version of the Announcement, and every version stays available.
+type AnnouncementService struct {
+	repo     repository.AnnouncementRepository
+	versions repository.ContentVersionRepository
+	tx       repository.Transactor
+	outbox   repository.OutboxRepository
+	clock    clock.Clock
+}
+
+// NewAnnouncementService creates an AnnouncementService.
+func NewAnnouncementService(repo repository.AnnouncementRepository, versions repository.ContentVersionRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, clk clock.Clock) *AnnouncementService {
+	return &AnnouncementService{repo: repo, versions: versions, tx: tx, outbox: outboxRepo, clock: clk}
+}
+
+// Publish stores an Announcement of the admin with its first version, and announces it (AnnouncementPublished)
+// in the same transaction.
+func (s *AnnouncementService) Publish(ctx context.Context, input models.AnnouncementInput, adminID string) (*models.Announcement, error) {
+	now := s.clock.Now()
+	announcement := &models.Announcement{
+		ID:        uuid.NewString(),
+		Title:     input.Title,
+		Body:      input.Body,
+		CreatedBy: adminID,
+		CreatedAt: now,
+		Version:   1,
+	}
+	version, err := nextVersion(models.ContentAnnouncement, announcement.ID, nil, input.Title, input.Body, adminID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Insert(ctx, announcement); err != nil {
+			return err
+		}
+		if err := insertVersion(ctx, s.versions, version); err != nil {
+			return err
+		}
+		return s.announce(ctx, bus.AnnouncementPublished{
+			ID:        announcement.ID,
+			Title:     announcement.Title,
+			Body:      announcement.Body,
+			CreatedBy: announcement.CreatedBy,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return announcement, nil
+}
+
+// List returns the most recent Announcements, newest first (DefaultAnnouncementLimit unless the query sets a limit).
+func (s *AnnouncementService) List(ctx context.Context, query models.AnnouncementQuery) ([]models.Announcement, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = models.DefaultAnnouncementLimit
+	}
+	return s.repo.FindRecent(ctx, limit)
+}
+
+// Get returns the Announcement with the given ID.
+func (s *AnnouncementService) Get(ctx context.Context, id string) (*models.Announcement, error) {
+	announcement, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrAnnouncementNotFound)
+	}
+	return announcement, nil
+}
+
+// Edit adds a version of the Announcement written by the admin. The edit is not pushed again.
+func (s *AnnouncementService) Edit(ctx context.Context, id string, input models.AnnouncementInput, adminID string) (*models.Announcement, error) {
+	announcement, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.write(ctx, announcement, input.Title, input.Body, adminID, 0)
+}
+
+// Versions returns the summaries of every version of the Announcement, the latest first.
+func (s *AnnouncementService) Versions(ctx context.Context, id string) ([]models.ContentVersionSummary, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	versions, err := s.versions.FindAll(ctx, models.ContentAnnouncement, id)
+	if err != nil {
+		return nil, err
+	}
+	return summarize(versions), nil
+}
+
+// Version returns the given version of the Announcement, with its diff from the previous one.
+func (s *AnnouncementService) Version(ctx context.Context, id string, number int) (*models.ContentVersion, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	version, err := s.versions.FindVersion(ctx, models.ContentAnnouncement, id, number)
+	if err != nil {
+		return nil, notFound(err, ErrVersionNotFound)
+	}
+	return version, nil
+}
+
+// Rollback adds a version of the Announcement restoring the content of an earlier version.
+func (s *AnnouncementService) Rollback(ctx context.Context, id string, number int, adminID string) (*models.Announcement, error) {
+	announcement, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.versions.FindVersion(ctx, models.ContentAnnouncement, id, number)
+	if err != nil {
+		return nil, notFound(err, ErrVersionNotFound)
+	}
+	return s.write(ctx, announcement, target.Title, target.Body, adminID, number)
+}
+
+// write adds a version of the Announcement with the title and body, restoring the given version (0 for an edit),
+// and makes it the current one in the same transaction.
+func (s *AnnouncementService) write(ctx context.Context, announcement *models.Announcement, title, body, adminID string, restoredFrom int) (*models.Announcement, error) {
+	latest, err := latestVersion(ctx, s.versions, models.ContentAnnouncement, announcement.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	version, err := nextVersion(models.ContentAnnouncement, announcement.ID, latest, title, body, adminID, now)
+	if err != nil {
+		return nil, err
+	}
+	version.RestoredFrom = restoredFrom
+
+	announcement.Title = title
+	announcement.Body = body
+	announcement.Version = version.Version
+	announcement.UpdatedBy = adminID
+	announcement.UpdatedAt = &now
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := insertVersion(ctx, s.versions, version); err != nil {
+			return err
+		}
+		found, err := s.repo.Update(ctx, announcement)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrAnnouncementNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return announcement, nil
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *AnnouncementService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
@@ -0,0 +1,38 @@
+// audit_service.go
+package services
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// Default and maximum number of audit log entries listed at once.
+const (
+	DefaultAuditLimit = 100
+	MaxAuditLimit     = 500
+)
+
+// AuditService lists the audit log. The entries are recorded by the services performing the audited actions,
+// in the transaction of the action.
+type AuditService struct {
+	repo repository.AuditRepository
+}
+
+// NewAuditService creates an AuditService backed by the given repository.
+func NewAuditService(repo repository.AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Recent returns the most recent entries matching the query, at most its limit (defaulted and capped).
+func (s *AuditService) Recent(ctx context.Context, query models.AuditQuery) ([]models.AuditEntry, error) {
+	limit := query.Limit
+	if limit < 1 {
+		limit = DefaultAuditLimit
+	}
+	if limit > MaxAuditLimit {
+		limit = MaxAuditLimit
+	}
+	return s.repo.FindRecent(ctx, query.Action, limit)
+}
@@ -0,0 +1,84 @@
+// block_service.go
+package services
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// BlockService lets the Complejos block each other. A blocked Complejo no longer sees the profile of the
+// Complejo blocking it (see PrivacyService), and neither can follow the other.
+type BlockService struct {
+	repo      repository.BlockRepository
+	follows   repository.FollowRepository
+	complejos repository.ComplejoRepository
+	tx        repository.Transactor
+	clock     clock.Clock
+}
+
+// NewBlockService creates a BlockService backed by the given repositories and clock.
+func NewBlockService(repo repository.BlockRepository, follows repository.FollowRepository, complejos repository.ComplejoRepository, tx repository.Transactor, clk clock.Clock) *BlockService {
+	return &BlockService{repo: repo, follows: follows, complejos: complejos, tx: tx, clock: clk}
+}
+
+// Block makes the blocker block the live Complejo with the given ID and removes their follows of each other, in a
+// single transaction; blocking it again changes nothing. A Complejo cannot block itself (ErrBlockSelf).
+func (s *BlockService) Block(ctx context.Context, blockerID, blockedID string) error {
+	if blockerID == blockedID {
+		return ErrBlockSelf
+	}
+	if _, err := s.complejos.FindByID(ctx, blockedID); err != nil {
+		return notFound(err, ErrComplejoNotFound)
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.repo.Insert(ctx, &models.Block{
+			ID:        uuid.NewString(),
+			BlockerID: blockerID,
+			BlockedID: blockedID,
+			CreatedAt: s.clock.Now(),
+		})
+		if err != nil && !errors.Is(err, repository.ErrDuplicate) {
+			return err
+		}
+		if _, err := s.follows.Delete(ctx, blockerID, blockedID); err != nil {
+			return err
+		}
+		_, err = s.follows.Delete(ctx, blockedID, blockerID)
+		return err
+	})
+}
+
+// Unblock stops the blocker from blocking the Complejo with the given ID; the follows removed by the block are
+// not restored. Unblocking a Complejo that is not blocked changes nothing.
+func (s *BlockService) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	if _, err := s.complejos.FindByID(ctx, blockedID); err != nil {
+		return notFound(err, ErrComplejoNotFound)
+	}
+	_, err := s.repo.Delete(ctx, blockerID, blockedID)
+	return err
+}
+
+// Blocked returns the requested page of the live Complejos the blocker blocks, latest first, and their total
+// number. Missing values are defaulted on the query.
+func (s *BlockService) Blocked(ctx context.Context, blockerID string, query *models.BlockQuery) ([]models.BlockEntry, int64, error) {
+	query.Normalize()
+	return s.repo.FindBlocked(ctx, blockerID, query.Offset(), query.Limit)
+}
+
+// blocked reports whether either of the Complejos blocks the other.
+func blocked(ctx context.Context, blocks repository.BlockRepository, a, b string) (bool, error) {
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		exists, err := blocks.Exists(ctx, pair[0], pair[1])
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+	return false, nil
+}
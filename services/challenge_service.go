@@ -0,0 +1,345 @@
+// challenge_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/validation"
+
+	"github.com/google/uuid"
+)
+
+// ChallengeService runs the challenges between the Complejos: any Complejo creates one, the Complejos join it
+// until it ends, and a recurring task scores its participants by its metric, so the standings rank them as of
+// the last scoring. A challenge is scored a last time after its end, which makes its standings final and
+// announces its winners (ChallengeWon).
+type ChallengeService struct {
+	repo      repository.ChallengeRepository
+	complejos repository.ComplejoRepository
+	events    repository.EventRepository
+	workouts  repository.WorkoutRepository
+	nutrition repository.NutritionRepository
+	tx        repository.Transactor
+	outbox    repository.OutboxRepository
+	clock     clock.Clock
+	Location  *time.Location // Time zone of the days of the nutrition logs counted
+}
+
+// NewChallengeService creates a ChallengeService backed by the given repositories and clock.
+func NewChallengeService(repo repository.ChallengeRepository, complejos repository.ComplejoRepository, events repository.EventRepository, workouts repository.WorkoutRepository, nutrition repository.NutritionRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, clk clock.Clock) *ChallengeService {
+	return &ChallengeService{
+		repo:      repo,
+		complejos: complejos,
+		events:    events,
+		workouts:  workouts,
+		nutrition: nutrition,
+		tx:        tx,
+		outbox:    outboxRepo,
+		clock:     clk,
+		Location:  time.UTC,
+	}
+}
+
+// List returns the page of the filter of the challenges, latest start first, and their total number.
+func (s *ChallengeService) List(ctx context.Context, filter *models.ChallengeFilter) ([]models.Challenge, int64, error) {
+	filter.Normalize()
+	now := s.clock.Now()
+	challenges, total, err := s.repo.Search(ctx, *filter, now)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range challenges {
+		challenges[i].Status = challenges[i].StatusAt(now)
+	}
+	return challenges, total, nil
+}
+
+// Get returns the challenge with the given ID.
+func (s *ChallengeService) Get(ctx context.Context, id string) (*models.Challenge, error) {
+	challenge, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrChallengeNotFound)
+	}
+	challenge.Status = challenge.StatusAt(s.clock.Now())
+	return challenge, nil
+}
+
+// Create creates a challenge on behalf of the Complejo with the given ID. The creator is not a participant until
+// it joins.
+func (s *ChallengeService) Create(ctx context.Context, complejoID string, input models.ChallengeInput) (*models.Challenge, error) {
+	if !input.EndsAt.After(input.StartsAt) {
+		return nil, apperrors.Validation("Validation failed", []validation.FieldError{
+			{Field: "ends_at", Rule: "gtfield", Message: "must be after starts_at"},
+		})
+	}
+	if input.EndsAt.Sub(input.StartsAt) > models.MaxChallengeDays*24*time.Hour {
+		return nil, apperrors.Validation("Validation failed", []validation.FieldError{
+			{Field: "ends_at", Rule: "max", Message: fmt.Sprintf("must be at most %d days after starts_at", models.MaxChallengeDays)},
+		})
+	}
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	now := s.clock.Now()
+	challenge := &models.Challenge{
+		ID:          uuid.NewString(),
+		Title:       input.Title,
+		Description: input.Description,
+		Metric:      input.Metric,
+		StartsAt:    input.StartsAt,
+		EndsAt:      input.EndsAt,
+		CreatedBy:   complejoID,
+		CreatedAt:   now,
+	}
+	if err := s.repo.Insert(ctx, challenge); err != nil {
+		return nil, err
+	}
+	challenge.Status = challenge.StatusAt(now)
+	return challenge, nil
+}
+
+// Delete removes a challenge and its participants. Only admins and the creator of the challenge may remove it.
+func (s *ChallengeService) Delete(ctx context.Context, id, requesterID string, isAdmin bool) error {
+	challenge, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return notFound(err, ErrChallengeNotFound)
+	}
+	if !isAdmin && challenge.CreatedBy != requesterID {
+		return ErrNotChallengeCreator
+	}
+
+	found, err := s.repo.DeleteByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrChallengeNotFound
+	}
+	return nil
+}
+
+// Join adds the Complejo to the participants of a challenge that has not ended, and returns the standings;
+// joining it again changes nothing. The Complejo scores 0 until the next scoring.
+func (s *ChallengeService) Join(ctx context.Context, id, complejoID string) (*models.ChallengeStandings, error) {
+	challenge, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	err = s.repo.AddParticipant(ctx, &models.ChallengeParticipant{
+		ID:          uuid.NewString(),
+		ChallengeID: challenge.ID,
+		ComplejoID:  complejoID,
+		JoinedAt:    s.clock.Now(),
+	})
+	if err != nil && !errors.Is(err, repository.ErrDuplicate) {
+		return nil, err
+	}
+	return s.standings(ctx, challenge, complejoID)
+}
+
+// Leave removes the Complejo from the participants of a challenge that has not ended, with its score, and
+// returns the standings; leaving it again changes nothing.
+func (s *ChallengeService) Leave(ctx context.Context, id, complejoID string) (*models.ChallengeStandings, error) {
+	challenge, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.RemoveParticipant(ctx, challenge.ID, complejoID); err != nil {
+		return nil, err
+	}
+	return s.standings(ctx, challenge, complejoID)
+}
+
+// Standings ranks the participants of a challenge by their score as of the last scoring, with the place of the
+// Complejo with the given ID (none when empty or not a participant).
+func (s *ChallengeService) Standings(ctx context.Context, id, complejoID string) (*models.ChallengeStandings, error) {
+	challenge, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrChallengeNotFound)
+	}
+	return s.standings(ctx, challenge, complejoID)
+}
+
+// Score scores the participants of every challenge started and not yet scored after its end, and returns how
+// many challenges were scored. The last scoring of a challenge announces its winners in the same transaction.
+func (s *ChallengeService) Score(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	challenges, err := s.repo.FindScorable(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	scored := 0
+	for i := range challenges {
+		challenge := &challenges[i]
+		participants, err := s.repo.FindParticipants(ctx, challenge.ID)
+		if err != nil {
+			return scored, err
+		}
+
+		until := challenge.EndsAt
+		if now.Before(until) {
+			until = now
+		}
+		scores := make(map[string]float64, len(participants))
+		for _, complejoID := range participants {
+			score, err := s.score(ctx, challenge, complejoID, until)
+			if err != nil {
+				return scored, fmt.Errorf("error scoring %s in the challenge %s: %w", complejoID, challenge.ID, err)
+			}
+			scores[complejoID] = score
+		}
+		err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.repo.SetScores(ctx, challenge.ID, scores, now); err != nil {
+				return err
+			}
+			if now.Before(challenge.EndsAt) {
+				return nil
+			}
+			return s.announceWinners(ctx, challenge)
+		})
+		if err != nil {
+			return scored, fmt.Errorf("error storing the scores of the challenge %s: %w", challenge.ID, err)
+		}
+		scored++
+	}
+	return scored, nil
+}
+
+// score returns the metric of the challenge for the Complejo between the start of the challenge and until.
+func (s *ChallengeService) score(ctx context.Context, challenge *models.Challenge, complejoID string, until time.Time) (float64, error) {
+	switch challenge.Metric {
+	case models.ChallengeEvents:
+		events, err := s.events.FindByRSVP(ctx, complejoID, models.RSVPGoing, challenge.StartsAt)
+		if err != nil {
+			return 0, err
+		}
+		attended := 0
+		for _, event := range events {
+			if event.Date.Before(until) {
+				attended++
+			}
+		}
+		return float64(attended), nil
+
+	case models.ChallengeWorkouts, models.ChallengeVolume:
+		workouts, _, err := s.workouts.FindByComplejo(ctx, complejoID, 0, 0)
+		if err != nil {
+			return 0, err
+		}
+		var score float64
+		for _, workout := range workouts {
+			if workout.Date.Before(challenge.StartsAt) || !workout.Date.Before(until) {
+				continue
+			}
+			if challenge.Metric == models.ChallengeWorkouts {
+				score++
+				continue
+			}
+			for _, exercise := range workout.Exercises {
+				for _, set := range exercise.Sets {
+					score += float64(set.Reps) * set.Weight
+				}
+			}
+		}
+		return score, nil
+
+	case models.ChallengeNutritionDays:
+		// Days are counted in the time zone of the server, up to the day of the last instant counted
+		from := challenge.StartsAt.In(s.Location).Format(dayLayout)
+		to := until.Add(-time.Nanosecond).In(s.Location).Format(dayLayout)
+		logs, err := s.nutrition.FindByComplejo(ctx, complejoID, from, to)
+		if err != nil {
+			return 0, err
+		}
+		return float64(len(logs)), nil
+	}
+	return 0, fmt.Errorf("unknown challenge metric %q", challenge.Metric)
+}
+
+// announceWinners announces the live participants of the challenge with the highest score, unless it is 0.
+func (s *ChallengeService) announceWinners(ctx context.Context, challenge *models.Challenge) error {
+	entries, err := s.repo.Standings(ctx, challenge.ID)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Score <= 0 || entry.Score < entries[0].Score {
+			break
+		}
+		err := s.announce(ctx, bus.ChallengeWon{
+			ChallengeID: challenge.ID,
+			Title:       challenge.Title,
+			Metric:      challenge.Metric,
+			ComplejoID:  entry.ComplejoID,
+			Username:    entry.Username,
+			Score:       entry.Score,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *ChallengeService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
+
+// open returns the challenge with the given ID, when it has not ended.
+func (s *ChallengeService) open(ctx context.Context, id string) (*models.Challenge, error) {
+	challenge, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrChallengeNotFound)
+	}
+	if challenge.StatusAt(s.clock.Now()) == models.ChallengeEnded {
+		return nil, ErrChallengeEnded
+	}
+	return challenge, nil
+}
+
+// standings ranks the participants of the challenge, with the place of the Complejo with the given ID.
+// Participants with the same score share their rank.
+func (s *ChallengeService) standings(ctx context.Context, challenge *models.Challenge, complejoID string) (*models.ChallengeStandings, error) {
+	entries, err := s.repo.Standings(ctx, challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := &models.ChallengeStandings{
+		ChallengeID: challenge.ID,
+		Metric:      challenge.Metric,
+		Status:      challenge.StatusAt(s.clock.Now()),
+		ScoredAt:    challenge.ScoredAt,
+		Final:       challenge.ScoredAt != nil && !challenge.ScoredAt.Before(challenge.EndsAt),
+		Entries:     entries,
+	}
+	for i := range entries {
+		entries[i].Rank = int64(i + 1)
+		if i > 0 && entries[i].Score == entries[i-1].Score {
+			entries[i].Rank = entries[i-1].Rank
+		}
+		if complejoID != "" && entries[i].ComplejoID == complejoID {
+			standings.Me = &entries[i]
+		}
+	}
+	return standings, nil
+}
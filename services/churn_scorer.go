@@ -0,0 +1,152 @@
+// churn_scorer.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// Churn-risk score thresholds of the levels.
+const (
+	churnHighScore   = 70
+	churnMediumScore = 40
+)
+
+// churnLevelRank orders the churn-risk levels, so a report can list a level and the ones above it.
+var churnLevelRank = map[string]int{models.ChurnLow: 0, models.ChurnMedium: 1, models.ChurnHigh: 2}
+
+// ChurnScorer periodically scores how likely each member is to leave, from the decline of their
+// event attendance and subscription activity between the previous and the recent window,
+// and stores the score on their profile.
+type ChurnScorer struct {
+	complejos repository.ComplejoRepository
+	reports   repository.ReportRepository
+	clock     clock.Clock
+
+	Window time.Duration // Length of the recent window, compared with the window before it
+}
+
+// NewChurnScorer creates a ChurnScorer comparing the last 4 weeks with the 4 before.
+func NewChurnScorer(complejos repository.ComplejoRepository, reports repository.ReportRepository, clk clock.Clock) *ChurnScorer {
+	return &ChurnScorer{
+		complejos: complejos,
+		reports:   reports,
+		clock:     clk,
+		Window:    4 * cohortLength,
+	}
+}
+
+// Score scores every member and stores the scores, returning how many members were scored.
+func (s *ChurnScorer) Score(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	split := now.Add(-s.Window)
+	since := split.Add(-s.Window)
+
+	members, err := s.reports.MemberActivity(ctx, since, split, now)
+	if err != nil {
+		return 0, err
+	}
+
+	scored := 0
+	for _, member := range members {
+		risk := churnRisk(member, since, split, now)
+		if _, err := s.complejos.SetChurnRisk(ctx, member.ID, risk); err != nil {
+			return scored, fmt.Errorf("error storing the churn risk of %s: %w", member.ID, err)
+		}
+		scored++
+	}
+	return scored, nil
+}
+
+// AtRisk returns the scored members whose level is the given one or above (medium by default),
+// riskiest first.
+func (s *ChurnScorer) AtRisk(ctx context.Context, level string) ([]models.ChurnCandidate, error) {
+	if level == "" {
+		level = models.ChurnMedium
+	}
+
+	complejos, err := s.complejos.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []models.ChurnCandidate{}
+	for _, complejo := range complejos {
+		if complejo.ChurnRisk == nil || churnLevelRank[complejo.ChurnRisk.Level] < churnLevelRank[level] {
+			continue
+		}
+		candidates = append(candidates, models.ChurnCandidate{
+			ComplejoID: complejo.ID,
+			Username:   complejo.Username,
+			ChurnRisk:  *complejo.ChurnRisk,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Username < candidates[j].Username
+	})
+	return candidates, nil
+}
+
+// churnRisk scores the member's activity in the previous window [since, split) and the recent one [split, now).
+//
+// Members active in both windows score by how much their activity declined (up to 80), plus 20 when
+// the recent window is empty. Members without any activity score 90, or 60 when they signed up during
+// the previous window and never started.
+func churnRisk(member models.MemberActivity, since, split, now time.Time) *models.ChurnRisk {
+	previous := member.PreviousAttendance + member.PreviousSubscriptions
+	recent := member.RecentAttendance + member.RecentSubscriptions
+	newMember := member.CreatedAt != nil && !member.CreatedAt.Before(since)
+
+	risk := &models.ChurnRisk{Reasons: []string{}, Actions: []string{}, ScoredAt: now}
+	switch {
+	case member.CreatedAt != nil && !member.CreatedAt.Before(split):
+		// Signed up during the recent window: too early to tell
+	case previous == 0 && recent == 0 && newMember:
+		risk.Score = 60
+		risk.Reasons = append(risk.Reasons, "has not attended any event since signing up")
+		risk.Actions = append(risk.Actions, "Offer an onboarding session with a coach")
+	case previous == 0 && recent == 0:
+		risk.Score = 90
+		risk.Reasons = append(risk.Reasons, "no attendance or subscriptions in the last two windows")
+		risk.Actions = append(risk.Actions, "Send a personal message from a coach", "Invite them to an upcoming event")
+	case recent < previous:
+		decline := float64(previous-recent) / float64(previous)
+		risk.Score = int(decline * 80)
+		if member.RecentAttendance < member.PreviousAttendance {
+			risk.Reasons = append(risk.Reasons, fmt.Sprintf("attendance dropped from %d to %d events",
+				member.PreviousAttendance, member.RecentAttendance))
+		}
+		if member.RecentSubscriptions < member.PreviousSubscriptions {
+			risk.Reasons = append(risk.Reasons, fmt.Sprintf("subscription activity dropped from %d to %d",
+				member.PreviousSubscriptions, member.RecentSubscriptions))
+		}
+		if recent == 0 {
+			risk.Score += 20
+			risk.Reasons = append(risk.Reasons, "no activity in the recent window")
+			risk.Actions = append(risk.Actions, "Send a personal message from a coach")
+		} else {
+			risk.Actions = append(risk.Actions, "Remind them of the upcoming events")
+		}
+		risk.Actions = append(risk.Actions, "Invite them to events similar to the ones they attended")
+	}
+
+	switch {
+	case risk.Score >= churnHighScore:
+		risk.Level = models.ChurnHigh
+	case risk.Score >= churnMediumScore:
+		risk.Level = models.ChurnMedium
+	default:
+		risk.Level = models.ChurnLow
+	}
+	return risk
+}
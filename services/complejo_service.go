@@ -0,0 +1,387 @@
+// complejo_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/imaging"
+	"los-complejos-backend/jobs"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// ComplejoService implements the business logic for Complejo resources.
+type ComplejoService struct {
+	repo        repository.ComplejoRepository
+	events      repository.EventRepository
+	history     repository.SubscriptionEventRepository
+	invitations repository.InvitationRepository
+	tx          repository.Transactor
+	outbox      repository.OutboxRepository
+	moderator   *ModerationService
+	jobs        *jobs.Queue
+	images      *imaging.Pool
+	thumbnails  *imaging.Pool
+	photos      objectstore.Store
+	clock       clock.Clock
+
+	InvitationTTL time.Duration // How long the invitation link of a guest works
+}
+
+// JobProfilePhotoSizes is the kind of the jobs making the size variants of a profile photo, run by MakePhotoSizes.
+const JobProfilePhotoSizes = "complejo.photo_sizes"
+
+// profilePhotoSizesJob is the payload of the JobProfilePhotoSizes jobs.
+type profilePhotoSizesJob struct {
+	PhotoID string `json:"photo_id"`
+}
+
+// NewComplejoService creates a ComplejoService backed by the given repositories, image pool, profile photo store and clock;
+// the sizes of the profile photos are made on the thumbnail pool in jobs of the queue.
+// The Event repositories are used to withdraw a deleted Complejo from the Events it joined, and to carry over
+// the Events attended by an invited guest; invitation links work for a week. The moderator holds flagged usernames
+// for review.
+func NewComplejoService(repo repository.ComplejoRepository, events repository.EventRepository, history repository.SubscriptionEventRepository, invitations repository.InvitationRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, moderator *ModerationService, queue *jobs.Queue, images, thumbnails *imaging.Pool, photos objectstore.Store, clk clock.Clock) *ComplejoService {
+	return &ComplejoService{
+		repo:          repo,
+		events:        events,
+		history:       history,
+		invitations:   invitations,
+		tx:            tx,
+		outbox:        outboxRepo,
+		moderator:     moderator,
+		jobs:          queue,
+		images:        images,
+		thumbnails:    thumbnails,
+		photos:        photos,
+		clock:         clk,
+		InvitationTTL: 7 * 24 * time.Hour,
+	}
+}
+
+// Create assigns a new ID, IMC and sign-up time to the Complejo, moves its normalized photo to the photo store, stores it
+// and returns a JWT for it.
+// ErrUsernameTaken is returned when another Complejo (even a deleted one) already uses the username,
+// and a username flagged by the content filter is held for review. The registration is announced through the outbox in the same transaction.
+func (s *ComplejoService) Create(ctx context.Context, complejo *models.Complejo) (string, error) {
+	return s.register(ctx, complejo, nil)
+}
+
+// register stores the new Complejo like Create, running then (when not nil) in the same transaction.
+func (s *ComplejoService) register(ctx context.Context, complejo *models.Complejo, then func(ctx context.Context) error) (string, error) {
+	now := s.clock.Now()
+	complejo.ID = uuid.NewString()
+	complejo.IMC = utils.CalcIMC(complejo.Weight, complejo.Height)
+	complejo.CreatedAt = &now
+
+	photoID, err := s.savePhoto(ctx, complejo.Photo)
+	if err != nil {
+		return "", err
+	}
+	complejo.Photo = ""
+	complejo.PhotoID = photoID
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Insert(ctx, complejo); err != nil {
+			return usernameTaken(err, complejo.Username)
+		}
+		if err := s.enqueueSizes(ctx, photoID); err != nil {
+			return err
+		}
+		if reasons := s.moderator.Flagged(ctx, complejo.Username); reasons != nil {
+			err := s.moderator.Hold(ctx, models.HoldUsername, complejo.ID, complejo.ID, complejo.Username, "", reasons)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := s.announce(ctx, bus.ComplejoRegistered{
+			ID:       complejo.ID,
+			Username: complejo.Username,
+			Role:     complejo.Role,
+		})
+		if err != nil || then == nil {
+			return err
+		}
+		return then(ctx)
+	})
+	if err != nil {
+		s.removePhoto(ctx, photoID)
+		return "", err
+	}
+
+	return utils.GenerateToken(complejo.ID, complejo.Role, complejo.Username, s.clock.Now())
+}
+
+// List returns every Complejo.
+func (s *ComplejoService) List(ctx context.Context) ([]models.Complejo, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// Get returns the Complejo with the given ID.
+func (s *ComplejoService) Get(ctx context.Context, id string) (*models.Complejo, error) {
+	complejo, err := s.repo.FindByID(ctx, id)
+	return complejo, notFound(err, ErrComplejoNotFound)
+}
+
+// UpdateForUser applies the profile fields present in the update to the user's own Complejo; a new username
+// flagged by the content filter is held for review. ErrNoValidFields is returned when none are present.
+func (s *ComplejoService) UpdateForUser(ctx context.Context, id string, update models.ProfileUpdate) error {
+	return s.update(ctx, id, "user", update.Fields())
+}
+
+// UpdateForAdmin applies the fields present in the update to the Complejo with the given ID.
+// ErrNoValidFields is returned when none are present.
+func (s *ComplejoService) UpdateForAdmin(ctx context.Context, id string, update models.ComplejoUpdate) error {
+	return s.update(ctx, id, "", update.Fields())
+}
+
+// SetPhoto normalizes the uploaded image and makes it the profile photo of the Complejo with the given ID,
+// replacing its previous one, and returns the ID of the new photo.
+func (s *ComplejoService) SetPhoto(ctx context.Context, id string, data []byte) (string, error) {
+	photoID, err := s.storePhoto(ctx, data)
+	if err != nil {
+		return "", err
+	}
+	if err := s.update(ctx, id, "", map[string]interface{}{"photo_id": photoID}); err != nil {
+		return "", err
+	}
+	return photoID, nil
+}
+
+// Photo returns the profile photo stored under the given ID, or its size variant when size is set, and whether
+// it is the requested one: the photo itself is returned until the variant is made.
+// ErrPhotoNotFound is returned for unknown IDs.
+func (s *ComplejoService) Photo(ctx context.Context, photoID, size string) ([]byte, bool, error) {
+	if _, err := uuid.Parse(photoID); err != nil {
+		return nil, false, ErrPhotoNotFound
+	}
+	data, exact, err := sized(ctx, s.photos, models.ProfilePhotoKey(photoID), size)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, false, ErrPhotoNotFound
+	}
+	return data, exact, err
+}
+
+// MakePhotoSizes runs a JobProfilePhotoSizes job: it scales the profile photo down to each size on the thumbnail
+// pool and stores the variants next to it. Photos removed since, and photos the pool cannot process, are left
+// alone; a busy pool is retried.
+func (s *ComplejoService) MakePhotoSizes(ctx context.Context, payload []byte) error {
+	var job profilePhotoSizesJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	_, err := makeSizes(ctx, s.thumbnails, s.photos, models.ProfilePhotoKey(job.PhotoID))
+	return err
+}
+
+// enqueueSizes enqueues the job making the sizes of the new profile photo with the given ID, if any;
+// call it inside the transaction setting the photo.
+func (s *ComplejoService) enqueueSizes(ctx context.Context, photoID string) error {
+	if photoID == "" {
+		return nil
+	}
+	return s.jobs.Enqueue(ctx, JobProfilePhotoSizes, profilePhotoSizesJob{PhotoID: photoID})
+}
+
+// savePhoto normalizes a base64-encoded photo (optionally a `data:` URL) and keeps it in the photo store under a
+// new ID, which it returns; an empty photo is not stored and gets no ID.
+func (s *ComplejoService) savePhoto(ctx context.Context, photo string) (string, error) {
+	if photo == "" {
+		return "", nil
+	}
+	data, _, err := decodePhoto(photo)
+	if err != nil {
+		return "", err
+	}
+	return s.storePhoto(ctx, data)
+}
+
+// storePhoto normalizes the image and keeps it in the photo store under a new ID, which it returns.
+func (s *ComplejoService) storePhoto(ctx context.Context, data []byte) (string, error) {
+	image, err := processImage(ctx, s.images, data)
+	if err != nil {
+		return "", err
+	}
+	photoID := uuid.NewString()
+	if err := s.photos.Put(ctx, models.ProfilePhotoKey(photoID), image.Data); err != nil {
+		return "", err
+	}
+	return photoID, nil
+}
+
+// removePhoto removes the profile photo with the given ID and its size variants from the photo store, if any.
+// It is best effort: a failure leaves at worst orphan files.
+func (s *ComplejoService) removePhoto(ctx context.Context, photoID string) {
+	if photoID != "" {
+		removeSizes(ctx, s.photos, models.ProfilePhotoKey(photoID))
+		s.photos.Delete(ctx, models.ProfilePhotoKey(photoID))
+	}
+}
+
+// update moves a new photo to the photo store, applies fields to the Complejo (restricted to the role when not empty),
+// recalculates its IMC when the weight or height changes, announces a PRAchieved event for every lift it
+// improves, for users, holds a flagged username for review and enqueues the job making the sizes of a new photo,
+// in a single transaction. The photo it replaces is
+// then removed from the photo store.
+func (s *ComplejoService) update(ctx context.Context, id, role string, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return ErrNoValidFields
+	}
+
+	if photo, exists := fields["photo"]; exists {
+		photoID, err := s.savePhoto(ctx, photo.(string))
+		if err != nil {
+			return err
+		}
+		fields["photo_id"] = photoID
+	}
+	// The base64 photo of the Complejos signed up before the photo store is dropped with any new photo
+	photoID, hasPhoto := fields["photo_id"].(string)
+	if hasPhoto {
+		fields["photo"] = ""
+	}
+
+	replaced := ""
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var records []bus.PRAchieved
+		var hold []string
+		previous := ""
+		_, hasWeight := fields["weight"]
+		_, hasHeight := fields["height"]
+		if username, renamed := fields["username"]; renamed && role == "user" {
+			hold = s.moderator.Flagged(ctx, username.(string))
+		}
+		if hasLift(fields) || hasWeight || hasHeight || hasPhoto || hold != nil {
+			complejo, err := s.repo.FindByID(ctx, id)
+			if err != nil {
+				return notFound(err, ErrComplejoNotFound)
+			}
+			records = personalRecords(complejo, fields)
+			previous = complejo.Username
+			if hasPhoto {
+				replaced = complejo.PhotoID
+			}
+
+			if hasWeight || hasHeight {
+				weight, height := complejo.Weight, complejo.Height
+				if hasWeight {
+					weight = fields["weight"].(float64)
+				}
+				if hasHeight {
+					height = fields["height"].(float64)
+				}
+				fields["imc"] = utils.CalcIMC(weight, height)
+			}
+		}
+
+		found, err := s.repo.UpdateByID(ctx, id, role, fields)
+		if err != nil {
+			return usernameTaken(err, fields["username"])
+		}
+		if !found {
+			return ErrComplejoNotFound
+		}
+		if hold != nil && fields["username"] != previous {
+			err := s.moderator.Hold(ctx, models.HoldUsername, id, id, fields["username"].(string), previous, hold)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, record := range records {
+			if err := s.announce(ctx, record); err != nil {
+				return err
+			}
+		}
+		if replaced != photoID {
+			return s.enqueueSizes(ctx, photoID)
+		}
+		return nil
+	})
+	if err != nil {
+		s.removePhoto(ctx, photoID)
+		return err
+	}
+	if replaced != photoID {
+		s.removePhoto(ctx, replaced)
+	}
+	return nil
+}
+
+// Delete marks the Complejo with the given ID as deleted and withdraws its RSVPs from every Event,
+// recording an "unsubscribed" transition for each Event it was going to, in a single transaction. The Complejo can be restored until
+// it is purged; its subscriptions are not.
+func (s *ComplejoService) Delete(ctx context.Context, id string) error {
+	complejo, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return notFound(err, ErrComplejoNotFound)
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		found, err := s.repo.DeleteByID(ctx, id, s.clock.Now())
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrComplejoNotFound
+		}
+
+		eventIDs, err := s.events.RemoveRSVPsFromAll(ctx, complejo.ID)
+		if err != nil {
+			return err
+		}
+		now := s.clock.Now()
+		for _, eventID := range eventIDs {
+			err := s.history.Append(ctx, &models.SubscriptionEvent{
+				ID:         uuid.NewString(),
+				EventID:    eventID,
+				Username:   complejo.Username,
+				Type:       models.SubscriptionUnsubscribed,
+				OccurredAt: now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if eventIDs == nil {
+			eventIDs = []string{}
+		}
+		return s.announce(ctx, bus.ComplejoDeleted{
+			ID:       complejo.ID,
+			Username: complejo.Username,
+			Events:   eventIDs,
+		})
+	})
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *ComplejoService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
+
+// Restore clears the deletion mark of the Complejo with the given ID.
+func (s *ComplejoService) Restore(ctx context.Context, id string) error {
+	found, err := s.repo.RestoreByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrDeletedComplejoNotFound
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// complejo_service_test.go
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+)
+
+// newTestComplejoService creates a ComplejoService on the fakes, for the updates that need no photo, job or
+// content filter.
+func newTestComplejoService(complejos *fakeComplejos) *ComplejoService {
+	return NewComplejoService(complejos, newFakeEvents(), nil, nil, fakeTx{}, &fakeOutbox{}, nil, nil, nil, nil, nil,
+		clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)))
+}
+
+func TestUpdateForUserOnlyUpdatesUsers(t *testing.T) {
+	weight := 80.0
+	tests := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{"user", "user", nil},
+		{"admin", "admin", ErrComplejoNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			complejos := newFakeComplejos(models.Complejo{ID: "c1", Username: "maria", Role: tt.role, Weight: 70, Height: 1.7})
+			svc := newTestComplejoService(complejos)
+
+			err := svc.UpdateForUser(context.Background(), "c1", models.ProfileUpdate{Weight: &weight})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateForUser error = %v, want %v", err, tt.wantErr)
+			}
+			stored, _ := complejos.FindByID(context.Background(), "c1")
+			if updated := stored.Weight == weight; updated != (tt.wantErr == nil) {
+				t.Errorf("weight = %v, updated = %v, want updated = %v", stored.Weight, updated, tt.wantErr == nil)
+			}
+			if tt.wantErr == nil && (stored.IMC == nil || stored.IMC.Value == 0) {
+				t.Errorf("IMC = %+v, want it recalculated with the new weight", stored.IMC)
+			}
+		})
+	}
+}
+
+func TestUpdateForAdminUpdatesAnyRole(t *testing.T) {
+	role, gender := "admin", "female"
+	for _, current := range []string{"user", "admin"} {
+		t.Run(current, func(t *testing.T) {
+			complejos := newFakeComplejos(models.Complejo{ID: "c1", Username: "maria", Role: current, Gender: "other"})
+			svc := newTestComplejoService(complejos)
+
+			update := models.ComplejoUpdate{Role: &role, Gender: &gender}
+			if err := svc.UpdateForAdmin(context.Background(), "c1", update); err != nil {
+				t.Fatalf("UpdateForAdmin: %v", err)
+			}
+			stored, _ := complejos.FindByID(context.Background(), "c1")
+			if stored.Role != role || stored.Gender != gender {
+				t.Errorf("role = %s, gender = %s, want %s and %s", stored.Role, stored.Gender, role, gender)
+			}
+		})
+	}
+}
+
+func TestUpdateErrors(t *testing.T) {
+	weight := 80.0
+	svc := newTestComplejoService(newFakeComplejos(models.Complejo{ID: "c1", Role: "user"}))
+	ctx := context.Background()
+
+	if err := svc.UpdateForUser(ctx, "c1", models.ProfileUpdate{}); !errors.Is(err, ErrNoValidFields) {
+		t.Errorf("UpdateForUser without fields: error = %v, want ErrNoValidFields", err)
+	}
+	if err := svc.UpdateForAdmin(ctx, "c1", models.ComplejoUpdate{}); !errors.Is(err, ErrNoValidFields) {
+		t.Errorf("UpdateForAdmin without fields: error = %v, want ErrNoValidFields", err)
+	}
+	if err := svc.UpdateForAdmin(ctx, "missing", models.ComplejoUpdate{ProfileUpdate: models.ProfileUpdate{Weight: &weight}}); !errors.Is(err, ErrComplejoNotFound) {
+		t.Errorf("UpdateForAdmin of an unknown Complejo: error = %v, want ErrComplejoNotFound", err)
+	}
+}
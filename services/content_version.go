@@ -0,0 +1,72 @@
+// content_version.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/textdiff"
+
+	"github.com/google/uuid"
+)
+
+// diffContext is the number of unchanged lines shown around each change in the diffs between versions.
+const diffContext = 3
+
+// nextVersion returns the version of the document following latest (nil for its first version), with the diff
+// of its body. ErrContentUnchanged is returned when it would change neither the title nor the body.
+func nextVersion(documentType, documentID string, latest *models.ContentVersion, title, body, authorID string, now time.Time) (*models.ContentVersion, error) {
+	version := &models.ContentVersion{
+		ID:           uuid.NewString(),
+		DocumentType: documentType,
+		DocumentID:   documentID,
+		Version:      1,
+		Title:        title,
+		Body:         body,
+		CreatedBy:    authorID,
+		CreatedAt:    now,
+	}
+	if latest == nil {
+		return version, nil
+	}
+	if latest.Title == title && latest.Body == body {
+		return nil, ErrContentUnchanged
+	}
+
+	version.Version = latest.Version + 1
+	version.Diff = textdiff.Unified(fmt.Sprintf("v%d", latest.Version), fmt.Sprintf("v%d", version.Version),
+		latest.Body, body, diffContext)
+	return version, nil
+}
+
+// latestVersion returns the latest version of the document, or nil when it has none.
+func latestVersion(ctx context.Context, versions repository.ContentVersionRepository, documentType, documentID string) (*models.ContentVersion, error) {
+	latest, err := versions.FindLatest(ctx, documentType, documentID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	return latest, err
+}
+
+// insertVersion stores the version, returning ErrVersionConflict when another version with its number was
+// stored meanwhile.
+func insertVersion(ctx context.Context, versions repository.ContentVersionRepository, version *models.ContentVersion) error {
+	err := versions.Insert(ctx, version)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return ErrVersionConflict
+	}
+	return err
+}
+
+// summarize returns the summaries of the versions, in the same order.
+func summarize(versions []models.ContentVersion) []models.ContentVersionSummary {
+	summaries := make([]models.ContentVersionSummary, 0, len(versions))
+	for _, version := range versions {
+		summaries = append(summaries, version.Summary())
+	}
+	return summaries
+}
@@ -0,0 +1,331 @@
+// data_export_service.go
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/jobs"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// JobDataExport is the kind of the jobs generating the archive of a DataExport, run by Generate.
+const JobDataExport = "complejo.data_export"
+
+// dataExportJob is the payload of the JobDataExport jobs.
+type dataExportJob struct {
+	ExportID string `json:"export_id"`
+}
+
+// dataExportStale is how long a DataExport may stay pending before it is requested again: by then its job was
+// given up.
+const dataExportStale = 24 * time.Hour
+
+// DataExportService exports everything stored about a Complejo, at its own request: its profile (with its
+// churn-risk score, but never its password) and profile photo, the Events it organized, answered, liked or
+// brought guests to, its subscription history, the devices it receives the notifications on, its loans, its
+// lift history, its workouts and its nutrition logs. The archive is a zip of JSON files, generated in a job of
+// the queue and kept in the object store for Retention.
+type DataExportService struct {
+	exports       repository.DataExportRepository
+	complejos     repository.ComplejoRepository
+	events        repository.EventRepository
+	subscriptions repository.SubscriptionEventRepository
+	devices       repository.DeviceRepository
+	inventory     repository.InventoryRepository
+	lifts         repository.LiftRepository
+	workouts      repository.WorkoutRepository
+	nutrition     repository.NutritionRepository
+	tx            repository.Transactor
+	jobs          *jobs.Queue
+	objects       objectstore.Store
+	photos        objectstore.Store
+	clock         clock.Clock
+
+	Retention time.Duration // How long an archive can be downloaded once generated
+}
+
+// NewDataExportService creates a DataExportService keeping the archives in objects for 7 days; the profile
+// photos are read from photos.
+func NewDataExportService(exports repository.DataExportRepository, complejos repository.ComplejoRepository, events repository.EventRepository, subscriptions repository.SubscriptionEventRepository, devices repository.DeviceRepository, inventory repository.InventoryRepository, lifts repository.LiftRepository, workouts repository.WorkoutRepository, nutrition repository.NutritionRepository, tx repository.Transactor, queue *jobs.Queue, objects, photos objectstore.Store, clk clock.Clock) *DataExportService {
+	return &DataExportService{
+		exports:       exports,
+		complejos:     complejos,
+		events:        events,
+		subscriptions: subscriptions,
+		devices:       devices,
+		inventory:     inventory,
+		lifts:         lifts,
+		workouts:      workouts,
+		nutrition:     nutrition,
+		tx:            tx,
+		jobs:          queue,
+		objects:       objects,
+		photos:        photos,
+		clock:         clk,
+		Retention:     7 * 24 * time.Hour,
+	}
+}
+
+// Request returns the latest DataExport of the Complejo while it is being generated or can be downloaded, with
+// its link once ready. Otherwise a new one is requested and its archive generated in the background.
+func (s *DataExportService) Request(ctx context.Context, complejoID string) (*models.DataExport, error) {
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	now := s.clock.Now()
+	latest, err := s.exports.FindLatest(ctx, complejoID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if latest != nil && exportCurrent(latest, now) {
+		return withExportLink(latest), nil
+	}
+
+	export := &models.DataExport{
+		ID:          uuid.NewString(),
+		ComplejoID:  complejoID,
+		Status:      models.DataExportPending,
+		RequestedAt: now,
+	}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.exports.Insert(ctx, export); err != nil {
+			return err
+		}
+		return s.jobs.EnqueueOnce(ctx, JobDataExport, export.ID, dataExportJob{ExportID: export.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Download returns the archive of the latest DataExport of the Complejo. ErrInvalidExportToken is returned when
+// the token does not authenticate it, and ErrDataExportPending while it is being generated.
+func (s *DataExportService) Download(ctx context.Context, complejoID, token string) ([]byte, error) {
+	export, err := s.exports.FindLatest(ctx, complejoID)
+	if err != nil {
+		return nil, notFound(err, ErrDataExportNotFound)
+	}
+	if !utils.VerifyExportToken(export.ID, token) {
+		return nil, ErrInvalidExportToken
+	}
+	if export.Status == models.DataExportPending {
+		return nil, ErrDataExportPending
+	}
+	if !exportCurrent(export, s.clock.Now()) {
+		return nil, ErrDataExportNotFound
+	}
+
+	archive, err := s.objects.Get(ctx, models.DataExportKey(export.ID))
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, ErrDataExportNotFound
+	}
+	return archive, err
+}
+
+// Generate runs a JobDataExport: it stores the archive of the pending DataExport and marks it ready. Exports
+// already generated, or of Complejos removed since, are skipped.
+func (s *DataExportService) Generate(ctx context.Context, payload []byte) error {
+	var job dataExportJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	export, err := s.exports.FindByID(ctx, job.ExportID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if export.Status != models.DataExportPending {
+		return nil
+	}
+
+	archive, err := s.archive(ctx, export.ComplejoID)
+	if errors.Is(err, repository.ErrNotFound) {
+		_, err = s.exports.Delete(ctx, export.ID)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.objects.Put(ctx, models.DataExportKey(export.ID), archive); err != nil {
+		return err
+	}
+	now := s.clock.Now()
+	_, err = s.exports.MarkReady(ctx, export.ID, int64(len(archive)), now, now.Add(s.Retention))
+	return err
+}
+
+// PurgeExpired removes the expired exports with their archive and returns how many were removed.
+func (s *DataExportService) PurgeExpired(ctx context.Context) (int64, error) {
+	expired, err := s.exports.FindExpired(ctx, s.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int64
+	for _, export := range expired {
+		if err := s.objects.Delete(ctx, models.DataExportKey(export.ID)); err != nil {
+			return removed, err
+		}
+		found, err := s.exports.Delete(ctx, export.ID)
+		if err != nil {
+			return removed, err
+		}
+		if found {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// archive builds the zip of the data of the Complejo, or returns repository.ErrNotFound when it was removed.
+func (s *DataExportService) archive(ctx context.Context, complejoID string) ([]byte, error) {
+	complejo, err := s.complejos.FindByID(ctx, complejoID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+
+	events, err := s.events.FindAll(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := s.subscriptions.FindByUsername(ctx, complejo.Username)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := s.devices.FindByComplejos(ctx, []string{complejoID})
+	if err != nil {
+		return nil, err
+	}
+	loans, err := s.inventory.FindLoans(ctx, models.LoanFilter{ComplejoID: complejoID}, now)
+	if err != nil {
+		return nil, err
+	}
+	lifts, err := s.lifts.FindByComplejo(ctx, complejoID, "")
+	if err != nil {
+		return nil, err
+	}
+	workouts, _, err := s.workouts.FindByComplejo(ctx, complejoID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	nutrition, err := s.nutrition.FindByComplejo(ctx, complejoID, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var photo []byte
+	if complejo.PhotoID != "" {
+		photo, err = s.photos.Get(ctx, models.ProfilePhotoKey(complejo.PhotoID))
+		if err != nil && !errors.Is(err, objectstore.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", complejo.Response(models.ComplejoView{Photo: true, Email: true, ChurnRisk: true, Relation: models.RelationSelf})},
+		{"events.json", personalEvents(events, complejoID)},
+		{"subscriptions.json", subscriptions},
+		{"devices.json", devices},
+		{"loans.json", loans},
+		{"lifts.json", lifts},
+		{"workouts.json", workouts},
+		{"nutrition.json", nutrition},
+	}
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeZipFile(archive, file.name, data, now); err != nil {
+			return nil, err
+		}
+	}
+	if len(photo) > 0 {
+		name := "profile_photo.jpg"
+		if http.DetectContentType(photo) == "image/png" {
+			name = "profile_photo.png"
+		}
+		if err := writeZipFile(archive, name, photo, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// personalEvents returns the Events the Complejo organized, answered, liked or brought guests to, as they
+// relate to it.
+func personalEvents(events []models.Event, complejoID string) []models.PersonalEvent {
+	personal := []models.PersonalEvent{}
+	for _, event := range events {
+		event.MarkLiked(complejoID)
+		entry := models.PersonalEvent{
+			ID:        event.ID,
+			Title:     event.Title,
+			Date:      event.Date,
+			Location:  event.Location,
+			Organizer: event.CreatedBy == complejoID,
+			RSVP:      event.FindRSVP(complejoID),
+			Liked:     event.LikedByMe,
+		}
+		for _, guest := range event.Guests {
+			if guest.HostID == complejoID {
+				entry.Guests = append(entry.Guests, guest)
+			}
+		}
+		if entry.Organizer || entry.RSVP != nil || entry.Liked || len(entry.Guests) > 0 {
+			personal = append(personal, entry)
+		}
+	}
+	return personal
+}
+
+// writeZipFile adds a file with the data to the archive, modified at the given time.
+func writeZipFile(archive *zip.Writer, name string, data []byte, modified time.Time) error {
+	w, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// exportCurrent reports whether the DataExport is still being generated or can still be downloaded at now.
+func exportCurrent(export *models.DataExport, now time.Time) bool {
+	if export.Status == models.DataExportPending {
+		return now.Sub(export.RequestedAt) < dataExportStale
+	}
+	return export.ExpiresAt != nil && export.ExpiresAt.After(now)
+}
+
+// withExportLink sets the link of the archive of the DataExport once ready.
+func withExportLink(export *models.DataExport) *models.DataExport {
+	if export.Status == models.DataExportReady {
+		export.Link = "/complejo/" + export.ComplejoID + "/export.zip?token=" + utils.ExportToken(export.ID)
+	}
+	return export
+}
@@ -0,0 +1,177 @@
+// erasure_service.go
+package services
+
+import (
+	"context"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// ErasureService erases the personal data of a Complejo, at its own request or an admin's (GDPR right to be
+// forgotten). Its profile keeps only its ID: the username becomes a placeholder and the other personal fields
+// are cleared. The placeholder replaces the username wherever it was copied, the Complejo leaves the
+// participants of every Event, and the media and posts it authored are removed. Every erasure is recorded in the
+// audit log, which names the Complejo by its ID only.
+type ErasureService struct {
+	complejos repository.ComplejoRepository
+	events    repository.EventRepository
+	history   repository.SubscriptionEventRepository
+	erasure   repository.ErasureRepository
+	audit     repository.AuditRepository
+	exports   repository.DataExportRepository
+	tx        repository.Transactor
+	outbox    repository.OutboxRepository
+	objects   objectstore.Store
+	photos    objectstore.Store
+	clock     clock.Clock
+}
+
+// NewErasureService creates an ErasureService backed by the given repositories and clock; the event photos and
+// archives are removed from objects, the profile photos from photos.
+func NewErasureService(complejos repository.ComplejoRepository, events repository.EventRepository, history repository.SubscriptionEventRepository, erasure repository.ErasureRepository, audit repository.AuditRepository, exports repository.DataExportRepository, tx repository.Transactor, outbox repository.OutboxRepository, objects, photos objectstore.Store, clk clock.Clock) *ErasureService {
+	return &ErasureService{
+		complejos: complejos,
+		events:    events,
+		history:   history,
+		erasure:   erasure,
+		audit:     audit,
+		exports:   exports,
+		tx:        tx,
+		outbox:    outbox,
+		objects:   objects,
+		photos:    photos,
+		clock:     clk,
+	}
+}
+
+// Erase erases the personal data of the Complejo with the given ID on behalf of the actor, once the current
+// username of the Complejo is typed again as confirmation, in a single transaction:
+//   - its RSVPs are withdrawn from every Event, recording an "unsubscribed" transition for each Event it was
+//     going to under the placeholder;
+//   - the placeholder replaces its username in the records of the other resources, and its photo tags are
+//     removed;
+//   - its event photos, lost-and-found posts, content held for review, devices, lift history, workouts,
+//     nutrition logs, challenge participations, group memberships, follows and blocks (both ways), feed items
+//     and the reports it filed are removed;
+//   - its profile is anonymized and marked as deleted, so it is purged like any deleted Complejo but can no
+//     longer be restored;
+//   - the erasure is recorded in the audit log, and announced as a deletion under the placeholder.
+//
+// The files of its photos and of its latest data export are then removed from the object stores. Deleted
+// Complejos must be restored before they can be erased.
+func (s *ErasureService) Erase(ctx context.Context, complejoID, actorID string, confirmation models.ErasureConfirmation) (*models.AuditEntry, error) {
+	complejo, err := s.complejos.FindByID(ctx, complejoID)
+	if err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	if confirmation.Username != complejo.Username {
+		return nil, ErrErasureNotConfirmed
+	}
+
+	placeholder := models.ErasedUsername(complejo.ID)
+	entry := &models.AuditEntry{
+		ID:         uuid.NewString(),
+		Action:     models.AuditComplejoErased,
+		ActorID:    actorID,
+		TargetID:   complejo.ID,
+		OccurredAt: s.clock.Now(),
+	}
+	var photoKeys []string
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		eventIDs, err := s.events.RemoveRSVPsFromAll(ctx, complejo.ID)
+		if err != nil {
+			return err
+		}
+		anonymized, err := s.erasure.Anonymize(ctx, complejo.ID, complejo.Username, placeholder)
+		if err != nil {
+			return err
+		}
+		for _, eventID := range eventIDs {
+			err := s.history.Append(ctx, &models.SubscriptionEvent{
+				ID:         uuid.NewString(),
+				EventID:    eventID,
+				Username:   placeholder,
+				Type:       models.SubscriptionUnsubscribed,
+				OccurredAt: entry.OccurredAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		keys, removed, err := s.erasure.RemoveContent(ctx, complejo.ID)
+		if err != nil {
+			return err
+		}
+		photoKeys = keys
+
+		found, err := s.complejos.Anonymize(ctx, complejo.ID, placeholder, entry.OccurredAt)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrComplejoNotFound
+		}
+
+		if eventIDs == nil {
+			eventIDs = []string{}
+		}
+		entry.Details = map[string]int64{"events_left": int64(len(eventIDs))}
+		for collection, count := range anonymized {
+			entry.Details["anonymized_"+collection] = count
+		}
+		for collection, count := range removed {
+			entry.Details["removed_"+collection] = count
+		}
+		if err := s.audit.Append(ctx, entry); err != nil {
+			return err
+		}
+		return s.announce(ctx, bus.ComplejoDeleted{
+			ID:       complejo.ID,
+			Username: placeholder,
+			Events:   eventIDs,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.removeFiles(ctx, complejo, photoKeys)
+	return entry, nil
+}
+
+// removeFiles removes the files of the erased Complejo: its event photos with their size variants, its profile
+// photo with its size variants and the archive of its latest data export, with the export itself. It is best
+// effort: a failure leaves at worst orphan files, and the archive is removed once it expires anyway.
+func (s *ErasureService) removeFiles(ctx context.Context, complejo *models.Complejo, photoKeys []string) {
+	for _, key := range photoKeys {
+		removeSizes(ctx, s.objects, key)
+		s.objects.Delete(ctx, key)
+	}
+	if complejo.PhotoID != "" {
+		removeSizes(ctx, s.photos, models.ProfilePhotoKey(complejo.PhotoID))
+		s.photos.Delete(ctx, models.ProfilePhotoKey(complejo.PhotoID))
+	}
+
+	export, err := s.exports.FindLatest(ctx, complejo.ID)
+	if err != nil {
+		return
+	}
+	s.objects.Delete(ctx, models.DataExportKey(export.ID))
+	s.exports.Delete(ctx, export.ID)
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *ErasureService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
@@ -0,0 +1,133 @@
+// erasure_service_test.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/outbox"
+)
+
+func TestErase(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	complejos := newFakeComplejos(models.Complejo{ID: "c1", Username: "maria", Role: "user", PhotoID: "p1"})
+	events := newFakeEvents()
+	events.rsvps["c1"] = []string{"e1", "e2"}
+	history, audit, out := &fakeHistory{}, &fakeAudit{}, &fakeOutbox{}
+	erasure := &fakeErasure{
+		anonymized: map[string]int64{"guests": 1},
+		removed:    map[string]int64{"photos": 1, "devices": 2},
+		photoKeys:  []string{"events/e1/photo.jpg"},
+	}
+	exports := &fakeExports{exports: map[string]models.DataExport{"x1": {ID: "x1", ComplejoID: "c1", RequestedAt: now}}}
+	objects, photos := objectstore.NewDir(t.TempDir()), objectstore.NewDir(t.TempDir())
+	files := map[objectstore.Store][]string{
+		objects: {"events/e1/photo.jpg", models.VariantKey("events/e1/photo.jpg", models.PhotoSizeThumb), models.DataExportKey("x1")},
+		photos:  {models.ProfilePhotoKey("p1"), models.VariantKey(models.ProfilePhotoKey("p1"), models.PhotoSizeMedium)},
+	}
+	for store, keys := range files {
+		for _, key := range keys {
+			if err := store.Put(ctx, key, []byte("data")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	svc := NewErasureService(complejos, events, history, erasure, audit, exports, fakeTx{}, out, objects, photos, clock.NewFake(now))
+
+	entry, err := svc.Erase(ctx, "c1", "admin", models.ErasureConfirmation{Username: "maria"})
+	if err != nil {
+		t.Fatalf("Erase: %v", err)
+	}
+
+	placeholder := models.ErasedUsername("c1")
+	stored, _ := complejos.FindByID(ctx, "c1")
+	if stored.Username != placeholder || stored.DeletedAt == nil {
+		t.Errorf("stored Complejo = %+v, want anonymized as %s and deleted", stored, placeholder)
+	}
+	if len(erasure.usernames) != 1 || erasure.usernames[0] != "maria" {
+		t.Errorf("anonymized usernames = %v, want [maria]", erasure.usernames)
+	}
+	if len(history.transitions) != 2 {
+		t.Fatalf("transitions = %+v, want one per Event left", history.transitions)
+	}
+	for _, transition := range history.transitions {
+		if transition.Username != placeholder || transition.Type != models.SubscriptionUnsubscribed {
+			t.Errorf("transition = %+v, want unsubscribed under the placeholder", transition)
+		}
+	}
+
+	want := map[string]int64{"events_left": 2, "anonymized_guests": 1, "removed_photos": 1, "removed_devices": 2}
+	if len(audit.entries) != 1 || audit.entries[0].ID != entry.ID {
+		t.Fatalf("audit entries = %+v, want the returned entry", audit.entries)
+	}
+	if entry.Action != models.AuditComplejoErased || entry.ActorID != "admin" || entry.TargetID != "c1" || !entry.OccurredAt.Equal(now) {
+		t.Errorf("entry = %+v, want the erasure of c1 by admin", entry)
+	}
+	if len(entry.Details) != len(want) {
+		t.Errorf("details = %v, want %v", entry.Details, want)
+	}
+	for key, count := range want {
+		if entry.Details[key] != count {
+			t.Errorf("details = %v, want %v", entry.Details, want)
+		}
+	}
+
+	if topics := out.topics(); len(topics) != 1 || topics[0] != outbox.TopicComplejoDeleted {
+		t.Fatalf("topics = %v, want [%s]", topics, outbox.TopicComplejoDeleted)
+	}
+	var deleted bus.ComplejoDeleted
+	if err := json.Unmarshal(out.messages[0].Payload, &deleted); err != nil {
+		t.Fatal(err)
+	}
+	if deleted.ID != "c1" || deleted.Username != placeholder || len(deleted.Events) != 2 {
+		t.Errorf("announced %+v, want c1 under the placeholder leaving 2 Events", deleted)
+	}
+
+	for store, keys := range files {
+		for _, key := range keys {
+			if _, err := store.Get(ctx, key); !errors.Is(err, objectstore.ErrNotFound) {
+				t.Errorf("Get(%s) error = %v, want the file removed", key, err)
+			}
+		}
+	}
+	if _, ok := exports.exports["x1"]; ok {
+		t.Error("the data export was kept, want it removed")
+	}
+}
+
+func TestEraseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		username string
+		wantErr  error
+	}{
+		{"wrong confirmation", "c1", "Maria", ErrErasureNotConfirmed},
+		{"empty confirmation", "c1", "", ErrErasureNotConfirmed},
+		{"unknown Complejo", "c2", "maria", ErrComplejoNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			complejos := newFakeComplejos(models.Complejo{ID: "c1", Username: "maria", Role: "user"})
+			out := &fakeOutbox{}
+			// The other repositories are nil: the erasure is refused before reaching them
+			svc := NewErasureService(complejos, nil, nil, nil, nil, nil, fakeTx{}, out, nil, nil, clock.NewFake(time.Now()))
+
+			_, err := svc.Erase(context.Background(), tt.id, "admin", models.ErasureConfirmation{Username: tt.username})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Erase error = %v, want %v", err, tt.wantErr)
+			}
+			if stored, _ := complejos.FindByID(context.Background(), "c1"); stored.Username != "maria" || len(out.messages) != 0 {
+				t.Errorf("stored = %+v, messages = %d, want nothing changed", stored, len(out.messages))
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// errors.go
+package services
+
+import (
+	"errors"
+	"net/http"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/repository"
+)
+
+// Typed errors returned by the services and rendered by the error middleware.
+var (
+	ErrComplejoNotFound        = apperrors.New(http.StatusNotFound, "complejo_not_found", "Complejo not found")
+	ErrEventNotFound           = apperrors.New(http.StatusNotFound, "event_not_found", "Event not found")
+	ErrDeletedComplejoNotFound = apperrors.New(http.StatusNotFound, "deleted_complejo_not_found", "No deleted Complejo with this ID")
+	ErrDeletedEventNotFound    = apperrors.New(http.StatusNotFound, "deleted_event_not_found", "No deleted event with this ID")
+	ErrNoValidFields           = apperrors.New(http.StatusBadRequest, "no_valid_fields", "No valid fields to update")
+	ErrEventPast               = apperrors.New(http.StatusConflict, "event_past", "The event has already taken place")
+	ErrAlreadySubscribed       = apperrors.New(http.StatusConflict, "already_subscribed", "Complejo is already subscribed to the event")
+	ErrNotSubscribed           = apperrors.New(http.StatusConflict, "not_subscribed", "Complejo is not subscribed to the event")
+	ErrNotEventOwner           = apperrors.New(http.StatusForbidden, "not_event_owner", "Only admins and the creator of the event can change or delete it")
+	ErrJournalEntryNotFound    = apperrors.New(http.StatusNotFound, "journal_entry_not_found", "Journal entry not found")
+	ErrUsernameTaken           = apperrors.New(http.StatusConflict, "username_taken", "This username is already taken, please choose another one")
+	ErrImageQueueFull          = apperrors.New(http.StatusTooManyRequests, "image_queue_full", "Too many images are being processed, please retry later")
+	ErrPaymentsDisabled        = apperrors.New(http.StatusForbidden, "payments_disabled", "Payment webhooks are disabled")
+	ErrInvalidStripeSignature  = apperrors.New(http.StatusBadRequest, "invalid_signature", "The webhook signature is invalid or expired")
+	ErrInvalidStripePayload    = apperrors.New(http.StatusBadRequest, "invalid_payload", "The webhook payload is not a valid Stripe event")
+	ErrExpenseNotFound         = apperrors.New(http.StatusNotFound, "expense_not_found", "Expense not found")
+	ErrNotEventOrganizer       = apperrors.New(http.StatusForbidden, "not_event_organizer", "Only admins and the creator of the event can manage its expenses")
+	ErrItemNotFound            = apperrors.New(http.StatusNotFound, "item_not_found", "Inventory item not found")
+	ErrItemOnLoan              = apperrors.New(http.StatusConflict, "item_on_loan", "Units of this item are on loan")
+	ErrItemUnavailable         = apperrors.New(http.StatusConflict, "item_unavailable", "Not enough units of this item are available")
+	ErrLoanNotFound            = apperrors.New(http.StatusNotFound, "loan_not_found", "Loan not found")
+	ErrLoanReturned            = apperrors.New(http.StatusConflict, "loan_returned", "The item has already been returned")
+	ErrLostItemNotFound        = apperrors.New(http.StatusNotFound, "lost_item_not_found", "Lost-and-found post not found")
+	ErrLostItemResolved        = apperrors.New(http.StatusConflict, "lost_item_resolved", "The lost-and-found post has already been resolved")
+	ErrNotLostItemPoster       = apperrors.New(http.StatusForbidden, "not_lost_item_poster", "Only admins and the author of the post can remove it")
+	ErrOwnLostItem             = apperrors.New(http.StatusConflict, "own_lost_item", "You cannot claim your own post")
+	ErrClaimNotFound           = apperrors.New(http.StatusNotFound, "claim_not_found", "Claim not found")
+	ErrClaimPending            = apperrors.New(http.StatusConflict, "claim_pending", "You already have a pending claim on this post")
+	ErrClaimDecided            = apperrors.New(http.StatusConflict, "claim_decided", "The claim has already been decided")
+	ErrHoldNotFound            = apperrors.New(http.StatusNotFound, "hold_not_found", "Held content not found")
+	ErrHoldDecided             = apperrors.New(http.StatusConflict, "hold_decided", "The held content has already been reviewed")
+	ErrLevelRestricted         = apperrors.New(http.StatusForbidden, "level_restricted", "This advanced session is closed to beginners; ask the organizer to let you through")
+	ErrNotLevelOrganizer       = apperrors.New(http.StatusForbidden, "not_level_organizer", "Only admins and the creator of the event can manage its level gate")
+	ErrEventFull               = apperrors.New(http.StatusConflict, "event_full", "The event is full")
+	ErrGuestPassesUsed         = apperrors.New(http.StatusConflict, "guest_passes_used", "You have used all your guest passes for this month")
+	ErrGuestNotFound           = apperrors.New(http.StatusNotFound, "guest_not_found", "Guest not found")
+	ErrNotGuestHost            = apperrors.New(http.StatusForbidden, "not_guest_host", "Only admins and the member who registered the guest can remove it")
+	ErrInvitationNotFound      = apperrors.New(http.StatusNotFound, "invitation_not_found", "Invitation not found")
+	ErrInvitationExpired       = apperrors.New(http.StatusGone, "invitation_expired", "The invitation has expired")
+	ErrInvitationUsed          = apperrors.New(http.StatusConflict, "invitation_used", "The invitation has already been used")
+	ErrInvalidCalendarToken    = apperrors.New(http.StatusUnauthorized, "invalid_calendar_token", "The calendar token is missing or invalid")
+	ErrRouteNotFound           = apperrors.New(http.StatusNotFound, "route_not_found", "The event has no route")
+	ErrEventNotOutdoor         = apperrors.New(http.StatusConflict, "event_not_outdoor", "Routes can only be attached to outdoor events")
+	ErrNotEventParticipant     = apperrors.New(http.StatusForbidden, "not_event_participant", "Only the participants and organizers of the event can download its route")
+	ErrPhotoNotFound           = apperrors.New(http.StatusNotFound, "photo_not_found", "Photo not found")
+	ErrEventNotStarted         = apperrors.New(http.StatusConflict, "event_not_started", "Photos can be added to the album once the event has started")
+	ErrNotAlbumParticipant     = apperrors.New(http.StatusForbidden, "not_album_participant", "Only the participants and organizers of the event can add photos to its album")
+	ErrNotPhotoOrganizer       = apperrors.New(http.StatusForbidden, "not_photo_organizer", "Only admins and the creator of the event can approve its photos")
+	ErrNotPhotoAuthor          = apperrors.New(http.StatusForbidden, "not_photo_author", "Only admins, the creator of the event and the author of the photo can remove it")
+	ErrPhotoApproved           = apperrors.New(http.StatusConflict, "photo_approved", "The photo has already been approved")
+	ErrNotTagged               = apperrors.New(http.StatusConflict, "not_tagged", "You are not tagged in this photo")
+	ErrConsentGranted          = apperrors.New(http.StatusConflict, "consent_granted", "You have already granted your consent to this photo")
+	ErrShiftNotFound           = apperrors.New(http.StatusNotFound, "shift_not_found", "Volunteer shift not found")
+	ErrNotShiftOrganizer       = apperrors.New(http.StatusForbidden, "not_shift_organizer", "Only admins and the creator of the event can manage its volunteer shifts")
+	ErrShiftStarted            = apperrors.New(http.StatusConflict, "shift_started", "The volunteer shift has already started")
+	ErrShiftFull               = apperrors.New(http.StatusConflict, "shift_full", "The volunteer shift is full")
+	ErrAlreadyVolunteering     = apperrors.New(http.StatusConflict, "already_volunteering", "Complejo is already signed up for the volunteer shift")
+	ErrNotVolunteering         = apperrors.New(http.StatusConflict, "not_volunteering", "Complejo is not signed up for the volunteer shift")
+	ErrPushDisabled            = apperrors.New(http.StatusForbidden, "push_disabled", "Push notifications are disabled for this platform")
+	ErrDeviceNotFound          = apperrors.New(http.StatusNotFound, "device_not_found", "Device not found")
+	ErrAnnouncementNotFound    = apperrors.New(http.StatusNotFound, "announcement_not_found", "Announcement not found")
+	ErrTermsNotFound           = apperrors.New(http.StatusNotFound, "terms_not_found", "No terms document of this kind has been published")
+	ErrVersionNotFound         = apperrors.New(http.StatusNotFound, "version_not_found", "Version not found")
+	ErrContentUnchanged        = apperrors.New(http.StatusConflict, "content_unchanged", "The new version is identical to the current one")
+	ErrVersionConflict         = apperrors.New(http.StatusConflict, "version_conflict", "The document was changed at the same time, please retry")
+	ErrOffboardingNotFound     = apperrors.New(http.StatusNotFound, "offboarding_not_found", "No offboarding of the club is pending")
+	ErrOffboardingPending      = apperrors.New(http.StatusConflict, "offboarding_pending", "An offboarding of the club is already pending")
+	ErrInvalidConfirmationCode = apperrors.New(http.StatusForbidden, "invalid_confirmation_code", "The confirmation code does not match")
+	ErrOffboardingNotExported  = apperrors.New(http.StatusConflict, "offboarding_not_exported", "The data must be exported after the offboarding was requested")
+	ErrOffboardingCoolingOff   = apperrors.New(http.StatusConflict, "offboarding_cooling_off", "The cooling-off delay of the offboarding has not elapsed yet")
+	ErrEventStreamUnavailable  = apperrors.New(http.StatusServiceUnavailable, "event_stream_unavailable", "The live stream of events is not available on this deployment")
+	ErrResumeTokenExpired      = apperrors.New(http.StatusGone, "resume_token_expired", "The stream cannot resume after this event, reload the events and reconnect without Last-Event-ID")
+	ErrWebhookNotFound         = apperrors.New(http.StatusNotFound, "webhook_not_found", "Webhook not found")
+	ErrSandboxDisabled         = apperrors.New(http.StatusForbidden, "sandbox_disabled", "This deployment is not a sandbox, its data cannot be reset")
+	ErrImportFormat            = apperrors.New(http.StatusUnsupportedMediaType, "unsupported_import_format", "Imports must be sent as text/csv or application/json")
+	ErrServiceAccountNotFound  = apperrors.New(http.StatusNotFound, "service_account_not_found", "Service account not found")
+	ErrInvalidServiceToken     = apperrors.New(http.StatusUnauthorized, "invalid_service_token", "Invalid or expired token")
+	ErrNotServiceToken         = apperrors.New(http.StatusForbidden, "not_service_token", "Only a request authenticated with a service token can rotate it")
+	ErrServiceAccountForbidden = apperrors.New(http.StatusForbidden, "service_account_forbidden", "Service accounts cannot manage the service accounts")
+	ErrDataExportNotFound      = apperrors.New(http.StatusNotFound, "data_export_not_found", "No export of your data can be downloaded, request one with GET /complejo/me/export")
+	ErrDataExportPending       = apperrors.New(http.StatusConflict, "data_export_pending", "The archive of your data is still being generated")
+	ErrInvalidExportToken      = apperrors.New(http.StatusUnauthorized, "invalid_export_token", "The export token is missing or invalid")
+	ErrErasureNotConfirmed     = apperrors.New(http.StatusUnprocessableEntity, "erasure_not_confirmed", "Type the current username of the Complejo to confirm its erasure")
+	ErrWorkoutNotFound         = apperrors.New(http.StatusNotFound, "workout_not_found", "Workout not found")
+	ErrNotWorkoutOwner         = apperrors.New(http.StatusForbidden, "not_workout_owner", "Only the author of the workout can read or change it")
+	ErrExerciseNotFound        = apperrors.New(http.StatusNotFound, "exercise_not_found", "Exercise not found")
+	ErrNutritionLogNotFound    = apperrors.New(http.StatusNotFound, "nutrition_log_not_found", "Nutrition log not found")
+	ErrNotNutritionLogOwner    = apperrors.New(http.StatusForbidden, "not_nutrition_log_owner", "Only the author of the nutrition log can read or change it")
+	ErrNutritionDayLogged      = apperrors.New(http.StatusConflict, "nutrition_day_logged", "This day already has a nutrition log, change it instead")
+	ErrBodyweightUnknown       = apperrors.New(http.StatusConflict, "bodyweight_unknown", "Set your weight on your profile to calculate your nutrition targets")
+	ErrChallengeNotFound       = apperrors.New(http.StatusNotFound, "challenge_not_found", "Challenge not found")
+	ErrNotChallengeCreator     = apperrors.New(http.StatusForbidden, "not_challenge_creator", "Only admins and the creator of the challenge can delete it")
+	ErrChallengeEnded          = apperrors.New(http.StatusConflict, "challenge_ended", "The challenge has ended, its participants can no longer change")
+	ErrGroupNotFound           = apperrors.New(http.StatusNotFound, "group_not_found", "Group not found")
+	ErrGroupMemberNotFound     = apperrors.New(http.StatusNotFound, "group_member_not_found", "The Complejo is not a member of the group")
+	ErrNotGroupOwner           = apperrors.New(http.StatusForbidden, "not_group_owner", "Only admins and the owners of the group can change it")
+	ErrNotGroupMember          = apperrors.New(http.StatusForbidden, "not_group_member", "Only the members of the group can do this")
+	ErrLastGroupOwner          = apperrors.New(http.StatusConflict, "last_group_owner", "The group needs an owner: make another member owner first, or delete the group")
+	ErrFollowSelf              = apperrors.New(http.StatusUnprocessableEntity, "follow_self", "You cannot follow yourself")
+	ErrBlockSelf               = apperrors.New(http.StatusUnprocessableEntity, "block_self", "You cannot block yourself")
+	ErrBlocked                 = apperrors.New(http.StatusForbidden, "blocked", "One of you blocked the other")
+	ErrReportSelf              = apperrors.New(http.StatusUnprocessableEntity, "report_self", "You cannot report yourself")
+	ErrAlreadyReported         = apperrors.New(http.StatusConflict, "already_reported", "You already reported this, it is waiting for review")
+	ErrReportNotFound          = apperrors.New(http.StatusNotFound, "report_not_found", "Report not found")
+	ErrReportDecided           = apperrors.New(http.StatusConflict, "report_decided", "The report was already reviewed")
+)
+
+// usernameTaken replaces repository.ErrDuplicate with ErrUsernameTaken naming the username, and returns other errors unchanged.
+func usernameTaken(err error, username interface{}) error {
+	if errors.Is(err, repository.ErrDuplicate) {
+		return ErrUsernameTaken.WithDetails(map[string]interface{}{"username": username})
+	}
+	return err
+}
+
+// notFound replaces repository.ErrNotFound with the given typed error and returns other errors unchanged.
+func notFound(err error, typed *apperrors.Error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return typed
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+// event_ingest.go
+package services
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// Ingest upserts event definitions pushed by a trusted external producer, keyed by their external ID.
+//
+// Each definition is handled independently and reported in the returned IngestReport:
+// unknown external IDs create a new Event, newer versions update the existing one, identical
+// definitions are left untouched, and stale or contradictory definitions are reported as conflicts.
+// Re-sending the same batch is therefore safe. Only storage failures abort the batch.
+func (s *EventService) Ingest(ctx context.Context, definitions []models.IngestEvent) (*models.IngestReport, error) {
+	report := &models.IngestReport{
+		Summary: map[string]int{},
+		Results: make([]models.IngestResult, 0, len(definitions)),
+	}
+
+	seen := map[string]bool{}
+	for _, definition := range definitions {
+		result, err := s.ingestOne(ctx, definition, seen)
+		if err != nil {
+			return nil, err
+		}
+		report.Results = append(report.Results, result)
+		report.Summary[result.Status]++
+	}
+
+	return report, nil
+}
+
+// ingestOne applies a single definition; seen tracks the external IDs already handled in the batch.
+func (s *EventService) ingestOne(ctx context.Context, definition models.IngestEvent, seen map[string]bool) (models.IngestResult, error) {
+	result := models.IngestResult{ExternalID: definition.ExternalID}
+
+	if reason := invalidIngestReason(definition); reason != "" {
+		result.Status, result.Reason = models.IngestInvalid, reason
+		return result, nil
+	}
+	if seen[definition.ExternalID] {
+		result.Status, result.Reason = models.IngestConflict, "external_id appears more than once in the batch"
+		return result, nil
+	}
+	seen[definition.ExternalID] = true
+
+	existing, err := s.repo.FindByExternalID(ctx, definition.ExternalID)
+	if errors.Is(err, repository.ErrNotFound) {
+		updatedAt := definition.UpdatedAt
+		event := &models.Event{
+			Title:             definition.Title,
+			Description:       definition.Description,
+			RSVPs:             []models.RSVP{},
+			Date:              definition.Date,
+			Image:             definition.Image,
+			Location:          definition.Location,
+			ExternalID:        definition.ExternalID,
+			ExternalUpdatedAt: &updatedAt,
+		}
+		if err := s.Create(ctx, event, ""); err != nil {
+			return result, err
+		}
+		result.Status, result.EventID = models.IngestCreated, event.ID
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.EventID = existing.ID
+	stored := existing.ExternalUpdatedAt
+
+	switch {
+	case existing.DeletedAt != nil:
+		result.Status, result.Reason = models.IngestConflict, "the event was deleted by an admin"
+		return result, nil
+	case stored != nil && definition.UpdatedAt.Before(*stored):
+		result.Status, result.Reason = models.IngestConflict, "a newer version of this event is already stored"
+		return result, nil
+	case matchesDefinition(existing, definition):
+		result.Status = models.IngestUnchanged
+		return result, nil
+	case stored != nil && definition.UpdatedAt.Equal(*stored):
+		result.Status, result.Reason = models.IngestConflict, "definition differs from the stored version but updated_at did not change"
+		return result, nil
+	}
+
+	err = s.update(ctx, existing.ID, map[string]interface{}{
+		"title":               definition.Title,
+		"description":         definition.Description,
+		"date":                definition.Date,
+		"image":               definition.Image,
+		"location":            definition.Location,
+		"external_updated_at": definition.UpdatedAt,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Status = models.IngestUpdated
+	return result, nil
+}
+
+// invalidIngestReason returns why the definition cannot be ingested, or "" when it is valid.
+func invalidIngestReason(definition models.IngestEvent) string {
+	switch {
+	case definition.ExternalID == "":
+		return "external_id is required"
+	case definition.Title == "":
+		return "title is required"
+	case definition.Date.IsZero():
+		return "date is required"
+	case definition.UpdatedAt.IsZero():
+		return "updated_at is required"
+	}
+	return ""
+}
+
+// matchesDefinition reports whether the stored Event already has the definition's content.
+func matchesDefinition(event *models.Event, definition models.IngestEvent) bool {
+	sameImage := (event.Image == nil && definition.Image == nil) ||
+		(event.Image != nil && definition.Image != nil && *event.Image == *definition.Image)
+
+	return event.Title == definition.Title &&
+		event.Description == definition.Description &&
+		event.Date.Equal(definition.Date) &&
+		event.Location == definition.Location &&
+		sameImage
+}
@@ -0,0 +1,113 @@
+// event_route.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/gpx"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/validation"
+)
+
+// SetRoute attaches the GPX file as the route of the outdoor Event, replacing its previous route, and returns
+// its summary (distance and elevation). Only admins and the creator of the Event may attach one.
+// ErrEventNotOutdoor is returned for indoor Events, and a validation error when the file is not a GPX route.
+func (s *EventService) SetRoute(ctx context.Context, eventID string, data []byte, requesterID string, isAdmin bool) (*models.EventRoute, error) {
+	event, err := s.repo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+	if !owns(event, requesterID, isAdmin) {
+		return nil, ErrNotEventOwner
+	}
+	if !event.Outdoor {
+		return nil, ErrEventNotOutdoor
+	}
+
+	summary, err := gpx.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperrors.Validation("Validation failed", []validation.FieldError{
+			{Field: "route", Rule: "gpx", Message: "must be a GPX file with a track or route of at least two points"},
+		})
+	}
+
+	route := &models.EventRoute{
+		Name:           summary.Name,
+		DistanceM:      summary.Distance,
+		ElevationGainM: summary.ElevationGain,
+		ElevationLossM: summary.ElevationLoss,
+		MinElevationM:  summary.MinElevation,
+		MaxElevationM:  summary.MaxElevation,
+		Points:         summary.Points,
+		Size:           len(data),
+		Key:            "routes/" + eventID + ".gpx",
+		UploadedBy:     requesterID,
+		UploadedAt:     s.clock.Now(),
+	}
+	// The file is stored first: a failure leaves the previous summary describing the previous file at worst
+	if err := s.objects.Put(ctx, route.Key, data); err != nil {
+		return nil, err
+	}
+	found, err := s.repo.SetRoute(ctx, eventID, route)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrEventNotFound
+	}
+	return route, nil
+}
+
+// DeleteRoute removes the route of the Event and its GPX file. Only admins and the creator of the Event may remove it.
+func (s *EventService) DeleteRoute(ctx context.Context, eventID, requesterID string, isAdmin bool) error {
+	event, err := s.repo.FindByID(ctx, eventID)
+	if err != nil {
+		return notFound(err, ErrEventNotFound)
+	}
+	if !owns(event, requesterID, isAdmin) {
+		return ErrNotEventOwner
+	}
+	if event.Route == nil {
+		return ErrRouteNotFound
+	}
+
+	found, err := s.repo.SetRoute(ctx, eventID, nil)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrEventNotFound
+	}
+	return s.objects.Delete(ctx, event.Route.Key)
+}
+
+// RouteFile returns the GPX file of the route of the Event. Only admins, the creator of the Event and the
+// Complejos going or maybe going may download it; ErrNotEventParticipant is returned to the others.
+func (s *EventService) RouteFile(ctx context.Context, eventID, requesterID string, isAdmin bool) ([]byte, error) {
+	event, err := s.repo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+	if event.Route == nil {
+		return nil, ErrRouteNotFound
+	}
+	if !owns(event, requesterID, isAdmin) {
+		rsvp := event.FindRSVP(requesterID)
+		if rsvp == nil || (rsvp.Status != models.RSVPGoing && rsvp.Status != models.RSVPMaybe) {
+			return nil, ErrNotEventParticipant
+		}
+	}
+
+	data, err := s.objects.Get(ctx, event.Route.Key)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, ErrRouteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,807 @@
+// event_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/ical"
+	"los-complejos-backend/imaging"
+	"los-complejos-backend/markdown"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// EventService implements the business logic for Event resources.
+type EventService struct {
+	repo       repository.EventRepository
+	complejos  repository.ComplejoRepository
+	history    repository.SubscriptionEventRepository
+	tx         repository.Transactor
+	outbox     repository.OutboxRepository
+	settings   *SettingsService
+	thumbnails *imaging.Pool
+	objects    objectstore.Store
+	photos     objectstore.Store
+	clock      clock.Clock
+
+	GuestPasses int             // Guests each member may bring per calendar month
+	Location    *time.Location  // Time zone of the months of the guest passes
+	Markdown    markdown.Policy // Renders the Markdown descriptions into sanitized HTML
+	PinDuration time.Duration   // How long an Event stays pinned when the admin sets no expiry
+	Duration    time.Duration   // How long Events last in the exported calendars, as they have no end
+	// How far back the personal calendar feeds go, so attended Events stay in the calendar apps
+	CalendarHistory time.Duration
+	// Follows the changes of the Events for their live stream (nil when the storage backend cannot)
+	Watcher repository.EventWatcher
+	// Memberships checked when answering "going" to the Events of a group (nil to let anyone go)
+	Groups repository.GroupRepository
+	// Hides the photos of the participants from the callers their privacy settings exclude, and the participants
+	// blocking the caller (nil to show every participant)
+	Privacy *PrivacyService
+}
+
+// NewEventService creates an EventService backed by the given repositories and clock, giving each member
+// two guest passes per UTC month, rendering the descriptions with the default Markdown policy and pinning events for a week. The Complejo repository provides the lifts checked by the level gate,
+// thumbnails of the participants' photos are made on the given pool and the GPX routes and share images are kept in the object store;
+// the profile photos are read from their own store. The listing settings of the club default the Event listings.
+func NewEventService(repo repository.EventRepository, complejos repository.ComplejoRepository, history repository.SubscriptionEventRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, settings *SettingsService, thumbnails *imaging.Pool, objects, photos objectstore.Store, clk clock.Clock) *EventService {
+	return &EventService{
+		repo:        repo,
+		complejos:   complejos,
+		history:     history,
+		tx:          tx,
+		outbox:      outboxRepo,
+		settings:    settings,
+		thumbnails:  thumbnails,
+		objects:     objects,
+		photos:      photos,
+		clock:       clk,
+		GuestPasses: 2,
+		Location:    time.UTC,
+		Markdown:    markdown.DefaultPolicy(),
+		PinDuration: 7 * 24 * time.Hour,
+		Duration:    2 * time.Hour,
+
+		CalendarHistory: 30 * 24 * time.Hour,
+	}
+}
+
+// Create assigns a new ID to the Event, records the Complejo that created it and stores it with its initial RSVPs.
+// The creation is announced (EventCreated) through the outbox in the same transaction.
+func (s *EventService) Create(ctx context.Context, event *models.Event, creatorID string) error {
+	event.ID = uuid.NewString()
+	event.CreatedBy = creatorID
+	if event.RSVPs == nil {
+		event.RSVPs = []models.RSVP{}
+	}
+	s.present(event)
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Insert(ctx, event); err != nil {
+			return err
+		}
+		return s.announce(ctx, bus.EventCreated{Event: *event})
+	})
+}
+
+// List returns every Event with its RSVP counts, the pinned ones first.
+func (s *EventService) List(ctx context.Context) ([]models.Event, error) {
+	events, err := s.repo.FindAll(ctx, s.clock.Now())
+	s.presentAll(events)
+	return events, err
+}
+
+// Search returns the requested page of Events matching the filter, the pinned ones first, then by date,
+// with their RSVP counts, and the total number of matches. What the filter leaves out is defaulted on it from
+// the listing settings of the club (see models.EventFilter.Apply).
+func (s *EventService) Search(ctx context.Context, filter *models.EventFilter) ([]models.Event, int64, error) {
+	settings, err := s.settings.Listing(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	now := s.clock.Now()
+	filter.Apply(settings, now)
+	filter.Normalize()
+	events, total, err := s.repo.Search(ctx, *filter, now)
+	s.presentAll(events)
+	return events, total, err
+}
+
+// Featured returns the upcoming featured Events for the feed of the public site, the pinned ones first,
+// then by date, ten unless the query says otherwise.
+func (s *EventService) Featured(ctx context.Context, query models.FeaturedQuery) ([]models.Event, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = models.DefaultFeaturedLimit
+	}
+	events, err := s.repo.FindFeatured(ctx, s.clock.Now(), min(limit, models.MaxFeaturedLimit))
+	s.presentAll(events)
+	return events, err
+}
+
+// Pin pins the Event to the top of the listings until the given time, or for PinDuration when none is given.
+// Pinning it again replaces the expiry.
+func (s *EventService) Pin(ctx context.Context, id string, pin models.EventPin) (*models.Event, error) {
+	until := s.clock.Now().Add(s.PinDuration)
+	if pin.Until != nil {
+		until = *pin.Until
+	}
+	return s.promote(ctx, id, map[string]interface{}{"pinned_until": until})
+}
+
+// Unpin removes the pin of the Event; unpinning it again changes nothing.
+func (s *EventService) Unpin(ctx context.Context, id string) (*models.Event, error) {
+	return s.promote(ctx, id, map[string]interface{}{"pinned_until": nil})
+}
+
+// Feature shows the Event in the feed of the public site, or removes it from the feed when featured is false.
+func (s *EventService) Feature(ctx context.Context, id string, featured bool) (*models.Event, error) {
+	return s.promote(ctx, id, map[string]interface{}{"featured": featured})
+}
+
+// promote sets the pin or feature fields on the Event and returns it.
+func (s *EventService) promote(ctx context.Context, id string, fields map[string]interface{}) (*models.Event, error) {
+	found, err := s.repo.UpdateByID(ctx, id, fields)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrEventNotFound
+	}
+	return s.Get(ctx, id)
+}
+
+// TextSearch returns the Events most relevant to the full-text query, with their score and RSVP counts.
+func (s *EventService) TextSearch(ctx context.Context, search models.EventSearch) ([]models.ScoredEvent, error) {
+	if search.Limit < 1 {
+		search.Limit = models.DefaultEventPageLimit
+	}
+	events, err := s.repo.TextSearch(ctx, search.Query, search.Limit)
+	for i := range events {
+		s.present(&events[i].Event)
+	}
+	return events, err
+}
+
+// Get returns the Event with the given ID with its RSVP counts.
+func (s *EventService) Get(ctx context.Context, id string) (*models.Event, error) {
+	event, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+	s.present(event)
+	return event, nil
+}
+
+// UpdateForAdmin applies the fields present in the update to the Event with the given ID.
+// ErrNoValidFields is returned when none are present.
+func (s *EventService) UpdateForAdmin(ctx context.Context, id string, update models.EventUpdate) error {
+	fields := update.Fields()
+	if len(fields) == 0 {
+		return ErrNoValidFields
+	}
+	return s.update(ctx, id, fields)
+}
+
+// Update applies the fields present in the update to the Event with the given ID.
+// Only admins and the Complejo that created the Event may update it; ErrNoValidFields is returned when no field is present.
+func (s *EventService) Update(ctx context.Context, id string, update models.EventUpdate, requesterID string, isAdmin bool) error {
+	event, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return notFound(err, ErrEventNotFound)
+	}
+	if !owns(event, requesterID, isAdmin) {
+		return ErrNotEventOwner
+	}
+
+	fields := update.Fields()
+	if len(fields) == 0 {
+		return ErrNoValidFields
+	}
+	return s.update(ctx, id, fields)
+}
+
+// update applies fields to the Event with the given ID and announces the changes (EventUpdated)
+// through the outbox in the same transaction. The participants of a rescheduled Event may be warned of
+// severe weather and reminded of it again, and its share image is rendered again.
+func (s *EventService) update(ctx context.Context, id string, fields map[string]interface{}) error {
+	stored := fields
+	if _, ok := fields["date"]; ok {
+		stored = map[string]interface{}{"weather_warned_at": nil, "reminded_at": nil}
+		for key, value := range fields {
+			stored[key] = value
+		}
+	}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		found, err := s.repo.UpdateByID(ctx, id, stored)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrEventNotFound
+		}
+		return s.announce(ctx, bus.EventUpdated{ID: id, Changes: fields})
+	})
+	if err != nil {
+		return err
+	}
+
+	// The share image is rendered again on its next request; a failure leaves the previous one served
+	s.objects.Delete(ctx, shareImageKey(id))
+	return nil
+}
+
+// Delete marks the Event with the given ID as deleted; it can be restored until it is purged.
+// Only admins and the Complejo that created the Event may delete it.
+// The deletion, with the Complejos going or maybe going to notify, is announced through the outbox in the same transaction.
+func (s *EventService) Delete(ctx context.Context, id, requesterID string, isAdmin bool) error {
+	event, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return notFound(err, ErrEventNotFound)
+	}
+
+	if !owns(event, requesterID, isAdmin) {
+		return ErrNotEventOwner
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		found, err := s.repo.DeleteByID(ctx, id, s.clock.Now())
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrEventNotFound
+		}
+
+		return s.announce(ctx, bus.EventDeleted{
+			ID:             event.ID,
+			Title:          event.Title,
+			Date:           event.Date,
+			Participants:   event.Usernames(models.RSVPGoing, models.RSVPMaybe),
+			ParticipantIDs: event.ComplejoIDs(models.RSVPGoing, models.RSVPMaybe),
+		})
+	})
+}
+
+// Restore clears the deletion mark of the Event with the given ID.
+func (s *EventService) Restore(ctx context.Context, id string) error {
+	found, err := s.repo.RestoreByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrDeletedEventNotFound
+	}
+	return nil
+}
+
+// Participants returns the requested page of the profiles of the Complejos going to the Event, in the order
+// they answered, with thumbnails of their photos, and their total number, as seen through the view by the viewer
+// (see PrivacyService.Views): the participants blocking the viewer are left out of the page, and the photos the
+// viewer may not see have no thumbnail. Missing pagination values are defaulted on the query.
+func (s *EventService) Participants(ctx context.Context, eventID string, view models.ComplejoView, viewerID string, query *models.ParticipantQuery) ([]models.Participant, int64, error) {
+	if _, err := s.repo.FindByID(ctx, eventID); err != nil {
+		return nil, 0, notFound(err, ErrEventNotFound)
+	}
+
+	query.Normalize()
+	participants, total, err := s.repo.FindParticipants(ctx, eventID, query.Offset(), query.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var views map[string]models.ComplejoView
+	if s.Privacy != nil {
+		ids := make([]string, 0, len(participants))
+		for i := range participants {
+			ids = append(ids, participants[i].ComplejoID)
+		}
+		if views, err = s.Privacy.Views(ctx, view, viewerID, ids); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	visible := participants[:0]
+	for _, participant := range participants {
+		v, found := views[participant.ComplejoID]
+		if views != nil && !found {
+			continue
+		}
+		if views == nil || v.Sees("photo") {
+			if participant.PhotoID != "" {
+				participant.Thumbnail = storedThumbnail(ctx, s.thumbnails, s.photos, participant.PhotoID)
+			} else {
+				participant.Thumbnail = thumbnail(ctx, s.thumbnails, participant.Photo)
+			}
+		}
+		visible = append(visible, participant)
+	}
+	return visible, total, nil
+}
+
+// Subscribe answers "going" to an upcoming Event on behalf of the Complejo and returns the warning
+// of the level gate, if any. ErrAlreadySubscribed is returned when the Complejo is already going.
+func (s *EventService) Subscribe(ctx context.Context, eventID, complejoID, username string) (string, error) {
+	rsvp, changed, err := s.answer(ctx, eventID, complejoID, username, models.RSVPGoing)
+	if err != nil {
+		return "", err
+	}
+	if !changed {
+		return "", ErrAlreadySubscribed
+	}
+	return rsvp.Warning, nil
+}
+
+// Unsubscribe withdraws the "going" RSVP of the Complejo from the Event.
+// ErrNotSubscribed is returned when the Complejo is not going.
+// The transition is recorded and announced in the same transaction.
+func (s *EventService) Unsubscribe(ctx context.Context, eventID, complejoID, username string) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		event, err := s.repo.FindByID(ctx, eventID)
+		if err != nil {
+			return notFound(err, ErrEventNotFound)
+		}
+		if rsvp := event.FindRSVP(complejoID); rsvp == nil || rsvp.Status != models.RSVPGoing {
+			return ErrNotSubscribed
+		}
+
+		matched, modified, err := s.repo.RemoveRSVP(ctx, eventID, complejoID)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			return ErrEventNotFound
+		}
+		if !modified {
+			return ErrNotSubscribed
+		}
+		if err := s.recordTransition(ctx, eventID, username, models.SubscriptionUnsubscribed); err != nil {
+			return err
+		}
+		return s.announce(ctx, bus.UserUnsubscribed{EventID: eventID, ComplejoID: complejoID, Username: username})
+	})
+}
+
+// RSVP records the answer ("going", "maybe" or "declined") of the Complejo to an upcoming Event and returns it.
+// Giving the same answer again changes nothing. A "going" answer is rejected with ErrEventFull when the Event is full,
+// and with ErrNotGroupMember when the Event belongs to a group the Complejo is not a member of;
+// to a gated advanced session it is returned with a warning, or rejected with ErrLevelRestricted, when the Complejo is a beginner.
+func (s *EventService) RSVP(ctx context.Context, eventID, complejoID, username, status string) (*models.RSVP, error) {
+	rsvp, _, err := s.answer(ctx, eventID, complejoID, username, status)
+	return rsvp, err
+}
+
+// answer sets the RSVP of the Complejo on an upcoming Event and reports whether it changed.
+// A change is announced (RSVPChanged) in the same transaction; starting or stopping to go is also
+// recorded in the subscription history and announced as a subscription (UserSubscribed or UserUnsubscribed).
+func (s *EventService) answer(ctx context.Context, eventID, complejoID, username, status string) (*models.RSVP, bool, error) {
+	var rsvp *models.RSVP
+	changed := false
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		event, err := s.repo.FindByID(ctx, eventID)
+		if err != nil {
+			return notFound(err, ErrEventNotFound)
+		}
+		now := s.clock.Now()
+		if event.IsPast(now) {
+			return ErrEventPast
+		}
+
+		previous := ""
+		if current := event.FindRSVP(complejoID); current != nil {
+			if current.Status == status {
+				rsvp = current
+				return nil
+			}
+			previous = current.Status
+		}
+
+		warning := ""
+		if status == models.RSVPGoing {
+			if event.IsFull() {
+				return ErrEventFull.WithDetails(map[string]interface{}{"capacity": event.Capacity})
+			}
+			if err := s.checkGroup(ctx, event, complejoID); err != nil {
+				return err
+			}
+			if warning, err = s.checkLevel(ctx, event, complejoID); err != nil {
+				return err
+			}
+		}
+
+		rsvp = &models.RSVP{ComplejoID: complejoID, Username: username, Status: status, RespondedAt: now, Warning: warning}
+		found, err := s.repo.SetRSVP(ctx, eventID, *rsvp)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrEventNotFound
+		}
+		changed = true
+
+		switch {
+		case status == models.RSVPGoing:
+			if err := s.recordTransition(ctx, eventID, username, models.SubscriptionSubscribed); err != nil {
+				return err
+			}
+			err = s.announce(ctx, bus.UserSubscribed{EventID: eventID, ComplejoID: complejoID, Username: username})
+		case previous == models.RSVPGoing:
+			if err := s.recordTransition(ctx, eventID, username, models.SubscriptionUnsubscribed); err != nil {
+				return err
+			}
+			err = s.announce(ctx, bus.UserUnsubscribed{EventID: eventID, ComplejoID: complejoID, Username: username})
+		}
+		if err != nil {
+			return err
+		}
+		return s.announce(ctx, bus.RSVPChanged{
+			EventID:    eventID,
+			ComplejoID: complejoID,
+			Username:   username,
+			Status:     status,
+			Previous:   previous,
+		})
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return rsvp, changed, nil
+}
+
+// checkGroup returns ErrNotGroupMember when the Event belongs to a group the Complejo is not a member of. The
+// Events of a deleted group are open to every Complejo.
+func (s *EventService) checkGroup(ctx context.Context, event *models.Event, complejoID string) error {
+	if event.GroupID == "" || s.Groups == nil {
+		return nil
+	}
+	_, err := s.Groups.FindMembership(ctx, event.GroupID, complejoID)
+	if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	if _, err := s.Groups.FindByID(ctx, event.GroupID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	return ErrNotGroupMember
+}
+
+// levelWarning is returned to beginners going to an advanced session whose level gate warns.
+const levelWarning = "This is an advanced session and your recorded lifts suggest a beginner level"
+
+// checkLevel applies the level gate of the Event to the Complejo answering "going": it returns levelWarning
+// when the gate warns, and ErrLevelRestricted when it blocks.
+func (s *EventService) checkLevel(ctx context.Context, event *models.Event, complejoID string) (string, error) {
+	// Only look up the lifts when a beginner would be gated
+	if !event.Gated(complejoID, models.SkillBeginner) {
+		return "", nil
+	}
+	complejo, err := s.complejos.FindByID(ctx, complejoID)
+	if err != nil {
+		return "", notFound(err, ErrComplejoNotFound)
+	}
+	skill := complejo.SkillLevel()
+	if !event.Gated(complejoID, skill) {
+		return "", nil
+	}
+	if event.LevelGate == models.LevelGateBlock {
+		return "", ErrLevelRestricted.WithDetails(map[string]interface{}{"level": skill, "event_level": event.Level})
+	}
+	return levelWarning, nil
+}
+
+// SetLevelOverride lets the Complejo through the level gate of the Event, or withdraws the override when allowed
+// is false. Only admins and the creator of the Event may manage its level gate.
+func (s *EventService) SetLevelOverride(ctx context.Context, eventID, complejoID, requesterID string, isAdmin, allowed bool) error {
+	event, err := s.repo.FindByID(ctx, eventID)
+	if err != nil {
+		return notFound(err, ErrEventNotFound)
+	}
+	if !isAdmin && (event.CreatedBy == "" || event.CreatedBy != requesterID) {
+		return ErrNotLevelOrganizer
+	}
+	if allowed {
+		if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+			return notFound(err, ErrComplejoNotFound)
+		}
+	}
+
+	found, err := s.repo.SetLevelOverride(ctx, eventID, complejoID, allowed)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrEventNotFound
+	}
+	return nil
+}
+
+// RegisterGuest registers a guest of the Complejo for an upcoming Event and returns it. The guest takes a place
+// of the Event, and one of the guest passes of the Complejo for the current month.
+// ErrEventFull is returned when no place is left, and ErrGuestPassesUsed when the passes are used up.
+func (s *EventService) RegisterGuest(ctx context.Context, eventID, hostID string, input models.GuestInput) (*models.Guest, error) {
+	var guest *models.Guest
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		event, err := s.repo.FindByID(ctx, eventID)
+		if err != nil {
+			return notFound(err, ErrEventNotFound)
+		}
+		now := s.clock.Now()
+		if event.IsPast(now) {
+			return ErrEventPast
+		}
+		if event.IsFull() {
+			return ErrEventFull.WithDetails(map[string]interface{}{"capacity": event.Capacity})
+		}
+
+		local := now.In(s.Location)
+		month := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, s.Location)
+		used, err := s.repo.CountGuests(ctx, hostID, month, month.AddDate(0, 1, 0))
+		if err != nil {
+			return err
+		}
+		if used >= int64(s.GuestPasses) {
+			return ErrGuestPassesUsed.WithDetails(map[string]interface{}{
+				"passes":   s.GuestPasses,
+				"renew_at": month.AddDate(0, 1, 0),
+			})
+		}
+
+		host, err := s.complejos.FindByID(ctx, hostID)
+		if err != nil {
+			return notFound(err, ErrComplejoNotFound)
+		}
+
+		guest = &models.Guest{
+			ID:           uuid.NewString(),
+			Name:         input.Name,
+			HostID:       host.ID,
+			HostUsername: host.Username,
+			CreatedAt:    now,
+		}
+		found, err := s.repo.AddGuest(ctx, eventID, *guest)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrEventNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return guest, nil
+}
+
+// RemoveGuest removes a guest from an upcoming Event, handing its pass back to its host.
+// Only admins and the Complejo that registered the guest may remove it.
+func (s *EventService) RemoveGuest(ctx context.Context, eventID, guestID, requesterID string, isAdmin bool) error {
+	event, err := s.repo.FindByID(ctx, eventID)
+	if err != nil {
+		return notFound(err, ErrEventNotFound)
+	}
+	if event.IsPast(s.clock.Now()) {
+		return ErrEventPast
+	}
+
+	var guest *models.Guest
+	for i := range event.Guests {
+		if event.Guests[i].ID == guestID {
+			guest = &event.Guests[i]
+		}
+	}
+	if guest == nil {
+		return ErrGuestNotFound
+	}
+	if !isAdmin && guest.HostID != requesterID {
+		return ErrNotGuestHost
+	}
+
+	removed, err := s.repo.RemoveGuest(ctx, eventID, guestID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrGuestNotFound
+	}
+	return nil
+}
+
+// Like adds the Complejo to the likes of the Event, or removes it when liked is false, and returns the likes
+// of the Event. Liking twice, or unliking an Event the Complejo does not like, changes nothing.
+func (s *EventService) Like(ctx context.Context, eventID, complejoID string, liked bool) (*models.EventLikes, error) {
+	found, err := s.repo.SetLike(ctx, eventID, complejoID, liked)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrEventNotFound
+	}
+
+	event, err := s.repo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+	event.MarkLiked(complejoID)
+	return &models.EventLikes{LikeCount: len(event.Likes), LikedByMe: event.LikedByMe}, nil
+}
+
+// SubscriptionHistory returns every subscription transition of the Event
+// together with the participants and waitlist derived from them.
+func (s *EventService) SubscriptionHistory(ctx context.Context, eventID string) (*models.SubscriptionHistory, error) {
+	if _, err := s.repo.FindByID(ctx, eventID); err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+
+	events, err := s.history.FindByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, waitlist := models.DeriveSubscriptionState(events)
+	return &models.SubscriptionHistory{
+		EventID:      eventID,
+		Participants: participants,
+		Waitlist:     waitlist,
+		Events:       events,
+	}, nil
+}
+
+// recordTransition appends a subscription transition to the Event's history.
+func (s *EventService) recordTransition(ctx context.Context, eventID, username, transition string) error {
+	return s.history.Append(ctx, &models.SubscriptionEvent{
+		ID:         uuid.NewString(),
+		EventID:    eventID,
+		Username:   username,
+		Type:       transition,
+		OccurredAt: s.clock.Now(),
+	})
+}
+
+// owns reports whether the requester may change the Event: admins own every Event, and other Complejos
+// the Events they created.
+func owns(event *models.Event, requesterID string, isAdmin bool) bool {
+	return isAdmin || (event.CreatedBy != "" && event.CreatedBy == requesterID)
+}
+
+// Calendar returns the upcoming Events as an iCalendar feed.
+func (s *EventService) Calendar(ctx context.Context) (*ical.Calendar, error) {
+	now := s.clock.Now()
+	events, err := s.repo.FindAll(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming := make([]models.Event, 0, len(events))
+	for _, event := range events {
+		if !event.IsPast(now) {
+			upcoming = append(upcoming, event)
+		}
+	}
+	return s.calendar("Los Complejos", upcoming), nil
+}
+
+// EventCalendar returns the Event with the given ID as an iCalendar file.
+func (s *EventService) EventCalendar(ctx context.Context, id string) (*ical.Calendar, error) {
+	event, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+	return s.calendar(event.Title, []models.Event{*event}), nil
+}
+
+// CalendarLink returns the personal calendar feed of the Complejo, with the token authenticating it.
+func (s *EventService) CalendarLink(ctx context.Context, complejoID string) (*models.CalendarLink, error) {
+	complejo, err := s.complejos.FindByID(ctx, complejoID)
+	if err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	return calendarLink(complejo), nil
+}
+
+// RotateCalendarLink replaces the calendar nonce of the Complejo, revoking the links of its personal calendar
+// feed, e.g. after one was shared by mistake, and returns the new link.
+func (s *EventService) RotateCalendarLink(ctx context.Context, complejoID string) (*models.CalendarLink, error) {
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	nonce := hex.EncodeToString(secret)
+
+	found, err := s.complejos.UpdateByID(ctx, complejoID, "", map[string]interface{}{"calendar_nonce": nonce})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrComplejoNotFound
+	}
+	return calendarLink(&models.Complejo{ID: complejoID, CalendarNonce: nonce}), nil
+}
+
+// calendarLink returns the personal calendar feed of the Complejo with its current token.
+func calendarLink(complejo *models.Complejo) *models.CalendarLink {
+	token := utils.CalendarToken(complejo.ID, complejo.CalendarNonce)
+	return &models.CalendarLink{Token: token, Link: "/complejo/" + complejo.ID + "/calendar.ics?token=" + token}
+}
+
+// PersonalCalendar returns the iCalendar feed of the Events the Complejo is going to, from CalendarHistory ago
+// on, for calendar apps to fetch again every hour. ErrInvalidCalendarToken is returned when the token does not
+// authenticate the feed of the Complejo, or was revoked by RotateCalendarLink.
+func (s *EventService) PersonalCalendar(ctx context.Context, complejoID, token string) (*ical.Calendar, error) {
+	complejo, err := s.complejos.FindByID(ctx, complejoID)
+	if err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	if !utils.VerifyCalendarToken(complejo.ID, complejo.CalendarNonce, token) {
+		return nil, ErrInvalidCalendarToken
+	}
+
+	events, err := s.repo.FindByRSVP(ctx, complejoID, models.RSVPGoing, s.clock.Now().Add(-s.CalendarHistory))
+	if err != nil {
+		return nil, err
+	}
+	calendar := s.calendar("Los Complejos - "+complejo.Username, events)
+	calendar.Refresh = time.Hour
+	return calendar, nil
+}
+
+// calendar builds the iCalendar of the events, each lasting Duration. Their UID derives from their ID,
+// so importing them again updates them instead of duplicating them.
+func (s *EventService) calendar(name string, events []models.Event) *ical.Calendar {
+	now := s.clock.Now()
+	calendar := &ical.Calendar{Name: name, Events: make([]ical.Event, 0, len(events))}
+	for _, event := range events {
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         event.ID + "@los-complejos",
+			Summary:     event.Title,
+			Description: event.Description,
+			Location:    event.Location,
+			Start:       event.Date,
+			End:         event.Date.Add(s.Duration),
+			Stamp:       now,
+		})
+	}
+	return calendar
+}
+
+// presentAll fills in the computed fields of the events.
+func (s *EventService) presentAll(events []models.Event) {
+	for i := range events {
+		s.present(&events[i])
+	}
+}
+
+// present fills in the computed fields of the event: its RSVP and like counts, whether it is pinned and the HTML
+// of its description. Its guests are listed as empty when it has none.
+func (s *EventService) present(event *models.Event) {
+	if event.Guests == nil {
+		event.Guests = []models.Guest{}
+	}
+	event.RSVPCounts = models.CountRSVPs(*event)
+	event.LikeCount = len(event.Likes)
+	event.Pinned = event.PinnedUntil != nil && event.PinnedUntil.After(s.clock.Now())
+	event.DescriptionHTML = s.Markdown.Render(event.Description)
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *EventService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
@@ -0,0 +1,144 @@
+// event_service_test.go
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/outbox"
+	"los-complejos-backend/utils"
+)
+
+func TestRotateCalendarLinkRevokesThePreviousLink(t *testing.T) {
+	utils.JWTSecret = []byte("test-secret")
+	ctx := context.Background()
+	complejos := newFakeComplejos(models.Complejo{ID: "maria", Username: "maria", Role: "user"})
+	svc := NewEventService(newFakeEvents(), complejos, nil, nil, nil, nil, nil, nil, nil, clock.NewFake(time.Now()))
+
+	first, err := svc.CalendarLink(ctx, "maria")
+	if err != nil {
+		t.Fatalf("CalendarLink: %v", err)
+	}
+	if _, err := svc.PersonalCalendar(ctx, "maria", first.Token); err != nil {
+		t.Fatalf("PersonalCalendar with the link: %v", err)
+	}
+
+	rotated, err := svc.RotateCalendarLink(ctx, "maria")
+	if err != nil {
+		t.Fatalf("RotateCalendarLink: %v", err)
+	}
+	if rotated.Token == first.Token {
+		t.Fatal("RotateCalendarLink returned the previous token")
+	}
+	if _, err := svc.PersonalCalendar(ctx, "maria", first.Token); !errors.Is(err, ErrInvalidCalendarToken) {
+		t.Errorf("PersonalCalendar with the revoked link: error = %v, want ErrInvalidCalendarToken", err)
+	}
+	if _, err := svc.PersonalCalendar(ctx, "maria", rotated.Token); err != nil {
+		t.Errorf("PersonalCalendar with the new link: %v", err)
+	}
+	if current, err := svc.CalendarLink(ctx, "maria"); err != nil || current.Link != rotated.Link {
+		t.Errorf("CalendarLink after the rotation = %v, %v, want %s", current, err, rotated.Link)
+	}
+}
+
+func TestCalendarTokenIsBoundToTheComplejo(t *testing.T) {
+	utils.JWTSecret = []byte("test-secret")
+	ctx := context.Background()
+	complejos := newFakeComplejos(models.Complejo{ID: "maria", Role: "user"}, models.Complejo{ID: "jose", Role: "user"})
+	svc := NewEventService(newFakeEvents(), complejos, nil, nil, nil, nil, nil, nil, nil, clock.NewFake(time.Now()))
+
+	link, err := svc.CalendarLink(ctx, "maria")
+	if err != nil {
+		t.Fatalf("CalendarLink: %v", err)
+	}
+	if _, err := svc.PersonalCalendar(ctx, "jose", link.Token); !errors.Is(err, ErrInvalidCalendarToken) {
+		t.Errorf("PersonalCalendar of another Complejo: error = %v, want ErrInvalidCalendarToken", err)
+	}
+	if _, err := svc.RotateCalendarLink(ctx, "nobody"); !errors.Is(err, ErrComplejoNotFound) {
+		t.Errorf("RotateCalendarLink of an unknown Complejo: error = %v, want ErrComplejoNotFound", err)
+	}
+}
+
+func TestEventUpdateForAdmin(t *testing.T) {
+	ctx := context.Background()
+	title := "Morning ride"
+	out := &fakeOutbox{}
+	svc := NewEventService(newFakeEvents(models.Event{ID: "e1", Title: "Ride"}), newFakeComplejos(), nil, fakeTx{}, out,
+		nil, nil, objectstore.NewDir(t.TempDir()), nil, clock.NewFake(time.Now()))
+
+	if err := svc.UpdateForAdmin(ctx, "e1", models.EventUpdate{}); !errors.Is(err, ErrNoValidFields) {
+		t.Errorf("UpdateForAdmin without fields: error = %v, want ErrNoValidFields", err)
+	}
+	if err := svc.UpdateForAdmin(ctx, "missing", models.EventUpdate{Title: &title}); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("UpdateForAdmin of an unknown Event: error = %v, want ErrEventNotFound", err)
+	}
+	if err := svc.UpdateForAdmin(ctx, "e1", models.EventUpdate{Title: &title}); err != nil {
+		t.Fatalf("UpdateForAdmin: %v", err)
+	}
+	if topics := out.topics(); len(topics) != 1 || topics[0] != outbox.TopicEventUpdated {
+		t.Errorf("enqueued topics = %v, want only %s", topics, outbox.TopicEventUpdated)
+	}
+}
+
+func TestParticipantsApplyPrivacyAndBlocks(t *testing.T) {
+	ctx := context.Background()
+	photos := objectstore.NewDir(t.TempDir())
+	events := newFakeEvents(models.Event{ID: "e1", Title: "Ride"})
+	for _, id := range []string{"public", "followers", "private", "blocker"} {
+		photoID := "photo-" + id
+		if err := photos.Put(ctx, models.VariantKey(models.ProfilePhotoKey(photoID), models.PhotoSizeThumb), []byte("thumb")); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		events.participants["e1"] = append(events.participants["e1"], models.Participant{ComplejoID: id, Username: id, PhotoID: photoID})
+	}
+	privacy := NewPrivacyService(
+		&fakePrivacy{settings: map[string]models.PrivacySettings{
+			"followers": {ComplejoID: "followers", Photo: models.AudienceFollowers},
+			"private":   {ComplejoID: "private", Photo: models.AudienceMe},
+		}},
+		&fakeFollows{followees: map[string][]string{"fan": {"followers"}}},
+		&fakeBlocks{blockers: map[string][]string{"viewer": {"blocker"}, "fan": {"blocker"}}},
+		nil, clock.NewFake(time.Now()))
+	svc := NewEventService(events, newFakeComplejos(), nil, nil, nil, nil, nil, nil, photos, clock.NewFake(time.Now()))
+	svc.Privacy = privacy
+
+	tests := []struct {
+		name     string
+		view     models.ComplejoView
+		viewerID string
+		want     map[string]bool // Whether each listed participant has a thumbnail
+	}{
+		{"other member", models.ComplejoView{}, "viewer", map[string]bool{"public": true, "followers": false, "private": false}},
+		{"follower", models.ComplejoView{}, "fan", map[string]bool{"public": true, "followers": true, "private": false}},
+		{"anonymous", models.ComplejoView{}, "", map[string]bool{"public": true, "followers": false, "private": false, "blocker": true}},
+		{"admin", models.ComplejoView{Relation: models.RelationSelf}, "admin", map[string]bool{"public": true, "followers": true, "private": true, "blocker": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			participants, total, err := svc.Participants(ctx, "e1", tt.view, tt.viewerID, &models.ParticipantQuery{})
+			if err != nil {
+				t.Fatalf("Participants: %v", err)
+			}
+			if total != 4 {
+				t.Errorf("total = %d, want 4", total)
+			}
+			got := map[string]bool{}
+			for _, participant := range participants {
+				got[participant.ComplejoID] = participant.Thumbnail != ""
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("participants = %v, want %v", got, tt.want)
+			}
+			for id, thumbnail := range tt.want {
+				if shown, listed := got[id]; !listed || shown != thumbnail {
+					t.Errorf("participant %s: listed = %v, thumbnail = %v, want listed with thumbnail = %v", id, listed, shown, thumbnail)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// event_stream.go
+package services
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// Stream opens a stream of the changes of the Events made after the change with the given ID (the resume token of
+// a change already streamed), or from now when it is empty. The Events of the changes are presented like the
+// Events read from the API.
+func (s *EventService) Stream(ctx context.Context, lastID string) (repository.EventChangeStream, error) {
+	if s.Watcher == nil {
+		return nil, ErrEventStreamUnavailable
+	}
+	stream, err := s.Watcher.Watch(ctx, lastID)
+	if errors.Is(err, repository.ErrInvalidResumeToken) {
+		return nil, ErrResumeTokenExpired
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &presentedStream{EventChangeStream: stream, service: s}, nil
+}
+
+// presentedStream fills in the computed fields of the Events of a stream.
+type presentedStream struct {
+	repository.EventChangeStream
+	service *EventService
+}
+
+// Next waits for the next change.
+func (p *presentedStream) Next(ctx context.Context) (*models.EventChange, error) {
+	change, err := p.EventChangeStream.Next(ctx)
+	if errors.Is(err, repository.ErrInvalidResumeToken) {
+		return nil, ErrResumeTokenExpired
+	}
+	if err != nil || change == nil {
+		return nil, err
+	}
+	if change.Event != nil {
+		p.service.present(change.Event)
+	}
+	return change, nil
+}
@@ -0,0 +1,94 @@
+// exercise_service.go
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// ExerciseService manages the exercise library the workouts are logged with. Anyone can browse it; only admins
+// change it.
+type ExerciseService struct {
+	repo  repository.ExerciseRepository
+	clock clock.Clock
+}
+
+// NewExerciseService creates an ExerciseService backed by the given repository and clock.
+func NewExerciseService(repo repository.ExerciseRepository, clk clock.Clock) *ExerciseService {
+	return &ExerciseService{repo: repo, clock: clk}
+}
+
+// Search returns the requested page of the exercises matching the filter, ordered by name, and their total
+// number.
+func (s *ExerciseService) Search(ctx context.Context, filter *models.ExerciseFilter) ([]models.Exercise, int64, error) {
+	filter.Normalize()
+	filter.Query = strings.TrimSpace(filter.Query)
+	return s.repo.Search(ctx, *filter)
+}
+
+// Get returns the exercise with the given ID.
+func (s *ExerciseService) Get(ctx context.Context, id string) (*models.Exercise, error) {
+	exercise, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrExerciseNotFound)
+	}
+	return exercise, nil
+}
+
+// Create adds an exercise to the library. Its name must be unique.
+func (s *ExerciseService) Create(ctx context.Context, input models.ExerciseInput) (*models.Exercise, error) {
+	now := s.clock.Now()
+	exercise := &models.Exercise{ID: uuid.NewString(), CreatedAt: now}
+	applyExerciseInput(exercise, input, now)
+
+	if err := s.repo.Insert(ctx, exercise); err != nil {
+		return nil, err
+	}
+	return exercise, nil
+}
+
+// Update replaces the details of an exercise. The workouts already logged keep the name and muscle group it had.
+func (s *ExerciseService) Update(ctx context.Context, id string, input models.ExerciseInput) (*models.Exercise, error) {
+	exercise, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrExerciseNotFound)
+	}
+
+	applyExerciseInput(exercise, input, s.clock.Now())
+	found, err := s.repo.Replace(ctx, exercise)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrExerciseNotFound
+	}
+	return exercise, nil
+}
+
+// Delete removes an exercise from the library. The workouts already logged keep their copy of it.
+func (s *ExerciseService) Delete(ctx context.Context, id string) error {
+	found, err := s.repo.DeleteByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrExerciseNotFound
+	}
+	return nil
+}
+
+// applyExerciseInput copies the input onto the exercise.
+func applyExerciseInput(exercise *models.Exercise, input models.ExerciseInput, now time.Time) {
+	exercise.Name = input.Name
+	exercise.MuscleGroups = input.MuscleGroups
+	exercise.Equipment = input.Equipment
+	exercise.VideoURL = input.VideoURL
+	exercise.UpdatedAt = now
+}
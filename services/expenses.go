@@ -0,0 +1,105 @@
+// expenses.go
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"los-complejos-backend/models"
+
+	"github.com/google/uuid"
+)
+
+// Expenses returns the expenses of the Event, oldest first. Only admins and the creator of the Event may see them.
+func (s *FinanceService) Expenses(ctx context.Context, eventID, requesterID string, isAdmin bool) ([]models.Expense, error) {
+	if err := s.checkOrganizer(ctx, eventID, requesterID, isAdmin); err != nil {
+		return nil, err
+	}
+	return s.expenses.FindByEvent(ctx, eventID)
+}
+
+// CreateExpense records an expense of the Event. Only admins and the creator of the Event may record one.
+func (s *FinanceService) CreateExpense(ctx context.Context, eventID string, input models.ExpenseInput, requesterID string, isAdmin bool) (*models.Expense, error) {
+	if err := s.checkOrganizer(ctx, eventID, requesterID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	expense := &models.Expense{
+		ID:        uuid.NewString(),
+		EventID:   eventID,
+		CreatedBy: requesterID,
+		CreatedAt: now,
+	}
+	applyExpenseInput(expense, input, now)
+
+	if err := s.expenses.Insert(ctx, expense); err != nil {
+		return nil, err
+	}
+	return expense, nil
+}
+
+// UpdateExpense replaces the details of an expense of the Event. Only admins and the creator of the Event may change it.
+func (s *FinanceService) UpdateExpense(ctx context.Context, eventID, id string, input models.ExpenseInput, requesterID string, isAdmin bool) (*models.Expense, error) {
+	if err := s.checkOrganizer(ctx, eventID, requesterID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	expense, err := s.expenses.FindByID(ctx, eventID, id)
+	if err != nil {
+		return nil, notFound(err, ErrExpenseNotFound)
+	}
+	applyExpenseInput(expense, input, s.clock.Now())
+
+	found, err := s.expenses.Replace(ctx, expense)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrExpenseNotFound
+	}
+	return expense, nil
+}
+
+// DeleteExpense removes an expense of the Event. Only admins and the creator of the Event may remove it.
+func (s *FinanceService) DeleteExpense(ctx context.Context, eventID, id, requesterID string, isAdmin bool) error {
+	if err := s.checkOrganizer(ctx, eventID, requesterID, isAdmin); err != nil {
+		return err
+	}
+
+	found, err := s.expenses.DeleteByID(ctx, eventID, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrExpenseNotFound
+	}
+	return nil
+}
+
+// checkOrganizer returns ErrEventNotFound when the Event does not exist, and ErrNotEventOrganizer
+// when the requester is neither an admin nor its creator.
+func (s *FinanceService) checkOrganizer(ctx context.Context, eventID, requesterID string, isAdmin bool) error {
+	event, err := s.events.FindByID(ctx, eventID)
+	if err != nil {
+		return notFound(err, ErrEventNotFound)
+	}
+	if !isAdmin && (event.CreatedBy == "" || event.CreatedBy != requesterID) {
+		return ErrNotEventOrganizer
+	}
+	return nil
+}
+
+// applyExpenseInput copies the input onto the expense, incurred now unless the input says otherwise.
+func applyExpenseInput(expense *models.Expense, input models.ExpenseInput, now time.Time) {
+	expense.Category = input.Category
+	expense.Description = input.Description
+	expense.Amount = input.Amount
+	expense.Currency = strings.ToLower(input.Currency)
+	expense.IncurredAt = now
+	if input.IncurredAt != nil {
+		expense.IncurredAt = *input.IncurredAt
+	}
+	expense.UpdatedAt = now
+}
@@ -0,0 +1,318 @@
+// fakes_test.go
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// fakeComplejos is an in-memory repository.ComplejoRepository. The methods the tests do not need are left to the
+// embedded nil interface, and panic when called.
+type fakeComplejos struct {
+	repository.ComplejoRepository
+	mu        sync.Mutex
+	complejos map[string]models.Complejo
+}
+
+// newFakeComplejos stores the given Complejos.
+func newFakeComplejos(complejos ...models.Complejo) *fakeComplejos {
+	f := &fakeComplejos{complejos: map[string]models.Complejo{}}
+	for _, complejo := range complejos {
+		f.complejos[complejo.ID] = complejo
+	}
+	return f
+}
+
+func (f *fakeComplejos) FindByID(ctx context.Context, id string) (*models.Complejo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	complejo, ok := f.complejos[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &complejo, nil
+}
+
+func (f *fakeComplejos) FindAll(ctx context.Context) ([]models.Complejo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	all := make([]models.Complejo, 0, len(f.complejos))
+	for _, complejo := range f.complejos {
+		all = append(all, complejo)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all, nil
+}
+
+// UpdateByID sets the fields like a MongoDB $set, by their BSON names.
+func (f *fakeComplejos) UpdateByID(ctx context.Context, id, role string, fields map[string]interface{}) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	complejo, ok := f.complejos[id]
+	if !ok || (role != "" && complejo.Role != role) {
+		return false, nil
+	}
+	data, err := bson.Marshal(complejo)
+	if err != nil {
+		return false, err
+	}
+	var document bson.M
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return false, err
+	}
+	for key, value := range fields {
+		document[key] = value
+	}
+	if data, err = bson.Marshal(document); err != nil {
+		return false, err
+	}
+	var updated models.Complejo
+	if err := bson.Unmarshal(data, &updated); err != nil {
+		return false, err
+	}
+	f.complejos[id] = updated
+	return true, nil
+}
+
+// Anonymize replaces the username and marks the Complejo as deleted; the other personal fields are left.
+func (f *fakeComplejos) Anonymize(ctx context.Context, id, placeholder string, at time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	complejo, ok := f.complejos[id]
+	if !ok {
+		return false, nil
+	}
+	complejo.Username, complejo.DeletedAt = placeholder, &at
+	f.complejos[id] = complejo
+	return true, nil
+}
+
+// fakeEvents is an in-memory repository.EventRepository, with the same limits as fakeComplejos.
+type fakeEvents struct {
+	repository.EventRepository
+	events map[string]models.Event
+	going  map[string][]models.Event // Events returned by FindByRSVP, by Complejo ID
+
+	participants map[string][]models.Participant // Participants returned by FindParticipants, by Event ID
+	rsvps        map[string][]string             // IDs of the Events removed by RemoveRSVPsFromAll, by Complejo ID
+}
+
+// newFakeEvents stores the given Events.
+func newFakeEvents(events ...models.Event) *fakeEvents {
+	f := &fakeEvents{events: map[string]models.Event{}, going: map[string][]models.Event{}, participants: map[string][]models.Participant{}, rsvps: map[string][]string{}}
+	for _, event := range events {
+		f.events[event.ID] = event
+	}
+	return f
+}
+
+func (f *fakeEvents) FindByID(ctx context.Context, id string) (*models.Event, error) {
+	event, ok := f.events[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &event, nil
+}
+
+func (f *fakeEvents) FindAll(ctx context.Context, now time.Time) ([]models.Event, error) {
+	all := make([]models.Event, 0, len(f.events))
+	for _, event := range f.events {
+		all = append(all, event)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Date.Before(all[j].Date) })
+	return all, nil
+}
+
+func (f *fakeEvents) FindByRSVP(ctx context.Context, complejoID, status string, from time.Time) ([]models.Event, error) {
+	return f.going[complejoID], nil
+}
+
+func (f *fakeEvents) FindParticipants(ctx context.Context, id string, offset, limit int) ([]models.Participant, int64, error) {
+	participants := f.participants[id]
+	total := int64(len(participants))
+	if offset > len(participants) {
+		offset = len(participants)
+	}
+	participants = participants[offset:]
+	if limit < len(participants) {
+		participants = participants[:limit]
+	}
+	return append([]models.Participant(nil), participants...), total, nil
+}
+
+func (f *fakeEvents) RemoveRSVPsFromAll(ctx context.Context, complejoID string) ([]string, error) {
+	eventIDs := f.rsvps[complejoID]
+	delete(f.rsvps, complejoID)
+	return eventIDs, nil
+}
+
+func (f *fakeEvents) UpdateByID(ctx context.Context, id string, fields map[string]interface{}) (bool, error) {
+	_, ok := f.events[id]
+	return ok, nil
+}
+
+// fakeTx runs the functions without a transaction.
+type fakeTx struct{}
+
+func (fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeOutbox keeps the enqueued messages.
+type fakeOutbox struct {
+	repository.OutboxRepository
+	messages []models.OutboxMessage
+}
+
+func (f *fakeOutbox) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	f.messages = append(f.messages, *message)
+	return nil
+}
+
+// topics returns the topics of the enqueued messages, in order.
+func (f *fakeOutbox) topics() []string {
+	topics := make([]string, 0, len(f.messages))
+	for _, message := range f.messages {
+		topics = append(topics, message.Topic)
+	}
+	return topics
+}
+
+// fakeFollows is an in-memory repository.FollowRepository, with the same limits as fakeComplejos.
+type fakeFollows struct {
+	repository.FollowRepository
+	followees map[string][]string // IDs of the followed Complejos, by follower ID
+}
+
+func (f *fakeFollows) FindFolloweeIDs(ctx context.Context, followerID string) ([]string, error) {
+	return f.followees[followerID], nil
+}
+
+// fakeBlocks is an in-memory repository.BlockRepository, with the same limits as fakeComplejos.
+type fakeBlocks struct {
+	repository.BlockRepository
+	blockers map[string][]string // IDs of the blocking Complejos, by blocked ID
+}
+
+func (f *fakeBlocks) FindBlockerIDs(ctx context.Context, blockedID string) ([]string, error) {
+	return f.blockers[blockedID], nil
+}
+
+// fakePrivacy is an in-memory repository.PrivacyRepository, with the same limits as fakeComplejos.
+type fakePrivacy struct {
+	repository.PrivacyRepository
+	settings map[string]models.PrivacySettings
+}
+
+func (f *fakePrivacy) FindByComplejos(ctx context.Context, complejoIDs []string) ([]models.PrivacySettings, error) {
+	var found []models.PrivacySettings
+	for _, id := range complejoIDs {
+		if settings, ok := f.settings[id]; ok {
+			found = append(found, settings)
+		}
+	}
+	return found, nil
+}
+
+// fakeHistory keeps the appended subscription transitions.
+type fakeHistory struct {
+	repository.SubscriptionEventRepository
+	transitions []models.SubscriptionEvent
+}
+
+func (f *fakeHistory) Append(ctx context.Context, event *models.SubscriptionEvent) error {
+	f.transitions = append(f.transitions, *event)
+	return nil
+}
+
+// fakeErasure reports the given counts and photo keys, and records the usernames it anonymized.
+type fakeErasure struct {
+	anonymized map[string]int64
+	removed    map[string]int64
+	photoKeys  []string
+	usernames  []string // Usernames replaced by Anonymize
+}
+
+func (f *fakeErasure) Anonymize(ctx context.Context, complejoID, username, placeholder string) (map[string]int64, error) {
+	f.usernames = append(f.usernames, username)
+	return f.anonymized, nil
+}
+
+func (f *fakeErasure) RemoveContent(ctx context.Context, complejoID string) ([]string, map[string]int64, error) {
+	return f.photoKeys, f.removed, nil
+}
+
+// fakeAudit keeps the appended audit entries.
+type fakeAudit struct {
+	repository.AuditRepository
+	entries []models.AuditEntry
+}
+
+func (f *fakeAudit) Append(ctx context.Context, entry *models.AuditEntry) error {
+	f.entries = append(f.entries, *entry)
+	return nil
+}
+
+// fakeExports is an in-memory repository.DataExportRepository, with the same limits as fakeComplejos.
+type fakeExports struct {
+	repository.DataExportRepository
+	exports map[string]models.DataExport
+}
+
+func (f *fakeExports) FindLatest(ctx context.Context, complejoID string) (*models.DataExport, error) {
+	var latest *models.DataExport
+	for _, export := range f.exports {
+		if export.ComplejoID == complejoID && (latest == nil || export.RequestedAt.After(latest.RequestedAt)) {
+			latest = &export
+		}
+	}
+	if latest == nil {
+		return nil, repository.ErrNotFound
+	}
+	return latest, nil
+}
+
+func (f *fakeExports) Delete(ctx context.Context, id string) (bool, error) {
+	_, ok := f.exports[id]
+	delete(f.exports, id)
+	return ok, nil
+}
+
+// fakeOffboarding is an in-memory repository.OffboardingRepository, purging the given counts.
+type fakeOffboarding struct {
+	offboarding *models.Offboarding
+	removed     map[string]int64
+	purges      int
+}
+
+func (f *fakeOffboarding) Find(ctx context.Context) (*models.Offboarding, error) {
+	if f.offboarding == nil {
+		return nil, repository.ErrNotFound
+	}
+	offboarding := *f.offboarding
+	return &offboarding, nil
+}
+
+func (f *fakeOffboarding) Save(ctx context.Context, offboarding *models.Offboarding) error {
+	saved := *offboarding
+	f.offboarding = &saved
+	return nil
+}
+
+func (f *fakeOffboarding) Delete(ctx context.Context) (bool, error) {
+	found := f.offboarding != nil
+	f.offboarding = nil
+	return found, nil
+}
+
+func (f *fakeOffboarding) Purge(ctx context.Context) (map[string]int64, error) {
+	f.purges++
+	return f.removed, nil
+}
@@ -0,0 +1,130 @@
+// federation_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/federation"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// FederationService exchanges public events with the other clubs of the federation:
+// it publishes our upcoming events and imports theirs for the "nearby clubs" listing.
+type FederationService struct {
+	client *federation.Client // nil when the federation is not configured
+	club   string
+	events repository.EventRepository
+	nearby repository.FederatedEventRepository
+	tx     repository.Transactor
+	clock  clock.Clock
+}
+
+// NewFederationService creates a FederationService. A nil client disables publishing and importing.
+func NewFederationService(client *federation.Client, club string, events repository.EventRepository, nearby repository.FederatedEventRepository, tx repository.Transactor, clk clock.Clock) *FederationService {
+	return &FederationService{
+		client: client,
+		club:   club,
+		events: events,
+		nearby: nearby,
+		tx:     tx,
+		clock:  clk,
+	}
+}
+
+// Enabled reports whether a federation endpoint is configured.
+func (s *FederationService) Enabled() bool {
+	return s.client != nil
+}
+
+// Sync exports our events to the federation, then imports those of the other clubs, even when the export failed. It does nothing when the
+// federation is not configured.
+func (s *FederationService) Sync(ctx context.Context) error {
+	if !s.Enabled() {
+		return nil
+	}
+	var errs []error
+	if err := s.Export(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("federation export failed: %w", err))
+	}
+	if err := s.Import(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("federation import failed: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// Export publishes our upcoming events to the federation.
+func (s *FederationService) Export(ctx context.Context) error {
+	now := s.clock.Now()
+	events, err := s.events.FindAll(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	feed := federation.Feed{Club: s.club, GeneratedAt: now, Events: []federation.Event{}}
+	for _, event := range events {
+		if event.IsPast(now) || event.ExternalID != "" {
+			// Past events are irrelevant and ingested events belong to their producer
+			continue
+		}
+		feed.Events = append(feed.Events, federation.Event{
+			ID:          event.ID,
+			Title:       event.Title,
+			Description: event.Description,
+			Date:        event.Date,
+			Location:    event.Location,
+		})
+	}
+
+	return s.client.Publish(ctx, feed)
+}
+
+// Import refreshes the stored events of every other club from the federation feeds,
+// in a single transaction so the nearby listing never mixes old and new feeds.
+func (s *FederationService) Import(ctx context.Context) error {
+	feeds, err := s.client.FetchFeeds(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.importFeeds(ctx, feeds)
+	})
+}
+
+// importFeeds replaces the stored events of every other club with those of its feed.
+func (s *FederationService) importFeeds(ctx context.Context, feeds []federation.Feed) error {
+	now := s.clock.Now()
+	for _, feed := range feeds {
+		if feed.Club == s.club || feed.Club == "" {
+			continue
+		}
+
+		events := make([]models.FederatedEvent, 0, len(feed.Events))
+		for _, event := range feed.Events {
+			events = append(events, models.FederatedEvent{
+				ID:          feed.Club + ":" + event.ID,
+				Club:        feed.Club,
+				ExternalID:  event.ID,
+				Title:       event.Title,
+				Description: event.Description,
+				Date:        event.Date,
+				Location:    event.Location,
+				ImportedAt:  now,
+			})
+		}
+
+		if err := s.nearby.ReplaceClub(ctx, feed.Club, events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Nearby returns the upcoming events of the other clubs.
+func (s *FederationService) Nearby(ctx context.Context) ([]models.FederatedEvent, error) {
+	return s.nearby.FindUpcoming(ctx, s.clock.Now())
+}
@@ -0,0 +1,149 @@
+// feed_service.go
+package services
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/outbox"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// feedNamespace derives the IDs of the feed items from the activity they show, so that a domain event delivered
+// again does not add its item twice.
+var feedNamespace = uuid.MustParse("5b1e9c47-2d8a-4f63-a0c4-7e3f9b12d6a8")
+
+// FeedService keeps the activity feed: the subscriber of the domain events writes the personal records, event
+// subscriptions and challenges won by the Complejos, and the events created in the groups, and each Complejo reads
+// those of the Complejos it follows and of the groups it belongs to.
+type FeedService struct {
+	repo    repository.FeedRepository
+	follows repository.FollowRepository
+	groups  repository.GroupRepository
+	events  repository.EventRepository
+	clock   clock.Clock
+}
+
+// NewFeedService creates a FeedService backed by the given repositories and clock.
+func NewFeedService(repo repository.FeedRepository, follows repository.FollowRepository, groups repository.GroupRepository, events repository.EventRepository, clk clock.Clock) *FeedService {
+	return &FeedService{repo: repo, follows: follows, groups: groups, events: events, clock: clk}
+}
+
+// Feed returns the requested page of the activity of the Complejos the Complejo with the given ID follows and of
+// the groups it belongs to, latest first, and its total number. Missing values are defaulted on the query.
+func (s *FeedService) Feed(ctx context.Context, complejoID string, query *models.FeedQuery) ([]models.FeedItem, int64, error) {
+	query.Normalize()
+	followed, err := s.follows.FindFolloweeIDs(ctx, complejoID)
+	if err != nil {
+		return nil, 0, err
+	}
+	roles, err := s.groups.FindRoles(ctx, complejoID)
+	if err != nil {
+		return nil, 0, err
+	}
+	groupIDs := make([]string, 0, len(roles))
+	for id := range roles {
+		groupIDs = append(groupIDs, id)
+	}
+	if len(followed) == 0 && len(groupIDs) == 0 {
+		return []models.FeedItem{}, 0, nil
+	}
+	return s.repo.Find(ctx, followed, groupIDs, query.Offset(), query.Limit)
+}
+
+// Record keeps the activity feed up to date with a domain event: it adds the item of a personal record, an
+// event subscription, a challenge won or an event created in a group, and removes the items undone by an
+// unsubscription, a deleted event or a deleted Complejo. Other domain events are ignored.
+func (s *FeedService) Record(ctx context.Context, event bus.Event) error {
+	now := s.clock.Now()
+	switch e := event.(type) {
+	case *bus.PRAchieved:
+		eventID := outbox.MessageID(ctx)
+		if eventID == "" {
+			eventID = uuid.NewString()
+		}
+		return s.repo.Insert(ctx, &models.FeedItem{
+			ID:         feedItemID(models.FeedPersonalRecord, eventID, e.Lift),
+			Kind:       models.FeedPersonalRecord,
+			ComplejoID: e.ComplejoID,
+			Username:   e.Username,
+			Lift:       e.Lift,
+			Value:      e.Value,
+			CreatedAt:  now,
+		})
+
+	case *bus.UserSubscribed:
+		subscribed, err := s.events.FindByID(ctx, e.EventID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return s.repo.Insert(ctx, &models.FeedItem{
+			ID:         feedItemID(models.FeedEventSubscribed, e.EventID, e.ComplejoID),
+			Kind:       models.FeedEventSubscribed,
+			ComplejoID: e.ComplejoID,
+			Username:   e.Username,
+			EventID:    e.EventID,
+			Title:      subscribed.Title,
+			CreatedAt:  now,
+		})
+
+	case *bus.UserUnsubscribed:
+		_, err := s.repo.DeleteByEvent(ctx, e.EventID, models.FeedEventSubscribed, e.ComplejoID)
+		return err
+
+	case *bus.ChallengeWon:
+		return s.repo.Insert(ctx, &models.FeedItem{
+			ID:          feedItemID(models.FeedChallengeWon, e.ChallengeID, e.ComplejoID),
+			Kind:        models.FeedChallengeWon,
+			ComplejoID:  e.ComplejoID,
+			Username:    e.Username,
+			ChallengeID: e.ChallengeID,
+			Title:       e.Title,
+			Value:       e.Score,
+			CreatedAt:   now,
+		})
+
+	case *bus.EventCreated:
+		if e.GroupID == "" {
+			return nil
+		}
+		return s.repo.Insert(ctx, &models.FeedItem{
+			ID:        feedItemID(models.FeedGroupEvent, e.ID),
+			Kind:      models.FeedGroupEvent,
+			GroupID:   e.GroupID,
+			EventID:   e.ID,
+			Title:     e.Title,
+			CreatedAt: now,
+		})
+
+	case *bus.EventDeleted:
+		for _, kind := range []string{models.FeedEventSubscribed, models.FeedGroupEvent} {
+			if _, err := s.repo.DeleteByEvent(ctx, e.ID, kind, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *bus.ComplejoDeleted:
+		_, err := s.repo.DeleteByComplejo(ctx, e.ID)
+		return err
+	}
+	return nil
+}
+
+// feedItemID derives the ID of the feed item of the given kind from the parts identifying its activity.
+func feedItemID(kind string, parts ...string) string {
+	name := kind
+	for _, part := range parts {
+		name += "/" + part
+	}
+	return uuid.NewSHA1(feedNamespace, []byte(name)).String()
+}
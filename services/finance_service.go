@@ -0,0 +1,201 @@
+// finance_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/stripe"
+)
+
+// FinanceService records the payments notified by Stripe and the expenses of the events,
+// and summarizes the revenue and profit of the events.
+type FinanceService struct {
+	payments repository.PaymentRepository
+	expenses repository.ExpenseRepository
+	events   repository.EventRepository
+	clock    clock.Clock
+
+	WebhookSecret string         // Signing secret of the Stripe webhook endpoint (webhooks are rejected when empty)
+	Location      *time.Location // Time zone of the months in the summary
+}
+
+// NewFinanceService creates a FinanceService grouping months in UTC.
+func NewFinanceService(payments repository.PaymentRepository, expenses repository.ExpenseRepository, events repository.EventRepository, clk clock.Clock) *FinanceService {
+	return &FinanceService{payments: payments, expenses: expenses, events: events, clock: clk, Location: time.UTC}
+}
+
+// HandleWebhook verifies a Stripe webhook and records the charge it carries.
+// Only paid charges are recorded (charge.succeeded and charge.refunded); other events are ignored,
+// and recording the same charge twice is harmless.
+func (s *FinanceService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	if s.WebhookSecret == "" {
+		return ErrPaymentsDisabled
+	}
+
+	now := s.clock.Now()
+	if err := stripe.Verify(payload, signature, s.WebhookSecret, now); err != nil {
+		return ErrInvalidStripeSignature.Wrap(err)
+	}
+
+	var event stripe.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return ErrInvalidStripePayload.Wrap(err)
+	}
+	if event.Type != stripe.ChargeSucceeded && event.Type != stripe.ChargeRefunded {
+		return nil
+	}
+
+	var charge stripe.Charge
+	if err := json.Unmarshal(event.Data.Object, &charge); err != nil {
+		return ErrInvalidStripePayload.Wrap(err)
+	}
+	if charge.ID == "" {
+		return ErrInvalidStripePayload
+	}
+	if !charge.Paid {
+		return nil
+	}
+
+	return s.payments.Upsert(ctx, &models.Payment{
+		ID:             charge.ID,
+		EventID:        charge.Metadata["event_id"],
+		Amount:         charge.Amount,
+		AmountRefunded: charge.AmountRefunded,
+		Currency:       strings.ToLower(charge.Currency),
+		PaidAt:         time.Unix(charge.Created, 0).UTC(),
+		UpdatedAt:      now,
+	})
+}
+
+// Summary returns the revenue of the payments made and the expenses incurred between from and to (by default
+// the last 12 months), per event (highest profit first), per month (oldest first) and in total, with refunds
+// netted out of the revenue and expenses out of the profit. Each currency is totalled separately.
+func (s *FinanceService) Summary(ctx context.Context, from, to *time.Time) (*models.FinanceSummary, error) {
+	end := s.clock.Now()
+	if to != nil {
+		end = *to
+	}
+	start := end.AddDate(-1, 0, 0)
+	if from != nil {
+		start = *from
+	}
+
+	rows, err := s.payments.Revenue(ctx, start, end, s.Location)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.expenses.Totals(ctx, start, end, s.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	type eventKey struct{ eventID, currency string }
+	type monthKey struct{ month, currency string }
+	events := map[eventKey]*models.EventRevenue{}
+	months := map[monthKey]*models.MonthRevenue{}
+	totals := map[string]*models.Revenue{}
+
+	// revenues returns the event, month and currency totals an aggregated row counts towards.
+	revenues := func(eventID, month, currency string) []*models.Revenue {
+		event, ok := events[eventKey{eventID, currency}]
+		if !ok {
+			event = &models.EventRevenue{EventID: eventID, Revenue: models.Revenue{Currency: currency}}
+			events[eventKey{eventID, currency}] = event
+		}
+		monthly, ok := months[monthKey{month, currency}]
+		if !ok {
+			monthly = &models.MonthRevenue{Month: month, Revenue: models.Revenue{Currency: currency}}
+			months[monthKey{month, currency}] = monthly
+		}
+		total, ok := totals[currency]
+		if !ok {
+			total = &models.Revenue{Currency: currency}
+			totals[currency] = total
+		}
+		return []*models.Revenue{&event.Revenue, &monthly.Revenue, total}
+	}
+
+	for _, row := range rows {
+		for _, revenue := range revenues(row.EventID, row.Month, row.Currency) {
+			revenue.Payments += row.Payments
+			revenue.Gross += row.Gross
+			revenue.Refunded += row.Refunded
+			revenue.Net = revenue.Gross - revenue.Refunded
+			revenue.Profit = revenue.Net - revenue.Expenses
+		}
+	}
+	for _, row := range expenses {
+		for _, revenue := range revenues(row.EventID, row.Month, row.Currency) {
+			revenue.Expenses += row.Amount
+			revenue.Profit = revenue.Net - revenue.Expenses
+		}
+	}
+
+	summary := &models.FinanceSummary{
+		From:     start,
+		To:       end,
+		Timezone: s.Location.String(),
+		Events:   make([]models.EventRevenue, 0, len(events)),
+		Months:   make([]models.MonthRevenue, 0, len(months)),
+		Totals:   make([]models.Revenue, 0, len(totals)),
+	}
+
+	titles := map[string]string{}
+	for _, event := range events {
+		if _, ok := titles[event.EventID]; !ok && event.EventID != "" {
+			titles[event.EventID], err = s.eventTitle(ctx, event.EventID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		event.Title = titles[event.EventID]
+		summary.Events = append(summary.Events, *event)
+	}
+	for _, month := range months {
+		summary.Months = append(summary.Months, *month)
+	}
+	for _, total := range totals {
+		summary.Totals = append(summary.Totals, *total)
+	}
+
+	sort.Slice(summary.Events, func(i, j int) bool {
+		a, b := summary.Events[i], summary.Events[j]
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		if a.Profit != b.Profit {
+			return a.Profit > b.Profit
+		}
+		return a.EventID < b.EventID
+	})
+	sort.Slice(summary.Months, func(i, j int) bool {
+		a, b := summary.Months[i], summary.Months[j]
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		return a.Currency < b.Currency
+	})
+	sort.Slice(summary.Totals, func(i, j int) bool { return summary.Totals[i].Currency < summary.Totals[j].Currency })
+
+	return summary, nil
+}
+
+// eventTitle returns the title of the Event, or "" when it no longer exists.
+func (s *FinanceService) eventTitle(ctx context.Context, id string) (string, error) {
+	event, err := s.events.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return event.Title, nil
+}
@@ -0,0 +1,105 @@
+// follow_service.go
+package services
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// FollowService lets the Complejos follow each other. Following a Complejo lets its follower see the fields of
+// its profile it shares with its followers (see PrivacyService).
+type FollowService struct {
+	repo      repository.FollowRepository
+	blocks    repository.BlockRepository
+	complejos repository.ComplejoRepository
+	clock     clock.Clock
+}
+
+// NewFollowService creates a FollowService backed by the given repositories and clock.
+func NewFollowService(repo repository.FollowRepository, blocks repository.BlockRepository, complejos repository.ComplejoRepository, clk clock.Clock) *FollowService {
+	return &FollowService{repo: repo, blocks: blocks, complejos: complejos, clock: clk}
+}
+
+// Follow makes the follower follow the live Complejo with the given ID and returns the follow status of that
+// Complejo; following it again changes nothing. A Complejo cannot follow itself (ErrFollowSelf), nor a Complejo
+// it blocks or that blocks it (ErrBlocked).
+func (s *FollowService) Follow(ctx context.Context, followerID, followeeID string) (*models.FollowStatus, error) {
+	if followerID == followeeID {
+		return nil, ErrFollowSelf
+	}
+	if _, err := s.complejos.FindByID(ctx, followeeID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	isBlocked, err := blocked(ctx, s.blocks, followerID, followeeID)
+	if err != nil {
+		return nil, err
+	}
+	if isBlocked {
+		return nil, ErrBlocked
+	}
+
+	err = s.repo.Insert(ctx, &models.Follow{
+		ID:         uuid.NewString(),
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		CreatedAt:  s.clock.Now(),
+	})
+	if err != nil && !errors.Is(err, repository.ErrDuplicate) {
+		return nil, err
+	}
+	return s.Status(ctx, followeeID, followerID)
+}
+
+// Unfollow stops the follower from following the Complejo with the given ID and returns the follow status of that
+// Complejo; unfollowing it again changes nothing.
+func (s *FollowService) Unfollow(ctx context.Context, followerID, followeeID string) (*models.FollowStatus, error) {
+	if _, err := s.complejos.FindByID(ctx, followeeID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	if _, err := s.repo.Delete(ctx, followerID, followeeID); err != nil {
+		return nil, err
+	}
+	return s.Status(ctx, followeeID, followerID)
+}
+
+// Status counts the live followers and followed Complejos of the Complejo, and tells whether the viewer (none
+// when empty) follows it.
+func (s *FollowService) Status(ctx context.Context, complejoID, viewerID string) (*models.FollowStatus, error) {
+	followers, following, err := s.repo.Count(ctx, complejoID)
+	if err != nil {
+		return nil, err
+	}
+	status := &models.FollowStatus{ComplejoID: complejoID, Followers: followers, Following: following}
+	if viewerID != "" && viewerID != complejoID {
+		if status.FollowedByMe, err = s.repo.Exists(ctx, viewerID, complejoID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// Followers returns the requested page of the live followers of the live Complejo with the given ID, latest
+// first, and their total number. Missing values are defaulted on the query.
+func (s *FollowService) Followers(ctx context.Context, complejoID string, query *models.FollowQuery) ([]models.FollowEntry, int64, error) {
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, 0, notFound(err, ErrComplejoNotFound)
+	}
+	query.Normalize()
+	return s.repo.FindFollowers(ctx, complejoID, query.Offset(), query.Limit)
+}
+
+// Following returns the requested page of the live Complejos the live Complejo with the given ID follows, latest
+// first, and their total number. Missing values are defaulted on the query.
+func (s *FollowService) Following(ctx context.Context, complejoID string, query *models.FollowQuery) ([]models.FollowEntry, int64, error) {
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, 0, notFound(err, ErrComplejoNotFound)
+	}
+	query.Normalize()
+	return s.repo.FindFollowing(ctx, complejoID, query.Offset(), query.Limit)
+}
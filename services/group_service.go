@@ -0,0 +1,306 @@
+// group_service.go
+package services
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// GroupService runs the groups of Complejos: any Complejo creates one and becomes its owner, and the owners
+// manage its members, their roles and the events of the group, which only its members may go to. Each group
+// has its own leaderboard, ranking its members by their lifts.
+type GroupService struct {
+	repo        repository.GroupRepository
+	complejos   repository.ComplejoRepository
+	tx          repository.Transactor
+	events      *EventService
+	leaderboard *LeaderboardService
+	clock       clock.Clock
+}
+
+// NewGroupService creates a GroupService backed by the given repositories and clock, creating the events of the
+// groups through the event service and ranking their members through the leaderboard service.
+func NewGroupService(repo repository.GroupRepository, complejos repository.ComplejoRepository, tx repository.Transactor, events *EventService, leaderboard *LeaderboardService, clk clock.Clock) *GroupService {
+	return &GroupService{
+		repo:        repo,
+		complejos:   complejos,
+		tx:          tx,
+		events:      events,
+		leaderboard: leaderboard,
+		clock:       clk,
+	}
+}
+
+// List returns the page of the filter of the groups by name, and their total number, with the role of the
+// Complejo with the given ID in each of them.
+func (s *GroupService) List(ctx context.Context, filter *models.GroupFilter, complejoID string) ([]models.Group, int64, error) {
+	filter.Normalize()
+	groups, total, err := s.repo.Search(ctx, *filter, complejoID)
+	if err != nil {
+		return nil, 0, err
+	}
+	roles, err := s.repo.FindRoles(ctx, complejoID)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range groups {
+		groups[i].Role = roles[groups[i].ID]
+	}
+	return groups, total, nil
+}
+
+// Get returns the group with the given ID, with the role of the Complejo with the given ID in it.
+func (s *GroupService) Get(ctx context.Context, id, complejoID string) (*models.Group, error) {
+	group, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrGroupNotFound)
+	}
+	role, err := s.role(ctx, id, complejoID)
+	if err != nil {
+		return nil, err
+	}
+	group.Role = role
+	return group, nil
+}
+
+// Create creates a group on behalf of the Complejo with the given ID, which becomes its first owner.
+func (s *GroupService) Create(ctx context.Context, complejoID string, input models.GroupInput) (*models.Group, error) {
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	now := s.clock.Now()
+	group := &models.Group{
+		ID:          uuid.NewString(),
+		Name:        input.Name,
+		Description: input.Description,
+		CreatedBy:   complejoID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Insert(ctx, group); err != nil {
+			return err
+		}
+		return s.repo.SetMembership(ctx, &models.GroupMembership{
+			ID:         uuid.NewString(),
+			GroupID:    group.ID,
+			ComplejoID: complejoID,
+			Role:       models.GroupRoleOwner,
+			JoinedAt:   now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	group.Role = models.GroupRoleOwner
+	return group, nil
+}
+
+// Update replaces the name and description of a group. Only admins and the owners of the group may change it.
+func (s *GroupService) Update(ctx context.Context, id, requesterID string, isAdmin bool, input models.GroupInput) (*models.Group, error) {
+	group, err := s.managed(ctx, id, requesterID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Name = input.Name
+	group.Description = input.Description
+	group.UpdatedAt = s.clock.Now()
+	if err := s.repo.Replace(ctx, group); err != nil {
+		return nil, notFound(err, ErrGroupNotFound)
+	}
+	if group.Role, err = s.role(ctx, id, requesterID); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// Delete removes a group and its memberships. Only admins and the owners of the group may remove it; its events
+// are kept, open to every Complejo.
+func (s *GroupService) Delete(ctx context.Context, id, requesterID string, isAdmin bool) error {
+	if _, err := s.managed(ctx, id, requesterID, isAdmin); err != nil {
+		return err
+	}
+
+	found, err := s.repo.DeleteByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrGroupNotFound
+	}
+	return nil
+}
+
+// Members returns the requested page of the live members of a group, owners first then by join time, and their
+// total number. Missing values are defaulted on the query.
+func (s *GroupService) Members(ctx context.Context, id string, query *models.GroupMemberQuery) ([]models.GroupMember, int64, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, 0, notFound(err, ErrGroupNotFound)
+	}
+	query.Normalize()
+	return s.repo.FindMembers(ctx, id, query.Offset(), query.Limit)
+}
+
+// SetMember adds the Complejo to the members of a group with the role of the input ("member" by default), or
+// changes its role. Only admins and the owners of the group may manage its members, and the last owner cannot be
+// made a plain member (ErrLastGroupOwner).
+func (s *GroupService) SetMember(ctx context.Context, id, complejoID, requesterID string, isAdmin bool, input models.GroupMembershipInput) (*models.GroupMembership, error) {
+	if _, err := s.managed(ctx, id, requesterID, isAdmin); err != nil {
+		return nil, err
+	}
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	role := input.Role
+	if role == "" {
+		role = models.GroupRoleMember
+	}
+
+	var membership *models.GroupMembership
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.repo.FindMembership(ctx, id, complejoID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		if current != nil && current.Role == models.GroupRoleOwner && role != models.GroupRoleOwner {
+			if err := s.checkOwners(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		membership = &models.GroupMembership{
+			ID:         uuid.NewString(),
+			GroupID:    id,
+			ComplejoID: complejoID,
+			Role:       role,
+			JoinedAt:   s.clock.Now(),
+		}
+		if current != nil {
+			membership.ID = current.ID
+			membership.JoinedAt = current.JoinedAt
+		}
+		return s.repo.SetMembership(ctx, membership)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// RemoveMember removes the Complejo from the members of a group. Members may leave the group themselves; only
+// admins and the owners of the group may remove the others. The last owner cannot leave (ErrLastGroupOwner).
+func (s *GroupService) RemoveMember(ctx context.Context, id, complejoID, requesterID string, isAdmin bool) error {
+	if complejoID == requesterID {
+		if _, err := s.repo.FindByID(ctx, id); err != nil {
+			return notFound(err, ErrGroupNotFound)
+		}
+	} else if _, err := s.managed(ctx, id, requesterID, isAdmin); err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.repo.FindMembership(ctx, id, complejoID)
+		if err != nil {
+			return notFound(err, ErrGroupMemberNotFound)
+		}
+		if current.Role == models.GroupRoleOwner {
+			if err := s.checkOwners(ctx, id); err != nil {
+				return err
+			}
+		}
+		found, err := s.repo.RemoveMembership(ctx, id, complejoID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrGroupMemberNotFound
+		}
+		return nil
+	})
+}
+
+// CreateEvent creates an event of a group on behalf of the Complejo with the given ID; only the members of the
+// group may go to it. Only admins and the owners of the group may create its events.
+func (s *GroupService) CreateEvent(ctx context.Context, id, requesterID string, isAdmin bool, event *models.Event) error {
+	if _, err := s.managed(ctx, id, requesterID, isAdmin); err != nil {
+		return err
+	}
+	event.GroupID = id
+	return s.events.Create(ctx, event, requesterID)
+}
+
+// Events returns the requested page of the events of a group, as the event listing filtered on the group, and
+// their total number.
+func (s *GroupService) Events(ctx context.Context, id string, filter *models.EventFilter) ([]models.Event, int64, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, 0, notFound(err, ErrGroupNotFound)
+	}
+	filter.Group = id
+	return s.events.Search(ctx, filter)
+}
+
+// Leaderboard returns the requested page of the leaderboard of the members of a group, with the place of the
+// Complejo with the given ID (none when empty or not ranked), and the number of members ranked.
+func (s *GroupService) Leaderboard(ctx context.Context, id string, query *models.LeaderboardQuery, complejoID string) (*models.Leaderboard, int64, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, 0, notFound(err, ErrGroupNotFound)
+	}
+	members, err := s.repo.FindMemberIDs(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	query.Members = members
+	return s.leaderboard.Leaderboard(ctx, query, complejoID)
+}
+
+// managed returns the group with the given ID when the requester is an admin or one of its owners.
+func (s *GroupService) managed(ctx context.Context, id, requesterID string, isAdmin bool) (*models.Group, error) {
+	group, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrGroupNotFound)
+	}
+	if isAdmin {
+		return group, nil
+	}
+	role, err := s.role(ctx, id, requesterID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.GroupRoleOwner {
+		return nil, ErrNotGroupOwner
+	}
+	return group, nil
+}
+
+// role returns the role of the Complejo in the group, or "" when it is not a member.
+func (s *GroupService) role(ctx context.Context, id, complejoID string) (string, error) {
+	membership, err := s.repo.FindMembership(ctx, id, complejoID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return membership.Role, nil
+}
+
+// checkOwners returns ErrLastGroupOwner when the group has a single owner, who may then neither leave nor be made
+// a plain member.
+func (s *GroupService) checkOwners(ctx context.Context, id string) error {
+	owners, err := s.repo.CountOwners(ctx, id)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastGroupOwner
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// guest_invitation.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/models"
+
+	"github.com/google/uuid"
+)
+
+// InviteGuest makes an invitation link for a guest of the Event to join as a Complejo, valid for InvitationTTL.
+// Only admins and the Complejo that brought the guest may invite it. The token of the link is only returned here.
+func (s *ComplejoService) InviteGuest(ctx context.Context, eventID, guestID, requesterID string, isAdmin bool) (*models.InvitationLink, error) {
+	event, err := s.events.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+
+	var guest *models.Guest
+	for i := range event.Guests {
+		if event.Guests[i].ID == guestID {
+			guest = &event.Guests[i]
+		}
+	}
+	if guest == nil {
+		return nil, ErrGuestNotFound
+	}
+	if !isAdmin && guest.HostID != requesterID {
+		return nil, ErrNotGuestHost
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(secret)
+
+	now := s.clock.Now()
+	invitation := &models.GuestInvitation{
+		ID:        uuid.NewString(),
+		TokenHash: hashToken(token),
+		EventID:   eventID,
+		GuestID:   guest.ID,
+		Name:      guest.Name,
+		HostID:    guest.HostID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.InvitationTTL),
+	}
+	if err := s.invitations.Insert(ctx, invitation); err != nil {
+		return nil, err
+	}
+	return &models.InvitationLink{Token: token, Link: "/complejo/join/" + token, ExpiresAt: invitation.ExpiresAt}, nil
+}
+
+// Invitation returns the name and the Events of the guest invited with the token, to pre-fill its sign-up.
+func (s *ComplejoService) Invitation(ctx context.Context, token string) (*models.InvitationPreview, error) {
+	invitation, err := s.invitation(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.events.FindByGuest(ctx, invitation.HostID, invitation.Name)
+	if err != nil {
+		return nil, err
+	}
+	preview := &models.InvitationPreview{Name: invitation.Name, ExpiresAt: invitation.ExpiresAt, Events: []models.AttendedEvent{}}
+	for _, event := range events {
+		preview.Events = append(preview.Events, models.AttendedEvent{ID: event.ID, Title: event.Title, Date: event.Date})
+	}
+	return preview, nil
+}
+
+// Join registers the guest invited with the token as the Complejo, like Create, and returns its JWT and the IDs
+// of the Events it was carried over to. Each guest record the host registered under the same name becomes a "going"
+// RSVP of the Complejo, answered and recorded in the subscription history when the guest was registered, so its
+// attendance counts as its own (the passes used by the host are handed back).
+// The invitation is used up, and the conversion announced (GuestConverted), in the same transaction.
+func (s *ComplejoService) Join(ctx context.Context, token string, complejo *models.Complejo) (string, []string, error) {
+	invitation, err := s.invitation(ctx, token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	converted := []string{}
+	jwt, err := s.register(ctx, complejo, func(ctx context.Context) error {
+		accepted, err := s.invitations.Accept(ctx, invitation.ID, complejo.ID, s.clock.Now())
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			return ErrInvitationUsed
+		}
+
+		events, err := s.events.FindByGuest(ctx, invitation.HostID, invitation.Name)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := s.convertGuest(ctx, event, invitation, complejo); err != nil {
+				return err
+			}
+			converted = append(converted, event.ID)
+		}
+
+		return s.announce(ctx, bus.GuestConverted{
+			ComplejoID: complejo.ID,
+			Username:   complejo.Username,
+			HostID:     invitation.HostID,
+			Events:     converted,
+		})
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return jwt, converted, nil
+}
+
+// convertGuest replaces the guests of the invitation on the Event with a "going" RSVP of the Complejo,
+// dated when the first of them was registered.
+func (s *ComplejoService) convertGuest(ctx context.Context, event models.Event, invitation *models.GuestInvitation, complejo *models.Complejo) error {
+	var first *models.Guest
+	for i, guest := range event.Guests {
+		if guest.HostID != invitation.HostID || guest.Name != invitation.Name {
+			continue
+		}
+		if _, err := s.events.RemoveGuest(ctx, event.ID, guest.ID); err != nil {
+			return err
+		}
+		if first == nil {
+			first = &event.Guests[i]
+		}
+	}
+	if first == nil {
+		return nil
+	}
+
+	rsvp := models.RSVP{ComplejoID: complejo.ID, Username: complejo.Username, Status: models.RSVPGoing, RespondedAt: first.CreatedAt}
+	if _, err := s.events.SetRSVP(ctx, event.ID, rsvp); err != nil {
+		return err
+	}
+	return s.history.Append(ctx, &models.SubscriptionEvent{
+		ID:         uuid.NewString(),
+		EventID:    event.ID,
+		Username:   complejo.Username,
+		Type:       models.SubscriptionSubscribed,
+		OccurredAt: first.CreatedAt,
+	})
+}
+
+// PurgeExpiredInvitations removes the invitations never accepted that expired longer than InvitationTTL ago,
+// so their guests are told for a while that the link expired, and returns how many were removed.
+func (s *ComplejoService) PurgeExpiredInvitations(ctx context.Context) (int64, error) {
+	return s.invitations.PurgeExpired(ctx, s.clock.Now().Add(-s.InvitationTTL))
+}
+
+// invitation returns the invitation with the token, or ErrInvitationNotFound, ErrInvitationUsed
+// or ErrInvitationExpired when it cannot be used.
+func (s *ComplejoService) invitation(ctx context.Context, token string) (*models.GuestInvitation, error) {
+	invitation, err := s.invitations.FindByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, notFound(err, ErrInvitationNotFound)
+	}
+	if invitation.AcceptedAt != nil {
+		return nil, ErrInvitationUsed
+	}
+	if !s.clock.Now().Before(invitation.ExpiresAt) {
+		return nil, ErrInvitationExpired
+	}
+	return invitation, nil
+}
+
+// hashToken returns the hex SHA-256 of an invitation or service token, as stored.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
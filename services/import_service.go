@@ -0,0 +1,283 @@
+// import_service.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/export"
+	"los-complejos-backend/models"
+	"los-complejos-backend/validation"
+)
+
+// ImportService creates Complejos and Events in bulk from CSV files or JSON arrays, reporting row by row what it
+// created, skipped as duplicates and could not import.
+type ImportService struct {
+	complejos *ComplejoService
+	events    *EventService
+}
+
+// NewImportService creates an ImportService creating the records through the Complejo and Event services, like
+// their create endpoints.
+func NewImportService(complejos *ComplejoService, events *EventService) *ImportService {
+	return &ImportService{complejos: complejos, events: events}
+}
+
+// importRow is a row of an import once read: its position, its value and, when it cannot be imported, why.
+type importRow[T any] struct {
+	position int
+	value    T
+	err      *apperrors.Error
+}
+
+// Import creates the records of the file, of the given kind (models.ImportComplejos or models.ImportEvents) and
+// format (models.ImportCSV or models.ImportJSON), the Events on behalf of the admin. Each row is validated like
+// the payload of the create endpoint, and skipped when it duplicates a stored record or an earlier row: a
+// Complejo by its username, an Event by its title (ignoring case) and date. A dry run reports the same without
+// creating anything.
+//
+// A file that cannot be read as a whole (malformed, or naming unknown columns) is refused with a 400 or 422
+// error. Only storage failures abort the import, keeping the records created before: importing the file again
+// skips them.
+func (s *ImportService) Import(ctx context.Context, kind, format string, data []byte, dryRun bool, adminID string) (*models.ImportReport, error) {
+	report := &models.ImportReport{Kind: kind, DryRun: dryRun, Summary: map[string]int{}, Results: []models.ImportResult{}}
+
+	var err error
+	switch kind {
+	case models.ImportComplejos:
+		err = s.importComplejos(ctx, report, format, data)
+	case models.ImportEvents:
+		err = s.importEvents(ctx, report, format, data, adminID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range report.Results {
+		report.Summary[result.Status]++
+	}
+	return report, nil
+}
+
+// importComplejos imports the rows of Complejos into the report.
+func (s *ImportService) importComplejos(ctx context.Context, report *models.ImportReport, format string, data []byte) error {
+	var rows []importRow[models.Complejo]
+	var err error
+	if format == models.ImportCSV {
+		records, unknown, readErr := export.ReadMembersCSV(bytes.NewReader(data))
+		rows, err = csvRows(records, unknown, readErr, export.MemberFields)
+	} else {
+		rows, err = jsonRows[models.Complejo](data)
+	}
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.complejos.List(ctx)
+	if err != nil {
+		return err
+	}
+	taken := make(map[string]int, len(stored))
+	for _, complejo := range stored {
+		taken[complejo.Username] = 0
+	}
+
+	for _, row := range rows {
+		complejo := row.value
+		result := models.ImportResult{Row: row.position, Key: complejo.Username}
+		if row.err != nil {
+			failed(&result, row.err)
+			report.Results = append(report.Results, result)
+			continue
+		}
+		if reason := duplicateOf(taken, complejo.Username, "a Complejo with this username already exists"); reason != "" {
+			result.Status, result.Reason = models.ImportSkipped, reason
+			report.Results = append(report.Results, result)
+			continue
+		}
+		taken[complejo.Username] = row.position
+
+		result.Status = models.ImportCreated
+		if !report.DryRun {
+			// Imported members start without a photo, uploaded through POST /complejo/photo
+			complejo.Photo = ""
+			_, err := s.complejos.Create(ctx, &complejo)
+			if err != nil && !importable(&result, err) {
+				return err
+			}
+			if result.Status == models.ImportCreated {
+				result.ID = complejo.ID
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+	return nil
+}
+
+// importEvents imports the rows of Events into the report, created by the admin.
+func (s *ImportService) importEvents(ctx context.Context, report *models.ImportReport, format string, data []byte, adminID string) error {
+	var rows []importRow[models.Event]
+	var err error
+	if format == models.ImportCSV {
+		records, unknown, readErr := export.ReadEventsCSV(bytes.NewReader(data))
+		rows, err = csvRows(records, unknown, readErr, export.EventFields)
+	} else {
+		rows, err = jsonRows[models.Event](data)
+	}
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.events.List(ctx)
+	if err != nil {
+		return err
+	}
+	taken := make(map[string]int, len(stored))
+	for _, event := range stored {
+		taken[eventKey(event.Title, event.Date)] = 0
+	}
+
+	for _, row := range rows {
+		event := row.value
+		result := models.ImportResult{Row: row.position, Key: strings.TrimSpace(event.Title) + " " + event.Date.UTC().Format(time.RFC3339)}
+		if row.err != nil {
+			failed(&result, row.err)
+			report.Results = append(report.Results, result)
+			continue
+		}
+		key := eventKey(event.Title, event.Date)
+		if reason := duplicateOf(taken, key, "an event with this title and date already exists"); reason != "" {
+			result.Status, result.Reason = models.ImportSkipped, reason
+			report.Results = append(report.Results, result)
+			continue
+		}
+		taken[key] = row.position
+
+		result.Status = models.ImportCreated
+		if !report.DryRun {
+			event.ClearManaged()
+			err := s.events.Create(ctx, &event, adminID)
+			if err != nil && !importable(&result, err) {
+				return err
+			}
+			if result.Status == models.ImportCreated {
+				result.ID = event.ID
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+	return nil
+}
+
+// csvRows turns the records read from a CSV import into rows, validating their values like the JSON payloads
+// and reporting the errors under the columns of the file. It returns the error refusing the whole file when
+// it could not be read or named unknown columns.
+func csvRows[T any](records []export.Record[T], unknown []string, err error, fields map[string]export.Field[T]) ([]importRow[T], error) {
+	if err != nil {
+		return nil, apperrors.BadRequest("Invalid CSV file: " + err.Error())
+	}
+	if unknown != nil {
+		columns := make([]string, 0, len(fields))
+		for column := range fields {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		details := make([]validation.FieldError, len(unknown))
+		for i, column := range unknown {
+			details[i] = validation.FieldError{Field: column, Rule: "unknown", Message: "is not a column of the import (" + strings.Join(columns, ", ") + ")"}
+		}
+		return nil, apperrors.Validation("Unknown columns", details)
+	}
+
+	columns := export.JSONNames(fields)
+	rows := make([]importRow[T], len(records))
+	for i, record := range records {
+		rows[i] = importRow[T]{position: record.Line, value: record.Value}
+		if len(record.Errors) > 0 {
+			details := make([]validation.FieldError, 0, len(record.Errors))
+			for column, message := range record.Errors {
+				details = append(details, validation.FieldError{Field: column, Rule: "format", Message: message})
+			}
+			sort.Slice(details, func(a, b int) bool { return details[a].Field < details[b].Field })
+			rows[i].err = apperrors.Validation("Validation failed", details)
+			continue
+		}
+		if err := validation.Struct(&rows[i].value); err != nil {
+			appErr := apperrors.From(err)
+			if details, ok := appErr.Details.([]validation.FieldError); ok {
+				for j := range details {
+					if column, ok := columns[details[j].Field]; ok {
+						details[j].Field = column
+					}
+				}
+			}
+			rows[i].err = appErr
+		}
+	}
+	return rows, nil
+}
+
+// jsonRows reads the rows of a JSON import, an array of payloads validated like those of the create endpoint.
+// It returns the error refusing the whole file when it is not an array.
+func jsonRows[T any](data []byte) ([]importRow[T], error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, apperrors.BadRequest("Invalid JSON format: the file must be an array of records")
+	}
+
+	rows := make([]importRow[T], len(items))
+	for i, item := range items {
+		rows[i].position = i + 1
+		if err := validation.DecodeJSON(item, &rows[i].value); err != nil {
+			rows[i].err = apperrors.From(err)
+		}
+	}
+	return rows, nil
+}
+
+// duplicateOf returns why the key duplicates a stored record (the given reason) or an earlier row, by its
+// position in taken (0 for a stored record), or "" when it is new.
+func duplicateOf(taken map[string]int, key, stored string) string {
+	position, ok := taken[key]
+	switch {
+	case !ok:
+		return ""
+	case position == 0:
+		return stored
+	}
+	return "duplicates row " + strconv.Itoa(position)
+}
+
+// eventKey is what duplicate Events are detected by: their title, ignoring case and surrounding spaces, and date.
+func eventKey(title string, date time.Time) string {
+	return strings.ToLower(strings.TrimSpace(title)) + "\x00" + date.UTC().Format(time.RFC3339Nano)
+}
+
+// failed records the error of a row on its result.
+func failed(result *models.ImportResult, err *apperrors.Error) {
+	result.Status, result.Reason, result.Details = models.ImportFailed, err.Message, err.Details
+}
+
+// importable records on the result an error of the creation of a row that does not abort the import: a taken
+// username (a deleted Complejo keeps its username) skips the row, and the other client errors fail it. It
+// reports whether the error was recorded.
+func importable(result *models.ImportResult, err error) bool {
+	if errors.Is(err, ErrUsernameTaken) {
+		result.Status, result.Reason = models.ImportSkipped, "a Complejo with this username already exists"
+		return true
+	}
+	appErr := apperrors.From(err)
+	if appErr.Status >= http.StatusInternalServerError {
+		return false
+	}
+	failed(result, appErr)
+	return true
+}
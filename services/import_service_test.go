@@ -0,0 +1,133 @@
+// import_service_test.go
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/validation"
+)
+
+// newTestImportService creates an ImportService on the fakes. The fakes have no Insert, so a dry run creating a
+// record panics.
+func newTestImportService(complejos *fakeComplejos, events *fakeEvents) *ImportService {
+	clk := clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	return NewImportService(newTestComplejoService(complejos),
+		NewEventService(events, complejos, nil, fakeTx{}, &fakeOutbox{}, nil, nil, nil, nil, clk))
+}
+
+// wantResults compares the status, reason and invalid fields of the results with the expected ones.
+func wantResults(t *testing.T, report *models.ImportReport, want []models.ImportResult, fields [][]string) {
+	t.Helper()
+	if len(report.Results) != len(want) {
+		t.Fatalf("results = %+v, want %d", report.Results, len(want))
+	}
+	for i, result := range report.Results {
+		if result.Row != want[i].Row || result.Key != want[i].Key || result.Status != want[i].Status || result.ID != "" {
+			t.Errorf("result %d = %+v, want %+v", i, result, want[i])
+		}
+		if want[i].Reason != "" && result.Reason != want[i].Reason {
+			t.Errorf("result %d reason = %q, want %q", i, result.Reason, want[i].Reason)
+		}
+		var got []string
+		if details, ok := result.Details.([]validation.FieldError); ok {
+			for _, detail := range details {
+				got = append(got, detail.Field)
+			}
+		}
+		if len(got) != len(fields[i]) {
+			t.Errorf("result %d invalid fields = %v, want %v", i, got, fields[i])
+			continue
+		}
+		for j := range got {
+			if got[j] != fields[i][j] {
+				t.Errorf("result %d invalid fields = %v, want %v", i, got, fields[i])
+			}
+		}
+	}
+}
+
+func TestImportComplejosDryRun(t *testing.T) {
+	complejos := newFakeComplejos(models.Complejo{ID: "c1", Username: "maria", Role: "user"})
+	svc := newTestImportService(complejos, newFakeEvents())
+	data := "username,password,role,gender,weight_kg\n" +
+		"ana,secret,user,female,60\n" +
+		"maria,secret,user,female,55\n" +
+		"ana,other,user,female,61\n" +
+		"bob,secret,user,male,heavy\n" +
+		"carl,secret,user,robot,80\n"
+
+	report, err := svc.Import(context.Background(), models.ImportComplejos, models.ImportCSV, []byte(data), true, "admin")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if !report.DryRun || report.Kind != models.ImportComplejos {
+		t.Errorf("report = %+v, want a dry run of complejos", report)
+	}
+	wantResults(t, report, []models.ImportResult{
+		{Row: 2, Key: "ana", Status: models.ImportCreated},
+		{Row: 3, Key: "maria", Status: models.ImportSkipped, Reason: "a Complejo with this username already exists"},
+		{Row: 4, Key: "ana", Status: models.ImportSkipped, Reason: "duplicates row 2"},
+		{Row: 5, Key: "bob", Status: models.ImportFailed},
+		{Row: 6, Key: "carl", Status: models.ImportFailed},
+	}, [][]string{nil, nil, nil, {"weight_kg"}, {"gender"}})
+	want := map[string]int{models.ImportCreated: 1, models.ImportSkipped: 2, models.ImportFailed: 2}
+	for status, n := range want {
+		if report.Summary[status] != n {
+			t.Errorf("summary = %v, want %v", report.Summary, want)
+		}
+	}
+	if stored, _ := complejos.FindAll(context.Background()); len(stored) != 1 {
+		t.Errorf("stored %d Complejos, want the dry run to create none", len(stored))
+	}
+}
+
+func TestImportEventsDryRun(t *testing.T) {
+	date := time.Date(2030, 5, 1, 9, 0, 0, 0, time.UTC)
+	svc := newTestImportService(newFakeComplejos(), newFakeEvents(models.Event{ID: "e1", Title: "Ride", Date: date}))
+	data := `[
+		{"title":" ride ","description":"Again","date":"2030-05-01T09:00:00Z","location":"Park"},
+		{"title":"Run","description":"Easy","date":"2030-06-01T09:00:00Z","location":"Park"},
+		{"title":"Swim","description":"Pool","date":"2030-06-02T09:00:00Z"},
+		{"title":"RUN","description":"Again","date":"2030-06-01T11:00:00+02:00","location":"Park"}
+	]`
+
+	report, err := svc.Import(context.Background(), models.ImportEvents, models.ImportJSON, []byte(data), true, "admin")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	wantResults(t, report, []models.ImportResult{
+		{Row: 1, Key: "ride 2030-05-01T09:00:00Z", Status: models.ImportSkipped, Reason: "an event with this title and date already exists"},
+		{Row: 2, Key: "Run 2030-06-01T09:00:00Z", Status: models.ImportCreated},
+		{Row: 3, Key: "Swim 2030-06-02T09:00:00Z", Status: models.ImportFailed},
+		{Row: 4, Key: "RUN 2030-06-01T09:00:00Z", Status: models.ImportSkipped, Reason: "duplicates row 2"},
+	}, [][]string{nil, nil, {"location"}, nil})
+}
+
+func TestImportRefusesUnreadableFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   string
+		format string
+		data   string
+		status int
+	}{
+		{"unknown CSV column", models.ImportComplejos, models.ImportCSV, "username,nickname\nana,a\n", http.StatusUnprocessableEntity},
+		{"CSV without header", models.ImportEvents, models.ImportCSV, "", http.StatusBadRequest},
+		{"JSON object", models.ImportEvents, models.ImportJSON, `{"title":"Run"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestImportService(newFakeComplejos(), newFakeEvents())
+			_, err := svc.Import(context.Background(), tt.kind, tt.format, []byte(tt.data), true, "admin")
+			if err == nil || apperrors.From(err).Status != tt.status {
+				t.Errorf("Import error = %v, want status %d", err, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+// inventory_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// InventoryService manages the club equipment, lends it to Complejos and reminds them of overdue loans.
+type InventoryService struct {
+	repo      repository.InventoryRepository
+	complejos repository.ComplejoRepository
+	events    repository.EventRepository
+	tx        repository.Transactor
+	outbox    repository.OutboxRepository
+	clock     clock.Clock
+
+	RemindEvery time.Duration // Minimum time between two reminders of the same overdue loan
+}
+
+// NewInventoryService creates an InventoryService reminding each overdue loan once a day.
+func NewInventoryService(repo repository.InventoryRepository, complejos repository.ComplejoRepository, events repository.EventRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, clk clock.Clock) *InventoryService {
+	return &InventoryService{
+		repo:        repo,
+		complejos:   complejos,
+		events:      events,
+		tx:          tx,
+		outbox:      outboxRepo,
+		clock:       clk,
+		RemindEvery: 24 * time.Hour,
+	}
+}
+
+// Items returns every InventoryItem with the units currently on loan and available.
+func (s *InventoryService) Items(ctx context.Context) ([]models.ItemAvailability, error) {
+	return s.availability(ctx, func(models.Loan) bool { return true })
+}
+
+// EventEquipment returns every InventoryItem with the units available on the date of the Event:
+// the loans due on or after that date, and the overdue ones, are counted as still on loan.
+func (s *InventoryService) EventEquipment(ctx context.Context, eventID string) ([]models.ItemAvailability, error) {
+	event, err := s.events.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+
+	now := s.clock.Now()
+	return s.availability(ctx, func(loan models.Loan) bool {
+		return !loan.DueAt.Before(event.Date) || loan.IsOverdue(now)
+	})
+}
+
+// CreateItem adds an InventoryItem.
+func (s *InventoryService) CreateItem(ctx context.Context, input models.InventoryItemInput) (*models.InventoryItem, error) {
+	now := s.clock.Now()
+	item := &models.InventoryItem{ID: uuid.NewString(), CreatedAt: now}
+	applyItemInput(item, input, now)
+
+	if err := s.repo.InsertItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateItem replaces the details of an InventoryItem.
+// ErrItemOnLoan is returned when the new quantity is lower than the units on loan.
+func (s *InventoryService) UpdateItem(ctx context.Context, id string, input models.InventoryItemInput) (*models.InventoryItem, error) {
+	item, err := s.repo.FindItemByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrItemNotFound)
+	}
+
+	onLoan, err := s.onLoan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.Quantity < onLoan {
+		return nil, ErrItemOnLoan.WithDetails(map[string]interface{}{"on_loan": onLoan})
+	}
+
+	applyItemInput(item, input, s.clock.Now())
+	found, err := s.repo.ReplaceItem(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrItemNotFound
+	}
+	return item, nil
+}
+
+// DeleteItem removes an InventoryItem. ErrItemOnLoan is returned while some of its units are on loan.
+func (s *InventoryService) DeleteItem(ctx context.Context, id string) error {
+	onLoan, err := s.onLoan(ctx, id)
+	if err != nil {
+		return err
+	}
+	if onLoan > 0 {
+		return ErrItemOnLoan.WithDetails(map[string]interface{}{"on_loan": onLoan})
+	}
+
+	found, err := s.repo.DeleteItem(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+// Loans returns the loans matching the filter, most recently lent first.
+func (s *InventoryService) Loans(ctx context.Context, filter models.LoanFilter) ([]models.Loan, error) {
+	return s.repo.FindLoans(ctx, filter, s.clock.Now())
+}
+
+// Lend lends units of an InventoryItem (one by default) to a Complejo until the due date.
+// ErrItemUnavailable is returned when fewer units are available.
+func (s *InventoryService) Lend(ctx context.Context, input models.LoanInput, lenderID string) (*models.Loan, error) {
+	if input.Quantity == 0 {
+		input.Quantity = 1
+	}
+
+	var loan *models.Loan
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		item, err := s.repo.FindItemByID(ctx, input.ItemID)
+		if err != nil {
+			return notFound(err, ErrItemNotFound)
+		}
+		complejo, err := s.complejos.FindByID(ctx, input.ComplejoID)
+		if err != nil {
+			return notFound(err, ErrComplejoNotFound)
+		}
+
+		onLoan, err := s.onLoan(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+		if available := item.Quantity - onLoan; input.Quantity > available {
+			return ErrItemUnavailable.WithDetails(map[string]interface{}{"available": max(available, 0)})
+		}
+
+		loan = &models.Loan{
+			ID:         uuid.NewString(),
+			ItemID:     item.ID,
+			ComplejoID: complejo.ID,
+			Username:   complejo.Username,
+			Quantity:   input.Quantity,
+			LentBy:     lenderID,
+			LentAt:     s.clock.Now(),
+			DueAt:      input.DueAt,
+		}
+		return s.repo.InsertLoan(ctx, loan)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+// Return records the return of a lent item. ErrLoanReturned is returned when it was already returned.
+func (s *InventoryService) Return(ctx context.Context, loanID string) (*models.Loan, error) {
+	loan, err := s.repo.FindLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, notFound(err, ErrLoanNotFound)
+	}
+	if loan.ReturnedAt != nil {
+		return nil, ErrLoanReturned
+	}
+
+	now := s.clock.Now()
+	found, err := s.repo.MarkReturned(ctx, loanID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrLoanReturned
+	}
+	loan.ReturnedAt = &now
+	return loan, nil
+}
+
+// RemindOverdue announces a LoanOverdue event for every overdue loan not reminded within RemindEvery,
+// and returns how many reminders were sent.
+func (s *InventoryService) RemindOverdue(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	loans, err := s.repo.FindLoans(ctx, models.LoanFilter{Status: models.LoanOverdue}, now)
+	if err != nil {
+		return 0, err
+	}
+	if len(loans) == 0 {
+		return 0, nil
+	}
+
+	items, err := s.repo.FindItems(ctx)
+	if err != nil {
+		return 0, err
+	}
+	names := make(map[string]string, len(items))
+	for _, item := range items {
+		names[item.ID] = item.Name
+	}
+
+	reminded := 0
+	for _, loan := range loans {
+		if loan.RemindedAt != nil && now.Sub(*loan.RemindedAt) < s.RemindEvery {
+			continue
+		}
+
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.repo.MarkReminded(ctx, loan.ID, now); err != nil {
+				return err
+			}
+			return s.announce(ctx, bus.LoanOverdue{
+				LoanID:     loan.ID,
+				ItemID:     loan.ItemID,
+				ItemName:   names[loan.ItemID],
+				ComplejoID: loan.ComplejoID,
+				Username:   loan.Username,
+				Quantity:   loan.Quantity,
+				DueAt:      loan.DueAt,
+			})
+		})
+		if err != nil {
+			return reminded, fmt.Errorf("error reminding loan %s: %w", loan.ID, err)
+		}
+		reminded++
+	}
+	return reminded, nil
+}
+
+// availability returns every InventoryItem with the units of the open loans selected by counts.
+func (s *InventoryService) availability(ctx context.Context, counts func(models.Loan) bool) ([]models.ItemAvailability, error) {
+	items, err := s.repo.FindItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	loans, err := s.repo.FindLoans(ctx, models.LoanFilter{Status: models.LoanOpen}, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	onLoan := map[string]int{}
+	for _, loan := range loans {
+		if counts(loan) {
+			onLoan[loan.ItemID] += loan.Quantity
+		}
+	}
+
+	availability := make([]models.ItemAvailability, 0, len(items))
+	for _, item := range items {
+		availability = append(availability, models.ItemAvailability{
+			InventoryItem: item,
+			OnLoan:        onLoan[item.ID],
+			Available:     max(item.Quantity-onLoan[item.ID], 0),
+		})
+	}
+	return availability, nil
+}
+
+// onLoan returns the units of the InventoryItem currently on loan.
+func (s *InventoryService) onLoan(ctx context.Context, itemID string) (int, error) {
+	loans, err := s.repo.FindLoans(ctx, models.LoanFilter{Status: models.LoanOpen, ItemID: itemID}, s.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	units := 0
+	for _, loan := range loans {
+		units += loan.Quantity
+	}
+	return units, nil
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *InventoryService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
+
+// applyItemInput copies the input onto the item.
+func applyItemInput(item *models.InventoryItem, input models.InventoryItemInput, now time.Time) {
+	item.Name = input.Name
+	item.Category = input.Category
+	item.Description = input.Description
+	item.Quantity = input.Quantity
+	item.UpdatedAt = now
+}
@@ -0,0 +1,53 @@
+// journal_service.go
+package services
+
+import (
+	"context"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// Default and maximum number of journal entries listed at once.
+const (
+	DefaultJournalLimit = 50
+	MaxJournalLimit     = 500
+)
+
+// JournalService records and lists the envelopes of failed requests.
+type JournalService struct {
+	repo  repository.JournalRepository
+	clock clock.Clock
+}
+
+// NewJournalService creates a JournalService backed by the given repository and clock.
+func NewJournalService(repo repository.JournalRepository, clk clock.Clock) *JournalService {
+	return &JournalService{repo: repo, clock: clk}
+}
+
+// Record assigns an ID and timestamp to the entry and stores it.
+func (s *JournalService) Record(ctx context.Context, entry *models.JournalEntry) error {
+	entry.ID = uuid.NewString()
+	entry.OccurredAt = s.clock.Now()
+	return s.repo.Append(ctx, entry)
+}
+
+// Recent returns the most recent entries, at most limit (defaulted and capped).
+func (s *JournalService) Recent(ctx context.Context, limit int) ([]models.JournalEntry, error) {
+	if limit < 1 {
+		limit = DefaultJournalLimit
+	}
+	if limit > MaxJournalLimit {
+		limit = MaxJournalLimit
+	}
+	return s.repo.FindRecent(ctx, limit)
+}
+
+// Get returns the entry with the given ID.
+func (s *JournalService) Get(ctx context.Context, id string) (*models.JournalEntry, error) {
+	entry, err := s.repo.FindByID(ctx, id)
+	return entry, notFound(err, ErrJournalEntryNotFound)
+}
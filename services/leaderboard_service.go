@@ -0,0 +1,38 @@
+// leaderboard_service.go
+package services
+
+import (
+	"context"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// LeaderboardService ranks the Complejos by their lifts: the kilos of the bench press, squat, deadlift or their
+// total, or the Wilks or DOTS score of those kilos relative to bodyweight.
+type LeaderboardService struct {
+	repo repository.LeaderboardRepository
+}
+
+// NewLeaderboardService creates a LeaderboardService backed by the given repository.
+func NewLeaderboardService(repo repository.LeaderboardRepository) *LeaderboardService {
+	return &LeaderboardService{repo: repo}
+}
+
+// Leaderboard returns the requested page of the leaderboard with the place of the Complejo with the given ID
+// (none when empty or not ranked), and the number of Complejos ranked. Missing values are defaulted on the
+// query.
+func (s *LeaderboardService) Leaderboard(ctx context.Context, query *models.LeaderboardQuery, complejoID string) (*models.Leaderboard, int64, error) {
+	query.Normalize()
+	entries, me, total, err := s.repo.Rank(ctx, *query, complejoID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &models.Leaderboard{
+		Lift:    query.Lift,
+		Gender:  query.Gender,
+		Scoring: query.Scoring,
+		Entries: entries,
+		Me:      me,
+	}, total, nil
+}
@@ -0,0 +1,183 @@
+// lift_service.go
+package services
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/utils"
+	"los-complejos-backend/validation"
+
+	"github.com/google/uuid"
+)
+
+// LiftService keeps the lift history of the Complejos. The best weight of each lift stays denormalized on the
+// profile (bench, squad and dl), raised by the entries beating it.
+type LiftService struct {
+	complejos repository.ComplejoRepository
+	lifts     repository.LiftRepository
+	tx        repository.Transactor
+	outbox    repository.OutboxRepository
+	clock     clock.Clock
+	Location  *time.Location // Time zone of the months of the progress statistics
+}
+
+// NewLiftService creates a LiftService backed by the given repositories and clock.
+func NewLiftService(complejos repository.ComplejoRepository, lifts repository.LiftRepository, tx repository.Transactor, outbox repository.OutboxRepository, clk clock.Clock) *LiftService {
+	return &LiftService{complejos: complejos, lifts: lifts, tx: tx, outbox: outbox, clock: clk, Location: time.UTC}
+}
+
+// Record adds a lift to the history of the Complejo with the given ID, dated now unless the input is dated
+// earlier, with its estimated one-rep max when it has several repetitions and the bodyweight of the input (the weight on the profile by default). When it beats the best weight of the lift on the profile, the profile is raised to it and a
+// PRAchieved event announced, in the same transaction.
+func (s *LiftService) Record(ctx context.Context, complejoID string, input models.LiftEntryInput) (*models.LiftEntry, error) {
+	now := s.clock.Now()
+	date := now
+	if input.Date != nil {
+		if input.Date.After(now) {
+			return nil, apperrors.Validation("Validation failed", []validation.FieldError{
+				{Field: "date", Rule: "past", Message: "must not be in the future"},
+			})
+		}
+		date = *input.Date
+	}
+	reps := input.Reps
+	if reps < 1 {
+		reps = 1
+	}
+
+	entry := &models.LiftEntry{
+		ID:         uuid.NewString(),
+		ComplejoID: complejoID,
+		Lift:       input.Lift,
+		Weight:     input.Weight,
+		Reps:       reps,
+		E1RM:       utils.EstimateOneRepMax(input.Weight, reps).Estimate,
+		Date:       date,
+		CreatedAt:  now,
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		complejo, err := s.complejos.FindByID(ctx, complejoID)
+		if err != nil {
+			return notFound(err, ErrComplejoNotFound)
+		}
+		entry.Bodyweight = complejo.Weight
+		if input.Bodyweight != nil {
+			entry.Bodyweight = *input.Bodyweight
+		}
+
+		fields := map[string]interface{}{input.Lift: input.Weight}
+		records := personalRecords(complejo, fields)
+		entry.Record = len(records) > 0
+		if err := s.lifts.Insert(ctx, entry); err != nil {
+			return err
+		}
+		if !entry.Record {
+			return nil
+		}
+
+		found, err := s.complejos.UpdateByID(ctx, complejoID, "", fields)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrComplejoNotFound
+		}
+		for _, record := range records {
+			if err := s.announce(ctx, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// History returns the lift history of the Complejo with the given ID, of the lift of the query (of every lift
+// when empty), oldest first.
+func (s *LiftService) History(ctx context.Context, complejoID string, query models.LiftHistoryQuery) ([]models.LiftEntry, error) {
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	return s.lifts.FindByComplejo(ctx, complejoID, query.Lift)
+}
+
+// Progress returns the monthly trends of the lift history of the Complejo with the given ID over the months of
+// the query, up to the current one: the average bodyweight and the heaviest of each lift by month, and the
+// estimated total of the best of each lift so far (counting the months before the range too) once all three
+// are recorded.
+func (s *LiftService) Progress(ctx context.Context, complejoID string, query models.ProgressQuery) (*models.Progress, error) {
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	months := query.Months
+	if months < 1 {
+		months = models.DefaultProgressMonths
+	}
+	if months > models.MaxProgressMonths {
+		months = models.MaxProgressMonths
+	}
+
+	rows, err := s.lifts.MonthlyProgress(ctx, complejoID, s.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now().In(s.Location)
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, s.Location)
+	first := current.AddDate(0, 1-months, 0).Format("2006-01")
+
+	// best holds the heaviest bench, squat and deadlift so far.
+	var best [3]float64
+	raise := func(row models.LiftMonth) {
+		for i, weight := range []float64{row.Bench, row.Squad, row.DL} {
+			best[i] = max(best[i], weight)
+		}
+	}
+	i := 0
+	for ; i < len(rows) && rows[i].Month < first; i++ {
+		raise(rows[i])
+	}
+
+	progress := &models.Progress{Timezone: s.Location.String(), Months: make([]models.ProgressMonth, 0, months)}
+	for m := 0; m < months; m++ {
+		month := models.ProgressMonth{Month: current.AddDate(0, m+1-months, 0).Format("2006-01")}
+		if i < len(rows) && rows[i].Month == month.Month {
+			row := rows[i]
+			raise(row)
+			month.Bodyweight, month.Bench, month.Squad, month.DL = recorded(row.Bodyweight), recorded(row.Bench), recorded(row.Squad), recorded(row.DL)
+			i++
+		}
+		if best[0] > 0 && best[1] > 0 && best[2] > 0 {
+			total := best[0] + best[1] + best[2]
+			month.EstimatedTotal = &total
+		}
+		progress.Months = append(progress.Months, month)
+	}
+	return progress, nil
+}
+
+// recorded returns the aggregated value, or nil when nothing was recorded (0).
+func recorded(value float64) *float64 {
+	if value <= 0 {
+		return nil
+	}
+	return &value
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *LiftService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
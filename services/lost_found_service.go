@@ -0,0 +1,285 @@
+// lost_found_service.go
+package services
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/imaging"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// LostFoundService runs the lost-and-found board: members post lost and found items, claim them,
+// and admins decide the claims. Posts flagged by the content filter are held until an admin approves them,
+// and posts are removed once they expire.
+type LostFoundService struct {
+	repo      repository.LostFoundRepository
+	complejos repository.ComplejoRepository
+	tx        repository.Transactor
+	outbox    repository.OutboxRepository
+	moderator *ModerationService
+	images    *imaging.Pool
+	clock     clock.Clock
+
+	Expiry time.Duration // How long a post stays on the board
+}
+
+// NewLostFoundService creates a LostFoundService whose posts expire after 60 days.
+func NewLostFoundService(repo repository.LostFoundRepository, complejos repository.ComplejoRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, moderator *ModerationService, images *imaging.Pool, clk clock.Clock) *LostFoundService {
+	return &LostFoundService{
+		repo:      repo,
+		complejos: complejos,
+		tx:        tx,
+		outbox:    outboxRepo,
+		moderator: moderator,
+		images:    images,
+		clock:     clk,
+		Expiry:    60 * 24 * time.Hour,
+	}
+}
+
+// List returns the open posts (of the given kind when not empty), newest first, with their photos when asked for.
+func (s *LostFoundService) List(ctx context.Context, query models.LostItemQuery) ([]models.LostItem, error) {
+	items, err := s.repo.FindOpen(ctx, query.Kind, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if query.Include != "photo" {
+		for i := range items {
+			items[i].Photo = ""
+		}
+	}
+	return items, nil
+}
+
+// Post publishes a lost or found item on behalf of the Complejo, normalizing its photo. A post whose title or
+// description is flagged by the content filter is stored as held, hidden from the board until an admin approves it.
+func (s *LostFoundService) Post(ctx context.Context, input models.LostItemInput, posterID string) (*models.LostItem, error) {
+	poster, err := s.complejos.FindByID(ctx, posterID)
+	if err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	photo, err := processPhoto(ctx, s.images, input.Photo)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	item := &models.LostItem{
+		ID:          uuid.NewString(),
+		Kind:        input.Kind,
+		Title:       input.Title,
+		Description: input.Description,
+		Photo:       photo,
+		PostedBy:    poster.ID,
+		Username:    poster.Username,
+		Status:      models.LostItemOpen,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.Expiry),
+	}
+
+	reasons := s.moderator.Flagged(ctx, item.Title+"\n"+item.Description)
+	if reasons != nil {
+		item.Status = models.LostItemHeld
+	}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Insert(ctx, item); err != nil {
+			return err
+		}
+		if reasons == nil {
+			return nil
+		}
+		return s.moderator.Hold(ctx, models.HoldLostItem, item.ID, poster.ID, item.Title+"\n"+item.Description, "", reasons)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Delete removes a post and its claims. Only admins and the author of the post may remove it.
+func (s *LostFoundService) Delete(ctx context.Context, id, requesterID string, isAdmin bool) error {
+	item, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return notFound(err, ErrLostItemNotFound)
+	}
+	if !isAdmin && item.PostedBy != requesterID {
+		return ErrNotLostItemPoster
+	}
+
+	found, err := s.repo.DeleteByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrLostItemNotFound
+	}
+	return nil
+}
+
+// Claim records the Complejo's claim on an open post, to be decided by an admin.
+// Members cannot claim their own posts nor have two pending claims on the same post.
+func (s *LostFoundService) Claim(ctx context.Context, itemID string, input models.ClaimInput, claimantID string) (*models.Claim, error) {
+	item, err := s.openItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.PostedBy == claimantID {
+		return nil, ErrOwnLostItem
+	}
+
+	claims, err := s.repo.FindClaims(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	for _, claim := range claims {
+		if claim.ClaimantID == claimantID && claim.Status == models.ClaimPending {
+			return nil, ErrClaimPending
+		}
+	}
+
+	claimant, err := s.complejos.FindByID(ctx, claimantID)
+	if err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	claim := &models.Claim{
+		ID:         uuid.NewString(),
+		ItemID:     itemID,
+		ClaimantID: claimant.ID,
+		Username:   claimant.Username,
+		Message:    input.Message,
+		Status:     models.ClaimPending,
+		CreatedAt:  s.clock.Now(),
+	}
+	if err := s.repo.InsertClaim(ctx, claim); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// Claims returns the claims of a post, oldest first.
+func (s *LostFoundService) Claims(ctx context.Context, itemID string) ([]models.Claim, error) {
+	if _, err := s.repo.FindByID(ctx, itemID); err != nil {
+		return nil, notFound(err, ErrLostItemNotFound)
+	}
+	return s.repo.FindClaims(ctx, itemID)
+}
+
+// Decide approves or rejects a pending claim. Approving it resolves the post and rejects its other pending claims.
+// Each decision is announced (ClaimDecided) through the outbox in the same transaction.
+func (s *LostFoundService) Decide(ctx context.Context, itemID, claimID string, decision models.ClaimDecision, adminID string) (*models.Claim, error) {
+	item, err := s.openItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := s.repo.FindClaims(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	var claim *models.Claim
+	for i := range claims {
+		if claims[i].ID == claimID {
+			claim = &claims[i]
+		}
+	}
+	if claim == nil {
+		return nil, ErrClaimNotFound
+	}
+	if claim.Status != models.ClaimPending {
+		return nil, ErrClaimDecided
+	}
+
+	now := s.clock.Now()
+	claim.Status = decision.Status
+	claim.Note = decision.Note
+	claim.DecidedBy = adminID
+	claim.DecidedAt = &now
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		found, err := s.repo.DecideClaim(ctx, claim)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrClaimDecided
+		}
+		if err := s.announceDecision(ctx, item, *claim); err != nil {
+			return err
+		}
+		if claim.Status != models.ClaimApproved {
+			return nil
+		}
+
+		found, err = s.repo.Resolve(ctx, itemID, now)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrLostItemResolved
+		}
+		const note = "Another claim was approved"
+		if _, err := s.repo.RejectPending(ctx, itemID, adminID, note, now); err != nil {
+			return err
+		}
+		for _, other := range claims {
+			if other.ID == claim.ID || other.Status != models.ClaimPending {
+				continue
+			}
+			other.Status, other.Note = models.ClaimRejected, note
+			if err := s.announceDecision(ctx, item, other); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// PurgeExpired removes the posts that expired and returns how many were removed.
+func (s *LostFoundService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.PurgeExpired(ctx, s.clock.Now())
+}
+
+// openItem returns the post with the given ID, or ErrLostItemNotFound when it does not exist, expired or is held
+// for review, and ErrLostItemResolved when a claim on it was already approved.
+func (s *LostFoundService) openItem(ctx context.Context, id string) (*models.LostItem, error) {
+	item, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrLostItemNotFound)
+	}
+	if !item.ExpiresAt.After(s.clock.Now()) || item.Status == models.LostItemHeld {
+		return nil, ErrLostItemNotFound
+	}
+	if item.Status != models.LostItemOpen {
+		return nil, ErrLostItemResolved
+	}
+	return item, nil
+}
+
+// announceDecision records the ClaimDecided event of the claim in the outbox.
+func (s *LostFoundService) announceDecision(ctx context.Context, item *models.LostItem, claim models.Claim) error {
+	message, err := bus.Message(bus.ClaimDecided{
+		ClaimID:    claim.ID,
+		ItemID:     item.ID,
+		ItemTitle:  item.Title,
+		ClaimantID: claim.ClaimantID,
+		Username:   claim.Username,
+		Status:     claim.Status,
+		Note:       claim.Note,
+	}, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
@@ -0,0 +1,91 @@
+// media_service.go
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// MediaService serves the stored images by their ID alone, whatever they illustrate: the profile photos and
+// the album photos, for browsers and CDNs to cache them under a single URL scheme.
+type MediaService struct {
+	photos   repository.PhotoRepository
+	events   repository.EventRepository
+	objects  objectstore.Store
+	profiles objectstore.Store
+}
+
+// NewMediaService creates a MediaService reading the album photos from the object store and the profile photos
+// from the profile photo store.
+func NewMediaService(photos repository.PhotoRepository, events repository.EventRepository, objects, profiles objectstore.Store) *MediaService {
+	return &MediaService{photos: photos, events: events, objects: objects, profiles: profiles}
+}
+
+// Get returns the image with the given ID, or its size variant when size is set (the image itself until the
+// variant is made). Album photos are only returned to the viewers that may see them (see PhotoService.Album);
+// ErrPhotoNotFound is returned for the others and for unknown IDs.
+func (s *MediaService) Get(ctx context.Context, id, size, viewerID string, isAdmin bool) (*models.Media, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrPhotoNotFound
+	}
+
+	photo, err := s.photos.FindByID(ctx, id)
+	if err == nil {
+		return s.albumPhoto(ctx, photo, size, viewerID, isAdmin)
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	data, exact, err := sized(ctx, s.profiles, models.ProfilePhotoKey(id), size)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, ErrPhotoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	// A new profile photo gets a new ID, so only a variant not made yet changes
+	return newMedia(data, http.DetectContentType(data), false, exact), nil
+}
+
+// albumPhoto returns the file of the album photo, or of its size variant, when the viewer may see it.
+func (s *MediaService) albumPhoto(ctx context.Context, photo *models.EventPhoto, size, viewerID string, isAdmin bool) (*models.Media, error) {
+	event, err := s.events.FindByID(ctx, photo.EventID)
+	if err != nil {
+		return nil, notFound(err, ErrPhotoNotFound)
+	}
+	if !canSee(event, photo, viewerID, isAdmin) {
+		return nil, ErrPhotoNotFound
+	}
+
+	data, _, err := sized(ctx, s.objects, photo.Key, size)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, ErrPhotoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	// A published photo may still be removed, so it is never immutable
+	return newMedia(data, photo.ContentType, !photo.Published(), false), nil
+}
+
+// newMedia returns the image with its entity tag, the hash of its content.
+func newMedia(data []byte, contentType string, private, immutable bool) *models.Media {
+	sum := sha256.Sum256(data)
+	return &models.Media{
+		Data:        data,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+		Private:     private,
+		Immutable:   immutable,
+	}
+}
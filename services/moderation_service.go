@@ -0,0 +1,265 @@
+// moderation_service.go
+package services
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/locale"
+	"los-complejos-backend/models"
+	"los-complejos-backend/moderation"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// ModerationService screens user content with the content filter and lets admins review what it holds.
+// Content is never rejected when it is written: flagged usernames are kept until an admin rejects them,
+// and flagged lost-and-found posts stay hidden until an admin approves them. The users report the events and
+// profiles they find abusive, and admins dismiss those reports or act on them by removing the content.
+type ModerationService struct {
+	repo      repository.ModerationRepository
+	reports   repository.ContentReportRepository
+	complejos repository.ComplejoRepository
+	lostFound repository.LostFoundRepository
+	tx        repository.Transactor
+	outbox    repository.OutboxRepository
+	clock     clock.Clock
+
+	Filter moderation.Filter // Blocked words per locale and link-spam limit
+	// Deletes the reported Events acted on (set once the EventService exists)
+	Events *EventService
+	// Deletes the reported Complejos acted on (set once the ComplejoService exists)
+	Accounts *ComplejoService
+}
+
+// NewModerationService creates a ModerationService with the default Filter: no blocked words,
+// at most moderation.DefaultMaxLinks links.
+func NewModerationService(repo repository.ModerationRepository, reports repository.ContentReportRepository, complejos repository.ComplejoRepository, lostFound repository.LostFoundRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, clk clock.Clock) *ModerationService {
+	return &ModerationService{
+		repo:      repo,
+		reports:   reports,
+		complejos: complejos,
+		lostFound: lostFound,
+		tx:        tx,
+		outbox:    outboxRepo,
+		clock:     clk,
+		Filter:    moderation.NewFilter(nil, moderation.DefaultMaxLinks),
+	}
+}
+
+// Flagged returns the reasons the text would be held, using the word list of the request's locale.
+func (s *ModerationService) Flagged(ctx context.Context, text string) []string {
+	return s.Filter.Check(text, locale.FromContext(ctx).Locale)
+}
+
+// Hold stores a pending ContentHold of the text with the given reasons; call it inside the transaction
+// storing the content. previous is the username restored when a renaming is rejected.
+func (s *ModerationService) Hold(ctx context.Context, kind, targetID, authorID, text, previous string, reasons []string) error {
+	return s.repo.Insert(ctx, &models.ContentHold{
+		ID:        uuid.NewString(),
+		Kind:      kind,
+		TargetID:  targetID,
+		AuthorID:  authorID,
+		Text:      text,
+		Previous:  previous,
+		Locale:    locale.FromContext(ctx).Locale,
+		Reasons:   reasons,
+		Status:    models.HoldPending,
+		CreatedAt: s.clock.Now(),
+	})
+}
+
+// Holds returns the held content with the status of the query (pending unless it says otherwise), oldest first.
+func (s *ModerationService) Holds(ctx context.Context, query models.HoldQuery) ([]models.ContentHold, error) {
+	status := query.Status
+	if status == "" {
+		status = models.HoldPending
+	}
+	return s.repo.FindByStatus(ctx, status)
+}
+
+// Decide stores the admin's decision on held content and applies it, announcing it (HoldDecided) in the same
+// transaction. Approving a lost-and-found post publishes it, rejecting it removes it; rejecting a username
+// restores the previous one, or replaces it with a neutral one when the Complejo signed up with it.
+// ErrHoldDecided is returned when the content was already reviewed.
+func (s *ModerationService) Decide(ctx context.Context, id string, decision models.HoldDecision, adminID string) (*models.ContentHold, error) {
+	hold, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrHoldNotFound)
+	}
+	if hold.Status != models.HoldPending {
+		return nil, ErrHoldDecided
+	}
+
+	now := s.clock.Now()
+	hold.Status = decision.Status
+	hold.Note = decision.Note
+	hold.DecidedBy = adminID
+	hold.DecidedAt = &now
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		found, err := s.repo.Decide(ctx, hold)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrHoldDecided
+		}
+		if err := s.apply(ctx, hold); err != nil {
+			return err
+		}
+
+		message, err := bus.Message(bus.HoldDecided{
+			HoldID:   hold.ID,
+			Kind:     hold.Kind,
+			TargetID: hold.TargetID,
+			AuthorID: hold.AuthorID,
+			Status:   hold.Status,
+			Note:     hold.Note,
+		}, now)
+		if err != nil {
+			return err
+		}
+		return s.outbox.Enqueue(ctx, message)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// apply publishes or removes the held content according to the decision. Content removed or changed by its
+// author in the meantime is left alone.
+func (s *ModerationService) apply(ctx context.Context, hold *models.ContentHold) error {
+	switch hold.Kind {
+	case models.HoldLostItem:
+		if hold.Status == models.HoldApproved {
+			_, err := s.lostFound.Release(ctx, hold.TargetID)
+			return err
+		}
+		_, err := s.lostFound.DeleteByID(ctx, hold.TargetID)
+		return err
+
+	case models.HoldUsername:
+		if hold.Status == models.HoldApproved {
+			return nil
+		}
+		complejo, err := s.complejos.FindByID(ctx, hold.TargetID)
+		if err == repository.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if complejo.Username != hold.Text {
+			return nil
+		}
+
+		username := hold.Previous
+		if username == "" {
+			username = "member-" + hold.TargetID[:min(8, len(hold.TargetID))]
+		}
+		if _, err := s.complejos.UpdateByID(ctx, hold.TargetID, "", map[string]interface{}{"username": username}); err != nil {
+			return usernameTaken(err, username)
+		}
+	}
+	return nil
+}
+
+// Report stores a pending ContentReport of the live Event or Complejo by the reporter. A Complejo cannot report
+// itself (ErrReportSelf), nor report the same content again while its report is pending (ErrAlreadyReported).
+func (s *ModerationService) Report(ctx context.Context, input models.ContentReportInput, reporterID string) (*models.ContentReport, error) {
+	switch input.Kind {
+	case models.ReportEvent:
+		if _, err := s.Events.Get(ctx, input.TargetID); err != nil {
+			return nil, err
+		}
+	case models.ReportComplejo:
+		if input.TargetID == reporterID {
+			return nil, ErrReportSelf
+		}
+		if _, err := s.complejos.FindByID(ctx, input.TargetID); err != nil {
+			return nil, notFound(err, ErrComplejoNotFound)
+		}
+	}
+
+	report := &models.ContentReport{
+		ID:         uuid.NewString(),
+		Kind:       input.Kind,
+		TargetID:   input.TargetID,
+		ReporterID: reporterID,
+		Reason:     input.Reason,
+		Details:    input.Details,
+		Status:     models.ReportPending,
+		CreatedAt:  s.clock.Now(),
+	}
+	if err := s.reports.Insert(ctx, report); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrAlreadyReported
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+// Reports returns the requested page of the reports with the status and kind of the query (pending ones of
+// every kind unless it says otherwise), oldest first, and their total number. Missing values are defaulted on
+// the query.
+func (s *ModerationService) Reports(ctx context.Context, query *models.ContentReportQuery) ([]models.ContentReport, int64, error) {
+	query.Normalize()
+	return s.reports.Find(ctx, query.Status, query.Kind, query.Offset(), query.Limit)
+}
+
+// DecideReport stores the admin's decision on a report. Dismissing it leaves the content alone; acting on it
+// deletes the reported Event or Complejo and decides every pending report of that content the same way.
+// ErrReportDecided is returned when the report was already reviewed.
+func (s *ModerationService) DecideReport(ctx context.Context, id string, decision models.ContentReportDecision, adminID string) (*models.ContentReport, error) {
+	report, err := s.reports.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrReportNotFound)
+	}
+	if report.Status != models.ReportPending {
+		return nil, ErrReportDecided
+	}
+
+	now := s.clock.Now()
+	report.Status = decision.Status
+	report.Note = decision.Note
+	report.DecidedBy = adminID
+	report.DecidedAt = &now
+
+	if report.Status == models.ReportDismissed {
+		found, err := s.reports.Decide(ctx, report)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, ErrReportDecided
+		}
+		return report, nil
+	}
+
+	// The deletions run in their own transactions; content already removed is left alone
+	switch report.Kind {
+	case models.ReportEvent:
+		err = s.Events.Delete(ctx, report.TargetID, adminID, true)
+		if errors.Is(err, ErrEventNotFound) {
+			err = nil
+		}
+	case models.ReportComplejo:
+		err = s.Accounts.Delete(ctx, report.TargetID)
+		if errors.Is(err, ErrComplejoNotFound) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.reports.DecideByTarget(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
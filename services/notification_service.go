@@ -0,0 +1,158 @@
+// notification_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/mailer"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// EventEmail is the data of the email templates about an Event, rendered for each recipient.
+type EventEmail struct {
+	Username string    // Current username of the recipient
+	Title    string    // Title of the Event
+	Date     time.Time // Date of the Event, in the gym's time zone
+	Location string    // Location of the Event (empty in cancellations)
+
+	// Fields changed by an update
+	TitleChanged       bool
+	DateChanged        bool
+	LocationChanged    bool
+	DescriptionChanged bool
+}
+
+// NotificationService emails the Complejos going or maybe going to an upcoming Event when it is updated or
+// cancelled. It subscribes to the domain events; Complejos without an email address are not notified.
+type NotificationService struct {
+	events    repository.EventRepository
+	complejos repository.ComplejoRepository
+	mailer    mailer.Mailer
+	templates *mailer.Templates
+	clock     clock.Clock
+	logger    *slog.Logger
+
+	Location *time.Location // Time zone of the dates in the emails
+}
+
+// NewNotificationService creates a NotificationService sending the emails rendered from the templates with the
+// mailer. Dates are shown in UTC.
+func NewNotificationService(events repository.EventRepository, complejos repository.ComplejoRepository, m mailer.Mailer, templates *mailer.Templates, clk clock.Clock, logger *slog.Logger) *NotificationService {
+	return &NotificationService{
+		events:    events,
+		complejos: complejos,
+		mailer:    m,
+		templates: templates,
+		clock:     clk,
+		logger:    logger,
+		Location:  time.UTC,
+	}
+}
+
+// Handle emails the participants of the Events updated (EventUpdated) or deleted (EventDeleted); it ignores
+// the other domain events. It fails, so the domain event is published again, only when no email could be sent
+// (e.g. the SMTP server is down): the recipients whose email failed are otherwise logged, so that the others are
+// not emailed twice.
+func (s *NotificationService) Handle(ctx context.Context, event bus.Event) error {
+	switch e := event.(type) {
+	case *bus.EventUpdated:
+		return s.eventUpdated(ctx, e)
+	case *bus.EventDeleted:
+		return s.eventDeleted(ctx, e)
+	}
+	return nil
+}
+
+// eventUpdated notifies the changes of title, date, location or description of an upcoming Event; the
+// other changes, such as its capacity or image, are not worth an email.
+func (s *NotificationService) eventUpdated(ctx context.Context, updated *bus.EventUpdated) error {
+	_, title := updated.Changes["title"]
+	_, date := updated.Changes["date"]
+	_, location := updated.Changes["location"]
+	_, description := updated.Changes["description"]
+	if !title && !date && !location && !description {
+		return nil
+	}
+
+	event, err := s.events.FindByID(ctx, updated.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		// Deleted since: its cancellation is notified instead
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if event.IsPast(s.clock.Now()) {
+		return nil
+	}
+
+	return s.send(ctx, event.ComplejoIDs(models.RSVPGoing, models.RSVPMaybe), mailer.TemplateEventUpdated, EventEmail{
+		Title:              event.Title,
+		Date:               event.Date.In(s.Location),
+		Location:           event.Location,
+		TitleChanged:       title,
+		DateChanged:        date,
+		LocationChanged:    location,
+		DescriptionChanged: description,
+	})
+}
+
+// eventDeleted notifies the cancellation of an upcoming Event.
+func (s *NotificationService) eventDeleted(ctx context.Context, deleted *bus.EventDeleted) error {
+	if deleted.Date.Before(s.clock.Now()) {
+		return nil
+	}
+	return s.send(ctx, deleted.ParticipantIDs, mailer.TemplateEventCancelled, EventEmail{
+		Title: deleted.Title,
+		Date:  deleted.Date.In(s.Location),
+	})
+}
+
+// send emails the Complejos with the given IDs, in their locale, rendering the template with the data and
+// their username.
+func (s *NotificationService) send(ctx context.Context, complejoIDs []string, template string, data EventEmail) error {
+	sent, failed := 0, 0
+	var lastErr error
+	for _, id := range complejoIDs {
+		ok, err := s.email(ctx, id, template, data)
+		if err != nil {
+			s.logger.Warn("notification email not sent", "template", template, "complejo_id", id, "error", err)
+			failed, lastErr = failed+1, err
+		} else if ok {
+			sent++
+		}
+	}
+
+	if failed > 0 && sent == 0 {
+		return lastErr
+	}
+	return nil
+}
+
+// email emails the Complejo with the given ID and reports whether it was emailed: deleted Complejos and
+// Complejos without an email address are skipped.
+func (s *NotificationService) email(ctx context.Context, complejoID, template string, data EventEmail) (bool, error) {
+	complejo, err := s.complejos.FindByID(ctx, complejoID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if complejo.Email == "" {
+		return false, nil
+	}
+
+	data.Username = complejo.Username
+	message, err := s.templates.Render(template, complejo.Locale, complejo.Email, data)
+	if err != nil {
+		return false, err
+	}
+	return true, s.mailer.Send(ctx, message)
+}
@@ -0,0 +1,252 @@
+// nutrition_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/utils"
+	"los-complejos-backend/validation"
+
+	"github.com/google/uuid"
+)
+
+// dayLayout is the layout of the days of the nutrition logs.
+const dayLayout = "2006-01-02"
+
+// NutritionService keeps the daily nutrition logs of the Complejos and calculates their targets. Logs are
+// private: only their author can read, replace or remove them.
+type NutritionService struct {
+	repo      repository.NutritionRepository
+	complejos repository.ComplejoRepository
+	clock     clock.Clock
+	Location  *time.Location // Time zone of the current day and of the weeks and months of the summaries
+}
+
+// NewNutritionService creates a NutritionService backed by the given repositories and clock.
+func NewNutritionService(repo repository.NutritionRepository, complejos repository.ComplejoRepository, clk clock.Clock) *NutritionService {
+	return &NutritionService{repo: repo, complejos: complejos, clock: clk, Location: time.UTC}
+}
+
+// List returns the nutrition logs of the Complejo with the given ID over the days of the query, by default the 30
+// days up to today, oldest first.
+func (s *NutritionService) List(ctx context.Context, complejoID string, query models.NutritionQuery) ([]models.NutritionLog, error) {
+	to := query.To
+	if to == "" {
+		to = s.today()
+	}
+	from := query.From
+	if from == "" {
+		last, _ := time.Parse(dayLayout, to)
+		from = last.AddDate(0, 0, 1-models.DefaultNutritionDays).Format(dayLayout)
+	}
+	if from > to {
+		return nil, apperrors.Validation("Validation failed", []validation.FieldError{
+			{Field: "from", Rule: "ltefield", Message: "must not be after to"},
+		})
+	}
+	return s.repo.FindByComplejo(ctx, complejoID, from, to)
+}
+
+// Get returns the nutrition log with the given ID, when the Complejo is its author.
+func (s *NutritionService) Get(ctx context.Context, id, complejoID string) (*models.NutritionLog, error) {
+	return s.owned(ctx, id, complejoID)
+}
+
+// Log records what the Complejo with the given ID ate over a day, today unless the input is dated earlier.
+// ErrNutritionDayLogged is returned when the day already has a log.
+func (s *NutritionService) Log(ctx context.Context, complejoID string, input models.NutritionInput) (*models.NutritionLog, error) {
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	now := s.clock.Now()
+	log := &models.NutritionLog{ID: uuid.NewString(), ComplejoID: complejoID, Date: s.today(), CreatedAt: now}
+	if err := s.apply(log, input, now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Insert(ctx, log); err != nil {
+		return nil, dayLogged(err, log.Date)
+	}
+	return log, nil
+}
+
+// Update replaces a nutrition log of the Complejo, and its day when the input has one. ErrNutritionDayLogged is
+// returned when the new day already has another log.
+func (s *NutritionService) Update(ctx context.Context, id, complejoID string, input models.NutritionInput) (*models.NutritionLog, error) {
+	log, err := s.owned(ctx, id, complejoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(log, input, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	found, err := s.repo.Replace(ctx, log)
+	if err != nil {
+		return nil, dayLogged(err, log.Date)
+	}
+	if !found {
+		return nil, ErrNutritionLogNotFound
+	}
+	return log, nil
+}
+
+// Delete removes a nutrition log of the Complejo.
+func (s *NutritionService) Delete(ctx context.Context, id, complejoID string) error {
+	if _, err := s.owned(ctx, id, complejoID); err != nil {
+		return err
+	}
+
+	found, err := s.repo.DeleteByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNutritionLogNotFound
+	}
+	return nil
+}
+
+// Targets returns the daily targets of the Complejo with the given ID, derived from the weight and goal of its
+// profile. ErrBodyweightUnknown is returned when its weight is not set.
+func (s *NutritionService) Targets(ctx context.Context, complejoID string) (*models.NutritionTargets, error) {
+	complejo, err := s.complejos.FindByID(ctx, complejoID)
+	if err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	targets := utils.CalcNutritionTargets(complejo.Weight, complejo.Goal)
+	if targets == nil {
+		return nil, ErrBodyweightUnknown
+	}
+	return targets, nil
+}
+
+// Summary sums the nutrition logs of the Complejo with the given ID by week (starting on Monday) or by month,
+// over the periods of the query up to the current one, with the daily average of the days logged and its
+// targets when its weight is known.
+func (s *NutritionService) Summary(ctx context.Context, complejoID string, query models.NutritionSummaryQuery) (*models.NutritionSummary, error) {
+	complejo, err := s.complejos.FindByID(ctx, complejoID)
+	if err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	period := query.Period
+	if period == "" {
+		period = models.PeriodWeek
+	}
+	count := query.Periods
+	if count < 1 {
+		count = models.DefaultNutritionPeriods[period]
+	}
+	count = min(count, models.MaxNutritionPeriods[period])
+
+	now := s.clock.Now().In(s.Location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	// The periods are built on UTC days, like the days of the logs
+	next := func(start time.Time, n int) time.Time { return start.AddDate(0, 0, 7*n) }
+	current := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	if period == models.PeriodMonth {
+		next = func(start time.Time, n int) time.Time { return start.AddDate(0, n, 0) }
+		current = today.AddDate(0, 0, 1-today.Day())
+	}
+	first := next(current, 1-count)
+
+	summary := &models.NutritionSummary{
+		Period:   period,
+		Timezone: s.Location.String(),
+		Targets:  utils.CalcNutritionTargets(complejo.Weight, complejo.Goal),
+		Periods:  make([]models.NutritionPeriod, 0, count),
+	}
+	for i := 0; i < count; i++ {
+		start := next(first, i)
+		summary.Periods = append(summary.Periods, models.NutritionPeriod{
+			Start: start.Format(dayLayout),
+			End:   next(start, 1).AddDate(0, 0, -1).Format(dayLayout),
+		})
+	}
+
+	logs, err := s.repo.FindByComplejo(ctx, complejoID, summary.Periods[0].Start, summary.Periods[count-1].End)
+	if err != nil {
+		return nil, err
+	}
+	p := 0
+	for _, log := range logs {
+		for log.Date > summary.Periods[p].End {
+			p++
+		}
+		total := &summary.Periods[p].Total
+		total.Calories += float64(log.Calories)
+		total.Protein += log.Protein
+		total.Carbs += log.Carbs
+		total.Fat += log.Fat
+		summary.Periods[p].DaysLogged++
+	}
+	for i := range summary.Periods {
+		logged := &summary.Periods[i]
+		if logged.DaysLogged == 0 {
+			continue
+		}
+		days := float64(logged.DaysLogged)
+		logged.DailyAverage = &models.Macros{
+			Calories: math.Round(logged.Total.Calories/days*10) / 10,
+			Protein:  math.Round(logged.Total.Protein/days*10) / 10,
+			Carbs:    math.Round(logged.Total.Carbs/days*10) / 10,
+			Fat:      math.Round(logged.Total.Fat/days*10) / 10,
+		}
+	}
+	return summary, nil
+}
+
+// today returns the current day in the time zone of the service ("2006-01-02").
+func (s *NutritionService) today() string {
+	return s.clock.Now().In(s.Location).Format(dayLayout)
+}
+
+// owned returns the nutrition log with the given ID, or ErrNotNutritionLogOwner when the Complejo is not its
+// author.
+func (s *NutritionService) owned(ctx context.Context, id, complejoID string) (*models.NutritionLog, error) {
+	log, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrNutritionLogNotFound)
+	}
+	if log.ComplejoID != complejoID {
+		return nil, ErrNotNutritionLogOwner
+	}
+	return log, nil
+}
+
+// apply copies the input onto the nutrition log, refusing a day in the future.
+func (s *NutritionService) apply(log *models.NutritionLog, input models.NutritionInput, now time.Time) error {
+	if input.Date != "" {
+		if input.Date > s.today() {
+			return apperrors.Validation("Validation failed", []validation.FieldError{
+				{Field: "date", Rule: "past", Message: "must not be in the future"},
+			})
+		}
+		log.Date = input.Date
+	}
+	log.Calories = input.Calories
+	log.Protein = input.Protein
+	log.Carbs = input.Carbs
+	log.Fat = input.Fat
+	log.Notes = input.Notes
+	log.UpdatedAt = now
+	return nil
+}
+
+// dayLogged replaces repository.ErrDuplicate with ErrNutritionDayLogged naming the day, and returns other errors
+// unchanged.
+func dayLogged(err error, day string) error {
+	if errors.Is(err, repository.ErrDuplicate) {
+		return ErrNutritionDayLogged.WithDetails(map[string]interface{}{"date": day})
+	}
+	return err
+}
@@ -0,0 +1,193 @@
+// offboarding_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/export"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/repository"
+)
+
+// OffboardingService lets a club leaving the platform take its data and then removes it. The data is exported
+// in the documented format of the export package. The purge is guarded: an admin requests it and receives a
+// confirmation code once, the data must be exported after the request, and the purge can only be confirmed with
+// the code once the cooling-off delay elapsed; until then any admin can cancel it.
+type OffboardingService struct {
+	complejos   repository.ComplejoRepository
+	events      repository.EventRepository
+	offboarding repository.OffboardingRepository
+	objects     objectstore.Store
+	clock       clock.Clock
+	logger      *slog.Logger
+
+	Club    string        // Name of the club in the exports
+	Delay   time.Duration // Cooling-off delay between the request of the purge and its earliest confirmation
+	OnPurge func()        // Called once the data is removed, to drop what is cached of it (nil when nothing is)
+}
+
+// NewOffboardingService creates an OffboardingService with a 72-hour cooling-off delay.
+func NewOffboardingService(complejos repository.ComplejoRepository, events repository.EventRepository, offboarding repository.OffboardingRepository, objects objectstore.Store, clk clock.Clock, logger *slog.Logger) *OffboardingService {
+	return &OffboardingService{
+		complejos:   complejos,
+		events:      events,
+		offboarding: offboarding,
+		objects:     objects,
+		clock:       clk,
+		logger:      logger,
+		Delay:       72 * time.Hour,
+	}
+}
+
+// Export returns the full export of the data of the club; deleted Complejos and Events waiting to be purged are
+// left out. During an offboarding the export is recorded, which
+// allows confirming the purge.
+func (s *OffboardingService) Export(ctx context.Context) (*export.Document, error) {
+	now := s.clock.Now()
+	complejos, err := s.complejos.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.events.FindAll(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	doc := export.Build(s.Club, now, complejos, events)
+
+	offboarding, err := s.pending(ctx)
+	if err == nil {
+		offboarding.ExportedAt = &now
+		err = s.offboarding.Save(ctx, offboarding)
+	}
+	if err != nil && !errors.Is(err, ErrOffboardingNotFound) {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Status returns the offboarding of the club.
+func (s *OffboardingService) Status(ctx context.Context) (*models.Offboarding, error) {
+	offboarding, err := s.offboarding.Find(ctx)
+	if err != nil {
+		return nil, notFound(err, ErrOffboardingNotFound)
+	}
+	return offboarding, nil
+}
+
+// Request starts the offboarding of the club on behalf of the admin. The returned offboarding holds the
+// confirmation code, which is not stored and cannot be shown again.
+func (s *OffboardingService) Request(ctx context.Context, adminID string) (*models.Offboarding, error) {
+	if _, err := s.pending(ctx); err == nil {
+		return nil, ErrOffboardingPending
+	} else if !errors.Is(err, ErrOffboardingNotFound) {
+		return nil, err
+	}
+
+	secret := make([]byte, 10)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	code := base32.StdEncoding.EncodeToString(secret)
+	code = code[0:4] + "-" + code[4:8] + "-" + code[8:12] + "-" + code[12:16]
+
+	now := s.clock.Now()
+	offboarding := &models.Offboarding{
+		Status:      models.OffboardingPending,
+		RequestedBy: adminID,
+		RequestedAt: now,
+		PurgeAfter:  now.Add(s.Delay),
+		CodeHash:    hashConfirmationCode(code),
+	}
+	if err := s.offboarding.Save(ctx, offboarding); err != nil {
+		return nil, err
+	}
+	s.logger.Warn("offboarding requested", "admin_id", adminID, "purge_after", offboarding.PurgeAfter)
+
+	offboarding.Code = code
+	return offboarding, nil
+}
+
+// Cancel cancels the pending offboarding of the club.
+func (s *OffboardingService) Cancel(ctx context.Context, adminID string) error {
+	if _, err := s.pending(ctx); err != nil {
+		return err
+	}
+	if _, err := s.offboarding.Delete(ctx); err != nil {
+		return err
+	}
+	s.logger.Warn("offboarding cancelled", "admin_id", adminID)
+	return nil
+}
+
+// Confirm purges every data of the club, including the stored files, on behalf of the admin. Only the
+// offboarding itself is kept, marked as purged with the number of records removed.
+func (s *OffboardingService) Confirm(ctx context.Context, adminID string, input models.OffboardingConfirmation) (*models.Offboarding, error) {
+	offboarding, err := s.pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashConfirmationCode(input.Code)), []byte(offboarding.CodeHash)) != 1 {
+		return nil, ErrInvalidConfirmationCode
+	}
+	if offboarding.ExportedAt == nil {
+		return nil, ErrOffboardingNotExported
+	}
+	now := s.clock.Now()
+	if now.Before(offboarding.PurgeAfter) {
+		return nil, ErrOffboardingCoolingOff.WithDetails(map[string]interface{}{"purge_after": offboarding.PurgeAfter})
+	}
+
+	s.logger.Warn("purging the data of the club", "admin_id", adminID)
+	removed, err := s.offboarding.Purge(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.objects.Clear(ctx); err != nil {
+		return nil, err
+	}
+	if s.OnPurge != nil {
+		s.OnPurge()
+	}
+
+	offboarding.Status = models.OffboardingPurged
+	offboarding.PurgedBy = adminID
+	offboarding.PurgedAt = &now
+	if err := s.offboarding.Save(ctx, offboarding); err != nil {
+		return nil, err
+	}
+	s.logger.Warn("purged the data of the club", "admin_id", adminID, "removed", removed)
+
+	offboarding.Removed = removed
+	return offboarding, nil
+}
+
+// pending returns the pending offboarding of the club, or ErrOffboardingNotFound.
+func (s *OffboardingService) pending(ctx context.Context) (*models.Offboarding, error) {
+	offboarding, err := s.offboarding.Find(ctx)
+	if err != nil {
+		return nil, notFound(err, ErrOffboardingNotFound)
+	}
+	if offboarding.Status != models.OffboardingPending {
+		return nil, ErrOffboardingNotFound
+	}
+	return offboarding, nil
+}
+
+// hashConfirmationCode returns the hex SHA-256 of a confirmation code, as stored. Codes are compared without
+// case, spaces or dashes.
+func hashConfirmationCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToUpper(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,119 @@
+// offboarding_service_test.go
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+)
+
+// newTestOffboardingService creates an OffboardingService on the fakes, storing the files in objects.
+func newTestOffboardingService(offboarding *fakeOffboarding, objects objectstore.Store, clk clock.Clock) *OffboardingService {
+	complejos := newFakeComplejos(models.Complejo{ID: "c1", Username: "maria", Role: "user"})
+	events := newFakeEvents(models.Event{ID: "e1", Title: "Ride"})
+	return NewOffboardingService(complejos, events, offboarding, objects, clk, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestOffboardingPurge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+	repo := &fakeOffboarding{removed: map[string]int64{"complejos": 1, "events": 1}}
+	objects := objectstore.NewDir(t.TempDir())
+	if err := objects.Put(ctx, "events/e1/photo.jpg", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	svc := newTestOffboardingService(repo, objects, clk)
+	purged := 0
+	svc.OnPurge = func() { purged++ }
+
+	requested, err := svc.Request(ctx, "admin")
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if !regexp.MustCompile(`^[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}$`).MatchString(requested.Code) {
+		t.Errorf("code = %q, want four groups of four base32 characters", requested.Code)
+	}
+	if !requested.PurgeAfter.Equal(now.Add(72*time.Hour)) || repo.offboarding.Code != "" || repo.offboarding.CodeHash == "" {
+		t.Errorf("stored offboarding = %+v, want purgeable after 72h with the hash of the code only", repo.offboarding)
+	}
+	if _, err := svc.Request(ctx, "admin"); !errors.Is(err, ErrOffboardingPending) {
+		t.Errorf("second Request error = %v, want ErrOffboardingPending", err)
+	}
+
+	if _, err := svc.Confirm(ctx, "admin", models.OffboardingConfirmation{Code: "AAAA-AAAA-AAAA-AAAA"}); !errors.Is(err, ErrInvalidConfirmationCode) {
+		t.Errorf("Confirm with a wrong code: error = %v, want ErrInvalidConfirmationCode", err)
+	}
+	code := models.OffboardingConfirmation{Code: strings.ToLower(strings.ReplaceAll(requested.Code, "-", " "))}
+	if _, err := svc.Confirm(ctx, "admin", code); !errors.Is(err, ErrOffboardingNotExported) {
+		t.Errorf("Confirm before the export: error = %v, want ErrOffboardingNotExported", err)
+	}
+
+	doc, err := svc.Export(ctx)
+	if err != nil || doc == nil {
+		t.Fatalf("Export = %v, %v", doc, err)
+	}
+	if repo.offboarding.ExportedAt == nil {
+		t.Error("the export was not recorded on the offboarding")
+	}
+	clk.Advance(71 * time.Hour)
+	if _, err := svc.Confirm(ctx, "admin", code); !errors.Is(err, ErrOffboardingCoolingOff) {
+		t.Errorf("Confirm before the delay: error = %v, want ErrOffboardingCoolingOff", err)
+	}
+	if repo.purges != 0 || purged != 0 {
+		t.Fatalf("purged %d times (OnPurge %d) before the confirmation was accepted", repo.purges, purged)
+	}
+
+	clk.Advance(time.Hour)
+	offboarding, err := svc.Confirm(ctx, "admin", code)
+	if err != nil {
+		t.Fatalf("Confirm: %v", err)
+	}
+	if offboarding.Status != models.OffboardingPurged || offboarding.PurgedBy != "admin" || offboarding.Removed["complejos"] != 1 {
+		t.Errorf("offboarding = %+v, want purged by admin with the removed counts", offboarding)
+	}
+	if repo.purges != 1 || purged != 1 || repo.offboarding.Status != models.OffboardingPurged {
+		t.Errorf("purges = %d, OnPurge calls = %d, stored = %+v, want one purge recorded", repo.purges, purged, repo.offboarding)
+	}
+	if _, err := objects.Get(ctx, "events/e1/photo.jpg"); !errors.Is(err, objectstore.ErrNotFound) {
+		t.Errorf("Get after the purge: error = %v, want the files removed", err)
+	}
+	if _, err := svc.Confirm(ctx, "admin", code); !errors.Is(err, ErrOffboardingNotFound) {
+		t.Errorf("second Confirm error = %v, want ErrOffboardingNotFound", err)
+	}
+}
+
+func TestOffboardingCancel(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeOffboarding{}
+	svc := newTestOffboardingService(repo, objectstore.NewDir(t.TempDir()), clock.NewFake(time.Now()))
+
+	if err := svc.Cancel(ctx, "admin"); !errors.Is(err, ErrOffboardingNotFound) {
+		t.Errorf("Cancel without offboarding: error = %v, want ErrOffboardingNotFound", err)
+	}
+	requested, err := svc.Request(ctx, "admin")
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if err := svc.Cancel(ctx, "other-admin"); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if _, err := svc.Status(ctx); !errors.Is(err, ErrOffboardingNotFound) {
+		t.Errorf("Status after Cancel: error = %v, want ErrOffboardingNotFound", err)
+	}
+	if _, err := svc.Confirm(ctx, "admin", models.OffboardingConfirmation{Code: requested.Code}); !errors.Is(err, ErrOffboardingNotFound) {
+		t.Errorf("Confirm after Cancel: error = %v, want ErrOffboardingNotFound", err)
+	}
+	if repo.purges != 0 {
+		t.Error("a cancelled offboarding was purged")
+	}
+}
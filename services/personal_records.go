@@ -0,0 +1,43 @@
+// personal_records.go
+package services
+
+import (
+	"los-complejos-backend/bus"
+	"los-complejos-backend/models"
+)
+
+// lifts lists the Complejo fields holding a lift record, in kilograms.
+var lifts = []string{"bench", "squad", "dl"}
+
+// hasLift reports whether the update touches a lift record.
+func hasLift(fields map[string]interface{}) bool {
+	for _, lift := range lifts {
+		if _, exists := fields[lift]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// personalRecords returns a PRAchieved event for every lift the update raises above the Complejo's current record.
+// The lifts must be float64 values (see models.ProfileUpdate); a zero current record means there is none yet.
+func personalRecords(complejo *models.Complejo, fields map[string]interface{}) []bus.PRAchieved {
+	current := map[string]float64{"bench": complejo.Bench, "squad": complejo.Squad, "dl": complejo.DL}
+
+	var records []bus.PRAchieved
+	for _, lift := range lifts {
+		next, ok := fields[lift].(float64)
+		if !ok || next <= current[lift] {
+			continue
+		}
+
+		records = append(records, bus.PRAchieved{
+			ComplejoID: complejo.ID,
+			Username:   complejo.Username,
+			Lift:       lift,
+			Previous:   current[lift],
+			Value:      next,
+		})
+	}
+	return records
+}
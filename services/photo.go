@@ -0,0 +1,171 @@
+// photo.go
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/imaging"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/validation"
+)
+
+// processPhoto normalizes a base64-encoded photo (optionally a `data:` URL) on the image pool.
+// The result keeps the input's form: a data URL stays a data URL with the new content type.
+func processPhoto(ctx context.Context, pool *imaging.Pool, photo string) (string, error) {
+	if photo == "" {
+		return "", nil
+	}
+
+	data, isDataURL, err := decodePhoto(photo)
+	if err != nil {
+		return "", err
+	}
+	image, err := processImage(ctx, pool, data)
+	if err != nil {
+		return "", err
+	}
+
+	result := base64.StdEncoding.EncodeToString(image.Data)
+	if isDataURL {
+		result = dataURL(image)
+	}
+	return result, nil
+}
+
+// decodePhoto decodes a base64-encoded photo, optionally a `data:` URL, and reports whether it was a data URL.
+func decodePhoto(photo string) ([]byte, bool, error) {
+	encoded, isDataURL := photo, false
+	if rest, ok := strings.CutPrefix(photo, "data:"); ok {
+		_, encoded, ok = strings.Cut(rest, ";base64,")
+		if !ok {
+			return nil, false, invalidPhoto("must be a base64-encoded image")
+		}
+		isDataURL = true
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false, invalidPhoto("must be a base64-encoded image")
+	}
+	return data, isDataURL, nil
+}
+
+// processImage normalizes the image on the pool, turning its errors into the typed errors of the services.
+func processImage(ctx context.Context, pool *imaging.Pool, data []byte) (*imaging.Image, error) {
+	image, err := pool.Process(ctx, data)
+	switch {
+	case errors.Is(err, imaging.ErrQueueFull):
+		return nil, ErrImageQueueFull
+	case errors.Is(err, imaging.ErrUnsupportedImage):
+		return nil, invalidPhoto("must be a JPEG, PNG or GIF image of at most 5 MB")
+	case err != nil:
+		return nil, err
+	}
+	return image, nil
+}
+
+// dataURL returns the image as a `data:` URL.
+func dataURL(image *imaging.Image) string {
+	return "data:" + image.ContentType + ";base64," + base64.StdEncoding.EncodeToString(image.Data)
+}
+
+// thumbnail scales a stored photo down on the thumbnail pool, keeping its form like processPhoto.
+// It is best effort: the thumbnail is left out ("") when the photo cannot be processed or the pool is busy.
+func thumbnail(ctx context.Context, pool *imaging.Pool, photo string) string {
+	thumb, err := processPhoto(ctx, pool, photo)
+	if err != nil {
+		return ""
+	}
+	return thumb
+}
+
+// storedThumbnail returns the thumbnail of the profile photo kept in the object store under the ID as a data URL,
+// scaling the photo down on the thumbnail pool while its thumbnail is not made yet. It is best effort like thumbnail.
+func storedThumbnail(ctx context.Context, pool *imaging.Pool, photos objectstore.Store, photoID string) string {
+	key := models.ProfilePhotoKey(photoID)
+	if data, err := photos.Get(ctx, models.VariantKey(key, models.PhotoSizeThumb)); err == nil {
+		return "data:" + http.DetectContentType(data) + ";base64," + base64.StdEncoding.EncodeToString(data)
+	}
+	data, err := photos.Get(ctx, key)
+	if err != nil {
+		return ""
+	}
+	thumb, err := processImage(ctx, pool, data)
+	if err != nil {
+		return ""
+	}
+	return dataURL(thumb)
+}
+
+// sizeOptions are the options making each size variant of the photos from the stored photo.
+var sizeOptions = map[string]imaging.Options{
+	models.PhotoSizeThumb:  imaging.ThumbnailOptions,
+	models.PhotoSizeMedium: imaging.MediumOptions,
+}
+
+// makeSizes scales the photo stored under the key down to each size on the pool, and stores the variants next to
+// it (see models.VariantKey). It returns the thumbnail, or nil when the photo was removed since or cannot be
+// processed; ErrImageQueueFull is returned when the pool is busy, for the job to be retried.
+func makeSizes(ctx context.Context, pool *imaging.Pool, store objectstore.Store, key string) (*imaging.Image, error) {
+	data, err := store.Get(ctx, key)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var thumb *imaging.Image
+	for _, size := range models.PhotoSizes {
+		image, err := pool.ProcessWith(ctx, data, sizeOptions[size])
+		switch {
+		case errors.Is(err, imaging.ErrQueueFull):
+			return nil, ErrImageQueueFull
+		case errors.Is(err, imaging.ErrUnsupportedImage):
+			return nil, nil
+		case err != nil:
+			return nil, err
+		}
+		if err := store.Put(ctx, models.VariantKey(key, size), image.Data); err != nil {
+			return nil, err
+		}
+		if size == models.PhotoSizeThumb {
+			thumb = image
+		}
+	}
+	return thumb, nil
+}
+
+// sized returns the size variant of the photo stored under the key (the photo itself when size is empty), and
+// whether it is that variant: the photo itself is returned while the variant is not made yet.
+func sized(ctx context.Context, store objectstore.Store, key, size string) ([]byte, bool, error) {
+	if size != "" {
+		data, err := store.Get(ctx, models.VariantKey(key, size))
+		if !errors.Is(err, objectstore.ErrNotFound) {
+			return data, err == nil, err
+		}
+	}
+	data, err := store.Get(ctx, key)
+	return data, size == "", err
+}
+
+// removeSizes removes the size variants of the photo stored under the key. It is best effort: a failure leaves
+// at worst orphan files.
+func removeSizes(ctx context.Context, store objectstore.Store, key string) {
+	for _, size := range models.PhotoSizes {
+		store.Delete(ctx, models.VariantKey(key, size))
+	}
+}
+
+// invalidPhoto returns the 422 error of an unusable photo.
+func invalidPhoto(message string) error {
+	return apperrors.Validation("Validation failed", []validation.FieldError{
+		{Field: "photo", Rule: "image", Message: message},
+	})
+}
@@ -0,0 +1,406 @@
+// photo_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/imaging"
+	"los-complejos-backend/jobs"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/validation"
+
+	"github.com/google/uuid"
+)
+
+// PhotoService manages the photo albums of events: once an Event has started its participants add photos,
+// its organizers approve them, and the Complejos tagged in a photo grant or refuse their consent according
+// to their photo-consent privacy setting. The photo files are kept in the object store, and their thumbnails
+// and size variants are made in the background (JobPhotoThumbnail).
+type PhotoService struct {
+	repo       repository.PhotoRepository
+	events     repository.EventRepository
+	complejos  repository.ComplejoRepository
+	tx         repository.Transactor
+	outbox     repository.OutboxRepository
+	jobs       *jobs.Queue
+	images     *imaging.Pool
+	thumbnails *imaging.Pool
+	objects    objectstore.Store
+	clock      clock.Clock
+}
+
+// JobPhotoThumbnail is the kind of the jobs making the thumbnail and size variants of an album photo,
+// run by MakeThumbnail.
+const JobPhotoThumbnail = "photo.thumbnail"
+
+// photoThumbnailJob is the payload of the JobPhotoThumbnail jobs.
+type photoThumbnailJob struct {
+	PhotoID string `json:"photo_id"`
+}
+
+// NewPhotoService creates a PhotoService normalizing the photos on the image pool, and scaling them down to their
+// sizes on the thumbnail pool in jobs of the queue.
+func NewPhotoService(repo repository.PhotoRepository, events repository.EventRepository, complejos repository.ComplejoRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, queue *jobs.Queue, images, thumbnails *imaging.Pool, objects objectstore.Store, clk clock.Clock) *PhotoService {
+	return &PhotoService{
+		repo:       repo,
+		events:     events,
+		complejos:  complejos,
+		tx:         tx,
+		outbox:     outboxRepo,
+		jobs:       queue,
+		images:     images,
+		thumbnails: thumbnails,
+		objects:    objects,
+		clock:      clk,
+	}
+}
+
+// Photos returns the album of the Event with the given ID as the viewer may see it (see Album).
+func (s *PhotoService) Photos(ctx context.Context, eventID, viewerID string, isAdmin bool) ([]models.EventPhoto, error) {
+	event, err := s.events.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+	return s.Album(ctx, event, viewerID, isAdmin)
+}
+
+// Album returns the photos of the album of the Event the viewer (empty when anonymous) may see, oldest first:
+// the published ones, plus every photo for admins and the creator of the Event, and the photos the viewer
+// uploaded or is tagged in.
+func (s *PhotoService) Album(ctx context.Context, event *models.Event, viewerID string, isAdmin bool) ([]models.EventPhoto, error) {
+	photos, err := s.repo.FindByEvent(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	album := make([]models.EventPhoto, 0, len(photos))
+	for _, photo := range photos {
+		if canSee(event, &photo, viewerID, isAdmin) {
+			photo.SetURLs()
+			album = append(album, photo)
+		}
+	}
+	return album, nil
+}
+
+// Upload adds a photo to the album of the Event, once it has started. Only admins, the creator of the Event
+// and the Complejos going may add one. The photos of the organizers are approved at once; the others wait
+// for an organizer. Each tagged Complejo is asked for its consent unless it allows tags (or tagged itself);
+// Complejos denying tags cannot be tagged.
+func (s *PhotoService) Upload(ctx context.Context, eventID string, input models.EventPhotoInput, requesterID string, isAdmin bool) (*models.EventPhoto, error) {
+	event, err := s.events.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+	now := s.clock.Now()
+	if event.IsUpcoming(now) {
+		return nil, ErrEventNotStarted
+	}
+	organizer := owns(event, requesterID, isAdmin)
+	if !organizer {
+		rsvp := event.FindRSVP(requesterID)
+		if rsvp == nil || rsvp.Status != models.RSVPGoing {
+			return nil, ErrNotAlbumParticipant
+		}
+	}
+
+	uploader, err := s.complejos.FindByID(ctx, requesterID)
+	if err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	tags, err := s.tags(ctx, input.Tagged, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := decodePhoto(input.Photo)
+	if err != nil {
+		return nil, err
+	}
+	image, err := processImage(ctx, s.images, data)
+	if err != nil {
+		return nil, err
+	}
+
+	photo := &models.EventPhoto{
+		ID:          uuid.NewString(),
+		EventID:     eventID,
+		Caption:     input.Caption,
+		ContentType: image.ContentType,
+		Width:       image.Width,
+		Height:      image.Height,
+		Tags:        tags,
+		Status:      models.PhotoPending,
+		UploadedBy:  requesterID,
+		Username:    uploader.Username,
+		CreatedAt:   now,
+	}
+	photo.Key = "photos/" + eventID + "/" + photo.ID + extension(image.ContentType)
+	if organizer {
+		photo.Status = models.PhotoApproved
+		photo.ApprovedBy = requesterID
+		photo.ApprovedAt = &now
+	}
+	// The file is stored first: a failure leaves at worst an orphan file, never a photo without its file
+	if err := s.objects.Put(ctx, photo.Key, image.Data); err != nil {
+		return nil, err
+	}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Insert(ctx, photo); err != nil {
+			return err
+		}
+		// The album lists the photo without a thumbnail, and serves its sizes as the photo, until the job has made them
+		if err := s.jobs.Enqueue(ctx, JobPhotoThumbnail, photoThumbnailJob{PhotoID: photo.ID}); err != nil {
+			return err
+		}
+		for _, tag := range photo.Tags {
+			if tag.Consent != models.TagPending {
+				continue
+			}
+			err := s.announce(ctx, bus.PhotoTagged{
+				PhotoID:    photo.ID,
+				EventID:    eventID,
+				EventTitle: event.Title,
+				ComplejoID: tag.ComplejoID,
+				Username:   tag.Username,
+				TaggedBy:   uploader.Username,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.objects.Delete(ctx, photo.Key)
+		return nil, err
+	}
+	photo.SetURLs()
+	return photo, nil
+}
+
+// MakeThumbnail runs a JobPhotoThumbnail job: it scales the photo down to each size on the thumbnail pool, stores
+// the variants next to the photo and the thumbnail with it. Photos removed since, and photos the pool cannot
+// process, are left alone; a busy pool is retried.
+func (s *PhotoService) MakeThumbnail(ctx context.Context, payload []byte) error {
+	var job photoThumbnailJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	photo, err := s.repo.FindByID(ctx, job.PhotoID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	thumb, err := makeSizes(ctx, s.thumbnails, s.objects, photo.Key)
+	if err != nil || thumb == nil {
+		return err
+	}
+	_, err = s.repo.SetThumbnail(ctx, photo.ID, dataURL(thumb))
+	return err
+}
+
+// Approve approves a pending photo of the album of the Event. Only admins and the creator of the Event may approve one.
+func (s *PhotoService) Approve(ctx context.Context, eventID, photoID, requesterID string, isAdmin bool) (*models.EventPhoto, error) {
+	event, photo, err := s.photo(ctx, eventID, photoID)
+	if err != nil {
+		return nil, err
+	}
+	if !owns(event, requesterID, isAdmin) {
+		return nil, ErrNotPhotoOrganizer
+	}
+	if photo.Status == models.PhotoApproved {
+		return nil, ErrPhotoApproved
+	}
+
+	now := s.clock.Now()
+	approved, err := s.repo.Approve(ctx, photoID, requesterID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !approved {
+		// Another organizer approved it in the meantime
+		return nil, ErrPhotoApproved
+	}
+
+	photo.Status = models.PhotoApproved
+	photo.ApprovedBy = requesterID
+	photo.ApprovedAt = &now
+	photo.SetURLs()
+	return photo, nil
+}
+
+// Delete removes a photo from the album of the Event, with its file. Admins and the creator of the Event
+// may remove any photo, and the other Complejos the photos they uploaded.
+func (s *PhotoService) Delete(ctx context.Context, eventID, photoID, requesterID string, isAdmin bool) error {
+	event, photo, err := s.photo(ctx, eventID, photoID)
+	if err != nil {
+		return err
+	}
+	if !owns(event, requesterID, isAdmin) && photo.UploadedBy != requesterID {
+		return ErrNotPhotoAuthor
+	}
+	return s.remove(ctx, photo)
+}
+
+// Consent records the answer of a Complejo tagged in a photo of the album of the Event. A refusal, even after
+// granting it, removes the photo; the granted photo is returned, or nil when it was removed.
+// ErrNotTagged is returned when the Complejo is not tagged in the photo.
+func (s *PhotoService) Consent(ctx context.Context, eventID, photoID, complejoID string, input models.PhotoConsentInput) (*models.EventPhoto, error) {
+	_, photo, err := s.photo(ctx, eventID, photoID)
+	if err != nil {
+		return nil, err
+	}
+	tag := photo.FindTag(complejoID)
+	if tag == nil {
+		return nil, ErrNotTagged
+	}
+
+	if input.Consent == models.TagRefused {
+		return nil, s.remove(ctx, photo)
+	}
+	if tag.Consent == models.TagGranted {
+		return nil, ErrConsentGranted
+	}
+
+	now := s.clock.Now()
+	granted, err := s.repo.GrantTag(ctx, photoID, complejoID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !granted {
+		return nil, ErrConsentGranted
+	}
+	tag.Consent = models.TagGranted
+	tag.DecidedAt = &now
+	photo.SetURLs()
+	return photo, nil
+}
+
+// File returns a photo of the album of the Event and its file, or the file of its size variant when size is set
+// (the photo itself until the variant is made), when the viewer may see it (see Album).
+// ErrPhotoNotFound is returned for the photos the viewer may not see.
+func (s *PhotoService) File(ctx context.Context, eventID, photoID, size, viewerID string, isAdmin bool) (*models.EventPhoto, []byte, error) {
+	event, photo, err := s.photo(ctx, eventID, photoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canSee(event, photo, viewerID, isAdmin) {
+		return nil, nil, ErrPhotoNotFound
+	}
+
+	data, _, err := sized(ctx, s.objects, photo.Key, size)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, nil, ErrPhotoNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return photo, data, nil
+}
+
+// tags resolves the Complejos tagged by the uploader, leaving out duplicates. A tag is granted at once for
+// the uploader and the Complejos allowing tags, and pending for the others; a validation error is returned
+// for unknown Complejos and the Complejos denying tags.
+func (s *PhotoService) tags(ctx context.Context, tagged []string, uploaderID string) ([]models.PhotoTag, error) {
+	now := s.clock.Now()
+	tags := []models.PhotoTag{}
+	seen := map[string]bool{}
+	for _, id := range tagged {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		complejo, err := s.complejos.FindByID(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, invalidTag("contains an unknown Complejo: " + id)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tag := models.PhotoTag{ComplejoID: complejo.ID, Username: complejo.Username, Consent: models.TagPending}
+		switch {
+		case complejo.ID == uploaderID || complejo.PhotoConsentSetting() == models.PhotoConsentAllow:
+			tag.Consent = models.TagGranted
+			tag.DecidedAt = &now
+		case complejo.PhotoConsentSetting() == models.PhotoConsentDeny:
+			return nil, invalidTag(complejo.Username + " does not allow being tagged in photos")
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// photo returns the live Event and its photo with the given ID, or ErrPhotoNotFound when the photo does not
+// exist or belongs to another Event.
+func (s *PhotoService) photo(ctx context.Context, eventID, photoID string) (*models.Event, *models.EventPhoto, error) {
+	event, err := s.events.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, nil, notFound(err, ErrEventNotFound)
+	}
+	photo, err := s.repo.FindByID(ctx, photoID)
+	if err != nil {
+		return nil, nil, notFound(err, ErrPhotoNotFound)
+	}
+	if photo.EventID != eventID {
+		return nil, nil, ErrPhotoNotFound
+	}
+	return event, photo, nil
+}
+
+// remove deletes the photo, its file and the files of its size variants.
+func (s *PhotoService) remove(ctx context.Context, photo *models.EventPhoto) error {
+	found, err := s.repo.Delete(ctx, photo.ID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrPhotoNotFound
+	}
+	removeSizes(ctx, s.objects, photo.Key)
+	return s.objects.Delete(ctx, photo.Key)
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *PhotoService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
+
+// canSee reports whether the viewer (empty when anonymous) may see the photo of the Event: published photos
+// are seen by everyone, the others by admins, the creator of the Event, their author and the Complejos tagged in them.
+func canSee(event *models.Event, photo *models.EventPhoto, viewerID string, isAdmin bool) bool {
+	if photo.Published() || owns(event, viewerID, isAdmin) {
+		return true
+	}
+	return viewerID != "" && (photo.UploadedBy == viewerID || photo.FindTag(viewerID) != nil)
+}
+
+// extension returns the file extension of the normalized image type.
+func extension(contentType string) string {
+	if contentType == "image/jpeg" {
+		return ".jpg"
+	}
+	return ".png"
+}
+
+// invalidTag returns the 422 error of an unusable tag.
+func invalidTag(message string) error {
+	return apperrors.Validation("Validation failed", []validation.FieldError{
+		{Field: "tagged", Rule: "consent", Message: message},
+	})
+}
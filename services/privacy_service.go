@@ -0,0 +1,145 @@
+// privacy_service.go
+package services
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// PrivacyService keeps the privacy settings of the Complejos, the audiences of the fields of their profile, and
+// tells which of those fields a caller sees, from its relation to the Complejo: itself, a follower or another.
+// The profile of a Complejo is hidden from the Complejos it blocks.
+type PrivacyService struct {
+	repo      repository.PrivacyRepository
+	follows   repository.FollowRepository
+	blocks    repository.BlockRepository
+	complejos repository.ComplejoRepository
+	clock     clock.Clock
+}
+
+// NewPrivacyService creates a PrivacyService backed by the given repositories and clock.
+func NewPrivacyService(repo repository.PrivacyRepository, follows repository.FollowRepository, blocks repository.BlockRepository, complejos repository.ComplejoRepository, clk clock.Clock) *PrivacyService {
+	return &PrivacyService{repo: repo, follows: follows, blocks: blocks, complejos: complejos, clock: clk}
+}
+
+// Settings returns the privacy settings of the Complejo with the given ID, the default ones while it never
+// changed them.
+func (s *PrivacyService) Settings(ctx context.Context, complejoID string) (*models.PrivacySettings, error) {
+	settings, err := s.repo.FindByComplejo(ctx, complejoID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.DefaultPrivacySettings(complejoID), nil
+	}
+	return settings, err
+}
+
+// Update changes the audiences present in the input of the privacy settings of the live Complejo with the given
+// ID, and returns its settings.
+func (s *PrivacyService) Update(ctx context.Context, complejoID string, input models.PrivacySettingsInput) (*models.PrivacySettings, error) {
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+	settings, err := s.Settings(ctx, complejoID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	settings.Apply(input)
+	settings.UpdatedAt = &now
+	if err := s.repo.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// View completes the view of the profile of the Complejo with the given ID for the viewer (none when empty):
+// its relation to the Complejo, unless the view already has one (admins see every field), and the privacy
+// settings of the Complejo. ErrComplejoNotFound is returned when the Complejo blocks the viewer.
+func (s *PrivacyService) View(ctx context.Context, view models.ComplejoView, viewerID, complejoID string) (models.ComplejoView, error) {
+	if view.Relation == "" {
+		view.Relation = models.RelationOther
+		switch {
+		case viewerID == "":
+		case viewerID == complejoID:
+			view.Relation = models.RelationSelf
+		default:
+			hidden, err := s.blocks.Exists(ctx, complejoID, viewerID)
+			if err != nil {
+				return view, err
+			}
+			if hidden {
+				return view, ErrComplejoNotFound
+			}
+			follows, err := s.follows.Exists(ctx, viewerID, complejoID)
+			if err != nil {
+				return view, err
+			}
+			if follows {
+				view.Relation = models.RelationFollower
+			}
+		}
+	}
+	settings, err := s.Settings(ctx, complejoID)
+	if err != nil {
+		return view, err
+	}
+	view.Privacy = settings
+	return view, nil
+}
+
+// Views completes the view of the profiles of the Complejos with the given IDs for the viewer (none when empty),
+// like View, and returns them by Complejo ID. The Complejos blocking the viewer are left out.
+func (s *PrivacyService) Views(ctx context.Context, view models.ComplejoView, viewerID string, complejoIDs []string) (map[string]models.ComplejoView, error) {
+	followed := map[string]bool{}
+	hidden := map[string]bool{}
+	if view.Relation == "" && viewerID != "" {
+		ids, err := s.follows.FindFolloweeIDs(ctx, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			followed[id] = true
+		}
+		if ids, err = s.blocks.FindBlockerIDs(ctx, viewerID); err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			hidden[id] = true
+		}
+	}
+	stored, err := s.repo.FindByComplejos(ctx, complejoIDs)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]*models.PrivacySettings, len(stored))
+	for i := range stored {
+		settings[stored[i].ComplejoID] = &stored[i]
+	}
+
+	views := make(map[string]models.ComplejoView, len(complejoIDs))
+	for _, id := range complejoIDs {
+		if hidden[id] {
+			continue
+		}
+		v := view
+		if v.Relation == "" {
+			switch {
+			case viewerID != "" && viewerID == id:
+				v.Relation = models.RelationSelf
+			case followed[id]:
+				v.Relation = models.RelationFollower
+			default:
+				v.Relation = models.RelationOther
+			}
+		}
+		v.Privacy = settings[id]
+		if v.Privacy == nil {
+			v.Privacy = models.DefaultPrivacySettings(id)
+		}
+		views[id] = v
+	}
+	return views, nil
+}
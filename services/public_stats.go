@@ -0,0 +1,86 @@
+// public_stats.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/models"
+	"los-complejos-backend/outbox"
+
+	"github.com/google/uuid"
+)
+
+// recordNamespace derives the IDs of the logged PersonalRecords from the domain event that announced them, so
+// that a domain event delivered again does not log its record twice.
+var recordNamespace = uuid.MustParse("9d3a7c15-4e2b-4f80-b6c9-1a5e8f2d7b43")
+
+// RecordPersonalRecord logs the lift record announced by a PRAchieved domain event, for the monthly total of the
+// public counters. Other domain events are ignored.
+func (s *ReportService) RecordPersonalRecord(ctx context.Context, event bus.Event) error {
+	pr, ok := event.(*bus.PRAchieved)
+	if !ok {
+		return nil
+	}
+	eventID := outbox.MessageID(ctx)
+	if eventID == "" {
+		eventID = uuid.NewString()
+	}
+	return s.records.Insert(ctx, &models.PersonalRecord{
+		ID:         uuid.NewSHA1(recordNamespace, []byte(eventID+"/"+pr.Lift)).String(),
+		ComplejoID: pr.ComplejoID,
+		Lift:       pr.Lift,
+		Previous:   pr.Previous,
+		Value:      pr.Value,
+		AchievedAt: s.clock.Now(),
+	})
+}
+
+// RefreshPublicStats computes the public counters of the club and caches them for PublicStats. It is run by
+// the scheduler.
+func (s *ReportService) RefreshPublicStats(ctx context.Context) error {
+	now := s.clock.Now()
+	local := now.In(s.Location)
+	month := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, s.Location)
+
+	members, events, err := s.repo.ClubTotals(ctx, now)
+	if err != nil {
+		return fmt.Errorf("error counting the members and events: %w", err)
+	}
+	lifted, err := s.records.TotalSince(ctx, month)
+	if err != nil {
+		return fmt.Errorf("error totalling the lift records: %w", err)
+	}
+
+	stats := &models.PublicStats{
+		Members:              members,
+		EventsHosted:         events,
+		KilosLiftedThisMonth: lifted,
+		Month:                month.Format("2006-01"),
+		UpdatedAt:            now,
+	}
+	s.mu.Lock()
+	s.stats = stats
+	s.mu.Unlock()
+	return nil
+}
+
+// PublicStats returns the public counters of the club as of the last refresh, computing them when they have
+// not been yet.
+func (s *ReportService) PublicStats(ctx context.Context) (*models.PublicStats, error) {
+	s.mu.Lock()
+	stats := s.stats
+	s.mu.Unlock()
+	if stats != nil {
+		return stats, nil
+	}
+
+	if err := s.RefreshPublicStats(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats, nil
+}
@@ -0,0 +1,44 @@
+// purger.go
+package services
+
+import (
+	"context"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/repository"
+)
+
+// Purger permanently removes the Complejos and Events deleted longer ago than the retention window.
+type Purger struct {
+	complejos repository.ComplejoRepository
+	events    repository.EventRepository
+	clock     clock.Clock
+
+	Retention time.Duration // How long deleted documents stay restorable
+}
+
+// NewPurger creates a Purger with a 30-day retention.
+func NewPurger(complejos repository.ComplejoRepository, events repository.EventRepository, clk clock.Clock) *Purger {
+	return &Purger{
+		complejos: complejos,
+		events:    events,
+		clock:     clk,
+		Retention: 30 * 24 * time.Hour,
+	}
+}
+
+// Purge removes the documents deleted before the retention window and returns how many were removed.
+func (p *Purger) Purge(ctx context.Context) (int64, error) {
+	before := p.clock.Now().Add(-p.Retention)
+
+	complejos, err := p.complejos.PurgeDeleted(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	events, err := p.events.PurgeDeleted(ctx, before)
+	if err != nil {
+		return complejos, err
+	}
+	return complejos + events, nil
+}
@@ -0,0 +1,232 @@
+// push_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/push"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/validation"
+
+	"github.com/google/uuid"
+)
+
+// pushBodyLength is the maximum length, in characters, of the body of the push notifications, about what the
+// phones show before it is expanded.
+const pushBodyLength = 200
+
+// pushDateLayout formats the dates in the push notifications, e.g. "Sat 7 Nov, 09:00".
+const pushDateLayout = "Mon 2 Jan, 15:04"
+
+// PushService delivers push notifications to the apps and browsers of the Complejos: it registers their devices,
+// reminds the Complejos going to an Event shortly before it starts, and pushes the Announcements of the admins.
+// It subscribes to the domain events; the devices whose token is no longer accepted are forgotten.
+type PushService struct {
+	devices repository.DeviceRepository
+	events  repository.EventRepository
+	tx      repository.Transactor
+	outbox  repository.OutboxRepository
+	senders map[string]push.Sender
+	clock   clock.Clock
+	logger  *slog.Logger
+
+	Location     *time.Location // Time zone of the dates in the notifications
+	RemindBefore time.Duration  // How long before the start of an Event its participants are reminded
+}
+
+// NewPushService creates a PushService delivering the notifications with the sender of each platform
+// (models.PlatformFCM or models.PlatformWebPush); the platforms without a sender are disabled. It reminds the
+// participants of the Events starting within 2 hours, and shows dates in UTC.
+func NewPushService(devices repository.DeviceRepository, events repository.EventRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, senders map[string]push.Sender, clk clock.Clock, logger *slog.Logger) *PushService {
+	return &PushService{
+		devices:      devices,
+		events:       events,
+		tx:           tx,
+		outbox:       outboxRepo,
+		senders:      senders,
+		clock:        clk,
+		logger:       logger,
+		Location:     time.UTC,
+		RemindBefore: 2 * time.Hour,
+	}
+}
+
+// WebPushKey returns the key browsers subscribe with, or ErrPushDisabled when Web Push is disabled.
+func (s *PushService) WebPushKey() (*models.WebPushKey, error) {
+	sender, ok := s.senders[models.PlatformWebPush].(*push.WebPushSender)
+	if !ok {
+		return nil, ErrPushDisabled
+	}
+	return &models.WebPushKey{PublicKey: sender.PublicKey()}, nil
+}
+
+// Register registers a device of the Complejo, or updates it when its token is already registered (moving it
+// to the Complejo). ErrPushDisabled is returned when its platform is disabled.
+func (s *PushService) Register(ctx context.Context, complejoID string, input models.DeviceInput) (*models.Device, error) {
+	if _, ok := s.senders[input.Platform]; !ok {
+		return nil, ErrPushDisabled
+	}
+
+	now := s.clock.Now()
+	device := &models.Device{
+		ID:         uuid.NewString(),
+		ComplejoID: complejoID,
+		Platform:   input.Platform,
+		Token:      input.Token,
+		Name:       input.Name,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if input.Platform == models.PlatformWebPush {
+		keys := input.Subscription.Keys
+		if err := push.ValidateSubscriptionKeys(keys.P256dh, keys.Auth); err != nil {
+			return nil, apperrors.Validation("Validation failed", []validation.FieldError{
+				{Field: "subscription.keys", Rule: "webpush", Message: "must be the keys of a Web Push subscription"},
+			})
+		}
+		device.Token = input.Subscription.Endpoint
+		device.P256dh = keys.P256dh
+		device.Auth = keys.Auth
+	}
+
+	if err := s.devices.Upsert(ctx, device); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// Devices returns the devices registered by the Complejo, most recently registered first.
+func (s *PushService) Devices(ctx context.Context, complejoID string) ([]models.Device, error) {
+	return s.devices.FindByComplejos(ctx, []string{complejoID})
+}
+
+// Unregister removes the device with the given ID of the Complejo.
+func (s *PushService) Unregister(ctx context.Context, id, complejoID string) error {
+	found, err := s.devices.Delete(ctx, id, complejoID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
+
+// Handle pushes the reminders of the Events starting soon (EventReminder) to the devices of their participants,
+// and the Announcements (AnnouncementPublished) to every device; it ignores the other domain events. Like the
+// emails, it fails only when no notification could be delivered.
+func (s *PushService) Handle(ctx context.Context, event bus.Event) error {
+	switch e := event.(type) {
+	case *bus.EventReminder:
+		devices, err := s.devices.FindByComplejos(ctx, e.ParticipantIDs)
+		if err != nil {
+			return err
+		}
+		body := e.Date.In(s.Location).Format(pushDateLayout)
+		if e.Location != "" {
+			body += " · " + e.Location
+		}
+		return s.deliver(ctx, devices, push.Notification{
+			Title: e.Title,
+			Body:  body,
+			Data:  map[string]string{"type": "event_reminder", "event_id": e.EventID},
+		})
+	case *bus.AnnouncementPublished:
+		devices, err := s.devices.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+		return s.deliver(ctx, devices, push.Notification{
+			Title: e.Title,
+			Body:  shorten(e.Body, pushBodyLength),
+			Data:  map[string]string{"type": "announcement", "announcement_id": e.ID},
+		})
+	}
+	return nil
+}
+
+// deliver sends the notification to each device, forgetting the devices whose token is no longer valid.
+// It fails only when every delivery failed, so that the devices already notified are not notified twice.
+func (s *PushService) deliver(ctx context.Context, devices []models.Device, notification push.Notification) error {
+	sent, failed := 0, 0
+	var lastErr error
+	for _, device := range devices {
+		sender, ok := s.senders[device.Platform]
+		if !ok {
+			continue
+		}
+
+		err := sender.Send(ctx, device, notification)
+		switch {
+		case errors.Is(err, push.ErrInvalidToken):
+			if err := s.devices.DeleteByToken(ctx, device.Token); err != nil {
+				s.logger.Warn("invalid push token not pruned", "device_id", device.ID, "error", err)
+			} else {
+				s.logger.Info("invalid push token pruned", "device_id", device.ID, "platform", device.Platform)
+			}
+		case err != nil:
+			failed++
+			lastErr = err
+			s.logger.Warn("push notification not delivered", "device_id", device.ID, "platform", device.Platform, "error", err)
+		default:
+			sent++
+		}
+	}
+
+	if sent == 0 && failed > 0 {
+		return fmt.Errorf("no push notification delivered: %w", lastErr)
+	}
+	return nil
+}
+
+// RemindUpcoming announces an EventReminder for each Event starting within RemindBefore whose participants were
+// not reminded yet, and returns how many Events were marked reminded (those without participants are marked
+// too). Each Event is marked in the same transaction, so its participants are reminded once, and again when it
+// is rescheduled.
+func (s *PushService) RemindUpcoming(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	events, err := s.events.FindUnreminded(ctx, now, now.Add(s.RemindBefore))
+	if err != nil {
+		return 0, err
+	}
+
+	reminded := 0
+	for _, event := range events {
+		participants := event.ComplejoIDs(models.RSVPGoing)
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			found, err := s.events.UpdateByID(ctx, event.ID, map[string]interface{}{"reminded_at": now})
+			if err != nil || !found || len(participants) == 0 {
+				return err
+			}
+			return s.announce(ctx, bus.EventReminder{
+				EventID:        event.ID,
+				Title:          event.Title,
+				Date:           event.Date,
+				Location:       event.Location,
+				ParticipantIDs: participants,
+			})
+		})
+		if err != nil {
+			return reminded, fmt.Errorf("error reminding event %s: %w", event.ID, err)
+		}
+		reminded++
+	}
+	return reminded, nil
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *PushService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
@@ -0,0 +1,151 @@
+// report_service.go
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// cohortLength is the length of a sign-up cohort.
+const cohortLength = 7 * 24 * time.Hour
+
+// ReportService builds the aggregated reports of the admin dashboard, and the public busy times and counters.
+type ReportService struct {
+	repo    repository.ReportRepository
+	records repository.PersonalRecordRepository
+	clock   clock.Clock
+
+	mu        sync.Mutex
+	busy      *models.BusyTimes
+	busyUntil time.Time
+	stats     *models.PublicStats // Public counters of the last RefreshPublicStats
+
+	Location     *time.Location // Time zone of the hours in the heatmap and busy times
+	BusyTimesTTL time.Duration  // How long the public busy times are served from cache
+}
+
+// NewReportService creates a ReportService backed by the given repositories and clock,
+// reporting hours in UTC and caching the busy times for 15 minutes.
+func NewReportService(repo repository.ReportRepository, records repository.PersonalRecordRepository, clk clock.Clock) *ReportService {
+	return &ReportService{repo: repo, records: records, clock: clk, Location: time.UTC, BusyTimesTTL: 15 * time.Minute}
+}
+
+// Retention returns the retention matrix of the given number of weekly sign-up cohorts (defaulted and capped),
+// ending with the current week. A member is retained in a week when they attended an event that week.
+// Every week gets a row, even without sign-ups, and row i has one column per week elapsed since its cohort.
+func (s *ReportService) Retention(ctx context.Context, weeks int) (*models.RetentionReport, error) {
+	weeks = reportWeeks(weeks)
+	now := s.clock.Now().UTC()
+	from := startOfWeek(now).Add(-time.Duration(weeks-1) * cohortLength)
+
+	activity, err := s.repo.CohortActivity(ctx, from, now)
+	if err != nil {
+		return nil, err
+	}
+	byWeek := make(map[time.Time]models.CohortActivity, len(activity))
+	for _, cohort := range activity {
+		byWeek[cohort.Week] = cohort
+	}
+
+	report := &models.RetentionReport{From: from, To: now, Cohorts: make([]models.CohortRetention, 0, weeks)}
+	for i := 0; i < weeks; i++ {
+		start := from.Add(time.Duration(i) * cohortLength)
+		cohort := byWeek[start]
+
+		row := models.CohortRetention{
+			Week:     start,
+			Members:  cohort.Members,
+			Retained: make([]int, weeks-i),
+			Rates:    make([]float64, weeks-i),
+		}
+		for _, offset := range cohort.Offsets {
+			if offset >= 0 && offset < len(row.Retained) {
+				row.Retained[offset]++
+			}
+		}
+		if row.Members > 0 {
+			for k, retained := range row.Retained {
+				row.Rates[k] = float64(retained) / float64(row.Members)
+			}
+		}
+		report.Cohorts = append(report.Cohorts, row)
+	}
+	return report, nil
+}
+
+// Heatmap returns the event attendance of the past weeks (defaulted and capped) by weekday and hour.
+func (s *ReportService) Heatmap(ctx context.Context, weeks int) (*models.Heatmap, error) {
+	now := s.clock.Now()
+	from := now.Add(-time.Duration(reportWeeks(weeks)) * cohortLength)
+
+	slots, err := s.repo.AttendanceByHour(ctx, from, now, s.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	heatmap := &models.Heatmap{From: from, To: now, Timezone: s.Location.String(), Weekdays: models.Weekdays}
+	for _, slot := range slots {
+		if slot.Weekday < 1 || slot.Weekday > 7 || slot.Hour < 0 || slot.Hour > 23 {
+			continue
+		}
+		heatmap.Counts[slot.Weekday-1][slot.Hour] += slot.Count
+		heatmap.Max = max(heatmap.Max, heatmap.Counts[slot.Weekday-1][slot.Hour])
+	}
+	return heatmap, nil
+}
+
+// BusyTimes summarizes the heatmap of the default period as busy levels relative to the busiest slot:
+// "busy" from two thirds of it, "moderate" from one third, "quiet" below. It is cached for BusyTimesTTL.
+func (s *ReportService) BusyTimes(ctx context.Context) (*models.BusyTimes, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	if s.busy != nil && now.Before(s.busyUntil) {
+		return s.busy, nil
+	}
+
+	heatmap, err := s.Heatmap(ctx, models.DefaultReportWeeks)
+	if err != nil {
+		return nil, err
+	}
+
+	busy := &models.BusyTimes{Timezone: heatmap.Timezone, Days: make([]models.BusyDay, 0, len(models.Weekdays))}
+	for d, weekday := range models.Weekdays {
+		day := models.BusyDay{Weekday: weekday}
+		for h, count := range heatmap.Counts[d] {
+			switch {
+			case heatmap.Max > 0 && 3*count >= 2*heatmap.Max:
+				day.Levels[h] = models.BusyBusy
+			case heatmap.Max > 0 && 3*count >= heatmap.Max:
+				day.Levels[h] = models.BusyModerate
+			default:
+				day.Levels[h] = models.BusyQuiet
+			}
+		}
+		busy.Days = append(busy.Days, day)
+	}
+
+	s.busy, s.busyUntil = busy, now.Add(s.BusyTimesTTL)
+	return busy, nil
+}
+
+// reportWeeks defaults and caps the number of weeks covered by a report.
+func reportWeeks(weeks int) int {
+	if weeks < 1 {
+		return models.DefaultReportWeeks
+	}
+	return min(weeks, models.MaxReportWeeks)
+}
+
+// startOfWeek returns midnight (UTC) of the Monday starting the week of t.
+func startOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
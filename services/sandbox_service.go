@@ -0,0 +1,75 @@
+// sandbox_service.go
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/repository"
+)
+
+// Seeder fills the emptied database with the seed data and reports what it created.
+type Seeder func(ctx context.Context) (models.SeedSummary, error)
+
+// SandboxService resets the data of a sandbox deployment to the seed data, so frontend developers and demo
+// sessions can experiment destructively. The sandbox is a deployment of its own, with its own database and object
+// store, designated by the configuration (which checks both are named after the sandbox and refuses it in
+// production): resets are refused everywhere else.
+type SandboxService struct {
+	offboarding repository.OffboardingRepository
+	objects     objectstore.Store
+	seed        Seeder
+	clock       clock.Clock
+	logger      *slog.Logger
+
+	mu sync.Mutex // Serializes the resets
+
+	Enabled bool   // Whether this deployment is the sandbox
+	OnPurge func() // Called once the data is removed, to drop what is cached of it (nil when nothing is)
+}
+
+// NewSandboxService creates a disabled SandboxService removing the data like the offboarding purge (the object
+// store included) and filling the database again with the seeder.
+func NewSandboxService(offboarding repository.OffboardingRepository, objects objectstore.Store, seed Seeder, clk clock.Clock, logger *slog.Logger) *SandboxService {
+	return &SandboxService{
+		offboarding: offboarding,
+		objects:     objects,
+		seed:        seed,
+		clock:       clk,
+		logger:      logger,
+	}
+}
+
+// Reset removes every data of the sandbox, the admin requesting it included, and creates the seed data again.
+// ErrSandboxDisabled is returned when this deployment is not the sandbox.
+func (s *SandboxService) Reset(ctx context.Context, adminID string) (*models.SandboxReset, error) {
+	if !s.Enabled {
+		return nil, ErrSandboxDisabled
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reset := &models.SandboxReset{ResetBy: adminID, ResetAt: s.clock.Now()}
+	removed, err := s.offboarding.Purge(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reset.Removed = removed
+	if err := s.objects.Clear(ctx); err != nil {
+		return nil, err
+	}
+	if s.OnPurge != nil {
+		s.OnPurge()
+	}
+
+	if reset.Seeded, err = s.seed(ctx); err != nil {
+		return nil, err
+	}
+	s.logger.Info("sandbox reset", "admin_id", adminID, "removed", removed,
+		"complejos", reset.Seeded.Complejos, "events", reset.Seeded.Events)
+	return reset, nil
+}
@@ -0,0 +1,54 @@
+// sandbox_service_test.go
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+)
+
+func TestSandboxReset(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeOffboarding{removed: map[string]int64{"complejos": 3}}
+	objects := objectstore.NewDir(t.TempDir())
+	if err := objects.Put(ctx, "events/e1/photo.jpg", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	seeded := 0
+	seed := func(ctx context.Context) (models.SeedSummary, error) {
+		seeded++
+		if _, err := objects.Get(ctx, "events/e1/photo.jpg"); !errors.Is(err, objectstore.ErrNotFound) {
+			t.Errorf("seeding before the files were removed: error = %v", err)
+		}
+		return models.SeedSummary{Complejos: 2, Events: 1}, nil
+	}
+	svc := NewSandboxService(repo, objects, seed, clock.NewFake(time.Now()), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	purged := 0
+	svc.OnPurge = func() { purged++ }
+
+	if _, err := svc.Reset(ctx, "admin"); !errors.Is(err, ErrSandboxDisabled) {
+		t.Fatalf("Reset of a disabled sandbox: error = %v, want ErrSandboxDisabled", err)
+	}
+	if repo.purges != 0 || seeded != 0 {
+		t.Fatal("a disabled sandbox was reset")
+	}
+
+	svc.Enabled = true
+	reset, err := svc.Reset(ctx, "admin")
+	if err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if repo.purges != 1 || purged != 1 || seeded != 1 {
+		t.Errorf("purges = %d, OnPurge calls = %d, seeds = %d, want one of each", repo.purges, purged, seeded)
+	}
+	if reset.ResetBy != "admin" || reset.Removed["complejos"] != 3 || reset.Seeded.Complejos != 2 {
+		t.Errorf("reset = %+v, want the removed and seeded counts", reset)
+	}
+}
@@ -0,0 +1,240 @@
+// service_account_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// Defaults of the rotation of the tokens of the ServiceAccounts.
+const (
+	DefaultRotationDays = 30
+	DefaultOverlapHours = 24
+)
+
+// ServiceAccountService manages the ServiceAccounts of the integrations and authenticates their tokens. Only
+// the hashes of the tokens are stored. A token is due for rotation RotationDays after it was issued: the
+// integration then gets the next one with POST /service/token, and the previous ones keep working OverlapHours
+// more, so that its running instances can switch over.
+type ServiceAccountService struct {
+	accounts repository.ServiceAccountRepository
+	tx       repository.Transactor
+	clock    clock.Clock
+}
+
+// NewServiceAccountService creates a ServiceAccountService.
+func NewServiceAccountService(accounts repository.ServiceAccountRepository, tx repository.Transactor, clk clock.Clock) *ServiceAccountService {
+	return &ServiceAccountService{accounts: accounts, tx: tx, clock: clk}
+}
+
+// Accounts returns every ServiceAccount with its working tokens, oldest first.
+func (s *ServiceAccountService) Accounts(ctx context.Context) ([]models.ServiceAccount, error) {
+	accounts, err := s.accounts.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	for i := range accounts {
+		if accounts[i].Tokens, err = s.accounts.FindTokens(ctx, accounts[i].ID, now); err != nil {
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
+// Account returns the ServiceAccount with the given ID with its working tokens.
+func (s *ServiceAccountService) Account(ctx context.Context, id string) (*models.ServiceAccount, error) {
+	account, err := s.accounts.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrServiceAccountNotFound)
+	}
+	if account.Tokens, err = s.accounts.FindTokens(ctx, id, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Create creates a ServiceAccount of the admin and issues its first token, returned only here.
+func (s *ServiceAccountService) Create(ctx context.Context, input models.ServiceAccountInput, adminID string) (*models.CreatedServiceAccount, error) {
+	now := s.clock.Now()
+	account := &models.ServiceAccount{
+		ID:        uuid.NewString(),
+		CreatedBy: adminID,
+		CreatedAt: now,
+	}
+	applyServiceAccount(account, input, now)
+
+	var issued *models.IssuedServiceToken
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.accounts.Insert(ctx, account); err != nil {
+			return err
+		}
+		token, err := s.issue(ctx, account, now)
+		if err != nil {
+			return err
+		}
+		account.Tokens = []models.ServiceToken{*token.stored}
+		issued = token.issued
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.CreatedServiceAccount{Account: account, Token: issued}, nil
+}
+
+// Update replaces the name, role, rotation and state of the ServiceAccount with the given ID. The new rotation
+// applies to the tokens issued from then on.
+func (s *ServiceAccountService) Update(ctx context.Context, id string, input models.ServiceAccountInput) (*models.ServiceAccount, error) {
+	account, err := s.Account(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyServiceAccount(account, input, s.clock.Now())
+	found, err := s.accounts.Update(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrServiceAccountNotFound
+	}
+	return account, nil
+}
+
+// Delete removes the ServiceAccount with the given ID; its tokens stop working at once.
+func (s *ServiceAccountService) Delete(ctx context.Context, id string) error {
+	found, err := s.accounts.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrServiceAccountNotFound
+	}
+	return nil
+}
+
+// Rotate issues a new token of the ServiceAccount with the given ID, returned only here. Its other tokens keep
+// working for OverlapHours at most.
+func (s *ServiceAccountService) Rotate(ctx context.Context, id string) (*models.IssuedServiceToken, error) {
+	account, err := s.accounts.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrServiceAccountNotFound)
+	}
+
+	now := s.clock.Now()
+	var issued *models.IssuedServiceToken
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		overlap := time.Duration(account.OverlapHours) * time.Hour
+		if err := s.accounts.ExpireTokens(ctx, account.ID, now.Add(overlap)); err != nil {
+			return err
+		}
+		token, err := s.issue(ctx, account, now)
+		if err != nil {
+			return err
+		}
+		issued = token.issued
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+// Authenticate returns the identity of the service token, or ErrInvalidServiceToken when it is unknown,
+// expired or of an inactive ServiceAccount.
+func (s *ServiceAccountService) Authenticate(ctx context.Context, token string) (*models.ServiceIdentity, error) {
+	if !strings.HasPrefix(token, models.ServiceTokenPrefix) {
+		return nil, ErrInvalidServiceToken
+	}
+	stored, err := s.accounts.FindTokenByHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, notFound(err, ErrInvalidServiceToken)
+	}
+	if !s.clock.Now().Before(stored.ExpiresAt) {
+		return nil, ErrInvalidServiceToken
+	}
+	account, err := s.accounts.FindByID(ctx, stored.AccountID)
+	if err != nil {
+		return nil, notFound(err, ErrInvalidServiceToken)
+	}
+	if !account.Active {
+		return nil, ErrInvalidServiceToken
+	}
+
+	return &models.ServiceIdentity{
+		AccountID: account.ID,
+		Name:      account.Name,
+		Role:      account.Role,
+		TokenID:   stored.ID,
+		RotateAt:  stored.RotateAt,
+		ExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// PurgeExpiredTokens removes the expired service tokens and returns how many were removed.
+func (s *ServiceAccountService) PurgeExpiredTokens(ctx context.Context) (int64, error) {
+	return s.accounts.PurgeExpiredTokens(ctx, s.clock.Now())
+}
+
+// issuedToken is a newly issued token, as stored and as returned.
+type issuedToken struct {
+	stored *models.ServiceToken
+	issued *models.IssuedServiceToken
+}
+
+// issue stores a new token of the account, due for rotation after RotationDays and expiring OverlapHours later.
+func (s *ServiceAccountService) issue(ctx context.Context, account *models.ServiceAccount, now time.Time) (*issuedToken, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := models.ServiceTokenPrefix + hex.EncodeToString(secret)
+
+	rotateAt := now.AddDate(0, 0, account.RotationDays)
+	stored := &models.ServiceToken{
+		ID:        uuid.NewString(),
+		AccountID: account.ID,
+		Hash:      hashToken(token),
+		Hint:      token[len(token)-4:],
+		IssuedAt:  now,
+		RotateAt:  rotateAt,
+		ExpiresAt: rotateAt.Add(time.Duration(account.OverlapHours) * time.Hour),
+	}
+	if err := s.accounts.InsertToken(ctx, stored); err != nil {
+		return nil, err
+	}
+	return &issuedToken{stored: stored, issued: &models.IssuedServiceToken{
+		Token:     token,
+		ID:        stored.ID,
+		AccountID: account.ID,
+		RotateAt:  stored.RotateAt,
+		ExpiresAt: stored.ExpiresAt,
+	}}, nil
+}
+
+// applyServiceAccount copies the input onto the account, with the default rotation and state.
+func applyServiceAccount(account *models.ServiceAccount, input models.ServiceAccountInput, now time.Time) {
+	account.Name = input.Name
+	account.Role = input.Role
+	account.RotationDays = input.RotationDays
+	if account.RotationDays == 0 {
+		account.RotationDays = DefaultRotationDays
+	}
+	account.OverlapHours = input.OverlapHours
+	if account.OverlapHours == 0 {
+		account.OverlapHours = DefaultOverlapHours
+	}
+	account.Active = input.Active == nil || *input.Active
+	account.UpdatedAt = now
+}
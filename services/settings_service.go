@@ -0,0 +1,48 @@
+// settings_service.go
+package services
+
+import (
+	"context"
+	"errors"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// SettingsService manages the settings of the club chosen by its admins, such as the defaults of the event
+// listing applied by EventService.Search.
+type SettingsService struct {
+	repo  repository.SettingsRepository
+	clock clock.Clock
+}
+
+// NewSettingsService creates a SettingsService backed by the given repository and clock.
+func NewSettingsService(repo repository.SettingsRepository, clk clock.Clock) *SettingsService {
+	return &SettingsService{repo: repo, clock: clk}
+}
+
+// Listing returns the listing settings of the club, the default ones while the admins never changed them.
+func (s *SettingsService) Listing(ctx context.Context) (*models.ListingSettings, error) {
+	settings, err := s.repo.FindListing(ctx)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.DefaultListingSettings(), nil
+	}
+	return settings, err
+}
+
+// SetListing replaces the listing settings of the club on behalf of the admin.
+func (s *SettingsService) SetListing(ctx context.Context, adminID string, input models.ListingSettingsInput) (*models.ListingSettings, error) {
+	now := s.clock.Now()
+	settings := &models.ListingSettings{
+		HidePastAfterDays: input.HidePastAfterDays,
+		Sort:              input.Sort,
+		PageSize:          input.PageSize,
+		UpdatedBy:         adminID,
+		UpdatedAt:         &now,
+	}
+	if err := s.repo.SaveListing(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
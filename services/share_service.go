@@ -0,0 +1,187 @@
+// share_service.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/imaging"
+	"los-complejos-backend/markdown"
+	"los-complejos-backend/models"
+	"los-complejos-backend/objectstore"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/sharecard"
+	"los-complejos-backend/sitemap"
+)
+
+// shareDateLayout formats the dates on the share images, e.g. "Sat 7 Nov 2026, 09:00".
+const shareDateLayout = "Mon 2 Jan 2006, 15:04"
+
+// metaDescriptionLength is the maximum length, in characters, of the descriptions of the public pages, about
+// what search engines show.
+const metaDescriptionLength = 160
+
+// ShareService presents the Events to the outside world: it renders their share images (title, date and
+// location over the image of the Event), shown by social networks when a link to an Event is shared, and
+// describes their public pages to search engines. Images are cached in the object store until the Event is
+// updated.
+type ShareService struct {
+	events  repository.EventRepository
+	images  *imaging.Pool
+	objects objectstore.Store
+	fetcher *sharecard.Fetcher
+	clock   clock.Clock
+	logger  *slog.Logger
+
+	Location *time.Location // Time zone of the dates on the images
+	SiteURL  string         // Base URL of the public site, whose Event pages are at <SiteURL>/event/<id>
+	APIURL   string         // Base URL of the API as reached from outside, for the URLs of the share images
+}
+
+// NewShareService creates a ShareService downloading the images of the Events with the fetcher (nil to always
+// use the plain background) and normalizing them on the image pool. Dates are shown in UTC, and the public site
+// and the API are assumed to run locally.
+func NewShareService(events repository.EventRepository, images *imaging.Pool, objects objectstore.Store, fetcher *sharecard.Fetcher, clk clock.Clock, logger *slog.Logger) *ShareService {
+	return &ShareService{
+		events:   events,
+		images:   images,
+		objects:  objects,
+		fetcher:  fetcher,
+		clock:    clk,
+		logger:   logger,
+		Location: time.UTC,
+		SiteURL:  "http://localhost:3000",
+		APIURL:   "http://localhost:8080",
+	}
+}
+
+// Sitemap returns the sitemap of the public pages of the Events, the most recent first.
+func (s *ShareService) Sitemap(ctx context.Context) (*sitemap.Sitemap, error) {
+	events, err := s.events.FindAll(ctx, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.After(events[j].Date)
+	})
+
+	urls := make([]sitemap.URL, 0, len(events))
+	for _, event := range events {
+		urls = append(urls, sitemap.URL{Loc: s.pageURL(event.ID)})
+	}
+	return &sitemap.Sitemap{URLs: urls}, nil
+}
+
+// Meta returns the SEO metadata of the public page of the Event with the given ID.
+func (s *ShareService) Meta(ctx context.Context, id string) (*models.EventMeta, error) {
+	event, err := s.events.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+
+	return &models.EventMeta{
+		Title:       event.Title,
+		Description: shorten(markdown.PlainText(event.Description), metaDescriptionLength),
+		URL:         s.pageURL(event.ID),
+		Image:       strings.TrimSuffix(s.APIURL, "/") + "/event/" + url.PathEscape(event.ID) + "/og.png",
+		ImageWidth:  sharecard.Width,
+		ImageHeight: sharecard.Height,
+		Date:        event.Date,
+		Location:    event.Location,
+	}, nil
+}
+
+// pageURL returns the URL of the public page of the Event.
+func (s *ShareService) pageURL(eventID string) string {
+	return strings.TrimSuffix(s.SiteURL, "/") + "/event/" + url.PathEscape(eventID)
+}
+
+// shorten cuts the text to at most limit characters, between words when possible, ending it with an ellipsis
+// when cut.
+func shorten(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)[:limit-1]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > len(cut)/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:.") + "…"
+}
+
+// Image returns the share image of the Event with the given ID as PNG, rendering it when it is not cached.
+// An image of the Event that cannot be downloaded is replaced by the plain background, and the result is then
+// not cached so the image is tried again on the next request.
+func (s *ShareService) Image(ctx context.Context, id string) ([]byte, error) {
+	event, err := s.events.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+
+	key := shareImageKey(id)
+	data, err := s.objects.Get(ctx, key)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, objectstore.ErrNotFound) {
+		return nil, err
+	}
+
+	background, complete := s.background(ctx, event)
+	data, err = sharecard.Render(sharecard.Card{
+		Title:      event.Title,
+		Date:       event.Date.In(s.Location).Format(shareDateLayout),
+		Location:   event.Location,
+		Background: background,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if complete {
+		// A failure to cache the image only costs rendering it again
+		if err := s.objects.Put(ctx, key, data); err != nil {
+			s.logger.Warn("share image not cached", "event_id", id, "error", err)
+		}
+	}
+	return data, nil
+}
+
+// background returns the image of the Event, or nil for the plain background, and reports whether it is the
+// final background: false when the image of the Event could not be downloaded or processed.
+func (s *ShareService) background(ctx context.Context, event *models.Event) (image.Image, bool) {
+	if s.fetcher == nil || event.Image == nil || *event.Image == "" {
+		return nil, true
+	}
+
+	data, err := s.fetcher.Fetch(ctx, *event.Image)
+	if err != nil {
+		s.logger.Warn("event image not downloaded for its share image", "event_id", event.ID, "error", err)
+		return nil, false
+	}
+	normalized, err := s.images.Process(ctx, data)
+	if err != nil {
+		s.logger.Warn("event image not processed for its share image", "event_id", event.ID, "error", err)
+		// An unusable image stays unusable; only a busy pool is worth retrying
+		return nil, !errors.Is(err, imaging.ErrQueueFull) && ctx.Err() == nil
+	}
+	background, _, err := image.Decode(bytes.NewReader(normalized.Data))
+	if err != nil {
+		return nil, true
+	}
+	return background, true
+}
+
+// shareImageKey returns the key of the cached share image of the Event in the object store.
+func shareImageKey(eventID string) string {
+	return "share/" + eventID + ".png"
+}
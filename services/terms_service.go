@@ -0,0 +1,94 @@
+// terms_service.go
+package services
+
+import (
+	"context"
+
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+)
+
+// TermsService publishes the terms documents (terms of service, privacy policy). A document is never changed:
+// each publication and rollback adds a version, and every version stays available.
+type TermsService struct {
+	versions repository.ContentVersionRepository
+	clock    clock.Clock
+}
+
+// NewTermsService creates a TermsService.
+func NewTermsService(versions repository.ContentVersionRepository, clk clock.Clock) *TermsService {
+	return &TermsService{versions: versions, clock: clk}
+}
+
+// Current returns the latest version of the terms document of the given kind.
+func (s *TermsService) Current(ctx context.Context, kind string) (*models.ContentVersion, error) {
+	if !models.IsTermsKind(kind) {
+		return nil, ErrTermsNotFound
+	}
+	version, err := s.versions.FindLatest(ctx, models.ContentTerms, kind)
+	if err != nil {
+		return nil, notFound(err, ErrTermsNotFound)
+	}
+	return version, nil
+}
+
+// Publish adds a version of the terms document of the given kind written by the admin.
+func (s *TermsService) Publish(ctx context.Context, kind string, input models.TermsInput, adminID string) (*models.ContentVersion, error) {
+	return s.write(ctx, kind, input.Title, input.Body, adminID, 0)
+}
+
+// Versions returns the summaries of every version of the terms document of the given kind, the latest first.
+func (s *TermsService) Versions(ctx context.Context, kind string) ([]models.ContentVersionSummary, error) {
+	if !models.IsTermsKind(kind) {
+		return nil, ErrTermsNotFound
+	}
+	versions, err := s.versions.FindAll(ctx, models.ContentTerms, kind)
+	if err != nil {
+		return nil, err
+	}
+	return summarize(versions), nil
+}
+
+// Version returns the given version of the terms document of the given kind, with its diff from the previous one.
+func (s *TermsService) Version(ctx context.Context, kind string, number int) (*models.ContentVersion, error) {
+	if !models.IsTermsKind(kind) {
+		return nil, ErrTermsNotFound
+	}
+	version, err := s.versions.FindVersion(ctx, models.ContentTerms, kind, number)
+	if err != nil {
+		return nil, notFound(err, ErrVersionNotFound)
+	}
+	return version, nil
+}
+
+// Rollback adds a version of the terms document of the given kind restoring the content of an earlier version.
+func (s *TermsService) Rollback(ctx context.Context, kind string, number int, adminID string) (*models.ContentVersion, error) {
+	target, err := s.Version(ctx, kind, number)
+	if err != nil {
+		return nil, err
+	}
+	return s.write(ctx, kind, target.Title, target.Body, adminID, number)
+}
+
+// write adds a version of the terms document of the given kind with the title and body, restoring the given
+// version (0 for a publication).
+func (s *TermsService) write(ctx context.Context, kind, title, body, adminID string, restoredFrom int) (*models.ContentVersion, error) {
+	if !models.IsTermsKind(kind) {
+		return nil, ErrTermsNotFound
+	}
+	latest, err := latestVersion(ctx, s.versions, models.ContentTerms, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := nextVersion(models.ContentTerms, kind, latest, title, body, adminID, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	version.RestoredFrom = restoredFrom
+	if err := insertVersion(ctx, s.versions, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
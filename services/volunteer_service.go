@@ -0,0 +1,266 @@
+// volunteer_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+
+	"github.com/google/uuid"
+)
+
+// VolunteerService manages the volunteer shifts of events (spotter, loader, scorer): organizers open shifts,
+// Complejos sign up for them and are reminded the day before, and the hours of the ended shifts are tallied.
+type VolunteerService struct {
+	repo      repository.VolunteerRepository
+	events    repository.EventRepository
+	complejos repository.ComplejoRepository
+	tx        repository.Transactor
+	outbox    repository.OutboxRepository
+	clock     clock.Clock
+
+	RemindBefore time.Duration // How long before the start of a shift its volunteers are reminded
+}
+
+// NewVolunteerService creates a VolunteerService reminding the volunteers of the shifts starting within a day.
+func NewVolunteerService(repo repository.VolunteerRepository, events repository.EventRepository, complejos repository.ComplejoRepository, tx repository.Transactor, outboxRepo repository.OutboxRepository, clk clock.Clock) *VolunteerService {
+	return &VolunteerService{
+		repo:         repo,
+		events:       events,
+		complejos:    complejos,
+		tx:           tx,
+		outbox:       outboxRepo,
+		clock:        clk,
+		RemindBefore: 24 * time.Hour,
+	}
+}
+
+// Shifts returns the shifts of the Event, ordered by start and role, with how many volunteers can still sign up.
+func (s *VolunteerService) Shifts(ctx context.Context, eventID string) ([]models.VolunteerShiftView, error) {
+	if _, err := s.events.FindByID(ctx, eventID); err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+
+	shifts, err := s.repo.FindShifts(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]models.VolunteerShiftView, 0, len(shifts))
+	for _, shift := range shifts {
+		views = append(views, models.VolunteerShiftView{VolunteerShift: shift, Open: shift.Open()})
+	}
+	return views, nil
+}
+
+// CreateShift opens a volunteer shift for the Event. Only admins and the creator of the Event may open one.
+func (s *VolunteerService) CreateShift(ctx context.Context, eventID string, input models.VolunteerShiftInput, requesterID string, isAdmin bool) (*models.VolunteerShift, error) {
+	if err := s.checkOrganizer(ctx, eventID, requesterID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	shift := &models.VolunteerShift{
+		ID:         uuid.NewString(),
+		EventID:    eventID,
+		Role:       input.Role,
+		Capacity:   input.Capacity,
+		StartsAt:   input.StartsAt,
+		EndsAt:     input.EndsAt,
+		Volunteers: []models.Volunteer{},
+		CreatedBy:  requesterID,
+		CreatedAt:  s.clock.Now(),
+	}
+	if err := s.repo.InsertShift(ctx, shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// DeleteShift removes a volunteer shift of the Event and its sign-ups.
+// Only admins and the creator of the Event may remove one.
+func (s *VolunteerService) DeleteShift(ctx context.Context, eventID, shiftID, requesterID string, isAdmin bool) error {
+	if err := s.checkOrganizer(ctx, eventID, requesterID, isAdmin); err != nil {
+		return err
+	}
+	if _, err := s.shift(ctx, eventID, shiftID); err != nil {
+		return err
+	}
+
+	found, err := s.repo.DeleteShift(ctx, shiftID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrShiftNotFound
+	}
+	return nil
+}
+
+// SignUp signs the Complejo up for a shift of the Event that has not started.
+// ErrShiftFull is returned when the shift has no open slot, and ErrAlreadyVolunteering when it already signed up.
+func (s *VolunteerService) SignUp(ctx context.Context, eventID, shiftID, complejoID string) (*models.VolunteerShiftView, error) {
+	shift, err := s.upcomingShift(ctx, eventID, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	if shift.FindVolunteer(complejoID) != nil {
+		return nil, ErrAlreadyVolunteering
+	}
+	if shift.Open() == 0 {
+		return nil, ErrShiftFull
+	}
+
+	complejo, err := s.complejos.FindByID(ctx, complejoID)
+	if err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	volunteer := models.Volunteer{ComplejoID: complejo.ID, Username: complejo.Username, SignedUpAt: s.clock.Now()}
+	added, err := s.repo.AddVolunteer(ctx, shiftID, volunteer)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		// Another sign-up took the last slot in the meantime.
+		return nil, ErrShiftFull
+	}
+
+	shift.Volunteers = append(shift.Volunteers, volunteer)
+	return &models.VolunteerShiftView{VolunteerShift: *shift, Open: shift.Open()}, nil
+}
+
+// Withdraw removes the Complejo from a shift of the Event that has not started.
+// ErrNotVolunteering is returned when it has not signed up.
+func (s *VolunteerService) Withdraw(ctx context.Context, eventID, shiftID, complejoID string) error {
+	if _, err := s.upcomingShift(ctx, eventID, shiftID); err != nil {
+		return err
+	}
+
+	removed, err := s.repo.RemoveVolunteer(ctx, shiftID, complejoID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotVolunteering
+	}
+	return nil
+}
+
+// Hours returns the hours the Complejo volunteered in the shifts that have ended.
+func (s *VolunteerService) Hours(ctx context.Context, complejoID string) (float64, error) {
+	return s.repo.Hours(ctx, complejoID, s.clock.Now())
+}
+
+// Leaderboard returns the Complejos with the most hours volunteered in the shifts that have ended,
+// most hours first, ten unless the query says otherwise.
+func (s *VolunteerService) Leaderboard(ctx context.Context, query models.VolunteerLeaderboardQuery) ([]models.VolunteerHours, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = models.DefaultVolunteerLeaderboardLimit
+	}
+	return s.repo.Leaderboard(ctx, s.clock.Now(), min(limit, models.MaxVolunteerLeaderboardLimit))
+}
+
+// RemindUpcoming announces a ShiftReminder to every volunteer not yet reminded of a shift starting
+// within RemindBefore, and returns how many reminders were sent.
+func (s *VolunteerService) RemindUpcoming(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	shifts, err := s.repo.FindStartingBetween(ctx, now, now.Add(s.RemindBefore))
+	if err != nil {
+		return 0, err
+	}
+
+	titles := map[string]string{}
+	reminded := 0
+	for _, shift := range shifts {
+		for _, volunteer := range shift.Volunteers {
+			if volunteer.RemindedAt != nil {
+				continue
+			}
+
+			title, ok := titles[shift.EventID]
+			if !ok {
+				event, err := s.events.FindByID(ctx, shift.EventID)
+				if err != nil {
+					return reminded, fmt.Errorf("error reminding shift %s: %w", shift.ID, err)
+				}
+				title = event.Title
+				titles[shift.EventID] = title
+			}
+
+			err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+				if err := s.repo.MarkReminded(ctx, shift.ID, volunteer.ComplejoID, now); err != nil {
+					return err
+				}
+				return s.announce(ctx, bus.ShiftReminder{
+					ShiftID:    shift.ID,
+					EventID:    shift.EventID,
+					EventTitle: title,
+					Role:       shift.Role,
+					ComplejoID: volunteer.ComplejoID,
+					Username:   volunteer.Username,
+					StartsAt:   shift.StartsAt,
+					EndsAt:     shift.EndsAt,
+				})
+			})
+			if err != nil {
+				return reminded, fmt.Errorf("error reminding shift %s: %w", shift.ID, err)
+			}
+			reminded++
+		}
+	}
+	return reminded, nil
+}
+
+// checkOrganizer returns ErrEventNotFound when the Event does not exist, and ErrNotShiftOrganizer
+// when the requester is neither an admin nor its creator.
+func (s *VolunteerService) checkOrganizer(ctx context.Context, eventID, requesterID string, isAdmin bool) error {
+	event, err := s.events.FindByID(ctx, eventID)
+	if err != nil {
+		return notFound(err, ErrEventNotFound)
+	}
+	if !isAdmin && (event.CreatedBy == "" || event.CreatedBy != requesterID) {
+		return ErrNotShiftOrganizer
+	}
+	return nil
+}
+
+// shift returns the shift with the given ID, or ErrShiftNotFound when it does not exist or belongs to another Event.
+func (s *VolunteerService) shift(ctx context.Context, eventID, shiftID string) (*models.VolunteerShift, error) {
+	shift, err := s.repo.FindShiftByID(ctx, shiftID)
+	if err != nil {
+		return nil, notFound(err, ErrShiftNotFound)
+	}
+	if shift.EventID != eventID {
+		return nil, ErrShiftNotFound
+	}
+	return shift, nil
+}
+
+// upcomingShift returns the shift of the live Event with the given ID, or ErrShiftStarted when it has started.
+func (s *VolunteerService) upcomingShift(ctx context.Context, eventID, shiftID string) (*models.VolunteerShift, error) {
+	if _, err := s.events.FindByID(ctx, eventID); err != nil {
+		return nil, notFound(err, ErrEventNotFound)
+	}
+	shift, err := s.shift(ctx, eventID, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	if !shift.StartsAt.After(s.clock.Now()) {
+		return nil, ErrShiftStarted
+	}
+	return shift, nil
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *VolunteerService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
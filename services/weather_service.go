@@ -0,0 +1,152 @@
+// weather_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/weather"
+)
+
+// WeatherService forecasts the weather of outdoor Events: forecasts are fetched from the provider when an Event
+// is read and cached on it, and the participants are warned when severe weather is forecast shortly before it.
+// Without a provider, Events are never forecast.
+type WeatherService struct {
+	events   repository.EventRepository
+	provider weather.Provider
+	tx       repository.Transactor
+	outbox   repository.OutboxRepository
+	clock    clock.Clock
+	logger   *slog.Logger
+
+	TTL        time.Duration // How long a cached forecast is used before it is fetched again
+	Horizon    time.Duration // How far ahead the provider forecasts; later Events are not forecast yet
+	WarnBefore time.Duration // How long before an Event its participants are warned of severe weather
+}
+
+// NewWeatherService creates a WeatherService fetching the forecasts from the provider (nil to disable them),
+// caching them for three hours and forecasting up to ten days ahead. Participants are warned of severe weather
+// 12 hours before the Event.
+func NewWeatherService(events repository.EventRepository, provider weather.Provider, tx repository.Transactor, outboxRepo repository.OutboxRepository, clk clock.Clock, logger *slog.Logger) *WeatherService {
+	return &WeatherService{
+		events:     events,
+		provider:   provider,
+		tx:         tx,
+		outbox:     outboxRepo,
+		clock:      clk,
+		logger:     logger,
+		TTL:        3 * time.Hour,
+		Horizon:    10 * 24 * time.Hour,
+		WarnBefore: 12 * time.Hour,
+	}
+}
+
+// Forecast sets the weather forecast of the outdoor Event, fetching it again when the cached one is older than
+// TTL or was fetched for another date or location. Past Events, and Events beyond the Horizon, keep their cached
+// forecast. A failing provider never fails the request: the cached forecast, if any, is kept and the error logged.
+func (s *WeatherService) Forecast(ctx context.Context, event *models.Event) {
+	now := s.clock.Now()
+	if !s.forecastable(event, now) || s.fresh(event, now) {
+		return
+	}
+	if _, err := s.fetch(ctx, event); err != nil && !errors.Is(err, weather.ErrNoForecast) {
+		s.logger.Warn("weather forecast failed", "event_id", event.ID, "error", err)
+	}
+}
+
+// WarnSevere announces a SevereWeather warning, once, for every outdoor Event starting within WarnBefore whose
+// forecast is severe, and returns how many Events were warned about. The forecasts of these Events are always
+// fetched again, unless fetched within the hour.
+func (s *WeatherService) WarnSevere(ctx context.Context) (int, error) {
+	if s.provider == nil {
+		return 0, nil
+	}
+	now := s.clock.Now()
+	events, err := s.events.FindUnwarnedOutdoor(ctx, now, now.Add(s.WarnBefore))
+	if err != nil {
+		return 0, err
+	}
+
+	warned := 0
+	for i := range events {
+		event := &events[i]
+		forecast := event.Weather
+		if forecast == nil || !forecast.Matches(*event) || now.Sub(forecast.FetchedAt) >= min(s.TTL, time.Hour) {
+			if forecast, err = s.fetch(ctx, event); err != nil {
+				// Keep checking the other Events; this one is checked again next time
+				if !errors.Is(err, weather.ErrNoForecast) {
+					s.logger.Warn("weather forecast failed", "event_id", event.ID, "error", err)
+				}
+				continue
+			}
+		}
+		if !forecast.Severe {
+			continue
+		}
+
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			found, err := s.events.UpdateByID(ctx, event.ID, map[string]interface{}{"weather_warned_at": now})
+			if err != nil || !found {
+				return err
+			}
+			return s.announce(ctx, bus.SevereWeather{
+				EventID:      event.ID,
+				Title:        event.Title,
+				Date:         event.Date,
+				Location:     event.Location,
+				Forecast:     *forecast,
+				Participants: event.Usernames(models.RSVPGoing, models.RSVPMaybe),
+			})
+		})
+		if err != nil {
+			return warned, fmt.Errorf("error warning of severe weather for event %s: %w", event.ID, err)
+		}
+		warned++
+	}
+	return warned, nil
+}
+
+// forecastable reports whether the weather of the Event can be forecast: it is an upcoming outdoor Event
+// within the Horizon and a provider is configured.
+func (s *WeatherService) forecastable(event *models.Event, now time.Time) bool {
+	return s.provider != nil && event.Outdoor && event.IsUpcoming(now) && event.Date.Sub(now) <= s.Horizon
+}
+
+// fresh reports whether the cached forecast of the Event matches its date and location and is younger than TTL.
+func (s *WeatherService) fresh(event *models.Event, now time.Time) bool {
+	return event.Weather != nil && event.Weather.Matches(*event) && now.Sub(event.Weather.FetchedAt) < s.TTL
+}
+
+// fetch fetches the forecast of the Event from the provider, caches it on the Event and sets it.
+func (s *WeatherService) fetch(ctx context.Context, event *models.Event) (*models.Forecast, error) {
+	forecast, err := s.provider.Forecast(ctx, event.Location, event.Date)
+	if err != nil {
+		return nil, err
+	}
+	forecast.Location = event.Location
+	forecast.For = event.Date
+	forecast.FetchedAt = s.clock.Now()
+	forecast.Severe = forecast.IsSevere()
+
+	if _, err := s.events.SetForecast(ctx, event.ID, *forecast); err != nil {
+		return nil, err
+	}
+	event.Weather = forecast
+	return forecast, nil
+}
+
+// announce records the domain event in the outbox; call it inside the transaction of the triggering change.
+func (s *WeatherService) announce(ctx context.Context, event bus.Event) error {
+	message, err := bus.Message(event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	return s.outbox.Enqueue(ctx, message)
+}
@@ -0,0 +1,342 @@
+// webhook_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/bus"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/outbox"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/validation"
+	"los-complejos-backend/webhook"
+
+	"github.com/google/uuid"
+)
+
+// deliveryNamespace derives the IDs of the deliveries from their webhook and domain event, so that a domain event
+// published again is not delivered twice to the same webhook.
+var deliveryNamespace = uuid.MustParse("5b0e3f64-2f8e-4c1a-9d57-7a4c1e9b2d10")
+
+// WebhookService delivers the domain events to the webhooks registered by the admins. It subscribes to the
+// domain events and records a delivery for each active webhook of their topic; its worker POSTs the pending
+// deliveries, signed with the secret of their webhook, and retries the failed ones with exponential backoff.
+// Every attempt is logged with its delivery.
+type WebhookService struct {
+	webhooks repository.WebhookRepository
+	client   *webhook.Client
+	clock    clock.Clock
+	logger   *slog.Logger
+
+	Interval    time.Duration // Wait between polls when no delivery is due
+	Lease       time.Duration // How long a delivery being attempted is hidden from the other workers
+	MaxAttempts int           // Attempts before a delivery is given up
+	MaxBackoff  time.Duration // Upper bound of the retry delay
+	Retention   time.Duration // How long finished deliveries stay in the log
+}
+
+// NewWebhookService creates a WebhookService POSTing the deliveries with the client. It polls every 5 seconds,
+// gives a delivery up after 8 attempts over about an hour, and keeps the log for 30 days.
+func NewWebhookService(webhooks repository.WebhookRepository, client *webhook.Client, clk clock.Clock, logger *slog.Logger) *WebhookService {
+	return &WebhookService{
+		webhooks:    webhooks,
+		client:      client,
+		clock:       clk,
+		logger:      logger,
+		Interval:    5 * time.Second,
+		Lease:       time.Minute,
+		MaxAttempts: 8,
+		MaxBackoff:  time.Hour,
+		Retention:   30 * 24 * time.Hour,
+	}
+}
+
+// Topics returns the topics webhooks can receive, sorted.
+func (s *WebhookService) Topics() []string {
+	topics := bus.Topics()
+	sort.Strings(topics)
+	return topics
+}
+
+// Webhooks returns every Webhook, oldest first.
+func (s *WebhookService) Webhooks(ctx context.Context) ([]models.Webhook, error) {
+	return s.webhooks.FindAll(ctx)
+}
+
+// Webhook returns the Webhook with the given ID.
+func (s *WebhookService) Webhook(ctx context.Context, id string) (*models.Webhook, error) {
+	hook, err := s.webhooks.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrWebhookNotFound)
+	}
+	return hook, nil
+}
+
+// Create registers a Webhook of the admin, active unless the input says otherwise.
+func (s *WebhookService) Create(ctx context.Context, input models.WebhookInput, adminID string) (*models.Webhook, error) {
+	if err := s.validate(input); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	hook := &models.Webhook{
+		ID:        uuid.NewString(),
+		URL:       input.URL,
+		Secret:    input.Secret,
+		Topics:    uniqueTopics(input.Topics),
+		Active:    input.Active == nil || *input.Active,
+		CreatedBy: adminID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.webhooks.Insert(ctx, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// Update replaces the URL, secret, topics and state of the Webhook with the given ID. Its pending deliveries
+// are sent with the new URL and secret.
+func (s *WebhookService) Update(ctx context.Context, id string, input models.WebhookInput) (*models.Webhook, error) {
+	if err := s.validate(input); err != nil {
+		return nil, err
+	}
+	hook, err := s.Webhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	hook.URL = input.URL
+	hook.Secret = input.Secret
+	hook.Topics = uniqueTopics(input.Topics)
+	hook.Active = input.Active == nil || *input.Active
+	hook.UpdatedAt = s.clock.Now()
+	found, err := s.webhooks.Update(ctx, hook)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrWebhookNotFound
+	}
+	return hook, nil
+}
+
+// Delete removes the Webhook with the given ID and its deliveries.
+func (s *WebhookService) Delete(ctx context.Context, id string) error {
+	found, err := s.webhooks.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Deliveries returns the log of the deliveries of the Webhook with the given ID, newest first: at most 50 by
+// default.
+func (s *WebhookService) Deliveries(ctx context.Context, id string, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, error) {
+	if _, err := s.Webhook(ctx, id); err != nil {
+		return nil, err
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 50
+	}
+	return s.webhooks.FindDeliveries(ctx, id, filter)
+}
+
+// validate checks that the webhook is reached over HTTP(S) and that its topics exist.
+func (s *WebhookService) validate(input models.WebhookInput) error {
+	var details []validation.FieldError
+	if parsed, err := url.Parse(input.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		details = append(details, validation.FieldError{Field: "url", Rule: "url", Message: "must be an http or https URL"})
+	}
+	topics := s.Topics()
+	for _, topic := range input.Topics {
+		if i := sort.SearchStrings(topics, topic); i == len(topics) || topics[i] != topic {
+			details = append(details, validation.FieldError{
+				Field:   "topics",
+				Rule:    "oneof",
+				Message: "must be one of: " + strings.Join(topics, ", "),
+			})
+			break
+		}
+	}
+	if len(details) > 0 {
+		return apperrors.Validation("Validation failed", details)
+	}
+	return nil
+}
+
+// uniqueTopics returns the topics without repetitions, sorted.
+func uniqueTopics(topics []string) []string {
+	unique := make([]string, 0, len(topics))
+	seen := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		if !seen[topic] {
+			seen[topic] = true
+			unique = append(unique, topic)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// Handle records a delivery of the domain event for each active webhook of its topic. The deliveries of a
+// domain event published again are only recorded once.
+func (s *WebhookService) Handle(ctx context.Context, event bus.Event) error {
+	hooks, err := s.webhooks.FindByTopic(ctx, event.Topic())
+	if err != nil || len(hooks) == 0 {
+		return err
+	}
+
+	eventID := outbox.MessageID(ctx)
+	if eventID == "" {
+		eventID = uuid.NewString()
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	now := s.clock.Now()
+	body, err := json.Marshal(webhook.Payload{ID: eventID, Topic: event.Topic(), CreatedAt: now, Data: data})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(hooks))
+	for _, hook := range hooks {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID:            uuid.NewSHA1(deliveryNamespace, []byte(hook.ID+"/"+eventID)).String(),
+			WebhookID:     hook.ID,
+			EventID:       eventID,
+			Topic:         event.Topic(),
+			Payload:       body,
+			Status:        models.DeliveryPending,
+			Attempts:      []models.WebhookAttempt{},
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		})
+	}
+	return s.webhooks.Enqueue(ctx, deliveries)
+}
+
+// DeliverPending attempts every delivery that is currently due and returns how many were delivered.
+func (s *WebhookService) DeliverPending(ctx context.Context) (int, error) {
+	delivered := 0
+	for ctx.Err() == nil {
+		delivery, err := s.webhooks.Claim(ctx, s.clock.Now(), s.Lease)
+		if errors.Is(err, repository.ErrNotFound) {
+			return delivered, nil
+		}
+		if err != nil {
+			return delivered, err
+		}
+
+		ok, err := s.deliver(ctx, delivery)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, ctx.Err()
+}
+
+// deliver attempts the delivery and logs the attempt, and reports whether the webhook accepted it.
+// A delivery of a disabled or removed webhook is given up without being sent.
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	start := s.clock.Now()
+	attempt := models.WebhookAttempt{At: start}
+
+	hook, err := s.webhooks.FindByID(ctx, delivery.WebhookID)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		attempt.Error = "webhook removed"
+	case err != nil:
+		return false, err
+	case !hook.Active:
+		attempt.Error = "webhook disabled"
+	default:
+		attempt.StatusCode, err = s.client.Send(ctx, hook.URL, hook.Secret, webhook.Delivery{
+			ID:    delivery.ID,
+			Topic: delivery.Topic,
+			Body:  delivery.Payload,
+		}, start)
+		if err != nil {
+			attempt.Error = err.Error()
+		}
+		attempt.DurationMS = s.clock.Now().Sub(start).Milliseconds()
+	}
+
+	if attempt.Error == "" {
+		return true, s.webhooks.RecordAttempt(ctx, delivery.ID, attempt, models.DeliveryDelivered, s.clock.Now())
+	}
+
+	attempts := len(delivery.Attempts) + 1
+	if hook == nil || !hook.Active || attempts >= s.MaxAttempts {
+		s.logger.Warn("webhook delivery given up", "id", delivery.ID, "webhook_id", delivery.WebhookID,
+			"topic", delivery.Topic, "attempt", attempts, "error", attempt.Error)
+		return false, s.webhooks.RecordAttempt(ctx, delivery.ID, attempt, models.DeliveryFailed, s.clock.Now())
+	}
+	retryAt := s.clock.Now().Add(s.backoff(attempts))
+	s.logger.Warn("webhook delivery failed", "id", delivery.ID, "webhook_id", delivery.WebhookID,
+		"topic", delivery.Topic, "attempt", attempts, "retry_at", retryAt, "error", attempt.Error)
+	return false, s.webhooks.RecordAttempt(ctx, delivery.ID, attempt, models.DeliveryPending, retryAt)
+}
+
+// backoff returns the delay before the next attempt after the given number of attempts: 30 seconds, doubled
+// after each attempt up to MaxBackoff.
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempts && delay < s.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.MaxBackoff {
+		delay = s.MaxBackoff
+	}
+	return delay
+}
+
+// Run delivers the due deliveries every Interval, and removes the finished deliveries older than Retention
+// from the log every hour, until the context is cancelled.
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	var purgedAt time.Time
+	for {
+		delivered, err := s.DeliverPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error("webhook deliveries failed", "error", err)
+		} else if delivered > 0 {
+			s.logger.Info("delivered domain events to webhooks", "count", delivered)
+		}
+
+		if now := s.clock.Now(); now.Sub(purgedAt) >= time.Hour {
+			purged, err := s.webhooks.PurgeDeliveries(ctx, now.Add(-s.Retention))
+			if err != nil && ctx.Err() == nil {
+				s.logger.Error("purge of webhook deliveries failed", "error", err)
+			} else if purged > 0 {
+				s.logger.Info("purged webhook deliveries", "count", purged)
+			}
+			purgedAt = now
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
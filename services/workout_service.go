@@ -0,0 +1,197 @@
+// workout_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/models"
+	"los-complejos-backend/repository"
+	"los-complejos-backend/validation"
+
+	"github.com/google/uuid"
+)
+
+// WorkoutService keeps the training log of the Complejos. Workouts are private: only their author can read,
+// replace or remove them.
+type WorkoutService struct {
+	repo      repository.WorkoutRepository
+	exercises repository.ExerciseRepository
+	complejos repository.ComplejoRepository
+	clock     clock.Clock
+	Location  *time.Location // Time zone of the weeks of the volume summaries
+}
+
+// NewWorkoutService creates a WorkoutService backed by the given repositories and clock.
+func NewWorkoutService(repo repository.WorkoutRepository, exercises repository.ExerciseRepository, complejos repository.ComplejoRepository, clk clock.Clock) *WorkoutService {
+	return &WorkoutService{repo: repo, exercises: exercises, complejos: complejos, clock: clk, Location: time.UTC}
+}
+
+// List returns the page of the query of the workouts of the Complejo with the given ID, newest first, and their
+// total number.
+func (s *WorkoutService) List(ctx context.Context, complejoID string, query *models.WorkoutQuery) ([]models.Workout, int64, error) {
+	query.Normalize()
+	return s.repo.FindByComplejo(ctx, complejoID, query.Offset(), query.Limit)
+}
+
+// Get returns the workout with the given ID, when the Complejo is its author.
+func (s *WorkoutService) Get(ctx context.Context, id, complejoID string) (*models.Workout, error) {
+	return s.owned(ctx, id, complejoID)
+}
+
+// Log records a workout of the Complejo with the given ID, dated now unless the input is dated earlier.
+func (s *WorkoutService) Log(ctx context.Context, complejoID string, input models.WorkoutInput) (*models.Workout, error) {
+	if _, err := s.complejos.FindByID(ctx, complejoID); err != nil {
+		return nil, notFound(err, ErrComplejoNotFound)
+	}
+
+	now := s.clock.Now()
+	workout := &models.Workout{ID: uuid.NewString(), ComplejoID: complejoID, Date: now, CreatedAt: now}
+	if err := s.apply(ctx, workout, input, now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Insert(ctx, workout); err != nil {
+		return nil, err
+	}
+	return workout, nil
+}
+
+// Update replaces the exercises and notes of a workout of the Complejo, and its date when the input has one.
+func (s *WorkoutService) Update(ctx context.Context, id, complejoID string, input models.WorkoutInput) (*models.Workout, error) {
+	workout, err := s.owned(ctx, id, complejoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, workout, input, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	found, err := s.repo.Replace(ctx, workout)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrWorkoutNotFound
+	}
+	return workout, nil
+}
+
+// Delete removes a workout of the Complejo.
+func (s *WorkoutService) Delete(ctx context.Context, id, complejoID string) error {
+	if _, err := s.owned(ctx, id, complejoID); err != nil {
+		return err
+	}
+
+	found, err := s.repo.DeleteByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrWorkoutNotFound
+	}
+	return nil
+}
+
+// Volume returns the training volume of the Complejo with the given ID by week, over the weeks of the query up
+// to the current one, and by muscle group: the sets, the repetitions and the repetitions times the weight.
+func (s *WorkoutService) Volume(ctx context.Context, complejoID string, query models.VolumeQuery) (*models.Volume, error) {
+	weeks := query.Weeks
+	if weeks < 1 {
+		weeks = models.DefaultVolumeWeeks
+	}
+	if weeks > models.MaxVolumeWeeks {
+		weeks = models.MaxVolumeWeeks
+	}
+
+	now := s.clock.Now().In(s.Location)
+	// Weeks start on Monday
+	monday := time.Date(now.Year(), now.Month(), now.Day()-(int(now.Weekday())+6)%7, 0, 0, 0, 0, s.Location)
+	first := monday.AddDate(0, 0, -7*(weeks-1))
+
+	rows, err := s.repo.WeeklyVolume(ctx, complejoID, first, s.Location)
+	if err != nil {
+		return nil, err
+	}
+	trained := make(map[[2]string]models.MuscleVolume, len(rows))
+	for _, row := range rows {
+		trained[[2]string{row.Week, row.MuscleGroup}] = row
+	}
+
+	volume := &models.Volume{Timezone: s.Location.String(), Weeks: make([]models.WeeklyVolume, 0, weeks)}
+	for w := 0; w < weeks; w++ {
+		week := models.WeeklyVolume{
+			Week:         first.AddDate(0, 0, 7*w).Format("2006-01-02"),
+			MuscleGroups: make([]models.MuscleVolume, 0, len(models.MuscleGroups)),
+		}
+		for _, group := range models.MuscleGroups {
+			muscle, ok := trained[[2]string{week.Week, group}]
+			if !ok {
+				muscle = models.MuscleVolume{Week: week.Week, MuscleGroup: group}
+			}
+			week.MuscleGroups = append(week.MuscleGroups, muscle)
+		}
+		volume.Weeks = append(volume.Weeks, week)
+	}
+	return volume, nil
+}
+
+// owned returns the workout with the given ID, or ErrNotWorkoutOwner when the Complejo is not its author.
+func (s *WorkoutService) owned(ctx context.Context, id, complejoID string) (*models.Workout, error) {
+	workout, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrWorkoutNotFound)
+	}
+	if workout.ComplejoID != complejoID {
+		return nil, ErrNotWorkoutOwner
+	}
+	return workout, nil
+}
+
+// apply copies the input onto the workout with the name and muscle group of its exercises, refusing a date in
+// the future and exercises missing from the library.
+func (s *WorkoutService) apply(ctx context.Context, workout *models.Workout, input models.WorkoutInput, now time.Time) error {
+	if input.Date != nil {
+		if input.Date.After(now) {
+			return apperrors.Validation("Validation failed", []validation.FieldError{
+				{Field: "date", Rule: "past", Message: "must not be in the future"},
+			})
+		}
+		workout.Date = *input.Date
+	}
+
+	ids := make([]string, len(input.Exercises))
+	for i, exercise := range input.Exercises {
+		ids[i] = exercise.ExerciseID
+	}
+	found, err := s.exercises.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	library := make(map[string]models.Exercise, len(found))
+	for _, exercise := range found {
+		library[exercise.ID] = exercise
+	}
+
+	exercises := make([]models.WorkoutExercise, len(input.Exercises))
+	for i, logged := range input.Exercises {
+		exercise, ok := library[logged.ExerciseID]
+		if !ok {
+			return apperrors.Validation("Validation failed", []validation.FieldError{
+				{Field: "exercise_id", Rule: "exists", Message: fmt.Sprintf("exercise %q is not in the library", logged.ExerciseID)},
+			})
+		}
+		exercises[i] = models.WorkoutExercise{
+			ExerciseID:  exercise.ID,
+			Name:        exercise.Name,
+			MuscleGroup: exercise.MuscleGroups[0],
+			Sets:        logged.Sets,
+		}
+	}
+	workout.Exercises = exercises
+	workout.Notes = input.Notes
+	workout.UpdatedAt = now
+	return nil
+}
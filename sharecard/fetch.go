@@ -0,0 +1,56 @@
+// fetch.go
+package sharecard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"los-complejos-backend/netguard"
+)
+
+// Fetcher downloads the images of the events from their public URL.
+type Fetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+// NewFetcher creates a Fetcher giving up after the timeout and on images larger than maxBytes.
+// It never connects to non-public addresses (failing with netguard.ErrPrivateAddress) and ignores the proxy
+// settings of the environment.
+func NewFetcher(timeout time.Duration, maxBytes int64) *Fetcher {
+	return &Fetcher{client: netguard.Client(timeout), maxBytes: maxBytes}
+}
+
+// Fetch downloads the image at the http or https URL.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, fmt.Errorf("invalid image URL %q", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image URL returned status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > f.maxBytes {
+		return nil, fmt.Errorf("image is larger than %d bytes", f.maxBytes)
+	}
+	return data, nil
+}
@@ -0,0 +1,168 @@
+// sharecard.go
+package sharecard
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	"los-complejos-backend/imaging"
+)
+
+// Size of the cards, the size recommended for Open Graph images.
+const (
+	Width  = 1200
+	Height = 630
+)
+
+// Layout of the cards.
+const (
+	margin        = 72
+	brandScale    = 4
+	detailScale   = 5
+	maxTitleLines = 3
+)
+
+// titleScales are the scales tried for the title, largest first, until it fits in maxTitleLines lines.
+var titleScales = []int{10, 8, 6}
+
+// Colors of the cards.
+var (
+	textColor   = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+	mutedColor  = color.RGBA{R: 0xCB, G: 0xD5, B: 0xE1, A: 0xFF}
+	accentColor = color.RGBA{R: 0xF9, G: 0x73, B: 0x16, A: 0xFF}
+	topColor    = color.RGBA{R: 0x1E, G: 0x29, B: 0x3B, A: 0xFF}
+	bottomColor = color.RGBA{R: 0x0F, G: 0x17, B: 0x2A, A: 0xFF}
+	shadeColor  = color.RGBA{A: 0x99} // Darkens the event image so the text stands out
+)
+
+// Brand is drawn at the top of every card.
+const Brand = "LOS COMPLEJOS"
+
+// Card is the content of the share image of an event.
+type Card struct {
+	Title      string
+	Date       string // Formatted date of the event
+	Location   string
+	Background image.Image // Image of the event (nil for the plain background)
+}
+
+// Render draws the card as a Width × Height PNG image: the title, date and location over the darkened image of
+// the event, scaled to cover the card, or over a plain gradient.
+func Render(card Card) ([]byte, error) {
+	dst := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	if card.Background != nil {
+		draw.Draw(dst, dst.Bounds(), imaging.Cover(card.Background, Width, Height), image.Point{}, draw.Src)
+		draw.Draw(dst, dst.Bounds(), image.NewUniform(shadeColor), image.Point{}, draw.Over)
+	} else {
+		gradient(dst, topColor, bottomColor)
+	}
+
+	// Brand and accent bar
+	y := margin
+	drawText(dst, margin, y, brandScale, Brand, textColor)
+	y += glyphHeight*brandScale + 16
+	draw.Draw(dst, image.Rect(margin, y, margin+96, y+8), image.NewUniform(accentColor), image.Point{}, draw.Src)
+	y += 8 + 40
+
+	// Title
+	scale, lines := fitTitle(card.Title)
+	for _, line := range lines {
+		drawText(dst, margin, y, scale, line, textColor)
+		y += cellHeight * scale
+	}
+
+	// Date and location, from the bottom
+	width := Width - 2*margin
+	y = Height - margin - glyphHeight*detailScale
+	if card.Location != "" {
+		drawText(dst, margin, y, detailScale, truncate(card.Location, width, detailScale), mutedColor)
+		y -= cellHeight * detailScale
+	}
+	if card.Date != "" {
+		drawText(dst, margin, y, detailScale, truncate(card.Date, width, detailScale), accentColor)
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, dst); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// fitTitle wraps the title at the largest scale at which it fits in maxTitleLines lines; at the smallest scale,
+// the lines that do not fit are left out and the last one ends with an ellipsis.
+func fitTitle(title string) (int, []string) {
+	width := Width - 2*margin
+	for _, scale := range titleScales {
+		if lines := wrap(title, width, scale); len(lines) <= maxTitleLines {
+			return scale, lines
+		}
+	}
+
+	scale := titleScales[len(titleScales)-1]
+	lines := wrap(title, width, scale)[:maxTitleLines]
+	lines[maxTitleLines-1] = truncate(lines[maxTitleLines-1]+"...", width, scale)
+	return scale, lines
+}
+
+// wrap breaks the text into lines of at most width pixels at the given scale, between words when possible.
+func wrap(text string, width, scale int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if textWidth(candidate, scale) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		// Words longer than a line are broken
+		for textWidth(word, scale) > width {
+			runes := []rune(word)
+			n := max((width+scale)/(cellWidth*scale), 1)
+			lines = append(lines, string(runes[:n]))
+			word = string(runes[n:])
+		}
+		line = word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// truncate shortens the text to at most width pixels at the given scale, ending it with an ellipsis when shortened.
+func truncate(text string, width, scale int) string {
+	if textWidth(text, scale) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && textWidth(string(runes)+"...", scale) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRight(string(runes), " ") + "..."
+}
+
+// gradient fills dst with a vertical gradient from top to bottom.
+func gradient(dst *image.RGBA, top, bottom color.RGBA) {
+	bounds := dst.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		t := y * 255 / max(bounds.Dy()-1, 1)
+		c := color.RGBA{
+			R: uint8((int(top.R)*(255-t) + int(bottom.R)*t) / 255),
+			G: uint8((int(top.G)*(255-t) + int(bottom.G)*t) / 255),
+			B: uint8((int(top.B)*(255-t) + int(bottom.B)*t) / 255),
+			A: 0xFF,
+		}
+		draw.Draw(dst, image.Rect(bounds.Min.X, y, bounds.Max.X, y+1), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+}
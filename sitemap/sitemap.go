@@ -0,0 +1,56 @@
+// sitemap.go
+package sitemap
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// MaxURLs is the maximum number of URLs of a sitemap.
+const MaxURLs = 50000
+
+// Sitemap is a sitemap (sitemaps.org protocol) listing the pages of a site for search engines.
+type Sitemap struct {
+	URLs []URL
+}
+
+// URL is a page of a Sitemap.
+type URL struct {
+	Loc     string    // Absolute URL of the page
+	LastMod time.Time // When the page last changed, omitted when zero
+}
+
+// urlSet and urlEntry are the XML form of a Sitemap.
+type urlSet struct {
+	XMLName xml.Name   `xml:"urlset"`
+	XMLNS   string     `xml:"xmlns,attr"`
+	URLs    []urlEntry `xml:"url"`
+}
+
+type urlEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// WriteTo writes the sitemap to w as XML, keeping its first MaxURLs URLs.
+func (s Sitemap) WriteTo(w io.Writer) (int64, error) {
+	set := urlSet{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9", URLs: make([]urlEntry, 0, min(len(s.URLs), MaxURLs))}
+	for i, url := range s.URLs {
+		if i == MaxURLs {
+			break
+		}
+		entry := urlEntry{Loc: url.Loc}
+		if !url.LastMod.IsZero() {
+			entry.LastMod = url.LastMod.UTC().Format(time.RFC3339)
+		}
+		set.URLs = append(set.URLs, entry)
+	}
+
+	data, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.WriteString(w, xml.Header+string(data)+"\n")
+	return int64(n), err
+}
@@ -0,0 +1,89 @@
+// stripe.go
+package stripe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the signature of a Stripe webhook ("t=<unix time>,v1=<hex>[,v1=...]").
+const SignatureHeader = "Stripe-Signature"
+
+// Tolerance is how old a signed webhook may be before it is rejected as a possible replay.
+const Tolerance = 5 * time.Minute
+
+// Webhook event types recorded in the payment ledger.
+const (
+	ChargeSucceeded = "charge.succeeded"
+	ChargeRefunded  = "charge.refunded"
+)
+
+// ErrInvalidSignature is returned when a webhook is not correctly signed or its timestamp is outside the tolerance.
+var ErrInvalidSignature = errors.New("invalid Stripe signature")
+
+// Event is a Stripe webhook event. Its object is decoded according to its type.
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// Charge is the subset of a Stripe charge object used by the payment ledger.
+// Amounts are in the smallest unit of the currency (e.g. cents).
+type Charge struct {
+	ID             string            `json:"id"`
+	Amount         int64             `json:"amount"`
+	AmountRefunded int64             `json:"amount_refunded"`
+	Currency       string            `json:"currency"`
+	Created        int64             `json:"created"` // Unix time
+	Paid           bool              `json:"paid"`
+	Metadata       map[string]string `json:"metadata"` // "event_id" ties the charge to an Event
+}
+
+// Verify checks the signature header of a webhook payload against the endpoint's signing secret.
+// At least one v1 signature must match and the signed timestamp must be within Tolerance of now.
+func Verify(payload []byte, header, secret string, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > Tolerance || age < -Tolerance {
+		return ErrInvalidSignature
+	}
+
+	expected := Sign(payload, timestamp, secret)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// Sign returns the v1 signature of the payload sent at the given Unix timestamp.
+func Sign(payload []byte, timestamp, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
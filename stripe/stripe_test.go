@@ -0,0 +1,49 @@
+// stripe_test.go
+package stripe
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"charge.succeeded"}`)
+	secret := "whsec_stripe"
+	signedAt := time.Unix(1792141200, 0)
+	// HMAC-SHA256 of "1792141200.<payload>" with the secret, computed independently
+	signature := "0c496081647235cfa632e060ae8fc091000cc8da0ea79958a79fbc2290d05b54"
+
+	tests := []struct {
+		name    string
+		payload []byte
+		header  string
+		secret  string
+		now     time.Time
+		valid   bool
+	}{
+		{"valid", payload, "t=1792141200,v1=" + signature, secret, signedAt, true},
+		{"spaces and a v0 signature", payload, "t=1792141200, v0=abc, v1=" + signature, secret, signedAt.Add(time.Minute), true},
+		{"one of several v1 signatures (secret rolling)", payload, "t=1792141200,v1=deadbeef,v1=" + signature, secret, signedAt, true},
+		{"at the edge of the tolerance", payload, "t=1792141200,v1=" + signature, secret, signedAt.Add(Tolerance), true},
+		{"too old", payload, "t=1792141200,v1=" + signature, secret, signedAt.Add(Tolerance + time.Second), false},
+		{"from the future", payload, "t=1792141200,v1=" + signature, secret, signedAt.Add(-Tolerance - time.Second), false},
+		{"wrong secret", payload, "t=1792141200,v1=" + signature, "whsec_other", signedAt, false},
+		{"tampered payload", []byte(`{"id":"evt_1","type":"charge.refunded"}`), "t=1792141200,v1=" + signature, secret, signedAt, false},
+		{"timestamp changed", payload, "t=1792141260,v1=" + signature, secret, signedAt, false},
+		{"no timestamp", payload, "v1=" + signature, secret, signedAt, false},
+		{"no v1 signature", payload, "t=1792141200,v0=" + signature, secret, signedAt, false},
+		{"empty header", payload, "", secret, signedAt, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.payload, tt.header, tt.secret, tt.now)
+			if tt.valid && err != nil {
+				t.Errorf("Verify: %v, want the signature accepted", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify error = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
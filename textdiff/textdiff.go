@@ -0,0 +1,165 @@
+// textdiff.go
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is the kind of an Edit.
+type Op int
+
+// Kinds of edits.
+const (
+	Equal  Op = iota // The line is in both texts
+	Delete           // The line is only in the old text
+	Insert           // The line is only in the new text
+)
+
+// Edit is a line of one of the texts with how it changed.
+type Edit struct {
+	Op   Op
+	Line string
+}
+
+// Lines returns the shortest edit script turning the lines of the text from into the lines of the text to,
+// computed from their longest common subsequence. Deletions come before the insertions replacing them.
+func Lines(from, to string) []Edit {
+	a, b := split(from), split(to)
+
+	// Common prefix and suffix are kept out of the quadratic part
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]Edit, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		edits = append(edits, Edit{Op: Equal, Line: line})
+	}
+	edits = append(edits, middle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, Edit{Op: Equal, Line: line})
+	}
+	return edits
+}
+
+// middle returns the edit script between a and b from the table of the lengths of their longest common
+// subsequences.
+func middle(a, b []string) []Edit {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var edits []Edit
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, Edit{Op: Equal, Line: a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, Edit{Op: Delete, Line: a[i]})
+			i++
+		default:
+			edits = append(edits, Edit{Op: Insert, Line: b[j]})
+			j++
+		}
+	}
+	return edits
+}
+
+// Unified returns the differences between the texts from and to in the unified format, with the given number
+// of unchanged lines around each change and the names of the texts in the header. It returns "" when the texts
+// have the same lines.
+func Unified(fromName, toName, from, to string, context int) string {
+	edits := Lines(from, to)
+
+	// Line numbers, in each text, before each edit
+	oldLine := make([]int, len(edits)+1)
+	newLine := make([]int, len(edits)+1)
+	for i, edit := range edits {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if edit.Op != Insert {
+			oldLine[i+1]++
+		}
+		if edit.Op != Delete {
+			newLine[i+1]++
+		}
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(edits); {
+		if edits[i].Op == Equal {
+			i++
+			continue
+		}
+
+		// A hunk runs from context lines before a change to context lines after the last change that is
+		// closer than 2 × context lines to the previous one
+		start, end := max(i-context, 0), i
+		for end < len(edits) {
+			if edits[end].Op != Equal {
+				end++
+				continue
+			}
+			run := end
+			for run < len(edits) && edits[run].Op == Equal {
+				run++
+			}
+			if run == len(edits) || run-end > 2*context {
+				end = min(end+context, len(edits))
+				break
+			}
+			end = run
+		}
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			hunkRange(oldLine[start], oldLine[end]-oldLine[start]), hunkRange(newLine[start], newLine[end]-newLine[start]))
+		for _, edit := range edits[start:end] {
+			out.WriteByte(" -+"[edit.Op])
+			out.WriteString(edit.Line)
+			out.WriteByte('\n')
+		}
+		i = end
+	}
+	return out.String()
+}
+
+// hunkRange formats the range of a hunk in one of the texts, starting after the given number of lines.
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// split returns the lines of the text, ignoring a final line break.
+func split(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
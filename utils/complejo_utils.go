@@ -1,43 +1,37 @@
 package utils
 
 import (
-	"fmt"
-	"strconv"
+	"math"
+
+	"los-complejos-backend/models"
 )
 
-// CalcIMC calculates the Body Mass Index (BMI) based on weight and height.
-// If weight or height is empty, it returns "N/A" to indicate that the IMC cannot be calculated.
-func CalcIMC(weight, height string) string {
-	// Check if weight or height is empty
-	if weight == "" || height == "" {
-		return "N/A" // Return "N/A" if the values are not provided
-	}
+// IMCCategories names the ranges of the IMC, from the lowest: under 18.5, from 18.5 to 25, from 25 to 30 and
+// from 30. It is set from the configuration (IMC_CATEGORIES) at startup.
+var IMCCategories = []string{"Soldado del Burgo De Los No Muertos", "NPC", "Susi Slayer", "Burger King Slayer"}
 
-	// Convert weight to float
-	weightF, err := strconv.ParseFloat(weight, 32)
-	if err != nil {
-		fmt.Println("Error converting weight:", err)
-		return "Invalid input"
-	}
+// imcBounds are the upper bounds of the first ranges of IMCCategories.
+var imcBounds = []float64{18.5, 25, 30}
 
-	// Convert height to float
-	heightF, err := strconv.ParseFloat(height, 32)
-	if err != nil {
-		fmt.Println("Error converting height:", err)
-		return "Invalid input"
+// CalcIMC calculates the Body Mass Index (BMI) based on weight (kilograms) and height (meters), rounded to one
+// decimal, with the name of its range among IMCCategories.
+// If weight or height is unknown (zero or negative), it returns nil to indicate that the IMC cannot be calculated.
+func CalcIMC(weight, height float64) *models.IMC {
+	// Check if weight or height is missing
+	if weight <= 0 || height <= 0 {
+		return nil
 	}
 
 	// Calculate IMC
-	calcIMC := weightF / (heightF * heightF)
+	value := math.Round(weight/(height*height)*10) / 10
 
-	// Return IMC category
-	if calcIMC < 18.5 {
-		return "Soldado del Burgo De Los No Muertos"
-	} else if calcIMC >= 18.5 && calcIMC < 25 {
-		return "NPC"
-	} else if calcIMC >= 25 && calcIMC < 30 {
-		return "Susi Slayer"
-	} else {
-		return "Burger King Slayer"
+	// Find the IMC category
+	category := len(imcBounds)
+	for i, bound := range imcBounds {
+		if value < bound {
+			category = i
+			break
+		}
 	}
+	return &models.IMC{Value: value, Category: IMCCategories[category]}
 }
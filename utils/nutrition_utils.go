@@ -0,0 +1,43 @@
+// nutrition_utils.go
+package utils
+
+import (
+	"math"
+
+	"los-complejos-backend/models"
+)
+
+// goalFactors are the daily kcal and grams of protein by kilogram of bodyweight aimed at for each goal.
+var goalFactors = map[string]struct{ calories, protein float64 }{
+	models.GoalLose:     {calories: 26, protein: 2.2},
+	models.GoalMaintain: {calories: 31, protein: 1.8},
+	models.GoalGain:     {calories: 35, protein: 2},
+}
+
+// fatPerKilogram is the daily grams of fat by kilogram of bodyweight aimed at, whatever the goal.
+const fatPerKilogram = 0.9
+
+// CalcNutritionTargets calculates the daily targets of a bodyweight (kilograms) for the goal ("maintain" when
+// empty): the kcal and protein scale with the bodyweight by goal, the fat is 0.9 g/kg, and the carbohydrates
+// fill the remaining kcal (4 kcal/g for protein and carbohydrates, 9 kcal/g for fat). The kcal are rounded to
+// tens and the grams to units.
+// If the weight is unknown (zero or negative), it returns nil to indicate that the targets cannot be calculated.
+func CalcNutritionTargets(weight float64, goal string) *models.NutritionTargets {
+	if weight <= 0 {
+		return nil
+	}
+	if goal == "" {
+		goal = models.GoalMaintain
+	}
+
+	factors := goalFactors[goal]
+	calories := math.Round(weight*factors.calories/10) * 10
+	protein := math.Round(weight * factors.protein)
+	fat := math.Round(weight * fatPerKilogram)
+	carbs := math.Max(math.Round((calories-4*protein-9*fat)/4), 0)
+	return &models.NutritionTargets{
+		Goal:       goal,
+		Bodyweight: weight,
+		Macros:     models.Macros{Calories: calories, Protein: protein, Carbs: carbs, Fat: fat},
+	}
+}
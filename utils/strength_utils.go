@@ -0,0 +1,39 @@
+// strength_utils.go
+package utils
+
+import (
+	"math"
+
+	"los-complejos-backend/models"
+)
+
+// Epley estimates the one-rep max (kilograms) of a set of the given weight and repetitions with the Epley
+// formula. A single is its own one-rep max.
+func Epley(weight float64, reps int) float64 {
+	if reps <= 1 {
+		return weight
+	}
+	return weight * (1 + float64(reps)/30)
+}
+
+// Brzycki estimates the one-rep max (kilograms) of a set of the given weight and repetitions with the Brzycki
+// formula, which is only meaningful well under 37 repetitions.
+func Brzycki(weight float64, reps int) float64 {
+	if reps <= 1 {
+		return weight
+	}
+	return weight * 36 / (37 - float64(reps))
+}
+
+// EstimateOneRepMax estimates the one-rep max of a set with both formulas, rounded to one decimal, and their
+// average.
+func EstimateOneRepMax(weight float64, reps int) models.OneRepMax {
+	epley, brzycki := Epley(weight, reps), Brzycki(weight, reps)
+	return models.OneRepMax{
+		Weight:   weight,
+		Reps:     reps,
+		Epley:    math.Round(epley*10) / 10,
+		Brzycki:  math.Round(brzycki*10) / 10,
+		Estimate: math.Round((epley+brzycki)/2*10) / 10,
+	}
+}
@@ -2,7 +2,11 @@
 package utils
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -12,20 +16,29 @@ import (
 // Ensure this key is kept secure and not exposed publicly.
 var JWTSecret = []byte(os.Getenv("JWT_SECRET"))
 
-// GenerateToken generates a JWT for a user.
+// TokenLifetime is how long the tokens generated by GenerateToken are valid; zero, the default, issues tokens that
+// never expire, as there is no route to log in again.
+var TokenLifetime time.Duration
+
+// GenerateToken generates a JWT for a user, valid for TokenLifetime from the given time when it is set.
 // Parameters:
 // - id: The user's unique identifier (e.g., database ID).
 // - role: The user's role (e.g., "admin" or "user").
 // - username: The user's username (e.g., "Xuculup").
+// - now: The current time, from the injected clock.
 // Returns:
 // - A signed JWT token as a string.
 // - An error if the signing process fails.
-func GenerateToken(id, role, username string) (string, error) {
+func GenerateToken(id, role, username string, now time.Time) (string, error) {
 	// Create the claims (payload)
 	claims := jwt.MapClaims{
-		"_id":      id,       // ID of the user
-		"username": username, // Username of the user
-		"role":     role,     // Role of the user
+		"_id":      id,         // ID of the user
+		"username": username,   // Username of the user
+		"role":     role,       // Role of the user
+		"iat":      now.Unix(), // When the token was issued
+	}
+	if TokenLifetime > 0 {
+		claims["exp"] = now.Add(TokenLifetime).Unix() // When the token expires
 	}
 
 	// Create the token
@@ -35,6 +48,47 @@ func GenerateToken(id, role, username string) (string, error) {
 	return token.SignedString(JWTSecret)
 }
 
+// CalendarToken returns the token authenticating the personal calendar feed of a user: an HMAC-SHA256 of its ID
+// and its calendar nonce signed with JWTSecret, so calendar apps can fetch the feed without a JWT. Replacing the
+// nonce revokes the previous tokens.
+// Parameters:
+// - id: The user's unique identifier.
+// - nonce: The user's calendar nonce ("" until it is first rotated).
+// Returns:
+// - The hex-encoded token.
+func CalendarToken(id, nonce string) string {
+	mac := hmac.New(sha256.New, JWTSecret)
+	if nonce == "" {
+		mac.Write([]byte("calendar:" + id)) // The tokens issued before the nonces keep working until rotated
+	} else {
+		mac.Write([]byte("calendar:" + id + ":" + nonce))
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCalendarToken reports whether the token authenticates the personal calendar feed of the user with the
+// given ID and calendar nonce.
+func VerifyCalendarToken(id, nonce, token string) bool {
+	return hmac.Equal([]byte(token), []byte(CalendarToken(id, nonce)))
+}
+
+// ExportToken returns the token authenticating the download of a data export: an HMAC-SHA256 of the export ID
+// signed with JWTSecret, so the archive can be downloaded from a plain link.
+// Parameters:
+// - id: The export's unique identifier.
+// Returns:
+// - The hex-encoded token.
+func ExportToken(id string) string {
+	mac := hmac.New(sha256.New, JWTSecret)
+	mac.Write([]byte("export:" + id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyExportToken reports whether the token authenticates the download of the data export with the given ID.
+func VerifyExportToken(id, token string) bool {
+	return hmac.Equal([]byte(token), []byte(ExportToken(id)))
+}
+
 // SetContextValues sets multiple key-value pairs into the Gin context.
 // Parameters:
 // - c: The Gin context to which values are added.
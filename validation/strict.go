@@ -0,0 +1,147 @@
+// strict.go
+package validation
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// strict makes BindJSON reject the payloads with fields its target does not declare.
+var strict = true
+
+// SetStrict sets whether BindJSON rejects unknown JSON fields (the default) or ignores them.
+func SetStrict(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	strict = enabled
+}
+
+// isStrict reports whether unknown JSON fields are rejected.
+func isStrict() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return strict
+}
+
+var (
+	jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// unknownFields returns the paths of the fields of the JSON body that the type does not declare, sorted
+// within each object, e.g. "dead_lift" or "events[0].venue". Nested objects are checked against the types
+// of their fields; values decoded by their own UnmarshalJSON (such as times) are not looked into.
+func unknownFields(body []byte, t reflect.Type) []string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil
+	}
+	var paths []string
+	collectUnknown(value, t, "", &paths)
+	return paths
+}
+
+// collectUnknown appends to paths the unknown fields of the decoded JSON value against the type.
+func collectUnknown(value interface{}, t reflect.Type, path string, paths *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshaler) || reflect.PointerTo(t).Implements(textUnmarshaler) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(object) {
+			field, ok := fields[key]
+			if !ok {
+				field, ok = fields[strings.ToLower(key)]
+			}
+			if !ok {
+				*paths = append(*paths, join(path, key))
+				continue
+			}
+			collectUnknown(object[key], field, join(path, key), paths)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for _, key := range sortedKeys(object) {
+			collectUnknown(object[key], t.Elem(), join(path, key), paths)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+		for i, item := range items {
+			collectUnknown(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), paths)
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of the struct type keyed by their JSON name, and by its
+// lowercase form since encoding/json matches the names case-insensitively. The fields of embedded structs
+// without a JSON name are promoted, as encoding/json does.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for promoted, fieldType := range jsonFields(embedded) {
+					if _, shadowed := fields[promoted]; !shadowed {
+						fields[promoted] = fieldType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
+// sortedKeys returns the keys of the JSON object in order.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// join returns the path of the key within the object at path.
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,222 @@
+// validation.go
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"los-complejos-backend/apperrors"
+	"los-complejos-backend/clock"
+	"los-complejos-backend/locale"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Allowed values of the custom "role" and "gender" rules.
+var (
+	Roles   = []string{"user", "admin"}
+	Genders = []string{"male", "female", "other"}
+)
+
+// FieldError describes why a single field failed validation.
+type FieldError struct {
+	Field   string `json:"field"`   // JSON name of the field
+	Rule    string `json:"rule"`    // Validation rule that failed (e.g. "required")
+	Message string `json:"message"` // Human-readable explanation
+}
+
+var (
+	mu       sync.RWMutex
+	clk      clock.Clock = clock.New()
+	validate             = newValidator()
+)
+
+// SetClock replaces the clock used by time-based rules such as "future".
+func SetClock(c clock.Clock) {
+	mu.Lock()
+	defer mu.Unlock()
+	clk = c
+}
+
+// now returns the current time of the configured clock.
+func now() time.Time {
+	mu.RLock()
+	defer mu.RUnlock()
+	return clk.Now()
+}
+
+// newValidator builds the validator with JSON field names and the custom rules registered.
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	v.RegisterValidation("role", oneOf(Roles))
+	v.RegisterValidation("gender", oneOf(Genders))
+	v.RegisterValidation("locale", oneOf(locale.Locales))
+	v.RegisterValidation("units", oneOf(locale.UnitSystems))
+	v.RegisterValidation("future", func(fl validator.FieldLevel) bool {
+		date, ok := fl.Field().Interface().(time.Time)
+		return ok && date.After(now())
+	})
+
+	return v
+}
+
+// oneOf returns a rule accepting only the given string values (empty values are left to "required").
+func oneOf(allowed []string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		if value == "" {
+			return true
+		}
+		for _, candidate := range allowed {
+			if value == candidate {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Struct validates obj against its `validate` tags.
+// It returns an apperrors 422 error listing every invalid field, or nil.
+func Struct(obj interface{}) error {
+	err := validate.Struct(obj)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	details := make([]FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		details = append(details, FieldError{
+			Field:   fieldErr.Field(),
+			Rule:    fieldErr.Tag(),
+			Message: message(fieldErr),
+		})
+	}
+	return apperrors.Validation("Validation failed", details)
+}
+
+// Value validates a single value against the given rules (e.g. "omitempty,locale").
+// Failures are reported like Struct, under the given field name.
+func Value(field string, value interface{}, rules string) error {
+	err := validate.Var(value, rules)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	details := make([]FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		details = append(details, FieldError{Field: field, Rule: fieldErr.Tag(), Message: message(fieldErr)})
+	}
+	return apperrors.Validation("Validation failed", details)
+}
+
+// BindJSON decodes the JSON request body into obj and validates it.
+// Malformed JSON yields a 400 error, and fields obj does not declare (unless strict mode is off, see
+// SetStrict) and invalid fields a 422 error with per-field details.
+func BindJSON(c *gin.Context, obj interface{}) error {
+	if c.Request == nil || c.Request.Body == nil {
+		return apperrors.BadRequest("Invalid JSON format: missing request body")
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return apperrors.BadRequest("Invalid JSON format: " + err.Error())
+	}
+	return DecodeJSON(body, obj)
+}
+
+// DecodeJSON decodes the JSON document into obj and validates it, failing like BindJSON. It validates the
+// documents that do not come alone in a request body, such as the rows of an import.
+func DecodeJSON(body []byte, obj interface{}) error {
+	strict := isStrict()
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(obj); err != nil {
+		// The decoder stops at the first unknown field: the body is walked again to list all of them
+		if unknown := unknownFields(body, reflect.TypeOf(obj)); strict && len(unknown) > 0 {
+			details := make([]FieldError, 0, len(unknown))
+			for _, field := range unknown {
+				details = append(details, FieldError{Field: field, Rule: "unknown", Message: "is not a known field"})
+			}
+			return apperrors.Validation("Unknown fields", details)
+		}
+		return apperrors.BadRequest("Invalid JSON format: " + err.Error())
+	}
+	return Struct(obj)
+}
+
+// BindQuery decodes the query string into obj (using its `form` tags) and validates it.
+// Unparsable values yield a 400 error and invalid fields a 422 error with per-field details.
+func BindQuery(c *gin.Context, obj interface{}) error {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		return apperrors.BadRequest("Invalid query parameters: " + err.Error())
+	}
+	return Struct(obj)
+}
+
+// message returns the human-readable explanation of a failed rule.
+func message(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required", "required_if":
+		return "is required"
+	case "role":
+		return "must be one of: " + strings.Join(Roles, ", ")
+	case "gender":
+		return "must be one of: " + strings.Join(Genders, ", ")
+	case "locale":
+		return "must be one of: " + strings.Join(locale.Locales, ", ")
+	case "units":
+		return "must be one of: " + strings.Join(locale.UnitSystems, ", ")
+	case "future":
+		return "must be in the future"
+	case "email", "eq=|email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "startswith":
+		return fmt.Sprintf("must start with %q", fieldErr.Param())
+	case "base64rawurl":
+		return "must be base64url-encoded without padding"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "min", "gte":
+		if fieldErr.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fieldErr.Param())
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s", fieldErr.Param())
+	}
+	return fmt.Sprintf("failed the %q rule", fieldErr.Tag())
+}
@@ -0,0 +1,73 @@
+// weather.go
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"los-complejos-backend/models"
+)
+
+// ErrNoForecast is returned when the provider has no forecast for the location and time,
+// e.g. because the location is unknown or the time is too far ahead.
+var ErrNoForecast = errors.New("no weather forecast available")
+
+// Provider fetches weather forecasts. Implementations fill in the weather figures of the Forecast;
+// the location, time and severity are set by the caller.
+type Provider interface {
+	Forecast(ctx context.Context, location string, at time.Time) (*models.Forecast, error)
+}
+
+// HTTPProvider fetches the forecasts from a weather service.
+//
+// Forecasts are requested with `GET <url>?location=<location>&time=<RFC 3339 time>`, authenticated with
+// `Authorization: Bearer <key>` when a key is set, and answered with the JSON of a models.Forecast;
+// a 404 status means no forecast is available.
+type HTTPProvider struct {
+	url        string
+	key        string
+	httpClient *http.Client
+}
+
+// NewHTTPProvider creates an HTTPProvider querying the given URL with the given API key (none when empty).
+func NewHTTPProvider(url, key string) *HTTPProvider {
+	return &HTTPProvider{url: url, key: key, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// Forecast returns the forecast for the location at the given time, or ErrNoForecast.
+func (p *HTTPProvider) Forecast(ctx context.Context, location string, at time.Time) (*models.Forecast, error) {
+	query := url.Values{"location": {location}, "time": {at.UTC().Format(time.RFC3339)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.key != "" {
+		req.Header.Set("Authorization", "Bearer "+p.key)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNoForecast
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("weather provider responded with status %d", resp.StatusCode)
+	}
+
+	var forecast models.Forecast
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&forecast); err != nil {
+		return nil, err
+	}
+	return &forecast, nil
+}
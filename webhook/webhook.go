@@ -0,0 +1,95 @@
+// webhook.go
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"los-complejos-backend/netguard"
+)
+
+// Headers of the deliveries.
+const (
+	SignatureHeader = "X-Complejos-Signature" // "t=<unix time>,v1=<hex>", see Sign
+	TopicHeader     = "X-Complejos-Topic"     // Topic of the domain event, e.g. "event.created"
+	DeliveryHeader  = "X-Complejos-Delivery"  // ID of the delivery, the same for each of its attempts
+)
+
+// UserAgent identifies the deliveries.
+const UserAgent = "LosComplejos-Webhooks/1"
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	ID        string          `json:"id"`         // ID of the domain event, the same for each webhook it is delivered to
+	Topic     string          `json:"topic"`      // What happened, e.g. "event.created"
+	CreatedAt time.Time       `json:"created_at"` // When the domain event was published
+	Data      json.RawMessage `json:"data"`       // The domain event
+}
+
+// Delivery is a payload to POST to a webhook.
+type Delivery struct {
+	ID    string
+	Topic string
+	Body  []byte // JSON-encoded Payload
+}
+
+// Client POSTs the deliveries to the URLs of the webhooks.
+type Client struct {
+	client *http.Client
+}
+
+// NewClient creates a Client giving up on a delivery after the timeout. It never connects to non-public
+// addresses (failing with netguard.ErrPrivateAddress) and ignores the proxy settings of the environment.
+func NewClient(timeout time.Duration) *Client {
+	client := netguard.Client(timeout)
+	// A redirect would be followed without the signature being checked against the new URL
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	return &Client{client: client}
+}
+
+// Send POSTs the delivery to the URL, signed with the secret at the given time. It returns the HTTP status of
+// the response (0 when none was received), and an error unless the status is 2xx.
+func (c *Client) Send(ctx context.Context, url, secret string, delivery Delivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set(SignatureHeader, "t="+timestamp+",v1="+Sign(delivery.Body, timestamp, secret))
+	req.Header.Set(TopicHeader, delivery.Topic)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Draining a little of the body lets the connection be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the v1 signature of the body sent at the given Unix timestamp: the hex HMAC-SHA256 of
+// "<timestamp>.<body>" with the secret of the webhook. Receivers should compute it again, compare it in
+// constant time and reject old timestamps.
+func Sign(body []byte, timestamp, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
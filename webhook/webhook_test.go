@@ -0,0 +1,75 @@
+// webhook_test.go
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"los-complejos-backend/netguard"
+)
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 of `1792141200.{"id":"evt_1"}` with the key "whsec_test", computed independently
+	want := "226751803230e75b660375b4c0be486942ca076304ac3ae20297593cc392a44f"
+	if got := Sign([]byte(`{"id":"evt_1"}`), "1792141200", "whsec_test"); got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+	if Sign([]byte(`{"id":"evt_1"}`), "1792141201", "whsec_test") == want {
+		t.Error("Sign ignores the timestamp")
+	}
+	if Sign([]byte(`{"id":"evt_1"}`), "1792141200", "other") == want {
+		t.Error("Sign ignores the secret")
+	}
+}
+
+func TestSendSignsTheDelivery(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":"evt_1"}`)
+	var received http.Header
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The test server listens on a loopback address, which the client of NewClient refuses
+	client := &Client{client: server.Client()}
+	status, err := client.Send(context.Background(), server.URL, "whsec_test", Delivery{ID: "d1", Topic: "event.created", Body: body}, now)
+	if err != nil || status != http.StatusNoContent {
+		t.Fatalf("Send = %d, %v, want 204", status, err)
+	}
+	if got, want := received.Get(SignatureHeader), "t=1792141200,v1="+Sign(body, "1792141200", "whsec_test"); got != want {
+		t.Errorf("%s = %s, want %s", SignatureHeader, got, want)
+	}
+	if received.Get(TopicHeader) != "event.created" || received.Get(DeliveryHeader) != "d1" {
+		t.Errorf("headers = %v, want the topic and delivery ID", received)
+	}
+	if string(receivedBody) != string(body) {
+		t.Errorf("body = %s, want %s", receivedBody, body)
+	}
+}
+
+func TestSendFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	delivery := Delivery{ID: "d1", Topic: "event.created", Body: []byte(`{}`)}
+
+	client := &Client{client: server.Client()}
+	if status, err := client.Send(context.Background(), server.URL, "s", delivery, time.Now()); err == nil || status != http.StatusInternalServerError {
+		t.Errorf("Send to a failing webhook = %d, %v, want 500 and an error", status, err)
+	}
+
+	_, err := NewClient(time.Second).Send(context.Background(), server.URL, "s", delivery, time.Now())
+	if !errors.Is(err, netguard.ErrPrivateAddress) {
+		t.Errorf("Send to a loopback address: error = %v, want netguard.ErrPrivateAddress", err)
+	}
+}